@@ -33,6 +33,7 @@ var fixtureNames = []string{
 	"attribute-changes",
 	"mixed-changes",
 	"no-changes",
+	"documentation-changes",
 }
 
 func loadManifest(t *testing.T, mfs *platform.MapFileSystem, path string) *M.Package {
@@ -134,3 +134,86 @@ var markdownTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
 func printResultMarkdown(w io.Writer, result *Result) error {
 	return markdownTmpl.Execute(w, result)
 }
+
+//go:embed templates/semver.md.tmpl
+var semverTemplate string
+
+func PrintAdvice(w io.Writer, advice *Advice, opts DisplayOptions) error {
+	switch opts.Format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(advice)
+	case "markdown":
+		return semverTmpl.Execute(w, advice)
+	case "text", "":
+		return printAdviceText(w, advice)
+	default:
+		return fmt.Errorf("invalid format: %s. Use 'text', 'json', or 'markdown'", opts.Format)
+	}
+}
+
+func printAdviceText(w io.Writer, advice *Advice) error {
+	if len(advice.Changes) == 0 {
+		_, err := lipgloss.Fprintln(w, tui.SuccessStyle.Render("No API changes detected"))
+		return err
+	}
+
+	sections := []struct {
+		bump  Bump
+		title string
+		style lipgloss.Style
+		icon  string
+	}{
+		{Major, "Major Changes", tui.ErrorStyle, "✗"},
+		{Minor, "Minor Changes", tui.InfoStyle, "+"},
+		{Patch, "Patch Changes", tui.SuccessStyle, "✓"},
+	}
+
+	for _, sec := range sections {
+		items := filterByBump(advice.Changes, sec.bump)
+		if len(items) == 0 {
+			continue
+		}
+
+		header := fmt.Sprintf("%s (%d)", sec.title, len(items))
+		if _, err := lipgloss.Fprintln(w, tui.SectionStyle.Render(header)); err != nil {
+			return err
+		}
+		for _, c := range items {
+			icon := sec.style.Render(sec.icon)
+			if _, err := lipgloss.Fprintf(w, "  %s %s\n", icon, c.Message); err != nil {
+				return err
+			}
+		}
+		if _, err := lipgloss.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	_, err := lipgloss.Fprintf(w, "Recommended release: %s\n", tui.SectionStyle.Render(advice.Bump.String()))
+	return err
+}
+
+func filterByBump(changes []SemverChange, b Bump) []SemverChange {
+	var filtered []SemverChange
+	for _, c := range changes {
+		if c.Bump == b {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+var semverTmpl = template.Must(template.New("semver").Funcs(template.FuncMap{
+	"escapeCell": func(s string) string {
+		return strings.ReplaceAll(s, "|", "\\|")
+	},
+	"bumps": func() []Bump {
+		return []Bump{Major, Minor, Patch}
+	},
+	"bumpTitle": func(b Bump) string {
+		return strings.ToUpper(b.String()[:1]) + b.String()[1:]
+	},
+	"filterByBump": filterByBump,
+}).Parse(semverTemplate))
@@ -0,0 +1,263 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package breaking
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	M "bennypowers.dev/cem/manifest"
+)
+
+// Rules which only Advise checks, since they never break consumers
+const (
+	RuleDeprecated           = "deprecated"
+	RuleDocumentationChanged = "documentation-changed"
+)
+
+// Bump is the part of a package's semantic version which a release
+// increments
+type Bump int
+
+const (
+	NoBump Bump = iota
+	Patch
+	Minor
+	Major
+)
+
+func (b Bump) String() string {
+	switch b {
+	case Major:
+		return "major"
+	case Minor:
+		return "minor"
+	case Patch:
+		return "patch"
+	default:
+		return "none"
+	}
+}
+
+func (b Bump) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + b.String() + `"`), nil
+}
+
+func (b *Bump) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	switch str {
+	case "major":
+		*b = Major
+	case "minor":
+		*b = Minor
+	case "patch":
+		*b = Patch
+	case "none":
+		*b = NoBump
+	default:
+		return fmt.Errorf("unknown bump: %s", str)
+	}
+	return nil
+}
+
+// SemverChange is a change and the release it calls for
+type SemverChange struct {
+	Change
+	Bump Bump `json:"bump"`
+}
+
+// Advice is the release which the changes between two manifests call for
+type Advice struct {
+	Bump    Bump           `json:"bump"`
+	Changes []SemverChange `json:"changes"`
+	Major   int            `json:"major"`
+	Minor   int            `json:"minor"`
+	Patch   int            `json:"patch"`
+}
+
+// Advise compares two manifests and classifies each change by semantic
+// versioning. Breaking changes call for a major release. Additions,
+// deprecations, and dangerous changes call for a minor release, and changes
+// to documentation call for a patch release.
+func Advise(base, head *M.Package, opts Options) *Advice {
+	changes := Compare(base, head, opts).Changes
+	disabled := make(map[string]bool, len(opts.Disable))
+	for _, id := range opts.Disable {
+		disabled[id] = true
+	}
+	for _, c := range documentationChanges(indexElements(base), indexElements(head)) {
+		if !disabled[c.Rule] {
+			changes = append(changes, c)
+		}
+	}
+
+	advice := &Advice{Changes: make([]SemverChange, 0, len(changes))}
+	for _, c := range changes {
+		bump := bumpFor(c)
+		advice.Changes = append(advice.Changes, SemverChange{Change: c, Bump: bump})
+		advice.Bump = max(advice.Bump, bump)
+		switch bump {
+		case Major:
+			advice.Major++
+		case Minor:
+			advice.Minor++
+		case Patch:
+			advice.Patch++
+		}
+	}
+
+	slices.SortFunc(advice.Changes, func(a, b SemverChange) int {
+		if c := cmp.Compare(b.Bump, a.Bump); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Element, b.Element); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Rule, b.Rule); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Subject, b.Subject); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Message, b.Message)
+	})
+
+	return advice
+}
+
+func bumpFor(c Change) Bump {
+	switch {
+	case c.Severity == Breaking:
+		return Major
+	case c.Severity == Dangerous,
+		c.Rule == RuleDeprecated,
+		strings.HasSuffix(c.Rule, "-added"):
+		return Minor
+	default:
+		return Patch
+	}
+}
+
+// apiItem is the documentation of one part of an element's API
+type apiItem struct {
+	kind       string
+	name       string
+	docs       string
+	deprecated bool
+}
+
+func (item apiItem) label() string {
+	return fmt.Sprintf("%s %q", item.kind, item.name)
+}
+
+func apiItems(ced *M.CustomElementDeclaration) map[string]apiItem {
+	items := make(map[string]apiItem)
+	add := func(kind string, fq M.FullyQualified, deprecated bool) {
+		items[kind+" "+fq.Name] = apiItem{
+			kind:       kind,
+			name:       fq.Name,
+			docs:       fq.Summary + "\n" + fq.Description,
+			deprecated: deprecated,
+		}
+	}
+	for _, a := range ced.OwnAttributes() {
+		add("attribute", a.FullyQualified, a.IsDeprecated())
+	}
+	for _, s := range ced.OwnSlots() {
+		add("slot", s.FullyQualified, s.IsDeprecated())
+	}
+	for _, e := range ced.OwnEvents() {
+		add("event", e.FullyQualified, e.IsDeprecated())
+	}
+	for _, p := range ced.OwnCssProperties() {
+		add("CSS custom property", p.FullyQualified, p.IsDeprecated())
+	}
+	for _, p := range ced.OwnCssParts() {
+		add("CSS part", p.FullyQualified, p.IsDeprecated())
+	}
+	for _, s := range ced.OwnCssStates() {
+		add("CSS state", s.FullyQualified, s.IsDeprecated())
+	}
+	for _, f := range publicFields(ced) {
+		add("field", f.FullyQualified, f.IsDeprecated())
+	}
+	for _, m := range publicMethods(ced) {
+		add("method", m.FullyQualified, m.IsDeprecated())
+	}
+	return items
+}
+
+// documentationChanges finds elements and parts of their APIs which were
+// deprecated, or whose summaries or descriptions changed
+func documentationChanges(base, head map[string]*M.CustomElementDeclaration) []Change {
+	var changes []Change
+	for tag, headEl := range head {
+		baseEl, ok := base[tag]
+		if !ok {
+			continue
+		}
+		if headEl.IsDeprecated() && !baseEl.IsDeprecated() {
+			changes = append(changes, Change{
+				Rule:     RuleDeprecated,
+				Severity: Safe,
+				Element:  tag,
+				Message:  fmt.Sprintf("element <%s> deprecated", tag),
+			})
+		}
+		if headEl.Summary != baseEl.Summary || headEl.Description != baseEl.Description {
+			changes = append(changes, Change{
+				Rule:     RuleDocumentationChanged,
+				Severity: Safe,
+				Element:  tag,
+				Message:  fmt.Sprintf("documentation of <%s> changed", tag),
+			})
+		}
+
+		baseItems := apiItems(baseEl)
+		for key, item := range apiItems(headEl) {
+			baseItem, ok := baseItems[key]
+			if !ok {
+				continue
+			}
+			if item.deprecated && !baseItem.deprecated {
+				changes = append(changes, Change{
+					Rule:     RuleDeprecated,
+					Severity: Safe,
+					Element:  tag,
+					Subject:  item.name,
+					Message:  fmt.Sprintf("%s deprecated in <%s>", item.label(), tag),
+				})
+			}
+			if item.docs != baseItem.docs {
+				changes = append(changes, Change{
+					Rule:     RuleDocumentationChanged,
+					Severity: Safe,
+					Element:  tag,
+					Subject:  item.name,
+					Message:  fmt.Sprintf("documentation of %s changed in <%s>", item.label(), tag),
+				})
+			}
+		}
+	}
+	return changes
+}
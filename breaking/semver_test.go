@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package breaking_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"bennypowers.dev/cem/breaking"
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvise(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata/fixtures", "/fixtures")
+
+	for _, name := range fixtureNames {
+		t.Run(name, func(t *testing.T) {
+			base := loadManifest(t, mfs, filepath.Join("/fixtures", name, "base.json"))
+			head := loadManifest(t, mfs, filepath.Join("/fixtures", name, "head.json"))
+			advice := breaking.Advise(base, head, breaking.Options{})
+			actual, err := json.MarshalIndent(advice, "", "  ")
+			require.NoError(t, err)
+			actual = append(actual, '\n')
+			testutil.CheckGolden(t, "semver-"+name, actual, testutil.GoldenOptions{
+				Dir:         "testdata/goldens",
+				Extension:   ".json",
+				UseJSONDiff: true,
+			})
+		})
+	}
+}
+
+func TestAdviseBump(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata/fixtures", "/fixtures")
+
+	// inline assertions: the recommended release is the largest bump of any change
+	for name, want := range map[string]breaking.Bump{
+		"element-removed":       breaking.Major,
+		"mixed-changes":         breaking.Major,
+		"documentation-changes": breaking.Minor,
+		"no-changes":            breaking.NoBump,
+	} {
+		base := loadManifest(t, mfs, filepath.Join("/fixtures", name, "base.json"))
+		head := loadManifest(t, mfs, filepath.Join("/fixtures", name, "head.json"))
+		require.Equal(t, want, breaking.Advise(base, head, breaking.Options{}).Bump, name)
+	}
+
+	base := loadManifest(t, mfs, "/fixtures/documentation-changes/base.json")
+	head := loadManifest(t, mfs, "/fixtures/documentation-changes/head.json")
+	advice := breaking.Advise(base, head, breaking.Options{Disable: []string{breaking.RuleDeprecated}})
+	require.Equal(t, breaking.Patch, advice.Bump, "documentation changes alone call for a patch release")
+}
+
+func TestPrintAdvice(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata/fixtures", "/fixtures")
+
+	for _, name := range fixtureNames {
+		base := loadManifest(t, mfs, filepath.Join("/fixtures", name, "base.json"))
+		head := loadManifest(t, mfs, filepath.Join("/fixtures", name, "head.json"))
+		advice := breaking.Advise(base, head, breaking.Options{})
+
+		t.Run("text-"+name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, breaking.PrintAdvice(&buf, advice, breaking.DisplayOptions{Format: "text"}))
+			testutil.CheckGolden(t, "text-semver-"+name, buf.Bytes(), testutil.GoldenOptions{
+				Dir:       "testdata/goldens",
+				Extension: ".txt",
+				StripANSI: true,
+			})
+		})
+
+		t.Run("markdown-"+name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, breaking.PrintAdvice(&buf, advice, breaking.DisplayOptions{Format: "markdown"}))
+			testutil.CheckGolden(t, "markdown-semver-"+name, buf.Bytes(), testutil.GoldenOptions{
+				Dir:       "testdata/goldens",
+				Extension: ".md",
+			})
+		})
+	}
+}
@@ -17,7 +17,6 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"bennypowers.dev/cem/breaking"
@@ -76,7 +75,11 @@ auto-detects the baseline from the latest semver tag.`,
 		allDisabled = append(allDisabled, configDisabled...)
 		allDisabled = append(allDisabled, disableFlags...)
 
-		validRules := make(map[string]bool)
+		// cem diff's rules may be disabled in the shared config
+		validRules := map[string]bool{
+			breaking.RuleDeprecated:           true,
+			breaking.RuleDocumentationChanged: true,
+		}
 		for _, r := range breaking.AllRules() {
 			validRules[r.ID()] = true
 		}
@@ -166,9 +169,9 @@ func loadManifestFile(fsys platform.FileSystem, path string) (*M.Package, error)
 	if err != nil {
 		return nil, err
 	}
-	var pkg M.Package
-	if err := json.Unmarshal(data, &pkg); err != nil {
+	pkg, err := M.UnmarshalPackage(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
 	}
-	return &pkg, nil
+	return pkg, nil
 }
@@ -25,6 +25,7 @@ import (
 // Type aliases re-exported from internal/config for backward compatibility.
 type CemConfig = IC.CemConfig
 type GenerateConfig = IC.GenerateConfig
+type OutputConfig = IC.OutputConfig
 type DemoDiscoveryConfig = IC.DemoDiscoveryConfig
 type DesignTokensConfig = IC.DesignTokensConfig
 type MCPConfig = IC.MCPConfig
@@ -62,6 +63,18 @@ func Clone(c *CemConfig) *CemConfig {
 		clone.Generate.Exclude = make([]string, len(c.Generate.Exclude))
 		copy(clone.Generate.Exclude, c.Generate.Exclude)
 	}
+	if c.Generate.Outputs != nil {
+		clone.Generate.Outputs = make([]OutputConfig, len(c.Generate.Outputs))
+		copy(clone.Generate.Outputs, c.Generate.Outputs)
+	}
+	if c.Generate.Publish.Strip != nil {
+		clone.Generate.Publish.Strip = make([]string, len(c.Generate.Publish.Strip))
+		copy(clone.Generate.Publish.Strip, c.Generate.Publish.Strip)
+	}
+	if c.Generate.DemoDiscovery.Conventions != nil {
+		clone.Generate.DemoDiscovery.Conventions = make([]string, len(c.Generate.DemoDiscovery.Conventions))
+		copy(clone.Generate.DemoDiscovery.Conventions, c.Generate.DemoDiscovery.Conventions)
+	}
 	// Deep copy import map config override
 	if c.Serve.ImportMap.Override.Imports != nil {
 		clone.Serve.ImportMap.Override.Imports = make(map[string]string, len(c.Serve.ImportMap.Override.Imports))
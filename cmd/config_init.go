@@ -189,6 +189,15 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		Existing:    cfg.SourceControlRootUrl,
 		Detected:    detectedGitRemote,
 	}
+	categoriesFV := fieldValue{
+		Title: "Element categories",
+		Description: "Assign elements to categories in your design system's taxonomy by tag name,\n" +
+			"in addition to `@category` JSDoc tags. Separate categories with semicolons.\n" +
+			"Learn more: https://bennypowers.dev/cem/docs/usage/documenting-components/",
+		Placeholder: "form: my-input, my-select; layout: my-grid*",
+		Existing:    formatCategories(cfg.Generate.Categories),
+		ValidateFn:  validateCategories,
+	}
 	globFV := fieldValue{
 		Title: "Demo file glob",
 		Description: "Glob pattern to discover demo HTML files in your project.\n" +
@@ -214,6 +223,14 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		Placeholder: "https://example.com/{{.tag}}/{{.demo}}/",
 		Existing:    cfg.Generate.DemoDiscovery.URLTemplate,
 	}
+	conventionsFV := fieldValue{
+		Title: "Demo conventions",
+		Description: "Comma-separated globs, relative to each element's module directory,\n" +
+			"for demos found next to the element. Supports {{.tag}} and {{.module}}.\n" +
+			"Learn more: https://bennypowers.dev/cem/docs/usage/demos/",
+		Placeholder: "demo/*.html, {{.module}}.demo.html",
+		Existing:    strings.Join(cfg.Generate.DemoDiscovery.Conventions, ", "),
+	}
 
 	var detectedTokenSpec string
 	if len(detectedTokens) == 1 {
@@ -268,12 +285,25 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		filesFV.gate = genGate
 		outputFV.gate = genGate
 		scurlFV.gate = genGate
+		categoriesFV.gate = genGate
 		groups = append(groups, filesFV.Groups()...)
 		groups = append(groups, outputFV.Groups()...)
 		groups = append(groups, scurlFV.Groups()...)
+		groups = append(groups, categoriesFV.Groups()...)
+
+		underscorePrivate := cfg.Generate.UnderscorePrivate
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Treat underscore-prefixed members as private?").
+				Value(&underscorePrivate),
+		).Title("Private Members").
+			Description("Members named like `_internal` are documented with private privacy,\n"+
+				"following the common JavaScript naming convention.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/configuration/").
+			WithHideFunc(func() bool { return !configureGenerate }))
 
 		// === Demo Discovery ===
-		hasDemos := globFV.Value() != "" || patternFV.Value() != ""
+		hasDemos := globFV.Value() != "" || patternFV.Value() != "" || conventionsFV.Value() != ""
 		configureDemos := hasDemos || len(detectedFiles) > 0
 		groups = append(groups, huh.NewGroup(
 			huh.NewConfirm().
@@ -288,9 +318,11 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		globFV.gate = demoGate
 		patternFV.gate = demoGate
 		templateFV.gate = demoGate
+		conventionsFV.gate = demoGate
 		groups = append(groups, globFV.Groups()...)
 		groups = append(groups, patternFV.Groups()...)
 		groups = append(groups, templateFV.Groups()...)
+		groups = append(groups, conventionsFV.Groups()...)
 
 		// === Design Tokens ===
 		configureTokens := len(detectedTokens) > 0 || cfg.Generate.DesignTokens.Spec != ""
@@ -318,6 +350,10 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		if existing == nil {
 			importMapGen = true
 		}
+		autoPort := cfg.Serve.AutoPort
+		serveTLS := cfg.Serve.TLS.Enabled
+		cdnCache := cfg.Serve.ImportMap.CDNCache.Enabled
+		cdnOffline := cfg.Serve.ImportMap.CDNCache.Offline
 		enableCSS := cfg.Serve.Transforms.CSS.Enabled || len(detectedCSSInclude) > 0
 
 		groups = append(groups, huh.NewGroup(
@@ -333,6 +369,43 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		portFV.gate = serveGate
 		groups = append(groups, portFV.Groups()...)
 
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Use the next free port when the port is taken?").
+				Value(&autoPort),
+		).Title("Auto Port").
+			Description("Lets several dev servers run side by side without picking ports by hand.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/commands/serve/").
+			WithHideFunc(func() bool { return !configureServe }))
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Serve HTTPS?").
+				Value(&serveTLS),
+		).Title("HTTPS").
+			Description("Makes demos secure contexts on other devices, e.g. for the clipboard API.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/commands/serve/").
+			WithHideFunc(func() bool { return !configureServe }))
+
+		tlsGate := func() bool { return configureServe && serveTLS }
+		tlsCertFV := fieldValue{
+			Title: "TLS certificate",
+			Description: "PEM certificate file, relative to the project root.\n" +
+				"Leave this and the key empty for a self-signed certificate.",
+			Placeholder: ".config/localhost.pem",
+			Existing:    cfg.Serve.TLS.Cert,
+			gate:        tlsGate,
+		}
+		tlsKeyFV := fieldValue{
+			Title:       "TLS key",
+			Description: "PEM private key file for the certificate, relative to the project root.",
+			Placeholder: ".config/localhost-key.pem",
+			Existing:    cfg.Serve.TLS.Key,
+			gate:        tlsGate,
+		}
+		groups = append(groups, tlsCertFV.Groups()...)
+		groups = append(groups, tlsKeyFV.Groups()...)
+
 		groups = append(groups, huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Demo rendering mode").
@@ -348,6 +421,24 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 				"Learn more: https://bennypowers.dev/cem/docs/usage/rendering-modes/").
 			WithHideFunc(func() bool { return !configureServe }))
 
+		themeAttributeFV := fieldValue{
+			Title: "Theme toggle attribute",
+			Description: "Attribute on <html> which the demo theme toggle sets to \"light\" or \"dark\".\n" +
+				"Leave this and the class empty for no theme toggle.",
+			Placeholder: "data-theme",
+			Existing:    cfg.Serve.Demos.ThemeToggle.Attribute,
+			gate:        serveGate,
+		}
+		themeClassFV := fieldValue{
+			Title:       "Theme toggle class",
+			Description: "Class on <html> which the demo theme toggle adds in the dark theme.",
+			Placeholder: "dark",
+			Existing:    cfg.Serve.Demos.ThemeToggle.Class,
+			gate:        serveGate,
+		}
+		groups = append(groups, themeAttributeFV.Groups()...)
+		groups = append(groups, themeClassFV.Groups()...)
+
 		groups = append(groups, huh.NewGroup(
 			huh.NewConfirm().
 				Title("Auto-generate import maps?").
@@ -358,6 +449,34 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 				"Learn more: https://bennypowers.dev/cem/docs/usage/import-maps/").
 			WithHideFunc(func() bool { return !configureServe }))
 
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Cache CDN modules from the import map?").
+				Value(&cdnCache),
+		).Title("CDN Cache").
+			Description("Keeps local copies of the CDN modules your import map resolves to,\n"+
+				"so demos keep working when the CDN is unreachable.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/usage/import-maps/").
+			WithHideFunc(func() bool { return !configureServe || !importMapGen }))
+
+		cdnCacheGate := func() bool { return configureServe && importMapGen && cdnCache }
+		cdnCacheDirFV := fieldValue{
+			Title: "CDN cache directory",
+			Description: "Directory for the cached modules, relative to the project root.\n" +
+				"Leave empty for cem's directory in your user cache directory.",
+			Placeholder: ".cache/cdn",
+			Existing:    cfg.Serve.ImportMap.CDNCache.Dir,
+			gate:        cdnCacheGate,
+		}
+		groups = append(groups, cdnCacheDirFV.Groups()...)
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Serve cached modules without contacting CDNs?").
+				Value(&cdnOffline),
+		).Title("CDN Cache Offline Mode").
+			WithHideFunc(func() bool { return !cdnCacheGate() }))
+
 		cssIncludeFV := fieldValue{
 			Title: "CSS include patterns",
 			Description: "Glob patterns for CSS files to transform to JavaScript modules.\n" +
@@ -523,6 +642,40 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 			groups = append(groups, efvs.packageName.Groups()...)
 		}
 
+		// === Language Server ===
+		diagnosticsFV := fieldValue{
+			Title: "Diagnostic severities",
+			Description: "Severity of each diagnostic rule: error, warning, info, hint, or off.\n" +
+				"Leave empty for the defaults.",
+			Placeholder: "unknown-attribute: hint, missing-import: off",
+			Existing:    formatDiagnostics(cfg.LSP.Diagnostics),
+			ValidateFn:  validateDiagnostics,
+		}
+		requiredSlotsFV := fieldValue{
+			Title: "Required slots",
+			Description: "Slots which usages of each element must fill, in addition to those\n" +
+				"its manifest marks required. Write the default slot as \"\".\n" +
+				"Leave empty for none.",
+			Placeholder: `my-card: header, ""; my-dialog: footer`,
+			Existing:    formatRequiredSlots(cfg.LSP.RequiredSlots),
+			ValidateFn:  validateRequiredSlots,
+		}
+		configureLSP := len(cfg.LSP.Diagnostics) > 0 || len(cfg.LSP.RequiredSlots) > 0
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Do you want to configure the language server?").
+				Value(&configureLSP),
+		).Title("Language Server").
+			Description("The cem language server adds completions, hover docs, and diagnostics\n"+
+				"for custom elements to your editor. Editor settings override these.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/lsp/"))
+
+		lspGate := func() bool { return configureLSP }
+		diagnosticsFV.gate = lspGate
+		requiredSlotsFV.gate = lspGate
+		groups = append(groups, diagnosticsFV.Groups()...)
+		groups = append(groups, requiredSlotsFV.Groups()...)
+
 		// === MCP ===
 		existingMaxDesc := ""
 		if cfg.MCP.MaxDescriptionLength != 0 {
@@ -534,7 +687,42 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 				"Leave empty for no limit.",
 			Existing: existingMaxDesc,
 		}
-		configureMCP := cfg.MCP.MaxDescriptionLength != 0
+		allowedDirsFV := fieldValue{
+			Title: "Allowed directories",
+			Description: "Comma-separated directories, relative to the project root, which the MCP server\n" +
+				"may read and write. Leave empty for no restriction.",
+			Placeholder: "., ../shared-tokens",
+			Existing:    strings.Join(cfg.MCP.AllowedDirs, ", "),
+		}
+		auditLogFV := fieldValue{
+			Title: "Audit log",
+			Description: "File to which every MCP tool invocation is appended as a JSON line.\n" +
+				"Leave empty to skip.",
+			Placeholder: ".cem/mcp-audit.jsonl",
+			Existing:    cfg.MCP.AuditLog,
+		}
+		existingChangelogDepth := ""
+		if cfg.MCP.ChangelogDepth != 0 {
+			existingChangelogDepth = strconv.Itoa(cfg.MCP.ChangelogDepth)
+		}
+		changelogDepthFV := fieldValue{
+			Title: "Changelog depth",
+			Description: "Number of commits the element_changelog tool reads by default.\n" +
+				"Leave empty for 20.",
+			Placeholder: "20",
+			Existing:    existingChangelogDepth,
+		}
+		mcpReadOnly := cfg.MCP.ReadOnly
+		mcpNoSessionMemory := cfg.MCP.NoSessionMemory
+		mcpNoResponseCache := cfg.MCP.NoResponseCache
+		mcpChangelog := cfg.MCP.Changelog
+		configureMCP := cfg.MCP.MaxDescriptionLength != 0 ||
+			cfg.MCP.ReadOnly ||
+			len(cfg.MCP.AllowedDirs) > 0 ||
+			cfg.MCP.AuditLog != "" ||
+			cfg.MCP.NoSessionMemory ||
+			cfg.MCP.NoResponseCache ||
+			cfg.MCP.Changelog
 		groups = append(groups, huh.NewGroup(
 			huh.NewConfirm().
 				Title("Do you want to configure MCP settings?").
@@ -543,9 +731,54 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 			Description("CEM provides an MCP server for AI coding assistants.\n"+
 				"Configure how component metadata is exposed to AI tools."))
 
-		maxDescFV.gate = func() bool { return configureMCP }
+		mcpGate := func() bool { return configureMCP }
+		maxDescFV.gate = mcpGate
 		groups = append(groups, maxDescFV.Groups()...)
 
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Run the MCP server read-only?").
+				Value(&mcpReadOnly),
+		).Title("MCP Read-Only Mode").
+			Description("Disables tools which modify the workspace, and rejects filesystem writes.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/configuration/").
+			WithHideFunc(func() bool { return !configureMCP }))
+
+		allowedDirsFV.gate = mcpGate
+		auditLogFV.gate = mcpGate
+		groups = append(groups, allowedDirsFV.Groups()...)
+		groups = append(groups, auditLogFV.Groups()...)
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Always send element overviews in full?").
+				Value(&mcpNoSessionMemory),
+		).Title("MCP Session Memory").
+			Description("By default, overviews of elements a client session already read are abbreviated.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/configuration/").
+			WithHideFunc(func() bool { return !configureMCP }))
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Render every tool response afresh?").
+				Value(&mcpNoResponseCache),
+		).Title("MCP Response Cache").
+			Description("By default, repeated tool calls return the earlier response while the manifests are unchanged.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/configuration/").
+			WithHideFunc(func() bool { return !configureMCP }))
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Enable the element changelog tool?").
+				Value(&mcpChangelog),
+		).Title("MCP Element Changelog").
+			Description("Lets AI tools read the git history of an element's modules.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/mcp/").
+			WithHideFunc(func() bool { return !configureMCP }))
+
+		changelogDepthFV.gate = func() bool { return configureMCP && mcpChangelog }
+		groups = append(groups, changelogDepthFV.Groups()...)
+
 		// === Additional Packages ===
 		existingAdditional := strings.Join(cfg.AdditionalPackages, ", ")
 		additionalFV := fieldValue{
@@ -571,6 +804,39 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 		additionalFV.gate = func() bool { return configureAdditional }
 		groups = append(groups, additionalFV.Groups()...)
 
+		// === Package Priority ===
+		priorityFV := fieldValue{
+			Title: "Package priority",
+			Description: "Comma-separated package names, highest priority first, whose manifests\n" +
+				"describe a tag name which several packages declare.\n" +
+				"Leave empty for the default order.",
+			Placeholder: "@acme/ui, @acme/icons",
+			Existing:    strings.Join(cfg.Registry.Priority, ", "),
+		}
+		preferLocal := cfg.Registry.PreferLocal
+		configureRegistry := cfg.Registry.PreferLocal || len(cfg.Registry.Priority) > 0
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Do you want to configure package priority?").
+				Value(&configureRegistry),
+		).Title("Package Priority").
+			Description("When several packages declare the same tag name, MCP and LSP\n"+
+				"describe it from one of them. By default, additional packages win\n"+
+				"over node_modules, which win over the workspace.\n"+
+				"Learn more: https://bennypowers.dev/cem/docs/reference/configuration/"))
+
+		priorityFV.gate = func() bool { return configureRegistry }
+		groups = append(groups, priorityFV.Groups()...)
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title("Prefer the workspace's own manifests?").
+				Value(&preferLocal),
+		).Title("Prefer Local Manifests").
+			Description("Lets workspace packages describe their tag names over copies\n"+
+				"in node_modules and additional packages.").
+			WithHideFunc(func() bool { return !configureRegistry }))
+
 		// === Output Format ===
 		if cfgPath == "" {
 			groups = append(groups, huh.NewGroup(
@@ -605,12 +871,19 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 			cfg.Generate.Files = splitCommaList(filesFV.Resolve())
 			cfg.Generate.Output = outputFV.Resolve()
 			cfg.SourceControlRootUrl = scurlFV.Resolve()
+			cfg.Generate.UnderscorePrivate = underscorePrivate
+			categories, parseErr := parseCategories(categoriesFV.Resolve())
+			if parseErr != nil {
+				return fmt.Errorf("invalid categories: %w", parseErr)
+			}
+			cfg.Generate.Categories = categories
 		}
 
 		if configureDemos {
 			cfg.Generate.DemoDiscovery.FileGlob = globFV.Resolve()
 			cfg.Generate.DemoDiscovery.URLPattern = patternFV.Resolve()
 			cfg.Generate.DemoDiscovery.URLTemplate = templateFV.Resolve()
+			cfg.Generate.DemoDiscovery.Conventions = splitCommaList(conventionsFV.Resolve())
 		}
 		if configureTokens {
 			if tokenSpecFV.Resolve() != "" {
@@ -626,8 +899,26 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("invalid port: %s", portStr)
 			}
 			cfg.Serve.Port = port
+			cfg.Serve.AutoPort = autoPort
+			cfg.Serve.TLS.Enabled = serveTLS
+			if serveTLS {
+				cfg.Serve.TLS.Cert = tlsCertFV.Resolve()
+				cfg.Serve.TLS.Key = tlsKeyFV.Resolve()
+				if (cfg.Serve.TLS.Cert == "") != (cfg.Serve.TLS.Key == "") {
+					return fmt.Errorf("TLS certificate and key must be configured together")
+				}
+			}
 			cfg.Serve.Demos.Rendering = rendering
+			cfg.Serve.Demos.ThemeToggle.Attribute = themeAttributeFV.Resolve()
+			cfg.Serve.Demos.ThemeToggle.Class = themeClassFV.Resolve()
 			cfg.Serve.ImportMap.Generate = importMapGen
+			cfg.Serve.ImportMap.CDNCache.Enabled = importMapGen && cdnCache
+			cfg.Serve.ImportMap.CDNCache.Dir = ""
+			cfg.Serve.ImportMap.CDNCache.Offline = false
+			if cfg.Serve.ImportMap.CDNCache.Enabled {
+				cfg.Serve.ImportMap.CDNCache.Dir = cdnCacheDirFV.Resolve()
+				cfg.Serve.ImportMap.CDNCache.Offline = cdnOffline
+			}
 
 			if enableCSS {
 				cfg.Serve.Transforms.CSS.Enabled = true
@@ -683,6 +974,19 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if configureLSP {
+			diagnostics, parseErr := parseDiagnostics(diagnosticsFV.Resolve())
+			if parseErr != nil {
+				return fmt.Errorf("invalid diagnostic severities: %w", parseErr)
+			}
+			cfg.LSP.Diagnostics = diagnostics
+			requiredSlots, parseErr := parseRequiredSlots(requiredSlotsFV.Resolve())
+			if parseErr != nil {
+				return fmt.Errorf("invalid required slots: %w", parseErr)
+			}
+			cfg.LSP.RequiredSlots = requiredSlots
+		}
+
 		if configureMCP {
 			md := maxDescFV.Resolve()
 			if md != "" {
@@ -695,6 +999,20 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 				}
 				cfg.MCP.MaxDescriptionLength = v
 			}
+			cfg.MCP.ReadOnly = mcpReadOnly
+			cfg.MCP.AllowedDirs = splitCommaList(allowedDirsFV.Resolve())
+			cfg.MCP.AuditLog = auditLogFV.Resolve()
+			cfg.MCP.NoSessionMemory = mcpNoSessionMemory
+			cfg.MCP.NoResponseCache = mcpNoResponseCache
+			cfg.MCP.Changelog = mcpChangelog
+			cfg.MCP.ChangelogDepth = 0
+			if depth := changelogDepthFV.Resolve(); mcpChangelog && depth != "" {
+				v, parseErr := strconv.Atoi(depth)
+				if parseErr != nil || v < 1 {
+					return fmt.Errorf("invalid changelog depth %q: must be a positive number", depth)
+				}
+				cfg.MCP.ChangelogDepth = v
+			}
 		}
 
 		if configureAdditional {
@@ -704,6 +1022,11 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if configureRegistry {
+			cfg.Registry.Priority = splitCommaList(priorityFV.Resolve())
+			cfg.Registry.PreferLocal = preferLocal
+		}
+
 		if hasTsConfig {
 			logging.Info("tsconfig.json detected. URL rewrites (rootDir/outDir) are auto-detected at serve time.")
 		}
@@ -31,5 +31,11 @@ var (
 	SplitCommaList      = splitCommaList
 	ReorderYAMLMapping  = reorderYAMLMapping
 	ValidatePackageSpec = validatePackageSpecifiers
+	FormatCategories    = formatCategories
+	ParseCategories     = parseCategories
+	FormatDiagnostics   = formatDiagnostics
+	ParseDiagnostics    = parseDiagnostics
+	FormatRequiredSlots = formatRequiredSlots
+	ParseRequiredSlots  = parseRequiredSlots
 	TreeSegment         = treeSegment
 )
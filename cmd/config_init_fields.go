@@ -18,11 +18,14 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"bennypowers.dev/cem/internal/set"
 	W "bennypowers.dev/cem/internal/workspace"
+	"bennypowers.dev/cem/lsp/types"
 	"charm.land/huh/v2"
 )
 
@@ -161,10 +164,132 @@ func validatePackageSpecifiers(input string) error {
 		return nil
 	}
 	for _, s := range splitCommaList(input) {
-		if W.IsPackageSpecifier(s) || W.IsURLSpecifier(s) || filepath.IsAbs(s) {
+		if W.IsPackageSpecifier(s) || W.IsURLSpecifier(s) || W.IsTarballSpecifier(s) || filepath.IsAbs(s) {
 			continue
 		}
-		return fmt.Errorf("%q is not a valid specifier (use npm:, jsr:, https://, or an absolute path)", s)
+		return fmt.Errorf("%q is not a valid specifier (use npm:, jsr:, https://, a .tgz file, or an absolute path)", s)
 	}
 	return nil
 }
+
+// formatCategories writes category tag name globs in the form
+// "form: my-input, my-select; layout: my-grid*"
+func formatCategories(categories map[string][]string) string {
+	var parts []string
+	for _, category := range slices.Sorted(maps.Keys(categories)) {
+		parts = append(parts, category+": "+strings.Join(categories[category], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseCategories reads category tag name globs written by formatCategories
+func parseCategories(input string) (map[string][]string, error) {
+	var categories map[string][]string
+	for entry := range strings.SplitSeq(input, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		category, globs, ok := strings.Cut(entry, ":")
+		category = strings.TrimSpace(category)
+		if !ok || category == "" {
+			return nil, fmt.Errorf("%q is not a category (use category: glob, glob)", strings.TrimSpace(entry))
+		}
+		if categories == nil {
+			categories = make(map[string][]string)
+		}
+		categories[category] = append(categories[category], splitCommaList(globs)...)
+	}
+	return categories, nil
+}
+
+func validateCategories(input string) error {
+	_, err := parseCategories(input)
+	return err
+}
+
+// formatDiagnostics writes diagnostic rule severities as
+// "rule: severity, rule: severity"
+func formatDiagnostics(diagnostics map[string]string) string {
+	var parts []string
+	for _, rule := range slices.Sorted(maps.Keys(diagnostics)) {
+		parts = append(parts, rule+": "+diagnostics[rule])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseDiagnostics reads diagnostic rule severities written by
+// formatDiagnostics
+func parseDiagnostics(input string) (map[string]string, error) {
+	var diagnostics map[string]string
+	for _, entry := range splitCommaList(input) {
+		rule, severity, ok := strings.Cut(entry, ":")
+		rule, severity = strings.TrimSpace(rule), strings.TrimSpace(severity)
+		if !ok || rule == "" {
+			return nil, fmt.Errorf("%q is not a rule severity (use rule: severity)", entry)
+		}
+		if _, valid := types.RuleSeverity(severity).DiagnosticSeverity(); !valid && severity != string(types.SeverityOff) {
+			return nil, fmt.Errorf("%q is not a severity (use error, warning, info, hint, or off)", severity)
+		}
+		if diagnostics == nil {
+			diagnostics = make(map[string]string)
+		}
+		diagnostics[rule] = severity
+	}
+	return diagnostics, nil
+}
+
+func validateDiagnostics(input string) error {
+	_, err := parseDiagnostics(input)
+	return err
+}
+
+// formatRequiredSlots writes the required slots of tag names in the form
+// "my-card: header, \"\"; my-dialog: footer", where "" is the default slot
+func formatRequiredSlots(requiredSlots map[string][]string) string {
+	var parts []string
+	for _, tagName := range slices.Sorted(maps.Keys(requiredSlots)) {
+		var slots []string
+		for _, slot := range requiredSlots[tagName] {
+			if slot == "" {
+				slot = `""`
+			}
+			slots = append(slots, slot)
+		}
+		parts = append(parts, tagName+": "+strings.Join(slots, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseRequiredSlots reads required slots written by formatRequiredSlots
+func parseRequiredSlots(input string) (map[string][]string, error) {
+	var requiredSlots map[string][]string
+	for entry := range strings.SplitSeq(input, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		tagName, slots, ok := strings.Cut(entry, ":")
+		tagName = strings.TrimSpace(tagName)
+		if !ok || !strings.Contains(tagName, "-") {
+			return nil, fmt.Errorf("%q is not a tag name's required slots (use tag-name: slot, slot)", strings.TrimSpace(entry))
+		}
+		names := splitCommaList(slots)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%q lists no slots (use \"\" for the default slot)", tagName)
+		}
+		for _, name := range names {
+			if name == `""` {
+				name = ""
+			}
+			if requiredSlots == nil {
+				requiredSlots = make(map[string][]string)
+			}
+			requiredSlots[tagName] = append(requiredSlots[tagName], name)
+		}
+	}
+	return requiredSlots, nil
+}
+
+func validateRequiredSlots(input string) error {
+	_, err := parseRequiredSlots(input)
+	return err
+}
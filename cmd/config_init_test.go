@@ -490,6 +490,100 @@ func TestValidatePackageSpecifiers(t *testing.T) {
 	}
 }
 
+// inline assertions: pure parse/format round trip
+func TestParseCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"single", "form: my-input, my-select", map[string][]string{"form": {"my-input", "my-select"}}, false},
+		{"multiple", "form: my-input; layout: my-grid*;", map[string][]string{"form": {"my-input"}, "layout": {"my-grid*"}}, false},
+		{"missing category", "my-input, my-select", nil, true},
+		{"empty category", ": my-input", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cmd.ParseCategories(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if got != nil {
+				again, err := cmd.ParseCategories(cmd.FormatCategories(got))
+				assert.NoError(t, err)
+				assert.Equal(t, got, again)
+			}
+		})
+	}
+}
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"single", "unknown-attribute: hint", map[string]string{"unknown-attribute": "hint"}, false},
+		{"multiple", "unknown-attribute: hint, missing-import: off,", map[string]string{"unknown-attribute": "hint", "missing-import": "off"}, false},
+		{"missing severity", "unknown-attribute", nil, true},
+		{"unknown severity", "unknown-attribute: loud", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cmd.ParseDiagnostics(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if got != nil {
+				again, err := cmd.ParseDiagnostics(cmd.FormatDiagnostics(got))
+				assert.NoError(t, err)
+				assert.Equal(t, got, again)
+			}
+		})
+	}
+}
+
+func TestParseRequiredSlots(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"single", "my-card: header", map[string][]string{"my-card": {"header"}}, false},
+		{"default slot", `my-card: header, ""; my-dialog: footer;`, map[string][]string{"my-card": {"header", ""}, "my-dialog": {"footer"}}, false},
+		{"missing slots", "my-card:", nil, true},
+		{"not a tag name", "card: header", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cmd.ParseRequiredSlots(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if got != nil {
+				again, err := cmd.ParseRequiredSlots(cmd.FormatRequiredSlots(got))
+				assert.NoError(t, err)
+				assert.Equal(t, got, again)
+			}
+		})
+	}
+}
+
 func TestMarshalConfigYAML_Full(t *testing.T)    { testMarshalConfig(t, fullTestConfig(), "yaml", "full") }
 func TestMarshalConfigYAML_Minimal(t *testing.T) { testMarshalConfig(t, minimalTestConfig(), "yaml", "minimal") }
 func TestMarshalConfigJSON_Full(t *testing.T)    { testMarshalConfig(t, fullTestConfig(), "json", "full") }
@@ -0,0 +1,108 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"bennypowers.dev/cem/breaking"
+	"bennypowers.dev/cem/internal/platform"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	diffCmd.Flags().String("format", "text", "Output format: text, json, or markdown")
+	diffCmd.Flags().String("fail-on", "", "Exit 1 if changes call for this release or larger: major or minor")
+	diffCmd.Flags().StringArray("disable", []string{}, "Disable specific change rules (can be repeated)")
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old-manifest.json> <new-manifest.json>",
+	Short: "Compare two custom-elements manifests and recommend a semver release",
+	Long: `Compare two custom-elements.json manifests and list the changes to each
+element's attributes, slots, events, CSS properties, parts, states, and
+members. Each change is classified by the release it calls for:
+
+  major  Removals and incompatible changes
+  minor  Additions, deprecations, and changed defaults or field types
+  patch  Changes to summaries and descriptions
+
+The recommended release is the largest of these.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "text", "json", "markdown":
+		default:
+			return fmt.Errorf("invalid format %q: must be text, json, or markdown", format)
+		}
+
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		if failOn != "" && failOn != "major" && failOn != "minor" {
+			return fmt.Errorf("invalid --fail-on value %q: must be major or minor", failOn)
+		}
+
+		disableFlags, _ := cmd.Flags().GetStringArray("disable")
+		configDisabled := viper.GetStringSlice("breaking.disable")
+		allDisabled := make([]string, 0, len(configDisabled)+len(disableFlags))
+		allDisabled = append(allDisabled, configDisabled...)
+		allDisabled = append(allDisabled, disableFlags...)
+
+		validRules := map[string]bool{
+			breaking.RuleDeprecated:           true,
+			breaking.RuleDocumentationChanged: true,
+		}
+		for _, r := range breaking.AllRules() {
+			validRules[r.ID()] = true
+		}
+		for _, id := range allDisabled {
+			if !validRules[id] {
+				return fmt.Errorf("unknown change rule %q; run 'cem diff --help' for available rules", id)
+			}
+		}
+
+		fsys := platform.NewOSFileSystem()
+		basePkg, err := loadManifestFile(fsys, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to load old manifest: %w", err)
+		}
+		headPkg, err := loadManifestFile(fsys, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to load new manifest: %w", err)
+		}
+
+		advice := breaking.Advise(basePkg, headPkg, breaking.Options{
+			Disable: allDisabled,
+		})
+
+		if err := breaking.PrintAdvice(cmd.OutOrStdout(), advice, breaking.DisplayOptions{Format: format}); err != nil {
+			return err
+		}
+
+		if failOn == "major" && advice.Bump >= breaking.Major {
+			return fmt.Errorf("changes call for a major release: %d major change(s) detected", advice.Major)
+		}
+		if failOn == "minor" && advice.Bump >= breaking.Minor {
+			return fmt.Errorf("changes call for a %s release: %d major and %d minor change(s) detected", advice.Bump, advice.Major, advice.Minor)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	W "bennypowers.dev/cem/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// docsCmd builds a static documentation site from the manifest
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Build a static documentation site for your elements",
+	Long: `Build a static documentation site from the custom elements manifest, with:
+- A page for each element, with tables of its attributes, properties,
+  methods, events, slots, CSS parts, custom properties, and states
+- The element's demos, embedded in iframes
+- An index page which searches the elements by name, summary, and API
+
+The site is built like 'cem serve --build', so it includes the demo pages,
+and reads the same serve configuration.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := W.GetWorkspaceContext(cmd)
+		if err != nil {
+			return fmt.Errorf("project context not initialized: %w", err)
+		}
+		config, err := newServeConfig(cmd)
+		if err != nil {
+			return err
+		}
+		return runBuild(config, ctx.Root(), cmd, true)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+
+	docsCmd.Flags().StringP("output", "o", "dist", "Output directory for the docs site")
+	docsCmd.Flags().String("base-path", "", "URL base path for deployment (e.g., /docs/components/)")
+	docsCmd.Flags().String("import", "vendor", "Dependency resolution for demos: vendor, esm, jspm, unpkg")
+	docsCmd.Flags().String("manifest", "", "Document this pre-built manifest instead of generating one (e.g., dist/custom-elements.json)")
+}
@@ -17,16 +17,26 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	C "bennypowers.dev/cem/cmd/config"
+	"bennypowers.dev/cem/export"
 	G "bennypowers.dev/cem/generate"
 	DD "bennypowers.dev/cem/generate/demodiscovery"
+	IC "bennypowers.dev/cem/internal/config"
 	"bennypowers.dev/cem/internal/logging"
 	"bennypowers.dev/cem/internal/platform"
 	"bennypowers.dev/cem/internal/tui"
+	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/types"
 	W "bennypowers.dev/cem/internal/workspace"
 	"github.com/spf13/cobra"
@@ -41,6 +51,31 @@ var generateCmd = &cobra.Command{
 	Short: "Generates a custom elements manifest",
 	RunE: func(cmd *cobra.Command, args []string) (errs error) {
 		start = time.Now()
+		cmd.SetOut(os.Stdout)
+
+		checkFixtures, err := cmd.Flags().GetString("check-fixtures")
+		if err != nil {
+			return err
+		}
+		if checkFixtures != "" {
+			return runCheckFixtures(cmd, checkFixtures)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "json" && format != "ndjson" {
+			return fmt.Errorf("unknown format %q: expected json or ndjson", format)
+		}
+
+		check, err := cmd.Flags().GetBool("check")
+		if err != nil {
+			return err
+		}
+		if check && format == "ndjson" {
+			return errors.New("cannot use --check with --format ndjson: --check compares the whole manifest")
+		}
 
 		if W.ShouldUseWorkspaceMode(cmd, platform.NewOSFileSystem()) {
 			return generateWorkspace(cmd)
@@ -100,6 +135,10 @@ var generateCmd = &cobra.Command{
 
 		cfg.Generate.Files = files
 		cfg.Generate.Exclude = exclude
+		logging.Event("generate.start", map[string]any{
+			"files":   len(files),
+			"exclude": len(exclude),
+		})
 
 		// Merge design tokens config from flags
 		if designTokensSpec := viper.GetString("generate.designTokens.spec"); designTokensSpec != "" {
@@ -122,10 +161,21 @@ var generateCmd = &cobra.Command{
 			return err
 		}
 
+		if watch && check {
+			return errors.New("cannot use --check with --watch")
+		}
 		if watch {
 			return runWatchMode(ctx, uniqueGlobs)
 		}
 
+		recordFixtures, err := cmd.Flags().GetString("record-fixtures")
+		if err != nil {
+			return err
+		}
+		if recordFixtures != "" {
+			return runRecordFixtures(ctx, recordFixtures)
+		}
+
 		// compute path to write custom elements manifest to
 		// consider moving this to the context struct
 		// if this is empty, we'll print to stdout instead
@@ -134,6 +184,10 @@ var generateCmd = &cobra.Command{
 			return err
 		}
 
+		if format == "ndjson" {
+			return generateNDJSON(ctx, cmd, outputPath)
+		}
+
 		if outputPath == "" {
 			outputPath = cfg.Generate.Output
 		}
@@ -147,18 +201,36 @@ var generateCmd = &cobra.Command{
 			}
 		}
 
+		options, err := sessionOptions(cmd, "")
+		if err != nil {
+			return err
+		}
+
 		// generate the manifest
-		manifestStr, err := G.Generate(ctx, platform.NewOSFileSystem())
+		pkg, diagnostics, err := G.GeneratePackageWithDiagnostics(ctx, platform.NewOSFileSystem(), options...)
 		if err != nil {
 			errs = errors.Join(errs, err)
 			// Print warnings for non-fatal errors
 			printErrorsAsWarnings(err)
 		}
+		if err := reportDiagnostics(cmd, diagnostics); err != nil {
+			errs = errors.Join(errs, err)
+		}
 
-		// Check if manifestStr is nil before dereferencing
-		if manifestStr == nil {
+		if pkg == nil {
 			return errs
 		}
+		if pkg, err = publishProfile(cmd, ctx, pkg); err != nil {
+			return errors.Join(errs, err)
+		}
+		manifestStr, err := M.SerializeToString(pkg)
+		if err != nil {
+			return errors.Join(errs, fmt.Errorf("module serialize failed: %w", err))
+		}
+		if check {
+			return errors.Join(errs, checkManifest(cmd, ctx.Root(), outputPath, manifestStr))
+		}
+		errs = errors.Join(errs, writeOutputs(ctx, pkg, cfg.Generate.Outputs))
 
 		if outputPath != "" {
 			writer, err := ctx.OutputWriter(outputPath)
@@ -170,7 +242,7 @@ var generateCmd = &cobra.Command{
 						errs = errors.Join(errs, err)
 					}
 				}()
-				_, err := writer.Write([]byte(*manifestStr + "\n"))
+				_, err := writer.Write([]byte(manifestStr + "\n"))
 				if err != nil {
 					errs = errors.Join(errs, err)
 				} else {
@@ -189,12 +261,151 @@ var generateCmd = &cobra.Command{
 				}
 			}
 		} else {
-			fmt.Println(*manifestStr + "\n")
-		}
+			fmt.Println(manifestStr + "\n")
+		}
+		logging.Event("generate.complete", map[string]any{
+			"output":      outputPath,
+			"modules":     len(pkg.Modules),
+			"diagnostics": len(diagnostics),
+			"durationMs":  time.Since(start).Milliseconds(),
+			"ok":          errs == nil,
+		})
 		return errs
 	},
 }
 
+// generateNDJSON generates the manifest and writes each module as a line of
+// JSON, to outputPath when given, otherwise to stdout. Unlike the JSON
+// format, it never writes to the package's customElements path, since that
+// file is expected to hold a whole manifest.
+func generateNDJSON(ctx types.WorkspaceContext, cmd *cobra.Command, outputPath string) (errs error) {
+	options, err := sessionOptions(cmd, "")
+	if err != nil {
+		return err
+	}
+	pkg, diagnostics, err := G.GeneratePackageWithDiagnostics(ctx, platform.NewOSFileSystem(), options...)
+	if err != nil {
+		errs = errors.Join(errs, err)
+		printErrorsAsWarnings(err)
+	}
+	if err := reportDiagnostics(cmd, diagnostics); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if pkg == nil {
+		return errs
+	}
+	if pkg, err = publishProfile(cmd, ctx, pkg); err != nil {
+		return errors.Join(errs, err)
+	}
+
+	if outputPath == "" {
+		w := bufio.NewWriter(os.Stdout)
+		if err := M.WriteNDJSON(w, pkg); err != nil {
+			return errors.Join(errs, err)
+		}
+		return errors.Join(errs, w.Flush())
+	}
+
+	writer, err := ctx.OutputWriter(outputPath)
+	if err != nil {
+		return errors.Join(errs, err)
+	}
+	w := bufio.NewWriter(writer)
+	if err := M.WriteNDJSON(w, pkg); err != nil {
+		errs = errors.Join(errs, err)
+	} else {
+		errs = errors.Join(errs, w.Flush())
+	}
+	return errors.Join(errs, writer.Close())
+}
+
+// publishProfile strips what generate.publish.strip names from the package
+// when --publish is set, so that internal details stay out of published
+// manifests. Otherwise it returns the package as is, for local tooling.
+func publishProfile(cmd *cobra.Command, ctx types.WorkspaceContext, pkg *M.Package) (*M.Package, error) {
+	publish, err := cmd.Flags().GetBool("publish")
+	if err != nil || !publish {
+		return pkg, err
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	strip := make([]M.PublishStrip, 0, len(cfg.Generate.Publish.Strip))
+	for _, s := range cfg.Generate.Publish.Strip {
+		strip = append(strip, M.PublishStrip(s))
+	}
+	published, err := M.StripForPublish(pkg, strip)
+	if err != nil {
+		return nil, fmt.Errorf("applying publish profile: %w", err)
+	}
+	return published, nil
+}
+
+// writeOutputs writes the additional files configured in generate.outputs,
+// rendering each from the already generated package, so that every format
+// shares a single analysis.
+func writeOutputs(ctx types.WorkspaceContext, pkg *M.Package, outputs []C.OutputConfig) (errs error) {
+	for _, output := range outputs {
+		outputPath := output.Path
+		if !filepath.IsAbs(outputPath) {
+			outputPath = filepath.Join(ctx.Root(), outputPath)
+		}
+		data, err := renderOutput(ctx, pkg, output.Format, outputPath)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("rendering %s: %w", output.Path, err))
+			continue
+		}
+		writer, err := ctx.OutputWriter(outputPath)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		_, err = writer.Write(data)
+		errs = errors.Join(errs, err, writer.Close())
+		if err == nil {
+			logging.Info("Wrote %s to %s", output.Format, output.Path)
+		}
+	}
+	return errs
+}
+
+// renderOutput renders the package in one of the generate.outputs formats
+func renderOutput(ctx types.WorkspaceContext, pkg *M.Package, format, outputPath string) ([]byte, error) {
+	switch format {
+	case IC.OutputFormatJSON:
+		manifestStr, err := M.SerializeToString(pkg)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(manifestStr + "\n"), nil
+	case IC.OutputFormatJSONC:
+		return M.SerializeAnnotated(pkg)
+	case IC.OutputFormatNDJSON:
+		var buf bytes.Buffer
+		if err := M.WriteNDJSON(&buf, pkg); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case IC.OutputFormatWebTypes:
+		var name, version string
+		if pkgJSON, err := ctx.PackageJSON(); err == nil && pkgJSON != nil {
+			name, version = pkgJSON.Name, pkgJSON.Version
+		}
+		return export.WebTypes(pkg, name, version)
+	case IC.OutputFormatVSCodeCustomData:
+		return export.VSCodeCustomData(pkg)
+	case IC.OutputFormatDTS:
+		dir, err := filepath.Rel(ctx.Root(), filepath.Dir(outputPath))
+		if err != nil {
+			return nil, err
+		}
+		return export.TagNameMap(pkg, filepath.ToSlash(dir)), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
 // expand resolves glob patterns via the workspace context.
 // Always use returned files even when err != nil (io.Reader pattern):
 //   - ([], err)      → fatal, no usable results
@@ -252,7 +463,17 @@ func init() {
 	generateCmd.Flags().String("demo-discovery-file-glob", "", "Glob pattern for discovering demo files")
 	generateCmd.Flags().String("demo-discovery-url-pattern", "", "Go Regexp pattern with named capture groups for generating canonical demo urls")
 	generateCmd.Flags().String("demo-discovery-url-template", "", "URL pattern string using {groupName} syntax to interpolate named captures from the URL pattern")
+	generateCmd.Flags().String("member-order", "", "order of class members in the manifest: source (default), alphabetical, or kind (fields, then methods)")
 	generateCmd.Flags().BoolP("watch", "w", false, "watch files for changes and regenerate")
+	generateCmd.Flags().String("report", "", "write per-file problems, such as syntax errors, to this JSON file")
+	generateCmd.Flags().Bool("strict", false, "exit with an error when any file has problems, such as syntax errors")
+	generateCmd.Flags().String("record-fixtures", "", "record each file and the manifest generated for it to this directory, as golden fixtures")
+	generateCmd.Flags().String("check-fixtures", "", "regenerate the fixtures recorded in this directory and report changes in the manifests")
+	generateCmd.Flags().String("format", "json", "output format: json for a manifest document, or ndjson for one module per line")
+	generateCmd.Flags().String("debug-ir", "", "write each file's intermediate representations, such as matched captures and pre- and post-merge declarations, to this directory")
+	generateCmd.Flags().Int64("seed", 0, "shuffle the order in which files are processed, to check that the manifest doesn't depend on scheduling")
+	generateCmd.Flags().Bool("check", false, "compare the generated manifest with the one on disk instead of writing it, and exit with an error when they differ")
+	generateCmd.Flags().Bool("publish", false, "apply the publish profile, stripping what generate.publish.strip names (by default source links, private members, and vendor extensions)")
 	_ = viper.BindPFlag("generate.noDefaultExcludes", generateCmd.Flags().Lookup("no-default-excludes"))
 	_ = viper.BindPFlag("generate.output", generateCmd.Flags().Lookup("output"))
 	_ = viper.BindPFlag("generate.exclude", generateCmd.Flags().Lookup("exclude"))
@@ -261,15 +482,29 @@ func init() {
 	_ = viper.BindPFlag("generate.demoDiscovery.fileGlob", generateCmd.Flags().Lookup("demo-discovery-file-glob"))
 	_ = viper.BindPFlag("generate.demoDiscovery.urlPattern", generateCmd.Flags().Lookup("demo-discovery-url-pattern"))
 	_ = viper.BindPFlag("generate.demoDiscovery.urlTemplate", generateCmd.Flags().Lookup("demo-discovery-url-template"))
+	_ = viper.BindPFlag("generate.memberOrder", generateCmd.Flags().Lookup("member-order"))
 }
 
 func generateWorkspace(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("record-fixtures") {
+		return fmt.Errorf("cannot use --record-fixtures in workspace mode\n" +
+			"To record a single package's fixtures, use: cem generate -p packages/foo --record-fixtures fixtures")
+	}
+	if cmd.Flags().Changed("format") {
+		return fmt.Errorf("cannot use --format in workspace mode\n" +
+			"To stream a single package's modules, use: cem generate -p packages/foo --format ndjson")
+	}
 	if cmd.Flags().Changed("output") {
 		return fmt.Errorf("cannot use --output in workspace mode\n" +
 			"Each package writes to its customElements path from package.json.\n" +
 			"To target a single package, use: cem generate -p packages/foo -o custom-path.json")
 	}
 
+	check, err := cmd.Flags().GetBool("check")
+	if err != nil {
+		return err
+	}
+
 	baseCtx, err := W.GetWorkspaceContext(cmd)
 	if err != nil {
 		return fmt.Errorf("project context not initialized: %w", err)
@@ -284,6 +519,7 @@ func generateWorkspace(cmd *cobra.Command) error {
 	rootExclude := rootCfg.Generate.Exclude
 
 	fsys := platform.NewOSFileSystem()
+	var diagnostics []G.Diagnostic
 	results := W.ForEachPackage(baseCtx.Root(), fsys, func(pkg W.PackageInfo) error {
 		ctx := W.NewFileSystemWorkspaceContext(pkg.Path)
 		if err := ctx.Init(); err != nil {
@@ -345,34 +581,200 @@ func generateWorkspace(cmd *cobra.Command) error {
 		if cmd.Flags().Changed("demo-discovery-url-template") {
 			cfg.Generate.DemoDiscovery.URLTemplate = viper.GetString("generate.demoDiscovery.urlTemplate")
 		}
+		if cmd.Flags().Changed("member-order") {
+			cfg.Generate.MemberOrder = viper.GetString("generate.memberOrder")
+		}
 
 		if len(cfg.Generate.Files) == 0 {
 			logging.Warning("Skipping %s: no source files resolved (custom-elements.json left untouched)", pkg.Name)
 			return nil
 		}
 
-		manifestStr, err := G.Generate(ctx, platform.NewOSFileSystem())
+		options, err := sessionOptions(cmd, pkg.Name)
 		if err != nil {
 			return err
 		}
-		if manifestStr == nil {
+		manifest, pkgDiagnostics, err := G.GeneratePackageWithDiagnostics(ctx, platform.NewOSFileSystem(), options...)
+		if err != nil {
+			return err
+		}
+		if manifest == nil {
 			return fmt.Errorf("no manifest produced")
 		}
+		if manifest, err = publishProfile(cmd, ctx, manifest); err != nil {
+			return err
+		}
+		manifestStr, err := M.SerializeToString(manifest)
+		if err != nil {
+			return fmt.Errorf("module serialize failed: %w", err)
+		}
+		// Report files relative to the workspace root
+		relPkgDir, err := filepath.Rel(baseCtx.Root(), pkg.Path)
+		if err != nil {
+			relPkgDir = pkg.Path
+		}
+		for _, d := range pkgDiagnostics {
+			d.File = filepath.ToSlash(filepath.Join(relPkgDir, d.File))
+			diagnostics = append(diagnostics, d)
+		}
 
 		outputPath := filepath.Join(pkg.Path, pkg.CustomElementsRef)
+		if check {
+			return checkManifest(cmd, baseCtx.Root(), outputPath, manifestStr)
+		}
 		writer, err := ctx.OutputWriter(outputPath)
 		if err != nil {
 			return fmt.Errorf("opening output: %w", err)
 		}
 		defer func() { _ = writer.Close() }()
 
-		if _, err := writer.Write([]byte(*manifestStr + "\n")); err != nil {
+		if _, err := writer.Write([]byte(manifestStr + "\n")); err != nil {
 			return fmt.Errorf("writing manifest: %w", err)
 		}
-		return nil
+		return writeOutputs(ctx, manifest, cfg.Generate.Outputs)
 	})
 
-	return W.ReportResults("Generated manifests", results)
+	return errors.Join(W.ReportResults("Generated manifests", results), reportDiagnostics(cmd, diagnostics))
+}
+
+// diagnosticsReport is the JSON document written by --report
+type diagnosticsReport struct {
+	Diagnostics []G.Diagnostic `json:"diagnostics"`
+}
+
+// reportDiagnostics warns about the per-file problems which generation
+// tolerated, writes them to the --report file when given, and fails when
+// there are any and --strict is set.
+func reportDiagnostics(cmd *cobra.Command, diagnostics []G.Diagnostic) error {
+	files := make(map[string]bool)
+	for i := range diagnostics {
+		logging.Warning("%v", &diagnostics[i])
+		files[diagnostics[i].File] = true
+	}
+
+	reportPath, err := cmd.Flags().GetString("report")
+	if err != nil {
+		return err
+	}
+	if reportPath != "" {
+		report := diagnosticsReport{Diagnostics: diagnostics}
+		if report.Diagnostics == nil {
+			report.Diagnostics = []G.Diagnostic{}
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := platform.NewOSFileSystem().WriteFile(reportPath, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	}
+
+	strict, err := cmd.Flags().GetBool("strict")
+	if err != nil {
+		return err
+	}
+	if strict && len(files) > 0 {
+		return fmt.Errorf("%d files have problems (--strict)", len(files))
+	}
+	return nil
+}
+
+// sessionOptions returns the generate session options set by flags. In
+// workspace mode, each package's debug IR is written to a directory named
+// for the package.
+func sessionOptions(cmd *cobra.Command, packageName string) (options []G.SessionOption, err error) {
+	debugIR, err := cmd.Flags().GetString("debug-ir")
+	if err != nil {
+		return nil, err
+	}
+	if debugIR != "" {
+		options = append(options, G.WithDebugIR(filepath.Join(debugIR, packageName)))
+	}
+	seed, err := cmd.Flags().GetInt64("seed")
+	if err != nil {
+		return nil, err
+	}
+	if seed != 0 {
+		options = append(options, G.WithSeed(seed))
+	}
+	return options, nil
+}
+
+// runRecordFixtures records golden fixtures for the workspace's files
+func runRecordFixtures(ctx types.WorkspaceContext, dir string) error {
+	files, err := G.RecordFixtures(ctx, platform.NewOSFileSystem(), dir)
+	if len(files) > 0 {
+		end := time.Since(start)
+		logging.Success("Recorded %d fixtures to %s in %s", len(files), dir, tui.ColorizeDuration(end).Render(fmt.Sprint(end)))
+	}
+	return err
+}
+
+// runCheckFixtures regenerates recorded fixtures and reports those whose
+// manifests changed
+func runCheckFixtures(cmd *cobra.Command, dir string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	results, err := G.CheckFixtures(platform.NewOSFileSystem(), absDir)
+	if err != nil {
+		printErrorsAsWarnings(err)
+	}
+	changed := 0
+	for _, result := range results {
+		if len(result.Changes) == 0 {
+			continue
+		}
+		changed++
+		logging.Warning("%s changed:", result.File)
+		for _, change := range result.Changes {
+			cmd.Println(change)
+		}
+	}
+	if changed > 0 {
+		return fmt.Errorf("%d of %d fixtures changed", changed, len(results))
+	}
+	if err != nil {
+		return fmt.Errorf("checking fixtures: %w", err)
+	}
+	logging.Success("All %d fixtures match", len(results))
+	return nil
+}
+
+// checkManifest compares a generated manifest with the one at outputPath,
+// printing a summary of the drift between them, module by module. It fails
+// when they differ, so that CI catches a manifest which is out of date with
+// its sources. JSON formatting and key order are insignificant.
+func checkManifest(cmd *cobra.Command, root, outputPath, manifestStr string) error {
+	if outputPath == "" {
+		return errors.New("--check needs a manifest to compare: pass --output, or set generate.output or customElements in package.json")
+	}
+	relPath, err := filepath.Rel(root, outputPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		relPath = outputPath
+	}
+	committed, err := platform.NewOSFileSystem().ReadFile(outputPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s does not exist: run cem generate to write it", relPath)
+	}
+	if err != nil {
+		return err
+	}
+	changes, err := G.CompareManifests(committed, []byte(manifestStr))
+	if err != nil {
+		return fmt.Errorf("comparing %s: %w", relPath, err)
+	}
+	if len(changes) > 0 {
+		logging.Warning("%s is out of date with its sources:", relPath)
+		for _, change := range changes {
+			cmd.Println(change)
+		}
+		return fmt.Errorf("%s has %d changes: run cem generate to update it", relPath, len(changes))
+	}
+	logging.Success("%s is up to date", relPath)
+	return nil
 }
 
 // runWatchMode starts the file watching mode - delegates to generate package
@@ -20,8 +20,10 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -113,3 +115,115 @@ func TestGenerateE2E(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateOutputs(t *testing.T) {
+	projectDir := setupTest(t, "generate-project")
+	config := `generate:
+  files:
+    - my-element.js
+  outputs:
+    - path: web-types.json
+      format: web-types
+    - path: vscode.html-custom-data.json
+      format: vscode-custom-data
+    - path: types/custom-elements.d.ts
+      format: dts
+`
+	if err := os.WriteFile(filepath.Join(projectDir, ".config", "cem.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	runCemCommand(t, projectDir, "generate")
+
+	if _, err := os.Stat(filepath.Join(projectDir, "dist/custom-elements.json")); err != nil {
+		t.Errorf("expected the manifest to be written: %v", err)
+	}
+	for file, want := range map[string]string{
+		"web-types.json":               `"name": "my-element"`,
+		"vscode.html-custom-data.json": `"name": "my-element"`,
+		"types/custom-elements.d.ts":   `'my-element': import('../my-element.js').MyElement;`,
+	} {
+		data, err := os.ReadFile(filepath.Join(projectDir, file))
+		if err != nil {
+			t.Errorf("expected %s to be written: %v", file, err)
+			continue
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s to contain %s, got:\n%s", file, want, data)
+		}
+	}
+}
+
+func TestGenerateNDJSON(t *testing.T) {
+	projectDir := setupTest(t, "generate-project")
+
+	stdout, _ := runCemCommand(t, projectDir, "generate", "my-element.js", "--format", "ndjson")
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one line per module, got %d:\n%s", len(lines), stdout)
+	}
+	var module struct {
+		Kind         string            `json:"kind"`
+		Path         string            `json:"path"`
+		Declarations []json.RawMessage `json:"declarations"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &module); err != nil {
+		t.Fatalf("line is not a JSON object: %v\n%s", err, lines[0])
+	}
+	if module.Kind != "javascript-module" || module.Path != "my-element.js" || len(module.Declarations) != 1 {
+		t.Errorf("unexpected module: %s", lines[0])
+	}
+
+	if _, err := os.Stat(filepath.Join(projectDir, "dist/custom-elements.json")); !os.IsNotExist(err) {
+		t.Errorf("expected ndjson output not to be written to the package's customElements path")
+	}
+}
+
+func TestGenerateCheck(t *testing.T) {
+	projectDir := setupTest(t, "generate-project")
+	check := func() (stdout string, err error) {
+		t.Helper()
+		cmd := exec.Command(cemBinary, "generate", "my-element.js", "--check")
+		cmd.Dir = projectDir
+		cmd.Env = append(os.Environ(), "GOCOVERDIR="+coverDir)
+		out, err := cmd.Output()
+		return string(out), err
+	}
+	manifestPath := filepath.Join(projectDir, "dist/custom-elements.json")
+
+	if _, err := check(); err == nil {
+		t.Error("expected --check to fail without a manifest")
+	}
+	if _, err := os.Stat(manifestPath); !os.IsNotExist(err) {
+		t.Fatalf("expected --check not to write the manifest")
+	}
+
+	runCemCommand(t, projectDir, "generate", "my-element.js")
+	if _, err := check(); err != nil {
+		t.Errorf("expected a freshly generated manifest to pass --check: %v", err)
+	}
+
+	source := "/**\n * @customElement my-element\n */\nexport class MyElement extends HTMLElement {\n  /** The greeting */\n  name = 'World';\n}\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "my-element.js"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := check()
+	if err == nil {
+		t.Fatal("expected --check to fail when the sources changed")
+	}
+	if !strings.Contains(stdout, "module my-element.js: class MyElement changed") || !strings.Contains(stdout, "name") {
+		t.Errorf("expected a drift summary naming the changed class, got:\n%s", stdout)
+	}
+	after, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Error("expected --check to leave the manifest as it was")
+	}
+}
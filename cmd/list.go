@@ -315,6 +315,58 @@ Examples:
 	"Methods",
 )
 
+// requireTagsFormat resolves the output format of the tags command, letting
+// the --json and --tree flags stand in for --format.
+func requireTagsFormat(cmd *cobra.Command) (string, error) {
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return "", err
+	}
+	asTree, err := cmd.Flags().GetBool("tree")
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case asJSON && asTree:
+		return "", errors.New("--json and --tree are mutually exclusive")
+	case asJSON:
+		return "json", nil
+	case asTree:
+		return "tree", nil
+	}
+	return requireFormat(cmd, []string{"table", "markdown", "json", "tree"})
+}
+
+func requireElementFilter(cmd *cobra.Command) (list.ElementFilter, error) {
+	expr, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return nil, err
+	}
+	filter, err := list.ParseElementFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := cmd.Flags().GetStringArray("category")
+	if err != nil || len(categories) == 0 {
+		return filter, err
+	}
+	// Elements in any of the named categories match
+	var byCategory []list.ElementFilter
+	for _, category := range categories {
+		f, err := list.ParseElementFilter("category=" + category)
+		if err != nil {
+			return nil, err
+		}
+		byCategory = append(byCategory, f)
+	}
+	return func(e list.ElementSummary) bool {
+		if filter != nil && !filter(e) {
+			return false
+		}
+		return slices.ContainsFunc(byCategory, func(f list.ElementFilter) bool { return f(e) })
+	}, nil
+}
+
 var listTagsCmd = &cobra.Command{
 	Use:     "tags",
 	Aliases: []string{"elements", "tag-names"},
@@ -323,6 +375,19 @@ var listTagsCmd = &cobra.Command{
 
 This command outputs a table with tag names and their corresponding source modules,
 allowing you to quickly see which custom elements are available and where they are defined.
+In a workspace, elements are grouped by package.
+
+The Attributes, Slots, Events, and Deprecated columns summarize each element, and are shown
+when named with --columns. The json and tree formats always include them.
+
+Use --filter to audit a subset of elements. A filter is a comma-separated list of terms,
+all of which must match: tag, class, and module match globs with = or !=, attrs, slots, and
+events compare counts with =, !=, <, <=, >, or >=, category matches any of the element's
+categories with = or !=, and deprecated (or !deprecated) matches on deprecation.
+
+Use --category to list only the elements in a category. Repeat it to list elements in any
+of several categories. Categories come from @category JSDoc tags and the generate.categories
+config.
 
 Example:
 
@@ -330,27 +395,53 @@ Example:
   cem list tags --format table --columns Class
   cem list tags --format table --columns Class --columns Module
   cem list tags --format table --columns Class --columns Module --columns Summary
+  cem list tags --columns Attributes --columns Slots --columns Events --columns Deprecated
+  cem list elements --tree
+  cem list elements --json --filter 'deprecated'
+  cem list elements --json --filter 'tag=my-*,attrs>0' | jq '.[].elements[].tagName'
+  cem list elements --category form --category feedback
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := requireTagsFormat(cmd)
+		if err != nil {
+			return err
+		}
+		filter, err := requireElementFilter(cmd)
+		if err != nil {
+			return err
+		}
+		columns, err := cmd.Flags().GetStringArray("columns")
+		if err != nil {
+			return err
+		}
+		opts := list.RenderOptions{Columns: columns, Markdown: format == "markdown", ElementFilter: filter}
+
 		if W.ShouldUseWorkspaceMode(cmd, platform.NewOSFileSystem()) {
-			format, err := requireFormat(cmd, []string{"table", "markdown"})
-			if err != nil {
-				return err
-			}
-			columns, err := cmd.Flags().GetStringArray("columns")
-			if err != nil {
-				return err
-			}
-			return listFromWorkspaceManifests(cmd, func(pkg W.PackageInfo, manifest *M.Package) error {
-				opts := list.RenderOptions{Columns: columns, Markdown: format == "markdown"}
+			var packages []list.PackageElements
+			err := listFromWorkspaceManifests(cmd, func(pkg W.PackageInfo, manifest *M.Package) error {
+				switch format {
+				case "json":
+					packages = append(packages, list.PackageElements{
+						Package:  pkg.Name,
+						Elements: list.SummarizeElements(manifest, filter),
+					})
+					return nil
+				case "tree":
+					s, err := list.RenderElementsTree(pkg.Name, list.SummarizeElements(manifest, filter))
+					if err != nil || s == "" {
+						return err
+					}
+					_, err = lipgloss.Fprintln(cmd.OutOrStdout(), s)
+					return err
+				}
 				s, err := list.RenderTagsTable(manifest, opts)
 				if err != nil {
 					return err
 				}
 				if format == "markdown" {
 					if _, err := fmt.Fprintf(cmd.OutOrStdout(), "\n## %s\n\n%s\n", pkg.Name, s); err != nil {
-							return err
-						}
+						return err
+					}
 				} else {
 					if _, err := lipgloss.Fprintf(cmd.OutOrStdout(), "\n%s:\n%s\n", pkg.Name, s); err != nil {
 						return err
@@ -358,6 +449,15 @@ Example:
 				}
 				return nil
 			})
+			if err != nil || format != "json" {
+				return err
+			}
+			s, err := list.RenderElementsJSON(packages)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), s)
+			return err
 		}
 
 		if ctx, err := W.GetWorkspaceContext(cmd); err != nil {
@@ -367,21 +467,24 @@ Example:
 			if err != nil {
 				return err
 			}
-			format, err := requireFormat(cmd, []string{"table", "markdown"})
-			if err != nil {
-				return err
-			}
-			columns, err := cmd.Flags().GetStringArray("columns")
-			if err != nil {
-				return err
+			var s string
+			switch format {
+			case "json":
+				pkg := list.PackageElements{Elements: list.SummarizeElements(manifest, filter)}
+				if packageJSON, err := ctx.PackageJSON(); err == nil && packageJSON != nil {
+					pkg.Package = packageJSON.Name
+				}
+				s, err = list.RenderElementsJSON([]list.PackageElements{pkg})
+			case "tree":
+				s, err = list.RenderElementsTree("Elements", list.SummarizeElements(manifest, filter))
+			default:
+				s, err = list.RenderTagsTable(manifest, opts)
 			}
-			opts := list.RenderOptions{Columns: columns, Markdown: format == "markdown"}
-			s, err := list.RenderTagsTable(manifest, opts)
 			if err != nil {
 				return err
 			}
 			switch format {
-			case "markdown":
+			case "markdown", "json":
 				if _, err := fmt.Fprintln(cmd.OutOrStdout(), s); err != nil {
 					return err
 				}
@@ -619,6 +722,10 @@ func init() {
 	listCmd.PersistentFlags().StringP("format", "f", "table", "Output format")
 	listCmd.PersistentFlags().Bool("deprecated", false, "Filter the results, showing only deprecated items")
 	listTagsCmd.Flags().StringArrayP("columns", "c", []string{}, "list of columns to display in the table")
+	listTagsCmd.Flags().Bool("json", false, "Output element summaries as JSON, same as --format json")
+	listTagsCmd.Flags().Bool("tree", false, "Output element summaries as a tree of modules, same as --format tree")
+	listTagsCmd.Flags().String("filter", "", "Filter expression, e.g. 'tag=my-*,attrs>0,!deprecated'")
+	listTagsCmd.Flags().StringArray("category", []string{}, "Only list elements in this category (repeatable)")
 	listModulesCmd.Flags().StringArrayP("columns", "c", []string{}, "list of columns to display in the table")
 	for _, c := range []*cobra.Command{
 		listAttrsCmd,
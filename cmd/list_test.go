@@ -44,9 +44,22 @@ func TestListE2E(t *testing.T) {
 			command: []string{"list", "tags"},
 		},
 		{
-			name:          "JSON",
-			command:       []string{"list", "tags", "--format", "json"},
-			expectedError: "Error: unknown format: json",
+			name:    "JSON",
+			command: []string{"list", "tags", "--format", "json"},
+		},
+		{
+			name:    "Elements Filter",
+			command: []string{"list", "elements", "--json", "--filter", "attrs>1,!deprecated"},
+		},
+		{
+			name:          "Invalid Filter",
+			command:       []string{"list", "elements", "--filter", "color=red"},
+			expectedError: `Error: invalid filter "color=red": unknown field "color"`,
+		},
+		{
+			name:          "YAML",
+			command:       []string{"list", "tags", "--format", "yaml"},
+			expectedError: "Error: unknown format: yaml",
 		},
 		{
 			name:    "Markdown",
@@ -22,6 +22,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 
+	"bennypowers.dev/cem/internal/logging"
 	LSP "bennypowers.dev/cem/lsp"
 	"bennypowers.dev/cem/types"
 	W "bennypowers.dev/cem/internal/workspace"
@@ -96,6 +97,10 @@ Features provided:
 		if err != nil {
 			return err
 		}
+		logging.Event("lsp.start", map[string]any{
+			"transport": string(transport),
+			"root":      wctx.Root(),
+		})
 		return server.Run()
 	},
 }
@@ -64,6 +64,24 @@ Tools provided:
 		if err := viper.BindPFlag("additionalPackages", cmd.Flags().Lookup("additional-packages")); err != nil {
 			return fmt.Errorf("failed to bind additional-packages flag: %w", err)
 		}
+		if err := viper.BindPFlag("mcp.readOnly", cmd.Flags().Lookup("read-only")); err != nil {
+			return fmt.Errorf("failed to bind read-only flag: %w", err)
+		}
+		if err := viper.BindPFlag("mcp.allowedDirs", cmd.Flags().Lookup("allowed-dirs")); err != nil {
+			return fmt.Errorf("failed to bind allowed-dirs flag: %w", err)
+		}
+		if err := viper.BindPFlag("mcp.auditLog", cmd.Flags().Lookup("audit-log")); err != nil {
+			return fmt.Errorf("failed to bind audit-log flag: %w", err)
+		}
+		if err := viper.BindPFlag("mcp.noSessionMemory", cmd.Flags().Lookup("no-session-memory")); err != nil {
+			return fmt.Errorf("failed to bind no-session-memory flag: %w", err)
+		}
+		if err := viper.BindPFlag("mcp.noResponseCache", cmd.Flags().Lookup("no-response-cache")); err != nil {
+			return fmt.Errorf("failed to bind no-response-cache flag: %w", err)
+		}
+		if err := viper.BindPFlag("mcp.changelog", cmd.Flags().Lookup("changelog")); err != nil {
+			return fmt.Errorf("failed to bind changelog flag: %w", err)
+		}
 
 		ctx := cmd.Context()
 		wctx := ctx.Value(workspace.WorkspaceContextKey).(types.WorkspaceContext)
@@ -81,6 +99,12 @@ Tools provided:
 		server, err := MCP.NewServerWithConfig(wctx, MCP.ServerConfig{
 			MaxDescriptionLength: maxDescriptionLength,
 			AdditionalPackages:   additionalPackages,
+			ReadOnly:             viper.GetBool("mcp.readOnly"),
+			AllowedDirs:          viper.GetStringSlice("mcp.allowedDirs"),
+			AuditLogPath:         viper.GetString("mcp.auditLog"),
+			NoSessionMemory:      viper.GetBool("mcp.noSessionMemory"),
+			NoResponseCache:      viper.GetBool("mcp.noResponseCache"),
+			Changelog:            viper.GetBool("mcp.changelog"),
 		})
 		if err != nil {
 			return err
@@ -93,5 +117,11 @@ Tools provided:
 func init() {
 	mcpCmd.Flags().IntP("max-description-length", "", 2000, "Maximum length for description fields before truncation")
 	mcpCmd.Flags().StringSliceP("additional-packages", "a", nil, "Additional packages to load (URLs, npm:, or jsr: specifiers). Failures are logged as warnings; server continues with available packages.")
+	mcpCmd.Flags().Bool("read-only", false, "Disable tools which modify the workspace, and reject filesystem writes")
+	mcpCmd.Flags().StringSlice("allowed-dirs", nil, "Restrict filesystem access to these directories, relative to the project root")
+	mcpCmd.Flags().String("audit-log", "", "Append a JSON line for every tool invocation to this file")
+	mcpCmd.Flags().Bool("no-session-memory", false, "Always send element overviews in full, instead of abbreviating those a session already read")
+	mcpCmd.Flags().Bool("no-response-cache", false, "Render every tool response afresh, instead of repeating responses to identical calls while the manifests are unchanged")
+	mcpCmd.Flags().Bool("changelog", false, "Enable the element_changelog tool, which summarizes the git history of an element's modules")
 	rootCmd.AddCommand(mcpCmd)
 }
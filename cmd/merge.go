@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/internal/logging"
+	"bennypowers.dev/cem/internal/platform"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	mergeCmd.Flags().StringP("output", "o", "", "Write the merged manifest to this file (default: stdout)")
+	mergeCmd.Flags().String("strategy", string(M.MergeError), "Conflict resolution: error, prefer-first, or prefer-latest-version")
+	rootCmd.AddCommand(mergeCmd)
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <manifest.json> <manifest.json>...",
+	Short: "Merge several custom-elements manifests into one",
+	Long: `Combine the modules of several custom-elements.json manifests into a single
+manifest, for example to publish one docs artifact for many packages.
+
+A conflict is a module path present in more than one manifest with different
+contents. The --strategy flag decides how conflicts are resolved:
+
+  error                  fail on the first conflict (default)
+  prefer-first           keep the module from the earliest manifest given
+  prefer-latest-version  keep the module from the manifest whose package has
+                         the highest version, read from the package.json next
+                         to each manifest`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy, _ := cmd.Flags().GetString("strategy")
+		if !slices.Contains(M.MergeStrategies, M.MergeStrategy(strategy)) {
+			names := make([]string, len(M.MergeStrategies))
+			for i, s := range M.MergeStrategies {
+				names[i] = string(s)
+			}
+			return fmt.Errorf("invalid strategy %q: must be one of %s", strategy, strings.Join(names, ", "))
+		}
+
+		fsys := platform.NewOSFileSystem()
+		sources := make([]M.MergeSource, 0, len(args))
+		for _, path := range args {
+			pkg, err := loadManifestFile(fsys, path)
+			if err != nil {
+				return fmt.Errorf("failed to load manifest: %w", err)
+			}
+			sources = append(sources, M.MergeSource{
+				Name:    path,
+				Version: manifestPackageVersion(fsys, path),
+				Package: pkg,
+			})
+		}
+
+		merged, conflicts, err := M.MergePackages(sources, M.MergeStrategy(strategy))
+		if err != nil {
+			return err
+		}
+		for _, conflict := range conflicts {
+			logging.Warning("Module %s differs between %s; kept %s",
+				conflict.Path, strings.Join(conflict.Sources, ", "), conflict.Kept)
+		}
+
+		data, err := M.SerializeToBytes(merged)
+		if err != nil {
+			return fmt.Errorf("failed to serialize merged manifest: %w", err)
+		}
+		data = append(data, '\n')
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			_, err := cmd.OutOrStdout().Write(data)
+			return err
+		}
+		if err := fsys.MkdirAll(filepath.Dir(output), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := fsys.WriteFile(output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write merged manifest: %w", err)
+		}
+		logging.Success("Merged %d manifests into %s", len(sources), output)
+		return nil
+	},
+}
+
+// manifestPackageVersion reads the version from the package.json beside a
+// manifest, returning empty when there is none
+func manifestPackageVersion(fsys platform.FileSystem, manifestPath string) string {
+	data, err := fsys.ReadFile(filepath.Join(filepath.Dir(manifestPath), "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg M.PackageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Version
+}
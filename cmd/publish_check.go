@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/workspace"
+	"bennypowers.dev/cem/publishcheck"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	publishCheckCmd.Flags().String("format", "text", "Output format: text or json")
+	rootCmd.AddCommand(publishCheckCmd)
+}
+
+var publishCheckCmd = &cobra.Command{
+	Use:   "publish-check [package-dir]",
+	Short: "Check that a package's manifest will survive npm publish",
+	Long: `Check a package before publishing it to npm, so that consumers don't
+receive a broken custom elements manifest. Fails when:
+
+  - package.json has no customElements field, or it names a missing file
+  - the manifest is not included by package.json "files"
+  - a module in the manifest is missing, or not included by "files"
+  - a source or demo link in the manifest is not an absolute URL
+
+Run it in a prepublishOnly script. In an npm workspace, checks every package
+with a customElements field.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("invalid format %q: must be text or json", format)
+		}
+
+		fsys := platform.NewOSFileSystem()
+		var results []*publishcheck.Result
+
+		if len(args) > 0 {
+			result, err := publishcheck.Check(fsys, args[0])
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		} else if workspace.ShouldUseWorkspaceMode(cmd, fsys) {
+			ctx, err := workspace.GetWorkspaceContext(cmd)
+			if err != nil {
+				return err
+			}
+			packages := workspace.ForEachPackage(ctx.Root(), fsys, func(pkg workspace.PackageInfo) error {
+				result, err := publishcheck.Check(fsys, pkg.Path)
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+				return nil
+			})
+			if err := workspace.ReportResults("Checked packages", packages); err != nil {
+				return err
+			}
+		} else {
+			ctx, err := workspace.GetWorkspaceContext(cmd)
+			if err != nil {
+				return err
+			}
+			result, err := publishcheck.Check(fsys, ctx.Root())
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		if err := publishcheck.PrintResults(cmd.OutOrStdout(), results, publishcheck.DisplayOptions{Format: format}); err != nil {
+			return err
+		}
+
+		problems := 0
+		for _, result := range results {
+			problems += len(result.Problems)
+		}
+		if problems > 0 {
+			return fmt.Errorf("%d publish problem(s) found", problems)
+		}
+		return nil
+	},
+}
@@ -59,6 +59,12 @@ func rootPersistentPreRunE(cmd *cobra.Command, args []string) error {
 			viper.Set("package", rootDir)
 		}
 
+		logFormat, err := logFormatFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		logging.SetFormat(logFormat)
+
 		// Handle verbose and quiet flags (mutually exclusive)
 		verboseCount, _ := cmd.Flags().GetCount("verbose")
 		quiet := viper.GetBool("quiet")
@@ -119,6 +125,12 @@ func rootPersistentPreRunE(cmd *cobra.Command, args []string) error {
 		return nil
 }
 
+// logFormatFromFlags reads the --log-format flag
+func logFormatFromFlags(cmd *cobra.Command) (logging.Format, error) {
+	value, _ := cmd.Flags().GetString("log-format")
+	return logging.ParseFormat(value)
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
@@ -132,6 +144,7 @@ func init() {
 	rootCmd.PersistentFlags().StringP("package", "p", "", "package specifier: npm:@scope/package, URL (https://cdn.example.com/pkg/), or local path")
 	rootCmd.PersistentFlags().CountP("verbose", "v", "increase verbosity (-v info, -vv debug, -vvv trace)")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "quiet output (only warnings and errors)")
+	rootCmd.PersistentFlags().String("log-format", "text", "log output format: text, or json for one JSON object per line on stderr")
 
 	_ = viper.BindPFlag("configFile", rootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("package", rootCmd.PersistentFlags().Lookup("package"))
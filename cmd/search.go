@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"bennypowers.dev/cem/internal/platform"
+	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/search"
 	W "bennypowers.dev/cem/internal/workspace"
 )
@@ -48,6 +49,11 @@ Examples:
   cem search --format tree deprecated  # Search for "deprecated" and show as tree
   cem search "css.*property"           # Find CSS-related properties
   cem search "slot.*header"            # Find header-related slots
+  cem search --fuzzy buton             # Elements whose names are closest to "buton"
+
+With --fuzzy, the query is not a pattern. Instead, custom elements are ranked by
+how closely their tag names, class names, and descriptions match it, tolerating
+misspellings, and the best matches are listed.
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,7 +80,7 @@ Examples:
 				return err
 			}
 
-			if s, err := search.RenderSearchResults(manifest, pattern, format); err != nil {
+			if s, err := renderSearch(cmd, manifest, pattern, format); err != nil {
 				return err
 			} else {
 				if _, err := lipgloss.Fprintln(cmd.OutOrStdout(), s); err != nil {
@@ -110,7 +116,7 @@ func searchWorkspace(cmd *cobra.Command, pattern string) error {
 		if manifest == nil {
 			return nil
 		}
-		s, err := search.RenderSearchResults(manifest, pattern, format)
+		s, err := renderSearch(cmd, manifest, pattern, format)
 		if err != nil {
 			return err
 		}
@@ -125,7 +131,26 @@ func searchWorkspace(cmd *cobra.Command, pattern string) error {
 	return W.ReportResults("Searched manifests", results)
 }
 
+// renderSearch renders the results of a pattern search, or with --fuzzy, the
+// elements which best match the query
+func renderSearch(cmd *cobra.Command, manifest *M.Package, query, format string) (string, error) {
+	fuzzy, err := cmd.Flags().GetBool("fuzzy")
+	if err != nil {
+		return "", err
+	}
+	if !fuzzy {
+		return search.RenderSearchResults(manifest, query, format)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return "", err
+	}
+	return search.RenderFuzzyResults(manifest, query, limit)
+}
+
 func init() {
 	searchCmd.Flags().StringP("format", "f", "table", "Output format (table or tree)")
+	searchCmd.Flags().Bool("fuzzy", false, "rank custom elements by how closely they match the query, tolerating misspellings")
+	searchCmd.Flags().Int("limit", 10, "with --fuzzy, the most elements to list (0 for all)")
 	rootCmd.AddCommand(searchCmd)
 }
@@ -22,6 +22,7 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -57,7 +58,12 @@ var serveCmd = &cobra.Command{
 - Multiple rendering modes (full UI, shadow DOM, or chromeless)
 - Static file serving with CORS`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if term.IsTerminal(int(os.Stdout.Fd())) {
+		// The interactive TUI would interleave with JSON output
+		logFormat, err := logFormatFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if term.IsTerminal(int(os.Stdout.Fd())) && logFormat != logging.FormatJSON {
 			serveTUILogger = servetui.NewLogger()
 			logging.SetMode(logging.ModeServe)
 			logging.SetServeSink(serveTUILogger)
@@ -72,144 +78,219 @@ var serveCmd = &cobra.Command{
 			return fmt.Errorf("project context not initialized: %w", err)
 		}
 
-		port := viper.GetInt("serve.port")
-		reload := !viper.GetBool("serve.no-reload")
-		targetStr := viper.GetString("serve.target")
+		config, err := newServeConfig(cmd)
+		if err != nil {
+			return err
+		}
 
-		// Load transform configuration
-		tsEnabled := viper.GetBool("serve.transforms.typescript.enabled")
-		if !viper.IsSet("serve.transforms.typescript.enabled") {
-			tsEnabled = true // Default to enabled
+		if buildMode, _ := cmd.Flags().GetBool("build"); buildMode {
+			return runBuild(config, ctx.Root(), cmd, false)
 		}
 
-		cssEnabled := viper.GetBool("serve.transforms.css.enabled")
-		if !viper.IsSet("serve.transforms.css.enabled") {
-			cssEnabled = true // Default to enabled
+		if serveTUILogger != nil {
+			defer func() {
+				logging.SetServeSink(nil)
+				logging.SetMode(logging.ModeCLI)
+			}()
+			return runInteractive(serveTUILogger, config, ctx.Root(), config.Reload)
 		}
+		return runNonInteractive(config, ctx.Root(), cmd, config.Reload)
+	},
+}
 
-		cssInclude := viper.GetStringSlice("serve.transforms.css.include")
-		cssExclude := viper.GetStringSlice("serve.transforms.css.exclude")
+// newServeConfig reads the dev server's configuration from the config file
+// and the command's flags
+func newServeConfig(cmd *cobra.Command) (serve.Config, error) {
+	ctx, err := W.GetWorkspaceContext(cmd)
+	if err != nil {
+		return serve.Config{}, fmt.Errorf("project context not initialized: %w", err)
+	}
 
-		// Load import map configuration
-		importMapGenerate := true
-		// Config file sets the default value if present
-		if viper.IsSet("serve.importMap.generate") {
-			importMapGenerate = viper.GetBool("serve.importMap.generate")
-		}
-		// CLI flag overrides config if explicitly set
-		if cmd.Flags().Changed("no-import-map-generate") {
-			noGenerate, _ := cmd.Flags().GetBool("no-import-map-generate")
-			importMapGenerate = !noGenerate
-		}
-		importMapOverrideFile := viper.GetString("serve.importMap.overrideFile")
+	port := viper.GetInt("serve.port")
+	autoPort := viper.GetBool("serve.autoPort")
+	otlpEndpoint := viper.GetString("serve.tracing.otlpEndpoint")
+	tlsConfig := serve.TLSConfig{
+		Enabled:  viper.GetBool("serve.tls.enabled"),
+		CertFile: viper.GetString("serve.tls.cert"),
+		KeyFile:  viper.GetString("serve.tls.key"),
+	}
+	reload := !viper.GetBool("serve.no-reload")
+	targetStr := viper.GetString("serve.target")
 
-		// Load config-based override (full import map structure)
-		var importMapOverride types.ImportMapOverride
-		if viper.IsSet("serve.importMap.override.imports") {
-			importMapOverride.Imports = viper.GetStringMapString("serve.importMap.override.imports")
-		}
-		if viper.IsSet("serve.importMap.override.scopes") {
-			scopes := viper.GetStringMap("serve.importMap.override.scopes")
-			if len(scopes) > 0 {
-				importMapOverride.Scopes = make(map[string]map[string]string)
-				for scopeKey, scopeVal := range scopes {
-					if scopeMap, ok := scopeVal.(map[string]any); ok {
-						importMapOverride.Scopes[scopeKey] = make(map[string]string)
-						for k, v := range scopeMap {
-							if str, ok := v.(string); ok {
-								importMapOverride.Scopes[scopeKey][k] = str
-							}
+	// Load transform configuration
+	tsEnabled := viper.GetBool("serve.transforms.typescript.enabled")
+	if !viper.IsSet("serve.transforms.typescript.enabled") {
+		tsEnabled = true // Default to enabled
+	}
+
+	cssEnabled := viper.GetBool("serve.transforms.css.enabled")
+	if !viper.IsSet("serve.transforms.css.enabled") {
+		cssEnabled = true // Default to enabled
+	}
+
+	cssInclude := viper.GetStringSlice("serve.transforms.css.include")
+	cssExclude := viper.GetStringSlice("serve.transforms.css.exclude")
+
+	// Load import map configuration
+	importMapGenerate := true
+	// Config file sets the default value if present
+	if viper.IsSet("serve.importMap.generate") {
+		importMapGenerate = viper.GetBool("serve.importMap.generate")
+	}
+	// CLI flag overrides config if explicitly set
+	if cmd.Flags().Changed("no-import-map-generate") {
+		noGenerate, _ := cmd.Flags().GetBool("no-import-map-generate")
+		importMapGenerate = !noGenerate
+	}
+	importMapOverrideFile := viper.GetString("serve.importMap.overrideFile")
+
+	// Offline serving implies caching CDN modules
+	cdnCache := types.CDNCacheConfig{
+		Enabled: viper.GetBool("serve.importMap.cdnCache.enabled"),
+		Dir:     viper.GetString("serve.importMap.cdnCache.dir"),
+		Offline: viper.GetBool("serve.importMap.cdnCache.offline"),
+	}
+	cdnCache.Enabled = cdnCache.Enabled || cdnCache.Offline
+	if cdnCache.Dir != "" && !filepath.IsAbs(cdnCache.Dir) {
+		cdnCache.Dir = filepath.Join(ctx.Root(), cdnCache.Dir)
+	}
+
+	// Load config-based override (full import map structure)
+	var importMapOverride types.ImportMapOverride
+	if viper.IsSet("serve.importMap.override.imports") {
+		importMapOverride.Imports = viper.GetStringMapString("serve.importMap.override.imports")
+	}
+	if viper.IsSet("serve.importMap.override.scopes") {
+		scopes := viper.GetStringMap("serve.importMap.override.scopes")
+		if len(scopes) > 0 {
+			importMapOverride.Scopes = make(map[string]map[string]string)
+			for scopeKey, scopeVal := range scopes {
+				if scopeMap, ok := scopeVal.(map[string]any); ok {
+					importMapOverride.Scopes[scopeKey] = make(map[string]string)
+					for k, v := range scopeMap {
+						if str, ok := v.(string); ok {
+							importMapOverride.Scopes[scopeKey][k] = str
 						}
 					}
 				}
 			}
 		}
+	}
 
-		// Get watch ignore patterns from config or flag
-		watchIgnore := viper.GetStringSlice("serve.watchIgnore")
+	// Get watch ignore patterns from config or flag
+	watchIgnore := viper.GetStringSlice("serve.watchIgnore")
 
-		// Get target from transforms config or fallback to --target flag
-		configTarget := viper.GetString("serve.transforms.typescript.target")
-		if targetStr == "" && configTarget != "" {
-			targetStr = configTarget
-		}
+	// Get target from transforms config or fallback to --target flag
+	configTarget := viper.GetString("serve.transforms.typescript.target")
+	if targetStr == "" && configTarget != "" {
+		targetStr = configTarget
+	}
 
-		var target transform.Target
-		if targetStr != "" {
-			if !IC.IsValidTarget(targetStr) {
-				return fmt.Errorf("invalid target %q: must be one of %s", targetStr, strings.Join(IC.ValidTargets(), ", "))
-			}
-			target = transform.Target(targetStr)
-		} else {
-			target = transform.ES2022
+	var target transform.Target
+	if targetStr != "" {
+		if !IC.IsValidTarget(targetStr) {
+			return serve.Config{}, fmt.Errorf("invalid target %q: must be one of %s", targetStr, strings.Join(IC.ValidTargets(), ", "))
 		}
+		target = transform.Target(targetStr)
+	} else {
+		target = transform.ES2022
+	}
 
-		// Get source control root URL from config
-		sourceControlRootURL := viper.GetString("sourceControlRootUrl")
+	// Get source control root URL from config
+	sourceControlRootURL := viper.GetString("sourceControlRootUrl")
 
-		// Compute demo URL prefix from urlTemplate for local route stripping
-		demoURLPrefix := routes.DemoURLPrefixFromTemplate(
-			viper.GetString("generate.demoDiscovery.urlTemplate"),
-		)
+	// Compute demo URL prefix from urlTemplate for local route stripping
+	demoURLPrefix := routes.DemoURLPrefixFromTemplate(
+		viper.GetString("generate.demoDiscovery.urlTemplate"),
+	)
 
-		// Get URL rewrites from config
-		var urlRewrites []config.URLRewrite
-		if err := viper.UnmarshalKey("serve.urlRewrites", &urlRewrites); err != nil {
-			return fmt.Errorf("failed to parse serve.urlRewrites: %w", err)
-		}
+	// Get URL rewrites from config
+	var urlRewrites []config.URLRewrite
+	if err := viper.UnmarshalKey("serve.urlRewrites", &urlRewrites); err != nil {
+		return serve.Config{}, fmt.Errorf("failed to parse serve.urlRewrites: %w", err)
+	}
 
-		// Get demo rendering mode from config (default: "light")
-		demoRendering := viper.GetString("serve.demos.rendering")
-		if demoRendering == "" {
-			demoRendering = "light"
+	// Get demo rendering mode from config (default: "light")
+	demoRendering := viper.GetString("serve.demos.rendering")
+	if demoRendering == "" {
+		demoRendering = "light"
+	}
+	if !IC.IsValidRenderingMode(demoRendering) {
+		return serve.Config{}, fmt.Errorf("invalid demo rendering mode %q: must be one of %s", demoRendering, strings.Join(IC.ValidRenderingModes(), ", "))
+	}
+
+	// Get demo env from the loaded config rather than viper, which
+	// lowercases map keys
+	cfg, err := ctx.Config()
+	if err != nil {
+		return serve.Config{}, fmt.Errorf("loading config: %w", err)
+	}
+	for name := range cfg.Serve.Demos.Env {
+		if !IC.IsValidEnvName(name) {
+			return serve.Config{}, fmt.Errorf("invalid demo env name %q: must start with a letter or underscore, followed by letters, digits, or underscores", name)
 		}
-		if !IC.IsValidRenderingMode(demoRendering) {
-			return fmt.Errorf("invalid demo rendering mode %q: must be one of %s", demoRendering, strings.Join(IC.ValidRenderingModes(), ", "))
+	}
+	// A pinned manifest is served as-is, skipping generation
+	manifestFile, _ := cmd.Flags().GetString("manifest")
+	if manifestFile != "" {
+		if !filepath.IsAbs(manifestFile) {
+			manifestFile = filepath.Join(ctx.Root(), manifestFile)
 		}
-		// Create server config
-		config := serve.Config{
-			Port:                 port,
-			Reload:               reload,
-			Target:               target,
-			WatchIgnore:          watchIgnore,
-			SourceControlRootURL: sourceControlRootURL,
-			DemoURLPrefix:        demoURLPrefix,
-			URLRewrites:          urlRewrites,
-			ConfigFile:           ctx.ConfigFile(),
-			ImportMap: types.ImportMapConfig{
-				Generate:     importMapGenerate,
-				OverrideFile: importMapOverrideFile,
-				Override:     importMapOverride,
-			},
-			Demos: serve.DemosConfig{
-				Rendering: demoRendering,
-			},
-			Transforms: serve.TransformConfig{
-				TypeScript: serve.TypeScriptConfig{
-					Enabled: tsEnabled,
-					Target:  target,
-				},
-				CSS: serve.CSSConfig{
-					Enabled: cssEnabled,
-					Include: cssInclude,
-					Exclude: cssExclude,
-				},
-			},
+		if _, err := os.Stat(manifestFile); err != nil {
+			return serve.Config{}, fmt.Errorf("reading manifest: %w", err)
 		}
+	}
 
-		if buildMode, _ := cmd.Flags().GetBool("build"); buildMode {
-			return runBuild(config, ctx.Root(), cmd)
+	// Certificate paths are relative to the project root
+	for _, file := range []*string{&tlsConfig.CertFile, &tlsConfig.KeyFile} {
+		if *file != "" && !filepath.IsAbs(*file) {
+			*file = filepath.Join(ctx.Root(), *file)
 		}
+	}
 
-		if serveTUILogger != nil {
-			defer func() {
-				logging.SetServeSink(nil)
-				logging.SetMode(logging.ModeCLI)
-			}()
-			return runInteractive(serveTUILogger, config, ctx.Root(), reload)
-		}
-		return runNonInteractive(config, ctx.Root(), reload)
-	},
+	// Create server config
+	config := serve.Config{
+		Port:                 port,
+		AutoPort:             autoPort,
+		TLS:                  tlsConfig,
+		OTLPEndpoint:         otlpEndpoint,
+		Reload:               reload,
+		Target:               target,
+		WatchIgnore:          watchIgnore,
+		SourceControlRootURL: sourceControlRootURL,
+		DemoURLPrefix:        demoURLPrefix,
+		URLRewrites:          urlRewrites,
+		ConfigFile:           ctx.ConfigFile(),
+		ImportMap: types.ImportMapConfig{
+			Generate:     importMapGenerate,
+			OverrideFile: importMapOverrideFile,
+			Override:     importMapOverride,
+			CDNCache:     cdnCache,
+		},
+		Demos: serve.DemosConfig{
+			Rendering:          demoRendering,
+			Env:                cfg.Serve.Demos.Env,
+			ScopedElementsFile: cfg.Serve.Demos.ScopedElementsFile,
+			ThemeToggle: serve.ThemeToggleConfig{
+				Attribute: cfg.Serve.Demos.ThemeToggle.Attribute,
+				Class:     cfg.Serve.Demos.ThemeToggle.Class,
+			},
+		},
+		ManifestFile: manifestFile,
+		Transforms: serve.TransformConfig{
+			TypeScript: serve.TypeScriptConfig{
+				Enabled: tsEnabled,
+				Target:  target,
+			},
+			CSS: serve.CSSConfig{
+				Enabled: cssEnabled,
+				Include: cssInclude,
+				Exclude: cssExclude,
+			},
+		},
+	}
+
+	return config, nil
 }
 
 func runInteractive(tl *servetui.Logger, config serve.Config, root string, reload bool) error {
@@ -241,7 +322,7 @@ func runInteractive(tl *servetui.Logger, config serve.Config, root string, reloa
 			server = s
 			mu.Unlock()
 			port := s.Port()
-			tl.SetStatus(formatStatusLine(port, reload, logging.CurrentVerbosity().String()))
+			tl.SetStatus(formatStatusLine(s.URL(), reload, logging.CurrentVerbosity().String()))
 			return servetui.ServerReadyMsg{Port: port, Reload: reload, WatchDone: s.Done()}
 		},
 		RebuildManifest: func() (int, error) {
@@ -267,8 +348,7 @@ func runInteractive(tl *servetui.Logger, config serve.Config, root string, reloa
 			if s == nil {
 				return fmt.Errorf("server not initialized")
 			}
-			port := s.Port()
-			url := fmt.Sprintf("http://localhost:%d", port)
+			url := s.URL()
 			tl.Info("Opening %s in browser...", url)
 			return openBrowser(url)
 		},
@@ -280,11 +360,11 @@ func runInteractive(tl *servetui.Logger, config serve.Config, root string, reloa
 			mu.Unlock()
 			logging.SetVerbosity(v)
 			tl.Info("Log level: %s", v)
-			port := 0
+			url := serve.LocalURL(config.TLS.Enabled, 0)
 			if s != nil {
-				port = s.Port()
+				url = s.URL()
 			}
-			tl.SetStatus(formatStatusLine(port, reload, v.String()))
+			tl.SetStatus(formatStatusLine(url, reload, v.String()))
 			return v.String()
 		},
 		Shutdown: func() {
@@ -338,7 +418,13 @@ func initServer(config serve.Config, log logger.Logger, root string, reload bool
 		return nil, fmt.Errorf("failed to initialize workspace mode: %w", err)
 	}
 
-	if !server.IsWorkspace() {
+	if config.ManifestFile != "" {
+		size, err := server.TryLoadExistingManifest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load manifest: %w", err)
+		}
+		log.Success("Serving manifest %s (%d bytes)", config.ManifestFile, size)
+	} else if !server.IsWorkspace() {
 		size, err := server.TryLoadExistingManifest()
 		if err != nil {
 			log.Warning("Could not load cached manifest: %v", err)
@@ -391,13 +477,30 @@ func initServer(config serve.Config, log logger.Logger, root string, reload bool
 	if reload {
 		reloadStatus = " (live reload enabled)"
 	}
-	log.Success("Server started on http://localhost:%d%s", server.Port(), reloadStatus)
+	log.Success("Server started on %s%s", server.URL(), reloadStatus)
+	logging.Event("serve.ready", map[string]any{
+		"url":    server.URL(),
+		"port":   server.Port(),
+		"reload": reload,
+	})
 
 	return server, nil
 }
 
-func runBuild(config serve.Config, root string, cmd *cobra.Command) error {
-	log := logger.NewDefaultLogger()
+// nonInteractiveLogger returns the logger for serve without the TUI. With
+// --log-format json, serve logs through the centralized logger, so each
+// message is a JSON record.
+func nonInteractiveLogger() logger.Logger {
+	if logging.CurrentFormat() == logging.FormatJSON {
+		return logging.GetLogger()
+	}
+	return logger.NewDefaultLogger()
+}
+
+// runBuild builds a static site, with a page for each demo, and when docs is
+// set, a page documenting each element
+func runBuild(config serve.Config, root string, cmd *cobra.Command, docs bool) error {
+	log := nonInteractiveLogger()
 	config.Logger = log
 
 	server, err := serve.NewServerWithConfig(config)
@@ -432,11 +535,12 @@ func runBuild(config serve.Config, root string, cmd *cobra.Command) error {
 		BasePath:   basePath,
 		ImportMode: importMode,
 		WorkDir:    cwd,
+		Docs:       docs,
 	})
 }
 
-func runNonInteractive(config serve.Config, root string, reload bool) error {
-	log := logger.NewDefaultLogger()
+func runNonInteractive(config serve.Config, root string, cmd *cobra.Command, reload bool) error {
+	log := nonInteractiveLogger()
 	config.Logger = log
 
 	server, err := initServer(config, log, root, reload)
@@ -449,6 +553,11 @@ func runNonInteractive(config serve.Config, root string, reload bool) error {
 		}
 	}()
 
+	// Logs go to stderr, so print the resolved URL on stdout for tooling to
+	// capture, e.g. when serving with --port 0
+	cmd.SetOut(os.Stdout)
+	cmd.Println(server.URL())
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	select {
@@ -485,15 +594,23 @@ var verbosityOrder = []logging.Verbosity{
 func init() {
 	rootCmd.AddCommand(serveCmd)
 
-	serveCmd.Flags().Int("port", 8000, "Port to serve on")
+	serveCmd.Flags().Int("port", 8000, "Port to serve on, or 0 for a port assigned by the OS")
+	serveCmd.Flags().Bool("auto-port", false, "Serve on the next free port when the port is unavailable")
 	serveCmd.Flags().Bool("no-reload", false, "Disable live reload")
+	serveCmd.Flags().Bool("tls", false, "Serve HTTPS, with a self-signed certificate unless --tls-cert and --tls-key are given")
+	serveCmd.Flags().String("tls-cert", "", "PEM certificate file to serve HTTPS with")
+	serveCmd.Flags().String("tls-key", "", "PEM private key file for --tls-cert")
+	serveCmd.Flags().String("otlp-endpoint", "", "Export request traces to this OpenTelemetry collector over OTLP/HTTP (e.g., http://localhost:4318)")
 	serveCmd.Flags().Bool("no-import-map-generate", false, "Disable automatic import map generation")
 	serveCmd.Flags().String("import-map-override-file", "", "Path to JSON file with custom import map entries")
+	serveCmd.Flags().Bool("cdn-cache", false, "Cache the CDN modules in the import map, serving them locally when the CDN is unreachable")
+	serveCmd.Flags().Bool("offline", false, "Serve cached CDN modules without contacting CDNs (implies --cdn-cache)")
 	serveCmd.Flags().String("target", "", "TypeScript/JavaScript transform target (es2015, es2016, es2017, es2018, es2019, es2020, es2021, es2022, es2023, esnext)")
 	serveCmd.Flags().String("rendering", "", "Demo rendering mode: light (full UI), shadow (Shadow DOM), or chromeless (minimal, no UI)")
 	serveCmd.Flags().StringSlice("watch-ignore", nil, "Glob patterns to ignore in file watcher (comma-separated, e.g., '_site/**,dist/**')")
 	serveCmd.Flags().StringSlice("css-transform", nil, "Glob patterns for CSS files to transform to JavaScript modules (e.g., 'src/**/*.css,elements/**/*.css')")
 	serveCmd.Flags().StringSlice("css-transform-exclude", nil, "Glob patterns for CSS files to exclude from transformation (e.g., 'demo/**/*.css')")
+	serveCmd.Flags().String("manifest", "", "Serve this pre-built manifest instead of generating one (e.g., dist/custom-elements.json)")
 	serveCmd.Flags().Bool("build", false, "Build a static site instead of starting a dev server")
 	serveCmd.Flags().StringP("output", "o", "dist", "Output directory for static build")
 	serveCmd.Flags().String("base-path", "", "URL base path for static build deployment (e.g., /docs/components/)")
@@ -502,13 +619,34 @@ func init() {
 	if err := viper.BindPFlag("serve.port", serveCmd.Flags().Lookup("port")); err != nil {
 		panic(fmt.Sprintf("failed to bind flag serve.port: %v", err))
 	}
+	if err := viper.BindPFlag("serve.autoPort", serveCmd.Flags().Lookup("auto-port")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.autoPort: %v", err))
+	}
 	if err := viper.BindPFlag("serve.no-reload", serveCmd.Flags().Lookup("no-reload")); err != nil {
 		panic(fmt.Sprintf("failed to bind flag serve.no-reload: %v", err))
 	}
+	if err := viper.BindPFlag("serve.tls.enabled", serveCmd.Flags().Lookup("tls")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.tls.enabled: %v", err))
+	}
+	if err := viper.BindPFlag("serve.tls.cert", serveCmd.Flags().Lookup("tls-cert")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.tls.cert: %v", err))
+	}
+	if err := viper.BindPFlag("serve.tls.key", serveCmd.Flags().Lookup("tls-key")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.tls.key: %v", err))
+	}
+	if err := viper.BindPFlag("serve.tracing.otlpEndpoint", serveCmd.Flags().Lookup("otlp-endpoint")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.tracing.otlpEndpoint: %v", err))
+	}
 	// Bind import map flags (note: --no-import-map-generate is handled specially in RunE to invert to serve.importMap.generate)
 	if err := viper.BindPFlag("serve.importMap.overrideFile", serveCmd.Flags().Lookup("import-map-override-file")); err != nil {
 		panic(fmt.Sprintf("failed to bind flag serve.importMap.overrideFile: %v", err))
 	}
+	if err := viper.BindPFlag("serve.importMap.cdnCache.enabled", serveCmd.Flags().Lookup("cdn-cache")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.importMap.cdnCache.enabled: %v", err))
+	}
+	if err := viper.BindPFlag("serve.importMap.cdnCache.offline", serveCmd.Flags().Lookup("offline")); err != nil {
+		panic(fmt.Sprintf("failed to bind flag serve.importMap.cdnCache.offline: %v", err))
+	}
 	if err := viper.BindPFlag("serve.target", serveCmd.Flags().Lookup("target")); err != nil {
 		panic(fmt.Sprintf("failed to bind flag serve.target: %v", err))
 	}
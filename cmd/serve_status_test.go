@@ -9,18 +9,18 @@ import (
 func TestFormatStatusLine(t *testing.T) {
 	tests := []struct {
 		name     string
-		port     int
+		url      string
 		reload   bool
 		logLevel string
 	}{
-		{name: "reload-enabled", port: 8000, reload: true, logLevel: "normal"},
-		{name: "reload-disabled", port: 3000, reload: false, logLevel: "quiet"},
-		{name: "port-zero", port: 0, reload: true, logLevel: "debug"},
+		{name: "reload-enabled", url: "http://localhost:8000", reload: true, logLevel: "normal"},
+		{name: "reload-disabled", url: "http://localhost:3000", reload: false, logLevel: "quiet"},
+		{name: "port-zero", url: "http://localhost:0", reload: true, logLevel: "debug"},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := formatStatusLine(tc.port, tc.reload, tc.logLevel)
+			got := formatStatusLine(tc.url, tc.reload, tc.logLevel)
 			testutil.CheckGolden(t, tc.name, []byte(got), testutil.GoldenOptions{
 				Dir: "testdata/goldens/serve-status",
 			})
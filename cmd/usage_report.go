@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
+	LSP "bennypowers.dev/cem/lsp"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/workspace/executeCommand"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	usageReportCmd.Flags().String("format", "markdown", "Output format: markdown or json")
+	rootCmd.AddCommand(usageReportCmd)
+}
+
+var usageReportCmd = &cobra.Command{
+	Use:   "usage-report",
+	Short: "Report how custom elements are used across the workspace",
+	Long: `Scan the workspace's HTML, TypeScript, and JavaScript files for custom
+elements, and report:
+
+  - how many times each element is used, and in how many files
+  - the values each attribute is used with
+  - where deprecated elements and attributes are still used
+
+Elements are looked up in the same manifests the language server loads, so
+the report matches the editor's cem.generateUsageReport command. Use it to
+track the adoption of a design system.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := requireFormat(cmd, []string{"markdown", "json"})
+		if err != nil {
+			return err
+		}
+
+		ctx, err := W.GetWorkspaceContext(cmd)
+		if err != nil {
+			return fmt.Errorf("project context not initialized: %w", err)
+		}
+
+		registry, err := LSP.NewRegistryWithDefaults()
+		if err != nil {
+			return err
+		}
+		if err := registry.LoadFromWorkspace(ctx); err != nil {
+			return err
+		}
+		dm, err := document.NewDocumentManager()
+		if err != nil {
+			return fmt.Errorf("failed to create document manager: %w", err)
+		}
+		defer dm.Close()
+
+		report := executeCommand.BuildUsageReport(registry, dm, platform.NewOSFileSystem(), ctx.Root(), nil)
+
+		if format == "json" {
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), report.Markdown())
+		return err
+	},
+}
@@ -0,0 +1,316 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"bennypowers.dev/cem/internal/tstype"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// webTypes is the JetBrains web-types document.
+// See https://github.com/JetBrains/web-types
+type webTypes struct {
+	Schema            string                `json:"$schema"`
+	Name              string                `json:"name"`
+	Version           string                `json:"version"`
+	DescriptionMarkup string                `json:"description-markup"`
+	JSTypesSyntax     string                `json:"js-types-syntax"`
+	Contributions     webTypesContributions `json:"contributions"`
+}
+
+type webTypesContributions struct {
+	HTML struct {
+		Elements []webTypesElement `json:"elements"`
+	} `json:"html"`
+}
+
+type webTypesElement struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Source      webTypesSource      `json:"source"`
+	Attributes  []webTypesAttribute `json:"attributes,omitempty"`
+	Slots       []webTypesSymbol    `json:"slots,omitempty"`
+	JS          webTypesJS          `json:"js"`
+	CSS         webTypesCSS         `json:"css"`
+}
+
+type webTypesSource struct {
+	Module string `json:"module"`
+	Symbol string `json:"symbol"`
+}
+
+type webTypesAttribute struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Default     string        `json:"default,omitempty"`
+	Value       webTypesValue `json:"value"`
+}
+
+type webTypesValue struct {
+	Type string `json:"type"`
+}
+
+type webTypesSymbol struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type webTypesJS struct {
+	Properties []webTypesSymbol `json:"properties,omitempty"`
+	Events     []webTypesSymbol `json:"events,omitempty"`
+}
+
+type webTypesCSS struct {
+	Properties []webTypesSymbol `json:"properties,omitempty"`
+	Parts      []webTypesSymbol `json:"parts,omitempty"`
+}
+
+// WebTypes renders the custom elements in the manifest as JetBrains
+// web-types, for completions and documentation in JetBrains IDEs.
+func WebTypes(pkg *M.Package, packageName, version string) ([]byte, error) {
+	doc := webTypes{
+		Schema:            "https://json.schemastore.org/web-types",
+		Name:              packageName,
+		Version:           version,
+		DescriptionMarkup: "markdown",
+		JSTypesSyntax:     "typescript",
+	}
+	doc.Contributions.HTML.Elements = []webTypesElement{}
+
+	for _, elem := range buildExportElements(pkg, packageName) {
+		wte := webTypesElement{
+			Name:        elem.TagName,
+			Description: describe(elem.Summary, elem.Description),
+			Source:      webTypesSource{Module: elem.ImportPath, Symbol: elem.ClassName},
+		}
+		for _, attr := range elem.Attributes {
+			wte.Attributes = append(wte.Attributes, webTypesAttribute{
+				Name:        attr.Name,
+				Description: attr.Summary,
+				Default:     attr.Default,
+				Value:       webTypesValue{Type: attr.Type},
+			})
+		}
+		for _, slot := range elem.Slots {
+			wte.Slots = append(wte.Slots, webTypesSymbol{Name: slot.Name, Description: slot.Summary})
+		}
+		for _, attr := range elem.Attributes {
+			if attr.FieldName != "" {
+				wte.JS.Properties = append(wte.JS.Properties, webTypesSymbol{
+					Name:        attr.FieldName,
+					Type:        attr.Type,
+					Description: attr.Summary,
+				})
+			}
+		}
+		for _, prop := range elem.Properties {
+			wte.JS.Properties = append(wte.JS.Properties, webTypesSymbol{
+				Name:        prop.Name,
+				Type:        prop.Type,
+				Description: prop.Summary,
+			})
+		}
+		for _, event := range elem.Events {
+			wte.JS.Events = append(wte.JS.Events, webTypesSymbol{
+				Name:        event.Name,
+				Type:        event.Type,
+				Description: event.Summary,
+			})
+		}
+		for _, prop := range elem.CssProperties {
+			wte.CSS.Properties = append(wte.CSS.Properties, webTypesSymbol{Name: prop.Name, Description: prop.Summary})
+		}
+		for _, part := range elem.CssParts {
+			wte.CSS.Parts = append(wte.CSS.Parts, webTypesSymbol{Name: part.Name, Description: part.Summary})
+		}
+		doc.Contributions.HTML.Elements = append(doc.Contributions.HTML.Elements, wte)
+	}
+
+	return marshalDocument(doc)
+}
+
+// vscodeCustomData is the VS Code HTML custom data document.
+// See https://github.com/microsoft/vscode-custom-data
+type vscodeCustomData struct {
+	Version float64     `json:"version"`
+	Tags    []vscodeTag `json:"tags"`
+}
+
+type vscodeTag struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Attributes  []vscodeAttribute `json:"attributes"`
+}
+
+type vscodeAttribute struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	ValueSet    string        `json:"valueSet,omitempty"`
+	Values      []vscodeValue `json:"values,omitempty"`
+}
+
+type vscodeValue struct {
+	Name string `json:"name"`
+}
+
+// VSCodeCustomData renders the custom elements in the manifest as VS Code
+// HTML custom data. Custom data has no notion of slots, events, or CSS,
+// so those are listed in each tag's description.
+func VSCodeCustomData(pkg *M.Package) ([]byte, error) {
+	doc := vscodeCustomData{Version: 1.1, Tags: []vscodeTag{}}
+	for _, elem := range buildExportElements(pkg, "") {
+		tag := vscodeTag{
+			Name:        elem.TagName,
+			Description: vscodeTagDescription(elem),
+			Attributes:  []vscodeAttribute{},
+		}
+		for _, attr := range elem.Attributes {
+			va := vscodeAttribute{Name: attr.Name, Description: attr.Summary}
+			if attr.IsBoolean {
+				// "v" is VS Code's value set for attributes without values
+				va.ValueSet = "v"
+			}
+			for _, value := range literalValues(attr.Type) {
+				va.Values = append(va.Values, vscodeValue{Name: value})
+			}
+			tag.Attributes = append(tag.Attributes, va)
+		}
+		doc.Tags = append(doc.Tags, tag)
+	}
+	return marshalDocument(doc)
+}
+
+// vscodeTagDescription describes the element, followed by lists of its
+// slots, events, CSS parts, and CSS custom properties.
+func vscodeTagDescription(elem ExportElement) string {
+	var b strings.Builder
+	b.WriteString(describe(elem.Summary, elem.Description))
+	list := func(title string, items [][2]string) {
+		if len(items) == 0 {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "%s:", title)
+		for _, item := range items {
+			name := item[0]
+			if name == "" {
+				name = "(default)"
+			}
+			fmt.Fprintf(&b, "\n- `%s`", name)
+			if item[1] != "" {
+				fmt.Fprintf(&b, " - %s", item[1])
+			}
+		}
+	}
+
+	var slots, events, parts, props [][2]string
+	for _, slot := range elem.Slots {
+		slots = append(slots, [2]string{slot.Name, slot.Summary})
+	}
+	for _, event := range elem.Events {
+		events = append(events, [2]string{event.Name, event.Summary})
+	}
+	for _, part := range elem.CssParts {
+		parts = append(parts, [2]string{part.Name, part.Summary})
+	}
+	for _, prop := range elem.CssProperties {
+		props = append(props, [2]string{prop.Name, prop.Summary})
+	}
+	list("Slots", slots)
+	list("Events", events)
+	list("CSS Parts", parts)
+	list("CSS Properties", props)
+	return b.String()
+}
+
+// TagNameMap renders a TypeScript declaration file which adds the custom
+// elements in the manifest to HTMLElementTagNameMap, so that
+// document.createElement and querySelector return their classes.
+// dir is the directory of the declaration file, relative to the package
+// root, against which module paths are resolved.
+func TagNameMap(pkg *M.Package, dir string) []byte {
+	var b strings.Builder
+	b.WriteString("// Generated by cem from the custom elements manifest. Do not edit.\n\n")
+	b.WriteString("declare global {\n")
+	b.WriteString("  interface HTMLElementTagNameMap {\n")
+	for _, elem := range buildExportElements(pkg, "") {
+		fmt.Fprintf(&b, "    '%s': import('%s').%s;\n", elem.TagName, relativeModule(dir, elem.ModulePath), elem.ClassName)
+	}
+	b.WriteString("  }\n")
+	b.WriteString("}\n\n")
+	b.WriteString("export {};\n")
+	return []byte(b.String())
+}
+
+// relativeModule returns the import specifier for a module path, relative
+// to dir. TypeScript sources are imported by their JavaScript paths.
+func relativeModule(dir, modulePath string) string {
+	if ext := path.Ext(modulePath); ext == ".ts" {
+		modulePath = strings.TrimSuffix(modulePath, ext) + ".js"
+	}
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(modulePath))
+	if err != nil {
+		rel = modulePath
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// describe joins an element's summary and description.
+func describe(summary, description string) string {
+	switch {
+	case summary == "":
+		return description
+	case description == "":
+		return summary
+	default:
+		return summary + "\n\n" + description
+	}
+}
+
+// literalValues returns the values of a union of string literals, or nil
+// when the type is anything else.
+func literalValues(typeText string) []string {
+	var values []string
+	for _, member := range tstype.SplitTopLevelUnion(typeText) {
+		if len(member) < 2 || (member[0] != '\'' && member[0] != '"') || member[len(member)-1] != member[0] {
+			return nil
+		}
+		values = append(values, member[1:len(member)-1])
+	}
+	return values
+}
+
+func marshalDocument(doc any) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
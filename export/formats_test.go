@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+)
+
+func checkFormatGolden(t *testing.T, filename string, content []byte) {
+	t.Helper()
+	goldenDir := filepath.Join("testdata", "golden", "formats")
+	if *testutil.Update {
+		if err := os.WriteFile(filepath.Join(goldenDir, filename), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	goldenFS := testutil.LoadTestdataFS(t, goldenDir, "/")
+	expected := testutil.ReadFixture(t, goldenFS, "/"+filename)
+	if string(expected) != string(content) {
+		t.Errorf("%s mismatch.\nExpected:\n%s\nGot:\n%s", filename, string(expected), string(content))
+	}
+}
+
+func TestWebTypes(t *testing.T) {
+	data, err := WebTypes(loadTestManifest(t), "my-package", "1.0.0")
+	if err != nil {
+		t.Fatalf("WebTypes: %v", err)
+	}
+	checkFormatGolden(t, "web-types.json", data)
+}
+
+func TestVSCodeCustomData(t *testing.T) {
+	data, err := VSCodeCustomData(loadTestManifest(t))
+	if err != nil {
+		t.Fatalf("VSCodeCustomData: %v", err)
+	}
+	checkFormatGolden(t, "vscode.html-custom-data.json", data)
+}
+
+func TestTagNameMap(t *testing.T) {
+	checkFormatGolden(t, "custom-elements.d.ts", TagNameMap(loadTestManifest(t), "types"))
+}
+
+func TestRelativeModule(t *testing.T) {
+	tests := []struct {
+		dir, modulePath, want string
+	}{
+		{".", "elements/my-button.js", "./elements/my-button.js"},
+		{"", "elements/my-button.js", "./elements/my-button.js"},
+		{"types", "elements/my-button.js", "../elements/my-button.js"},
+		{"elements", "elements/my-button.js", "./my-button.js"},
+		{".", "src/my-button.ts", "./src/my-button.js"},
+	}
+	for _, tt := range tests {
+		if got := relativeModule(tt.dir, tt.modulePath); got != tt.want {
+			t.Errorf("relativeModule(%q, %q) = %q, want %q", tt.dir, tt.modulePath, got, tt.want)
+		}
+	}
+}
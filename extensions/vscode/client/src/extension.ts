@@ -116,6 +116,9 @@ export function activate(context: vscode.ExtensionContext) {
         { scheme: 'file', language: 'ejs' },
         { scheme: 'file', language: 'typescript' },
         { scheme: 'file', language: 'javascript' },
+        { scheme: 'file', pattern: '**/.config/cem.{yaml,yml,json,jsonc}' },
+        { scheme: 'file', pattern: '**/.cem.{yaml,yml,json,jsonc}' },
+        { scheme: 'file', pattern: '**/package.json' },
       ],
       initializationOptions: {
         debugLogging,
@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"maps"
+	"path"
+	"slices"
+
+	M "bennypowers.dev/cem/manifest"
+)
+
+// applyConfigCategories adds the categories from the generate.categories
+// config to each custom element in the module whose tag name matches one of
+// the category's globs. Categories from `@category` JSDoc tags come first,
+// followed by config categories in name order.
+func applyConfigCategories(module *M.Module, categories map[string][]string) {
+	if len(categories) == 0 {
+		return
+	}
+	names := slices.Sorted(maps.Keys(categories))
+	for _, decl := range module.Declarations {
+		ced, ok := decl.(*M.CustomElementDeclaration)
+		if !ok || ced.TagName == "" {
+			continue
+		}
+		for _, name := range names {
+			if slices.Contains(ced.Categories, name) {
+				continue
+			}
+			if slices.ContainsFunc(categories[name], func(glob string) bool {
+				matched, _ := path.Match(glob, ced.TagName)
+				return matched
+			}) {
+				ced.Categories = append(ced.Categories, name)
+			}
+		}
+	}
+}
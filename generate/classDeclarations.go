@@ -22,6 +22,7 @@ import (
 	"slices"
 
 	"bennypowers.dev/cem/generate/jsdoc"
+	"bennypowers.dev/cem/internal/languages"
 	M "bennypowers.dev/cem/manifest"
 	Q "bennypowers.dev/cem/internal/treesitter"
 
@@ -35,23 +36,25 @@ func (mp *ModuleProcessor) generateClassDeclarationParsed(
 	className string,
 ) (*ParsedClass, error) {
 	_, hasCustomElementDecorator := captures["customElement"]
-	isHTMLElement := false
 
-	// Check if superclass is HTMLElement - try new expression capture first
+	// Find the superclass - try new expression capture first
+	var superclassName string
 	exprNodes, hasExpr := captures["superclass.expression"]
 	if hasExpr && len(exprNodes) > 0 {
 		exprNode := Q.GetDescendantById(mp.root, exprNodes[0].NodeId)
 		if exprNode != nil {
-			superclassName, _ := mp.parseHeritageExpression(exprNode)
-			isHTMLElement = superclassName == "HTMLElement"
+			superclassName, _ = mp.parseHeritageExpression(exprNode)
 		}
 	} else {
 		// Fallback to old superclass.name capture
 		superClassNameNodes, hasSuperClass := captures["superclass.name"]
 		if hasSuperClass {
-			isHTMLElement = superClassNameNodes[0].Text == "HTMLElement"
+			superclassName = superClassNameNodes[0].Text
 		}
 	}
+	isHTMLElement := superclassName == "HTMLElement"
+	// FAST elements may be defined without a decorator, by FASTElement.define()
+	isFASTElement := superclassName == fastElementBase
 
 	// Check if JSDoc contains @element, @tagName, or @customElement tags
 	hasJSDocElementTag := false
@@ -63,7 +66,7 @@ func (mp *ModuleProcessor) generateClassDeclarationParsed(
 		hasJSDocElementTag = has
 	}
 
-	isCustomElement := hasCustomElementDecorator || isHTMLElement || hasJSDocElementTag
+	isCustomElement := hasCustomElementDecorator || isHTMLElement || isFASTElement || hasJSDocElementTag
 	classDeclarationCaptures, hasClassDeclaration := captures["class.declaration"]
 	if !hasClassDeclaration || len(classDeclarationCaptures) <= 0 {
 		return nil, NewError("could not find class declaration")
@@ -166,6 +169,7 @@ func (mp *ModuleProcessor) generateCommonClassDeclaration(
 		}
 
 		if superclassName != "" {
+			name := superclassName
 			pkg := ""
 			module := ""
 			switch superclassName {
@@ -182,14 +186,14 @@ func (mp *ModuleProcessor) generateCommonClassDeclaration(
 			case "ReactiveElement":
 				pkg = "@lit/reactive-element"
 			default:
-				// Check if superclass is imported from another module
-				if imp, found := mp.importBindingToSpecMap[superclassName]; found {
-					module = mp.resolveImportSpec(imp.spec)
-				}
+				// Check if superclass is imported from another module or package
+				name, pkg, module = mp.resolveImportReference(superclassName)
 			}
-			declaration.Superclass = M.NewReference(superclassName, pkg, module)
+			declaration.Superclass = M.NewReference(name, pkg, module)
 		}
 
+		declaration.Implements = mp.implementedInterfaces(classDeclarationNode)
+
 		// Store mixins if present
 		if len(mixins) > 0 {
 			declaration.Mixins = mixins
@@ -254,16 +258,13 @@ func (mp *ModuleProcessor) generateHTMLElementClassDeclaration(
 			CustomElement: true,
 		},
 	}
+	declaration.Platform = mp.platformOptions(classDeclarationNode)
 
 	err = mp.step("Processing observedAttributes", 1, func() error {
-		for _, name := range captures["observedAttributes.attributeName"] {
-			declaration.CustomElement.Attributes = append(declaration.CustomElement.Attributes, M.Attribute{
-				StartByte: name.StartByte,
-				FullyQualified: M.FullyQualified{
-					Name: name.Text,
-				},
-			})
-		}
+		declaration.CustomElement.Attributes = append(
+			declaration.CustomElement.Attributes,
+			observedAttributes(captures["observedAttributes.attributeName"], declaration.Members)...,
+		)
 		return nil
 	})
 	if err != nil {
@@ -311,9 +312,12 @@ func (mp *ModuleProcessor) generateLitElementClassDeclaration(
 			CustomElement: true,
 		},
 	}
+	declaration.Platform = mp.platformOptions(classDeclarationNode)
 
 	if tagName := mp.resolveTagName(captures); tagName != "" {
 		declaration.TagName = tagName
+	} else if tagName := mp.fastDefinedTagName(className); tagName != "" {
+		declaration.TagName = tagName
 	}
 
 	declaration.CustomElement.Attributes = A.Chain(func(member M.ClassMember) []M.Attribute {
@@ -365,13 +369,18 @@ func (mp *ModuleProcessor) generateLitElementClassDeclaration(
 				htmlSource := capinfo.Text
 				node := Q.GetDescendantById(mp.root, nodeId)
 				if node != nil {
+					// Only templates of registered syntaxes, like Lit's html``
+					syntax, ok := languages.TemplateSyntaxForNode(node, mp.code)
+					if !ok {
+						continue
+					}
 					offset := node.StartByte() + 1
 					if len(htmlSource) > 1 && htmlSource[0] == '`' && htmlSource[len(htmlSource)-1] == '`' {
 						htmlSource = htmlSource[1 : len(htmlSource)-1]
 					}
 
 					if htmlSource != "" {
-						htmlSlots, htmlParts, htmlErr := mp.processRenderTemplate(htmlSource, uint(offset))
+						htmlSlots, htmlParts, htmlErr := mp.processRenderTemplate(syntax, htmlSource, uint(offset))
 						if htmlErr != nil {
 							errs = errors.Join(errs, fmt.Errorf("module %q: %w", mp.file, htmlErr))
 						}
@@ -401,6 +410,45 @@ func (mp *ModuleProcessor) generateLitElementClassDeclaration(
 	return declaration, alias, errs
 }
 
+// implementedInterfaces returns references to the interfaces named in a
+// class's implements clause, e.g. `class A extends B implements C, D<E>`,
+// resolved like the superclass
+func (mp *ModuleProcessor) implementedInterfaces(classDeclarationNode *ts.Node) []M.Reference {
+	var refs []M.Reference
+	cursor := classDeclarationNode.Walk()
+	defer cursor.Close()
+	for _, heritage := range classDeclarationNode.NamedChildren(cursor) {
+		if heritage.Kind() != "class_heritage" {
+			continue
+		}
+		heritageCursor := heritage.Walk()
+		defer heritageCursor.Close()
+		for _, clause := range heritage.NamedChildren(heritageCursor) {
+			if clause.Kind() != "implements_clause" {
+				continue
+			}
+			clauseCursor := clause.Walk()
+			defer clauseCursor.Close()
+			for _, typeNode := range clause.NamedChildren(clauseCursor) {
+				// Drop type arguments, e.g. Comparable for Comparable<Item>
+				if typeNode.Kind() == "generic_type" {
+					if nameNode := typeNode.ChildByFieldName("name"); nameNode != nil {
+						typeNode = *nameNode
+					}
+				}
+				binding := typeNode.Utf8Text(mp.code)
+				if binding == "" {
+					continue
+				}
+				var ref M.Reference
+				ref.Name, ref.Package, ref.Module = mp.resolveImportReference(binding)
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
 // parseHeritageExpression walks a heritage expression to extract the base superclass
 // and any mixins applied. Handles patterns like:
 // - LitElement → returns ("LitElement", nil)
@@ -419,21 +467,20 @@ func (mp *ModuleProcessor) parseHeritageExpression(node *ts.Node) (superclass st
 		return node.Utf8Text(mp.code), nil
 
 	case "call_expression":
+		// FAST's form-associated base: FASTElement.from(HTMLInputElement)
+		if object, method := mp.calledMethod(node); method == "from" && object.Utf8Text(mp.code) == fastElementBase {
+			return fastElementBase, nil
+		}
+
 		// Mixin pattern: MixinName(Base)
 		// Extract the function name as the mixin
 		functionNode := node.ChildByFieldName("function")
 		if functionNode != nil && functionNode.Kind() == "identifier" {
 			mixinName := functionNode.Utf8Text(mp.code)
 
-			// Create reference for the mixin
+			// Create reference for the mixin, checking if it is imported
 			var mixinRef M.Reference
-			mixinRef.Name = mixinName
-
-			// Check if mixin is imported
-			if imp, found := mp.importBindingToSpecMap[mixinName]; found {
-				module := mp.resolveImportSpec(imp.spec)
-				mixinRef.Module = module
-			}
+			mixinRef.Name, mixinRef.Package, mixinRef.Module = mp.resolveImportReference(mixinName)
 
 			// Recursively process the first argument to get base and any nested mixins
 			argsNode := node.ChildByFieldName("arguments")
@@ -35,6 +35,7 @@ var ignoredStaticFieldsHTML = S.NewSet(
 )
 
 var ignoredStaticFieldsLit = S.NewSet(
+	"properties",
 	"shadowRootOptions",
 	"styles",
 )
@@ -61,7 +62,7 @@ var ignoredInstanceMethodsLit = S.NewSet(
 
 func isIgnoredMember(memberName string, superclass string, isStatic bool) bool {
 	switch superclass {
-	case "HTMLElement":
+	case "HTMLElement", fastElementBase:
 		if (isStatic && ignoredStaticFieldsHTML.Has(memberName)) ||
 			(!isStatic && ignoredInstanceMethodsHTML.Has(memberName)) {
 			return true
@@ -79,11 +80,76 @@ func isIgnoredMember(memberName string, superclass string, isStatic bool) bool {
 	return false
 }
 
-func isPropertyField(captures Q.CaptureMap) bool {
+// inferConventionalPrivacy returns the privacy implied by a member's name.
+// ECMAScript `#private` names are always private; `_underscore` names are
+// private only when underscorePrivate is enabled. Returns "" when the name
+// carries no privacy convention.
+func inferConventionalPrivacy(memberName string, underscorePrivate bool) M.Privacy {
+	switch {
+	case strings.HasPrefix(memberName, "#"):
+		return M.Private
+	case underscorePrivate && strings.HasPrefix(memberName, "_"):
+		return M.Private
+	default:
+		return ""
+	}
+}
+
+// applyConventionalPrivacy marks members whose names imply privacy as private,
+// and ensures non-public members never produce attributes. Explicit
+// accessibility modifiers win over the underscore convention, but `#private`
+// members are always private.
+func applyConventionalPrivacy(member M.ClassMember, underscorePrivate bool) {
+	switch m := member.(type) {
+	case *M.CustomElementField:
+		privacy := inferConventionalPrivacy(m.Name, underscorePrivate)
+		if privacy == "" {
+			return
+		}
+		if m.Privacy == "" || strings.HasPrefix(m.Name, "#") {
+			m.Privacy = privacy
+		}
+		if m.Privacy != M.Public {
+			m.Attribute = ""
+			m.Reflects = false
+		}
+	case *M.ClassMethod:
+		privacy := inferConventionalPrivacy(m.Name, underscorePrivate)
+		if privacy == "" {
+			return
+		}
+		if m.Privacy == "" || strings.HasPrefix(m.Name, "#") {
+			m.Privacy = privacy
+		}
+	}
+}
+
+// underscorePrivate reports whether the project opted into treating
+// `_underscore` members as private.
+func (mp *ModuleProcessor) underscorePrivate() bool {
+	if mp.ctx == nil {
+		return false
+	}
+	cfg, err := mp.ctx.Config()
+	if err != nil || cfg == nil {
+		return false
+	}
+	return cfg.Generate.UnderscorePrivate
+}
+
+// isCustomElementBase reports whether the fields of classes which extend
+// superclass may be reactive properties with attributes
+func isCustomElementBase(superclass string) bool {
+	return superclass == "HTMLElement" || superclass == "LitElement" || superclass == fastElementBase
+}
+
+// isPropertyField reports whether a field is decorated as a reactive
+// property: Lit's @property, or FAST's @attr on FAST elements
+func isPropertyField(captures Q.CaptureMap, superclass string) bool {
 	_, hasDecorators := captures["decorator.name"]
 	if hasDecorators {
 		for _, name := range captures["decorator.name"] {
-			if name.Text == "property" {
+			if name.Text == "property" || name.Text == "attr" && superclass == fastElementBase {
 				return true
 			}
 		}
@@ -148,6 +214,7 @@ func (mp ModuleProcessor) amendMethodWithJsdoc(captures Q.CaptureMap, method *M.
 
 func amendFieldWithPropertyConfigCaptures(captures Q.CaptureMap, field *M.CustomElementField) {
 	field.Attribute = strings.ToLower(field.Name)
+	amendFieldWithFASTAttrCaptures(captures, field)
 	bool, hasAttrBool := captures["field.attr.bool"]
 	name, hasAttrName := captures["field.attr.name"]
 	reflect, hasAttrReflect := captures["field.attr.reflect"]
@@ -184,8 +251,7 @@ func (mp *ModuleProcessor) createClassFieldFromAccessorMatch(
 
 	amendFieldTypeWithCaptures(captures, &field.ClassField, mp.logger)
 	amendFieldPrivacyWithCaptures(captures, &field.ClassField)
-	isCustomElement := classType == "HTMLElement" || classType == "LitElement"
-	isProperty := isCustomElement && !isStatic && isPropertyField(captures)
+	isProperty := isCustomElementBase(classType) && !isStatic && isPropertyField(captures, classType)
 
 	if isProperty {
 		amendFieldWithPropertyConfigCaptures(captures, &field)
@@ -221,8 +287,7 @@ func (mp *ModuleProcessor) createClassFieldFromConstructorParameterMatch(
 
 	// Constructor parameters on non-CE classes typically don't have @property decorators
 	// but we'll keep the logic for completeness
-	isCustomElement := superclass == "HTMLElement" || superclass == "LitElement"
-	isProperty := isCustomElement && !isStatic && isPropertyField(captures)
+	isProperty := isCustomElementBase(superclass) && !isStatic && isPropertyField(captures, superclass)
 
 	if isProperty {
 		amendFieldWithPropertyConfigCaptures(captures, &field)
@@ -273,8 +338,7 @@ func (mp *ModuleProcessor) createClassFieldFromFieldMatch(
 		field.Default = defaultStr
 	}
 
-	isCustomElement := superclass == "HTMLElement" || superclass == "LitElement"
-	isProperty := isCustomElement && !isStatic && isPropertyField(captures)
+	isProperty := isCustomElementBase(superclass) && !isStatic && isPropertyField(captures, superclass)
 
 	if isProperty {
 		amendFieldWithPropertyConfigCaptures(captures, &field)
@@ -329,7 +393,24 @@ func (mp *ModuleProcessor) getClassMembersFromClassDeclarationNode(
 		isStatic := len(captures["member.static"]) > 0
 		kind := getMemberKindFromCaptures(captures)
 		key := memberKey{name: memberName, kind: kind, static: isStatic}
-		if kind == "" || isIgnoredMember(memberName, superclass, isStatic) {
+		recordIR := func(skipped string) {
+			if mp.ir != nil {
+				mp.ir.members = append(mp.ir.members, memberIR{
+					className: className,
+					name:      memberName,
+					kind:      kind,
+					static:    isStatic,
+					skipped:   skipped,
+					captures:  captures,
+				})
+			}
+		}
+		if kind == "" {
+			recordIR("unrecognized member kind")
+			continue
+		}
+		if isIgnoredMember(memberName, superclass, isStatic) {
+			recordIR("built-in " + superclass + " member")
 			continue
 		}
 
@@ -339,9 +420,11 @@ func (mp *ModuleProcessor) getClassMembersFromClassDeclarationNode(
 			if err != nil {
 				errs = errors.Join(errs, err)
 			} else if ignored {
+				recordIR("@ignore tag")
 				continue
 			}
 		}
+		recordIR("")
 
 		// Debug: log all variant property processing
 		if memberName == "variant" {
@@ -452,8 +535,30 @@ func (mp *ModuleProcessor) getClassMembersFromClassDeclarationNode(
 		}
 	}
 
+	// Reactive properties declared without decorators, in static properties
+	if superclass == "LitElement" {
+		for _, property := range mp.litStaticProperties(classDeclarationNode) {
+			var field *M.CustomElementField
+			for _, kind := range []string{"field", "accessor"} {
+				if existing, ok := memberMap[memberKey{name: property.name, kind: kind}].(*M.CustomElementField); ok {
+					field = existing
+					break
+				}
+			}
+			if field == nil {
+				field = newLitStaticPropertyField(property)
+				memberMap[memberKey{name: property.name, kind: "field"}] = field
+			}
+			if err := mp.applyLitStaticProperty(field, property); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+	}
+
 	// Collect in stable order (optional: sort if you want)
+	underscorePrivate := mp.underscorePrivate()
 	for _, member := range memberMap {
+		applyConventionalPrivacy(member, underscorePrivate)
 		members = append(members, member)
 	}
 
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,39 +77,57 @@ func TestIsIgnoredMember(t *testing.T) {
 
 func TestIsPropertyField(t *testing.T) {
 	tests := []struct {
-		name     string
-		captures Q.CaptureMap
-		want     bool
+		name       string
+		captures   Q.CaptureMap
+		superclass string
+		want       bool
 	}{
 		{
 			"has property decorator",
 			Q.CaptureMap{"decorator.name": {{Text: "property"}}},
+			"LitElement",
 			true,
 		},
 		{
 			"has other decorator",
 			Q.CaptureMap{"decorator.name": {{Text: "state"}}},
+			"LitElement",
 			false,
 		},
 		{
 			"multiple decorators with property",
 			Q.CaptureMap{"decorator.name": {{Text: "state"}, {Text: "property"}}},
+			"LitElement",
 			true,
 		},
 		{
 			"no decorators key",
 			Q.CaptureMap{"field": {{Text: "myField"}}},
+			"LitElement",
+			false,
+		},
+		{
+			"FAST attr decorator",
+			Q.CaptureMap{"decorator.name": {{Text: "attr"}}},
+			"FASTElement",
+			true,
+		},
+		{
+			"attr decorator outside FAST",
+			Q.CaptureMap{"decorator.name": {{Text: "attr"}}},
+			"LitElement",
 			false,
 		},
 		{
 			"empty capture map",
 			Q.CaptureMap{},
+			"LitElement",
 			false,
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.want, isPropertyField(tc.captures))
+			assert.Equal(t, tc.want, isPropertyField(tc.captures, tc.superclass))
 		})
 	}
 }
@@ -138,3 +157,77 @@ func TestIsStaticToTypeFlag(t *testing.T) {
 	assert.Equal(t, "static", isStaticToTypeFlag(true))
 	assert.Equal(t, "instance", isStaticToTypeFlag(false))
 }
+
+func TestInferConventionalPrivacy(t *testing.T) {
+	tests := []struct {
+		name              string
+		memberName        string
+		underscorePrivate bool
+		want              M.Privacy
+	}{
+		{"ecmascript private", "#count", false, M.Private},
+		{"ecmascript private with underscore flag", "#count", true, M.Private},
+		{"underscore without flag", "_count", false, ""},
+		{"underscore with flag", "_count", true, M.Private},
+		{"plain name", "count", true, ""},
+		{"empty name", "", true, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, inferConventionalPrivacy(tc.memberName, tc.underscorePrivate))
+		})
+	}
+}
+
+func TestApplyConventionalPrivacy(t *testing.T) {
+	newField := func(name string, privacy M.Privacy) *M.CustomElementField {
+		field := &M.CustomElementField{Attribute: "attr", Reflects: true}
+		field.Name = name
+		field.Privacy = privacy
+		return field
+	}
+
+	t.Run("ecmascript private field drops attribute", func(t *testing.T) {
+		field := newField("#count", "")
+		applyConventionalPrivacy(field, false)
+		assert.Equal(t, M.Private, field.Privacy)
+		assert.Empty(t, field.Attribute)
+		assert.False(t, field.Reflects)
+	})
+
+	t.Run("underscore field kept public without flag", func(t *testing.T) {
+		field := newField("_count", "")
+		applyConventionalPrivacy(field, false)
+		assert.Empty(t, field.Privacy)
+		assert.Equal(t, "attr", field.Attribute)
+	})
+
+	t.Run("underscore field made private with flag", func(t *testing.T) {
+		field := newField("_count", "")
+		applyConventionalPrivacy(field, true)
+		assert.Equal(t, M.Private, field.Privacy)
+		assert.Empty(t, field.Attribute)
+	})
+
+	t.Run("explicit modifier wins over underscore convention", func(t *testing.T) {
+		field := newField("_count", M.Protected)
+		applyConventionalPrivacy(field, true)
+		assert.Equal(t, M.Protected, field.Privacy)
+		assert.Empty(t, field.Attribute)
+	})
+
+	t.Run("explicit public modifier keeps attribute", func(t *testing.T) {
+		field := newField("_count", M.Public)
+		applyConventionalPrivacy(field, true)
+		assert.Equal(t, M.Public, field.Privacy)
+		assert.Equal(t, "attr", field.Attribute)
+		assert.True(t, field.Reflects)
+	})
+
+	t.Run("ecmascript private method", func(t *testing.T) {
+		method := &M.ClassMethod{Kind: "method"}
+		method.Name = "#update"
+		applyConventionalPrivacy(method, false)
+		assert.Equal(t, M.Private, method.Privacy)
+	})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"bennypowers.dev/cem/internal/logging"
+	"bennypowers.dev/cem/internal/platform"
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Files written for each source file by the debug IR recorder, in the
+// directory <dir>/<source file>/
+const (
+	// DebugIRCapturesFile holds the tree-sitter captures matched for each
+	// class and member, as s-expressions
+	DebugIRCapturesFile = "captures.scm"
+	// DebugIRClassesFile holds the classes extracted from the captures
+	DebugIRClassesFile = "classes.json"
+	// DebugIRPreMergeFile holds the module as generated from this file alone
+	DebugIRPreMergeFile = "pre-merge.json"
+	// DebugIRPostMergeFile holds the module after resolving inheritance,
+	// re-exports, types, demos, and design tokens across the package
+	DebugIRPostMergeFile = "post-merge.json"
+)
+
+// SessionOption configures a GenerateSession
+type SessionOption func(*GenerateSession)
+
+// WithDebugIR writes each file's intermediate representations to dir, so
+// users can diagnose why a declaration or member is missing from the
+// manifest: the captures matched in the file, the classes extracted from
+// them, and the file's module before and after merging with the package.
+func WithDebugIR(dir string) SessionOption {
+	return func(gs *GenerateSession) {
+		gs.debugIR = &debugIRRecorder{
+			dir:   dir,
+			fs:    gs.setupCtx.FileSystem(),
+			files: make(map[string]string),
+		}
+	}
+}
+
+// debugIRRecorder writes intermediate representations for WithDebugIR
+type debugIRRecorder struct {
+	dir   string
+	fs    platform.FileSystem
+	mu    sync.Mutex
+	files map[string]string // module path -> source file, protected by mu
+}
+
+// moduleIR collects the intermediate representations of one module while it
+// is processed
+type moduleIR struct {
+	classes []*ParsedClass
+	members []memberIR
+}
+
+// memberIR is a class member matched by the member query, with the reason
+// it was skipped, if it was
+type memberIR struct {
+	className string
+	name      string
+	kind      string
+	static    bool
+	skipped   string
+	captures  Q.CaptureMap
+}
+
+// classIR is the JSON form of a ParsedClass
+type classIR struct {
+	Name          string                `json:"name"`
+	Alias         string                `json:"alias,omitempty"`
+	Declaration   M.Declaration         `json:"declaration,omitempty"`
+	CssProperties []M.CssCustomProperty `json:"cssProperties,omitempty"`
+	Error         string                `json:"error,omitempty"`
+}
+
+// recordModule writes the captures, classes, and unmerged module of a
+// processed file. root is the file's syntax tree, which the captures
+// refer to.
+func (r *debugIRRecorder) recordModule(file string, root *ts.Node, ir *moduleIR, module *M.Module) {
+	if r == nil || ir == nil {
+		return
+	}
+	r.write(file, DebugIRCapturesFile, []byte(ir.captures(root)))
+
+	classes := make([]classIR, 0, len(ir.classes))
+	for _, parsed := range ir.classes {
+		class := classIR{
+			Name:          parsed.Name,
+			Alias:         parsed.Alias,
+			Declaration:   parsed.CEMDeclaration,
+			CssProperties: parsed.CssProperties,
+		}
+		if parsed.CEMDeclaration == nil {
+			class.Error = "no declaration was generated for this class; see the module's errors"
+		}
+		classes = append(classes, class)
+	}
+	r.writeJSON(file, DebugIRClassesFile, classes)
+	if module != nil {
+		r.mu.Lock()
+		r.files[module.Path] = file
+		r.mu.Unlock()
+		r.writeJSON(file, DebugIRPreMergeFile, module)
+	}
+}
+
+// recordPackage writes each module of the generated package, alongside the
+// representations of the source file it was generated from
+func (r *debugIRRecorder) recordPackage(pkg *M.Package) {
+	if r == nil || pkg == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range pkg.Modules {
+		file, ok := r.files[pkg.Modules[i].Path]
+		if !ok {
+			file = pkg.Modules[i].Path
+		}
+		r.writeJSON(file, DebugIRPostMergeFile, &pkg.Modules[i])
+	}
+}
+
+// captures renders the captures of each class and member as s-expressions
+func (ir *moduleIR) captures(root *ts.Node) string {
+	var b strings.Builder
+	for _, parsed := range ir.classes {
+		fmt.Fprintf(&b, ";; class %s\n", parsed.Name)
+		writeCaptures(&b, root, parsed.Captures)
+		b.WriteString("\n")
+	}
+	for _, member := range ir.members {
+		static := ""
+		if member.static {
+			static = "static "
+		}
+		fmt.Fprintf(&b, ";; member %s.%s (%s%s)", member.className, member.name, static, member.kind)
+		if member.skipped != "" {
+			fmt.Fprintf(&b, " skipped: %s", member.skipped)
+		}
+		b.WriteString("\n")
+		writeCaptures(&b, root, member.captures)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// writeCaptures writes each capture's name and text, followed by the
+// s-expression of its node, in order of capture name
+func writeCaptures(b *strings.Builder, root *ts.Node, captures Q.CaptureMap) {
+	for _, name := range slices.Sorted(maps.Keys(captures)) {
+		for _, capture := range captures[name] {
+			text, _, _ := strings.Cut(capture.Text, "\n")
+			if len(text) > 80 {
+				text = text[:80] + "…"
+			}
+			fmt.Fprintf(b, "; @%s %q\n", name, text)
+			if node := Q.GetDescendantById(root, capture.NodeId); node != nil {
+				b.WriteString(node.ToSexp())
+				b.WriteString("\n")
+			}
+		}
+	}
+}
+
+func (r *debugIRRecorder) writeJSON(file, name string, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logging.Warning("debug IR: encoding %s for %s: %v", name, file, err)
+		return
+	}
+	r.write(file, name, append(data, '\n'))
+}
+
+func (r *debugIRRecorder) write(file, name string, data []byte) {
+	path := filepath.Join(r.dir, filepath.FromSlash(file), name)
+	if err := r.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logging.Warning("debug IR: creating directory for %s: %v", path, err)
+		return
+	}
+	if err := r.fs.WriteFile(path, data, 0644); err != nil {
+		logging.Warning("debug IR: writing %s: %v", path, err)
+	}
+}
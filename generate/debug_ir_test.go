@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate_test
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+	"testing/synctest"
+
+	G "bennypowers.dev/cem/generate"
+	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: small virtual workspace whose element has an ignored member, so the
+// debug IR shows both the kept and the skipped member
+
+// TestDebugIR verifies that each file's captures, classes, and pre- and
+// post-merge modules are written, and that skipped members say why.
+func TestDebugIR(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mapFS := platform.NewMapFileSystem(nil)
+		root := "/test-workspace"
+		dir := "/debug-ir"
+
+		mapFS.AddFile(root+"/package.json", `{"name": "test-package"}`, 0644)
+		mapFS.AddFile(root+"/src/my-button.ts", `/**
+ * A button
+ * @customElement my-button
+ */
+export class MyButton extends HTMLElement {
+  /** Whether the button is disabled */
+  disabled = false;
+
+  /**
+   * @ignore
+   */
+  internal = 0;
+}
+`, 0644)
+
+		workspace := W.NewFileSystemWorkspaceContext(root, W.WithFileSystem(mapFS))
+		require.NoError(t, workspace.Init())
+		cfg, err := workspace.Config()
+		require.NoError(t, err)
+		cfg.Generate.Files = []string{"src/my-button.ts"}
+
+		pkg, _, err := G.GeneratePackageWithDiagnostics(workspace, mapFS, G.WithDebugIR(dir))
+		require.NoError(t, err)
+		require.NotNil(t, pkg)
+
+		fileDir := path.Join(dir, "src/my-button.ts")
+
+		captures, err := mapFS.ReadFile(path.Join(fileDir, G.DebugIRCapturesFile))
+		require.NoError(t, err)
+		assert.Contains(t, string(captures), ";; class MyButton")
+		assert.Contains(t, string(captures), "(class_declaration")
+		assert.Contains(t, string(captures), ";; member MyButton.disabled (field)\n")
+		assert.Contains(t, string(captures), ";; member MyButton.internal (field) skipped: @ignore tag")
+
+		var classes []struct {
+			Name        string         `json:"name"`
+			Declaration map[string]any `json:"declaration"`
+		}
+		data, err := mapFS.ReadFile(path.Join(fileDir, G.DebugIRClassesFile))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &classes))
+		require.Len(t, classes, 1)
+		assert.Equal(t, "MyButton", classes[0].Name)
+		assert.Equal(t, "my-button", classes[0].Declaration["tagName"])
+
+		for _, name := range []string{G.DebugIRPreMergeFile, G.DebugIRPostMergeFile} {
+			var module struct {
+				Declarations []map[string]any `json:"declarations"`
+			}
+			data, err := mapFS.ReadFile(path.Join(fileDir, name))
+			require.NoError(t, err, "should write %s", name)
+			require.NoError(t, json.Unmarshal(data, &module))
+			require.Len(t, module.Declarations, 1, "%s should hold the element", name)
+			assert.Equal(t, "MyButton", module.Declarations[0]["name"])
+		}
+	})
+}
@@ -17,7 +17,10 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package generate
 
 import (
+	"path/filepath"
 	"testing"
+
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
 )
 
 // TestDemoDiscoverySkipping tests the demo discovery optimization logic
@@ -35,3 +38,41 @@ func TestDemoDiscoverySkipping(t *testing.T) {
 
 	t.Log("Demo discovery optimization functions are properly defined")
 }
+
+// TestDemoDiscoveryConventions_Watch tests that watch mode treats demo
+// conventions as demo discovery configuration
+func TestDemoDiscoveryConventions_Watch(t *testing.T) {
+	ctx := testworkspace.NewMapWorkspaceContext(t, filepath.Join("demodiscovery", "testdata", "conventions"))
+	if err := ctx.Init(); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	ws := &WatchSession{ctx: ctx}
+
+	for path, expected := range map[string]bool{
+		"/src/conv-button/demo/new.html":       true,
+		"/src/conv-card/conv-card.demo.html":   true,
+		"/src/conv-button/conv-button.ts":      false,
+		"/src/conv-button/examples/sizes.html": false,
+	} {
+		if got := ws.matchesDemoGlobs(path); got != expected {
+			t.Errorf("matchesDemoGlobs(%q) = %v, want %v", path, got, expected)
+		}
+	}
+
+	if ws.shouldSkipDemoDiscovery() {
+		t.Error("Expected demo discovery to run on the first run with only conventions configured")
+	}
+	ws.updateDemoDiscoveryState()
+	if !ws.shouldSkipDemoDiscovery() {
+		t.Error("Expected demo discovery to be skipped when nothing changed")
+	}
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Generate.DemoDiscovery.Conventions = append(cfg.Generate.DemoDiscovery.Conventions, "stories/*.html")
+	if ws.shouldSkipDemoDiscovery() {
+		t.Error("Expected demo discovery to run after the conventions changed")
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package demodiscovery
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	C "bennypowers.dev/cem/cmd/config"
+	"bennypowers.dev/cem/internal/logging"
+	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/types"
+)
+
+// Enabled reports whether demo discovery is configured, either with a file
+// glob or with filesystem conventions.
+func Enabled(cfg C.DemoDiscoveryConfig) bool {
+	return cfg.FileGlob != "" || len(cfg.Conventions) > 0
+}
+
+// ConventionGlobs renders the configured conventions for one element into
+// globs relative to the package root. Conventions are relative to the
+// directory of the element's module, and may use the template placeholders
+// {{.tag}} (the element's tag name) and {{.module}} (the module's file name
+// without extension), along with the demo URL template functions.
+func ConventionGlobs(
+	conventions []string,
+	modulePath string,
+	tagName string,
+	tagAliases map[string]string,
+) ([]string, error) {
+	moduleDir := filepath.Dir(modulePath)
+	data := map[string]string{
+		"tag":    tagName,
+		"module": strings.TrimSuffix(filepath.Base(modulePath), filepath.Ext(modulePath)),
+	}
+
+	globs := make([]string, 0, len(conventions))
+	for _, convention := range conventions {
+		if convention == "" {
+			continue
+		}
+		pattern := convention
+		if strings.Contains(convention, "{{") {
+			tmpl, err := cache.getOrCreateTemplate(convention, tagAliases)
+			if err != nil {
+				return nil, fmt.Errorf("invalid demo discovery convention %q: %w", convention, err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return nil, fmt.Errorf("failed to execute demo discovery convention %q: %w", convention, err)
+			}
+			pattern = buf.String()
+		}
+		globs = append(globs, filepath.Join(moduleDir, filepath.FromSlash(pattern)))
+	}
+	return globs, nil
+}
+
+var templateActionRe = regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+// ConventionWatchGlob returns a glob matching every file which the convention
+// could discover for any element, for use when watching for new demo files.
+func ConventionWatchGlob(convention string) string {
+	return "**/" + templateActionRe.ReplaceAllString(convention, "*")
+}
+
+// conventionDemoFiles finds the demo files for an element by convention.
+func conventionDemoFiles(
+	ctx types.WorkspaceContext,
+	cfg *C.CemConfig,
+	modulePath string,
+	tagName string,
+	tagAliases map[string]string,
+) ([]string, error) {
+	globs, err := ConventionGlobs(cfg.Generate.DemoDiscovery.Conventions, modulePath, tagName, tagAliases)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, glob := range globs {
+		matches, err := ctx.Glob(glob)
+		if err != nil {
+			logging.Debug("demo discovery convention glob: %v", err)
+		}
+		for _, match := range matches {
+			if filepath.Ext(match) == ".html" {
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+// devServerRoute returns the URL the dev server serves a demo file at.
+func devServerRoute(ctx types.WorkspaceContext, demoPath string) string {
+	processedPath := demoPath
+	if root := ctx.Root(); root != "" {
+		processedPath = strings.TrimPrefix(demoPath, root)
+	}
+	urlPath := filepath.ToSlash(processedPath)
+	if !strings.HasPrefix(urlPath, "/") {
+		urlPath = "/" + urlPath
+	}
+	return urlPath
+}
+
+// normalizeDemoURL reduces a demo URL to a comparable path, so that an
+// absolute URL, a root-relative dev server route, and a URL relative to the
+// module all compare equal when they point at the same demo page. The host is
+// ignored, as are a trailing "index.html", ".html" extension, or slash.
+func normalizeDemoURL(rawURL string, moduleDir string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	p := u.Path
+	if u.Host == "" && !strings.HasPrefix(p, "/") {
+		p = path.Join("/", filepath.ToSlash(moduleDir), p)
+	}
+	p = strings.TrimSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/index.html")
+	p = strings.TrimSuffix(p, ".html")
+	return strings.TrimSuffix(p, "/")
+}
+
+// addDemo adds a discovered demo to an element, merging it into an existing
+// demo for the same page, e.g. one declared with a JSDoc `@demo` tag. Demos
+// match when their normalized URLs or their source files are the same.
+func addDemo(ce *M.CustomElementDeclaration, demo M.Demo, moduleDir string) {
+	normalized := normalizeDemoURL(demo.URL, moduleDir)
+	for i := range ce.Demos {
+		existing := &ce.Demos[i]
+		sameSource := existing.Source != nil && demo.Source != nil && existing.Source.Href == demo.Source.Href
+		if !sameSource && normalizeDemoURL(existing.URL, moduleDir) != normalized {
+			continue
+		}
+		if existing.Description == "" {
+			existing.Description = demo.Description
+		}
+		if existing.Source == nil {
+			existing.Source = demo.Source
+		}
+		return
+	}
+	ce.Demos = append(ce.Demos, demo)
+}
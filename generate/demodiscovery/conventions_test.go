@@ -0,0 +1,123 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package demodiscovery
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	M "bennypowers.dev/cem/manifest"
+)
+
+func TestDiscoverDemos_Conventions(t *testing.T) {
+	ctx := testworkspace.NewMapWorkspaceContext(t, filepath.Join("testdata", "conventions"))
+	if err := ctx.Init(); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	var module M.Module
+	if err := json.Unmarshal(testutil.LoadFixtureFile(t, filepath.Join("conventions", "module.json")), &module); err != nil {
+		t.Fatalf("Failed to unmarshal module JSON: %v", err)
+	}
+
+	// No file glob: demos are found only by convention. demo/index.html and
+	// demo/sizes.html merge into the element's @demo entries, demo/card.html
+	// declares a different element, and conv-button.demo.html falls back to
+	// its dev server route.
+	if err := DiscoverDemos(ctx, nil, &module, nil, DemoMap{}, ctx.FileSystem()); err != nil {
+		t.Fatalf("DiscoverDemos failed: %v", err)
+	}
+
+	ce, ok := module.Declarations[0].(*M.CustomElementDeclaration)
+	if !ok {
+		t.Fatalf("Expected a custom element declaration, got %T", module.Declarations[0])
+	}
+
+	var expected []M.Demo
+	if err := json.Unmarshal(testutil.LoadFixtureFile(t, filepath.Join("conventions", "expected.json")), &expected); err != nil {
+		t.Fatalf("Failed to unmarshal expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(ce.Demos, expected) {
+		actual, _ := json.MarshalIndent(ce.Demos, "", "  ")
+		t.Errorf("Demos mismatch, got:\n%s", actual)
+	}
+}
+
+// Inline: pure function, table-driven
+func TestConventionGlobs(t *testing.T) {
+	globs, err := ConventionGlobs(
+		[]string{"demo/*.html", "{{.module}}.demo.html", "", "demos/{{.tag | alias}}/*.html"},
+		"src/rh-button/rh-button.js",
+		"rh-button",
+		map[string]string{"rh-button": "button"},
+	)
+	if err != nil {
+		t.Fatalf("ConventionGlobs failed: %v", err)
+	}
+	expected := []string{
+		filepath.Join("src", "rh-button", "demo", "*.html"),
+		filepath.Join("src", "rh-button", "rh-button.demo.html"),
+		filepath.Join("src", "rh-button", "demos", "button", "*.html"),
+	}
+	if !reflect.DeepEqual(globs, expected) {
+		t.Errorf("got %v, want %v", globs, expected)
+	}
+
+	if _, err := ConventionGlobs([]string{"{{.module"}, "src/x.js", "x-x", nil); err == nil {
+		t.Error("Expected an error for an invalid convention template")
+	}
+}
+
+// Inline: pure function, table-driven
+func TestConventionWatchGlob(t *testing.T) {
+	tests := []struct {
+		convention string
+		expected   string
+	}{
+		{"demo/*.html", "**/demo/*.html"},
+		{"{{.module}}.demo.html", "**/*.demo.html"},
+		{"demos/{{.tag | alias}}/*.html", "**/demos/*/*.html"},
+	}
+	for _, tt := range tests {
+		if got := ConventionWatchGlob(tt.convention); got != tt.expected {
+			t.Errorf("ConventionWatchGlob(%q) = %q, want %q", tt.convention, got, tt.expected)
+		}
+	}
+}
+
+// Inline: pure function, table-driven
+func TestNormalizeDemoURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://ux.example.com/src/x/demo/", "/src/x/demo"},
+		{"/src/x/demo/index.html", "/src/x/demo"},
+		{"demo/sizes.html", "/src/x/demo/sizes"},
+		{"./demo/sizes/", "/src/x/demo/sizes"},
+		{"https://example.com/src/x/demo/sizes.html", "/src/x/demo/sizes"},
+	}
+	for _, tt := range tests {
+		if got := normalizeDemoURL(tt.url, "src/x"); got != tt.expected {
+			t.Errorf("normalizeDemoURL(%q) = %q, want %q", tt.url, got, tt.expected)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -305,6 +306,16 @@ func (c *templateCache) getOrCreatePattern(patternStr string) (*urlpattern.URLPa
 func ValidateDemoDiscoveryConfig(cfg *C.CemConfig, tagAliases map[string]string) error {
 	demoConfig := cfg.Generate.DemoDiscovery
 
+	// Validate convention templates
+	for _, convention := range demoConfig.Conventions {
+		if !strings.Contains(convention, "{{") {
+			continue
+		}
+		if _, err := cache.getOrCreateTemplate(convention, tagAliases); err != nil {
+			return fmt.Errorf("invalid demo discovery convention %q: %w\n\nConventions are globs relative to the element's module directory, and may use the placeholders {{.tag}} and {{.module}}. Example: \"{{.module}}.demo.html\"", convention, err)
+		}
+	}
+
 	// Skip validation if demo discovery is not configured
 	if demoConfig.URLPattern == "" && demoConfig.URLTemplate == "" {
 		return nil
@@ -439,7 +450,10 @@ func resolveDemoSourceURL(cfg *C.CemConfig, demoPath string) (string, error) {
 	return base.ResolveReference(rel).String(), nil
 }
 
-// DiscoverDemos attaches demos (indexed by tag name) to custom element declarations.
+// DiscoverDemos attaches demos (indexed by tag name, or found by convention
+// next to the element's module) to custom element declarations. A demo with
+// the same URL as one the element already has, e.g. from a JSDoc @demo tag,
+// is merged into it.
 func DiscoverDemos(
 	ctx types.WorkspaceContext,
 	tagAliases map[string]string,
@@ -461,6 +475,31 @@ func DiscoverDemos(
 		}
 		tagName := ce.TagName
 		demoFiles := demoMap[tagName]
+
+		// Files found by convention are associated with the element by their
+		// location, unless they explicitly declare a different element.
+		conventionFiles := make(map[string]bool)
+		if len(cfg.Generate.DemoDiscovery.Conventions) > 0 {
+			files, err := conventionDemoFiles(ctx, cfg, module.Path, tagName, tagAliases)
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+			explicitFiles := make(map[string]bool, len(demoFiles))
+			for _, file := range demoFiles {
+				explicitFiles[file] = true
+			}
+			demoFiles = slices.Clone(demoFiles)
+			for _, file := range files {
+				if conventionFiles[file] {
+					continue
+				}
+				conventionFiles[file] = true
+				if !explicitFiles[file] {
+					demoFiles = append(demoFiles, file)
+				}
+			}
+		}
+
 		moduleDir := filepath.Dir(module.Path)
 		sort.SliceStable(demoFiles, func(i, j int) bool {
 			iDir := filepath.Dir(demoFiles[i])
@@ -492,6 +531,10 @@ func DiscoverDemos(
 				continue
 			}
 
+			if conventionFiles[demoPath] && len(metadata.DemoFor) > 0 && !slices.Contains(metadata.DemoFor, tagName) {
+				continue
+			}
+
 			var demoUrl string
 
 			// Use explicit URL from microdata if available
@@ -504,6 +547,10 @@ func DiscoverDemos(
 					errs = errors.Join(errs, err)
 					continue
 				}
+				if fallbackUrl == "" && conventionFiles[demoPath] {
+					// Demos found by convention fall back to their dev server route
+					fallbackUrl = devServerRoute(ctx, demoPath)
+				}
 				if fallbackUrl == "" {
 					// No URL pattern configured, skip this demo
 					logging.Warning("No URL configured for demo %q and no URLPattern fallback available", demoPath)
@@ -527,7 +574,7 @@ func DiscoverDemos(
 					Href: href,
 				},
 			}
-			ce.Demos = append(ce.Demos, demo)
+			addDemo(ce, demo, moduleDir)
 		}
 	}
 	return errs
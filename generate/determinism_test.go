@@ -0,0 +1,68 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"context"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+// generateWithSchedule generates a fixture's manifest with the given worker
+// count and seed
+func generateWithSchedule(t *testing.T, fixture string, workers int, seed int64) string {
+	t.Helper()
+	ctx := setupTestContext(t, fixture)
+	session, err := NewGenerateSession(ctx, platform.NewOSFileSystem(), WithSeed(seed))
+	require.NoError(t, err)
+	defer session.Close()
+	session.SetMaxWorkers(workers)
+
+	pkg, err := session.GenerateFullManifest(context.Background())
+	require.NoError(t, err)
+	manifest, err := M.SerializeToString(pkg)
+	require.NoError(t, err)
+	return manifest
+}
+
+// TestGenerate_Deterministic generates each fixture under several schedules,
+// and diffs the manifests: they must be byte-identical, so that CI pipelines
+// can key caches on them
+func TestGenerate_Deterministic(t *testing.T) {
+	schedules := []struct {
+		workers int
+		seed    int64
+	}{
+		{workers: 0, seed: 0}, // generate twice with the default schedule
+		{workers: 1, seed: 1},
+		{workers: 3, seed: 7},
+		{workers: 8, seed: 42},
+	}
+
+	for _, fixture := range []string{"../examples/kitchen-sink", "../examples/intermediate"} {
+		t.Run(fixture, func(t *testing.T) {
+			want := generateWithSchedule(t, fixture, 0, 0)
+			for _, schedule := range schedules {
+				got := generateWithSchedule(t, fixture, schedule.workers, schedule.seed)
+				require.Equal(t, want, got, "manifest changed with %d workers and seed %d", schedule.workers, schedule.seed)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// maxSyntaxDiagnostics caps the syntax errors reported for one file, since a
+// single typo can cascade into many error nodes
+const maxSyntaxDiagnostics = 10
+
+// Diagnostic is a problem in one source file which generation tolerates,
+// such as a syntax error. Declarations from the parseable portions of the
+// file are still emitted. Line and Column are 1-based, and zero when the
+// problem concerns the whole file.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    uint   `json:"line,omitempty"`
+	Column  uint   `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+func (d *Diagnostic) Error() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// syntaxDiagnostics reports the ERROR and MISSING nodes of a parsed file.
+// Nodes inside an ERROR node are not reported separately.
+func syntaxDiagnostics(file string, root *ts.Node) []*Diagnostic {
+	if root == nil || !root.HasError() {
+		return nil
+	}
+	var diagnostics []*Diagnostic
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		if len(diagnostics) >= maxSyntaxDiagnostics {
+			return
+		}
+		var message string
+		switch {
+		case node.IsMissing():
+			message = fmt.Sprintf("syntax error: missing %q", node.Kind())
+		case node.IsError():
+			message = "syntax error"
+		case !node.HasError():
+			return
+		default:
+			for i := range node.ChildCount() {
+				walk(node.Child(i))
+			}
+			return
+		}
+		position := node.StartPosition()
+		diagnostics = append(diagnostics, &Diagnostic{
+			File:    file,
+			Line:    position.Row + 1,
+			Column:  position.Column + 1,
+			Message: message,
+		})
+	}
+	walk(root)
+	return diagnostics
+}
+
+// splitDiagnostics separates the tolerated per-file diagnostics in err from
+// the errors which should fail generation
+func splitDiagnostics(err error) (diagnostics []Diagnostic, fatal error) {
+	for _, e := range flattenJoined(err) {
+		if d, ok := e.(*Diagnostic); ok {
+			diagnostics = append(diagnostics, *d)
+		} else {
+			fatal = errors.Join(fatal, e)
+		}
+	}
+	sortDiagnostics(diagnostics)
+	return diagnostics, fatal
+}
+
+// flattenJoined recursively flattens errors joined with errors.Join
+func flattenJoined(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, flattenJoined(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+func sortDiagnostics(diagnostics []Diagnostic) {
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+}
+
+// Diagnostics returns the per-file problems which the most recent generation
+// tolerated, sorted by file and position
+func (gs *GenerateSession) Diagnostics() []Diagnostic {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return slices.Clone(gs.diagnostics)
+}
+
+// setDiagnostics records the diagnostics of a generation. When files is
+// non-nil, only the diagnostics of those files are replaced, as after
+// incremental processing.
+func (gs *GenerateSession) setDiagnostics(diagnostics []Diagnostic, files []string) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if files != nil {
+		kept := slices.DeleteFunc(slices.Clone(gs.diagnostics), func(d Diagnostic) bool {
+			return slices.Contains(files, d.File)
+		})
+		diagnostics = append(kept, diagnostics...)
+		sortDiagnostics(diagnostics)
+	}
+	gs.diagnostics = diagnostics
+}
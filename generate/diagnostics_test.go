@@ -0,0 +1,68 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate_test
+
+import (
+	"testing"
+	"testing/synctest"
+
+	G "bennypowers.dev/cem/generate"
+	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: small virtual workspace, verifying tolerated syntax errors
+
+// TestGenerateWithSyntaxErrors verifies that a syntax error in one file is
+// reported as a diagnostic, while declarations from the parseable portions of
+// the file and from other files are still generated.
+func TestGenerateWithSyntaxErrors(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mapFS := platform.NewMapFileSystem(nil)
+		root := "/test-workspace"
+
+		mapFS.AddFile(root+"/package.json", `{"name": "test-package"}`, 0644)
+		mapFS.AddFile(root+"/.config/cem.yaml", "generate:\n  files:\n    - src/*.ts\n", 0644)
+		mapFS.AddFile(root+"/src/good-element.ts", `/** @customElement good-element */
+export class GoodElement extends HTMLElement {}
+`, 0644)
+		mapFS.AddFile(root+"/src/broken-element.ts", `/** @customElement broken-element */
+export class BrokenElement extends HTMLElement {}
+
+export function broken( {
+`, 0644)
+
+		workspace := W.NewFileSystemWorkspaceContext(root, W.WithFileSystem(mapFS))
+		require.NoError(t, workspace.Init())
+
+		manifestStr, diagnostics, err := G.GenerateWithDiagnostics(workspace, mapFS)
+		require.NoError(t, err, "syntax errors should not fail generation")
+		require.NotNil(t, manifestStr)
+
+		assert.Contains(t, *manifestStr, `"good-element"`)
+		assert.Contains(t, *manifestStr, `"broken-element"`, "declarations before the syntax error should be emitted")
+
+		require.NotEmpty(t, diagnostics)
+		for _, d := range diagnostics {
+			assert.Equal(t, "src/broken-element.ts", d.File)
+			assert.NotZero(t, d.Line)
+			assert.Contains(t, d.Message, "syntax error")
+		}
+	})
+}
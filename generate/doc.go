@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package generate analyzes a package's sources and produces its custom
+// elements manifest.
+//
+// Go programs can embed generation with a GenerateSession instead of running
+// the cem binary. A session keeps its parsers and dependency graph between
+// runs, so that after the first full generation, changed files are
+// reprocessed incrementally:
+//
+//	session, err := generate.NewGenerateSessionForDir("path/to/package")
+//	if err != nil {
+//		return err
+//	}
+//	defer session.Close()
+//
+//	unsubscribe := session.Subscribe(func(pkg *manifest.Package) {
+//		site.UpdateElements(pkg) // read-only, shared with other subscribers
+//	})
+//	defer unsubscribe()
+//
+//	if _, err := session.GenerateFullManifest(ctx); err != nil {
+//		return err
+//	}
+//
+//	for changed := range fileChanges { // paths relative to the package root
+//		if _, err := session.ProcessChangedFiles(ctx, changed); err != nil {
+//			if errors.Is(err, context.Canceled) {
+//				return nil
+//			}
+//			log.Print(err)
+//		}
+//	}
+//
+// Every session method which takes a context stops at the next phase boundary
+// once the context is cancelled, returning ctx.Err() and leaving the last good
+// manifest in place.
+package generate
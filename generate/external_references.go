@@ -0,0 +1,176 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"sync"
+
+	L "bennypowers.dev/cem/internal/logging"
+	"bennypowers.dev/cem/internal/platform"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// externalReferenceResolver finds the modules which declare superclasses,
+// mixins, and interfaces imported from other packages, using the custom
+// elements manifests those packages publish in node_modules
+type externalReferenceResolver struct {
+	root      string
+	fs        platform.FileSystem
+	mu        sync.Mutex
+	manifests map[string]*M.Package // package name -> manifest, nil if it has none; protected by mu
+}
+
+func newExternalReferenceResolver(root string, fsys platform.FileSystem) *externalReferenceResolver {
+	return &externalReferenceResolver{
+		root:      root,
+		fs:        fsys,
+		manifests: make(map[string]*M.Package),
+	}
+}
+
+// resolveExternalReferences points the cross-package superclasses, mixins,
+// and interfaces of a module's classes at the modules which declare them
+func resolveExternalReferences(module *M.Module, r *externalReferenceResolver) {
+	if r == nil {
+		return
+	}
+	for _, decl := range module.Declarations {
+		var classLike *M.ClassLike
+		switch d := decl.(type) {
+		case *M.ClassDeclaration:
+			classLike = &d.ClassLike
+		case *M.CustomElementDeclaration:
+			classLike = &d.ClassLike
+		case *M.MixinDeclaration:
+			classLike = &d.ClassLike
+		case *M.CustomElementMixinDeclaration:
+			classLike = &d.ClassLike
+		default:
+			continue
+		}
+		if classLike.Superclass != nil {
+			r.resolve(classLike.Superclass)
+		}
+		for i := range classLike.Mixins {
+			r.resolve(&classLike.Mixins[i])
+		}
+		for i := range classLike.Implements {
+			r.resolve(&classLike.Implements[i])
+		}
+	}
+}
+
+// resolve sets the module of a reference to another package to the module
+// of that package's manifest which declares the referenced name. The
+// module named by the import specifier is kept when it declares the name;
+// otherwise the name is followed through the package's exports, e.g. from
+// its index module to the module which declares it.
+func (r *externalReferenceResolver) resolve(ref *M.Reference) {
+	if ref.Package == "" || ref.Package == "global:" {
+		return
+	}
+	pkg := r.manifest(ref.Package)
+	if pkg == nil {
+		return
+	}
+	if ref.Module != "" {
+		if pkg.FindDeclaration(*ref) != nil {
+			return
+		}
+	}
+	for i := range pkg.Modules {
+		mod := &pkg.Modules[i]
+		if ref.Module != "" && mod.Path != ref.Module {
+			continue
+		}
+		for _, export := range mod.Exports {
+			jsExport, ok := export.(*M.JavaScriptExport)
+			if !ok || jsExport.Name != ref.Name || jsExport.Declaration == nil {
+				continue
+			}
+			target := *jsExport.Declaration
+			if target.Package != "" {
+				// Re-exported from yet another package
+				ref.Package = target.Package
+				ref.Name = target.Name
+				ref.Module = target.Module
+				return
+			}
+			if target.Module == "" {
+				target.Module = mod.Path
+			}
+			if pkg.FindDeclaration(target) != nil {
+				ref.Name = target.Name
+				ref.Module = target.Module
+				return
+			}
+		}
+	}
+	// The specifier's module doesn't export the name, e.g. because the
+	// import was resolved through the package's export map
+	for i := range pkg.Modules {
+		candidate := M.Reference{Name: ref.Name, Module: pkg.Modules[i].Path}
+		if pkg.FindDeclaration(candidate) != nil {
+			ref.Module = candidate.Module
+			return
+		}
+	}
+}
+
+// manifest reads the custom elements manifest of a package installed in
+// node_modules, or returns nil if the package doesn't have one
+func (r *externalReferenceResolver) manifest(pkgName string) *M.Package {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if pkg, ok := r.manifests[pkgName]; ok {
+		return pkg
+	}
+	pkg := r.readManifest(pkgName)
+	r.manifests[pkgName] = pkg
+	return pkg
+}
+
+func (r *externalReferenceResolver) readManifest(pkgName string) *M.Package {
+	pkgDir := filepath.Join(r.root, "node_modules", filepath.FromSlash(pkgName))
+	data, err := r.fs.ReadFile(filepath.Join(pkgDir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkgJSON M.PackageJSON
+	if err := json.Unmarshal(data, &pkgJSON); err != nil {
+		L.Debug("externalReferenceResolver: cannot parse package.json of %s: %v", pkgName, err)
+		return nil
+	}
+	if pkgJSON.CustomElements == "" {
+		return nil
+	}
+	manifestPath := filepath.Join(pkgDir, filepath.FromSlash(path.Clean(pkgJSON.CustomElements)))
+	data, err = r.fs.ReadFile(manifestPath)
+	if err != nil {
+		L.Debug("externalReferenceResolver: cannot read manifest of %s: %v", pkgName, err)
+		return nil
+	}
+	var pkg M.Package
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		L.Debug("externalReferenceResolver: cannot parse manifest of %s: %v", pkgName, err)
+		return nil
+	}
+	return &pkg
+}
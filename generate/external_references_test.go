@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate_test
+
+import (
+	"testing"
+	"testing/synctest"
+
+	G "bennypowers.dev/cem/generate"
+	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: small virtual workspace with a dependency in node_modules, whose
+// manifest declares the superclass in a module re-exported by its index
+
+// TestExternalReferences verifies that superclasses imported from other
+// packages reference the module of the dependency's manifest which declares
+// them, and that implemented interfaces are recorded.
+func TestExternalReferences(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mapFS := platform.NewMapFileSystem(nil)
+		root := "/test-workspace"
+
+		mapFS.AddFile(root+"/package.json", `{"name": "test-package"}`, 0644)
+		mapFS.AddFile(root+"/node_modules/@base/elements/package.json",
+			`{"name": "@base/elements", "customElements": "custom-elements.json"}`, 0644)
+		mapFS.AddFile(root+"/node_modules/@base/elements/custom-elements.json", `{
+  "schemaVersion": "2.1.0",
+  "modules": [{
+    "kind": "javascript-module",
+    "path": "index.js",
+    "exports": [{"kind": "js", "name": "BaseElement", "declaration": {"name": "BaseElement", "module": "base/base-element.js"}}]
+  }, {
+    "kind": "javascript-module",
+    "path": "base/base-element.js",
+    "declarations": [{"kind": "class", "name": "BaseElement", "customElement": true}],
+    "exports": [{"kind": "js", "name": "BaseElement", "declaration": {"name": "BaseElement", "module": "base/base-element.js"}}]
+  }]
+}`, 0644)
+		mapFS.AddFile(root+"/src/my-button.ts", `import { BaseElement } from '@base/elements';
+import { Focusable as CanFocus } from './focusable.js';
+
+/**
+ * A button
+ * @customElement my-button
+ */
+export class MyButton extends BaseElement implements CanFocus, EventTarget {}
+`, 0644)
+
+		workspace := W.NewFileSystemWorkspaceContext(root, W.WithFileSystem(mapFS))
+		require.NoError(t, workspace.Init())
+		cfg, err := workspace.Config()
+		require.NoError(t, err)
+		cfg.Generate.Files = []string{"src/my-button.ts"}
+
+		pkg, _, err := G.GeneratePackageWithDiagnostics(workspace, mapFS)
+		require.NoError(t, err)
+		require.Len(t, pkg.Modules, 1)
+		require.Len(t, pkg.Modules[0].Declarations, 1)
+
+		decl, ok := pkg.Modules[0].Declarations[0].(*M.CustomElementDeclaration)
+		require.True(t, ok, "should be a custom element")
+
+		require.NotNil(t, decl.Superclass)
+		assert.Equal(t, M.Reference{
+			Name:    "BaseElement",
+			Package: "@base/elements",
+			Module:  "base/base-element.js",
+		}, *decl.Superclass)
+
+		assert.Equal(t, []M.Reference{
+			{Name: "Focusable", Module: "src/focusable.js"},
+			{Name: "EventTarget"},
+		}, decl.Implements)
+	})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package generate
+
+import (
+	"slices"
+
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// fastElementBase is the class which FAST elements extend
+const fastElementBase = "FASTElement"
+
+// amendFieldWithFASTAttrCaptures applies the options of FAST's @attr.
+// Unlike Lit's properties, its attributes reflect unless their mode is
+// fromView, and its boolean mode makes a boolean attribute.
+func amendFieldWithFASTAttrCaptures(captures Q.CaptureMap, field *M.CustomElementField) {
+	if !slices.ContainsFunc(captures["decorator.name"], func(c Q.CaptureInfo) bool {
+		return c.Text == "attr"
+	}) {
+		return
+	}
+	field.Reflects = true
+	for _, capture := range captures["field.attr.mode"] {
+		switch capture.Text {
+		case "fromView":
+			field.Reflects = false
+		case "boolean":
+			if field.Type == nil {
+				field.Type = &M.Type{Text: "boolean"}
+			}
+		}
+	}
+}
+
+// fastDefinedTagName returns the tag name which a FAST element class is
+// defined with by a statement of its module, rather than a decorator:
+//
+//	FASTElement.define(MyElement, { name: 'my-element' });
+//	MyElement.define({ name: 'my-element', template });
+//	MyElement.compose({ name: 'my-element', template }).define();
+func (mp *ModuleProcessor) fastDefinedTagName(className string) string {
+	for i := range mp.root.NamedChildCount() {
+		statement := mp.root.NamedChild(i)
+		if statement == nil || statement.Kind() != "expression_statement" {
+			continue
+		}
+		call := statement.NamedChild(0)
+		if call == nil || call.Kind() != "call_expression" {
+			continue
+		}
+		if definition := mp.fastDefinition(call, className); definition != nil {
+			if tagName := mp.fastDefinitionName(definition); tagName != "" {
+				return tagName
+			}
+		}
+	}
+	return ""
+}
+
+// fastDefinition returns the definition object of a call which defines
+// className, or nil if the call doesn't define it
+func (mp *ModuleProcessor) fastDefinition(call *ts.Node, className string) *ts.Node {
+	object, method := mp.calledMethod(call)
+	if object == nil {
+		return nil
+	}
+	args := argumentNodes(call)
+	switch {
+	// FASTElement.define(MyElement, { name })
+	case method == "define" && object.Utf8Text(mp.code) == fastElementBase:
+		if len(args) == 2 && args[0].Utf8Text(mp.code) == className {
+			return args[1]
+		}
+	// MyElement.define({ name }) and MyElement.compose({ name })
+	case (method == "define" || method == "compose") && object.Utf8Text(mp.code) == className:
+		if len(args) == 1 {
+			return args[0]
+		}
+	// MyElement.compose({ name }).define()
+	case method == "define" && object.Kind() == "call_expression" && len(args) == 0:
+		return mp.fastDefinition(object, className)
+	}
+	return nil
+}
+
+// fastDefinitionName returns the name in a definition object, a string or
+// a reference to a string constant
+func (mp *ModuleProcessor) fastDefinitionName(definition *ts.Node) string {
+	if definition.Kind() != "object" {
+		return ""
+	}
+	for i := range definition.NamedChildCount() {
+		pair := definition.NamedChild(i)
+		if pair == nil || pair.Kind() != "pair" || propertyKeyText(pair.ChildByFieldName("key"), mp.code) != "name" {
+			continue
+		}
+		value := pair.ChildByFieldName("value")
+		switch {
+		case value == nil:
+		case value.Kind() == "string":
+			return stringNodeValue(value, mp.code)
+		case value.Kind() == "identifier":
+			return mp.resolveConstStringValue(value.Utf8Text(mp.code))
+		}
+	}
+	return ""
+}
+
+// calledMethod returns the object and method name of a method call, e.g.
+// `MyElement` and `define` for `MyElement.define()`
+func (mp *ModuleProcessor) calledMethod(call *ts.Node) (*ts.Node, string) {
+	function := call.ChildByFieldName("function")
+	if function == nil || function.Kind() != "member_expression" {
+		return nil, ""
+	}
+	property := function.ChildByFieldName("property")
+	if property == nil {
+		return nil, ""
+	}
+	return function.ChildByFieldName("object"), property.Utf8Text(mp.code)
+}
+
+// argumentNodes returns the arguments of a call
+func argumentNodes(call *ts.Node) []*ts.Node {
+	arguments := call.ChildByFieldName("arguments")
+	if arguments == nil {
+		return nil
+	}
+	var nodes []*ts.Node
+	for i := range arguments.NamedChildCount() {
+		if arg := arguments.NamedChild(i); arg != nil && arg.Kind() != "comment" {
+			nodes = append(nodes, arg)
+		}
+	}
+	return nodes
+}
+
+// stringNodeValue returns the contents of a string literal node
+func stringNodeValue(node *ts.Node, code []byte) string {
+	for i := range node.NamedChildCount() {
+		if fragment := node.NamedChild(i); fragment != nil && fragment.Kind() == "string_fragment" {
+			return fragment.Utf8Text(code)
+		}
+	}
+	return ""
+}
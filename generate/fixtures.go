@@ -0,0 +1,306 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/version"
+	W "bennypowers.dev/cem/internal/workspace"
+	"bennypowers.dev/cem/types"
+	"github.com/nsf/jsondiff"
+)
+
+// FixturesIndexFile lists the recorded fixtures, relative to the fixtures directory
+const FixturesIndexFile = "fixtures.json"
+
+// fixturesGoldenDir holds each input's recorded manifest, at <input>.json
+const fixturesGoldenDir = "golden"
+
+// FixturesIndex describes a directory of recorded fixtures
+type FixturesIndex struct {
+	// Version of cem which recorded the fixtures
+	Version string `json:"version"`
+	// Files are the recorded inputs, relative to the fixtures directory
+	Files []string `json:"files"`
+}
+
+// FixtureResult is the outcome of checking one recorded fixture
+type FixtureResult struct {
+	// File is the input, relative to the fixtures directory
+	File string `json:"file"`
+	// Changes describe how the generated manifest differs from the recorded
+	// one, by module and declaration. Empty when they match.
+	Changes []string `json:"changes,omitempty"`
+}
+
+// RecordFixtures copies each of the workspace's generate files into dir,
+// alongside the manifest generated for that file alone, so that CheckFixtures
+// can later detect changes in generation behavior. The package.json and cem
+// config are copied too, so the fixtures generate with the same settings.
+// Returns the recorded files, relative to dir.
+func RecordFixtures(ctx types.WorkspaceContext, fsys platform.FileSystem, dir string) ([]string, error) {
+	cfg, err := ctx.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	root := ctx.Root()
+	var files []string
+	for _, file := range cfg.Generate.Files {
+		if filepath.IsAbs(file) {
+			rel, err := filepath.Rel(root, file)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			file = rel
+		}
+		files = append(files, filepath.ToSlash(filepath.Clean(file)))
+	}
+	slices.Sort(files)
+	files = slices.Compact(files)
+	if len(files) == 0 {
+		return nil, errors.New("no files to record")
+	}
+
+	// Copy all inputs before generating, since generating one file may
+	// resolve types from another
+	supporting := []string{"package.json"}
+	if configFile := ctx.ConfigFile(); configFile != "" {
+		if rel, err := filepath.Rel(root, configFile); err == nil && !strings.HasPrefix(rel, "..") {
+			supporting = append(supporting, filepath.ToSlash(rel))
+		}
+	}
+	if spec := cfg.Generate.DesignTokens.Spec; spec != "" && !strings.Contains(spec, ":") && !filepath.IsAbs(spec) {
+		supporting = append(supporting, filepath.ToSlash(filepath.Clean(spec)))
+	}
+	for _, file := range supporting {
+		if fsys.Exists(filepath.Join(root, file)) {
+			if err := copyFixtureFile(fsys, root, dir, file); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, file := range files {
+		if err := copyFixtureFile(fsys, root, dir, file); err != nil {
+			return nil, err
+		}
+	}
+
+	// Restore the configured files after generating each one alone
+	originalFiles := cfg.Generate.Files
+	defer func() { cfg.Generate.Files = originalFiles }()
+
+	var errs error
+	var recorded []string
+	for _, file := range files {
+		cfg.Generate.Files = []string{file}
+		manifest, _, err := GenerateWithDiagnostics(ctx, fsys)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("generating %s: %w", file, err))
+			continue
+		}
+		golden := filepath.Join(dir, fixturesGoldenDir, filepath.FromSlash(file)+".json")
+		if err := writeFixtureFile(fsys, golden, []byte(*manifest+"\n")); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		recorded = append(recorded, file)
+	}
+
+	index, err := json.MarshalIndent(FixturesIndex{
+		Version: version.GetVersion(),
+		Files:   recorded,
+	}, "", "  ")
+	if err != nil {
+		return recorded, errors.Join(errs, err)
+	}
+	if err := writeFixtureFile(fsys, filepath.Join(dir, FixturesIndexFile), append(index, '\n')); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	return recorded, errs
+}
+
+// CheckFixtures regenerates each fixture recorded in dir by RecordFixtures,
+// and compares the result with the recorded manifest, module by module and
+// declaration by declaration. Returns one result per fixture.
+func CheckFixtures(fsys platform.FileSystem, dir string) ([]FixtureResult, error) {
+	data, err := fsys.ReadFile(filepath.Join(dir, FixturesIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading fixtures index: %w", err)
+	}
+	var index FixturesIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parsing fixtures index: %w", err)
+	}
+
+	ctx := W.NewFileSystemWorkspaceContext(dir, W.WithFileSystem(fsys))
+	if err := ctx.Init(); err != nil {
+		return nil, fmt.Errorf("initializing fixtures workspace: %w", err)
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading fixtures config: %w", err)
+	}
+
+	var errs error
+	results := make([]FixtureResult, 0, len(index.Files))
+	for _, file := range index.Files {
+		expected, err := fsys.ReadFile(filepath.Join(dir, fixturesGoldenDir, filepath.FromSlash(file)+".json"))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("reading golden manifest for %s: %w", file, err))
+			continue
+		}
+		cfg.Generate.Files = []string{file}
+		actual, _, err := GenerateWithDiagnostics(ctx, fsys)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("generating %s: %w", file, err))
+			continue
+		}
+		changes, err := CompareManifests(expected, []byte(*actual))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("comparing %s: %w", file, err))
+			continue
+		}
+		results = append(results, FixtureResult{File: file, Changes: changes})
+	}
+	return results, errs
+}
+
+// manifestSnapshot is the part of a manifest which fixtures compare
+type manifestSnapshot struct {
+	Modules []struct {
+		Path         string            `json:"path"`
+		Declarations []json.RawMessage `json:"declarations"`
+		Exports      []json.RawMessage `json:"exports"`
+	} `json:"modules"`
+}
+
+// CompareManifests describes the differences between two manifests, by
+// module and declaration. JSON key order and formatting are insignificant.
+func CompareManifests(expected, actual []byte) ([]string, error) {
+	var want, got manifestSnapshot
+	if err := json.Unmarshal(expected, &want); err != nil {
+		return nil, fmt.Errorf("invalid recorded manifest: %w", err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		return nil, fmt.Errorf("invalid generated manifest: %w", err)
+	}
+
+	type module struct {
+		declarations map[string]json.RawMessage
+		exports      []json.RawMessage
+	}
+	index := func(snapshot manifestSnapshot) map[string]module {
+		modules := make(map[string]module)
+		for _, m := range snapshot.Modules {
+			declarations := make(map[string]json.RawMessage)
+			for _, raw := range m.Declarations {
+				var decl struct {
+					Kind string `json:"kind"`
+					Name string `json:"name"`
+				}
+				if err := json.Unmarshal(raw, &decl); err == nil {
+					declarations[decl.Kind+" "+decl.Name] = raw
+				}
+			}
+			modules[m.Path] = module{declarations: declarations, exports: m.Exports}
+		}
+		return modules
+	}
+	wantModules, gotModules := index(want), index(got)
+
+	var changes []string
+	paths := maps.Clone(wantModules)
+	maps.Copy(paths, gotModules)
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		wantModule, inWant := wantModules[path]
+		gotModule, inGot := gotModules[path]
+		switch {
+		case !inGot:
+			changes = append(changes, fmt.Sprintf("module %s: removed", path))
+			continue
+		case !inWant:
+			changes = append(changes, fmt.Sprintf("module %s: added", path))
+			continue
+		}
+
+		names := maps.Clone(wantModule.declarations)
+		maps.Copy(names, gotModule.declarations)
+		for _, name := range slices.Sorted(maps.Keys(names)) {
+			wantDecl, inWant := wantModule.declarations[name]
+			gotDecl, inGot := gotModule.declarations[name]
+			switch {
+			case !inGot:
+				changes = append(changes, fmt.Sprintf("module %s: %s removed", path, name))
+			case !inWant:
+				changes = append(changes, fmt.Sprintf("module %s: %s added", path, name))
+			default:
+				if diff, ok := jsonDiff(wantDecl, gotDecl); !ok {
+					changes = append(changes, fmt.Sprintf("module %s: %s changed\n%s", path, name, diff))
+				}
+			}
+		}
+
+		wantExports, _ := json.Marshal(wantModule.exports)
+		gotExports, _ := json.Marshal(gotModule.exports)
+		if diff, ok := jsonDiff(wantExports, gotExports); !ok {
+			changes = append(changes, fmt.Sprintf("module %s: exports changed\n%s", path, diff))
+		}
+	}
+	return changes, nil
+}
+
+// jsonDiff compares two JSON documents semantically, returning a plain-text
+// diff when they differ
+func jsonDiff(expected, actual []byte) (string, bool) {
+	options := jsondiff.DefaultConsoleOptions()
+	options.Added = jsondiff.Tag{Begin: "+", End: ""}
+	options.Removed = jsondiff.Tag{Begin: "-", End: ""}
+	options.Changed = jsondiff.Tag{Begin: "~", End: ""}
+	options.Skipped = jsondiff.Tag{Begin: "", End: ""}
+	options.Normal = jsondiff.Tag{Begin: "", End: ""}
+	options.SkipMatches = true
+	difference, diff := jsondiff.Compare(expected, actual, &options)
+	return diff, difference == jsondiff.FullMatch
+}
+
+// copyFixtureFile copies a file, relative to root, to the same path in dir
+func copyFixtureFile(fsys platform.FileSystem, root, dir, file string) error {
+	data, err := fsys.ReadFile(filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	return writeFixtureFile(fsys, filepath.Join(dir, filepath.FromSlash(file)), data)
+}
+
+func writeFixtureFile(fsys platform.FileSystem, path string, data []byte) error {
+	if err := fsys.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", path, err)
+	}
+	if err := fsys.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate_test
+
+import (
+	"strings"
+	"testing"
+	"testing/synctest"
+
+	G "bennypowers.dev/cem/generate"
+	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: small virtual workspace, recording fixtures and then editing the
+// recorded goldens to stand in for a change in generation behavior
+
+// TestRecordAndCheckFixtures verifies that recorded fixtures match when
+// regenerated, and that a changed declaration is reported by name.
+func TestRecordAndCheckFixtures(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mapFS := platform.NewMapFileSystem(nil)
+		root := "/test-workspace"
+		fixtures := "/fixtures"
+
+		mapFS.AddFile(root+"/package.json", `{"name": "test-package"}`, 0644)
+		mapFS.AddFile(root+"/.config/cem.yaml", "generate:\n  files:\n    - src/*.ts\n", 0644)
+		mapFS.AddFile(root+"/src/my-button.ts", `/**
+ * A button
+ * @customElement my-button
+ */
+export class MyButton extends HTMLElement {
+  /** Whether the button is disabled */
+  disabled = false;
+}
+`, 0644)
+		mapFS.AddFile(root+"/src/my-card.ts", `/** @customElement my-card */
+export class MyCard extends HTMLElement {}
+`, 0644)
+
+		workspace := W.NewFileSystemWorkspaceContext(root, W.WithFileSystem(mapFS))
+		require.NoError(t, workspace.Init())
+		cfg, err := workspace.Config()
+		require.NoError(t, err)
+		cfg.Generate.Files = []string{"src/my-button.ts", "src/my-card.ts"}
+
+		recorded, err := G.RecordFixtures(workspace, mapFS, fixtures)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src/my-button.ts", "src/my-card.ts"}, recorded)
+		assert.True(t, mapFS.Exists(fixtures+"/src/my-button.ts"), "should copy the input")
+		assert.True(t, mapFS.Exists(fixtures+"/.config/cem.yaml"), "should copy the config")
+		assert.True(t, mapFS.Exists(fixtures+"/golden/src/my-button.ts.json"), "should record the manifest")
+
+		results, err := G.CheckFixtures(mapFS, fixtures)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.Empty(t, result.Changes, "fixture %s should match", result.File)
+		}
+
+		// Stand in for a change in generation behavior
+		golden := fixtures + "/golden/src/my-button.ts.json"
+		data, err := mapFS.ReadFile(golden)
+		require.NoError(t, err)
+		mapFS.AddFile(golden, strings.Replace(string(data), "Whether the button is disabled", "Disables the button", 1), 0644)
+
+		results, err = G.CheckFixtures(mapFS, fixtures)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "src/my-button.ts", results[0].File)
+		require.Len(t, results[0].Changes, 1)
+		assert.Contains(t, results[0].Changes[0], "module src/my-button.js: class MyButton changed")
+		assert.Empty(t, results[1].Changes)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"sync"
 
@@ -89,10 +90,13 @@ func preprocess(ctx types.WorkspaceContext) (r preprocessResult, errs error) {
 			logging.Debug("demo discovery glob: %v", err)
 		}
 	}
+	// Files which more than one pattern matches are processed once
+	seen := make(map[string]bool)
 	for _, filePattern := range cfg.Generate.Files {
 		expandedFiles, err := ctx.Glob(filePattern)
 		for _, file := range expandedFiles {
-			if !matchesAnyPattern(file, r.excludePatterns) {
+			if !seen[file] && !matchesAnyPattern(file, r.excludePatterns) {
+				seen[file] = true
 				r.includedFiles = append(r.includedFiles, file)
 			}
 		}
@@ -104,8 +108,9 @@ func preprocess(ctx types.WorkspaceContext) (r preprocessResult, errs error) {
 }
 
 type processJob struct {
-	file string
-	ctx  types.WorkspaceContext
+	file    string
+	ctx     types.WorkspaceContext
+	debugIR *debugIRRecorder
 }
 
 func processModule(
@@ -116,6 +121,10 @@ func processModule(
 	cssCache CssCache,
 	fsys platform.FileSystem,
 ) (module *M.Module, tagAliases map[string]string, typeAliases map[string]string, imports map[string]importInfo, logCtx *LogCtx, errs error) {
+	if filepath.Ext(job.file) == ".vue" {
+		module, logCtx, errs = processVueModule(job, qm, depTracker, fsys)
+		return module, nil, nil, nil, logCtx, errs
+	}
 	defer parser.Reset()
 	mp, err := NewModuleProcessor(job.ctx, job.file, parser, qm, cssCache, fsys)
 	if err != nil {
@@ -125,7 +134,24 @@ func processModule(
 	if mp.logger.Verbose {
 		fmt.Fprintf(mp.logger.Buffer, "\n== Module: %s ==\n\n", mp.logger.File)
 	}
+	if job.debugIR != nil {
+		mp.ir = &moduleIR{}
+	}
 	module, tagAliases, typeAliases, imports, err = mp.Collect()
+	job.debugIR.recordModule(job.file, mp.root, mp.ir, module)
+
+	// Tolerate syntax errors, emitting declarations from the rest of the file.
+	// Processing errors in such a file likely stem from its syntax errors, so
+	// they are tolerated too.
+	if diagnostics := syntaxDiagnostics(job.file, mp.root); len(diagnostics) > 0 {
+		for _, e := range flattenJoined(err) {
+			diagnostics = append(diagnostics, &Diagnostic{File: job.file, Message: e.Error()})
+		}
+		err = nil
+		for _, d := range diagnostics {
+			err = errors.Join(err, d)
+		}
+	}
 
 	// Record dependencies for incremental rebuilds
 	if depTracker != nil && module != nil {
@@ -179,24 +205,35 @@ func postprocess(
 		errsList = append(errsList, cfgErr)
 	}
 	var urlPattern string
+	var hasConventions bool
+	var categories map[string][]string
+	var memberOrder string
 	if cfgErr == nil {
 		urlPattern = cfg.Generate.DemoDiscovery.URLPattern
+		hasConventions = len(cfg.Generate.DemoDiscovery.Conventions) > 0
+		categories = cfg.Generate.Categories
+		memberOrder = cfg.Generate.MemberOrder
 	}
 	demoMap, err := DD.NewDemoMapWithPattern(ctx, result.demoFiles, urlPattern, allTagAliases, fsys)
 	if err != nil {
 		errsList = append(errsList, err)
 	}
 
-	// Because demo discovery and design tokens may mutate modules, we need to coordinate by pointer
+	references := newExternalReferenceResolver(ctx.Root(), fsys)
+
+	// Because categories, member order, references, demo discovery, and design tokens may mutate modules, we need to coordinate by pointer
 	for i := range modules {
 		wg.Add(1)
 		go func(module *M.Module) {
 			defer wg.Done()
+			applyConfigCategories(module, categories)
+			applyMemberOrder(module, memberOrder)
+			resolveExternalReferences(module, references)
 			if result.designTokens != nil {
 				DT.MergeDesignTokensToModule(module, result.designTokens)
 			}
 			// Discover demos and attach to manifest
-			if len(demoMap) > 0 {
+			if len(demoMap) > 0 || hasConventions {
 				err := DD.DiscoverDemos(ctx, allTagAliases, module, qm, demoMap, fsys)
 				if err != nil {
 					errsMu.Lock()
@@ -220,6 +257,9 @@ func postprocess(
 	if err := ResolveTypeAliases(&pkg, typeAliases, imports, externalResolver); err != nil {
 		errsList = append(errsList, fmt.Errorf("type resolution failed: %w", err))
 	}
+	if cfgErr == nil && cfg.Generate.TypeDeclarations {
+		declareReferencedTypes(&pkg)
+	}
 	if len(errsList) > 0 {
 		errs = errors.Join(errsList...)
 	}
@@ -228,22 +268,42 @@ func postprocess(
 
 // Generates a custom-elements manifest from a list of typescript files
 func Generate(ctx types.WorkspaceContext, fsys platform.FileSystem) (manifest *string, errs error) {
-	session, err := NewGenerateSession(ctx, fsys)
+	manifest, _, errs = GenerateWithDiagnostics(ctx, fsys)
+	return manifest, errs
+}
+
+// GenerateWithDiagnostics generates a custom-elements manifest like Generate,
+// and also returns the per-file problems which generation tolerated, such as
+// syntax errors.
+func GenerateWithDiagnostics(ctx types.WorkspaceContext, fsys platform.FileSystem) (manifest *string, diagnostics []Diagnostic, errs error) {
+	pkg, diagnostics, err := GeneratePackageWithDiagnostics(ctx, fsys)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer session.Close()
 
-	pkg, err := session.GenerateFullManifest(context.Background())
+	manifestStr, err := M.SerializeToString(pkg)
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("module serialize failed: %w", err)
 	}
+	return &manifestStr, diagnostics, nil
+}
 
-	manifestStr, err := M.SerializeToString(pkg)
+// GeneratePackageWithDiagnostics generates a custom-elements manifest like
+// GenerateWithDiagnostics, but returns the package unserialized, so callers
+// can write it out in other formats. Options configure the session, e.g.
+// WithDebugIR.
+func GeneratePackageWithDiagnostics(ctx types.WorkspaceContext, fsys platform.FileSystem, options ...SessionOption) (pkg *M.Package, diagnostics []Diagnostic, errs error) {
+	session, err := NewGenerateSession(ctx, fsys, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer session.Close()
+
+	pkg, err = session.GenerateFullManifest(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("module serialize failed: %w", err)
+		return nil, nil, err
 	}
-	return &manifestStr, nil
+	return pkg, session.Diagnostics(), nil
 }
 
 // validateAndLoadDesignTokens loads design tokens from cache.
@@ -22,7 +22,8 @@ import (
 	"regexp"
 	"strings"
 
-	htmllang "bennypowers.dev/cem/internal/languages/html"
+	"bennypowers.dev/cem/internal/languages"
+	_ "bennypowers.dev/cem/internal/languages/html"
 	M "bennypowers.dev/cem/manifest"
 	Q "bennypowers.dev/cem/internal/treesitter"
 	"gopkg.in/yaml.v3"
@@ -34,6 +35,8 @@ type HtmlDocYaml struct {
 	Description string `yaml:"description"`
 	Summary     string `yaml:"summary"`
 	Deprecated  any    `yaml:"deprecated"`
+	// Required marks a slot which usages of the element must fill
+	Required bool `yaml:"required"`
 }
 
 func (h *HtmlDocYaml) UnmarshalYAML(value *yaml.Node) error {
@@ -53,9 +56,11 @@ type NestedHtmlDocYaml struct {
 	Description string       `yaml:"description"`
 	Summary     string       `yaml:"summary"`
 	Deprecated  any          `yaml:"deprecated"`
+	Required    bool         `yaml:"required"`
 }
 
 func (mp *ModuleProcessor) processRenderTemplate(
+	syntax languages.TemplateSyntax,
 	htmlSource string,
 	offset uint,
 ) (
@@ -63,8 +68,12 @@ func (mp *ModuleProcessor) processRenderTemplate(
 	parts []M.CssPart,
 	errs error,
 ) {
-	parser := htmllang.BorrowParser()
-	defer htmllang.ReturnParser(parser)
+	lang := languages.Get(syntax.Language)
+	if lang == nil {
+		return nil, nil, fmt.Errorf("template syntax %q: language %q is not registered", syntax.Name, syntax.Language)
+	}
+	parser := lang.BorrowParser()
+	defer lang.ReturnParser(parser)
 
 	text := []byte(htmlSource)
 	tree := parser.Parse(text, nil)
@@ -72,7 +81,7 @@ func (mp *ModuleProcessor) processRenderTemplate(
 	defer tree.Close()
 	root := tree.RootNode()
 
-	matcher, qmErr := Q.NewQueryMatcher(mp.queryManager, "html", "slotsAndParts")
+	matcher, qmErr := Q.NewQueryMatcher(mp.queryManager, syntax.Language, "slotsAndParts")
 	if qmErr != nil {
 		return nil, nil, qmErr
 	}
@@ -117,6 +126,7 @@ func (mp *ModuleProcessor) processRenderTemplate(
 			slot.Description = slotDoc.Description
 			slot.Summary = slotDoc.Summary
 			slot.Deprecated = M.NewDeprecated(slotDoc.Deprecated)
+			slot.Required = slotDoc.Required
 			partDoc, err := parseYamlComment(commentText, "part")
 			if err != nil {
 				errs = errors.Join(errs, WrapComponentError("part", fmt.Sprintf("%v", partNames), err))
@@ -239,6 +249,7 @@ func parseYamlComment(comment string, kind string) (HtmlDocYaml, error) {
 		Description: description,
 		Summary:     raw.Summary,
 		Deprecated:  raw.Deprecated,
+		Required:    raw.Required,
 	}, err
 }
 
@@ -140,6 +140,14 @@ func TestParseYamlComment(t *testing.T) {
 		assert.Equal(t, "Default", doc.Summary)
 	})
 
+	t.Run("required slot", func(t *testing.T) {
+		comment := "<!--\n  summary: Heading\n  required: true\n-->"
+		doc, err := parseYamlComment(comment, "slot")
+		require.NoError(t, err)
+		assert.Equal(t, "Heading", doc.Summary)
+		assert.True(t, doc.Required)
+	})
+
 	t.Run("part kind extracts part docs", func(t *testing.T) {
 		comment := "<!-- part:\n  description: The button part\n-->"
 		doc, err := parseYamlComment(comment, "part")
@@ -80,6 +80,9 @@ func applyToCustomElementDeclaration(info *classInfo, declaration *M.CustomEleme
 			if declaration.CustomElement.Slots[i].Deprecated == nil {
 				declaration.CustomElement.Slots[i].Deprecated = jsdocSlot.Deprecated
 			}
+			if jsdocSlot.Required {
+				declaration.CustomElement.Slots[i].Required = true
+			}
 			delete(jsdocSlots, jsdocSlot.Name)
 		}
 	}
@@ -133,6 +136,8 @@ func applyToCustomElementDeclaration(info *classInfo, declaration *M.CustomEleme
 
 	declaration.CustomElement.CssStates = info.CssStates
 	declaration.Demos = info.Demos
+	declaration.Categories = info.Categories
+	declaration.Role = info.Role
 	if info.TagName != "" {
 		declaration.TagName = info.TagName
 	}
@@ -145,6 +150,9 @@ func applyToCSSProperty(info *cssPropertyInfo, declaration *M.CssCustomProperty)
 	if info.Syntax != "" {
 		declaration.Syntax = info.Syntax
 	}
+	if info.Group != "" {
+		declaration.Group = info.Group
+	}
 }
 
 func applyToPropertyLike(info *propertyInfo, declaration *M.PropertyLike) {
@@ -193,14 +201,14 @@ func applyToFunctionLike(info *methodInfo, declaration *M.FunctionLike) {
 }
 
 func applyToMethod(info *methodInfo, declaration *M.ClassMethod) {
-	declaration.Description = normalizeJsdocLines(info.Description)
+	declaration.Description = info.Description
 	declaration.Deprecated = info.Deprecated
 	declaration.Summary = info.Summary
 	applyToFunctionLike(info, &declaration.FunctionLike)
 }
 
 func applyToFunctionDeclaration(info *methodInfo, declaration *M.FunctionDeclaration) {
-	declaration.Description = normalizeJsdocLines(info.Description)
+	declaration.Description = info.Description
 	declaration.Deprecated = info.Deprecated
 	declaration.Summary = info.Summary
 	applyToFunctionLike(info, &declaration.FunctionLike)
@@ -177,6 +177,13 @@ func TestApplyToCSSProperty(t *testing.T) {
 				assert.Equal(t, "<length>", prop.Syntax)
 			},
 		},
+		{
+			name: "sets group",
+			info: cssPropertyInfo{Group: "Surface"},
+			check: func(t *testing.T, prop *M.CssCustomProperty) {
+				assert.Equal(t, "Surface", prop.Group)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -10,10 +10,81 @@ func stripTrailingSplat(str string) string {
 	return regexp.MustCompile(` *\*$`).ReplaceAllString(str, "")
 }
 
+// jsdocGutter matches the leading star of a JSDoc comment line, with the
+// whitespace before it and at most one space or tab after it. Any further
+// indentation belongs to the line's content.
+var jsdocGutter = regexp.MustCompile(`^[ \t]*\*[ \t]?`)
+
+// normalizeJsdocLines strips the leading stars from the lines of a JSDoc
+// comment while preserving the markdown they contain. The first line is
+// trimmed, since it follows the comment opener or a tag rather than the
+// comment's margin. When the next line continues the first, as with tag text
+// aligned under its tag, the lines after the first are dedented by their
+// common indentation, so nested lists and indented code keep their structure.
+// Lines inside fenced code blocks are otherwise kept verbatim.
 func normalizeJsdocLines(str string) string {
-	re := regexp.MustCompile(`(?m)^[ \t]*\*[ \t]*`)
-	new := re.ReplaceAllString(str, "")
-	return stripTrailingSplat(new)
+	lines := strings.Split(str, "\n")
+	if hasJsdocGutter(lines) {
+		for i, line := range lines {
+			lines[i] = jsdocGutter.ReplaceAllString(line, "")
+		}
+	}
+	lines[0] = strings.TrimLeft(lines[0], " \t")
+
+	margin := -1
+	inFence := false
+	if len(lines) < 2 || strings.TrimSpace(lines[1]) == "" {
+		// A blank line ends the first paragraph, so any further
+		// indentation is markdown, e.g. an indented code block
+		margin = 0
+	}
+	for _, line := range lines[1:] {
+		if margin == 0 {
+			break
+		}
+		content := strings.TrimLeft(line, " \t")
+		fence := isMarkdownFence(content)
+		if content != "" && (!inFence || fence) {
+			if indent := len(line) - len(content); margin < 0 || indent < margin {
+				margin = indent
+			}
+		}
+		if fence {
+			inFence = !inFence
+		}
+	}
+	if margin > 0 {
+		for i, line := range lines[1:] {
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			lines[i+1] = line[min(indent, margin):]
+		}
+	}
+
+	return stripTrailingSplat(strings.Join(lines, "\n"))
+}
+
+// hasJsdocGutter reports whether the lines after the first all start with a
+// star, as in a conventional JSDoc comment, where the stars are indented or
+// stand alone on blank lines. A single line's star is always stripped. Text
+// which was already normalized, or comments written without stars, are left
+// alone, so that markdown list items starting with a star survive.
+func hasJsdocGutter(lines []string) bool {
+	gutter := len(lines) == 1
+	for _, line := range lines[1:] {
+		content := strings.TrimLeft(line, " \t")
+		if content != "" && !strings.HasPrefix(content, "*") {
+			return false
+		}
+		if content != "" && (content != line || strings.TrimSpace(content) == "*") {
+			gutter = true
+		}
+	}
+	return gutter
+}
+
+// isMarkdownFence reports whether a line opens or closes a fenced code block
+func isMarkdownFence(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~")
 }
 
 func findNamedMatches(
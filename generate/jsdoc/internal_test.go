@@ -72,6 +72,36 @@ func TestNormalizeJsdocLines(t *testing.T) {
 			input: "  *  indented\n * normal",
 			want:  "indented\nnormal",
 		},
+		{
+			name:  "nested list keeps indentation",
+			input: " * - a\n *   - b\n * - c",
+			want:  "- a\n  - b\n- c",
+		},
+		{
+			name:  "fenced code keeps indentation",
+			input: " * ```html\n * <a>\n *   <b></b>\n * </a>\n * ```",
+			want:  "```html\n<a>\n  <b></b>\n</a>\n```",
+		},
+		{
+			name:  "aligned tag continuation",
+			input: "multiline\n *          summary",
+			want:  "multiline\nsummary",
+		},
+		{
+			name:  "aligned tag continuation with nested list",
+			input: "Items:\n *        - one\n *          - two",
+			want:  "Items:\n- one\n  - two",
+		},
+		{
+			name:  "indented code block",
+			input: "Intro\n *\n *     code()",
+			want:  "Intro\n\n    code()",
+		},
+		{
+			name:  "already normalized list is unchanged",
+			input: "Options:\n* a\n* b",
+			want:  "Options:\n* a\n* b",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
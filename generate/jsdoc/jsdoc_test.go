@@ -208,6 +208,128 @@ func TestHasElementTag(t *testing.T) {
 	}
 }
 
+func TestEnrichTypeWithJSDoc(t *testing.T) {
+	qm := newTestQueryManager(t)
+
+	decl := &M.TypeDeclaration{}
+	ignore, err := EnrichTypeWithJSDoc(`/**
+ * Visual variants of buttons.
+ * @summary Button variants
+ * @deprecated Use ButtonKind
+ */`, decl, qm)
+	require.NoError(t, err)
+	assert.False(t, ignore)
+	assert.Equal(t, "Visual variants of buttons.", decl.Description)
+	assert.Equal(t, "Button variants", decl.Summary)
+	require.NotNil(t, decl.Deprecated)
+	assert.Equal(t, "Use ButtonKind", decl.Deprecated.Value())
+
+	ignore, err = EnrichTypeWithJSDoc("/** @internal */", &M.TypeDeclaration{}, qm)
+	require.NoError(t, err)
+	assert.True(t, ignore)
+}
+
+func TestEnrichModuleWithJSDoc(t *testing.T) {
+	qm := newTestQueryManager(t)
+
+	module := M.NewModule("button.js")
+	documented, err := EnrichModuleWithJSDoc(`/**
+ * Buttons and their helpers.
+ * @summary Buttons
+ * @deprecated Use the action module
+ */`, module, true, qm)
+	require.NoError(t, err)
+	assert.True(t, documented)
+	assert.Equal(t, "Buttons and their helpers.", module.Description)
+	assert.Equal(t, "Buttons", module.Summary)
+	require.NotNil(t, module.Deprecated)
+	assert.Equal(t, "Use the action module", module.Deprecated.Value())
+
+	module = M.NewModule("button.js")
+	documented, err = EnrichModuleWithJSDoc("/** A button */", module, false, qm)
+	require.NoError(t, err)
+	assert.False(t, documented, "untagged comments after the imports document the declaration after them")
+	assert.Empty(t, module.Description)
+
+	module = M.NewModule("button.js")
+	documented, err = EnrichModuleWithJSDoc("/** @file Buttons and their helpers */", module, false, qm)
+	require.NoError(t, err)
+	assert.True(t, documented)
+	assert.Equal(t, "Buttons and their helpers", module.Description)
+
+	module = M.NewModule("button.js")
+	documented, err = EnrichModuleWithJSDoc("/** @license MIT */", module, true, qm)
+	require.NoError(t, err)
+	assert.False(t, documented, "license headers don't document the module")
+	assert.Empty(t, module.Description)
+
+	decl := &M.ClassDeclaration{}
+	require.NoError(t, EnrichClassWithJSDoc(`/**
+ * Buttons and their helpers.
+ * @module button
+ */`, decl, qm))
+	assert.Empty(t, decl.Description, "module comments don't document the class after them")
+}
+
+func TestParseTypedefs(t *testing.T) {
+	qm := newTestQueryManager(t)
+
+	tests := []struct {
+		name  string
+		input string
+		want  []Typedef
+	}{
+		{
+			name: "single typedef takes the comment's description",
+			input: `/**
+ * Visual variants of buttons.
+ * @summary Button variants
+ * @typedef {'primary' | 'secondary'} Variant
+ */`,
+			want: []Typedef{{
+				Name:        "Variant",
+				Type:        "'primary' | 'secondary'",
+				Summary:     "Button variants",
+				Description: "Visual variants of buttons.",
+			}},
+		},
+		{
+			name: "object type with description",
+			input: `/**
+ * @typedef {{ label: string }} Options - Button options
+ */`,
+			want: []Typedef{{
+				Name:        "Options",
+				Type:        "{ label: string }",
+				Description: "Button options",
+			}},
+		},
+		{
+			name: "several typedefs",
+			input: `/**
+ * Shared types.
+ * @typedef {'sm' | 'lg'} Size
+ * @typedef {import('./variant.js').Variant} Variant
+ */`,
+			want: []Typedef{
+				{Name: "Size", Type: "'sm' | 'lg'"},
+				{Name: "Variant", Type: "import('./variant.js').Variant"},
+			},
+		},
+		{
+			name:  "internal",
+			input: "/**\n * @internal\n * @typedef {string} Secret\n */",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTypedefs(tt.input, qm)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // TestQueryManagerCreation verifies we can create QueryManagers with the right queries.
 func TestQueryManagerCreation(t *testing.T) {
 	t.Run("valid jsdoc query", func(t *testing.T) {
@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package jsdoc
+
+import (
+	jsdoclang "bennypowers.dev/cem/internal/languages/jsdoc"
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+)
+
+type moduleInfo struct {
+	Description string
+	Summary     string
+	Deprecated  M.Deprecated
+	// Tagged is true when a @module, @file, or @fileoverview tag marks the
+	// comment as the module's own
+	Tagged bool
+	// License is true for license headers, which don't document the module
+	License bool
+}
+
+// isModuleTag reports whether a tag marks a comment as documenting its module
+func isModuleTag(tag string) bool {
+	switch tag {
+	case "@module",
+		"@file",
+		"@fileoverview",
+		"@overview":
+		return true
+	}
+	return false
+}
+
+func parseForModule(source string, queryManager *Q.QueryManager) (*moduleInfo, error) {
+	info := moduleInfo{}
+	code := []byte(expandSingleLineComment(source))
+	parser := jsdoclang.BorrowParser()
+	defer jsdoclang.ReturnParser(parser)
+	tree := parser.Parse(code, nil)
+	defer tree.Close()
+	root := tree.RootNode()
+
+	qm, err := Q.NewQueryMatcher(queryManager, "jsdoc", "jsdoc")
+	if err != nil {
+		return nil, err
+	}
+	defer qm.Close()
+
+	var fileDescription string
+	for match := range qm.AllQueryMatches(root, code) {
+		for _, capture := range match.Captures {
+			switch qm.GetCaptureNameByIndex(capture.Index) {
+			case "doc.description":
+				info.Description = normalizeJsdocLines(capture.Node.Utf8Text(code))
+			case "doc.tag":
+				tagInfo := newTagInfo(capture.Node.Utf8Text(code))
+				switch tagInfo.Tag {
+				case "@module":
+					info.Tagged = true
+				case "@file",
+					"@fileoverview",
+					"@overview":
+					info.Tagged = true
+					fileDescription = normalizeJsdocLines(tagInfo.Description)
+				case "@summary":
+					info.Summary = normalizeJsdocLines(tagInfo.Description)
+				case "@deprecated":
+					if tagInfo.Description == "" {
+						info.Deprecated = M.NewDeprecated(true)
+					} else {
+						info.Deprecated = M.NewDeprecated(tagInfo.Description)
+					}
+				case "@example":
+					info.Description = appendExample(info.Description, tagInfo.toExample())
+				case "@license",
+					"@copyright",
+					"@preserve":
+					info.License = true
+				}
+			}
+		}
+	}
+	if info.Description == "" {
+		info.Description = fileDescription
+	}
+	return &info, nil
+}
+
+// EnrichModuleWithJSDoc parses a module's leading JSDoc comment and applies
+// its description, or that of a @file or @fileoverview tag, and its @summary
+// and @deprecated tags to the module. A comment which precedes the module's
+// imports documents it, as does one with a @module, @file, or @fileoverview
+// tag; others document the declaration after them. It reports whether the
+// comment documented the module: license headers never do.
+func EnrichModuleWithJSDoc(jsdocText string, module *M.Module, beforeImports bool, queryManager *Q.QueryManager) (bool, error) {
+	info, err := parseForModule(jsdocText, queryManager)
+	if err != nil {
+		return false, err
+	}
+	if info.License || !(beforeImports || info.Tagged) {
+		return false, nil
+	}
+	module.Description = info.Description
+	module.Summary = info.Summary
+	module.Deprecated = info.Deprecated
+	return true, nil
+}
@@ -29,6 +29,8 @@ type classInfo struct {
 	Alias         string
 	Summary       string
 	Deprecated    M.Deprecated
+	Categories    []string
+	Role          string
 	Attrs         []M.Attribute
 	CssParts      []M.CssPart
 	CssProperties []M.CssCustomProperty
@@ -62,6 +64,7 @@ type cssPropertyInfo struct {
 	Default     string
 	Summary     string
 	Description string
+	Group       string
 	Deprecated  M.Deprecated
 }
 
@@ -98,6 +101,10 @@ func parseForClass(source string, queryManager *Q.QueryManager) (*classInfo, err
 			case "doc.tag":
 				tagInfo := newTagInfo(capture.Node.Utf8Text(code))
 				tagInfo.startByte = capture.Node.StartByte()
+				if isModuleTag(tagInfo.Tag) {
+					// the comment documents the module, not the declaration after it
+					return &classInfo{}, nil
+				}
 				switch tagInfo.Tag {
 				case "@alias":
 					info.Alias = tagInfo.toAlias()
@@ -105,6 +112,8 @@ func parseForClass(source string, queryManager *Q.QueryManager) (*classInfo, err
 					"@attribute":
 					attr := tagInfo.toAttribute()
 					info.Attrs = append(info.Attrs, attr)
+				case "@category":
+					info.Categories = append(info.Categories, tagInfo.toCategories()...)
 				case "@customElement",
 					"@element",
 					"@tagName":
@@ -134,6 +143,8 @@ func parseForClass(source string, queryManager *Q.QueryManager) (*classInfo, err
 					"@fires":
 					event := tagInfo.toEvent()
 					info.Events = append(info.Events, event)
+				case "@role":
+					info.Role = tagInfo.Description
 				case "@slot":
 					slot := tagInfo.toSlot()
 					info.Slots = append(info.Slots, slot)
@@ -185,6 +196,10 @@ func parseForProperty(code string, queryManager *Q.QueryManager) (*propertyInfo,
 					content = normalizeJsdocLines(child.Utf8Text(barr))
 				}
 			}
+			if isModuleTag(tagName) {
+				// the comment documents the module, not the declaration after it
+				return &propertyInfo{}, nil
+			}
 			switch tagName {
 			case "@summary":
 				info.Summary += normalizeJsdocLines(content)
@@ -234,7 +249,7 @@ func parseForCSSProperty(code string, queryManager *Q.QueryManager) (*cssPropert
 		descriptionNodes := match.NodesForCaptureIndex(descriptionCaptureIndex)
 		tagNodes := match.NodesForCaptureIndex(tagCaptureIndex)
 		for _, node := range descriptionNodes {
-			info.Description = normalizeJsdocLines(node.Utf8Text(barr))
+			info.Description, info.Group = splitCssPropertyGroup(normalizeJsdocLines(node.Utf8Text(barr)))
 		}
 		for _, node := range tagNodes {
 			var tagName, tagType, content string
@@ -294,6 +309,10 @@ func parseForMethod(source string, queryManager *Q.QueryManager) (*methodInfo, e
 			case "doc.tag":
 				tagInfo := newTagInfo(capture.Node.Utf8Text(code))
 				tagInfo.startByte = capture.Node.StartByte()
+				if isModuleTag(tagInfo.Tag) {
+					// the comment documents the module, not the declaration after it
+					return &methodInfo{}, nil
+				}
 				switch tagInfo.Tag {
 				case "@param",
 					"@parameter":
@@ -340,6 +359,17 @@ func (info tagInfo) toAlias() string {
 	return matches["alias"]
 }
 
+// toCategories splits a `@category` tag's comma-separated categories
+func (info tagInfo) toCategories() []string {
+	var categories []string
+	for category := range strings.SplitSeq(info.Description, ",") {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
 func (info tagInfo) toAttribute() M.Attribute {
 	re := regexp.MustCompile(`(?ms)[\s*]*@attr(ibute)?[\s*]+(\{(?P<type>[^}]+)\}[\s*]+)?(\[(?P<kv>.*)\]|(?P<name>[\w-]+))([\s*]+-[\s*]+(?P<description>.*))?`)
 	matches := findNamedMatches(re, info.source, true)
@@ -367,7 +397,7 @@ func (info tagInfo) toAttribute() M.Attribute {
 }
 
 func (info tagInfo) toCssPart() M.CssPart {
-	re := regexp.MustCompile(`(?ms)[\s*]*@csspart[\s*]+(?P<name>[\w-]+)([\s*]+-[\s*]+(?P<description>.*))?`)
+	re := regexp.MustCompile(`(?ms)[\s*]*@csspart[\s*]+(?P<name>[\w-]+[\w*-]*)([\s*]+-[\s*]+(?P<description>.*))?`)
 	matches := findNamedMatches(re, info.source, true)
 	return M.CssPart{
 		StartByte: info.startByte,
@@ -375,11 +405,26 @@ func (info tagInfo) toCssPart() M.CssPart {
 			Name:        matches["name"],
 			Description: normalizeJsdocLines(matches["description"]),
 		},
+		Pattern: isNamePattern(matches["name"]),
 	}
 }
 
+// cssPropertyGroupRE matches the group suffix of a CSS custom property's
+// description, e.g. "Background color [group: Surface]"
+var cssPropertyGroupRE = regexp.MustCompile(`(?s)\s*\[group:\s*([^\]]*?)\s*\]\s*$`)
+
+// splitCssPropertyGroup separates the group suffix from a CSS custom
+// property's description
+func splitCssPropertyGroup(description string) (string, string) {
+	loc := cssPropertyGroupRE.FindStringSubmatchIndex(description)
+	if loc == nil {
+		return description, ""
+	}
+	return description[:loc[0]], description[loc[2]:loc[3]]
+}
+
 func (info tagInfo) toCssCustomProperty() M.CssCustomProperty {
-	re := regexp.MustCompile(`(?ms)[\s*]*@cssprop(erty)?\s*(\{(?P<type>[^}]+)\})?[\s*]*(\[(?P<kv>.*)\]|(?P<name>[\w-]+))([\s*]+-[\s*]+(?P<description>.*)$)?`)
+	re := regexp.MustCompile(`(?ms)[\s*]*@cssprop(erty)?\s*(\{(?P<type>[^}]+)\})?[\s*]*(\[(?P<kv>[^\]]*)\]|(?P<name>[\w-]+))([\s*]+-[\s*]+(?P<description>.*)$)?`)
 	matches := findNamedMatches(re, info.source, true)
 	if matches["kv"] != "" {
 		slice := strings.SplitN(matches["kv"], "=", 2)
@@ -390,13 +435,15 @@ func (info tagInfo) toCssCustomProperty() M.CssCustomProperty {
 	} else {
 		info.Name = matches["name"]
 	}
+	description, group := splitCssPropertyGroup(normalizeJsdocLines(matches["description"]))
 	prop := M.CssCustomProperty{
 		FullyQualified: M.FullyQualified{
 			Name:        info.Name,
-			Description: normalizeJsdocLines(matches["description"]),
+			Description: description,
 		},
 		Syntax:    matches["type"],
 		Default:   info.Value,
+		Group:     group,
 		StartByte: info.startByte,
 	}
 	return prop
@@ -453,8 +500,18 @@ func (info tagInfo) toEvent() M.Event {
 	return event
 }
 
+// requiredSlotPattern matches the marker of a required slot, like
+// `@slot (required) header - The header`
+var requiredSlotPattern = regexp.MustCompile(`(@slot[\s*]+)\(required\)[\s*]*`)
+
 func (info tagInfo) toSlot() M.Slot {
-	re := regexp.MustCompile(`(?ms)[\s*]*(@slot[\s*]+-[\s*]+(?P<anonDescription>.*))|(@slot[\s*]+(?P<name>[\w-]+)([\s*]+-[\s*]+(?P<description>.*))?)`)
+	required := requiredSlotPattern.MatchString(info.source)
+	if required {
+		startByte := info.startByte
+		info = newTagInfo(requiredSlotPattern.ReplaceAllString(info.source, "${1}"))
+		info.startByte = startByte
+	}
+	re := regexp.MustCompile(`(?ms)[\s*]*(@slot[\s*]+-[\s*]+(?P<anonDescription>.*))|(@slot[\s*]+(?P<name>[\w-]+[\w*-]*)([\s*]+-[\s*]+(?P<description>.*))?)`)
 	matches := findNamedMatches(re, info.source, true)
 	if matches["description"] != "" {
 		info.Description = normalizeJsdocLines(matches["description"])
@@ -468,9 +525,17 @@ func (info tagInfo) toSlot() M.Slot {
 			Name:        matches["name"],
 			Description: info.Description,
 		},
+		Pattern:  isNamePattern(matches["name"]),
+		Required: required,
 	}
 }
 
+// isNamePattern reports whether a slot or part name documents a family of
+// names with wildcards, like `@slot item-*`
+func isNamePattern(name string) bool {
+	return strings.Contains(name, "*")
+}
+
 func (info tagInfo) toReturn() returnInfo {
 	re := regexp.MustCompile(`(?ms)[\s*]*@return(s)?\s*(\{(?P<type>[^}]+)\})?(([\\s*]+-[\s*])*(?P<description>.*))?`)
 	matches := findNamedMatches(re, info.source, true)
@@ -508,7 +573,8 @@ func (info tagInfo) toParameter() parameterInfo {
 }
 
 func (info tagInfo) toExample() string {
-	content := normalizeJsdocLines(info.Description)
+	// Description was normalized by newTagInfo, or by the caller of handleExampleTag
+	content := info.Description
 
 	// 1. Check for explicit <caption> tag
 	if caption, code := extractExplicitCaption(content); caption != "" {
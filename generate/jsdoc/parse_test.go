@@ -163,10 +163,11 @@ func TestTagInfoToAttribute(t *testing.T) {
 
 func TestTagInfoToCssPart(t *testing.T) {
 	tests := []struct {
-		name     string
-		source   string
-		wantName string
-		wantDesc string
+		name        string
+		source      string
+		wantName    string
+		wantDesc    string
+		wantPattern bool
 	}{
 		{
 			name:     "part with description",
@@ -180,6 +181,13 @@ func TestTagInfoToCssPart(t *testing.T) {
 			wantName: "container",
 			wantDesc: "",
 		},
+		{
+			name:        "part pattern",
+			source:      "@csspart header-* - Headers of each section",
+			wantName:    "header-*",
+			wantDesc:    "Headers of each section",
+			wantPattern: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -187,6 +195,7 @@ func TestTagInfoToCssPart(t *testing.T) {
 			part := info.toCssPart()
 			assert.Equal(t, tt.wantName, part.Name)
 			assert.Equal(t, tt.wantDesc, part.Description)
+			assert.Equal(t, tt.wantPattern, part.Pattern)
 		})
 	}
 }
@@ -203,6 +212,7 @@ func TestTagInfoToCssCustomProperty(t *testing.T) {
 		wantDesc   string
 		wantSyntax string
 		wantDef    string
+		wantGroup  string
 	}{
 		{
 			name:     "simple css property",
@@ -228,6 +238,28 @@ func TestTagInfoToCssCustomProperty(t *testing.T) {
 			wantName: "--spacing",
 			wantDef:  "8px",
 		},
+		{
+			name:       "css property with group",
+			source:     "@cssprop {<color>} --surface-bg - Background color [group: Surface]",
+			wantName:   "--surface-bg",
+			wantSyntax: "<color>",
+			wantDesc:   "Background color",
+			wantGroup:  "Surface",
+		},
+		{
+			name:      "css property with default and group",
+			source:    "@cssprop [--gap=8px] - Space between items [group: Layout Spacing]",
+			wantName:  "--gap",
+			wantDef:   "8px",
+			wantDesc:  "Space between items",
+			wantGroup: "Layout Spacing",
+		},
+		{
+			name:     "brackets mid-description are not a group",
+			source:   "@cssprop --x - Uses [group: a] syntax internally",
+			wantName: "--x",
+			wantDesc: "Uses [group: a] syntax internally",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -237,6 +269,7 @@ func TestTagInfoToCssCustomProperty(t *testing.T) {
 			assert.Equal(t, tt.wantDesc, prop.Description)
 			assert.Equal(t, tt.wantSyntax, prop.Syntax)
 			assert.Equal(t, tt.wantDef, prop.Default)
+			assert.Equal(t, tt.wantGroup, prop.Group)
 		})
 	}
 }
@@ -373,10 +406,12 @@ func TestTagInfoToEvent(t *testing.T) {
 
 func TestTagInfoToSlot(t *testing.T) {
 	tests := []struct {
-		name     string
-		source   string
-		wantName string
-		wantDesc string
+		name         string
+		source       string
+		wantName     string
+		wantDesc     string
+		wantPattern  bool
+		wantRequired bool
 	}{
 		{
 			name:     "named slot with description",
@@ -396,6 +431,27 @@ func TestTagInfoToSlot(t *testing.T) {
 			wantName: "",
 			wantDesc: "The default slot content",
 		},
+		{
+			name:        "slot pattern",
+			source:      "@slot item-* - One slot per item",
+			wantName:    "item-*",
+			wantDesc:    "One slot per item",
+			wantPattern: true,
+		},
+		{
+			name:         "required slot",
+			source:       "@slot (required) header - The header content",
+			wantName:     "header",
+			wantDesc:     "The header content",
+			wantRequired: true,
+		},
+		{
+			name:         "required default slot",
+			source:       "@slot (required) - The default slot content",
+			wantName:     "",
+			wantDesc:     "The default slot content",
+			wantRequired: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -403,6 +459,8 @@ func TestTagInfoToSlot(t *testing.T) {
 			slot := info.toSlot()
 			assert.Equal(t, tt.wantName, slot.Name)
 			assert.Equal(t, tt.wantDesc, slot.Description)
+			assert.Equal(t, tt.wantPattern, slot.Pattern)
+			assert.Equal(t, tt.wantRequired, slot.Required)
 		})
 	}
 }
@@ -0,0 +1,136 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package jsdoc
+
+import (
+	"regexp"
+	"strings"
+
+	jsdoclang "bennypowers.dev/cem/internal/languages/jsdoc"
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// Typedef is a type defined by a JSDoc @typedef tag
+type Typedef struct {
+	Name        string
+	Type        string
+	Summary     string
+	Description string
+	Deprecated  M.Deprecated
+}
+
+// typedefPattern matches the type and name of a @typedef tag. The type may
+// itself contain braces, as in `{{ label: string }}`.
+var typedefPattern = regexp.MustCompile(`(?ms)[\s*]*@typedef[\s*]+\{(?P<type>.+?)\}[\s*]+(?P<name>[\w$]+)([\s*]+(-[\s*]+)?(?P<description>.*))?`)
+
+// EnrichTypeWithJSDoc parses JSDoc comment text and applies its summary,
+// description, and deprecation to a TypeDeclaration. It reports whether the
+// comment marks the type @ignore or @internal.
+func EnrichTypeWithJSDoc(jsdocText string, decl *M.TypeDeclaration, queryManager *Q.QueryManager) (ignore bool, err error) {
+	info, err := parseForProperty(expandSingleLineComment(jsdocText), queryManager)
+	if err != nil {
+		return false, err
+	}
+	decl.Summary = info.Summary
+	decl.Description = info.Description
+	decl.Deprecated = info.Deprecated
+	return info.Ignore, nil
+}
+
+// ParseTypedefs returns the types defined by @typedef tags in JSDoc comment
+// text. A comment which defines one type documents it with its description,
+// @summary, and @deprecated tags; the description following a @typedef's
+// name documents only that type. Comments marked @ignore or @internal define
+// no documented types.
+func ParseTypedefs(jsdocText string, queryManager *Q.QueryManager) ([]Typedef, error) {
+	code := []byte(expandSingleLineComment(jsdocText))
+	parser := jsdoclang.BorrowParser()
+	defer jsdoclang.ReturnParser(parser)
+	tree := parser.Parse(code, nil)
+	defer tree.Close()
+	root := tree.RootNode()
+
+	qm, err := Q.NewQueryMatcher(queryManager, "jsdoc", "jsdoc")
+	if err != nil {
+		return nil, err
+	}
+	defer qm.Close()
+
+	var typedefs []Typedef
+	var description, summary string
+	var deprecated M.Deprecated
+	for match := range qm.AllQueryMatches(root, code) {
+		for _, capture := range match.Captures {
+			switch qm.GetCaptureNameByIndex(capture.Index) {
+			case "doc.description":
+				description = normalizeJsdocLines(capture.Node.Utf8Text(code))
+			case "doc.tag":
+				info := newTagInfo(capture.Node.Utf8Text(code))
+				switch info.Tag {
+				case "@typedef":
+					if typedef, ok := info.toTypedef(); ok {
+						typedefs = append(typedefs, typedef)
+					}
+				case "@summary":
+					summary = normalizeJsdocLines(info.Description)
+				case "@deprecated":
+					if info.Description == "" {
+						deprecated = M.NewDeprecated(true)
+					} else {
+						deprecated = M.NewDeprecated(info.Description)
+					}
+				case "@ignore",
+					"@internal":
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	if len(typedefs) == 1 {
+		typedef := &typedefs[0]
+		if typedef.Description == "" {
+			typedef.Description = description
+		}
+		typedef.Summary = summary
+		typedef.Deprecated = deprecated
+	}
+	return typedefs, nil
+}
+
+func (info tagInfo) toTypedef() (Typedef, bool) {
+	matches := findNamedMatches(typedefPattern, info.source, true)
+	typedef := Typedef{
+		Name:        matches["name"],
+		Type:        strings.TrimSpace(matches["type"]),
+		Description: normalizeJsdocLines(matches["description"]),
+	}
+	return typedef, typedef.Name != "" && typedef.Type != ""
+}
+
+// expandSingleLineComment puts a single-line comment's content on a line of
+// its own, since the JSDoc grammar doesn't parse a tag without a description
+// followed by the end of the comment, as in `/** @internal */`
+func expandSingleLineComment(jsdocText string) string {
+	text := strings.TrimSpace(jsdocText)
+	if strings.Contains(text, "\n") || !strings.HasPrefix(text, "/**") || !strings.HasSuffix(text, "*/") || len(text) < len("/***/") {
+		return jsdocText
+	}
+	content := strings.TrimSpace(text[len("/**") : len(text)-len("*/")])
+	return "/**\n * " + content + "\n */"
+}
@@ -0,0 +1,203 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"strings"
+
+	"bennypowers.dev/cem/generate/jsdoc"
+	M "bennypowers.dev/cem/manifest"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// litPropertyTypes maps the `type` option of a Lit reactive property, which
+// names the constructor used to convert its attribute, to a type
+var litPropertyTypes = map[string]string{
+	"Array":   "unknown[]",
+	"Boolean": "boolean",
+	"Number":  "number",
+	"Object":  "object",
+	"String":  "string",
+}
+
+// litStaticProperty is a reactive property declared in the static
+// `properties` of a LitElement, the form Lit uses without decorators:
+//
+//	static properties = { open: { type: Boolean, reflect: true } };
+//	static get properties() { return { open: { type: Boolean } }; }
+type litStaticProperty struct {
+	name      string
+	attribute string // empty for properties without an attribute
+	reflects  bool
+	typeText  string
+	jsdoc     string
+	startByte uint
+}
+
+// litStaticProperties returns the reactive properties declared in a class's
+// static `properties` field or getter
+func (mp *ModuleProcessor) litStaticProperties(classDeclarationNode *ts.Node) []litStaticProperty {
+	body := classDeclarationNode.ChildByFieldName("body")
+	if body == nil {
+		return nil
+	}
+	for i := range body.NamedChildCount() {
+		member := body.NamedChild(i)
+		if member == nil || !isStaticMember(member) {
+			continue
+		}
+		name := member.ChildByFieldName("name")
+		if name == nil || name.Utf8Text(mp.code) != "properties" {
+			continue
+		}
+		var object *ts.Node
+		switch member.Kind() {
+		case "public_field_definition":
+			object = member.ChildByFieldName("value")
+		case "method_definition":
+			object = returnedObject(member.ChildByFieldName("body"))
+		}
+		if object != nil && object.Kind() == "object" {
+			return mp.parseLitPropertiesObject(object)
+		}
+	}
+	return nil
+}
+
+// isStaticMember reports whether a class body member has the static keyword
+func isStaticMember(member *ts.Node) bool {
+	for i := range member.ChildCount() {
+		if child := member.Child(i); child != nil && child.Kind() == "static" {
+			return true
+		}
+	}
+	return false
+}
+
+// returnedObject finds the object returned by a getter body, e.g.
+// `{ return { open: { type: Boolean } }; }`
+func returnedObject(body *ts.Node) *ts.Node {
+	if body == nil {
+		return nil
+	}
+	for i := range body.NamedChildCount() {
+		statement := body.NamedChild(i)
+		if statement == nil || statement.Kind() != "return_statement" {
+			continue
+		}
+		value := statement.NamedChild(0)
+		for value != nil && value.Kind() == "parenthesized_expression" {
+			value = value.NamedChild(0)
+		}
+		return value
+	}
+	return nil
+}
+
+func (mp *ModuleProcessor) parseLitPropertiesObject(object *ts.Node) (properties []litStaticProperty) {
+	for i := range object.NamedChildCount() {
+		pair := object.NamedChild(i)
+		if pair == nil || pair.Kind() != "pair" {
+			continue
+		}
+		name := propertyKeyText(pair.ChildByFieldName("key"), mp.code)
+		options := pair.ChildByFieldName("value")
+		if name == "" || options == nil || options.Kind() != "object" {
+			continue
+		}
+		property := litStaticProperty{
+			name:      name,
+			attribute: strings.ToLower(name),
+			jsdoc:     jsdoc.ExtractFromNode(pair, mp.code),
+			startByte: pair.StartByte(),
+		}
+		for j := range options.NamedChildCount() {
+			option := options.NamedChild(j)
+			if option == nil || option.Kind() != "pair" {
+				continue
+			}
+			value := option.ChildByFieldName("value")
+			if value == nil {
+				continue
+			}
+			switch propertyKeyText(option.ChildByFieldName("key"), mp.code) {
+			case "type":
+				property.typeText = litPropertyTypes[value.Utf8Text(mp.code)]
+			case "attribute":
+				switch value.Kind() {
+				case "false":
+					property.attribute = ""
+				case "string":
+					property.attribute = propertyKeyText(value, mp.code)
+				}
+			case "reflect":
+				property.reflects = value.Kind() == "true"
+			case "state":
+				// Internal reactive state has no attribute
+				if value.Kind() == "true" {
+					property.attribute = ""
+				}
+			}
+		}
+		if property.attribute == "" {
+			property.reflects = false
+		}
+		properties = append(properties, property)
+	}
+	return properties
+}
+
+// propertyKeyText returns the name of an object key, without quotes for
+// string keys
+func propertyKeyText(key *ts.Node, code []byte) string {
+	if key == nil {
+		return ""
+	}
+	switch key.Kind() {
+	case "property_identifier":
+		return key.Utf8Text(code)
+	case "string":
+		return strings.Trim(key.Utf8Text(code), `"'`)
+	}
+	return ""
+}
+
+// newLitStaticPropertyField creates the field for a reactive property which
+// is not also declared as a class field or accessor
+func newLitStaticPropertyField(property litStaticProperty) *M.CustomElementField {
+	field := &M.CustomElementField{}
+	field.Kind = "field"
+	field.Name = property.name
+	return field
+}
+
+// applyLitStaticProperty amends the field of a reactive property with its
+// options. The field keeps its own type, default, and documentation, e.g.
+// from a class field initializer, and is ordered as the property is declared.
+func (mp *ModuleProcessor) applyLitStaticProperty(field *M.CustomElementField, property litStaticProperty) error {
+	field.Attribute = property.attribute
+	field.Reflects = property.reflects
+	field.StartByte = property.startByte
+	if field.Type == nil && property.typeText != "" {
+		field.Type = &M.Type{Text: property.typeText}
+	}
+	if property.jsdoc != "" && field.Description == "" && field.Summary == "" {
+		return jsdoc.EnrichPropertyWithJSDoc(property.jsdoc, &field.PropertyLike, mp.queryManager)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"cmp"
+	"slices"
+
+	IC "bennypowers.dev/cem/internal/config"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// applyMemberOrder orders the members of each class in the module by the
+// generate.memberOrder config. Members are generated in source order, so
+// the source order, which is the default, needs no work. Sorts are stable,
+// so members which compare equal keep their source order.
+func applyMemberOrder(module *M.Module, order string) {
+	var compare func(a, b M.ClassMember) int
+	switch order {
+	case IC.MemberOrderAlphabetical:
+		compare = func(a, b M.ClassMember) int {
+			return cmp.Compare(classMemberName(a), classMemberName(b))
+		}
+	case IC.MemberOrderKind:
+		compare = func(a, b M.ClassMember) int {
+			return cmp.Compare(classMemberKindRank(a), classMemberKindRank(b))
+		}
+	default:
+		return
+	}
+	for _, decl := range module.Declarations {
+		switch d := decl.(type) {
+		case *M.CustomElementDeclaration:
+			slices.SortStableFunc(d.Members, compare)
+		case *M.ClassDeclaration:
+			slices.SortStableFunc(d.Members, compare)
+		case *M.MixinDeclaration:
+			slices.SortStableFunc(d.Members, compare)
+		case *M.CustomElementMixinDeclaration:
+			slices.SortStableFunc(d.MixinDeclaration.Members, compare)
+		}
+	}
+}
+
+func classMemberName(member M.ClassMember) string {
+	switch m := member.(type) {
+	case *M.CustomElementField:
+		return m.Name
+	case *M.ClassField:
+		return m.Name
+	case *M.ClassMethod:
+		return m.Name
+	}
+	return ""
+}
+
+// classMemberKindRank ranks fields before methods
+func classMemberKindRank(member M.ClassMember) int {
+	if _, ok := member.(*M.ClassMethod); ok {
+		return 1
+	}
+	return 0
+}
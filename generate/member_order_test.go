@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"testing"
+
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Inline: members are built in source order, as the generator emits them
+
+func TestApplyMemberOrder(t *testing.T) {
+	field := func(name string, start uint) M.ClassMember {
+		return &M.ClassField{Kind: "field", PropertyLike: M.PropertyLike{
+			FullyQualified: M.FullyQualified{Name: name},
+			StartByte:      start,
+		}}
+	}
+	property := func(name string, start uint) M.ClassMember {
+		return &M.CustomElementField{ClassField: *field(name, start).(*M.ClassField)}
+	}
+	method := func(name string, start uint) M.ClassMember {
+		return &M.ClassMethod{
+			Kind:           "method",
+			FullyQualified: M.FullyQualified{Name: name},
+			FunctionLike:   M.FunctionLike{StartByte: start},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		order    string
+		expected []string
+	}{
+		{name: "default", order: "", expected: []string{"variant", "toggle", "disabled", "focus", "label"}},
+		{name: "source", order: "source", expected: []string{"variant", "toggle", "disabled", "focus", "label"}},
+		{name: "alphabetical", order: "alphabetical", expected: []string{"disabled", "focus", "label", "toggle", "variant"}},
+		{name: "kind", order: "kind", expected: []string{"variant", "disabled", "label", "toggle", "focus"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decl := &M.CustomElementDeclaration{}
+			decl.Members = []M.ClassMember{
+				property("variant", 10),
+				method("toggle", 20),
+				property("disabled", 30),
+				method("focus", 40),
+				field("label", 50),
+			}
+			module := &M.Module{Declarations: []M.Declaration{decl}}
+
+			applyMemberOrder(module, tt.order)
+
+			names := make([]string, 0, len(decl.Members))
+			for _, member := range decl.Members {
+				names = append(names, classMemberName(member))
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
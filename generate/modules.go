@@ -67,8 +67,9 @@ type ModuleProcessor struct {
 	packageJSON                  *M.PackageJSON
 	ctx                          types.WorkspaceContext
 	fs                           platform.FileSystem
-	cssCache                     CssCache // CSS parsing cache for performance
-	lineOffsets                  []uint   // Cache of newline byte offsets for fast line number lookup
+	cssCache                     CssCache  // CSS parsing cache for performance
+	lineOffsets                  []uint    // Cache of newline byte offsets for fast line number lookup
+	ir                           *moduleIR // Intermediate representations, collected for WithDebugIR
 }
 
 func NewModuleProcessor(
@@ -94,6 +95,9 @@ func NewModuleProcessor(
 	logger.Debug("Processing module: %s (address: %p)", file, module)
 
 	tree := parser.Parse(code, nil)
+	if tree == nil {
+		return nil, &Diagnostic{File: file, Message: "failed to parse"}
+	}
 	root := tree.RootNode()
 
 	packageJson, err := ctx.PackageJSON()
@@ -168,10 +172,18 @@ func (mp *ModuleProcessor) Collect() (
 	if err != nil {
 		errs = errors.Join(errs, err)
 	}
+	err = mp.step("Processing module documentation", 0, mp.processModuleDoc)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
 	err = mp.step("Processing type aliases", 0, mp.processTypeAliases)
 	if err != nil {
 		errs = errors.Join(errs, err)
 	}
+	err = mp.step("Processing type declarations", 0, mp.processTypeDeclarations)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	}
 	err = mp.step("Processing classes", 0, mp.processClasses)
 	if err != nil {
 		errs = errors.Join(errs, err)
@@ -285,6 +297,41 @@ func (mp *ModuleProcessor) processImports() error {
 	return nil
 }
 
+// processModuleDoc documents the module with the JSDoc comment which leads
+// it, before any imports or declarations
+func (mp *ModuleProcessor) processModuleDoc() error {
+	for i := range mp.root.NamedChildCount() {
+		node := mp.root.NamedChild(i)
+		switch node.Kind() {
+		case "hash_bang_line":
+			continue
+		case "comment":
+		default:
+			return nil
+		}
+		text := node.Utf8Text(mp.code)
+		if !strings.HasPrefix(text, "/**") {
+			continue
+		}
+		documented, err := jsdoc.EnrichModuleWithJSDoc(text, mp.module, precedesImports(node), mp.queryManager)
+		if documented || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// precedesImports reports whether an import statement follows a comment,
+// perhaps after other comments
+func precedesImports(comment *ts.Node) bool {
+	for next := comment.NextNamedSibling(); next != nil; next = next.NextNamedSibling() {
+		if next.Kind() != "comment" {
+			return next.Kind() == "import_statement"
+		}
+	}
+	return false
+}
+
 func (mp *ModuleProcessor) processTypeAliases() error {
 	// Initialize the type alias map
 	mp.typeAliasMap = make(map[string]string)
@@ -362,6 +409,9 @@ func (mp *ModuleProcessor) processClasses() error {
 			Name:     className,
 			Captures: captures,
 		}
+		if mp.ir != nil {
+			mp.ir.classes = append(mp.ir.classes, parsed)
+		}
 
 		d, alias, err := mp.generateClassDeclaration(captures, className)
 		if err != nil {
@@ -404,6 +454,9 @@ func (mp *ModuleProcessor) processClasses() error {
 					if existing.Syntax == "" && newProp.Syntax != "" {
 						existing.Syntax = newProp.Syntax
 					}
+					if existing.Group == "" && newProp.Group != "" {
+						existing.Group = newProp.Group
+					}
 				} else {
 					// New property not in JSDoc, append it
 					ce.CustomElement.CssProperties = append(ce.CustomElement.CssProperties, newProp)
@@ -629,8 +682,9 @@ func (mp *ModuleProcessor) processDeclarations() error {
 	}
 
 	// if the declaration for this export exists in the same module,
-	// append its reference to the export object.
-	for name := range mp.classNamesAdded {
+	// append its reference to the export object. Sorted, so that exports
+	// at the same position keep a stable order.
+	for _, name := range slices.Sorted(maps.Keys(mp.classNamesAdded)) {
 		reference := M.NewReference(name, "", mp.module.Path)
 		index := slices.IndexFunc(mp.module.Declarations, func(d M.Declaration) bool {
 			if ce, ok := d.(*M.CustomElementDeclaration); ok {
@@ -737,3 +791,31 @@ func (mp *ModuleProcessor) resolveImportSpec(importSpec string) string {
 	// Clean the path (remove . and .. segments)
 	return path.Clean(resolvedPath)
 }
+
+// resolveImportReference resolves a name used in this module to the
+// package and module which declare it. Names imported with a relative
+// specifier, or from this package's own name, are local to the package.
+// Names imported from other packages reference that package, with the
+// module named by the specifier's subpath, if any, which
+// resolveExternalReferences later corrects using the dependency's manifest.
+// Imported names are resolved to the name they are exported with, e.g.
+// Base for `import { Base as MyBase }`. Names which are not imported are
+// returned as is.
+func (mp *ModuleProcessor) resolveImportReference(binding string) (name, pkg, module string) {
+	imp, found := mp.importBindingToSpecMap[binding]
+	if !found {
+		return binding, "", ""
+	}
+	pkgName := extractPackageName(imp.spec)
+	if pkgName == "" {
+		return imp.name, "", mp.resolveImportSpec(imp.spec)
+	}
+	subpath := strings.TrimPrefix(extractSubpath(imp.spec), "./")
+	if subpath == "." {
+		subpath = ""
+	}
+	if mp.packageJSON != nil && mp.packageJSON.Name == pkgName {
+		return imp.name, "", subpath
+	}
+	return imp.name, pkgName, subpath
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"strings"
+	"unicode"
+
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// observedAttributes returns the attributes a vanilla element declares in
+// its static `observedAttributes` field or getter:
+//
+//	static observedAttributes = ['size'];
+//	static get observedAttributes() { return ['size']; }
+//
+// Each attribute takes its type, default, and docs from the instance field
+// or accessor which reflects it, when the class has one, and that field is
+// marked as the attribute's property.
+func observedAttributes(names []Q.CaptureInfo, members []M.ClassMember) []M.Attribute {
+	var attributes []M.Attribute
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name.Text] {
+			continue
+		}
+		seen[name.Text] = true
+
+		attribute := M.Attribute{
+			StartByte: name.StartByte,
+			FullyQualified: M.FullyQualified{
+				Name: name.Text,
+			},
+		}
+		if field := observedAttributeField(members, name.Text); field != nil {
+			field.Attribute = name.Text
+			attribute.FieldName = field.Name
+			attribute.Type = field.Type
+			attribute.Default = field.Default
+			attribute.Summary = field.Summary
+			attribute.Description = field.Description
+			attribute.Deprecated = field.Deprecated
+		}
+		attributes = append(attributes, attribute)
+	}
+	return attributes
+}
+
+// observedAttributeField finds the public instance field or accessor for an
+// observed attribute, named either exactly like the attribute, or by
+// convention, e.g. `ariaLabel` or `arialabel` for `aria-label`
+func observedAttributeField(members []M.ClassMember, attribute string) *M.CustomElementField {
+	var conventional *M.CustomElementField
+	for _, member := range members {
+		field, ok := member.(*M.CustomElementField)
+		if !ok || field.Static || field.Privacy == M.Private {
+			continue
+		}
+		if field.Name == attribute {
+			return field
+		}
+		if conventional == nil && (strings.ToLower(field.Name) == attribute || kebabCase(field.Name) == attribute) {
+			conventional = field
+		}
+	}
+	return conventional
+}
+
+// kebabCase converts a camelCase name to kebab-case
+func kebabCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
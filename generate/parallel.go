@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"maps"
+	"math/rand/v2"
 	"runtime"
 	"sync"
 
@@ -50,6 +51,7 @@ type ModuleProcessorSimpleFunc func(job processJob, qm *Q.QueryManager, parser *
 // Worker Management: Optimizes worker count based on job size (avoids over-allocation)
 type ModuleBatchProcessor struct {
 	numWorkers   int
+	seed         int64 // shuffles the order jobs are run in, when non-zero
 	queryManager *Q.QueryManager
 	depTracker   *FileDependencyTracker
 	cssCache     CssCache
@@ -69,6 +71,17 @@ type ProcessingResult struct {
 	Errors      error
 }
 
+// jobResult holds what processing one job returned
+type jobResult struct {
+	module      *M.Module
+	tagAliases  map[string]string
+	typeAliases map[string]string
+	imports     map[string]importInfo
+	logger      *LogCtx
+	err         error
+	done        bool // false when the job was cancelled before it ran
+}
+
 // moduleTypeAliasesMap tracks type aliases for each module (key: module path)
 type moduleTypeAliasesMap map[string]map[string]string
 
@@ -100,6 +113,13 @@ func (mbp *ModuleBatchProcessor) SetWorkerCount(count int) {
 	}
 }
 
+// SetSeed shuffles the order in which workers pick up jobs, so that tests
+// and CI can vary the schedule. Results are always collected in job order,
+// so the seed never changes the output. Zero runs jobs in order.
+func (mbp *ModuleBatchProcessor) SetSeed(seed int64) {
+	mbp.seed = seed
+}
+
 // WorkerCount returns the configured number of workers
 func (mbp *ModuleBatchProcessor) WorkerCount() int {
 	return mbp.numWorkers
@@ -154,31 +174,30 @@ func (mbp *ModuleBatchProcessor) processModulesInternal(
 	numWorkers := min(len(jobs), mbp.numWorkers)
 	logging.Debug("Starting generation with %d workers", numWorkers)
 
-	// Initialize result collection
-	var wg sync.WaitGroup
-	var aliasesMu sync.Mutex
-	var typeAliasesMu sync.Mutex
-	var importsMu sync.Mutex
-	var modulesMu sync.Mutex
-	var errsMu sync.Mutex
-	var logsMu sync.Mutex
-
-	errsList := make([]error, 0)
-	logs := make([]*LogCtx, 0, len(jobs))
-	aliases := make(map[string]string)
-	typeAliasesMap := make(moduleTypeAliasesMap)
-	importsMap := make(moduleImportsMap)
-	var modules []M.Module
+	// Each job writes only to its own result slot, so that results are
+	// collected in job order, whichever worker ran the job and whenever
+	// it finished
+	results := make([]jobResult, len(jobs))
+	order := make([]int, len(jobs))
+	for i := range order {
+		order[i] = i
+	}
+	if mbp.seed != 0 {
+		// Vary the schedule, e.g. to check that it doesn't change the output
+		r := rand.New(rand.NewPCG(uint64(mbp.seed), 0))
+		r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	}
 
-	jobsChan := make(chan processJob, len(jobs))
+	jobsChan := make(chan int, len(jobs))
 
 	// Fill jobs channel
-	for _, job := range jobs {
-		jobsChan <- job
+	for _, i := range order {
+		jobsChan <- i
 	}
 	close(jobsChan)
 
 	// Start workers
+	var wg sync.WaitGroup
 	wg.Add(numWorkers)
 	for range numWorkers {
 		go func() {
@@ -186,7 +205,7 @@ func (mbp *ModuleBatchProcessor) processModulesInternal(
 			parser := typescript.BorrowParser()
 			defer typescript.ReturnParser(parser)
 
-			for job := range jobsChan {
+			for i := range jobsChan {
 				// Check for cancellation
 				select {
 				case <-ctx.Done():
@@ -194,53 +213,45 @@ func (mbp *ModuleBatchProcessor) processModulesInternal(
 				default:
 				}
 
-				module, tagAliases, typeAliases, imports, logger, err := processor(job, mbp.queryManager, parser)
-
-				// Handle errors
-				if err != nil {
-					errsMu.Lock()
-					errsList = append(errsList, err)
-					errsMu.Unlock()
-				}
-
-				// Collect logs (always save duration for bar chart)
-				logsMu.Lock()
-				logs = append(logs, logger)
-				logsMu.Unlock()
-
-				// Merge tag aliases thread-safely
-				if len(tagAliases) > 0 {
-					aliasesMu.Lock()
-					maps.Copy(aliases, tagAliases)
-					aliasesMu.Unlock()
-				}
-
-				// Collect type aliases thread-safely
-				if module != nil && len(typeAliases) > 0 {
-					typeAliasesMu.Lock()
-					typeAliasesMap[module.Path] = typeAliases
-					typeAliasesMu.Unlock()
-				}
-
-				// Collect imports thread-safely
-				if module != nil && len(imports) > 0 {
-					importsMu.Lock()
-					importsMap[module.Path] = imports
-					importsMu.Unlock()
-				}
-
-				// Collect modules
-				if module != nil {
-					modulesMu.Lock()
-					modules = append(modules, *module)
-					modulesMu.Unlock()
-				}
+				result := &results[i]
+				result.module, result.tagAliases, result.typeAliases, result.imports, result.logger, result.err = processor(jobs[i], mbp.queryManager, parser)
+				result.done = true
 			}
 		}()
 	}
 
 	wg.Wait()
 
+	// Merge results in job order, so that e.g. when two files declare the
+	// same alias, the later file always wins
+	errsList := make([]error, 0)
+	logs := make([]*LogCtx, 0, len(jobs))
+	aliases := make(map[string]string)
+	typeAliasesMap := make(moduleTypeAliasesMap)
+	importsMap := make(moduleImportsMap)
+	var modules []M.Module
+	for _, result := range results {
+		if !result.done {
+			continue
+		}
+		if result.err != nil {
+			errsList = append(errsList, result.err)
+		}
+		// Collect logs (always save duration for bar chart)
+		logs = append(logs, result.logger)
+		maps.Copy(aliases, result.tagAliases)
+		if result.module == nil {
+			continue
+		}
+		if len(result.typeAliases) > 0 {
+			typeAliasesMap[result.module.Path] = result.typeAliases
+		}
+		if len(result.imports) > 0 {
+			importsMap[result.module.Path] = result.imports
+		}
+		modules = append(modules, *result.module)
+	}
+
 	// Combine errors
 	var errs error
 	if len(errsList) > 0 {
@@ -0,0 +1,181 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"regexp"
+	"slices"
+
+	M "bennypowers.dev/cem/manifest"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// platformOptions returns how a custom element class uses the platform, or
+// nil if it says nothing about it. It reads:
+//
+//	static shadowRootOptions = { ...LitElement.shadowRootOptions, delegatesFocus: true };
+//	static formAssociated = true;
+//	this.attachShadow({ mode: 'open', delegatesFocus: true });
+//	this.#internals = this.attachInternals();
+//
+// It also records the notable platform features the class uses, like the
+// popover API or CSS anchor positioning in its styles.
+func (mp *ModuleProcessor) platformOptions(classDeclarationNode *ts.Node) *M.PlatformOptions {
+	body := classDeclarationNode.ChildByFieldName("body")
+	if body == nil {
+		return nil
+	}
+	options := &M.PlatformOptions{}
+	for i := range body.NamedChildCount() {
+		member := body.NamedChild(i)
+		if member == nil || !isStaticMember(member) {
+			continue
+		}
+		name := member.ChildByFieldName("name")
+		if name == nil {
+			continue
+		}
+		var value *ts.Node
+		switch member.Kind() {
+		case "public_field_definition":
+			value = member.ChildByFieldName("value")
+		case "method_definition":
+			value = returnedObject(member.ChildByFieldName("body"))
+		}
+		if value == nil {
+			continue
+		}
+		switch name.Utf8Text(mp.code) {
+		case "shadowRootOptions":
+			if value.Kind() == "object" {
+				options.ShadowRoot = mp.parseShadowRootOptions(value)
+			}
+		case "formAssociated":
+			options.FormAssociated = value.Kind() == "true"
+		}
+	}
+	mp.findPlatformCalls(body, options)
+	options.Features = mp.platformFeatures(body, options)
+
+	if options.IsEmpty() {
+		return nil
+	}
+	return options
+}
+
+// findPlatformCalls finds the `attachShadow()` and `attachInternals()` calls
+// in a class body, e.g. in its constructor
+func (mp *ModuleProcessor) findPlatformCalls(node *ts.Node, options *M.PlatformOptions) {
+	if node.Kind() == "call_expression" {
+		if function := node.ChildByFieldName("function"); function != nil && function.Kind() == "member_expression" {
+			switch propertyKeyText(function.ChildByFieldName("property"), mp.code) {
+			case "attachShadow":
+				if options.ShadowRoot == nil {
+					if args := node.ChildByFieldName("arguments"); args != nil {
+						if init := args.NamedChild(0); init != nil && init.Kind() == "object" {
+							options.ShadowRoot = mp.parseShadowRootOptions(init)
+						}
+					}
+				}
+			case "attachInternals":
+				options.Internals = true
+			}
+		}
+	}
+	for i := range node.NamedChildCount() {
+		if child := node.NamedChild(i); child != nil {
+			mp.findPlatformCalls(child, options)
+		}
+	}
+}
+
+// platformFeaturePatterns find uses of platform features in a class body's
+// code, styles, and templates
+var platformFeaturePatterns = map[string]*regexp.Regexp{
+	M.FeatureAnchorPositioning:    regexp.MustCompile(`\b(?:anchor-name|position-anchor|position-area|position-try)\s*:|\banchor(?:-size)?\(\s*--`),
+	M.FeatureDeclarativeShadowDOM: regexp.MustCompile(`(?i)\bshadowrootmode\b|\binternals\.shadowRoot\b`),
+	M.FeaturePopover:              regexp.MustCompile(`\b(?:show|hide|toggle)Popover\s*\(|:popover-open\b|\.popover\s*=[^=]|\spopover(?:target(?:action)?)?(?:\s*=|[\s/>])`),
+}
+
+// platformFeatures returns the sorted platform features used in a class
+// body. Comments are ignored, so that documentation which mentions a
+// feature doesn't count as using it.
+func (mp *ModuleProcessor) platformFeatures(body *ts.Node, options *M.PlatformOptions) []string {
+	code := slices.Clone(mp.code[body.StartByte():body.EndByte()])
+	blankComments(body, code, body.StartByte())
+	var features []string
+	for feature, pattern := range platformFeaturePatterns {
+		if pattern.Match(code) {
+			features = append(features, feature)
+		}
+	}
+	if options.Internals {
+		features = append(features, M.FeatureElementInternals)
+	}
+	slices.Sort(features)
+	return features
+}
+
+// blankComments replaces the comments under node in code, which starts at
+// byte offset start, with spaces
+func blankComments(node *ts.Node, code []byte, start uint) {
+	if node.Kind() == "comment" {
+		for i := node.StartByte(); i < node.EndByte(); i++ {
+			code[i-start] = ' '
+		}
+		return
+	}
+	for i := range node.NamedChildCount() {
+		if child := node.NamedChild(i); child != nil {
+			blankComments(child, code, start)
+		}
+	}
+}
+
+// parseShadowRootOptions reads the literal options in a ShadowRootInit
+// object, or returns nil if it has none. Options spread from elsewhere, e.g.
+// a superclass's, are unknown.
+func (mp *ModuleProcessor) parseShadowRootOptions(object *ts.Node) *M.ShadowRootOptions {
+	options := &M.ShadowRootOptions{}
+	for i := range object.NamedChildCount() {
+		pair := object.NamedChild(i)
+		if pair == nil || pair.Kind() != "pair" {
+			continue
+		}
+		value := pair.ChildByFieldName("value")
+		if value == nil {
+			continue
+		}
+		switch propertyKeyText(pair.ChildByFieldName("key"), mp.code) {
+		case "mode":
+			options.Mode = propertyKeyText(value, mp.code)
+		case "delegatesFocus":
+			options.DelegatesFocus = value.Kind() == "true"
+		case "slotAssignment":
+			options.SlotAssignment = propertyKeyText(value, mp.code)
+		case "clonable":
+			options.Clonable = value.Kind() == "true"
+		case "serializable":
+			options.Serializable = value.Kind() == "true"
+		}
+	}
+	if *options == (M.ShadowRootOptions{}) {
+		return nil
+	}
+	return options
+}
@@ -23,6 +23,7 @@ import (
 
 	"bennypowers.dev/cem/internal/logging"
 	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
 	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/types"
 )
@@ -35,6 +36,12 @@ import (
 // - generate/generate.go: NewGenerateSession() for single generation runs
 // - generate/session_watch.go: Used throughout watch session lifecycle
 //
+// Embedding: GenerateSession is the stable entry point for Go programs which
+// embed manifest generation, e.g. static site generators or custom dev
+// servers. Create one with NewGenerateSessionForDir, call GenerateFullManifest
+// once, then feed file changes to ProcessChangedFiles and receive updated
+// manifests through Subscribe. See the package documentation for an example.
+//
 // Thread Safety: sync.RWMutex protects inMemoryManifest and moduleIndex fields for concurrent access
 type GenerateSession struct {
 	setupCtx         *GenerateContext
@@ -42,6 +49,13 @@ type GenerateSession struct {
 	moduleIndex      map[string]*M.Module // path -> module for O(1) lookups, protected by mu
 	mu               sync.RWMutex         // protects inMemoryManifest and moduleIndex
 	maxWorkers       int                  // configured max workers for batch processing (0 = use NumCPU)
+	seed             int64                // shuffles the order files are processed in, when set by WithSeed
+	diagnostics      []Diagnostic         // tolerated per-file problems, protected by mu
+	debugIR          *debugIRRecorder     // writes intermediate representations, when set by WithDebugIR
+
+	subscribers    map[int]func(*M.Package) // protected by subMu
+	nextSubscriber int                      // protected by subMu
+	subMu          sync.Mutex               // protects subscribers and nextSubscriber
 }
 
 // NewGenerateSession creates a new session with initialized setup context.
@@ -53,16 +67,80 @@ type GenerateSession struct {
 // - generate/generate.go:234 (single generation)
 //
 // Performance: Expensive operation (~10-50ms) due to tree-sitter query compilation
-func NewGenerateSession(ctx types.WorkspaceContext, fsys platform.FileSystem) (*GenerateSession, error) {
+func NewGenerateSession(ctx types.WorkspaceContext, fsys platform.FileSystem, options ...SessionOption) (*GenerateSession, error) {
 	setupCtx, err := NewGenerateContext(ctx, fsys)
 	if err != nil {
 		return nil, fmt.Errorf("initialize setup context: %w", err)
 	}
 
-	return &GenerateSession{
+	gs := &GenerateSession{
 		setupCtx:    setupCtx,
 		moduleIndex: make(map[string]*M.Module),
-	}, nil
+	}
+	for _, option := range options {
+		option(gs)
+	}
+	return gs, nil
+}
+
+// NewGenerateSessionForDir creates a session for the package rooted at dir,
+// reading its cem config and sources from the OS filesystem. It is the
+// constructor for programs outside this module, which cannot name the
+// workspace and filesystem types that NewGenerateSession takes.
+//
+// Callers must Close the session when done.
+func NewGenerateSessionForDir(dir string) (*GenerateSession, error) {
+	ctx := W.NewFileSystemWorkspaceContext(dir)
+	if err := ctx.Init(); err != nil {
+		return nil, fmt.Errorf("initialize workspace %s: %w", dir, err)
+	}
+	return NewGenerateSession(ctx, platform.NewOSFileSystem())
+}
+
+// Subscribe registers fn to receive the manifest each time the session
+// produces a new one, whether from GenerateFullManifest or from incremental
+// processing. It returns a function which removes the subscription.
+//
+// Subscribers run synchronously, in no particular order, on the goroutine
+// which produced the manifest, after the session's locks are released. They
+// share one deep copy of the manifest per update, which they must treat as
+// read-only. A slow subscriber delays the caller of ProcessChangedFiles, so
+// hand long-running work off to another goroutine.
+func (gs *GenerateSession) Subscribe(fn func(*M.Package)) (unsubscribe func()) {
+	gs.subMu.Lock()
+	defer gs.subMu.Unlock()
+	if gs.subscribers == nil {
+		gs.subscribers = make(map[int]func(*M.Package))
+	}
+	id := gs.nextSubscriber
+	gs.nextSubscriber++
+	gs.subscribers[id] = fn
+	return func() {
+		gs.subMu.Lock()
+		defer gs.subMu.Unlock()
+		delete(gs.subscribers, id)
+	}
+}
+
+// notifySubscribers sends a deep copy of the in-memory manifest to each
+// subscriber. Must be called without gs.mu held.
+func (gs *GenerateSession) notifySubscribers() {
+	gs.subMu.Lock()
+	subscribers := make([]func(*M.Package), 0, len(gs.subscribers))
+	for _, fn := range gs.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	gs.subMu.Unlock()
+	if len(subscribers) == 0 {
+		return
+	}
+	pkg := gs.InMemoryManifestDeep()
+	if pkg == nil {
+		return
+	}
+	for _, fn := range subscribers {
+		fn(pkg)
+	}
 }
 
 // Close releases resources held by the session
@@ -90,6 +168,10 @@ func (gs *GenerateSession) DependencyTracker() *FileDependencyTracker {
 
 // GenerateFullManifest performs a complete generation using the existing logic.
 // This is used for the initial generation in watch mode and for regular generate command.
+//
+// Generation checks ctx between its phases and within the worker pool, and
+// returns ctx.Err() once ctx is cancelled. A cancelled generation leaves the
+// previous in-memory manifest in place and notifies no subscribers.
 func (gs *GenerateSession) GenerateFullManifest(ctx context.Context) (*M.Package, error) {
 	// Check for cancellation
 	select {
@@ -111,6 +193,7 @@ func (gs *GenerateSession) GenerateFullManifest(ctx context.Context) (*M.Package
 	}
 
 	modules, logs, aliases, typeAliases, imports, err := gs.processWithContext(ctx, result)
+	diagnostics, err := splitDiagnostics(err)
 	if err != nil {
 		return nil, WrapProcessError(err)
 	}
@@ -131,11 +214,15 @@ func (gs *GenerateSession) GenerateFullManifest(ctx context.Context) (*M.Package
 	gs.inMemoryManifest = &pkg
 	gs.rebuildModuleIndex()
 	gs.mu.Unlock()
+	gs.setDiagnostics(diagnostics, nil)
+	gs.debugIR.recordPackage(&pkg)
 
 	if logging.AtLevel(logging.LogLevelDebug) {
 		RenderBarChart(logs)
 	}
 
+	gs.notifySubscribers()
+
 	return &pkg, nil
 }
 
@@ -220,6 +307,15 @@ func (gs *GenerateSession) SetMaxWorkers(count int) {
 	gs.maxWorkers = count
 }
 
+// WithSeed shuffles the order in which workers process files, seeded so that
+// a schedule can be reproduced. The manifest is the same for every seed and
+// worker count; tests use it to vary the schedule and check that it is.
+func WithSeed(seed int64) SessionOption {
+	return func(gs *GenerateSession) {
+		gs.seed = seed
+	}
+}
+
 // WorkerCount returns the number of workers that would be used for parallel processing.
 // This creates a temporary processor to get the actual configured worker count.
 func (gs *GenerateSession) WorkerCount() int {
@@ -261,7 +357,7 @@ func (gs *GenerateSession) processWithDeps(ctx context.Context, result preproces
 	// Create jobs for all included files
 	jobs := make([]processJob, 0, len(result.includedFiles))
 	for _, file := range result.includedFiles {
-		jobs = append(jobs, processJob{file: file, ctx: gs.setupCtx.WorkspaceContext})
+		jobs = append(jobs, processJob{file: file, ctx: gs.setupCtx.WorkspaceContext, debugIR: gs.debugIR})
 	}
 
 	// Use parallel processor with dependency tracking
@@ -274,6 +370,7 @@ func (gs *GenerateSession) processWithDeps(ctx context.Context, result preproces
 	if maxWorkers > 0 {
 		processor.SetWorkerCount(maxWorkers)
 	}
+	processor.SetSeed(gs.seed)
 
 	processingResult := processor.ProcessModules(ctx, jobs, ModuleProcessorFunc(processModule))
 
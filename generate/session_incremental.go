@@ -28,7 +28,16 @@ import (
 	M "bennypowers.dev/cem/manifest"
 )
 
-// ProcessChangedFiles performs incremental processing for a set of changed files
+// ProcessChangedFiles performs incremental processing for a set of changed files,
+// given as paths relative to the package root, e.g. "src/my-button.ts".
+// Only the modules which depend on the changed files are reprocessed; when
+// there is no manifest yet, or too many modules are affected, it falls back
+// to GenerateFullManifest. When no module is affected it returns the current
+// manifest without notifying subscribers.
+//
+// Cancelling ctx abandons the update and returns ctx.Err(). Embedders which
+// debounce file events should cancel the previous call's context before
+// starting the next, as the watch session does.
 func (gs *GenerateSession) ProcessChangedFiles(ctx context.Context, changedFiles []string) (*M.Package, error) {
 	return gs.ProcessChangedFilesWithSkip(ctx, changedFiles, false)
 }
@@ -98,9 +107,11 @@ func (gs *GenerateSession) ProcessModulesIncrementalWithSkip(ctx context.Context
 
 	// Process only the affected modules
 	updatedModules, logs, aliases, err := gs.processSpecificModules(ctx, result, modulePaths)
+	diagnostics, err := splitDiagnostics(err)
 	if err != nil {
 		return nil, WrapIncrementalError("module processing", err)
 	}
+	gs.setDiagnostics(diagnostics, modulePaths)
 
 	// Merge the updated modules into the existing manifest
 	gs.MergeModulesIntoManifest(updatedModules)
@@ -122,6 +133,8 @@ func (gs *GenerateSession) ProcessModulesIncrementalWithSkip(ctx context.Context
 
 	logging.Debug("Processed %d modules incrementally", len(logs))
 
+	gs.notifySubscribers()
+
 	return updatedManifest, nil
 }
 
@@ -137,7 +150,7 @@ func (gs *GenerateSession) processSpecificModules(ctx context.Context, result pr
 	validJobs := make([]processJob, 0, len(modulePaths))
 	for _, modulePath := range modulePaths {
 		if includedSet[modulePath] {
-			validJobs = append(validJobs, processJob{file: modulePath, ctx: gs.setupCtx.WorkspaceContext})
+			validJobs = append(validJobs, processJob{file: modulePath, ctx: gs.setupCtx.WorkspaceContext, debugIR: gs.debugIR})
 		} else {
 			logging.Trace("Skipping module not in included files: %s", modulePath)
 		}
@@ -187,7 +200,7 @@ func (gs *GenerateSession) MergeModulesIntoManifest(updatedModules []M.Module) {
 	}
 }
 
-// applyPostProcessingToModules applies demo discovery and design tokens to specific modules only, with optional demo discovery skipping
+// applyPostProcessingToModules applies categories, demo discovery, and design tokens to specific modules only, with optional demo discovery skipping
 func (gs *GenerateSession) applyPostProcessingToModules(ctx context.Context, result preprocessResult, allTagAliases map[string]string, modules []M.Module, skipDemoDiscovery bool) error {
 	select {
 	case <-ctx.Done():
@@ -205,6 +218,14 @@ func (gs *GenerateSession) applyPostProcessingToModules(ctx context.Context, res
 
 	// Build the demo map once if needed and not skipped
 	var demoMap map[string][]string
+	var hasConventions bool
+	var categories map[string][]string
+	var memberOrder string
+	if cfg, err := gs.setupCtx.Config(); err == nil {
+		hasConventions = !skipDemoDiscovery && len(cfg.Generate.DemoDiscovery.Conventions) > 0
+		categories = cfg.Generate.Categories
+		memberOrder = cfg.Generate.MemberOrder
+	}
 	if !skipDemoDiscovery && len(result.demoFiles) > 0 {
 		var err error
 		cfg, cfgErr := gs.setupCtx.Config()
@@ -221,19 +242,25 @@ func (gs *GenerateSession) applyPostProcessingToModules(ctx context.Context, res
 		}
 	}
 
+	references := newExternalReferenceResolver(gs.setupCtx.Root(), gs.setupCtx.FileSystem())
+
 	// Process each updated module
 	for i := range modules {
 		wg.Add(1)
 		go func(module *M.Module) {
 			defer wg.Done()
 
+			applyConfigCategories(module, categories)
+			applyMemberOrder(module, memberOrder)
+			resolveExternalReferences(module, references)
+
 			// Apply design tokens if available
 			if result.designTokens != nil {
 				DT.MergeDesignTokensToModule(module, result.designTokens)
 			}
 
 			// Discover demos and attach to module if available
-			if len(demoMap) > 0 {
+			if len(demoMap) > 0 || hasConventions {
 				err := DD.DiscoverDemos(gs.setupCtx.WorkspaceContext, allTagAliases, module, gs.setupCtx.QueryManager(), demoMap, gs.setupCtx.FileSystem())
 				if err != nil {
 					errsMu.Lock()
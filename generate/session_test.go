@@ -661,3 +661,39 @@ func TestGenerateSession_IncrementalAutoDeriveAliases(t *testing.T) {
 			"incremental: demo URL %q should contain /button/ (alias-stripped)", demo.URL)
 	}
 }
+
+func TestGenerateSession_Subscribe(t *testing.T) {
+	session, err := NewGenerateSessionForDir("../examples/minimal")
+	require.NoError(t, err)
+	defer session.Close()
+
+	cfg, err := session.WorkspaceContext().Config()
+	require.NoError(t, err)
+	cfg.Generate.Files = []string{"elements/hello-world/hello-world.ts"}
+
+	var received []*M.Package
+	unsubscribe := session.Subscribe(func(pkg *M.Package) {
+		received = append(received, pkg)
+	})
+
+	pkg, err := session.GenerateFullManifest(context.Background())
+	require.NoError(t, err)
+	require.Len(t, received, 1, "full generation should notify subscribers")
+	assert.Equal(t, len(pkg.Modules), len(received[0].Modules))
+	assert.NotSame(t, pkg, received[0], "subscribers should receive a copy")
+
+	_, err = session.ProcessModulesIncremental(context.Background(), []string{"elements/hello-world/hello-world.ts"})
+	require.NoError(t, err)
+	require.Len(t, received, 2, "incremental processing should notify subscribers")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = session.GenerateFullManifest(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, received, 2, "cancelled generation should not notify subscribers")
+
+	unsubscribe()
+	_, err = session.GenerateFullManifest(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, received, 2, "unsubscribed callbacks should not be called")
+}
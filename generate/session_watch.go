@@ -22,11 +22,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	C "bennypowers.dev/cem/cmd/config"
+	DD "bennypowers.dev/cem/generate/demodiscovery"
 	"bennypowers.dev/cem/internal/logging"
 	"bennypowers.dev/cem/internal/platform"
 	"bennypowers.dev/cem/internal/tui"
@@ -48,6 +53,7 @@ type WatchSession struct {
 	lastWrittenHash map[string][32]byte  // file path -> SHA256 hash of content we wrote
 	lastWrittenTime map[string]time.Time // file path -> modification time when we wrote it
 	pendingChanges  map[string]bool      // files that have changed and are pending processing
+	sourcesRemoved  bool                 // a watched file was removed or renamed since the last cycle
 	// Demo discovery optimization
 	demoFilesChanged bool                  // flag set when demo files change (event-driven)
 	lastDemoConfig   C.DemoDiscoveryConfig // stored config copy for change detection
@@ -160,6 +166,22 @@ func (ws *WatchSession) setupWatcher() (*fsnotify.Watcher, error) {
 		}
 	}
 
+	// Add demo convention directories next to source files, e.g. demo/
+	if err == nil {
+		for _, convention := range cfg.Generate.DemoDiscovery.Conventions {
+			conventionDir := filepath.Dir(filepath.FromSlash(convention))
+			if conventionDir == "." || strings.Contains(conventionDir, "{{") {
+				continue
+			}
+			for dir := range maps.Clone(dirs) {
+				demoDir := filepath.Join(dir, conventionDir)
+				if info, err := ws.fs.Stat(demoDir); err == nil && info.IsDir() {
+					dirs[demoDir] = true
+				}
+			}
+		}
+	}
+
 	// Watch all identified directories
 	for dir := range dirs {
 		if err := watcher.Add(dir); err != nil {
@@ -172,8 +194,9 @@ func (ws *WatchSession) setupWatcher() (*fsnotify.Watcher, error) {
 
 // handleFileChange processes a file system event with debouncing
 func (ws *WatchSession) handleFileChange(event fsnotify.Event) {
-	// Only care about write and create events
-	if event.Op&fsnotify.Write == 0 && event.Op&fsnotify.Create == 0 {
+	// Only care about write, create, remove, and rename events
+	removed := event.Op&(fsnotify.Remove|fsnotify.Rename) != 0
+	if event.Op&(fsnotify.Write|fsnotify.Create) == 0 && !removed {
 		return
 	}
 
@@ -203,13 +226,19 @@ func (ws *WatchSession) handleFileChange(event fsnotify.Event) {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
-	// Track this file as changed - convert FS path to module path
-	modulePath, err := ws.ctx.FSPathToModule(event.Name)
-	if err != nil {
-		// If conversion fails, use the FS path as-is
-		modulePath = event.Name
+	if removed {
+		// Incremental processing only replaces modules, so dropping the
+		// removed file's module takes a full rebuild
+		ws.sourcesRemoved = true
+	} else {
+		// Track this file as changed - convert FS path to module path
+		modulePath, err := ws.ctx.FSPathToModule(event.Name)
+		if err != nil {
+			// If conversion fails, use the FS path as-is
+			modulePath = event.Name
+		}
+		ws.pendingChanges[modulePath] = true
 	}
-	ws.pendingChanges[modulePath] = true
 
 	// Cancel any existing timer
 	if ws.debounceTimer != nil {
@@ -300,7 +329,7 @@ func (ws *WatchSession) matchesInputGlobs(filePath string) bool {
 // matchesDemoGlobs checks if a file path matches the demo discovery glob pattern
 func (ws *WatchSession) matchesDemoGlobs(filePath string) bool {
 	cfg, err := ws.ctx.Config()
-	if err != nil || cfg.Generate.DemoDiscovery.FileGlob == "" {
+	if err != nil || !DD.Enabled(cfg.Generate.DemoDiscovery) {
 		return false
 	}
 
@@ -311,15 +340,28 @@ func (ws *WatchSession) matchesDemoGlobs(filePath string) bool {
 		relPath = filePath
 	}
 
-	// Check against demo discovery glob pattern
-	matched, err := filepath.Match(cfg.Generate.DemoDiscovery.FileGlob, relPath)
-	if err == nil && matched {
-		return true
+	if fileGlob := cfg.Generate.DemoDiscovery.FileGlob; fileGlob != "" {
+		// Check against demo discovery glob pattern
+		matched, err := filepath.Match(fileGlob, relPath)
+		if err == nil && matched {
+			return true
+		}
+
+		// Also try with doublestar for more complex patterns like **/*.html
+		matched, err = DS.PathMatch(fileGlob, relPath)
+		if err == nil && matched {
+			return true
+		}
 	}
 
-	// Also try with doublestar for more complex patterns like **/*.html
-	matched, err = DS.PathMatch(cfg.Generate.DemoDiscovery.FileGlob, relPath)
-	return err == nil && matched
+	// Check against conventions, which may match next to any module
+	for _, convention := range cfg.Generate.DemoDiscovery.Conventions {
+		matched, err := DS.PathMatch(DD.ConventionWatchGlob(convention), relPath)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
 }
 
 // processChanges handles the actual regeneration after debouncing
@@ -341,6 +383,8 @@ func (ws *WatchSession) processChanges() {
 		changedFiles = append(changedFiles, file)
 	}
 	ws.pendingChanges = make(map[string]bool)
+	fullRebuild := ws.sourcesRemoved
+	ws.sourcesRemoved = false
 
 	ws.mu.Unlock()
 
@@ -348,7 +392,7 @@ func (ws *WatchSession) processChanges() {
 	start := time.Now()
 
 	// Try incremental processing first
-	if len(changedFiles) > 0 {
+	if len(changedFiles) > 0 && !fullRebuild {
 		logging.Debug("Changed files: %v", changedFiles)
 
 		// Check if we can skip demo discovery for this generation cycle
@@ -386,7 +430,7 @@ func (ws *WatchSession) processChanges() {
 			ws.updateDemoDiscoveryState()
 		}
 	} else {
-		// Fallback to full rebuild if no specific files tracked
+		// Fallback to full rebuild if files were removed or no specific files tracked
 		if err := ws.generateOnce(ctx); err != nil {
 			if errors.Is(err, context.Canceled) {
 				logging.Warning("Generation cancelled due to new file changes")
@@ -494,7 +538,7 @@ func (ws *WatchSession) writeManifest(manifestStr string) error {
 // shouldSkipDemoDiscovery checks if demo discovery can be skipped
 func (ws *WatchSession) shouldSkipDemoDiscovery() bool {
 	cfg, err := ws.ctx.Config()
-	if err != nil || cfg.Generate.DemoDiscovery.FileGlob == "" {
+	if err != nil || !DD.Enabled(cfg.Generate.DemoDiscovery) {
 		return true // Skip if no demo discovery configured
 	}
 
@@ -503,15 +547,15 @@ func (ws *WatchSession) shouldSkipDemoDiscovery() bool {
 	lastConfig := ws.lastDemoConfig
 	demoFilesChanged := ws.demoFilesChanged
 	// Check if this is the first run
-	isFirstRun := ws.lastDemoConfig == (C.DemoDiscoveryConfig{})
+	isFirstRun := !DD.Enabled(ws.lastDemoConfig)
 	ws.mu.Unlock()
 
 	if isFirstRun {
 		return false // Need to run discovery on first run
 	}
 
-	// Safe comparison outside the lock (both are value types)
-	configChanged := lastConfig != cfg.Generate.DemoDiscovery
+	// Safe comparison outside the lock (lastConfig is a deep copy)
+	configChanged := !reflect.DeepEqual(lastConfig, cfg.Generate.DemoDiscovery)
 
 	// Skip only if: no config changes AND no demo file changes
 	return !configChanged && !demoFilesChanged
@@ -527,6 +571,7 @@ func (ws *WatchSession) updateDemoDiscoveryState() {
 	ws.mu.Lock()
 	// Store value copy, not pointer - safe for concurrent access
 	ws.lastDemoConfig = cfg.Generate.DemoDiscovery
+	ws.lastDemoConfig.Conventions = slices.Clone(cfg.Generate.DemoDiscovery.Conventions)
 	ws.demoFilesChanged = false // Reset flag
 	ws.mu.Unlock()
 }
@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package generate
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/synctest"
+
+	"bennypowers.dev/cem/internal/platform"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"github.com/fsnotify/fsnotify"
+)
+
+// TestWatchSession_RemovedSource tests that removing a watched source
+// schedules a full rebuild, which drops the removed module, instead of
+// tracking the missing file for incremental processing
+func TestWatchSession_RemovedSource(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := testworkspace.NewMapWorkspaceContext(t, filepath.Join("demodiscovery", "testdata", "conventions"))
+		if err := ctx.Init(); err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+		ws := &WatchSession{
+			ctx:            ctx,
+			fs:             platform.NewMapFileSystem(nil),
+			globs:          []string{"src/**/*.ts"},
+			pendingChanges: make(map[string]bool),
+		}
+
+		ws.handleFileChange(fsnotify.Event{Name: "/src/conv-button/conv-button.ts", Op: fsnotify.Remove})
+		// The debounce timer cannot fire before the bubble blocks
+		if ws.debounceTimer == nil {
+			t.Fatal("Expected removal to schedule regeneration")
+		}
+		ws.debounceTimer.Stop()
+
+		if !ws.sourcesRemoved {
+			t.Error("Expected removal to request a full rebuild")
+		}
+		if len(ws.pendingChanges) != 0 {
+			t.Errorf("Expected no pending incremental changes, got %v", ws.pendingChanges)
+		}
+
+		ws.handleFileChange(fsnotify.Event{Name: "/src/conv-button/conv-button.ts", Op: fsnotify.Chmod})
+		ws.debounceTimer.Stop()
+		if len(ws.pendingChanges) != 0 {
+			t.Errorf("Expected chmod to be ignored, got %v", ws.pendingChanges)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+import { FASTElement, attr } from '@microsoft/fast-element';
+
+class FastCounter extends FASTElement {
+  @attr count = 0;
+}
+
+FastCounter.define({ name: 'fast-counter' });
+
+const badgeTagName = 'fast-badge';
+
+class FastBadge extends FASTElement {
+  @attr({ mode: 'boolean' }) pill = false;
+}
+
+FASTElement.define(FastBadge, { name: badgeTagName });
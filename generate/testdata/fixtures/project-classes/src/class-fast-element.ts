@@ -0,0 +1,9 @@
+import { FASTElement, attr, customElement, observable } from '@microsoft/fast-element';
+
+@customElement({ name: 'fast-toggle' })
+class FastToggle extends FASTElement {
+  @attr label: string = 'Toggle';
+  @attr({ mode: 'boolean' }) checked = false;
+  @attr({ attribute: 'aria-controls', mode: 'fromView' }) controls: string;
+  @observable items: string[] = [];
+}
@@ -0,0 +1,24 @@
+@customElement('class-platform-features')
+class ClassPlatformFeatures extends LitElement {
+  static styles = css`
+    :host { anchor-name: --menu-button; }
+    [popover] { position-anchor: --menu-button; }
+  `;
+
+  #internals: ElementInternals = this.attachInternals();
+
+  constructor() {
+    super();
+    // hydrates a server-rendered shadow root, which is not a popover
+    if (!this.#internals.shadowRoot) {
+      this.attachShadow({ mode: 'open' });
+    }
+  }
+
+  render() {
+    return html`
+      <button popovertarget="menu">Open</button>
+      <div id="menu" popover></div>
+    `;
+  }
+}
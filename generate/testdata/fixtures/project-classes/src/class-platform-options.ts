@@ -0,0 +1,8 @@
+@customElement('class-platform-options')
+class ClassPlatformOptions extends LitElement {
+  static shadowRootOptions = { ...LitElement.shadowRootOptions, delegatesFocus: true };
+
+  static formAssociated = true;
+
+  #internals: ElementInternals = this.attachInternals();
+}
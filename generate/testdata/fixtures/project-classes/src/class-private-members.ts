@@ -0,0 +1,24 @@
+@customElement('class-private-members')
+class ClassPrivateMembers extends LitElement {
+  /** Public reactive property */
+  @property() label = 'label';
+
+  /** ECMAScript private field */
+  #count = 0;
+
+  /** ECMAScript private field without initializer */
+  #internals: ElementInternals;
+
+  /** ECMAScript private accessor */
+  get #value(): string { return ''; }
+  set #value(v: string) {}
+
+  /** Underscore field, public unless underscorePrivate is enabled */
+  _legacy = false;
+
+  /** ECMAScript private method */
+  #update(): void {}
+
+  /** ECMAScript private arrow function method */
+  #onClick = (event: Event): void => {};
+}
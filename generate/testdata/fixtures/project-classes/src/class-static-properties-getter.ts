@@ -0,0 +1,12 @@
+@customElement('class-static-properties-getter')
+class ClassStaticPropertiesGetter extends LitElement {
+  static get properties() {
+    return {
+      /** @summary The heading level */
+      level: { type: Number, reflect: true },
+      'heading-text': { type: String },
+    };
+  }
+
+  level = 2;
+}
@@ -0,0 +1,19 @@
+@customElement('class-static-properties')
+class ClassStaticProperties extends LitElement {
+  static properties = {
+    /** Whether the panel is expanded */
+    open: { type: Boolean, reflect: true },
+    label: { type: String },
+    maxItems: { type: Number, attribute: 'max-items' },
+    items: { type: Array, attribute: false },
+    active: { state: true },
+  };
+
+  open = false;
+
+  label = 'Menu';
+
+  maxItems = 10;
+
+  items = [];
+}
@@ -0,0 +1,7 @@
+/**
+ * An alert banner.
+ * @category feedback
+ * @category form, layout
+ */
+@customElement('jsdoc-category')
+class JsdocCategory extends LitElement { }
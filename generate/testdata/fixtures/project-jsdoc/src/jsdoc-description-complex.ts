@@ -0,0 +1,28 @@
+/**
+ * A disclosure widget.
+ *
+ * Features:
+ * - keyboard support
+ *   - `Enter` and `Space` toggle the panel
+ *   - `Escape` closes it
+ * - animated transitions
+ *
+ * ```html
+ * <jsdoc-description-complex>
+ *   <span slot="summary">More</span>
+ *   <p>Details</p>
+ * </jsdoc-description-complex>
+ * ```
+ *
+ *     indented code block
+ *
+ * > **Note**: quoted text
+ * > continues here
+ *
+ * @slot summary - The summary, which
+ *                 may contain:
+ *                 - text
+ *                   - or icons
+ */
+@customElement('jsdoc-description-complex')
+class JsdocDescriptionComplex extends LitElement { }
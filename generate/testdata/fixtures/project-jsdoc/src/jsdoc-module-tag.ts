@@ -0,0 +1,6 @@
+/**
+ * @file Helpers for buttons
+ * @module button-helpers
+ */
+@customElement('jsdoc-module-tag')
+class JsdocModuleTag extends LitElement { }
@@ -0,0 +1,12 @@
+/**
+ * Buttons, and the helpers which render them.
+ * @summary Buttons
+ */
+import { LitElement } from 'lit';
+import { customElement } from 'lit/decorators.js';
+
+/**
+ * A button
+ */
+@customElement('jsdoc-module')
+class JsdocModule extends LitElement { }
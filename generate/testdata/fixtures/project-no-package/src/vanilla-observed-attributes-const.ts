@@ -0,0 +1,9 @@
+/** @customElement vanilla-observed-attributes-const */
+class VanillaObservedAttributesConst extends HTMLElement {
+  static observedAttributes = ['variant', 'disabled'] as const;
+
+  /** The visual variant */
+  variant: 'primary' | 'secondary' = 'primary';
+}
+
+customElements.define('vanilla-observed-attributes-const', VanillaObservedAttributesConst);
@@ -0,0 +1,14 @@
+/** @customElement vanilla-observed-attributes */
+class VanillaObservedAttributes extends HTMLElement {
+  static get observedAttributes() {
+    return ['size', 'accessible-label', 'open'];
+  }
+
+  /** The size of the element */
+  size: 'sm' | 'md' = 'md';
+
+  get accessibleLabel(): string {}
+  set accessibleLabel(value: string) {}
+}
+
+customElements.define('vanilla-observed-attributes', VanillaObservedAttributes);
@@ -0,0 +1,17 @@
+@customElement('underscore-private')
+class UnderscorePrivate extends LitElement {
+  /** Public reactive property */
+  @property() label = 'label';
+
+  /** Underscore reactive property, private by convention */
+  @property() _state = 'idle';
+
+  /** Underscore reactive property with an explicit public modifier */
+  @property() public _legacy = 'legacy';
+
+  /** Underscore field with an explicit protected modifier */
+  protected _cache = false;
+
+  /** Underscore method, private by convention */
+  _update(): void {}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/generate/jsdoc"
+	M "bennypowers.dev/cem/manifest"
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// typeDeclarationsEnabled reports whether the project opted into declaring
+// the types which component APIs refer to.
+func (mp *ModuleProcessor) typeDeclarationsEnabled() bool {
+	if mp.ctx == nil {
+		return false
+	}
+	cfg, err := mp.ctx.Config()
+	if err != nil || cfg == nil {
+		return false
+	}
+	return cfg.Generate.TypeDeclarations
+}
+
+// processTypeDeclarations collects the module's exported type aliases and
+// interfaces, and its JSDoc @typedefs. They are only declared in the manifest
+// once type resolution finds that a component API refers to them, so here
+// they are kept on the module by name. @typedefs are also type aliases, so
+// that JavaScript sources resolve them like TypeScript ones.
+func (mp *ModuleProcessor) processTypeDeclarations() error {
+	if !mp.typeDeclarationsEnabled() {
+		return nil
+	}
+	declarations := make(map[string]*M.TypeDeclaration)
+	cursor := mp.root.Walk()
+	defer cursor.Close()
+	for _, node := range mp.root.NamedChildren(cursor) {
+		switch node.GrammarName() {
+		case "export_statement":
+			decl, err := mp.exportedTypeDeclaration(&node)
+			if err != nil {
+				return err
+			}
+			if decl != nil {
+				declarations[decl.Name()] = decl
+			}
+		case "comment":
+			text := node.Utf8Text(mp.code)
+			if !strings.HasPrefix(text, "/**") || !strings.Contains(text, "@typedef") {
+				continue
+			}
+			typedefs, err := jsdoc.ParseTypedefs(text, mp.queryManager)
+			if err != nil {
+				return err
+			}
+			source, err := mp.generateSourceReference(&node)
+			if err != nil {
+				return err
+			}
+			for _, typedef := range typedefs {
+				declarations[typedef.Name] = &M.TypeDeclaration{
+					FullyQualified: M.FullyQualified{
+						Name:        typedef.Name,
+						Summary:     typedef.Summary,
+						Description: typedef.Description,
+					},
+					StartByte:  node.StartByte(),
+					Deprecated: typedef.Deprecated,
+					Type:       &M.Type{Text: typedef.Type},
+					Source:     source,
+				}
+				if _, ok := mp.typeAliasMap[typedef.Name]; !ok {
+					mp.typeAliasMap[typedef.Name] = typedef.Type
+				}
+			}
+		}
+	}
+	if len(declarations) > 0 {
+		mp.module.TypeDeclarations = declarations
+	}
+	return nil
+}
+
+// exportedTypeDeclaration returns the TypeDeclaration for an export
+// statement which exports a type alias or interface, or nil
+func (mp *ModuleProcessor) exportedTypeDeclaration(export *ts.Node) (*M.TypeDeclaration, error) {
+	declaration := export.ChildByFieldName("declaration")
+	if declaration == nil {
+		return nil, nil
+	}
+	var definition *ts.Node
+	switch declaration.GrammarName() {
+	case "type_alias_declaration":
+		definition = declaration.ChildByFieldName("value")
+	case "interface_declaration":
+		definition = declaration.ChildByFieldName("body")
+	default:
+		return nil, nil
+	}
+	name := declaration.ChildByFieldName("name")
+	if name == nil || definition == nil {
+		return nil, nil
+	}
+
+	decl := &M.TypeDeclaration{
+		FullyQualified: M.FullyQualified{Name: name.Utf8Text(mp.code)},
+		StartByte:      export.StartByte(),
+		Type:           &M.Type{Text: definition.Utf8Text(mp.code)},
+	}
+	if jsdocText := jsdoc.ExtractFromNode(export, mp.code); jsdocText != "" {
+		ignore, err := jsdoc.EnrichTypeWithJSDoc(jsdocText, decl, mp.queryManager)
+		if err != nil {
+			return nil, err
+		}
+		if ignore {
+			return nil, nil
+		}
+	}
+	source, err := mp.generateSourceReference(name)
+	if err != nil {
+		return nil, err
+	}
+	decl.Source = source
+	return decl, nil
+}
+
+// declareReferencedTypes lists, in the types of their modules, the types
+// which resolved class member types refer to. Attributes share their field's
+// type, so attribute types refer to the same declarations.
+func declareReferencedTypes(pkg *M.Package) {
+	type key struct{ module, name string }
+	declared := make(map[key]bool)
+	var modules []*M.Module
+	declare := func(typ *M.Type) {
+		if typ == nil {
+			return
+		}
+		for _, ref := range typ.References {
+			k := key{ref.Module, ref.Name}
+			if ref.Module == "" || declared[k] {
+				continue
+			}
+			module := findModuleByPath(pkg, ref.Module)
+			if module == nil {
+				continue
+			}
+			if decl, ok := module.TypeDeclarations[ref.Name]; ok {
+				declared[k] = true
+				module.Types = append(module.Types, decl)
+				if !slices.Contains(modules, module) {
+					modules = append(modules, module)
+				}
+			}
+		}
+	}
+
+	for i := range pkg.Modules {
+		for _, decl := range pkg.Modules[i].Declarations {
+			var class *M.ClassDeclaration
+			switch d := decl.(type) {
+			case *M.CustomElementDeclaration:
+				class = &d.ClassDeclaration
+			case *M.ClassDeclaration:
+				class = d
+			default:
+				continue
+			}
+			for _, member := range class.Members {
+				switch m := member.(type) {
+				case *M.ClassField:
+					declare(m.Type)
+				case *M.CustomElementField:
+					declare(m.Type)
+				case *M.ClassMethod:
+					for _, param := range m.Parameters {
+						declare(param.Type)
+					}
+					if m.Return != nil {
+						declare(m.Return.Type)
+					}
+				}
+			}
+		}
+	}
+
+	for _, module := range modules {
+		slices.SortStableFunc(module.Types, func(a, b *M.TypeDeclaration) int {
+			return cmp.Compare(a.StartByte, b.StartByte)
+		})
+	}
+}
+
+// findModuleByPath finds a module in the package by its path
+func findModuleByPath(pkg *M.Package, modulePath string) *M.Module {
+	for i := range pkg.Modules {
+		if pkg.Modules[i].Path == modulePath {
+			return &pkg.Modules[i]
+		}
+	}
+	return nil
+}
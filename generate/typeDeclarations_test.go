@@ -0,0 +1,114 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package generate
+
+import (
+	"testing"
+
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: small hand-built packages are clearer than fixtures
+
+func typedField(name, typeText string) *M.CustomElementField {
+	return &M.CustomElementField{ClassField: M.ClassField{
+		PropertyLike: M.PropertyLike{
+			FullyQualified: M.FullyQualified{Name: name},
+			Type:           &M.Type{Text: typeText},
+		},
+		Kind: "field",
+	}}
+}
+
+func typesPackage() *M.Package {
+	return &M.Package{
+		Modules: []M.Module{
+			{
+				Path: "src/button.js",
+				Declarations: []M.Declaration{
+					&M.CustomElementDeclaration{
+						ClassDeclaration: M.ClassDeclaration{
+							ClassLike: M.ClassLike{
+								FullyQualified: M.FullyQualified{Name: "MyButton"},
+								Members: []M.ClassMember{
+									typedField("variant", "import('./types.js').Variant"),
+									typedField("options", "import('./types.js').Options"),
+								},
+							},
+							Kind: "class",
+						},
+					},
+				},
+			},
+			{
+				Path: "src/types.js",
+				TypeDeclarations: map[string]*M.TypeDeclaration{
+					"Variant": {
+						FullyQualified: M.FullyQualified{Name: "Variant"},
+						Type:           &M.Type{Text: "'primary' | 'secondary'"},
+						StartByte:      40,
+					},
+					"Options": {
+						FullyQualified: M.FullyQualified{Name: "Options"},
+						Type:           &M.Type{Text: "{ label: string }"},
+						StartByte:      10,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveTypeAliases_DeclaredTypes(t *testing.T) {
+	pkg := typesPackage()
+	aliases := moduleTypeAliasesMap{
+		"src/types.js": {"Variant": "'primary' | 'secondary'"},
+	}
+	require.NoError(t, ResolveTypeAliases(pkg, aliases, nil, nil))
+
+	members := pkg.Modules[0].Declarations[0].(*M.CustomElementDeclaration).Members
+	variant := members[0].(*M.CustomElementField).Type
+	assert.Equal(t, "'primary' | 'secondary'", variant.Text, "aliases expand")
+	assert.Equal(t, []M.TypeReference{{Reference: M.Reference{Name: "Variant", Module: "src/types.js"}}}, variant.References)
+
+	options := members[1].(*M.CustomElementField).Type
+	assert.Equal(t, "Options", options.Text, "interfaces keep their name")
+	assert.Equal(t, []M.TypeReference{{Reference: M.Reference{Name: "Options", Module: "src/types.js"}}}, options.References)
+}
+
+func TestDeclareReferencedTypes(t *testing.T) {
+	pkg := typesPackage()
+	aliases := moduleTypeAliasesMap{
+		"src/types.js": {"Variant": "'primary' | 'secondary'"},
+	}
+	pkg.Modules[1].TypeDeclarations["Unused"] = &M.TypeDeclaration{
+		FullyQualified: M.FullyQualified{Name: "Unused"},
+		Type:           &M.Type{Text: "number"},
+	}
+	require.NoError(t, ResolveTypeAliases(pkg, aliases, nil, nil))
+	declareReferencedTypes(pkg)
+
+	var names []string
+	for _, decl := range pkg.Modules[1].Types {
+		names = append(names, decl.Name())
+	}
+	assert.Equal(t, []string{"Options", "Variant"}, names, "referenced types are listed in source order")
+	assert.Empty(t, pkg.Modules[1].Declarations, "types are not declarations")
+	assert.Empty(t, pkg.Modules[0].Types)
+}
@@ -18,6 +18,7 @@ package generate
 
 import (
 	"path"
+	"regexp"
 	"strings"
 
 	L "bennypowers.dev/cem/internal/logging"
@@ -257,7 +258,9 @@ func resolveType(typ *M.Type, module *M.Module, pkg *M.Package, typeAliases modu
 	ctx := newResolutionContext()
 	resolved, refs := resolveTypeText(typ.Text, module, pkg, typeAliases, imports, externalResolver, ctx)
 
-	if resolved != typ.Text {
+	// Types which refer to declared interfaces resolve to themselves, but
+	// still gain references
+	if resolved != typ.Text || len(refs) > 0 {
 		L.Trace("%s: resolved type %s -> %s", module.Path, typ.Text, resolved)
 		typ.Text = resolved
 		typ.References = refs
@@ -267,6 +270,17 @@ func resolveType(typ *M.Type, module *M.Module, pkg *M.Package, typeAliases modu
 func resolveTypeText(typeText string, module *M.Module, pkg *M.Package, typeAliases moduleTypeAliasesMap, imports moduleImportsMap, externalResolver *ExternalTypeResolver, ctx *resolutionContext) (string, []M.TypeReference) {
 	typeText = strings.TrimSpace(typeText)
 
+	// JSDoc refers to types in other modules with import types, e.g.
+	// import('./types.js').Variant
+	if match := importTypePattern.FindStringSubmatch(typeText); match != nil && !ctx.visited[typeText] {
+		ctx.visited[typeText] = true
+		defer delete(ctx.visited, typeText)
+		if targetModule := findModuleBySpec(pkg, module.Path, match[1]); targetModule != nil {
+			return resolveModuleType(match[2], targetModule, pkg, typeAliases, imports, externalResolver, ctx)
+		}
+		return typeText, nil
+	}
+
 	valueNode, source, tree, ok := tstype.ParseTypeValue(typeText)
 	if !ok {
 		return typeText, nil
@@ -352,6 +366,17 @@ func resolveTypeText(typeText string, module *M.Module, pkg *M.Package, typeAlia
 		}
 	}
 
+	// Interfaces aren't aliases, so they aren't expanded, but declared
+	// interfaces are referenced
+	if _, found := module.TypeDeclarations[typeText]; found {
+		return typeText, []M.TypeReference{{
+			Reference: M.Reference{
+				Name:   typeText,
+				Module: module.Path,
+			},
+		}}
+	}
+
 	// Check if the type is imported from another module
 	if moduleImports, hasImports := imports[module.Path]; hasImports {
 		if imp, found := moduleImports[typeText]; found {
@@ -363,22 +388,8 @@ func resolveTypeText(typeText string, module *M.Module, pkg *M.Package, typeAlia
 				L.Trace("%s: found target module %s for type '%s'", module.Path, targetModule.Path, typeText)
 
 				// Look up the type in the target module using the original name
-				if targetAliases, hasAliases := typeAliases[targetModule.Path]; hasAliases {
-					if definition, found := targetAliases[imp.name]; found {
-						// Create a reference to the imported type
-						ref := M.TypeReference{
-							Reference: M.Reference{
-								Name:   imp.name,
-								Module: targetModule.Path,
-							},
-						}
-
-						// Recursively resolve in the context of the target module
-						resolved, nestedRefs := resolveTypeText(definition, targetModule, pkg, typeAliases, imports, externalResolver, ctx)
-						refs := append([]M.TypeReference{ref}, nestedRefs...)
-
-						return resolved, refs
-					}
+				if resolved, refs := resolveModuleType(imp.name, targetModule, pkg, typeAliases, imports, externalResolver, ctx); len(refs) > 0 {
+					return resolved, refs
 				}
 
 				// Type exists in a local module but has no alias definition there;
@@ -409,6 +420,31 @@ func resolveTypeText(typeText string, module *M.Module, pkg *M.Package, typeAlia
 	return typeText, nil
 }
 
+// importTypePattern matches an import type, capturing its module specifier
+// and type name
+var importTypePattern = regexp.MustCompile(`^import\(\s*['"]([^'"]+)['"]\s*\)\.([A-Za-z_$][\w$]*)$`)
+
+// resolveModuleType resolves a type by its name in the module which declares
+// it, referencing the type when the module declares it as an alias or
+// interface. Returns no references when the module doesn't declare the type.
+func resolveModuleType(name string, targetModule *M.Module, pkg *M.Package, typeAliases moduleTypeAliasesMap, imports moduleImportsMap, externalResolver *ExternalTypeResolver, ctx *resolutionContext) (string, []M.TypeReference) {
+	ref := M.TypeReference{
+		Reference: M.Reference{
+			Name:   name,
+			Module: targetModule.Path,
+		},
+	}
+	if definition, found := typeAliases[targetModule.Path][name]; found {
+		// Recursively resolve in the context of the target module
+		resolved, nestedRefs := resolveTypeText(definition, targetModule, pkg, typeAliases, imports, externalResolver, ctx)
+		return resolved, append([]M.TypeReference{ref}, nestedRefs...)
+	}
+	if _, found := targetModule.TypeDeclarations[name]; found {
+		return name, []M.TypeReference{ref}
+	}
+	return name, nil
+}
+
 // findModuleBySpec finds a module in the package by resolving an import specifier
 // relative to the current module's path
 func findModuleBySpec(pkg *M.Package, currentModulePath string, importSpec string) *M.Module {
@@ -0,0 +1,107 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"bennypowers.dev/cem/generate/vue"
+	"bennypowers.dev/cem/internal/languages"
+	"bennypowers.dev/cem/internal/platform"
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// vueTemplateSyntax reads slots and parts from a component's <template>
+var vueTemplateSyntax = languages.TemplateSyntax{Name: "vue", Language: "html"}
+
+// processVueModule describes a Vue single-file component, which
+// defineCustomElement() turns into a custom element. The module's default
+// export is the component. Only `.ce.vue` files, components which call
+// defineCustomElement(), and components with a tag name in their JSDoc are
+// custom elements; other components have no module.
+func processVueModule(
+	job processJob,
+	qm *Q.QueryManager,
+	depTracker *FileDependencyTracker,
+	fsys platform.FileSystem,
+) (*M.Module, *LogCtx, error) {
+	path := job.file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(job.ctx.Root(), job.file)
+	}
+	code, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("processVueModule: %w", err)
+	}
+	logger := NewLogCtx(job.file)
+	module := M.NewModule(job.file)
+
+	packageJson, err := job.ctx.PackageJSON()
+	if err != nil {
+		logger.Warn("%v", err)
+	}
+	resolvedPath, err := M.ResolveExportPath(packageJson, module.Path)
+	if err != nil {
+		if !errors.Is(err, M.ErrNotExported) {
+			return nil, logger, err
+		}
+		logger.Warn("%v", err)
+	} else {
+		module.Path = resolvedPath
+	}
+
+	var errs error
+	component := vue.Analyze(job.file, code)
+	var slots []M.Slot
+	var parts []M.CssPart
+	if component.Template != nil {
+		mp := &ModuleProcessor{queryManager: qm}
+		slots, parts, err = mp.processRenderTemplate(vueTemplateSyntax, component.Template.Content, component.Template.Offset)
+		errs = errors.Join(errs, err)
+	}
+	declaration, err := component.Declaration(slots, parts, qm)
+	errs = errors.Join(errs, err)
+	if declaration.TagName == "" && !component.CustomElement {
+		logger.Debug("Skipping %s, which is not a custom element", job.file)
+		return nil, logger, errs
+	}
+
+	reference := M.NewReference(declaration.Name(), "", module.Path)
+	module.Declarations = append(module.Declarations, declaration)
+	module.Exports = append(module.Exports, &M.JavaScriptExport{
+		Kind:        "js",
+		Name:        "default",
+		Declaration: reference,
+	})
+	if declaration.TagName != "" {
+		module.Exports = append(module.Exports, M.NewCustomElementExport(
+			declaration.TagName,
+			reference,
+			0,
+			nil, // deprecated
+		))
+	}
+
+	if depTracker != nil {
+		errs = errors.Join(errs, depTracker.RecordModuleDependencies(job.file, nil, nil))
+	}
+	return module, logger, errs
+}
@@ -0,0 +1,184 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package vue reads the public API of Vue single-file components, which
+// defineCustomElement() turns into custom elements.
+package vue
+
+import (
+	"regexp"
+	"strings"
+)
+
+var attributePattern = regexp.MustCompile(`([^\s=/>]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?`)
+
+// Block is a top-level block of a single-file component, e.g. its
+// <template> or its <script setup>
+type Block struct {
+	Tag   string
+	Attrs map[string]string
+	// Content is the source between the block's tags
+	Content string
+	// Offset is the byte offset of the content in the file
+	Offset uint
+}
+
+// SFC is the blocks of a single-file component which declare its API
+type SFC struct {
+	Script      *Block
+	ScriptSetup *Block
+	Template    *Block
+}
+
+// ParseSFC splits a single-file component into its top-level blocks.
+// Blocks other than scripts and the template, e.g. styles, are skipped.
+func ParseSFC(code []byte) SFC {
+	var sfc SFC
+	src := string(code)
+	lower := strings.ToLower(src)
+	for i := 0; i < len(src); {
+		next := strings.IndexByte(src[i:], '<')
+		if next < 0 {
+			break
+		}
+		i += next
+		if strings.HasPrefix(src[i:], "<!--") {
+			end := strings.Index(src[i:], "-->")
+			if end < 0 {
+				break
+			}
+			i += end + len("-->")
+			continue
+		}
+
+		tag, attrs, contentStart, selfClosing := openTag(src, i)
+		if tag == "" {
+			i++
+			continue
+		}
+		if selfClosing {
+			i = contentStart
+			continue
+		}
+		contentEnd, end := closeTag(lower, contentStart, tag)
+		block := &Block{
+			Tag:     tag,
+			Attrs:   attrs,
+			Content: src[contentStart:contentEnd],
+			Offset:  uint(contentStart),
+		}
+		switch tag {
+		case "template":
+			if sfc.Template == nil {
+				sfc.Template = block
+			}
+		case "script":
+			if _, ok := attrs["setup"]; ok {
+				sfc.ScriptSetup = block
+			} else {
+				sfc.Script = block
+			}
+		}
+		i = end
+	}
+	return sfc
+}
+
+// openTag reads the start tag at src[i], returning its lowercase name, its
+// attributes, and the offset after it
+func openTag(src string, i int) (tag string, attrs map[string]string, end int, selfClosing bool) {
+	nameEnd := i + 1
+	for nameEnd < len(src) && isTagNameByte(src[nameEnd], nameEnd == i+1) {
+		nameEnd++
+	}
+	if nameEnd == i+1 {
+		return "", nil, 0, false
+	}
+
+	var quote byte
+	for j := nameEnd; j < len(src); j++ {
+		c := src[j]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			raw := strings.TrimSpace(src[nameEnd:j])
+			selfClosing = strings.HasSuffix(raw, "/")
+			return strings.ToLower(src[i+1 : nameEnd]), parseAttrs(strings.TrimSuffix(raw, "/")), j + 1, selfClosing
+		}
+	}
+	return "", nil, 0, false
+}
+
+// closeTag finds the end tag which closes a block, returning the offsets of
+// its start and of its end. Templates may nest, e.g. for `v-if` groups.
+func closeTag(lower string, from int, tag string) (contentEnd, end int) {
+	depth := 1
+	for j := from; j < len(lower); j++ {
+		next := strings.IndexByte(lower[j:], '<')
+		if next < 0 {
+			break
+		}
+		j += next
+		switch {
+		case strings.HasPrefix(lower[j:], "</"+tag) && isTagBoundary(lower, j+2+len(tag)):
+			depth--
+			if depth == 0 {
+				closeEnd := strings.IndexByte(lower[j:], '>')
+				if closeEnd < 0 {
+					return j, len(lower)
+				}
+				return j, j + closeEnd + 1
+			}
+		case tag == "template" && strings.HasPrefix(lower[j:], "<template") && isTagBoundary(lower, j+len("<template")):
+			depth++
+		}
+	}
+	return len(lower), len(lower)
+}
+
+// parseAttrs reads a start tag's attributes. Boolean attributes, like
+// `setup`, have empty values.
+func parseAttrs(raw string) map[string]string {
+	attrs := make(map[string]string)
+	for _, match := range attributePattern.FindAllStringSubmatch(raw, -1) {
+		attrs[strings.ToLower(match[1])] = match[2] + match[3] + match[4]
+	}
+	return attrs
+}
+
+func isTagNameByte(c byte, first bool) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+		return true
+	}
+	return !first && (c >= '0' && c <= '9' || c == '-')
+}
+
+func isTagBoundary(s string, i int) bool {
+	if i >= len(s) {
+		return true
+	}
+	switch s[i] {
+	case ' ', '\t', '\r', '\n', '>', '/':
+		return true
+	}
+	return false
+}
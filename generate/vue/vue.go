@@ -0,0 +1,632 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package vue
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"bennypowers.dev/cem/generate/jsdoc"
+	"bennypowers.dev/cem/internal/languages/typescript"
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// runtimeTypes maps the constructors of runtime prop declarations, e.g.
+// `{ type: Number }`, to TypeScript types
+var runtimeTypes = map[string]string{
+	"String":   "string",
+	"Number":   "number",
+	"Boolean":  "boolean",
+	"Array":    "unknown[]",
+	"Object":   "object",
+	"Function": "Function",
+	"Date":     "Date",
+	"Symbol":   "symbol",
+}
+
+// Component is the public API of a single-file component
+type Component struct {
+	// Name is the component's `name` option, or else its file name in
+	// PascalCase, e.g. "MyButton" for my-button.ce.vue
+	Name string
+	// JSDoc documents the component, either before its `export default`, or
+	// at the top of its `<script setup>`
+	JSDoc string
+	Props []Prop
+	Emits []Emit
+	// Template is the component's template block, if it has one
+	Template *Block
+	// CustomElement is true for `.ce.vue` files, and for components whose
+	// scripts call defineCustomElement()
+	CustomElement bool
+}
+
+// Prop is a prop declared with defineProps() or the `props` option
+type Prop struct {
+	Name      string
+	Type      string
+	Default   string
+	JSDoc     string
+	StartByte uint
+}
+
+// Emit is an event declared with defineEmits() or the `emits` option
+type Emit struct {
+	Name      string
+	JSDoc     string
+	StartByte uint
+}
+
+// Analyze reads the API which a single-file component declares in its
+// scripts, in either the Composition API's `<script setup>` or the Options
+// API's `export default`
+func Analyze(file string, code []byte) *Component {
+	sfc := ParseSFC(code)
+	c := &Component{
+		Name:          componentName(file),
+		Template:      sfc.Template,
+		CustomElement: strings.HasSuffix(file, ".ce.vue"),
+	}
+	for _, block := range []*Block{sfc.Script, sfc.ScriptSetup} {
+		if block != nil {
+			c.analyzeScript(block, block == sfc.ScriptSetup)
+		}
+	}
+	return c
+}
+
+// Declaration describes the component as a custom element, with the slots
+// and CSS parts of its template. Props become fields with attributes, as
+// defineCustomElement() hyphenates them, and emits become events. The
+// component's JSDoc adds its tag name and other documentation. Without a
+// tag name in its JSDoc, a custom element component whose hyphenated name is
+// a valid custom element name takes that, e.g. "my-button" for MyButton.
+func (c *Component) Declaration(slots []M.Slot, parts []M.CssPart, qm *Q.QueryManager) (*M.CustomElementDeclaration, error) {
+	decl := &M.CustomElementDeclaration{
+		ClassDeclaration: M.ClassDeclaration{
+			Kind: "class",
+			ClassLike: M.ClassLike{
+				FullyQualified: M.FullyQualified{Name: c.Name},
+				Superclass:     M.NewReference("VueElement", "vue", ""),
+			},
+		},
+		CustomElement: M.CustomElement{
+			CustomElement: true,
+			Slots:         slots,
+			CssParts:      parts,
+		},
+	}
+
+	var errs error
+	for _, prop := range c.Props {
+		field := &M.CustomElementField{
+			ClassField: M.ClassField{
+				Kind: "field",
+				PropertyLike: M.PropertyLike{
+					FullyQualified: M.FullyQualified{Name: prop.Name},
+					StartByte:      prop.StartByte,
+					Default:        prop.Default,
+				},
+			},
+			Attribute: hyphenate(prop.Name),
+		}
+		if prop.Type != "" {
+			field.Type = &M.Type{Text: prop.Type}
+		}
+		if prop.JSDoc != "" {
+			if err := jsdoc.EnrichPropertyWithJSDoc(prop.JSDoc, &field.PropertyLike, qm); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+		decl.Members = append(decl.Members, field)
+		decl.CustomElement.Attributes = append(decl.CustomElement.Attributes, M.Attribute{
+			FullyQualified: M.FullyQualified{
+				Name:        field.Attribute,
+				Summary:     field.Summary,
+				Description: field.Description,
+			},
+			Type:       field.Type,
+			Default:    field.Default,
+			FieldName:  field.Name,
+			Deprecated: field.Deprecated,
+			StartByte:  field.StartByte,
+		})
+	}
+
+	if c.JSDoc != "" {
+		if err := jsdoc.EnrichCustomElementWithJSDoc(c.JSDoc, decl, qm); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	if decl.TagName == "" && c.CustomElement {
+		if tagName := hyphenate(c.Name); strings.Contains(tagName, "-") {
+			decl.TagName = tagName
+		}
+	}
+
+	// Vue dispatches emits as CustomEvents, whose detail is the arguments
+	for _, emit := range c.Emits {
+		var doc M.PropertyLike
+		if emit.JSDoc != "" {
+			if err := jsdoc.EnrichPropertyWithJSDoc(emit.JSDoc, &doc, qm); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+		i := -1
+		for j := range decl.CustomElement.Events {
+			if decl.CustomElement.Events[j].Name == emit.Name {
+				i = j
+			}
+		}
+		if i < 0 {
+			decl.CustomElement.Events = append(decl.CustomElement.Events, M.Event{
+				FullyQualified: M.FullyQualified{Name: emit.Name},
+				Type:           &M.Type{Text: "CustomEvent"},
+			})
+			i = len(decl.CustomElement.Events) - 1
+		}
+		event := &decl.CustomElement.Events[i]
+		if event.Description == "" {
+			event.Description = doc.Description
+		}
+		if event.Summary == "" {
+			event.Summary = doc.Summary
+		}
+		if event.Deprecated == nil {
+			event.Deprecated = doc.Deprecated
+		}
+	}
+	return decl, errs
+}
+
+// script analyzes one script block
+type script struct {
+	component *Component
+	code      []byte
+	offset    uint
+	root      *ts.Node
+	setup     bool
+}
+
+func (c *Component) analyzeScript(block *Block, setup bool) {
+	code := []byte(block.Content)
+	parser := typescript.BorrowParser()
+	defer typescript.ReturnParser(parser)
+	tree := parser.Parse(code, nil)
+	if tree == nil {
+		return
+	}
+	defer tree.Close()
+
+	s := &script{component: c, code: code, offset: block.Offset, root: tree.RootNode(), setup: setup}
+	for i := range s.root.NamedChildCount() {
+		statement := s.root.NamedChild(i)
+		switch {
+		case setup && statement.Kind() == "comment":
+			if text := s.text(statement); c.JSDoc == "" && strings.HasPrefix(text, "/**") {
+				c.JSDoc = text
+			}
+		case !setup && statement.Kind() == "export_statement":
+			if options := s.exportedOptions(statement); options != nil {
+				if text := jsdoc.ExtractFromNode(statement, code); text != "" {
+					c.JSDoc = text
+				}
+				s.options(options)
+			}
+		}
+	}
+	s.walkMacros(s.root)
+}
+
+// exportedOptions returns the options object of `export default {}` or
+// `export default defineComponent({})` or
+// `export default defineCustomElement({})`
+func (s *script) exportedOptions(statement *ts.Node) *ts.Node {
+	value := statement.ChildByFieldName("value")
+	if value == nil {
+		return nil
+	}
+	if callee := s.callee(value); value.Kind() == "call_expression" && (callee == "defineComponent" || callee == "defineCustomElement") {
+		if args := value.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() > 0 {
+			value = args.NamedChild(0)
+		}
+	}
+	if value.Kind() != "object" {
+		return nil
+	}
+	return value
+}
+
+// options reads the component's name, props, and emits options
+func (s *script) options(object *ts.Node) {
+	for i := range object.NamedChildCount() {
+		pair := object.NamedChild(i)
+		if pair.Kind() != "pair" {
+			continue
+		}
+		value := pair.ChildByFieldName("value")
+		switch s.key(pair) {
+		case "name":
+			if value.Kind() == "string" {
+				s.component.Name = s.unquote(value)
+			}
+		case "props":
+			s.runtimeProps(value, nil)
+		case "emits":
+			s.runtimeEmits(value)
+		}
+	}
+}
+
+// walkMacros finds the defineProps() and defineEmits() compiler macros of a
+// `<script setup>`, and calls to defineCustomElement() in either script
+func (s *script) walkMacros(node *ts.Node) {
+	cursor := node.Walk()
+	defer cursor.Close()
+	for {
+		if n := cursor.Node(); n.Kind() == "call_expression" {
+			switch s.callee(n) {
+			case "defineProps":
+				if s.setup {
+					s.defineProps(n)
+				}
+			case "defineEmits":
+				if s.setup {
+					s.defineEmits(n)
+				}
+			case "defineCustomElement":
+				s.component.CustomElement = true
+			}
+		}
+		if cursor.GotoFirstChild() {
+			continue
+		}
+		for !cursor.GotoNextSibling() {
+			if !cursor.GotoParent() {
+				return
+			}
+		}
+	}
+}
+
+// defineProps reads the props of a defineProps() call, with their defaults
+// from withDefaults() or from destructuring them
+func (s *script) defineProps(call *ts.Node) {
+	defaults := make(map[string]string)
+	outer := call
+	if args := call.Parent(); args != nil && args.Kind() == "arguments" {
+		if wrapper := args.Parent(); wrapper != nil && s.callee(wrapper) == "withDefaults" {
+			outer = wrapper
+			if args.NamedChildCount() > 1 {
+				s.objectDefaults(args.NamedChild(1), defaults)
+			}
+		}
+	}
+	if declarator := outer.Parent(); declarator != nil && declarator.Kind() == "variable_declarator" {
+		if pattern := declarator.ChildByFieldName("name"); pattern != nil && pattern.Kind() == "object_pattern" {
+			s.destructuredDefaults(pattern, defaults)
+		}
+	}
+
+	if typeArgs := call.ChildByFieldName("type_arguments"); typeArgs != nil && typeArgs.NamedChildCount() > 0 {
+		for _, member := range s.typeMembers(typeArgs.NamedChild(0)) {
+			if member.Kind() != "property_signature" {
+				continue
+			}
+			prop := Prop{
+				Name:      s.key(member),
+				Default:   defaults[s.key(member)],
+				JSDoc:     jsdoc.ExtractFromNode(member, s.code),
+				StartByte: s.offset + member.StartByte(),
+			}
+			if annotation := member.ChildByFieldName("type"); annotation != nil && annotation.NamedChildCount() > 0 {
+				prop.Type = s.text(annotation.NamedChild(0))
+			}
+			s.component.Props = append(s.component.Props, prop)
+		}
+	} else if args := call.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() > 0 {
+		s.runtimeProps(args.NamedChild(0), defaults)
+	}
+}
+
+// runtimeProps reads props declared at runtime, either as an array of
+// names, or as an object of constructors or of prop options
+func (s *script) runtimeProps(node *ts.Node, defaults map[string]string) {
+	switch node.Kind() {
+	case "array":
+		for i := range node.NamedChildCount() {
+			if name := node.NamedChild(i); name.Kind() == "string" {
+				s.component.Props = append(s.component.Props, Prop{
+					Name:      s.unquote(name),
+					StartByte: s.offset + name.StartByte(),
+				})
+			}
+		}
+	case "object":
+		for i := range node.NamedChildCount() {
+			pair := node.NamedChild(i)
+			if pair.Kind() != "pair" {
+				continue
+			}
+			prop := Prop{
+				Name:      s.key(pair),
+				Default:   defaults[s.key(pair)],
+				JSDoc:     jsdoc.ExtractFromNode(pair, s.code),
+				StartByte: s.offset + pair.StartByte(),
+			}
+			value := pair.ChildByFieldName("value")
+			if value.Kind() == "object" {
+				for j := range value.NamedChildCount() {
+					option := value.NamedChild(j)
+					if option.Kind() != "pair" {
+						continue
+					}
+					switch s.key(option) {
+					case "type":
+						prop.Type = s.runtimeType(option.ChildByFieldName("value"))
+					case "default":
+						prop.Default = s.defaultText(option.ChildByFieldName("value"))
+					}
+				}
+			} else {
+				prop.Type = s.runtimeType(value)
+			}
+			s.component.Props = append(s.component.Props, prop)
+		}
+	}
+}
+
+// runtimeType converts a runtime prop type to a TypeScript type, preferring
+// the type of a `PropType` assertion, e.g. `String as PropType<'sm' | 'lg'>`
+func (s *script) runtimeType(node *ts.Node) string {
+	switch node.Kind() {
+	case "identifier":
+		return runtimeTypes[s.text(node)]
+	case "array":
+		var types []string
+		for i := range node.NamedChildCount() {
+			if t := s.runtimeType(node.NamedChild(i)); t != "" {
+				types = append(types, t)
+			}
+		}
+		return strings.Join(types, " | ")
+	case "as_expression":
+		if node.NamedChildCount() < 2 {
+			return ""
+		}
+		asserted := node.NamedChild(1)
+		if asserted.Kind() == "generic_type" {
+			if name := asserted.ChildByFieldName("name"); name != nil && s.text(name) == "PropType" {
+				if args := asserted.ChildByFieldName("type_arguments"); args != nil && args.NamedChildCount() > 0 {
+					return s.text(args.NamedChild(0))
+				}
+			}
+		}
+		return s.text(asserted)
+	}
+	return ""
+}
+
+// defineEmits reads the events of a defineEmits() call, declared with call
+// signatures, with named tuples, or at runtime
+func (s *script) defineEmits(call *ts.Node) {
+	typeArgs := call.ChildByFieldName("type_arguments")
+	if typeArgs == nil || typeArgs.NamedChildCount() == 0 {
+		if args := call.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() > 0 {
+			s.runtimeEmits(args.NamedChild(0))
+		}
+		return
+	}
+	for _, member := range s.typeMembers(typeArgs.NamedChild(0)) {
+		var name string
+		switch member.Kind() {
+		case "property_signature":
+			name = s.key(member)
+		case "call_signature":
+			// (e: 'change', id: number): void
+			params := member.ChildByFieldName("parameters")
+			if params == nil || params.NamedChildCount() == 0 {
+				continue
+			}
+			annotation := params.NamedChild(0).ChildByFieldName("type")
+			if annotation == nil || annotation.NamedChildCount() == 0 {
+				continue
+			}
+			if literal := annotation.NamedChild(0); literal.Kind() == "literal_type" && literal.NamedChildCount() > 0 {
+				name = s.unquote(literal.NamedChild(0))
+			}
+		}
+		if name != "" {
+			s.component.Emits = append(s.component.Emits, Emit{
+				Name:      name,
+				JSDoc:     jsdoc.ExtractFromNode(member, s.code),
+				StartByte: s.offset + member.StartByte(),
+			})
+		}
+	}
+}
+
+// runtimeEmits reads events declared at runtime, either as an array of
+// names, or as an object of validators
+func (s *script) runtimeEmits(node *ts.Node) {
+	for i := range node.NamedChildCount() {
+		child := node.NamedChild(i)
+		var name string
+		switch {
+		case node.Kind() == "array" && child.Kind() == "string":
+			name = s.unquote(child)
+		case node.Kind() == "object" && (child.Kind() == "pair" || child.Kind() == "method_definition"):
+			name = s.key(child)
+		}
+		if name != "" {
+			s.component.Emits = append(s.component.Emits, Emit{
+				Name:      name,
+				JSDoc:     jsdoc.ExtractFromNode(child, s.code),
+				StartByte: s.offset + child.StartByte(),
+			})
+		}
+	}
+}
+
+// typeMembers returns the members of an object type, following a type
+// reference to an interface or type alias in the same script
+func (s *script) typeMembers(node *ts.Node) []*ts.Node {
+	switch node.Kind() {
+	case "object_type", "interface_body":
+		members := make([]*ts.Node, 0, node.NamedChildCount())
+		for i := range node.NamedChildCount() {
+			members = append(members, node.NamedChild(i))
+		}
+		return members
+	case "type_identifier":
+		name := s.text(node)
+		for i := range s.root.NamedChildCount() {
+			statement := s.root.NamedChild(i)
+			if statement.Kind() == "export_statement" {
+				if declaration := statement.ChildByFieldName("declaration"); declaration != nil {
+					statement = declaration
+				}
+			}
+			declared := statement.ChildByFieldName("name")
+			if declared == nil || s.text(declared) != name {
+				continue
+			}
+			switch statement.Kind() {
+			case "interface_declaration":
+				if body := statement.ChildByFieldName("body"); body != nil {
+					return s.typeMembers(body)
+				}
+			case "type_alias_declaration":
+				if value := statement.ChildByFieldName("value"); value != nil {
+					return s.typeMembers(value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// objectDefaults reads the defaults object of withDefaults()
+func (s *script) objectDefaults(object *ts.Node, defaults map[string]string) {
+	if object.Kind() != "object" {
+		return
+	}
+	for i := range object.NamedChildCount() {
+		if pair := object.NamedChild(i); pair.Kind() == "pair" {
+			defaults[s.key(pair)] = s.defaultText(pair.ChildByFieldName("value"))
+		}
+	}
+}
+
+// destructuredDefaults reads defaults from destructured props, e.g.
+// `const { size = 'md' } = defineProps<Props>()`
+func (s *script) destructuredDefaults(pattern *ts.Node, defaults map[string]string) {
+	for i := range pattern.NamedChildCount() {
+		child := pattern.NamedChild(i)
+		if child.Kind() != "object_assignment_pattern" {
+			continue
+		}
+		left, right := child.ChildByFieldName("left"), child.ChildByFieldName("right")
+		if left != nil && right != nil {
+			defaults[s.text(left)] = s.defaultText(right)
+		}
+	}
+}
+
+// defaultText returns a default value's source, unwrapping the factory
+// functions of object and array defaults, e.g. `() => []`
+func (s *script) defaultText(node *ts.Node) string {
+	if node == nil {
+		return ""
+	}
+	if node.Kind() == "arrow_function" {
+		if body := node.ChildByFieldName("body"); body != nil && body.Kind() != "statement_block" {
+			if body.Kind() == "parenthesized_expression" && body.NamedChildCount() > 0 {
+				body = body.NamedChild(0)
+			}
+			return s.text(body)
+		}
+	}
+	return s.text(node)
+}
+
+// callee returns the name of the function a call expression calls
+func (s *script) callee(call *ts.Node) string {
+	if fn := call.ChildByFieldName("function"); fn != nil && fn.Kind() == "identifier" {
+		return s.text(fn)
+	}
+	return ""
+}
+
+// key returns the name of an object property or type member
+func (s *script) key(node *ts.Node) string {
+	key := node.ChildByFieldName("key")
+	if key == nil {
+		key = node.ChildByFieldName("name")
+	}
+	if key == nil {
+		return ""
+	}
+	if key.Kind() == "string" {
+		return s.unquote(key)
+	}
+	return s.text(key)
+}
+
+func (s *script) text(node *ts.Node) string {
+	return node.Utf8Text(s.code)
+}
+
+func (s *script) unquote(node *ts.Node) string {
+	return strings.Trim(s.text(node), "\"'`")
+}
+
+// componentName names a component after its file, e.g. "MyButton" for
+// my-button.ce.vue
+func componentName(file string) string {
+	base := strings.TrimSuffix(filepath.Base(file), ".vue")
+	base = strings.TrimSuffix(base, ".ce")
+	var b strings.Builder
+	for word := range strings.FieldsFuncSeq(base, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || unicode.IsSpace(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// hyphenate converts a camelCase name to kebab-case, as Vue does for the
+// attributes of props, e.g. "maxLength" to "max-length"
+func hyphenate(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
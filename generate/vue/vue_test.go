@@ -0,0 +1,256 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package vue
+
+import (
+	"strings"
+	"testing"
+
+	_ "bennypowers.dev/cem/internal/languages/jsdoc"
+	Q "bennypowers.dev/cem/internal/treesitter"
+	M "bennypowers.dev/cem/manifest"
+)
+
+const setupComponent = `<template>
+  <template v-if="icon"><slot name="icon"></slot></template>
+  <button part="button"><slot></slot></button>
+</template>
+
+<script setup lang="ts">
+/**
+ * A button which counts its clicks
+ * @customElement counter-button
+ */
+interface Props {
+  /** Button text */
+  label: string;
+  maxCount?: number;
+  size?: 'sm' | 'md' | 'lg';
+}
+
+const props = withDefaults(defineProps<Props>(), {
+  size: 'md',
+  maxCount: () => 10,
+});
+
+const emit = defineEmits<{
+  /** Fires when the count changes */
+  (e: 'change', count: number): void;
+  (e: 'reset'): void;
+}>();
+</script>
+
+<style>
+  button { color: red; }
+</style>
+`
+
+func TestParseSFC(t *testing.T) {
+	sfc := ParseSFC([]byte(setupComponent))
+	if sfc.Script != nil {
+		t.Errorf("Expected no plain script, got %q", sfc.Script.Content)
+	}
+	if sfc.ScriptSetup == nil || sfc.ScriptSetup.Attrs["lang"] != "ts" {
+		t.Fatalf("Expected a TypeScript script setup, got %+v", sfc.ScriptSetup)
+	}
+	if got := setupComponent[sfc.ScriptSetup.Offset:][:len(sfc.ScriptSetup.Content)]; got != sfc.ScriptSetup.Content {
+		t.Errorf("Expected the offset to point at the content, got %q", got)
+	}
+	if sfc.Template == nil || !strings.Contains(sfc.Template.Content, `<button part="button">`) {
+		t.Fatalf("Expected the whole template, including nested templates, got %+v", sfc.Template)
+	}
+	if strings.Contains(sfc.Template.Content, "<script") {
+		t.Errorf("Expected the template to end at its own closing tag, got %q", sfc.Template.Content)
+	}
+}
+
+func TestAnalyze_ScriptSetup(t *testing.T) {
+	c := Analyze("src/counter-button.ce.vue", []byte(setupComponent))
+	if c.Name != "CounterButton" {
+		t.Errorf("Expected the name to come from the file name, got %q", c.Name)
+	}
+	if !strings.Contains(c.JSDoc, "@customElement counter-button") {
+		t.Errorf("Expected the component's JSDoc, got %q", c.JSDoc)
+	}
+
+	want := []Prop{
+		{Name: "label", Type: "string", JSDoc: "/** Button text */"},
+		{Name: "maxCount", Type: "number", Default: "10"},
+		{Name: "size", Type: "'sm' | 'md' | 'lg'", Default: "'md'"},
+	}
+	if len(c.Props) != len(want) {
+		t.Fatalf("Expected %d props, got %+v", len(want), c.Props)
+	}
+	for i, prop := range want {
+		got := c.Props[i]
+		if got.Name != prop.Name || got.Type != prop.Type || got.Default != prop.Default || got.JSDoc != prop.JSDoc {
+			t.Errorf("Expected prop %+v, got %+v", prop, got)
+		}
+		if !strings.HasPrefix(setupComponent[got.StartByte:], prop.Name) {
+			t.Errorf("Expected the start byte of %s to point into the file, got %q", prop.Name, setupComponent[got.StartByte:][:10])
+		}
+	}
+
+	if len(c.Emits) != 2 || c.Emits[0].Name != "change" || c.Emits[1].Name != "reset" {
+		t.Fatalf("Expected change and reset emits, got %+v", c.Emits)
+	}
+	if c.Emits[0].JSDoc != "/** Fires when the count changes */" {
+		t.Errorf("Expected the change emit's JSDoc, got %q", c.Emits[0].JSDoc)
+	}
+}
+
+func TestAnalyze_ScriptSetupRuntime(t *testing.T) {
+	c := Analyze("x-toggle.vue", []byte(`<script setup>
+const { pressed = false } = defineProps({
+  pressed: Boolean,
+  variant: { type: String, default: 'plain' },
+  items: { type: Array, default: () => [] },
+  tags: ['one', 'two'],
+});
+defineEmits(['toggle', 'update:pressed']);
+</script>`))
+
+	want := map[string][2]string{
+		"pressed": {"boolean", "false"},
+		"variant": {"string", "'plain'"},
+		"items":   {"unknown[]", "[]"},
+		"tags":    {"", ""},
+	}
+	if len(c.Props) != len(want) {
+		t.Fatalf("Expected %d props, got %+v", len(want), c.Props)
+	}
+	for _, prop := range c.Props {
+		if w := want[prop.Name]; prop.Type != w[0] || prop.Default != w[1] {
+			t.Errorf("Expected %s to have type %q and default %q, got %q and %q", prop.Name, w[0], w[1], prop.Type, prop.Default)
+		}
+	}
+	if len(c.Emits) != 2 || c.Emits[1].Name != "update:pressed" {
+		t.Errorf("Expected the runtime emits, got %+v", c.Emits)
+	}
+}
+
+func TestAnalyze_Options(t *testing.T) {
+	c := Analyze("Alert.ce.vue", []byte(`<template><div part="body"><slot /></div></template>
+<script lang="ts">
+import { defineComponent, type PropType } from 'vue';
+
+/** An alert */
+export default defineComponent({
+  name: 'MyAlert',
+  props: {
+    /** How urgent the alert is */
+    state: String as PropType<'info' | 'danger'>,
+    dismissable: [Boolean, String],
+  },
+  emits: {
+    close: null,
+  },
+});
+</script>`))
+
+	if c.Name != "MyAlert" {
+		t.Errorf("Expected the name option, got %q", c.Name)
+	}
+	if c.JSDoc != "/** An alert */" {
+		t.Errorf("Expected the JSDoc of the default export, got %q", c.JSDoc)
+	}
+	if len(c.Props) != 2 ||
+		c.Props[0].Type != "'info' | 'danger'" ||
+		c.Props[0].JSDoc != "/** How urgent the alert is */" ||
+		c.Props[1].Type != "boolean | string" {
+		t.Errorf("Unexpected props %+v", c.Props)
+	}
+	if len(c.Emits) != 1 || c.Emits[0].Name != "close" {
+		t.Errorf("Expected the close emit, got %+v", c.Emits)
+	}
+}
+
+func TestDeclaration(t *testing.T) {
+	qm, err := Q.NewQueryManager(Q.QuerySelector{"jsdoc": {"jsdoc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer qm.Close()
+
+	c := Analyze("counter-button.ce.vue", []byte(setupComponent))
+	slots := []M.Slot{{FullyQualified: M.FullyQualified{Name: "icon"}}}
+	decl, err := c.Declaration(slots, nil, qm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decl.TagName != "counter-button" || !decl.CustomElement.CustomElement {
+		t.Errorf("Expected the counter-button custom element, got %q", decl.TagName)
+	}
+	if decl.Description != "A button which counts its clicks" {
+		t.Errorf("Expected the component's description, got %q", decl.Description)
+	}
+	if len(decl.Members) != 3 {
+		t.Fatalf("Expected a field for each prop, got %d", len(decl.Members))
+	}
+
+	attrs := decl.Attributes()
+	if len(attrs) != 3 || attrs[1].Name != "max-count" || attrs[1].FieldName != "maxCount" {
+		t.Fatalf("Expected hyphenated attributes, got %+v", attrs)
+	}
+	if attrs[0].Description != "Button text" || attrs[2].Default != "'md'" {
+		t.Errorf("Expected attributes to carry docs and defaults, got %+v", attrs)
+	}
+
+	events := decl.Events()
+	if len(events) != 2 || events[0].Name != "change" || events[0].Type.Text != "CustomEvent" {
+		t.Fatalf("Expected change and reset events, got %+v", events)
+	}
+	if events[0].Description != "Fires when the count changes" {
+		t.Errorf("Expected the change event's description, got %q", events[0].Description)
+	}
+	if len(decl.Slots()) != 1 {
+		t.Errorf("Expected the template's slots, got %+v", decl.Slots())
+	}
+}
+
+func TestDeclaration_TagName(t *testing.T) {
+	qm, err := Q.NewQueryManager(Q.QuerySelector{"jsdoc": {"jsdoc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer qm.Close()
+
+	for _, tc := range []struct {
+		file, code, want string
+		customElement    bool
+	}{
+		{"MyCard.ce.vue", "<template><div></div></template>", "my-card", true},
+		{"Card.ce.vue", "<template><div></div></template>", "", true},
+		{"MyCard.vue", "<template><div></div></template>", "", false},
+		{"MyCard.vue", "<script>\n/** @customElement x-card */\nexport default {}\n</script>", "x-card", false},
+		{"MyCard.vue", "<script>\nimport { defineCustomElement } from 'vue';\nexport default defineCustomElement({ props: { size: String } });\n</script>", "my-card", true},
+	} {
+		c := Analyze(tc.file, []byte(tc.code))
+		if c.CustomElement != tc.customElement {
+			t.Errorf("Expected %s %q to be a custom element: %t", tc.file, tc.code, tc.customElement)
+		}
+		decl, err := c.Declaration(nil, nil, qm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decl.TagName != tc.want {
+			t.Errorf("Expected %s %q to have tag name %q, got %q", tc.file, tc.code, tc.want, decl.TagName)
+		}
+	}
+}
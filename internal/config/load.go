@@ -19,6 +19,7 @@ type CemConfig struct {
 	PackageName  string `mapstructure:"packageName" yaml:"packageName" json:"packageName"`
 	Generate     GenerateConfig                `mapstructure:"generate" yaml:"generate" json:"generate"`
 	MCP          MCPConfig                     `mapstructure:"mcp" yaml:"mcp" json:"mcp"`
+	LSP          LSPConfig                     `mapstructure:"lsp" yaml:"lsp" json:"lsp,omitzero"`
 	Health       HealthConfig                  `mapstructure:"health" yaml:"health" json:"health"`
 	Breaking     BreakingConfig                `mapstructure:"breaking" yaml:"breaking" json:"breaking"`
 	Serve        ServeConfig                   `mapstructure:"serve" yaml:"serve" json:"serve"`
@@ -27,21 +28,72 @@ type CemConfig struct {
 	Verbose            bool     `mapstructure:"verbose" yaml:"verbose" json:"verbose"` // Deprecated: use LogLevel instead
 	LogLevel           string   `mapstructure:"logLevel" yaml:"logLevel" json:"logLevel,omitempty"`
 	AdditionalPackages []string `mapstructure:"additionalPackages" yaml:"additionalPackages" json:"additionalPackages"`
+	Registry           RegistryConfig `mapstructure:"registry" yaml:"registry" json:"registry,omitzero"`
+}
+
+// RegistryConfig decides which manifest describes a tag name, when more
+// than one of the manifests which the language server and MCP server load
+// declares it
+type RegistryConfig struct {
+	// PreferLocal lets the workspace's own manifests, and those of its
+	// workspace packages, describe their tag names over copies in
+	// node_modules and additional packages.
+	PreferLocal bool `mapstructure:"preferLocal" yaml:"preferLocal" json:"preferLocal,omitempty"`
+	// Priority lists package names, highest priority first. A listed
+	// package describes its tag names over packages listed after it, and
+	// over packages which aren't listed.
+	Priority []string `mapstructure:"priority" yaml:"priority" json:"priority,omitempty"`
 }
 
 type GenerateConfig struct {
 	Files             []string           `mapstructure:"files" yaml:"files" json:"files"`
 	Exclude           []string           `mapstructure:"exclude" yaml:"exclude" json:"exclude"`
 	NoDefaultExcludes *bool              `mapstructure:"noDefaultExcludes" yaml:"noDefaultExcludes" json:"noDefaultExcludes"`
+	// UnderscorePrivate treats members whose names begin with `_` as private,
+	// following the common JavaScript naming convention.
+	UnderscorePrivate bool               `mapstructure:"underscorePrivate" yaml:"underscorePrivate" json:"underscorePrivate"`
 	Output            string             `mapstructure:"output" yaml:"output" json:"output"`
+	// Outputs are additional files written from the same analysis, each in
+	// its own format.
+	Outputs           []OutputConfig     `mapstructure:"outputs" yaml:"outputs" json:"outputs,omitempty"`
 	DesignTokens      DesignTokensConfig `mapstructure:"designTokens" yaml:"designTokens" json:"designTokens"`
 	DemoDiscovery     DemoDiscoveryConfig `mapstructure:"demoDiscovery" yaml:"demoDiscovery" json:"demoDiscovery"`
+	// Categories maps each category in the design system's taxonomy to tag
+	// name globs for the elements in it.
+	Categories map[string][]string `mapstructure:"categories" yaml:"categories" json:"categories,omitempty"`
+	// MemberOrder orders each class's members: source, alphabetical, or
+	// kind. Empty means source.
+	MemberOrder string `mapstructure:"memberOrder" yaml:"memberOrder" json:"memberOrder,omitempty"`
+	// TypeDeclarations lists the exported types which component APIs refer
+	// to in the x-types of their modules.
+	TypeDeclarations bool `mapstructure:"typeDeclarations" yaml:"typeDeclarations" json:"typeDeclarations,omitempty"`
+	// Publish is the output profile `generate --publish` applies, for
+	// manifests which are published rather than used by local tooling.
+	Publish PublishConfig `mapstructure:"publish" yaml:"publish" json:"publish,omitempty"`
+}
+
+// PublishConfig declares what the publish profile strips from the manifest.
+type PublishConfig struct {
+	// Strip lists any of source, private, and extensions. Empty means all
+	// of them.
+	Strip []string `mapstructure:"strip" yaml:"strip" json:"strip,omitempty"`
+}
+
+// OutputConfig is an additional file written by generate.
+type OutputConfig struct {
+	Path string `mapstructure:"path" yaml:"path" json:"path"`
+	// Format is one of json, jsonc, ndjson, web-types, vscode-custom-data, or dts.
+	Format string `mapstructure:"format" yaml:"format" json:"format"`
 }
 
 type DemoDiscoveryConfig struct {
 	FileGlob    string `mapstructure:"fileGlob" yaml:"fileGlob" json:"fileGlob"`
 	URLPattern  string `mapstructure:"urlPattern" yaml:"urlPattern" json:"urlPattern"`
 	URLTemplate string `mapstructure:"urlTemplate" yaml:"urlTemplate" json:"urlTemplate"`
+	// Conventions are globs, relative to each element's module directory,
+	// for demo files associated with the element by location, e.g.
+	// "demo/*.html" or "{{.module}}.demo.html".
+	Conventions []string `mapstructure:"conventions" yaml:"conventions" json:"conventions"`
 }
 
 type DesignTokensConfig struct {
@@ -51,15 +103,49 @@ type DesignTokensConfig struct {
 
 type MCPConfig struct {
 	MaxDescriptionLength int `mapstructure:"maxDescriptionLength" yaml:"maxDescriptionLength" json:"maxDescriptionLength"`
+	// ReadOnly disables tools which modify the workspace and rejects filesystem writes.
+	ReadOnly bool `mapstructure:"readOnly" yaml:"readOnly" json:"readOnly"`
+	// AllowedDirs restricts filesystem access to these directories, relative
+	// to the project root. Empty means unrestricted.
+	AllowedDirs []string `mapstructure:"allowedDirs" yaml:"allowedDirs" json:"allowedDirs"`
+	// AuditLog is a file to which every tool invocation is appended as a JSON line.
+	AuditLog string `mapstructure:"auditLog" yaml:"auditLog" json:"auditLog"`
+	// NoSessionMemory always sends element overviews in full, instead of
+	// abbreviating those a client session already read.
+	NoSessionMemory bool `mapstructure:"noSessionMemory" yaml:"noSessionMemory" json:"noSessionMemory"`
+	// NoResponseCache renders every tool response afresh, instead of
+	// repeating responses to identical calls while the manifests are unchanged.
+	NoResponseCache bool `mapstructure:"noResponseCache" yaml:"noResponseCache" json:"noResponseCache,omitempty"`
+	// Changelog enables the element_changelog tool, which summarizes the git
+	// history of an element's modules.
+	Changelog bool `mapstructure:"changelog" yaml:"changelog" json:"changelog,omitempty"`
+	// ChangelogDepth is the number of commits the element_changelog tool
+	// reads by default. Zero means 20.
+	ChangelogDepth int `mapstructure:"changelogDepth" yaml:"changelogDepth" json:"changelogDepth,omitempty"`
+}
+
+// LSPConfig holds project-wide language server settings, which the
+// editor's settings override
+type LSPConfig struct {
+	// Diagnostics maps diagnostic rules, like unknown-attribute, to the
+	// severity of their diagnostics: error, warning, info, hint, or off.
+	Diagnostics map[string]string `mapstructure:"diagnostics" yaml:"diagnostics" json:"diagnostics,omitempty"`
+	// RequiredSlots maps tag names to the slots which usages of the element
+	// must fill, in addition to those its manifest marks required. The
+	// default slot is named "".
+	RequiredSlots map[string][]string `mapstructure:"requiredSlots" yaml:"requiredSlots" json:"requiredSlots,omitempty"`
 }
 
 type ServeConfig struct {
 	Port        int                    `mapstructure:"port" yaml:"port" json:"port"`
+	AutoPort    bool                   `mapstructure:"autoPort" yaml:"autoPort" json:"autoPort,omitempty"`
 	OpenBrowser *bool                  `mapstructure:"openBrowser" yaml:"openBrowser" json:"openBrowser"`
 	ImportMap   types.ImportMapConfig  `mapstructure:"importMap" yaml:"importMap" json:"importMap"`
 	Transforms  TransformsConfig       `mapstructure:"transforms" yaml:"transforms" json:"transforms"`
 	URLRewrites []URLRewrite           `mapstructure:"urlRewrites" yaml:"urlRewrites" json:"urlRewrites"`
 	Demos       DemosConfig            `mapstructure:"demos" yaml:"demos" json:"demos"`
+	Tracing     TracingConfig          `mapstructure:"tracing" yaml:"tracing" json:"tracing,omitempty"`
+	TLS         TLSConfig              `mapstructure:"tls" yaml:"tls" json:"tls,omitzero"`
 }
 
 type TransformsConfig struct {
@@ -79,7 +165,38 @@ type CSSTransformConfig struct {
 }
 
 type DemosConfig struct {
-	Rendering string `mapstructure:"rendering" yaml:"rendering" json:"rendering"`
+	Rendering string            `mapstructure:"rendering" yaml:"rendering" json:"rendering"`
+	Env       map[string]string `mapstructure:"env" yaml:"env" json:"env,omitempty"`
+	// ScopedElementsFile is a JSON file mapping tag names from scoped custom
+	// element registries to the global tag names SSR renders them as.
+	ScopedElementsFile string `mapstructure:"scopedElementsFile" yaml:"scopedElementsFile" json:"scopedElementsFile,omitempty"`
+	// ThemeToggle adds a button to demo pages outside the dev server chrome,
+	// which switches them between light and dark themes.
+	ThemeToggle ThemeToggleConfig `mapstructure:"themeToggle" yaml:"themeToggle" json:"themeToggle,omitzero"`
+}
+
+// ThemeToggleConfig configures what the demo theme toggle flips on <html>
+type ThemeToggleConfig struct {
+	// Attribute is set to "light" or "dark"
+	Attribute string `mapstructure:"attribute" yaml:"attribute" json:"attribute,omitempty"`
+	// Class is present in the dark theme
+	Class string `mapstructure:"class" yaml:"class" json:"class,omitempty"`
+}
+
+// TracingConfig configures request tracing in the dev server
+type TracingConfig struct {
+	// OTLPEndpoint is the base URL of an OpenTelemetry collector, e.g.
+	// http://localhost:4318, to which traces are exported over OTLP/HTTP.
+	OTLPEndpoint string `mapstructure:"otlpEndpoint" yaml:"otlpEndpoint" json:"otlpEndpoint,omitempty"`
+}
+
+// TLSConfig configures HTTPS in the dev server
+type TLSConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled" json:"enabled,omitempty"`
+	// Cert and Key are PEM files, relative to the project root. Without
+	// them, the dev server generates a self-signed certificate.
+	Cert string `mapstructure:"cert" yaml:"cert" json:"cert,omitempty"`
+	Key  string `mapstructure:"key" yaml:"key" json:"key,omitempty"`
 }
 
 type URLRewrite struct {
@@ -113,8 +230,20 @@ func LoadConfig(path string, fsys platform.FileSystem) (*CemConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
-
 	format := FormatFromPath(path)
+	if format == "" {
+		return nil, fmt.Errorf("unsupported config format for %s", path)
+	}
+	cfg, err := ParseConfig(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s config %s: %w", format, path, err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig parses config data in the given format, one of "yaml", "json",
+// or "jsonc", e.g. the unsaved content of a config file open in an editor.
+func ParseConfig(data []byte, format string) (*CemConfig, error) {
 	cfg := &CemConfig{}
 
 	switch format {
@@ -123,14 +252,14 @@ func LoadConfig(path string, fsys platform.FileSystem) (*CemConfig, error) {
 			return cfg, nil
 		}
 		if err := yaml.Unmarshal(data, cfg); err != nil {
-			return nil, fmt.Errorf("failed to parse yaml config %s: %w", path, err)
+			return nil, err
 		}
 	case "json":
 		if len(data) == 0 {
 			return cfg, nil
 		}
 		if err := json.Unmarshal(data, cfg); err != nil {
-			return nil, fmt.Errorf("failed to parse json config %s: %w", path, err)
+			return nil, err
 		}
 	case "jsonc":
 		stripped := bytes.TrimSpace(StripComments(data))
@@ -138,10 +267,10 @@ func LoadConfig(path string, fsys platform.FileSystem) (*CemConfig, error) {
 			return cfg, nil
 		}
 		if err := json.Unmarshal(stripped, cfg); err != nil {
-			return nil, fmt.Errorf("failed to parse jsonc config %s: %w", path, err)
+			return nil, err
 		}
 	default:
-		return nil, fmt.Errorf("unsupported config format for %s", path)
+		return nil, fmt.Errorf("unsupported config format %q", format)
 	}
 
 	return cfg, nil
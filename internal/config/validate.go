@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"path"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -77,6 +78,60 @@ func IsValidRenderingMode(mode string) bool {
 	return slices.Contains(validRenderingModes, mode)
 }
 
+const (
+	OutputFormatJSON             = "json"
+	OutputFormatJSONC            = "jsonc"
+	OutputFormatNDJSON           = "ndjson"
+	OutputFormatWebTypes         = "web-types"
+	OutputFormatVSCodeCustomData = "vscode-custom-data"
+	OutputFormatDTS              = "dts"
+)
+
+var validOutputFormats = []string{
+	OutputFormatJSON,
+	OutputFormatJSONC,
+	OutputFormatNDJSON,
+	OutputFormatWebTypes,
+	OutputFormatVSCodeCustomData,
+	OutputFormatDTS,
+}
+
+func IsValidOutputFormat(format string) bool {
+	return slices.Contains(validOutputFormats, format)
+}
+
+const (
+	MemberOrderSource       = "source"
+	MemberOrderAlphabetical = "alphabetical"
+	MemberOrderKind         = "kind"
+)
+
+var validMemberOrders = []string{
+	MemberOrderSource,
+	MemberOrderAlphabetical,
+	MemberOrderKind,
+}
+
+func IsValidMemberOrder(order string) bool {
+	return slices.Contains(validMemberOrders, order)
+}
+
+const (
+	PublishStripSource     = "source"
+	PublishStripPrivate    = "private"
+	PublishStripExtensions = "extensions"
+)
+
+var validPublishStrips = []string{
+	PublishStripSource,
+	PublishStripPrivate,
+	PublishStripExtensions,
+}
+
+func IsValidPublishStrip(strip string) bool {
+	return slices.Contains(validPublishStrips, strip)
+}
+
 var validTargets = []string{
 	"es2015", "es2016", "es2017", "es2018", "es2019",
 	"es2020", "es2021", "es2022", "es2023", "esnext",
@@ -86,6 +141,28 @@ func IsValidTarget(target string) bool {
 	return slices.Contains(validTargets, target)
 }
 
+// isValidMarkupName reports whether name can name an HTML attribute or class
+func isValidMarkupName(name string) bool {
+	return !strings.ContainsAny(name, " \t\n\f\r\"'<>/=")
+}
+
+// IsValidEnvName reports whether name can name a demo environment variable,
+// i.e. it is a letter or underscore followed by letters, digits, or underscores.
+func IsValidEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // Validate checks a CemConfig for invalid values and returns all errors found.
 func Validate(cfg *CemConfig, opts ValidateOptions) []ValidationError {
 	if cfg == nil {
@@ -102,6 +179,89 @@ func Validate(cfg *CemConfig, opts ValidateOptions) []ValidationError {
 		})
 	}
 
+	envNames := make([]string, 0, len(cfg.Serve.Demos.Env))
+	for name := range cfg.Serve.Demos.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		if !IsValidEnvName(name) {
+			errs = append(errs, ValidationError{
+				Field:   "serve.demos.env",
+				Message: "names must start with a letter or underscore, followed by letters, digits, or underscores",
+				Value:   name,
+			})
+		}
+	}
+
+	toggle := cfg.Serve.Demos.ThemeToggle
+	if toggle.Attribute != "" && !isValidMarkupName(toggle.Attribute) {
+		errs = append(errs, ValidationError{
+			Field:   "serve.demos.themeToggle.attribute",
+			Message: "must be an attribute name, without whitespace, quotes, or any of <>/=",
+			Value:   toggle.Attribute,
+		})
+	}
+	if toggle.Class != "" && !isValidMarkupName(toggle.Class) {
+		errs = append(errs, ValidationError{
+			Field:   "serve.demos.themeToggle.class",
+			Message: "must be a single class name, without whitespace, quotes, or any of <>/=",
+			Value:   toggle.Class,
+		})
+	}
+
+	categories := make([]string, 0, len(cfg.Generate.Categories))
+	for category := range cfg.Generate.Categories {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		for _, glob := range cfg.Generate.Categories[category] {
+			if _, err := path.Match(glob, ""); err != nil {
+				errs = append(errs, ValidationError{
+					Field:   "generate.categories." + category,
+					Message: fmt.Sprintf("invalid tag name glob: %v", err),
+					Value:   glob,
+				})
+			}
+		}
+	}
+
+	for i, output := range cfg.Generate.Outputs {
+		field := fmt.Sprintf("generate.outputs[%d]", i)
+		if output.Path == "" {
+			errs = append(errs, ValidationError{
+				Field:   field + ".path",
+				Message: "is required",
+			})
+		}
+		if !IsValidOutputFormat(output.Format) {
+			errs = append(errs, ValidationError{
+				Field:   field + ".format",
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(validOutputFormats, ", ")),
+				Value:   output.Format,
+			})
+		}
+	}
+
+	if o := cfg.Generate.MemberOrder; o != "" && !IsValidMemberOrder(o) {
+		errs = append(errs, ValidationError{
+			Field:   "generate.memberOrder",
+			Message: fmt.Sprintf("must be one of: %s", strings.Join(validMemberOrders, ", ")),
+			Value:   o,
+		})
+	}
+
+	for i, strip := range cfg.Generate.Publish.Strip {
+		if !IsValidPublishStrip(strip) {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("generate.publish.strip[%d]", i),
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(validPublishStrips, ", ")),
+				Value:   strip,
+			})
+		}
+	}
+
 	if t := cfg.Serve.Transforms.TypeScript.Target; t != "" && !IsValidTarget(t) {
 		errs = append(errs, ValidationError{
 			Field:   "serve.transforms.typescript.target",
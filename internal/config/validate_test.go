@@ -59,6 +59,128 @@ func TestValidate_RenderingMode(t *testing.T) {
 	}
 }
 
+func TestValidate_DemoEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"API_URL", false},
+		{"_private", false},
+		{"featureFlag2", false},
+		{"2FA", true},
+		{"API-URL", true},
+		{"api url", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &CemConfig{Serve: ServeConfig{Demos: DemosConfig{Env: map[string]string{tt.name: "value"}}}}
+			errs := Validate(cfg, ValidateOptions{})
+			if tt.wantErr && errs == nil {
+				t.Errorf("expected error for env name %q", tt.name)
+			}
+			if !tt.wantErr && errs != nil {
+				t.Errorf("unexpected error for env name %q: %v", tt.name, errs)
+			}
+			if tt.wantErr && errs != nil && errs[0].Field != "serve.demos.env" {
+				t.Errorf("expected field serve.demos.env, got %q", errs[0].Field)
+			}
+		})
+	}
+}
+
+func TestValidate_DemoThemeToggle(t *testing.T) {
+	tests := []struct {
+		name      string
+		toggle    ThemeToggleConfig
+		wantField string
+	}{
+		{"attribute", ThemeToggleConfig{Attribute: "data-theme"}, ""},
+		{"class", ThemeToggleConfig{Class: "dark"}, ""},
+		{"unset", ThemeToggleConfig{}, ""},
+		{"attribute with space", ThemeToggleConfig{Attribute: "data theme"}, "serve.demos.themeToggle.attribute"},
+		{"attribute with quote", ThemeToggleConfig{Attribute: `x"onload`}, "serve.demos.themeToggle.attribute"},
+		{"several classes", ThemeToggleConfig{Class: "dark theme"}, "serve.demos.themeToggle.class"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &CemConfig{Serve: ServeConfig{Demos: DemosConfig{ThemeToggle: tt.toggle}}}
+			errs := Validate(cfg, ValidateOptions{})
+			if tt.wantField == "" {
+				if errs != nil {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != tt.wantField {
+				t.Errorf("expected one error for %s, got %v", tt.wantField, errs)
+			}
+		})
+	}
+}
+
+func TestValidate_Categories(t *testing.T) {
+	cfg := &CemConfig{Generate: GenerateConfig{Categories: map[string][]string{
+		"form":   {"my-input", "my-select-*"},
+		"layout": {"my-grid[", "my-stack"},
+	}}}
+	errs := Validate(cfg, ValidateOptions{})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Field != "generate.categories.layout" || errs[0].Value != "my-grid[" {
+		t.Errorf("unexpected error %+v", errs[0])
+	}
+}
+
+func TestValidate_Outputs(t *testing.T) {
+	cfg := &CemConfig{Generate: GenerateConfig{Outputs: []OutputConfig{
+		{Path: "web-types.json", Format: "web-types"},
+		{Path: "", Format: "dts"},
+		{Path: "custom-data.json", Format: "vscode"},
+	}}}
+	errs := Validate(cfg, ValidateOptions{})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if errs[0].Field != "generate.outputs[1].path" {
+		t.Errorf("unexpected error %+v", errs[0])
+	}
+	if errs[1].Field != "generate.outputs[2].format" || errs[1].Value != "vscode" {
+		t.Errorf("unexpected error %+v", errs[1])
+	}
+}
+
+func TestValidate_MemberOrder(t *testing.T) {
+	for _, order := range []string{"", "source", "alphabetical", "kind"} {
+		cfg := &CemConfig{Generate: GenerateConfig{MemberOrder: order}}
+		if errs := Validate(cfg, ValidateOptions{}); len(errs) != 0 {
+			t.Errorf("memberOrder %q: unexpected errors %v", order, errs)
+		}
+	}
+	cfg := &CemConfig{Generate: GenerateConfig{MemberOrder: "alpha"}}
+	errs := Validate(cfg, ValidateOptions{})
+	if len(errs) != 1 || errs[0].Field != "generate.memberOrder" || errs[0].Value != "alpha" {
+		t.Errorf("expected one generate.memberOrder error, got %v", errs)
+	}
+}
+
+func TestValidate_PublishStrip(t *testing.T) {
+	cfg := &CemConfig{Generate: GenerateConfig{Publish: PublishConfig{
+		Strip: []string{"source", "private", "extensions"},
+	}}}
+	if errs := Validate(cfg, ValidateOptions{}); len(errs) != 0 {
+		t.Errorf("unexpected errors %v", errs)
+	}
+	cfg = &CemConfig{Generate: GenerateConfig{Publish: PublishConfig{
+		Strip: []string{"source", "comments"},
+	}}}
+	errs := Validate(cfg, ValidateOptions{})
+	if len(errs) != 1 || errs[0].Field != "generate.publish.strip[1]" || errs[0].Value != "comments" {
+		t.Errorf("expected one generate.publish.strip[1] error, got %v", errs)
+	}
+}
+
 func TestValidate_ESTarget(t *testing.T) {
 	valid := []string{
 		"", "es2015", "es2016", "es2017", "es2018", "es2019",
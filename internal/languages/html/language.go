@@ -22,6 +22,11 @@ func init() {
 		pool:   languages.NewParserPool(tsLang),
 	}
 	languages.Register(lang)
+	languages.RegisterTemplateSyntax(languages.TemplateSyntax{
+		Name:     "lit",
+		Tags:     []string{"html"},
+		Language: "html",
+	})
 }
 
 type language struct {
@@ -0,0 +1,77 @@
+package languages
+
+import (
+	"fmt"
+	"sync"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TemplateSyntax is a tagged template literal syntax, whose templates manifest
+// generation parses for slots and parts, e.g. Lit's html`<slot></slot>`.
+type TemplateSyntax struct {
+	// Name identifies the syntax, e.g. "lit".
+	Name string
+	// Tags are the tag functions of the syntax's templates. A tag matches
+	// either the tag expression, e.g. "html" or "uhtml.html", or the callee
+	// of a tag call, e.g. "hyperHTML.bind" for hyperHTML.bind(this)`...`.
+	Tags []string
+	// Language is the registered language which parses the templates. Its
+	// generate scope must include a "slotsAndParts" query with the same
+	// captures as the HTML language's. Defaults to "html".
+	Language string
+}
+
+var (
+	templateSyntaxes   = map[string]TemplateSyntax{}
+	templateSyntaxesMu sync.RWMutex
+)
+
+// RegisterTemplateSyntax adds a template syntax to the global registry, so
+// that its templates' slots and parts are extracted. Called by language
+// packages in their init() functions.
+func RegisterTemplateSyntax(syntax TemplateSyntax) {
+	if syntax.Language == "" {
+		syntax.Language = "html"
+	}
+	templateSyntaxesMu.Lock()
+	defer templateSyntaxesMu.Unlock()
+	for _, tag := range syntax.Tags {
+		if existing, exists := templateSyntaxes[tag]; exists {
+			panic(fmt.Sprintf("template tag %q already registered by %q", tag, existing.Name))
+		}
+	}
+	for _, tag := range syntax.Tags {
+		templateSyntaxes[tag] = syntax
+	}
+}
+
+// TemplateSyntaxForTag returns the registered syntax of a template tag.
+func TemplateSyntaxForTag(tag string) (TemplateSyntax, bool) {
+	templateSyntaxesMu.RLock()
+	defer templateSyntaxesMu.RUnlock()
+	syntax, ok := templateSyntaxes[tag]
+	return syntax, ok
+}
+
+// TemplateSyntaxForNode returns the registered syntax of a tagged template,
+// given its template_string node.
+func TemplateSyntaxForNode(template *ts.Node, code []byte) (TemplateSyntax, bool) {
+	call := template.Parent()
+	if call == nil || call.Kind() != "call_expression" {
+		return TemplateSyntax{}, false
+	}
+	tag := call.ChildByFieldName("function")
+	if tag == nil {
+		return TemplateSyntax{}, false
+	}
+	if syntax, ok := TemplateSyntaxForTag(tag.Utf8Text(code)); ok {
+		return syntax, true
+	}
+	if tag.Kind() == "call_expression" {
+		if callee := tag.ChildByFieldName("function"); callee != nil {
+			return TemplateSyntaxForTag(callee.Utf8Text(code))
+		}
+	}
+	return TemplateSyntax{}, false
+}
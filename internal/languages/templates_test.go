@@ -0,0 +1,64 @@
+package languages_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/languages"
+	ts "github.com/tree-sitter/go-tree-sitter"
+	tsTypescript "github.com/tree-sitter/tree-sitter-typescript/bindings/go"
+)
+
+func TestTemplateSyntaxForNode(t *testing.T) {
+	languages.RegisterTemplateSyntax(languages.TemplateSyntax{
+		Name: "test-hyperhtml",
+		Tags: []string{"test.html", "test.bind"},
+	})
+
+	tests := []struct {
+		name   string
+		code   string
+		syntax string
+	}{
+		{name: "tag expression", code: "test.html`<slot></slot>`", syntax: "test-hyperhtml"},
+		{name: "tag call", code: "test.bind(this)`<slot></slot>`", syntax: "test-hyperhtml"},
+		{name: "unregistered tag", code: "css`:host{}`"},
+	}
+
+	parser := ts.NewParser()
+	defer parser.Close()
+	if err := parser.SetLanguage(ts.NewLanguage(tsTypescript.LanguageTypescript())); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := []byte(tt.code)
+			tree := parser.Parse(code, nil)
+			defer tree.Close()
+
+			template := findKind(tree.RootNode(), "template_string")
+			if template == nil {
+				t.Fatal("Expected a template string")
+			}
+			syntax, ok := languages.TemplateSyntaxForNode(template, code)
+			if ok != (tt.syntax != "") || syntax.Name != tt.syntax {
+				t.Errorf("Expected syntax %q, got %q (ok: %v)", tt.syntax, syntax.Name, ok)
+			}
+			if ok && syntax.Language != "html" {
+				t.Errorf("Expected the html language by default, got %q", syntax.Language)
+			}
+		})
+	}
+}
+
+func findKind(node *ts.Node, kind string) *ts.Node {
+	if node.Kind() == kind {
+		return node
+	}
+	for i := range node.ChildCount() {
+		if found := findKind(node.Child(i), kind); found != nil {
+			return found
+		}
+	}
+	return nil
+}
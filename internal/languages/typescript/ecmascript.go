@@ -20,6 +20,7 @@ import (
 	"slices"
 	"strings"
 
+	"bennypowers.dev/cem/internal/languages"
 	htmllang "bennypowers.dev/cem/internal/languages/html"
 	Q "bennypowers.dev/cem/internal/treesitter"
 	ts "github.com/tree-sitter/go-tree-sitter"
@@ -167,6 +168,13 @@ func FindSlotDefinitionInSource(content []byte, slotName string, queryManager *Q
 	for captureMap := range classQueries.ParentCaptures(tree.RootNode(), content, "class") {
 		if templates, ok := captureMap["render.template"]; ok {
 			for _, template := range templates {
+				templateNode := Q.GetDescendantById(tree.RootNode(), template.NodeId)
+				if templateNode == nil {
+					continue
+				}
+				if _, ok := languages.TemplateSyntaxForNode(templateNode, content); !ok {
+					continue
+				}
 				templateContent := template.Text
 				if len(templateContent) >= 2 && templateContent[0] == '`' && templateContent[len(templateContent)-1] == '`' {
 					templateContent = templateContent[1 : len(templateContent)-1]
@@ -174,11 +182,7 @@ func FindSlotDefinitionInSource(content []byte, slotName string, queryManager *Q
 
 				slotRange := findSlotInTemplate(templateContent, slotName, queryManager)
 				if slotRange != nil {
-					templateNode := Q.GetDescendantById(tree.RootNode(), template.NodeId)
-					if templateNode != nil {
-						adjustedRange := adjustTemplateRange(slotRange, templateNode, content)
-						return adjustedRange, nil
-					}
+					return adjustTemplateRange(slotRange, templateNode, content), nil
 				}
 			}
 		}
@@ -187,10 +191,28 @@ func FindSlotDefinitionInSource(content []byte, slotName string, queryManager *Q
 	return nil, nil
 }
 
+// DefinedElement is a custom element tag name defined in source code, along
+// with the range of the tag name argument which defines it.
+type DefinedElement struct {
+	TagName string
+	Range   Q.Range
+}
+
 // FindDefinedElementTags uses the definedElements.scm query to find
 // custom element tag names defined in TypeScript/JavaScript source code
 // via @customElement decorators or customElements.define calls.
 func FindDefinedElementTags(code []byte, qm *Q.QueryManager) []string {
+	var tags []string
+	for _, element := range FindDefinedElements(code, qm) {
+		tags = append(tags, element.TagName)
+	}
+	return tags
+}
+
+// FindDefinedElements is like FindDefinedElementTags, but also reports where
+// each element is defined. When an element is defined more than once, only
+// the first definition is reported.
+func FindDefinedElements(code []byte, qm *Q.QueryManager) []DefinedElement {
 	parser := BorrowParser()
 	defer ReturnParser(parser)
 
@@ -211,7 +233,7 @@ func FindDefinedElementTags(code []byte, qm *Q.QueryManager) []string {
 	}
 	defer matcher.Close()
 
-	var tags []string
+	var elements []DefinedElement
 	for match := range matcher.AllQueryMatches(root, code) {
 		if match == nil {
 			continue
@@ -228,12 +250,16 @@ func FindDefinedElementTags(code []byte, qm *Q.QueryManager) []string {
 			case "defined.tagNameRef":
 				tag = resolveConstStringValue(root, code, capture.Node.Utf8Text(code), qm)
 			}
-			if tag != "" && !slices.Contains(tags, tag) {
-				tags = append(tags, tag)
+			if tag == "" || slices.ContainsFunc(elements, func(e DefinedElement) bool { return e.TagName == tag }) {
+				continue
 			}
+			elements = append(elements, DefinedElement{
+				TagName: tag,
+				Range:   Q.NodeToRange(&capture.Node, code),
+			})
 		}
 	}
-	return tags
+	return elements
 }
 
 func matchesAttribute(memberName, decoratorAttrName, targetAttr string) bool {
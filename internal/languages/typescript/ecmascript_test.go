@@ -357,3 +357,36 @@ export class LetElement extends LitElement {}
 		}
 	})
 }
+
+func TestFindDefinedElements(t *testing.T) {
+	qm := newTestQueryManager(t)
+
+	src := []byte(`import { LitElement } from 'lit';
+import { customElement } from 'lit/decorators.js';
+
+@customElement('my-element')
+export class MyElement extends LitElement {}
+
+class OtherElement extends HTMLElement {}
+customElements.define('other-element', OtherElement);
+`)
+	elements := FindDefinedElements(src, qm)
+	if len(elements) != 2 {
+		t.Fatalf("expected 2 elements, got %d: %v", len(elements), elements)
+	}
+	expected := []DefinedElement{
+		{TagName: "my-element", Range: Q.Range{
+			Start: Q.Position{Line: 3, Character: 16},
+			End:   Q.Position{Line: 3, Character: 26},
+		}},
+		{TagName: "other-element", Range: Q.Range{
+			Start: Q.Position{Line: 7, Character: 23},
+			End:   Q.Position{Line: 7, Character: 36},
+		}},
+	}
+	for i, want := range expected {
+		if elements[i] != want {
+			t.Errorf("element %d: expected %+v, got %+v", i, want, elements[i])
+		}
+	}
+}
@@ -6,11 +6,12 @@
   ; protected field: type = 'initializer'
   ; @property() attr = 'attr'
   ; @property({ attribute: 'attr-name' }) attrName = 'attr-name'
+  ; @attr({ mode: 'boolean' }) disabled = false
   (public_field_definition
     (decorator)*
     (decorator
       (call_expression
-        function: (identifier) @decorator.name (#eq? @decorator.name "property")
+        function: (identifier) @decorator.name
         arguments: (arguments
                      (object [
                        (pair
@@ -22,12 +23,16 @@
                        (pair
                          key: (property_identifier) @key2
                          value: (_) @field.attr.reflect (#eq? @key2 "reflect"))
-                     ]))?))?
+                       (pair
+                         key: (property_identifier) @key3
+                         value: (string (string_fragment) @field.attr.mode) (#eq? @key3 "mode"))
+                     ]))?)
+      (#any-of? @decorator.name "property" "attr"))?
     (decorator)*
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
     "readonly"? @field.readonly
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     type: (type_annotation (_) @field.type)?
     value: (_)? @field.initializer
       (#not-match? @field.initializer "\\(.*\\) \\=\\> "))) @field @member
@@ -41,11 +46,12 @@
   ; protected field: type;
   ; @property() attr;
   ; @property({ attribute: 'attr-name' }) attrName;
+  ; @attr({ attribute: 'attr-name' }) attrName;
   (public_field_definition
     (decorator)*
     (decorator
       (call_expression
-        function: (identifier) @decorator.name (#eq? @decorator.name "property")
+        function: (identifier) @decorator.name
         arguments: (arguments
                      (object [
                        (pair
@@ -57,12 +63,16 @@
                        (pair
                          key: (property_identifier) @key2
                          value: (_) @field.attr.reflect (#eq? @key2 "reflect"))
-                     ]))?))?
+                       (pair
+                         key: (property_identifier) @key3
+                         value: (string (string_fragment) @field.attr.mode) (#eq? @key3 "mode"))
+                     ]))?)
+      (#any-of? @decorator.name "property" "attr"))?
     (decorator)*
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
     "readonly"? @field.readonly
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     type: (type_annotation (_) @field.type)?
     !value) @field @member)
 
@@ -74,12 +84,13 @@
     (decorator)*
     (decorator
       (call_expression
-        function: (identifier) @decorator.name (#eq? @decorator.name "property")))?
+        function: (identifier) @decorator.name)
+      (#any-of? @decorator.name "property" "attr"))?
     (decorator)*
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
     "readonly"? @field.readonly
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     type: (type_annotation (_) @field.type)?
     value: (_)? @field.initializer
       (#not-match? @field.initializer "\\(.*\\) \\=\\> "))) @field @member
@@ -92,15 +103,35 @@
     (decorator)*
     (decorator
       (call_expression
-        function: (identifier) @decorator.name (#eq? @decorator.name "property")))?
+        function: (identifier) @decorator.name)
+      (#any-of? @decorator.name "property" "attr"))?
     (decorator)*
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
     "readonly"? @field.readonly
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     type: (type_annotation (_) @field.type)?
     !value) @field @member)
 
+( ; FAST attribute without options, which the patterns above can't
+  ; capture: an optional bare decorator would also match, and then
+  ; drop, fields with other bare decorators like @observable
+  ; @examples:
+  ; @attr label: string = 'Click'
+  ; @attr label?: string;
+  (public_field_definition
+    (decorator)*
+    (decorator
+      (identifier) @decorator.name (#eq? @decorator.name "attr"))
+    (decorator)*
+    (accessibility_modifier)? @member.privacy
+    "static"? @member.static
+    "readonly"? @field.readonly
+    name: [(property_identifier) (private_property_identifier)] @member.name
+    type: (type_annotation (_) @field.type)?
+    value: (_)? @field.initializer
+      (#not-match? @field.initializer "\\(.*\\) \\=\\> "))) @field @member
+
 ; class constructor properties (typescript only)
 (method_definition
   ; example : constructor (public field: Type) {}
@@ -122,7 +153,7 @@
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
     ["get" "set"] @field.accessor
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     parameters: (formal_parameters (_
                                      pattern: (identifier) @param.name
                                      type: (type_annotation (_) @param.type)))?
@@ -140,7 +171,7 @@
   ; set described() {}
   (decorator
     (call_expression
-      function: (identifier) @decorator.name (#eq? @decorator.name "property")
+      function: (identifier) @decorator.name
       arguments: (arguments
                    (object [
                      (pair
@@ -152,12 +183,16 @@
                      (pair
                        key: (property_identifier) @key2
                        value: (_) @field.attr.reflect (#eq? @key2 "reflect"))
-                   ]))?))
+                     (pair
+                       key: (property_identifier) @key3
+                       value: (string (string_fragment) @field.attr.mode) (#eq? @key3 "mode"))
+                   ]))?)
+    (#any-of? @decorator.name "property" "attr"))
   (method_definition
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
     ["get" "set"] @field.accessor
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     parameters: (formal_parameters (_
                                      pattern: (identifier) @param.name
                                      type: (type_annotation (_) @param.type)))?
@@ -174,7 +209,7 @@
     "static"? @member.static
     ["get" "set"]? @accessor
     (#not-any-of? @accessor "get" "set")
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     parameters: (formal_parameters
                   (_
                      pattern: [
@@ -194,7 +229,7 @@
   (public_field_definition
     (accessibility_modifier)? @member.privacy
     "static"? @member.static
-    name: (property_identifier) @member.name
+    name: [(property_identifier) (private_property_identifier)] @member.name
     value: (arrow_function
       parameters: (formal_parameters
                     (_
@@ -14,24 +14,38 @@
           value: (_) @superclass.expression))?
       body: (class_body
         ; static observedAttributes = ['a', 'b']
+        ; static observedAttributes = ['a', 'b'] as const
         (public_field_definition
           "static"
           name: (property_identifier) @observedAttributes.fieldName (#eq? @observedAttributes.fieldName "observedAttributes")
-          value: (array
-            (string
-              (string_fragment) @observedAttributes.attributeName)))? @observedAttributes
+          value: [
+            (array
+              (string
+                (string_fragment) @observedAttributes.attributeName))
+            (as_expression
+              (array
+                (string
+                  (string_fragment) @observedAttributes.attributeName)))
+          ])? @observedAttributes
 
         ; static get observedAttributes() { return ['a', 'b']; }
+        ; static get observedAttributes() { return ['a', 'b'] as const; }
         (method_definition
           "static"
           "get"
-          name: (property_identifier) @observedAttributes.fieldName
+          name: (property_identifier) @observedAttributes.fieldName (#eq? @observedAttributes.fieldName "observedAttributes")
           parameters: (formal_parameters)
           body: (statement_block
             (return_statement
-              (array
-                (string
-                  (string_fragment) @observedAttributes.attributeName)))))? @observedAttributes)) @class.declaration)) @class
+              [
+                (array
+                  (string
+                    (string_fragment) @observedAttributes.attributeName))
+                (as_expression
+                  (array
+                    (string
+                      (string_fragment) @observedAttributes.attributeName)))
+              ])))? @observedAttributes)) @class.declaration)) @class
 
 ( ; non-exported non-litelement class
   ;
@@ -48,24 +62,38 @@
         value: (_) @superclass.expression))?
     body: (class_body
       ; static observedAttributes = ['a', 'b']
+      ; static observedAttributes = ['a', 'b'] as const
       (public_field_definition
         "static"
         name: (property_identifier) @observedAttributes.fieldName (#eq? @observedAttributes.fieldName "observedAttributes")
-        value: (array
-          (string
-            (string_fragment) @observedAttributes.attributeName)))? @observedAttributes
+        value: [
+          (array
+            (string
+              (string_fragment) @observedAttributes.attributeName))
+          (as_expression
+            (array
+              (string
+                (string_fragment) @observedAttributes.attributeName)))
+        ])? @observedAttributes
 
       ; static get observedAttributes() { return ['a', 'b']; }
+      ; static get observedAttributes() { return ['a', 'b'] as const; }
       (method_definition
         "static"
         "get"
-        name: (property_identifier) @observedAttributes.fieldName
+        name: (property_identifier) @observedAttributes.fieldName (#eq? @observedAttributes.fieldName "observedAttributes")
         parameters: (formal_parameters)
         body: (statement_block
           (return_statement
-            (array
-              (string
-                (string_fragment) @observedAttributes.attributeName)))))? @observedAttributes)) @class.declaration) @class
+            [
+              (array
+                (string
+                  (string_fragment) @observedAttributes.attributeName))
+              (as_expression
+                (array
+                  (string
+                    (string_fragment) @observedAttributes.attributeName)))
+            ])))? @observedAttributes)) @class.declaration) @class
 
 ( ; exported custom element class (any base class)
   ;
@@ -87,7 +115,13 @@
       (call_expression
         function: (identifier) @class.decorator.name
         arguments: (arguments
-                     [(string (string_fragment) @tag-name) (identifier) @tag-name-ref]
+                     [(string (string_fragment) @tag-name)
+                      (identifier) @tag-name-ref
+                      ; FAST: @customElement({ name: 'custom-element', template })
+                      (object
+                        (pair
+                          key: (property_identifier) @_tag-name.key (#eq? @_tag-name.key "name")
+                          value: [(string (string_fragment) @tag-name) (identifier) @tag-name-ref]))]
                      (#eq? @class.decorator.name "customElement"))))
     (decorator) *
     declaration: (class_declaration
@@ -122,7 +156,7 @@
                   ; `;
                   (return_statement
                     (call_expression
-                      function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                      function: (_) @_t_tag
                       arguments: (template_string) @render.template))
 
                   ; return awesome ? html`
@@ -133,7 +167,7 @@
                   (return_statement
                     (ternary_expression
                       (call_expression
-                        function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                        function: (_) @_t_tag
                         arguments: (template_string) @render.template)))
 
                   ; return [
@@ -143,7 +177,7 @@
                   (return_statement
                     (array
                       (call_expression
-                        function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                        function: (_) @_t_tag
                         arguments: (template_string) @render.template)))
 
                   ; const composedSlot = html`
@@ -152,7 +186,7 @@
                   (lexical_declaration
                     (variable_declarator
                       (call_expression
-                        function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                        function: (_) @_t_tag
                         arguments: (template_string) @render.template)))
 
                 ]))?)
@@ -172,7 +206,13 @@
       (call_expression
         function: (identifier) @class.decorator.name
         arguments: (arguments
-                     [(string (string_fragment) @tag-name) (identifier) @tag-name-ref]
+                     [(string (string_fragment) @tag-name)
+                      (identifier) @tag-name-ref
+                      ; FAST: @customElement({ name: 'custom-element', template })
+                      (object
+                        (pair
+                          key: (property_identifier) @_tag-name.key (#eq? @_tag-name.key "name")
+                          value: [(string (string_fragment) @tag-name) (identifier) @tag-name-ref]))]
                      (#eq? @class.decorator.name "customElement"))))
     (decorator) *
     name: (type_identifier) @class.name
@@ -189,7 +229,7 @@
                   ; `;
                   (return_statement
                     (call_expression
-                      function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                      function: (_) @_t_tag
                       arguments: (template_string) @render.template))
 
                   ; return awesome ? html`
@@ -200,7 +240,7 @@
                   (return_statement
                     (ternary_expression
                       (call_expression
-                        function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                        function: (_) @_t_tag
                         arguments: (template_string) @render.template)))
 
                   ; return [
@@ -210,7 +250,7 @@
                   (return_statement
                     (array
                       (call_expression
-                        function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                        function: (_) @_t_tag
                         arguments: (template_string) @render.template)))
 
                   ; const composedSlot = html`
@@ -219,7 +259,7 @@
                   (lexical_declaration
                     (variable_declarator
                       (call_expression
-                        function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                        function: (_) @_t_tag
                         arguments: (template_string) @render.template)))
 
                   ; const composedSlot = tern ? html`
@@ -231,7 +271,7 @@
                     (variable_declarator
                       (ternary_expression
                         (call_expression
-                          function: (identifier) @_t_tag (#eq? @_t_tag "html")
+                          function: (_) @_t_tag
                           arguments: (template_string) @render.template))))
 
                 ]))?
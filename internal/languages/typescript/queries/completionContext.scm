@@ -33,6 +33,21 @@
   (#eq? @property.name "outerHTML")
 ) @context
 
+;; Module specifiers - import ... from '...', import '...', export ... from '...'
+(import_statement
+  source: (string) @import.source
+) @context
+
+(export_statement
+  source: (string) @import.source
+) @context
+
+;; Dynamic imports - import('...')
+(call_expression
+  function: (import)
+  arguments: (arguments . (string) @import.source)
+) @context
+
 ;; Template substitutions (JavaScript expressions inside ${...})
 ;; These should NOT be captured for CEM completions
 (template_substitution) @interpolation
\ No newline at end of file
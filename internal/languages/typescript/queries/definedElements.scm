@@ -2,23 +2,33 @@
 ; Used by LSP diagnostics to skip "unknown element" errors for locally-defined elements.
 ; Supports both string literals and identifier references (resolved via constStringValue query).
 
-( ; @customElement('tag-name') on exported classes
+( ; @customElement('tag-name') or FAST's @customElement({ name: 'tag-name' }) on exported classes
   (export_statement
     (decorator
       (call_expression
         function: (identifier) @_decorator.name
         arguments: (arguments
-                     [(string (string_fragment) @defined.tagName) (identifier) @defined.tagNameRef])
+                     [(string (string_fragment) @defined.tagName)
+                      (identifier) @defined.tagNameRef
+                      (object
+                        (pair
+                          key: (property_identifier) @_key (#eq? @_key "name")
+                          value: [(string (string_fragment) @defined.tagName) (identifier) @defined.tagNameRef]))])
         (#eq? @_decorator.name "customElement")))
     declaration: (class_declaration)))
 
-( ; @customElement('tag-name') on non-exported classes
+( ; @customElement('tag-name') or FAST's @customElement({ name: 'tag-name' }) on non-exported classes
   (class_declaration
     (decorator
       (call_expression
         function: (identifier) @_decorator.name
         arguments: (arguments
-                     [(string (string_fragment) @defined.tagName) (identifier) @defined.tagNameRef])
+                     [(string (string_fragment) @defined.tagName)
+                      (identifier) @defined.tagNameRef
+                      (object
+                        (pair
+                          key: (property_identifier) @_key (#eq? @_key "name")
+                          value: [(string (string_fragment) @defined.tagName) (identifier) @defined.tagNameRef]))])
         (#eq? @_decorator.name "customElement")))))
 
 ( ; customElements.define('tag-name', Class)
@@ -0,0 +1,159 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Format controls how CLI output is written to stderr.
+type Format int
+
+const (
+	// FormatText writes styled, human-readable lines
+	FormatText Format = iota
+	// FormatJSON writes one JSON Record per line, for wrappers and CI
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFormat parses the value of the --log-format flag.
+func ParseFormat(s string) (Format, error) {
+	switch s {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return FormatText, fmt.Errorf("unknown log format %q: expected text or json", s)
+	}
+}
+
+// EventLog is the event type of plain log messages.
+const EventLog = "log"
+
+// Record is a line of JSON output. Log messages have the "log" event type;
+// other events, like "generate.complete", carry their data in Fields.
+type Record struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Event   string         `json:"event"`
+	Message string         `json:"message,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// jsonWriteMu keeps concurrent records on separate lines
+var jsonWriteMu sync.Mutex
+
+// SetFormat sets the output format.
+func (l *Logger) SetFormat(f Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = f
+}
+
+// Format returns the output format.
+func (l *Logger) Format() Format {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.format
+}
+
+// Event records a machine-readable event, such as the end of a generate run
+// and its stats. Events are only written in FormatJSON, where they are
+// written at any verbosity; in FormatText, callers print their own messages.
+func (l *Logger) Event(event string, fields map[string]any) {
+	if l.Format() != FormatJSON {
+		return
+	}
+	l.writeJSON(Record{
+		Level:  "info",
+		Event:  event,
+		Fields: fields,
+	})
+}
+
+// writeJSON writes a record as a line of JSON to the logger's output
+func (l *Logger) writeJSON(record Record) {
+	l.mu.RLock()
+	var out io.Writer = os.Stderr
+	if l.output != nil {
+		out = l.output
+	}
+	l.mu.RUnlock()
+
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	record.Message = ansi.Strip(record.Message)
+	if record.Event == "" {
+		record.Event = EventLog
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	jsonWriteMu.Lock()
+	defer jsonWriteMu.Unlock()
+	_, _ = out.Write(append(line, '\n'))
+}
+
+// jsonLevel names a log level in JSON records
+func jsonLevel(level LogLevel) string {
+	switch level {
+	case LogLevelTrace:
+		return "trace"
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+func SetFormat(f Format) {
+	globalLogger.SetFormat(f)
+}
+
+func CurrentFormat() Format {
+	return globalLogger.Format()
+}
+
+func Event(event string, fields map[string]any) {
+	globalLogger.Event(event, fields)
+}
@@ -25,6 +25,9 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 // Quiet (-q) suppresses info and debug. Verbose (-v) enables debug. These
 // flags are respected automatically by all log functions.
 //
+// With --log-format json, CLI output is written as one JSON Record per line
+// instead, and Event records machine-readable progress and stats.
+//
 // This package does NOT own terminal UI primitives (spinners, live areas,
 // colored display formatting). Those stay at their callsites.
 package logging
@@ -32,6 +35,7 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 
@@ -122,6 +126,8 @@ type Logger struct {
 	lspCtx    context.Context
 	verbosity Verbosity
 	serveSink ServeSink
+	format    Format
+	output    io.Writer // JSON output, os.Stderr when nil
 }
 
 // LoggerMode determines how logs are output
@@ -299,6 +305,10 @@ func (l *Logger) Critical(format string, args ...any) {
 
 	switch mode {
 	case ModeCLI:
+		if l.Format() == FormatJSON {
+			l.writeJSON(Record{Level: "error", Message: message})
+			return
+		}
 		_, _ = lipgloss.Fprintf(os.Stderr, "%s %s\n", tui.ErrorPrefix, message)
 	case ModeServe:
 		if sink != nil {
@@ -332,6 +342,10 @@ func (l *Logger) Notify(format string, args ...any) {
 
 	switch mode {
 	case ModeCLI:
+		if l.Format() == FormatJSON {
+			l.writeJSON(Record{Level: "info", Message: message})
+			return
+		}
 		_, _ = lipgloss.Fprintf(os.Stderr, "%s %s\n", tui.InfoPrefix, message)
 	case ModeServe:
 		if sink != nil {
@@ -363,6 +377,16 @@ func (l *Logger) NotifyWithActions(message string, actions []MessageAction) {
 
 	switch mode {
 	case ModeCLI:
+		if l.Format() == FormatJSON {
+			fields := make(map[string]any, len(actions))
+			for _, action := range actions {
+				if action.URL != "" {
+					fields[action.Title] = action.URL
+				}
+			}
+			l.writeJSON(Record{Level: "info", Message: message, Fields: fields})
+			return
+		}
 		_, _ = lipgloss.Fprintf(os.Stderr, "%s %s\n", tui.InfoPrefix, message)
 		for _, action := range actions {
 			if action.URL != "" {
@@ -447,6 +471,10 @@ func (l *Logger) Success(format string, args ...any) {
 
 	switch mode {
 	case ModeCLI:
+		if l.Format() == FormatJSON {
+			l.writeJSON(Record{Level: "success", Message: message})
+			return
+		}
 		_, _ = lipgloss.Fprintf(os.Stderr, "%s %s\n", tui.SuccessPrefix, message)
 	case ModeServe:
 		if sink != nil {
@@ -502,6 +530,10 @@ func (l *Logger) logServe(level LogLevel, message string, sink ServeSink) {
 }
 
 func (l *Logger) logCLI(level LogLevel, message string) {
+	if l.Format() == FormatJSON {
+		l.writeJSON(Record{Level: jsonLevel(level), Message: message})
+		return
+	}
 	var prefix string
 	switch level {
 	case LogLevelTrace, LogLevelDebug:
@@ -519,6 +551,10 @@ func (l *Logger) logCLI(level LogLevel, message string) {
 // logLSP handles LSP-mode logging using LSP protocol messages
 func (l *Logger) logLSP(level LogLevel, message string, client protocol.Client, lspCtx context.Context) {
 	if client == nil {
+		if l.Format() == FormatJSON {
+			l.writeJSON(Record{Level: jsonLevel(level), Message: message})
+			return
+		}
 		fmt.Fprintf(os.Stderr, "[%s] %s\n", level.String(), message)
 		return
 	}
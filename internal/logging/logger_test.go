@@ -1,7 +1,10 @@
 package logging
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -201,3 +204,60 @@ func TestModeDoesNotAffectVerbosityGating(t *testing.T) {
 	assert.True(t, l.shouldLog(LogLevelDebug))
 	assert.False(t, l.shouldLog(LogLevelTrace))
 }
+
+func decodeRecords(t *testing.T, out *bytes.Buffer) []Record {
+	t.Helper()
+	var records []Record
+	for line := range strings.Lines(out.String()) {
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("expected a JSON record, got %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestJSONFormat(t *testing.T) {
+	var out bytes.Buffer
+	l := newTestLogger()
+	l.output = &out
+	l.SetFormat(FormatJSON)
+
+	l.Info("hidden at normal verbosity")
+	l.Warning("%d files skipped", 2)
+	l.Success("Wrote manifest to %s", "\x1b[32mcustom-elements.json\x1b[0m")
+	l.Event("generate.complete", map[string]any{"modules": 3})
+
+	records := decodeRecords(t, &out)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d: %s", len(records), out.String())
+	}
+	assert.Equal(t, "warning", records[0].Level)
+	assert.Equal(t, EventLog, records[0].Event)
+	assert.Equal(t, "2 files skipped", records[0].Message)
+	assert.False(t, records[0].Time.IsZero())
+	assert.Equal(t, "success", records[1].Level)
+	assert.Equal(t, "Wrote manifest to custom-elements.json", records[1].Message, "styles are stripped")
+	assert.Equal(t, "generate.complete", records[2].Event)
+	assert.Equal(t, float64(3), records[2].Fields["modules"])
+}
+
+func TestEventIsTextNoop(t *testing.T) {
+	var out bytes.Buffer
+	l := newTestLogger()
+	l.output = &out
+
+	l.Event("generate.complete", nil)
+	assert.Empty(t, out.String())
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": FormatText, "text": FormatText, "json": FormatJSON} {
+		got, err := ParseFormat(input)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseFormat("yaml")
+	assert.Error(t, err)
+}
@@ -28,6 +28,7 @@ import (
 	"bennypowers.dev/cem/internal/set"
 	"bennypowers.dev/cem/internal/treesitter"
 	"bennypowers.dev/cem/lsp/helpers"
+	M "bennypowers.dev/cem/manifest"
 )
 
 // ModuleGraph tracks the import/export relationships between modules
@@ -44,6 +45,18 @@ type ModuleGraph struct {
 	// Workspace root for lazy building
 	workspaceRoot string
 
+	// Lazy building state: the import paths, files, and manifest modules
+	// already walked, so that later builds skip them and UpdateFile can
+	// refresh just what changed. Guarded by buildMu.
+	buildMu              sync.Mutex
+	builtImportPaths     set.Set[string]
+	parsedFiles          set.Set[string]
+	builtManifestModules set.Set[string]
+
+	// Import map and workspace package.json for bare specifier resolution
+	importMap   *ImportMap
+	packageJSON *M.PackageJSON
+
 	// MaxTransitiveDepth is the maximum depth for transitive closure computation
 	// to prevent performance issues with deeply nested dependency chains
 	MaxTransitiveDepth int
@@ -433,10 +446,12 @@ func (mg *ModuleGraph) BuildFromWorkspace(workspaceRoot string) error {
 
 	mg.metrics.IncrementCounter("build_workspace_calls")
 
-	// Walk the workspace to find TypeScript/JavaScript files
+	// Walk the workspace to find TypeScript/JavaScript files,
+	// honoring .gitignore and .cemignore
 	skipDirs := set.NewSet("node_modules", "dist", "build")
 	wsFS := mg.fileParser.WorkspaceFS(workspaceRoot)
-	err := platform.WalkDir(wsFS, ".", skipDirs, func(path string, d fs.DirEntry, err error) error {
+	matcher := platform.LoadIgnoreMatcher(wsFS, ".", platform.WorkspaceIgnoreFiles...)
+	err := platform.WalkDirIgnoring(wsFS, ".", skipDirs, matcher, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			mg.metrics.IncrementCounter("file_walk_errors")
 			return err
@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package modulegraph
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"bennypowers.dev/cem/internal/set"
+	"bennypowers.dev/cem/lsp/helpers"
+)
+
+// Incremental Module Graph Updates
+// Lazy building walks each file and manifest module once. When a file
+// changes, UpdateFile refreshes only that file's edges, instead of
+// rebuilding everything that was walked before.
+
+// initBuildState allocates the lazy building state on first use.
+// Callers must hold buildMu.
+func (mg *ModuleGraph) initBuildState() {
+	if mg.builtImportPaths == nil {
+		mg.builtImportPaths = set.NewSet[string]()
+		mg.parsedFiles = set.NewSet[string]()
+		mg.builtManifestModules = set.NewSet[string]()
+	}
+}
+
+// UpdateFile brings the graph up to date after the source file at filePath
+// is created, changed, or deleted. It parses the file's imports and
+// re-exports again, replacing the edges it had, and invalidates the cached
+// transitive elements of only the modules which depend on it. New imports
+// are built as usual, but no other file is parsed again. Files which the
+// graph never walked are left for lazy building to find.
+func (mg *ModuleGraph) UpdateFile(filePath string) error {
+	switch filepath.Ext(filePath) {
+	case ".ts", ".js", ".mjs":
+	default:
+		return nil
+	}
+	if mg.workspaceRoot == "" {
+		return fmt.Errorf("workspace root not set for incremental module graph updates")
+	}
+
+	start := time.Now()
+	defer func() {
+		mg.metrics.RecordDuration("incremental_update_time", time.Since(start))
+	}()
+
+	mg.metrics.IncrementCounter("incremental_updates")
+
+	mg.buildMu.Lock()
+	defer mg.buildMu.Unlock()
+	mg.initBuildState()
+
+	changedModules := []string{mg.fileModulePath(filePath)}
+	manifestModule := mg.manifestResolver.GetManifestModulePath(filePath)
+	if manifestModule != "" {
+		changedModules = append(changedModules, manifestModule)
+	}
+
+	// Find dependents first: the changed modules' own edges are about to go,
+	// but the edges pointing at them stay
+	affected := mg.dependencyTracker.GetTransitiveDependents(changedModules...)
+
+	wasParsed := mg.parsedFiles.Has(filePath)
+	wasBuilt := manifestModule != "" && mg.builtManifestModules.Has(manifestModule)
+	for _, module := range changedModules {
+		mg.dependencyTracker.RemoveModule(module)
+		mg.exportTracker.RemoveReExportSources(module)
+	}
+	delete(mg.parsedFiles, filePath)
+	delete(mg.builtManifestModules, manifestModule)
+
+	// A created file may satisfy imports which previously resolved to
+	// nothing, so import paths are resolved again on their next build
+	clear(mg.builtImportPaths)
+
+	if wasParsed && mg.fileExists(filePath) {
+		if err := mg.processFileWithDependencies(filePath); err != nil {
+			mg.metrics.IncrementCounter("incremental_update_errors")
+			return fmt.Errorf("failed to update module graph for %s: %w", filePath, err)
+		}
+	}
+	if wasBuilt {
+		if err := mg.buildDependenciesRecursive(manifestModule); err != nil {
+			helpers.SafeDebugLog("[MODULE_GRAPH] Warning: Failed to rebuild dependencies for manifest module %s: %v", manifestModule, err)
+		}
+	}
+	mg.resolveReExportChains()
+
+	for _, module := range affected {
+		mg.TransitiveElementsCache.Delete(module)
+	}
+	helpers.SafeDebugLog("[MODULE_GRAPH] Updated %s, invalidating %d modules: %v", filePath, len(affected), affected)
+	return nil
+}
+
+// InvalidateManifestModules forgets which manifest modules' dependencies
+// were built, and every cached transitive element list, for when the
+// manifest changes which files map to which modules. File-level edges are
+// unaffected, so they are kept.
+func (mg *ModuleGraph) InvalidateManifestModules() {
+	mg.buildMu.Lock()
+	defer mg.buildMu.Unlock()
+	mg.initBuildState()
+
+	clear(mg.builtManifestModules)
+	clear(mg.builtImportPaths)
+	mg.ClearTransitiveElementsCache()
+}
+
+// fileModulePath converts a file path to the module path its imports are
+// recorded under, the same way parseFileExports does
+func (mg *ModuleGraph) fileModulePath(filePath string) string {
+	relPath, err := filepath.Rel(mg.workspaceRoot, filePath)
+	if err != nil {
+		relPath = filePath
+	}
+	return filepath.ToSlash(relPath)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package modulegraph_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bennypowers.dev/cem/internal/modulegraph"
+	"bennypowers.dev/cem/internal/treesitter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: integration test against a real temp directory, reusing the
+// transitive test files
+func TestModuleGraph_UpdateFile(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, createTransitiveTestFiles(tempDir))
+	queryManager, err := treesitter.GetGlobalQueryManager()
+	require.NoError(t, err)
+
+	metrics := modulegraph.NewDefaultMetricsCollector()
+	mg := modulegraph.NewModuleGraphWithDependencies(
+		modulegraph.NewOSFileParser(nil),
+		&modulegraph.DefaultExportParser{},
+		&modulegraph.NoOpManifestResolver{},
+		metrics,
+		queryManager,
+	)
+	mg.SetWorkspaceRoot(tempDir)
+
+	require.NoError(t, mg.BuildForImportPath("./my-tabs.ts"))
+	assert.Equal(t, int64(3), metrics.GetCounterValue("lazy_files_processed"))
+
+	t.Run("repeated builds don't parse again", func(t *testing.T) {
+		require.NoError(t, mg.BuildForImportPath("./my-tabs.ts"))
+		require.NoError(t, mg.BuildForImportPath("./my-tab.ts"))
+		assert.Equal(t, int64(3), metrics.GetCounterValue("lazy_files_processed"))
+	})
+
+	t.Run("changed file replaces its edges", func(t *testing.T) {
+		mg.TransitiveElementsCache.Store("my-tabs.ts", []string{"my-tabs", "my-tab", "my-icon"})
+		mg.TransitiveElementsCache.Store("unrelated.ts", []string{"unrelated"})
+
+		tabPath := filepath.Join(tempDir, "my-tab.ts")
+		require.NoError(t, os.WriteFile(tabPath, []byte("export class MyTab extends HTMLElement {}\n"), 0644))
+		require.NoError(t, mg.UpdateFile(tabPath))
+
+		assert.Empty(t, mg.GetModuleDependencies("my-tab.ts"))
+		assert.Equal(t, []string{"my-tab.ts"}, mg.GetModuleDependencies("my-tabs.ts"))
+		assert.Equal(t, int64(4), metrics.GetCounterValue("lazy_files_processed"), "only the changed file is parsed")
+
+		_, stale := mg.TransitiveElementsCache.Load("my-tabs.ts")
+		assert.False(t, stale, "dependents' transitive elements are invalidated")
+		_, kept := mg.TransitiveElementsCache.Load("unrelated.ts")
+		assert.True(t, kept, "unrelated transitive elements are kept")
+	})
+
+	t.Run("ignores files the graph never walked", func(t *testing.T) {
+		otherPath := filepath.Join(tempDir, "other.ts")
+		require.NoError(t, os.WriteFile(otherPath, []byte("import './my-icon.ts';\n"), 0644))
+		require.NoError(t, mg.UpdateFile(otherPath))
+		assert.Empty(t, mg.GetModuleDependencies("other.ts"))
+	})
+
+	t.Run("ignores non-script files", func(t *testing.T) {
+		require.NoError(t, mg.UpdateFile(filepath.Join(tempDir, "styles.css")))
+	})
+}
@@ -49,6 +49,17 @@ func (mg *ModuleGraph) BuildForImportPath(importPath string) error {
 
 	mg.metrics.IncrementCounter("lazy_build_calls")
 
+	mg.buildMu.Lock()
+	defer mg.buildMu.Unlock()
+	mg.initBuildState()
+
+	// Files and modules which were already walked stay current through
+	// UpdateFile, so a repeated import needs no work at all
+	if mg.builtImportPaths.Has(importPath) {
+		mg.metrics.IncrementCounter("lazy_build_skips")
+		return nil
+	}
+
 	// First, check if this import path corresponds to any known manifest modules
 	// The key insight: instead of resolving to files and parsing,
 	// we should discover dependencies between manifest modules
@@ -56,7 +67,7 @@ func (mg *ModuleGraph) BuildForImportPath(importPath string) error {
 	if manifestModule != "" {
 		helpers.SafeDebugLog("[MODULE_GRAPH] Found manifest module '%s' for import '%s'", manifestModule, importPath)
 		// Parse the source file for this manifest module to discover its dependencies
-		if err := mg.buildDependenciesForManifestModule(manifestModule); err != nil {
+		if err := mg.buildDependenciesRecursive(manifestModule); err != nil {
 			helpers.SafeDebugLog("[MODULE_GRAPH] Warning: Failed to build dependencies for manifest module %s: %v", manifestModule, err)
 		}
 	}
@@ -67,11 +78,8 @@ func (mg *ModuleGraph) BuildForImportPath(importPath string) error {
 		helpers.SafeDebugLog("[MODULE_GRAPH] Building graph for import %s -> %d files", importPath, len(filePaths))
 
 		// Parse only the specific files related to this import
-		// Use a set to track already processed files to avoid infinite recursion
-		processedFiles := make(map[string]bool)
-
 		for _, filePath := range filePaths {
-			if err := mg.processFileWithDependencies(filePath, processedFiles); err != nil {
+			if err := mg.processFileWithDependencies(filePath); err != nil {
 				mg.metrics.IncrementCounter("lazy_parse_errors")
 				helpers.SafeDebugLog("[MODULE_GRAPH] Warning: Failed to process file %s: %v", filePath, err)
 				// Continue with other files
@@ -88,6 +96,7 @@ func (mg *ModuleGraph) BuildForImportPath(importPath string) error {
 	// Cache invalidation is handled automatically by AddModuleDependency() calls
 	// during the recursive dependency building process, so no additional cache clearing needed
 
+	mg.builtImportPaths.Add(importPath)
 	mg.metrics.IncrementCounter("lazy_imports_built")
 	return nil
 }
@@ -152,53 +161,13 @@ func (mg *ModuleGraph) resolveImportPathToFilesRelativeTo(importPath, currentFil
 				}
 			}
 		}
-	} else if strings.Contains(importPath, "/") {
-		// Package import like '@rhds/elements/rh-tabs/rh-tabs.js' - look in workspace for matching files
-		// This handles cases where packages are developed locally
-
-		// Extract the meaningful part after the package name
-		// For '@rhds/elements/rh-tabs/rh-tabs.js' -> 'rh-tabs/rh-tabs.js'
-		var relativePath string
-		if strings.HasPrefix(importPath, "@") {
-			// Handle scoped packages like @rhds/elements/path/file.js
-			parts := strings.SplitN(importPath, "/", 3)
-			helpers.SafeDebugLog("[MODULE_GRAPH] 📦 Scoped package parts: %v", parts)
-			if len(parts) >= 3 {
-				relativePath = parts[2] // Get 'rh-tabs/rh-tabs.js'
-				helpers.SafeDebugLog("[MODULE_GRAPH] 📁 Extracted relative path: %s", relativePath)
-			} else {
-				relativePath = filepath.Base(importPath)
-				helpers.SafeDebugLog("[MODULE_GRAPH] 📁 Fallback to base path: %s", relativePath)
-			}
-		} else {
-			// Handle regular packages like some-package/path/file.js
-			parts := strings.SplitN(importPath, "/", 2)
-			helpers.SafeDebugLog("[MODULE_GRAPH] 📦 Regular package parts: %v", parts)
-			if len(parts) >= 2 {
-				relativePath = parts[1]
-				helpers.SafeDebugLog("[MODULE_GRAPH] 📁 Extracted relative path: %s", relativePath)
-			} else {
-				relativePath = filepath.Base(importPath)
-				helpers.SafeDebugLog("[MODULE_GRAPH] 📁 Fallback to base path: %s", relativePath)
-			}
-		}
-
-		possiblePaths := []string{
-			filepath.Join(mg.workspaceRoot, importPath),               // Try full import path
-			filepath.Join(mg.workspaceRoot, relativePath),             // Try relative path
-			filepath.Join(mg.workspaceRoot, "elements", relativePath), // Try in elements/ dir
-			filepath.Join(mg.workspaceRoot, "src", relativePath),      // Try in src/ dir
-			filepath.Join(mg.workspaceRoot, "lib", relativePath),      // Try in lib/ dir
-		}
-
-		helpers.SafeDebugLog("[MODULE_GRAPH] 🔍 Checking %d possible paths for import '%s'", len(possiblePaths), importPath)
-		for i, path := range possiblePaths {
-			helpers.SafeDebugLog("[MODULE_GRAPH] 📁 Path %d: %s", i+1, path)
-			if mg.fileExists(path) {
-				filePaths = append(filePaths, path)
-				helpers.SafeDebugLog("[MODULE_GRAPH] ✅ FOUND: %s", path)
-			} else {
-				helpers.SafeDebugLog("[MODULE_GRAPH] ❌ Not found: %s", path)
+	} else if resolved, ok := mg.ResolveSpecifier(importPath, currentFilePath); ok {
+		// Bare specifier resolved through the import map or package.json exports
+		helpers.SafeDebugLog("[MODULE_GRAPH] 📦 Resolved specifier '%s' to '%s'", importPath, resolved)
+		for _, candidate := range sourceCandidatesForResolvedPath(resolved) {
+			if mg.fileExists(candidate) {
+				filePaths = append(filePaths, candidate)
+				helpers.SafeDebugLog("[MODULE_GRAPH] ✅ FOUND: %s", candidate)
 			}
 		}
 	}
@@ -206,6 +175,24 @@ func (mg *ModuleGraph) resolveImportPathToFilesRelativeTo(importPath, currentFil
 	return filePaths
 }
 
+// sourceCandidatesForResolvedPath returns the resolved path along with the
+// TypeScript source it may have been compiled from, since packages often
+// export .js or .d.ts files while the workspace contains .ts sources
+func sourceCandidatesForResolvedPath(resolved string) []string {
+	if strings.Contains(resolved, "://") {
+		return nil
+	}
+	candidates := []string{resolved}
+	switch {
+	case strings.HasSuffix(resolved, ".d.ts"):
+		base := strings.TrimSuffix(resolved, ".d.ts")
+		candidates = append(candidates, base+".ts", base+".js")
+	case strings.HasSuffix(resolved, ".js"):
+		candidates = append(candidates, strings.TrimSuffix(resolved, ".js")+".ts")
+	}
+	return candidates
+}
+
 func (mg *ModuleGraph) fileExists(path string) bool {
 	return mg.fileParser.Exists(path)
 }
@@ -235,16 +222,18 @@ func (mg *ModuleGraph) processFile(filePath string) error {
 	return nil
 }
 
-// processFileWithDependencies processes a file and recursively processes its dependencies
-func (mg *ModuleGraph) processFileWithDependencies(filePath string, processedFiles map[string]bool) error {
-	// Check if already processed to avoid infinite recursion
-	if processedFiles[filePath] {
+// processFileWithDependencies processes a file and recursively processes its dependencies.
+// Callers must hold buildMu.
+func (mg *ModuleGraph) processFileWithDependencies(filePath string) error {
+	// Check if already processed, by this build or an earlier one, to avoid
+	// infinite recursion and re-parsing unchanged files
+	if mg.parsedFiles.Has(filePath) {
 		helpers.SafeDebugLog("[MODULE_GRAPH] Skipping already processed file: %s", filePath)
 		return nil
 	}
 
 	// Mark as processed
-	processedFiles[filePath] = true
+	mg.parsedFiles.Add(filePath)
 	helpers.SafeDebugLog("[MODULE_GRAPH] Processing file with dependencies: %s", filePath)
 
 	// Process the file itself
@@ -264,7 +253,7 @@ func (mg *ModuleGraph) processFileWithDependencies(filePath string, processedFil
 
 	// Recursively process each dependency
 	for _, depPath := range dependencies {
-		if err := mg.processFileWithDependencies(depPath, processedFiles); err != nil {
+		if err := mg.processFileWithDependencies(depPath); err != nil {
 			helpers.SafeDebugLog("[MODULE_GRAPH] Warning: Failed to process dependency %s: %v", depPath, err)
 			// Continue with other dependencies
 		}
@@ -355,30 +344,24 @@ func (mg *ModuleGraph) findManifestModuleForImportPath(importPath string) string
 	return ""
 }
 
-// buildDependenciesForManifestModule builds the complete dependency tree for a manifest module
+// buildDependenciesRecursive builds the complete dependency tree for a manifest module
 // by recursively tracking all imports and mapping them to manifest modules.
 // This ensures transitive dependency resolution works correctly (e.g., importing my-tabs.js
 // provides access to transitively imported elements like my-icon from my-tab.js).
-func (mg *ModuleGraph) buildDependenciesForManifestModule(manifestModule string) error {
-	visited := make(map[string]bool)
-	return mg.buildDependenciesRecursive(manifestModule, visited)
-}
-
-// buildDependenciesRecursive recursively builds dependencies for a manifest module and all
-// its transitive dependencies. The visited map prevents infinite recursion in circular
-// dependency scenarios while ensuring complete dependency tree construction.
-func (mg *ModuleGraph) buildDependenciesRecursive(manifestModule string, visited map[string]bool) error {
+// Modules already built are skipped, which prevents infinite recursion in circular
+// dependency scenarios. Callers must hold buildMu.
+func (mg *ModuleGraph) buildDependenciesRecursive(manifestModule string) error {
 	if mg.workspaceRoot == "" {
 		helpers.SafeDebugLog("[MODULE_GRAPH] No workspace root set, cannot build dependencies for manifest module: %s", manifestModule)
 		return nil
 	}
 
 	// Check if we've already processed this module to prevent infinite recursion
-	if visited[manifestModule] {
+	if mg.builtManifestModules.Has(manifestModule) {
 		helpers.SafeDebugLog("[MODULE_GRAPH] Skipping already processed manifest module: %s", manifestModule)
 		return nil
 	}
-	visited[manifestModule] = true
+	mg.builtManifestModules.Add(manifestModule)
 
 	helpers.SafeDebugLog("[MODULE_GRAPH] Building dependencies for manifest module: %s", manifestModule)
 
@@ -405,7 +388,7 @@ func (mg *ModuleGraph) buildDependenciesRecursive(manifestModule string, visited
 		}
 
 		// Process each dependency and recursively build its dependency tree
-		mg.processDependencies(manifestModule, dependencies, visited)
+		mg.processDependencies(manifestModule, dependencies)
 
 		helpers.SafeDebugLog("[MODULE_GRAPH] Successfully built dependencies for manifest module '%s' with %d dependencies", manifestModule, len(dependencies))
 		return nil
@@ -417,7 +400,7 @@ func (mg *ModuleGraph) buildDependenciesRecursive(manifestModule string, visited
 
 // processDependencies processes all file dependencies for a manifest module, mapping them
 // to manifest modules and recursively building their dependency trees.
-func (mg *ModuleGraph) processDependencies(manifestModule string, dependencies []string, visited map[string]bool) {
+func (mg *ModuleGraph) processDependencies(manifestModule string, dependencies []string) {
 	for _, depFilePath := range dependencies {
 		// Convert file path to manifest module path using ManifestResolver
 		depManifestModule := mg.manifestResolver.GetManifestModulePath(depFilePath)
@@ -431,7 +414,7 @@ func (mg *ModuleGraph) processDependencies(manifestModule string, dependencies [
 		helpers.SafeDebugLog("[MODULE_GRAPH] Manifest module dependency: %s -> %s (via file %s)", manifestModule, depManifestModule, depFilePath)
 
 		// Recursively build dependencies for the dependency module
-		if err := mg.buildDependenciesRecursive(depManifestModule, visited); err != nil {
+		if err := mg.buildDependenciesRecursive(depManifestModule); err != nil {
 			helpers.SafeDebugLog("[MODULE_GRAPH] Warning: Failed to recursively build dependencies for %s: %v", depManifestModule, err)
 			// Continue with other dependencies rather than failing completely
 		}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package modulegraph
+
+import (
+	"reflect"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+)
+
+func TestResolveImportPathToFiles_UnresolvedSpecifier(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/workspace/elements/rh-tabs/rh-tabs.js", "export class RhTabs extends HTMLElement {}", 0644)
+	fsys.AddFile("/workspace/vendor/lit/index.js", "export {};", 0644)
+
+	mg := NewModuleGraph(fsys, nil)
+	mg.SetWorkspaceRoot("/workspace")
+	// The import map overrides an unrelated package, and does not map the
+	// locally developed one
+	mg.SetImportMap(&ImportMap{
+		Imports: map[string]string{
+			"lit": "/vendor/lit/index.js",
+		},
+	})
+
+	if got, want := mg.resolveImportPathToFiles("lit"), []string{"/workspace/vendor/lit/index.js"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("import map: got %v, want %v", got, want)
+	}
+
+	// A specifier which neither the import map nor package.json resolves is
+	// not guessed from the workspace layout
+	if got := mg.resolveImportPathToFiles("@rhds/elements/rh-tabs/rh-tabs.js"); len(got) != 0 {
+		t.Errorf("unresolved specifier: got %v, want no files", got)
+	}
+}
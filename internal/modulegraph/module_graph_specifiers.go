@@ -0,0 +1,233 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package modulegraph
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// Specifier Resolution
+// These methods resolve bare module specifiers the way a browser or Node
+// would: through the workspace import map first, then through package.json
+// "exports" of the workspace package itself or of installed dependencies in
+// the node_modules directories above the importing file.
+
+// ImportMap is the subset of an ES module import map used for specifier
+// resolution. Addresses are URLs relative to the workspace root
+// (e.g. "/node_modules/lit/index.js"), or absolute remote URLs.
+type ImportMap struct {
+	Imports map[string]string            `json:"imports"`
+	Scopes  map[string]map[string]string `json:"scopes,omitempty"`
+}
+
+// SetImportMap sets the import map consulted when resolving bare specifiers
+func (mg *ModuleGraph) SetImportMap(importMap *ImportMap) {
+	mg.importMap = importMap
+}
+
+// SetPackageJSON sets the workspace package's package.json, whose "name" and
+// "exports" are used to resolve self-referencing specifiers
+func (mg *ModuleGraph) SetPackageJSON(packageJSON *M.PackageJSON) {
+	mg.packageJSON = packageJSON
+}
+
+// ResolveSpecifier resolves a bare specifier imported from referrer (a file
+// path, or "" for the workspace root) to an absolute file path in the
+// workspace, or to a remote URL. Returns false when the specifier cannot be
+// resolved through the import map, the workspace package's "exports", or an
+// installed package.
+func (mg *ModuleGraph) ResolveSpecifier(specifier, referrer string) (string, bool) {
+	if address, ok := ResolveImportMapSpecifier(mg.importMap, specifier, mg.referrerURL(referrer)); ok {
+		return mg.addressToPath(address), true
+	}
+
+	pkgName, subpath := splitBareSpecifier(specifier)
+	if pkgName == "" {
+		return "", false
+	}
+
+	// Self-reference: the workspace package importing itself by name
+	if mg.packageJSON != nil && mg.packageJSON.Name == pkgName {
+		if resolved, err := M.ResolveImportSubpath(mg.packageJSON, subpath); err == nil {
+			return filepath.Join(mg.workspaceRoot, resolved), true
+		}
+	}
+
+	// Installed dependency, in the nearest node_modules directory above the
+	// referrer which contains the package, as Node resolves it
+	for _, pkgDir := range mg.nodeModulesCandidates(pkgName, referrer) {
+		data, err := mg.fileParser.ReadFile(filepath.Join(pkgDir, "package.json"))
+		if err != nil {
+			continue
+		}
+		var pkg M.PackageJSON
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			helpers.SafeDebugLog("[MODULE_GRAPH] Failed to parse package.json for %s: %v", pkgName, err)
+			return "", false
+		}
+		resolved, err := resolveDependencySubpath(&pkg, subpath)
+		if err != nil {
+			return "", false
+		}
+		return filepath.Join(pkgDir, resolved), true
+	}
+	return "", false
+}
+
+// nodeModulesCandidates lists the directories a package could be installed
+// in, from the node_modules nearest to the referrer up to the filesystem root.
+// Without a referrer, the search starts from the workspace root.
+func (mg *ModuleGraph) nodeModulesCandidates(pkgName, referrer string) []string {
+	dir := mg.workspaceRoot
+	if referrer != "" {
+		dir = filepath.Dir(referrer)
+	}
+	var candidates []string
+	for {
+		if filepath.Base(dir) != "node_modules" {
+			candidates = append(candidates, filepath.Join(dir, "node_modules", pkgName))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return candidates
+		}
+		dir = parent
+	}
+}
+
+// resolveDependencySubpath resolves a subpath of an installed package. When
+// the package declares "exports", only exported subpaths resolve; otherwise
+// every file in the package is importable by its path.
+func resolveDependencySubpath(pkg *M.PackageJSON, subpath string) (string, error) {
+	if pkg.Exports != nil {
+		return M.ResolveImportSubpath(pkg, subpath)
+	}
+	if subpath == "." {
+		if resolved, err := M.ResolveImportSubpath(pkg, subpath); err == nil {
+			return resolved, nil
+		}
+		return "index.js", nil
+	}
+	return filepath.FromSlash(strings.TrimPrefix(subpath, "./")), nil
+}
+
+// ResolveImportMapSpecifier resolves a specifier against an import map per
+// the HTML import maps algorithm: scopes whose prefix matches referrerURL are
+// consulted from most to least specific, then the top-level imports. Within
+// each, an exact key wins over the longest matching trailing-slash prefix.
+func ResolveImportMapSpecifier(importMap *ImportMap, specifier, referrerURL string) (string, bool) {
+	if importMap == nil {
+		return "", false
+	}
+
+	scopePrefixes := make([]string, 0, len(importMap.Scopes))
+	for prefix := range importMap.Scopes {
+		if referrerURL == prefix || (strings.HasSuffix(prefix, "/") && strings.HasPrefix(referrerURL, prefix)) {
+			scopePrefixes = append(scopePrefixes, prefix)
+		}
+	}
+	sort.Slice(scopePrefixes, func(i, j int) bool {
+		return len(scopePrefixes[i]) > len(scopePrefixes[j])
+	})
+
+	for _, prefix := range scopePrefixes {
+		if address, ok := resolveImportsMatch(importMap.Scopes[prefix], specifier); ok {
+			return address, true
+		}
+	}
+	return resolveImportsMatch(importMap.Imports, specifier)
+}
+
+// resolveImportsMatch resolves a specifier against a single specifier map
+func resolveImportsMatch(imports map[string]string, specifier string) (string, bool) {
+	if address, ok := imports[specifier]; ok {
+		return address, true
+	}
+
+	bestKey := ""
+	for key := range imports {
+		if strings.HasSuffix(key, "/") && strings.HasPrefix(specifier, key) && len(key) > len(bestKey) {
+			bestKey = key
+		}
+	}
+	if bestKey == "" {
+		return "", false
+	}
+	address := imports[bestKey]
+	if !strings.HasSuffix(address, "/") {
+		// Invalid per spec: a trailing-slash key must map to a trailing-slash address
+		return "", false
+	}
+	return address + strings.TrimPrefix(specifier, bestKey), true
+}
+
+// splitBareSpecifier splits a bare specifier into its package name and the
+// package subpath, e.g. "@rhds/elements/rh-tabs/rh-tabs.js" becomes
+// ("@rhds/elements", "./rh-tabs/rh-tabs.js"). Returns "" for relative,
+// absolute, and URL specifiers.
+func splitBareSpecifier(specifier string) (pkgName, subpath string) {
+	if specifier == "" ||
+		strings.HasPrefix(specifier, ".") ||
+		strings.HasPrefix(specifier, "/") ||
+		strings.Contains(specifier, "://") {
+		return "", ""
+	}
+
+	segments := 1
+	if strings.HasPrefix(specifier, "@") {
+		segments = 2
+	}
+	parts := strings.SplitN(specifier, "/", segments+1)
+	if len(parts) < segments {
+		return "", ""
+	}
+	pkgName = strings.Join(parts[:segments], "/")
+	if len(parts) > segments {
+		return pkgName, "./" + parts[segments]
+	}
+	return pkgName, "."
+}
+
+// referrerURL converts a referrer file path to a workspace-relative URL for
+// matching import map scopes
+func (mg *ModuleGraph) referrerURL(referrer string) string {
+	if referrer == "" {
+		return "/"
+	}
+	rel, err := filepath.Rel(mg.workspaceRoot, referrer)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(referrer)
+	}
+	return "/" + filepath.ToSlash(rel)
+}
+
+// addressToPath converts an import map address to an absolute file path in
+// the workspace. Remote URLs are returned unchanged.
+func (mg *ModuleGraph) addressToPath(address string) string {
+	if strings.Contains(address, "://") {
+		return address
+	}
+	address = strings.TrimPrefix(address, "./")
+	address = strings.TrimPrefix(address, "/")
+	return filepath.Join(mg.workspaceRoot, filepath.FromSlash(address))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package modulegraph_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/modulegraph"
+	"bennypowers.dev/cem/internal/platform"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// Inline: pure function, table-driven
+
+func TestResolveImportMapSpecifier(t *testing.T) {
+	importMap := &modulegraph.ImportMap{
+		Imports: map[string]string{
+			"lit":                 "/node_modules/lit/index.js",
+			"lit/":                "/node_modules/lit/",
+			"@rhds/elements/":     "/elements/",
+			"@rhds/elements/lib/": "/lib/",
+			"bad/":                "/node_modules/bad/index.js",
+		},
+		Scopes: map[string]map[string]string{
+			"/node_modules/legacy/": {
+				"lit": "/node_modules/legacy/node_modules/lit/index.js",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		specifier   string
+		referrerURL string
+		want        string
+		wantOK      bool
+	}{
+		{"exact match", "lit", "/", "/node_modules/lit/index.js", true},
+		{"prefix match", "lit/decorators.js", "/", "/node_modules/lit/decorators.js", true},
+		{"longest prefix wins", "@rhds/elements/lib/context.js", "/", "/lib/context.js", true},
+		{"scoped package prefix", "@rhds/elements/rh-tabs/rh-tabs.js", "/", "/elements/rh-tabs/rh-tabs.js", true},
+		{"scope overrides top-level", "lit", "/node_modules/legacy/index.js", "/node_modules/legacy/node_modules/lit/index.js", true},
+		{"scope falls through to top-level", "lit/decorators.js", "/node_modules/legacy/index.js", "/node_modules/lit/decorators.js", true},
+		{"prefix key with non-slash address", "bad/thing.js", "/", "", false},
+		{"no match", "lit-html", "/", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := modulegraph.ResolveImportMapSpecifier(importMap, tt.specifier, tt.referrerURL)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ResolveImportMapSpecifier(%q, %q) = (%q, %v), want (%q, %v)",
+					tt.specifier, tt.referrerURL, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("nil import map", func(t *testing.T) {
+		if _, ok := modulegraph.ResolveImportMapSpecifier(nil, "lit", "/"); ok {
+			t.Error("expected no resolution for nil import map")
+		}
+	})
+}
+
+func TestModuleGraph_ResolveSpecifier(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/workspace/node_modules/@scope/dep/package.json", `{
+  "name": "@scope/dep",
+  "exports": {
+    ".": "./index.js",
+    "./*": "./dist/*"
+  }
+}`, 0644)
+	fsys.AddFile("/workspace/node_modules/legacy-dep/package.json", `{
+  "name": "legacy-dep",
+  "main": "lib/index.js"
+}`, 0644)
+	fsys.AddFile("/workspace/packages/app/node_modules/@scope/dep/package.json", `{
+  "name": "@scope/dep",
+  "exports": {
+    ".": "./nested.js"
+  }
+}`, 0644)
+
+	mg := modulegraph.NewModuleGraph(fsys, nil)
+	mg.SetWorkspaceRoot("/workspace")
+	mg.SetPackageJSON(&M.PackageJSON{
+		Name: "my-elements",
+		Exports: map[string]any{
+			"./*": "./elements/*",
+		},
+	})
+	mg.SetImportMap(&modulegraph.ImportMap{
+		Imports: map[string]string{
+			"mapped/": "/vendor/mapped/",
+		},
+	})
+
+	tests := []struct {
+		name      string
+		specifier string
+		referrer  string
+		want      string
+		wantOK    bool
+	}{
+		{"import map", "mapped/a.js", "", "/workspace/vendor/mapped/a.js", true},
+		{"self reference through exports", "my-elements/my-button/my-button.js", "", "/workspace/elements/my-button/my-button.js", true},
+		{"dependency root export", "@scope/dep", "", "/workspace/node_modules/@scope/dep/index.js", true},
+		{"dependency subpath pattern", "@scope/dep/util.js", "", "/workspace/node_modules/@scope/dep/dist/util.js", true},
+		{"nearest node_modules", "@scope/dep", "/workspace/packages/app/src/app.js", "/workspace/packages/app/node_modules/@scope/dep/nested.js", true},
+		{"nearest package exports apply", "@scope/dep/util.js", "/workspace/packages/app/src/app.js", "", false},
+		{"ancestor node_modules", "legacy-dep", "/workspace/packages/app/src/app.js", "/workspace/node_modules/legacy-dep/lib/index.js", true},
+		{"no exports root uses main", "legacy-dep", "", "/workspace/node_modules/legacy-dep/lib/index.js", true},
+		{"no exports subpath is a file path", "legacy-dep/lib/button.js", "", "/workspace/node_modules/legacy-dep/lib/button.js", true},
+		{"unknown package", "unknown/thing.js", "", "", false},
+		{"relative specifier", "./thing.js", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mg.ResolveSpecifier(tt.specifier, tt.referrer)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ResolveSpecifier(%q) = (%q, %v), want (%q, %v)", tt.specifier, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package modulegraph
 import (
 	"slices"
 	"sync"
+
+	"bennypowers.dev/cem/internal/set"
 )
 
 // ModuleExport represents an export statement in a module
@@ -169,6 +171,31 @@ func (et *ExportTracker) AddElementSource(tagName, modulePath string) {
 	et.ElementSources[tagName] = append(et.ElementSources[tagName], modulePath)
 }
 
+// RemoveReExportSources removes a module as a source of the elements it
+// only re-exports, so that re-export resolution can recompute them after the
+// module changes. Elements the module itself exports are kept.
+func (et *ExportTracker) RemoveReExportSources(modulePath string) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	exports := et.ModuleExports[modulePath]
+	for tagName, sources := range et.ElementSources {
+		if !slices.Contains(sources, modulePath) || slices.ContainsFunc(exports, func(export ModuleExport) bool {
+			return export.TagName == tagName
+		}) {
+			continue
+		}
+		sources = slices.DeleteFunc(slices.Clone(sources), func(source string) bool {
+			return source == modulePath
+		})
+		if len(sources) == 0 {
+			delete(et.ElementSources, tagName)
+		} else {
+			et.ElementSources[tagName] = sources
+		}
+	}
+}
+
 // DependencyTracker manages module dependencies and re-export chains
 type DependencyTracker struct {
 	mu sync.RWMutex
@@ -252,3 +279,43 @@ func (dt *DependencyTracker) GetReExportChains() map[string][]string {
 	}
 	return result
 }
+
+// RemoveModule forgets a module's imports and re-exports, so that they can
+// be parsed again after the module changes
+func (dt *DependencyTracker) RemoveModule(modulePath string) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	delete(dt.ModuleDependencies, modulePath)
+	delete(dt.ReExportChains, modulePath)
+}
+
+// GetTransitiveDependents returns the given modules along with every module
+// which imports or re-exports one of them, directly or transitively
+func (dt *DependencyTracker) GetTransitiveDependents(modulePaths ...string) []string {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	// Reverse the import and re-export edges
+	dependents := make(map[string][]string)
+	for _, edges := range []map[string][]string{dt.ModuleDependencies, dt.ReExportChains} {
+		for importer, imported := range edges {
+			for _, module := range imported {
+				dependents[module] = append(dependents[module], importer)
+			}
+		}
+	}
+
+	visited := set.NewSet[string]()
+	queue := slices.Clone(modulePaths)
+	for len(queue) > 0 {
+		module := queue[0]
+		queue = queue[1:]
+		if visited.Has(module) {
+			continue
+		}
+		visited.Add(module)
+		queue = append(queue, dependents[module]...)
+	}
+	return visited.Members()
+}
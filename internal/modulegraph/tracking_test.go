@@ -95,3 +95,38 @@ func TestDependencyTracker_GetReExportChains_DeepCopy(t *testing.T) {
 	chains["a.js"][0] = "mutated"
 	assert.Equal(t, "b.js", dt.GetReExportChains()["a.js"][0], "returns deep copy")
 }
+
+func TestDependencyTracker_RemoveModule(t *testing.T) {
+	dt := NewDependencyTracker()
+	dt.AddModuleDependency("index.js", "button.js")
+	dt.AddReExportChain("index.js", "button.js")
+	dt.AddModuleDependency("card.js", "button.js")
+
+	dt.RemoveModule("index.js")
+	assert.Nil(t, dt.GetModuleDependencies("index.js"))
+	assert.NotContains(t, dt.GetReExportChains(), "index.js")
+	assert.Equal(t, []string{"button.js"}, dt.GetModuleDependencies("card.js"))
+}
+
+func TestDependencyTracker_GetTransitiveDependents(t *testing.T) {
+	dt := NewDependencyTracker()
+	dt.AddModuleDependency("tabs.js", "tab.js")
+	dt.AddModuleDependency("tab.js", "icon.js")
+	dt.AddReExportChain("index.js", "tabs.js")
+	dt.AddModuleDependency("card.js", "button.js")
+
+	assert.ElementsMatch(t, []string{"icon.js", "tab.js", "tabs.js", "index.js"}, dt.GetTransitiveDependents("icon.js"))
+	assert.ElementsMatch(t, []string{"card.js"}, dt.GetTransitiveDependents("card.js"))
+}
+
+func TestExportTracker_RemoveReExportSources(t *testing.T) {
+	et := NewExportTracker()
+	et.AddDirectExport("index.js", "MyCard", "my-card")
+	et.AddDirectExport("button.js", "MyButton", "my-button")
+	et.AddElementSource("my-button", "index.js")
+	et.AddElementSource("my-card", "index.js")
+
+	et.RemoveReExportSources("index.js")
+	assert.Equal(t, []string{"button.js"}, et.GetElementSources("my-button"), "re-export source removed")
+	assert.Equal(t, []string{"index.js"}, et.GetElementSources("my-card"), "direct export kept")
+}
@@ -400,7 +400,7 @@ func TestModuleGraph_RecursiveDependencyBuilding(t *testing.T) {
 	)
 	mg.SetWorkspaceRoot(tempDir)
 
-	// Test that buildDependenciesForManifestModule builds the complete transitive tree
+	// Test that BuildForImportPath builds the complete transitive tree
 	// This should recursively find: my-tabs.ts -> my-tab.ts -> my-icon.ts
 	err = mg.BuildForImportPath("./my-tabs.ts")
 	if err != nil {
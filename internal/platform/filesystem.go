@@ -17,6 +17,7 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package platform
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -42,6 +43,7 @@ type TempFile interface {
 type FileSystem interface {
 	// File operations
 	WriteFile(name string, data []byte, perm fs.FileMode) error
+	AppendFile(name string, data []byte, perm fs.FileMode) error
 	ReadFile(name string) ([]byte, error)
 	Remove(name string) error
 	Create(name string) (io.WriteCloser, error)
@@ -76,6 +78,20 @@ func (fs *OSFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) er
 	return os.WriteFile(name, data, perm)
 }
 
+// AppendFile appends data to the named file, creating it with perm if it
+// does not exist.
+func (fs *OSFileSystem) AppendFile(name string, data []byte, perm fs.FileMode) error {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func (fs *OSFileSystem) ReadFile(name string) ([]byte, error) {
 	return os.ReadFile(name)
 }
@@ -129,6 +145,81 @@ func (fs *OSFileSystem) Open(name string) (fs.File, error) {
 	return os.Open(name)
 }
 
+// Lstat returns file info without following a final symbolic link.
+// Together with ReadLink it implements fs.ReadLinkFS.
+func (fs *OSFileSystem) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+func (fs *OSFileSystem) ReadLink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
+// maxSymlinks bounds the links EvalSymlinks follows, to stop at link cycles
+const maxSymlinks = 255
+
+// EvalSymlinks is the platform.FileSystem equivalent of filepath.EvalSymlinks.
+// It resolves symbolic links through fsys when fsys implements fs.ReadLinkFS,
+// and otherwise only checks that path exists.
+func EvalSymlinks(fsys FileSystem, path string) (string, error) {
+	path = filepath.Clean(path)
+	linkFS, ok := fsys.(fs.ReadLinkFS)
+	if !ok {
+		if _, err := fsys.Stat(path); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	volume := filepath.VolumeName(path)
+	resolved := volume
+	if filepath.IsAbs(path) {
+		resolved += string(filepath.Separator)
+	}
+	rest := strings.Split(path[len(volume):], string(filepath.Separator))
+	links := 0
+	for len(rest) > 0 {
+		elem := rest[0]
+		rest = rest[1:]
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, elem)
+		info, err := linkFS.Lstat(next)
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", &fs.PathError{Op: "evalsymlinks", Path: path, Err: errors.New("too many links")}
+		}
+		target, err := linkFS.ReadLink(next)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			volume = filepath.VolumeName(target)
+			resolved = volume + string(filepath.Separator)
+			target = target[len(volume):]
+		}
+		rest = append(strings.Split(target, string(filepath.Separator)), rest...)
+	}
+	if resolved == "" {
+		return ".", nil
+	}
+	return resolved, nil
+}
+
 // dirFS wraps a FileSystem into an fs.FS rooted at a directory.
 // Open calls are resolved relative to dir via filepath.Join.
 type dirFS struct {
@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package platform
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"bennypowers.dev/cem/internal/set"
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// WorkspaceIgnoreFiles are the ignore files at the workspace root which
+// workspace scans honor, in order. Patterns in .cemignore follow gitignore
+// syntax, and can re-include paths which .gitignore excludes with `!`.
+var WorkspaceIgnoreFiles = []string{".gitignore", ".cemignore"}
+
+// IgnoreMatcher matches paths, relative to the directory its ignore files
+// were loaded from, against gitignore-style patterns.
+// A nil IgnoreMatcher ignores nothing.
+type IgnoreMatcher struct {
+	gitignore *ignore.GitIgnore
+}
+
+// LoadIgnoreMatcher compiles the ignore files named names in dir on fsys,
+// as if they were one file in the given order, so later files can override
+// earlier ones. Missing files are skipped; when none exist, it returns nil.
+func LoadIgnoreMatcher(fsys fs.FS, dir string, names ...string) *IgnoreMatcher {
+	var lines []string
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		lines = append(lines, strings.Split(string(content), "\n")...)
+	}
+	if lines == nil {
+		return nil
+	}
+	return &IgnoreMatcher{gitignore: ignore.CompileIgnoreLines(lines...)}
+}
+
+// Ignored reports whether the slash-separated relative path is ignored.
+// isDir lets patterns with a trailing slash, like `dist/`, match directories.
+func (m *IgnoreMatcher) Ignored(relPath string, isDir bool) bool {
+	if m == nil || relPath == "." || relPath == "" {
+		return false
+	}
+	if isDir {
+		relPath += "/"
+	}
+	return m.gitignore.MatchesPath(relPath)
+}
+
+// WalkDirIgnoring walks like WalkDir, and also prunes the directories and
+// skips the files which matcher ignores, matching their paths relative to
+// root.
+func WalkDirIgnoring(fsys fs.FS, root string, skip set.Set[string], matcher *IgnoreMatcher, fn fs.WalkDirFunc) error {
+	return WalkDir(fsys, root, skip, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && matcher != nil {
+			if rel, relErr := filepath.Rel(root, p); relErr == nil && matcher.Ignored(filepath.ToSlash(rel), d.IsDir()) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+		}
+		return fn(p, d, err)
+	})
+}
@@ -57,6 +57,15 @@ func (m *MapFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
 	return nil
 }
 
+func (m *MapFS) AppendFile(name string, data []byte, perm fs.FileMode) error {
+	name = cleanMapFSPath(name)
+	if existing, ok := m.MapFS[name]; ok {
+		existing.Data = append(existing.Data, data...)
+		return nil
+	}
+	return m.WriteFile(name, data, perm)
+}
+
 func (m *MapFS) ReadFile(name string) ([]byte, error) {
 	return fs.ReadFile(m.MapFS, cleanMapFSPath(name))
 }
@@ -282,6 +282,17 @@ func (fs *TempDirFileSystem) WriteFile(name string, data []byte, perm fs.FileMod
 	return fs.OSFileSystem.WriteFile(path, data, perm)
 }
 
+func (fs *TempDirFileSystem) AppendFile(name string, data []byte, perm fs.FileMode) error {
+	path := fs.resolvePath(name)
+
+	// Ensure parent directory exists
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return fs.OSFileSystem.AppendFile(path, data, perm)
+}
+
 func (fs *TempDirFileSystem) ReadFile(name string) ([]byte, error) {
 	return fs.OSFileSystem.ReadFile(fs.resolvePath(name))
 }
@@ -306,6 +317,14 @@ func (fs *TempDirFileSystem) Exists(path string) bool {
 	return fs.OSFileSystem.Exists(fs.resolvePath(path))
 }
 
+func (fs *TempDirFileSystem) Lstat(name string) (fs.FileInfo, error) {
+	return fs.OSFileSystem.Lstat(fs.resolvePath(name))
+}
+
+func (fs *TempDirFileSystem) ReadLink(name string) (string, error) {
+	return fs.OSFileSystem.ReadLink(fs.resolvePath(name))
+}
+
 func (fs *TempDirFileSystem) Create(name string) (io.WriteCloser, error) {
 	path := fs.resolvePath(name)
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
@@ -437,6 +456,34 @@ func (mfs *MapFileSystem) WriteFile(name string, data []byte, perm fs.FileMode)
 	return nil
 }
 
+func (mfs *MapFileSystem) AppendFile(name string, data []byte, perm fs.FileMode) error {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
+	name = mfs.cleanPath(name)
+
+	if existing, ok := mfs.mapFS[name]; ok {
+		existing.Data = append(existing.Data, data...)
+		existing.ModTime = mfs.timeProvider.Now()
+	} else {
+		if err := mfs.ensureParentDirLocked(name); err != nil {
+			return err
+		}
+		mfs.mapFS[name] = &fstest.MapFile{
+			Data:    append([]byte(nil), data...),
+			Mode:    perm,
+			ModTime: mfs.timeProvider.Now(),
+		}
+	}
+
+	// Trigger file watcher event
+	if mfs.watcher != nil {
+		mfs.watcher.TriggerEvent("/"+name, Write)
+	}
+
+	return nil
+}
+
 func (mfs *MapFileSystem) ReadFile(name string) ([]byte, error) {
 	mfs.mu.RLock()
 	defer mfs.mu.RUnlock()
@@ -618,6 +665,20 @@ func (mfs *MapFileSystem) Open(name string) (fs.File, error) {
 	return mfs.mapFS.Open(mfs.cleanPath(name))
 }
 
+func (mfs *MapFileSystem) Lstat(name string) (fs.FileInfo, error) {
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+
+	return mfs.mapFS.Lstat(mfs.cleanPath(name))
+}
+
+func (mfs *MapFileSystem) ReadLink(name string) (string, error) {
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+
+	return mfs.mapFS.ReadLink(mfs.cleanPath(name))
+}
+
 // Helper methods
 
 func (mfs *MapFileSystem) cleanPath(path string) string {
@@ -691,6 +752,18 @@ func (mfs *MapFileSystem) AddFile(path string, content string, mode fs.FileMode)
 // AddDir adds a directory directly to the MapFS for test setup.
 // Note: MapFS represents directories implicitly through file paths.
 // Empty directories need a placeholder file to exist.
+// AddSymlink adds a symbolic link at path which points to target.
+func (mfs *MapFileSystem) AddSymlink(path, target string) {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
+	mfs.mapFS[mfs.cleanPath(path)] = &fstest.MapFile{
+		Data:    []byte(target),
+		Mode:    fs.ModeSymlink | 0777,
+		ModTime: mfs.timeProvider.Now(),
+	}
+}
+
 func (mfs *MapFileSystem) AddDir(path string, mode fs.FileMode) {
 	mfs.mu.Lock()
 	defer mfs.mu.Unlock()
@@ -163,3 +163,54 @@ func TestWalkDir(t *testing.T) {
 		}
 	})
 }
+
+func TestWalkDirIgnoring(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":           &fstest.MapFile{Data: []byte("dist/\ncoverage/\n*.min.js\nvendor/\n")},
+		".cemignore":           &fstest.MapFile{Data: []byte("fixtures/\n!vendor/\n")},
+		"src/main.ts":          &fstest.MapFile{},
+		"src/main.min.js":      &fstest.MapFile{},
+		"dist/main.js":         &fstest.MapFile{},
+		"coverage/lcov.js":     &fstest.MapFile{},
+		"fixtures/bad.ts":      &fstest.MapFile{},
+		"vendor/lib.js":        &fstest.MapFile{},
+		"packages/a/dist/a.js": &fstest.MapFile{},
+		"packages/a/src/a.ts":  &fstest.MapFile{},
+	}
+
+	walk := func(t *testing.T, matcher *platform.IgnoreMatcher) []string {
+		t.Helper()
+		var files []string
+		err := platform.WalkDirIgnoring(fsys, ".", nil, matcher, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && !strings.HasPrefix(d.Name(), ".") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return files
+	}
+
+	t.Run("honors .gitignore and .cemignore", func(t *testing.T) {
+		matcher := platform.LoadIgnoreMatcher(fsys, ".", platform.WorkspaceIgnoreFiles...)
+		got := walk(t, matcher)
+		want := []string{"packages/a/src/a.ts", "src/main.ts", "vendor/lib.js"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("nil matcher ignores nothing", func(t *testing.T) {
+		if matcher := platform.LoadIgnoreMatcher(fsys, ".", ".missingignore"); matcher != nil {
+			t.Fatal("expected nil matcher when no ignore file exists")
+		}
+		if got := walk(t, nil); len(got) != 8 {
+			t.Errorf("expected all 8 files, got %v", got)
+		}
+	})
+}
@@ -60,6 +60,46 @@ func queriesForScope(scope languages.Scope) QuerySelector {
 	return sel
 }
 
+// queryCacheKey identifies a compiled query by its grammar and source, so
+// that a changed query or grammar never reuses a stale compilation.
+type queryCacheKey struct {
+	language string
+	abi      uint32
+	name     string
+	source   string
+}
+
+// compiledQueries caches compiled queries for the life of the process, so
+// that the many QueryManagers constructed by the LSP and generate compile
+// each query only once. Tree-sitter cannot serialize compiled queries, so
+// the cache lives in memory. Queries are immutable once compiled, and each
+// QueryMatcher uses its own cursor, so they are safe to share.
+var compiledQueries sync.Map // queryCacheKey -> *ts.Query
+
+// compileQuery returns the compiled query for source, compiling it on first use.
+func compileQuery(lang languages.Language, queryName, source string) (*ts.Query, error) {
+	key := queryCacheKey{
+		language: lang.Name(),
+		abi:      lang.TSLanguage().AbiVersion(),
+		name:     queryName,
+		source:   source,
+	}
+	if cached, ok := compiledQueries.Load(key); ok {
+		return cached.(*ts.Query), nil
+	}
+
+	query, qerr := ts.NewQuery(lang.TSLanguage(), source)
+	if qerr != nil {
+		return nil, qerr
+	}
+	if cached, loaded := compiledQueries.LoadOrStore(key, query); loaded {
+		// Another goroutine compiled it first
+		query.Close()
+		return cached.(*ts.Query), nil
+	}
+	return query, nil
+}
+
 // QueryManager holds compiled tree-sitter queries organized by language.
 type QueryManager struct {
 	queries map[string]map[string]*ts.Query // language -> queryName -> query
@@ -101,9 +141,9 @@ func (qm *QueryManager) loadQuery(langName, queryName string) error {
 		return fmt.Errorf("failed to read query file %s from %s: %w", queryFile, langName, err)
 	}
 
-	query, qerr := ts.NewQuery(lang.TSLanguage(), string(data))
-	if qerr != nil {
-		return fmt.Errorf("failed to parse query %s: %w", queryName, qerr)
+	query, err := compileQuery(lang, queryName, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse query %s: %w", queryName, err)
 	}
 
 	qm.queries[langName][queryName] = query
@@ -119,13 +159,10 @@ func (qm *QueryManager) getQuery(queryName string, language string) (*ts.Query,
 	return nil, fmt.Errorf("unknown query %s for language %s", queryName, language)
 }
 
-// Close releases all compiled queries.
+// Close releases the manager's queries. The compiled queries themselves
+// stay cached for other managers to reuse.
 func (qm *QueryManager) Close() {
-	for _, langQueries := range qm.queries {
-		for _, query := range langQueries {
-			query.Close()
-		}
-	}
+	qm.queries = nil
 }
 
 // Thread-safe singleton QueryManager (there can be only one!)
@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package treesitter_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/languages/css"
+	"bennypowers.dev/cem/internal/treesitter"
+)
+
+// Compiled queries are shared between managers, so closing one manager
+// must leave the queries usable by the others.
+func TestQueryManager_SharesCompiledQueries(t *testing.T) {
+	selector := treesitter.QuerySelector{"css": {"cssCustomProperties"}}
+	first, err := treesitter.NewQueryManager(selector)
+	if err != nil {
+		t.Fatalf("failed to create first query manager: %v", err)
+	}
+	second, err := treesitter.NewQueryManager(selector)
+	if err != nil {
+		t.Fatalf("failed to create second query manager: %v", err)
+	}
+	defer second.Close()
+	first.Close()
+
+	matcher, err := treesitter.NewQueryMatcher(second, "css", "cssCustomProperties")
+	if err != nil {
+		t.Fatalf("failed to create query matcher: %v", err)
+	}
+	defer matcher.Close()
+
+	code := []byte(":host {\n  /** the color */\n  --color: blue;\n}\n")
+	parser := css.BorrowParser()
+	defer css.ReturnParser(parser)
+	tree := parser.Parse(code, nil)
+	defer tree.Close()
+
+	matches := 0
+	for range matcher.AllQueryMatches(tree.RootNode(), code) {
+		matches++
+	}
+	if matches == 0 {
+		t.Error("expected the shared query to match after closing the first manager")
+	}
+}
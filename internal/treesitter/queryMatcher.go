@@ -29,7 +29,7 @@ type QueryMatcher struct {
 }
 
 func (qm QueryMatcher) Close() {
-	// NOTE: we don't close queries here, only at the end of execution in QueryManager.Close
+	// NOTE: we don't close queries here, they are compiled once and shared (see compiledQueries)
 	// Close the cursor since we're not pooling
 	qm.cursor.Close()
 }
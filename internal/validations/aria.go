@@ -0,0 +1,169 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package validations
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/aria.json
+var ariaJSON []byte
+
+// ARIAAttribute is a WAI-ARIA state or property
+type ARIAAttribute struct {
+	Name string
+	// Type is the ARIA value type, e.g. "true/false", "token", or "idrefs"
+	Type string `json:"type"`
+	// Values are the allowed values of token and tokens attributes
+	Values []string `json:"values"`
+	// Roles support the attribute. Global attributes list no roles.
+	Roles []string `json:"roles"`
+	// Prohibited roles may not use the attribute, even if it's global
+	Prohibited []string `json:"prohibited"`
+	Deprecated bool     `json:"deprecated"`
+}
+
+// Global reports whether every role supports the attribute
+func (a ARIAAttribute) Global() bool {
+	return len(a.Roles) == 0
+}
+
+// AllowedOn reports whether an element with the given role may use the
+// attribute. When the role is empty or unknown, only role-specific
+// attributes can't be ruled out, so every attribute is allowed.
+func (a ARIAAttribute) AllowedOn(role string) bool {
+	if !IsARIARole(role) {
+		return true
+	}
+	if slices.Contains(a.Prohibited, role) {
+		return false
+	}
+	return a.Global() || slices.Contains(a.Roles, role)
+}
+
+// ValueChoices returns the values to offer for the attribute, or nil when
+// its values are free-form
+func (a ARIAAttribute) ValueChoices() []string {
+	switch a.Type {
+	case "true/false":
+		return []string{"true", "false"}
+	case "tristate":
+		return []string{"true", "false", "mixed"}
+	case "true/false/undefined":
+		return []string{"true", "false", "undefined"}
+	case "token", "tokens":
+		return a.Values
+	}
+	return nil
+}
+
+// AcceptsValue reports whether value is valid for the attribute
+func (a ARIAAttribute) AcceptsValue(value string) bool {
+	value = strings.TrimSpace(value)
+	switch a.Type {
+	case "integer":
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case "number":
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case "tokens":
+		tokens := strings.Fields(value)
+		if len(tokens) == 0 {
+			return false
+		}
+		for _, token := range tokens {
+			if !slices.Contains(a.Values, token) {
+				return false
+			}
+		}
+		return true
+	}
+	if choices := a.ValueChoices(); choices != nil {
+		return slices.Contains(choices, value)
+	}
+	return true
+}
+
+var (
+	ariaAttributes map[string]ARIAAttribute
+	ariaRoles      []string
+)
+
+// Initialize ARIA data from embedded WAI-ARIA 1.2 tables
+func init() {
+	var data struct {
+		Roles      []string                 `json:"roles"`
+		Attributes map[string]ARIAAttribute `json:"attributes"`
+	}
+	ariaAttributes = make(map[string]ARIAAttribute)
+	if err := json.Unmarshal(ariaJSON, &data); err == nil {
+		ariaRoles = data.Roles
+		for name, attr := range data.Attributes {
+			attr.Name = name
+			ariaAttributes[name] = attr
+		}
+	}
+}
+
+// LookupARIAAttribute returns the ARIA state or property with the given name
+func LookupARIAAttribute(name string) (ARIAAttribute, bool) {
+	attr, ok := ariaAttributes[strings.ToLower(name)]
+	return attr, ok
+}
+
+// ARIAAttributesForRole returns the ARIA attributes an element with the
+// given role may use, sorted by name. Deprecated attributes are omitted.
+// When the role is empty or unknown, only global attributes are returned.
+func ARIAAttributesForRole(role string) []ARIAAttribute {
+	known := IsARIARole(role)
+	var attrs []ARIAAttribute
+	for _, attr := range ariaAttributes {
+		if attr.Deprecated || (!known && !attr.Global()) || !attr.AllowedOn(role) {
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	slices.SortFunc(attrs, func(a, b ARIAAttribute) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return attrs
+}
+
+// ARIAAttributeNames returns the names of all ARIA states and properties
+func ARIAAttributeNames() []string {
+	names := make([]string, 0, len(ariaAttributes))
+	for name := range ariaAttributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// IsARIARole reports whether role is a concrete WAI-ARIA role
+func IsARIARole(role string) bool {
+	return role != "" && slices.Contains(ariaRoles, role)
+}
+
+// ARIARoles returns the concrete WAI-ARIA roles, sorted by name
+func ARIARoles() []string {
+	return slices.Clone(ariaRoles)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package validations
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: pure functions over embedded data, table-driven
+
+func TestARIAAttributesForRole(t *testing.T) {
+	names := func(role string) []string {
+		var names []string
+		for _, attr := range ARIAAttributesForRole(role) {
+			names = append(names, attr.Name)
+		}
+		return names
+	}
+
+	button := names("button")
+	assert.Contains(t, button, "aria-pressed", "role-specific attributes")
+	assert.Contains(t, button, "aria-label", "global attributes")
+	assert.NotContains(t, button, "aria-checked")
+	assert.NotContains(t, button, "aria-grabbed", "deprecated attributes")
+	assert.True(t, slices.IsSorted(button))
+
+	assert.NotContains(t, names("generic"), "aria-label", "prohibited attributes")
+
+	unknown := names("")
+	assert.Contains(t, unknown, "aria-describedby")
+	assert.NotContains(t, unknown, "aria-pressed", "no role-specific attributes without a role")
+}
+
+func TestARIAAttribute_AllowedOn(t *testing.T) {
+	tests := []struct {
+		attribute string
+		role      string
+		allowed   bool
+	}{
+		{"aria-pressed", "button", true},
+		{"aria-pressed", "link", false},
+		{"aria-checked", "switch", true},
+		{"aria-label", "button", true},
+		{"aria-label", "presentation", false},
+		{"aria-pressed", "", true},
+		{"aria-pressed", "not-a-role", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attribute+" on "+tt.role, func(t *testing.T) {
+			attr, ok := LookupARIAAttribute(tt.attribute)
+			require.True(t, ok)
+			assert.Equal(t, tt.allowed, attr.AllowedOn(tt.role))
+		})
+	}
+}
+
+func TestARIAAttribute_AcceptsValue(t *testing.T) {
+	tests := []struct {
+		attribute string
+		value     string
+		accepts   bool
+	}{
+		{"aria-hidden", "true", true},
+		{"aria-hidden", "yes", false},
+		{"aria-pressed", "mixed", true},
+		{"aria-busy", "mixed", false},
+		{"aria-current", "page", true},
+		{"aria-current", "pages", false},
+		{"aria-relevant", "additions text", true},
+		{"aria-relevant", "additions everything", false},
+		{"aria-level", "2", true},
+		{"aria-level", "two", false},
+		{"aria-valuenow", "0.5", true},
+		{"aria-label", "anything at all", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attribute+"="+tt.value, func(t *testing.T) {
+			attr, ok := LookupARIAAttribute(tt.attribute)
+			require.True(t, ok)
+			assert.Equal(t, tt.accepts, attr.AcceptsValue(tt.value))
+		})
+	}
+}
+
+func TestIsARIARole(t *testing.T) {
+	assert.True(t, IsARIARole("button"))
+	assert.True(t, IsARIARole("tabpanel"))
+	assert.False(t, IsARIARole("widget"), "abstract roles")
+	assert.False(t, IsARIARole(""))
+	assert.Len(t, ARIAAttributeNames(), len(ariaAttributes))
+}
@@ -59,7 +59,7 @@ func init() {
 // This includes:
 // - All standard global HTML attributes from MDN data (id, class, slot, style, etc.)
 // - data-* attributes (always valid)
-// - aria-* attributes (always valid)
+// - aria-* attributes and role (always valid)
 // - Event handler attributes starting with "on" (always valid)
 func IsGlobalAttribute(name string) bool {
 	nameLower := strings.ToLower(name)
@@ -74,8 +74,8 @@ func IsGlobalAttribute(name string) bool {
 		return true
 	}
 
-	// Check for aria-* attributes (always valid)
-	if strings.HasPrefix(nameLower, "aria-") {
+	// Check for aria-* attributes and role (always valid)
+	if strings.HasPrefix(nameLower, "aria-") || nameLower == "role" {
 		return true
 	}
 
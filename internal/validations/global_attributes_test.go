@@ -58,6 +58,7 @@ func TestIsGlobalAttribute(t *testing.T) {
 		{"aria attribute", "aria-label", true, "aria-* attributes are always global"},
 		{"aria attribute complex", "aria-describedby", true, "aria-* attributes are always global"},
 		{"aria attribute uppercase", "ARIA-HIDDEN", true, "aria-* attributes should be case insensitive"},
+		{"role attribute", "role", true, "role is always global, like aria-* attributes"},
 
 		// Event handler attributes
 		{"onclick event", "onclick", true, "on* event handlers are global"},
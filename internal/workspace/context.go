@@ -80,7 +80,7 @@ func GetWorkspaceContext(cmd *cobra.Command) (types.WorkspaceContext, error) {
 		}
 	}
 
-	if ctx, err := getAppropriateContextForSpec(spec, cmd.Name()); err != nil {
+	if ctx, err := getAppropriateContextForSpec(spec, cmd.Name(), nil); err != nil {
 		return nil, err
 	} else {
 		// If an explicit config path was given, inject it before Init
@@ -101,13 +101,33 @@ func GetWorkspaceContext(cmd *cobra.Command) (types.WorkspaceContext, error) {
 
 // GetContextForSpec creates the appropriate WorkspaceContext for a given package specifier.
 // It accepts URLs (https://...), npm specifiers (npm:@scope/pkg), jsr specifiers (jsr:...),
-// and local file paths. The returned context must be Init()'d before use.
+// packed packages (.tgz files or URLs), and local file paths. The returned context must be Init()'d before use.
 func GetContextForSpec(spec string) (types.WorkspaceContext, error) {
-	return getAppropriateContextForSpec(spec, "")
+	return getAppropriateContextForSpec(spec, "", nil)
 }
 
-func getAppropriateContextForSpec(spec, cmdName string) (ctx types.WorkspaceContext, err error) {
-	// Check for URL specifiers first (https://...)
+// GetContextForSpecWithFileSystem is like GetContextForSpec, but packages on
+// the local filesystem, including those found in node_modules, are read
+// through fsys. Remote packages are fetched from the network as usual.
+func GetContextForSpecWithFileSystem(spec string, fsys platform.FileSystem) (types.WorkspaceContext, error) {
+	return getAppropriateContextForSpec(spec, "", fsys)
+}
+
+func getAppropriateContextForSpec(spec, cmdName string, fsys platform.FileSystem) (ctx types.WorkspaceContext, err error) {
+	if fsys == nil {
+		fsys = platform.NewOSFileSystem()
+	}
+
+	// Check for packed packages first (.tgz files, URLs, and npm registry versions)
+	if IsTarballSpecifier(spec) {
+		if cmdName == "generate" {
+			return nil, errors.New("generate command cannot be used with a tarball")
+		}
+		cacheDir := filepath.Join(xdg.CacheHome, "cem", "tarballs")
+		return NewTarballWorkspaceContext(spec, cacheDir, fsys), nil
+	}
+
+	// Check for URL specifiers (https://...)
 	if IsURLSpecifier(spec) {
 		if cmdName == "generate" {
 			return nil, errors.New("generate command cannot be used with a remote URL")
@@ -125,9 +145,8 @@ func getAppropriateContextForSpec(spec, cmdName string) (ctx types.WorkspaceCont
 			return nil, err
 		}
 		localPath := filepath.Join("node_modules", name)
-		fsys := platform.NewOSFileSystem()
 		if stat, err := fsys.Stat(localPath); err == nil && stat.IsDir() {
-			return NewFileSystemWorkspaceContext(localPath), nil
+			return NewFileSystemWorkspaceContext(localPath, WithFileSystem(fsys)), nil
 		}
 		// Fetch from the network
 		if cmdName == "generate" {
@@ -137,7 +156,7 @@ func getAppropriateContextForSpec(spec, cmdName string) (ctx types.WorkspaceCont
 	}
 
 	// Otherwise, it's a file path
-	return NewFileSystemWorkspaceContext(spec), nil
+	return NewFileSystemWorkspaceContext(spec, WithFileSystem(fsys)), nil
 }
 
 func fileExists(path string, fsys platform.FileSystem) bool {
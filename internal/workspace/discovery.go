@@ -266,6 +266,9 @@ func mergeConfigDefaults(pkg, ws *C.CemConfig) {
 	if pkg.Serve.OpenBrowser == nil && ws.Serve.OpenBrowser != nil {
 		pkg.Serve.OpenBrowser = ws.Serve.OpenBrowser
 	}
+	if len(pkg.Serve.Demos.Env) == 0 && len(ws.Serve.Demos.Env) > 0 {
+		pkg.Serve.Demos.Env = ws.Serve.Demos.Env
+	}
 
 	// Generate (skip files/exclude -- they contain paths relative to workspace
 	// root which resolve incorrectly from package roots)
@@ -275,6 +278,19 @@ func mergeConfigDefaults(pkg, ws *C.CemConfig) {
 	if pkg.Generate.DesignTokens.Spec == "" && ws.Generate.DesignTokens.Spec != "" {
 		pkg.Generate.DesignTokens = ws.Generate.DesignTokens
 	}
+	// Categories are tag name globs, so they apply in every package
+	if len(pkg.Generate.Categories) == 0 && len(ws.Generate.Categories) > 0 {
+		pkg.Generate.Categories = ws.Generate.Categories
+	}
+	if pkg.Generate.MemberOrder == "" && ws.Generate.MemberOrder != "" {
+		pkg.Generate.MemberOrder = ws.Generate.MemberOrder
+	}
+	if !pkg.Generate.TypeDeclarations && ws.Generate.TypeDeclarations {
+		pkg.Generate.TypeDeclarations = true
+	}
+	if len(pkg.Generate.Publish.Strip) == 0 && len(ws.Generate.Publish.Strip) > 0 {
+		pkg.Generate.Publish.Strip = ws.Generate.Publish.Strip
+	}
 	// DemoDiscovery not cascaded here -- FileGlob contains root-relative paths.
 	// Callers resolve it per-package via ResolveWorkspaceGlob.
 
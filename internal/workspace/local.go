@@ -217,7 +217,7 @@ func (c *FileSystemWorkspaceContext) Manifest() (*M.Package, error) {
 	}
 	defer func() { _ = rc.Close() }()
 
-	m, err := decodeJSON[M.Package](rc)
+	m, err := decodeManifest(rc)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +262,7 @@ func (c *RemoteWorkspaceContext) Manifest() (*M.Package, error) {
 		rc, err := c.fs.Open(manifestPath)
 		if err == nil {
 			defer func() { _ = rc.Close() }()
-			return decodeJSON[M.Package](rc)
+			return decodeManifest(rc)
 		}
 	}
 	return nil, errors.New("no custom-elements.json found in remote workspace")
@@ -0,0 +1,405 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	C "bennypowers.dev/cem/cmd/config"
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/set"
+	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/types"
+	doublestar "github.com/bmatcuk/doublestar/v4"
+)
+
+var _ types.WorkspaceContext = (*TarballWorkspaceContext)(nil)
+
+const (
+	// maxTarballSize caps the size of a packed package
+	maxTarballSize = 256 << 20
+	// maxTarballEntrySize caps the size of each file extracted from a tarball
+	maxTarballEntrySize = 64 << 20
+	// maxTarballExtractedSize caps the total size of the files extracted from
+	// a tarball, which gzip can make far larger than the tarball itself
+	maxTarballExtractedSize = 512 << 20
+)
+
+// integrityHashes are the hash functions of the npm dist.integrity algorithms
+var integrityHashes = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// npmRegistryHosts are the npm registries whose version documents, e.g.
+// https://registry.npmjs.org/@scope/pkg/1.2.3, are loaded as tarballs
+var npmRegistryHosts = set.NewSet("registry.npmjs.org", "registry.yarnpkg.com")
+
+// TarballWorkspaceContext implements WorkspaceContext for packed packages,
+// like the output of `npm pack`. The tarball is read from the filesystem or
+// fetched from a URL, and its files are extracted in memory, so a package
+// can be checked before it is published, or reviewed without a registry.
+type TarballWorkspaceContext struct {
+	spec              string
+	cacheDir          string
+	fs                platform.FileSystem
+	files             *platform.MapFS
+	packageJSON       *M.PackageJSON
+	manifest          *M.Package
+	manifestPath      string
+	designTokensCache types.DesignTokensCache
+}
+
+// NewTarballWorkspaceContext creates a new context for a tarball spec, which
+// is a path to a .tgz file on fsys, a URL of a .tgz file, or the URL of a
+// package version on an npm registry. The cacheDir is used for HTTP response
+// caching.
+func NewTarballWorkspaceContext(spec, cacheDir string, fsys platform.FileSystem) *TarballWorkspaceContext {
+	if fsys == nil {
+		fsys = platform.NewOSFileSystem()
+	}
+	return &TarballWorkspaceContext{
+		spec:              spec,
+		cacheDir:          cacheDir,
+		fs:                fsys,
+		designTokensCache: NewDesignTokensCache(nil),
+	}
+}
+
+// IsTarballSpecifier checks if a string names a packed package: a .tgz or
+// .tar.gz file or URL, or the URL of a package version on an npm registry.
+func IsTarballSpecifier(spec string) bool {
+	name := spec
+	if IsURLSpecifier(spec) {
+		u, err := url.Parse(spec)
+		if err != nil {
+			return false
+		}
+		if isNpmRegistryVersionURL(u) {
+			return true
+		}
+		name = u.Path
+	}
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".tar.gz")
+}
+
+// isNpmRegistryVersionURL reports whether u is a version document on an npm
+// registry, i.e. /<name>/<version> or /@<scope>/<name>/<version>
+func isNpmRegistryVersionURL(u *url.URL) bool {
+	if !npmRegistryHosts.Has(u.Host) {
+		return false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	want := 2
+	if strings.HasPrefix(segments[0], "@") {
+		want = 3
+	}
+	return len(segments) == want && !strings.HasSuffix(u.Path, ".tgz")
+}
+
+func (c *TarballWorkspaceContext) Init() error {
+	data, err := c.readTarball()
+	if err != nil {
+		return err
+	}
+
+	c.files, err = extractTarball(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", c.spec, err)
+	}
+
+	content, err := c.files.ReadFile("package.json")
+	if err != nil {
+		return fmt.Errorf("%w: %s has no package.json", ErrNoPackageJSON, c.spec)
+	}
+	var pkgJSON M.PackageJSON
+	if err := json.Unmarshal(content, &pkgJSON); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+	c.packageJSON = &pkgJSON
+
+	if c.packageJSON.CustomElements == "" {
+		return ErrNoPackageCustomElements
+	}
+	c.manifestPath = path.Clean(strings.TrimPrefix(c.packageJSON.CustomElements, "./"))
+
+	manifestContent, err := c.files.ReadFile(c.manifestPath)
+	if err != nil {
+		// Most often the manifest was left out by the "files" field of package.json
+		return fmt.Errorf("%w: %s is not packed in %s", ErrManifestNotFound, c.manifestPath, c.spec)
+	}
+	manifest, err := M.UnmarshalPackage(manifestContent)
+	if err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	c.manifest = manifest
+
+	return nil
+}
+
+// readTarball reads the tarball from the filesystem or the network. For npm
+// registry version documents, it fetches the tarball the document names, and
+// verifies it against the document's integrity hash.
+func (c *TarballWorkspaceContext) readTarball() ([]byte, error) {
+	if !IsURLSpecifier(c.spec) {
+		f, err := c.fs.Open(c.spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tarball %s: %w", c.spec, err)
+		}
+		defer func() { _ = f.Close() }()
+		data, err := io.ReadAll(io.LimitReader(f, maxTarballSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tarball %s: %w", c.spec, err)
+		}
+		if len(data) > maxTarballSize {
+			return nil, fmt.Errorf("tarball %s is larger than %d bytes", c.spec, maxTarballSize)
+		}
+		return data, nil
+	}
+
+	cache := NewHTTPCache(c.cacheDir)
+	if u, err := url.Parse(c.spec); err == nil && isNpmRegistryVersionURL(u) {
+		content, err := cache.Fetch(c.spec)
+		if errors.Is(err, ErrHTTPNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrPackageNotFound, c.spec)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", c.spec, err)
+		}
+		var version struct {
+			Dist struct {
+				Tarball   string `json:"tarball"`
+				Integrity string `json:"integrity"`
+				Shasum    string `json:"shasum"`
+			} `json:"dist"`
+		}
+		if err := json.Unmarshal(content, &version); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", c.spec, err)
+		}
+		if version.Dist.Tarball == "" {
+			return nil, fmt.Errorf("%s does not name a tarball", c.spec)
+		}
+		data, err := fetchTarball(cache, version.Dist.Tarball)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyIntegrity(data, version.Dist.Integrity, version.Dist.Shasum); err != nil {
+			return nil, fmt.Errorf("tarball %s: %w", version.Dist.Tarball, err)
+		}
+		return data, nil
+	}
+
+	return fetchTarball(cache, c.spec)
+}
+
+// fetchTarball fetches a tarball through the HTTP cache
+func fetchTarball(cache *HTTPCache, tarballURL string) ([]byte, error) {
+	data, err := cache.Fetch(tarballURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tarball %s: %w", tarballURL, err)
+	}
+	if len(data) > maxTarballSize {
+		return nil, fmt.Errorf("tarball %s is larger than %d bytes", tarballURL, maxTarballSize)
+	}
+	return data, nil
+}
+
+// verifyIntegrity checks a tarball against the dist.integrity of its npm
+// version document, a subresource integrity string like "sha512-<base64>",
+// or when none of its algorithms are known, against the hex SHA-1 dist.shasum
+func verifyIntegrity(data []byte, integrity, shasum string) error {
+	for entry := range strings.FieldsSeq(integrity) {
+		algorithm, digest, _ := strings.Cut(entry, "-")
+		newHash, ok := integrityHashes[algorithm]
+		if !ok {
+			continue
+		}
+		want, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil {
+			return fmt.Errorf("malformed integrity %q: %w", entry, err)
+		}
+		h := newHash()
+		h.Write(data)
+		if !bytes.Equal(h.Sum(nil), want) {
+			return fmt.Errorf("%w: %s digest does not match", ErrIntegrityMismatch, algorithm)
+		}
+		return nil
+	}
+	if shasum == "" {
+		return fmt.Errorf("%w: no integrity or shasum to verify", ErrIntegrityMismatch)
+	}
+	sum := sha1.Sum(data)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), shasum) {
+		return fmt.Errorf("%w: sha1 digest does not match", ErrIntegrityMismatch)
+	}
+	return nil
+}
+
+// extractTarball reads the regular files of a gzipped tarball into memory.
+// Packed packages keep their files in a top-level directory, `package/` for
+// npm, which is stripped from the paths. Files larger than
+// maxTarballEntrySize, or more than maxTarballExtractedSize in all, are
+// rejected.
+func extractTarball(r io.Reader) (*platform.MapFS, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gzr.Close() }()
+
+	files := platform.NewMapFS(nil)
+	tr := tar.NewReader(gzr)
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		top, name, found := strings.Cut(path.Clean(strings.TrimPrefix(hdr.Name, "/")), "/")
+		if !found || top == ".." || name == "" {
+			continue
+		}
+		if hdr.Size > maxTarballEntrySize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", name, maxTarballEntrySize)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxTarballEntrySize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxTarballEntrySize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", name, maxTarballEntrySize)
+		}
+		if total += int64(len(data)); total > maxTarballExtractedSize {
+			return nil, fmt.Errorf("extracted files are larger than %d bytes", maxTarballExtractedSize)
+		}
+		if err := files.WriteFile(name, data, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func (c *TarballWorkspaceContext) ConfigFile() string {
+	return ""
+}
+
+func (c *TarballWorkspaceContext) Config() (*C.CemConfig, error) {
+	// Packed packages don't carry a config file
+	return &C.CemConfig{}, nil
+}
+
+func (c *TarballWorkspaceContext) PackageJSON() (*M.PackageJSON, error) {
+	if c.packageJSON == nil {
+		return nil, ErrNoPackageJSON
+	}
+	return c.packageJSON, nil
+}
+
+func (c *TarballWorkspaceContext) Manifest() (*M.Package, error) {
+	if c.manifest == nil {
+		return nil, ErrManifestNotFound
+	}
+	return c.manifest, nil
+}
+
+func (c *TarballWorkspaceContext) CustomElementsManifestPath() string {
+	return c.manifestPath
+}
+
+func (c *TarballWorkspaceContext) ReadFile(filePath string) (io.ReadCloser, error) {
+	if c.files == nil {
+		return nil, fs.ErrNotExist
+	}
+	return c.files.Open(strings.TrimPrefix(filePath, "./"))
+}
+
+func (c *TarballWorkspaceContext) Stat(filePath string) (fs.FileInfo, error) {
+	if c.files == nil {
+		return nil, fs.ErrNotExist
+	}
+	return c.files.Stat(strings.TrimPrefix(filePath, "./"))
+}
+
+func (c *TarballWorkspaceContext) ReadDir(filePath string) ([]fs.DirEntry, error) {
+	if c.files == nil {
+		return nil, fs.ErrNotExist
+	}
+	return c.files.ReadDir(strings.TrimPrefix(filePath, "./"))
+}
+
+func (c *TarballWorkspaceContext) Glob(pattern string) ([]string, error) {
+	if c.files == nil {
+		return nil, nil
+	}
+	return doublestar.Glob(c.files.MapFS, strings.TrimPrefix(pattern, "./"))
+}
+
+func (c *TarballWorkspaceContext) OutputWriter(_ string) (io.WriteCloser, error) {
+	// Tarballs are read-only
+	return nil, ErrRemoteUnsupported
+}
+
+func (c *TarballWorkspaceContext) Root() string {
+	return c.spec
+}
+
+func (c *TarballWorkspaceContext) Cleanup() error {
+	// Files are only held in memory
+	return nil
+}
+
+func (c *TarballWorkspaceContext) ModulePathToFS(modulePath string) string {
+	return modulePath
+}
+
+func (c *TarballWorkspaceContext) FSPathToModule(fsPath string) (string, error) {
+	return fsPath, nil
+}
+
+func (c *TarballWorkspaceContext) ResolveModuleDependency(modulePath, dependencyPath string) (string, error) {
+	if strings.HasPrefix(dependencyPath, ".") {
+		return path.Join(path.Dir(modulePath), dependencyPath), nil
+	}
+	return dependencyPath, nil
+}
+
+func (c *TarballWorkspaceContext) DesignTokensCache() types.DesignTokensCache {
+	return c.designTokensCache
+}
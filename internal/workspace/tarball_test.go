@@ -0,0 +1,215 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package workspace_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packTarball builds a gzipped tarball like `npm pack` does, with each file
+// under package/
+func packTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     "package/" + name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+const tarballManifest = `{
+  "schemaVersion": "1.0.0",
+  "modules": [{
+    "kind": "javascript-module",
+    "path": "elements/my-button.js",
+    "declarations": [{"kind": "class", "name": "MyButton", "tagName": "my-button", "customElement": true}]
+  }]
+}`
+
+// Inline: tarball is built in the test, scalar assertions
+func TestTarballWorkspaceContext_Init(t *testing.T) {
+	tarball := packTarball(t, map[string]string{
+		"package.json":              `{"name": "@test/elements", "version": "1.0.0", "customElements": "./dist/custom-elements.json"}`,
+		"dist/custom-elements.json": tarballManifest,
+		"elements/my-button.js":     `export class MyButton extends HTMLElement {}`,
+	})
+	mapFS := platform.NewMapFS(nil)
+	require.NoError(t, mapFS.WriteFile("test-elements-1.0.0.tgz", tarball, 0644))
+
+	ctx := workspace.NewTarballWorkspaceContext("test-elements-1.0.0.tgz", t.TempDir(), mapFS)
+	require.NoError(t, ctx.Init())
+
+	pkgJSON, err := ctx.PackageJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "@test/elements", pkgJSON.Name)
+
+	manifest, err := ctx.Manifest()
+	require.NoError(t, err)
+	require.Len(t, manifest.Modules, 1)
+	assert.Equal(t, "dist/custom-elements.json", ctx.CustomElementsManifestPath())
+
+	rc, err := ctx.ReadFile("./elements/my-button.js")
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+	source, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Contains(t, string(source), "class MyButton")
+
+	_, err = ctx.OutputWriter("custom-elements.json")
+	assert.ErrorIs(t, err, workspace.ErrRemoteUnsupported)
+}
+
+func TestTarballWorkspaceContext_ManifestNotPacked(t *testing.T) {
+	tarball := packTarball(t, map[string]string{
+		"package.json": `{"name": "@test/elements", "customElements": "custom-elements.json"}`,
+	})
+	mapFS := platform.NewMapFS(nil)
+	require.NoError(t, mapFS.WriteFile("pkg.tgz", tarball, 0644))
+
+	ctx := workspace.NewTarballWorkspaceContext("pkg.tgz", t.TempDir(), mapFS)
+	err := ctx.Init()
+	assert.ErrorIs(t, err, workspace.ErrManifestNotFound)
+	assert.Contains(t, err.Error(), "custom-elements.json is not packed")
+}
+
+func TestTarballWorkspaceContext_URL(t *testing.T) {
+	tarball := packTarball(t, map[string]string{
+		"package.json":         `{"name": "remote-elements", "customElements": "custom-elements.json"}`,
+		"custom-elements.json": tarballManifest,
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/remote-elements/-/remote-elements-1.0.0.tgz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(tarball)
+	}))
+	defer server.Close()
+
+	spec := server.URL + "/remote-elements/-/remote-elements-1.0.0.tgz"
+	specCtx, err := workspace.GetContextForSpec(spec)
+	require.NoError(t, err)
+	assert.IsType(t, &workspace.TarballWorkspaceContext{}, specCtx)
+
+	ctx := workspace.NewTarballWorkspaceContext(spec, t.TempDir(), nil)
+	require.NoError(t, ctx.Init())
+
+	pkgJSON, err := ctx.PackageJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "remote-elements", pkgJSON.Name)
+}
+
+func TestTarballWorkspaceContext_EntryTooLarge(t *testing.T) {
+	tarball := packTarball(t, map[string]string{
+		"package.json": `{"name": "@test/elements", "customElements": "custom-elements.json"}`,
+		"huge.js":      strings.Repeat("0", 64<<20+1),
+	})
+	mapFS := platform.NewMapFS(nil)
+	require.NoError(t, mapFS.WriteFile("pkg.tgz", tarball, 0644))
+
+	ctx := workspace.NewTarballWorkspaceContext("pkg.tgz", t.TempDir(), mapFS)
+	err := ctx.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "huge.js is larger than")
+}
+
+// Inline: pure function, table-driven
+func TestVerifyIntegrity(t *testing.T) {
+	data := []byte("tarball")
+	sha512sum := sha512.Sum512(data)
+	sha1sum := sha1.Sum(data)
+	integrity := "sha512-" + base64.StdEncoding.EncodeToString(sha512sum[:])
+	shasum := hex.EncodeToString(sha1sum[:])
+
+	tests := []struct {
+		name      string
+		integrity string
+		shasum    string
+		wantErr   bool
+	}{
+		{name: "integrity matches", integrity: integrity},
+		{name: "integrity mismatch", integrity: "sha512-" + base64.StdEncoding.EncodeToString(make([]byte, 64)), shasum: shasum, wantErr: true},
+		{name: "unknown algorithm falls back to shasum", integrity: "md5-AAAA", shasum: shasum},
+		{name: "shasum matches", shasum: shasum},
+		{name: "shasum mismatch", shasum: strings.Repeat("0", 40), wantErr: true},
+		{name: "nothing to verify", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workspace.VerifyIntegrity(data, tt.integrity, tt.shasum)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, workspace.ErrIntegrityMismatch)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Inline: pure function, table-driven
+func TestIsTarballSpecifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected bool
+	}{
+		{name: "npm pack output", spec: "rhds-elements-2.0.0.tgz", expected: true},
+		{name: "tar.gz path", spec: "./dist/elements.tar.gz", expected: true},
+		{name: "tarball URL", spec: "https://registry.npmjs.org/@rhds/elements/-/elements-2.0.0.tgz", expected: true},
+		{name: "tarball URL with query", spec: "https://example.com/pkg.tgz?token=abc", expected: true},
+		{name: "npm registry version", spec: "https://registry.npmjs.org/@rhds/elements/2.0.0", expected: true},
+		{name: "npm registry unscoped version", spec: "https://registry.npmjs.org/lit/latest", expected: true},
+		{name: "npm registry package document", spec: "https://registry.npmjs.org/@rhds/elements", expected: false},
+		{name: "CDN URL", spec: "https://cdn.jsdelivr.net/npm/@rhds/elements@2.0.0/", expected: false},
+		{name: "npm specifier", spec: "npm:@rhds/elements@2.0.0", expected: false},
+		{name: "directory", spec: "./node_modules/@rhds/elements", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, workspace.IsTarballSpecifier(tt.spec), "IsTarballSpecifier(%q)", tt.spec)
+		})
+	}
+}
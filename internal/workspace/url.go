@@ -95,11 +95,11 @@ func (c *URLWorkspaceContext) Init() error {
 		return fmt.Errorf("failed to fetch manifest from %s: %w", manifestURL, err)
 	}
 
-	var manifest M.Package
-	if err := json.Unmarshal(manifestContent, &manifest); err != nil {
+	manifest, err := M.UnmarshalPackage(manifestContent)
+	if err != nil {
 		return fmt.Errorf("failed to parse manifest: %w", err)
 	}
-	c.manifest = &manifest
+	c.manifest = manifest
 
 	return nil
 }
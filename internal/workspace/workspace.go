@@ -24,6 +24,7 @@ import (
 	"io"
 	"strings"
 
+	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/types"
 )
 
@@ -37,6 +38,7 @@ var ErrRemoteUnsupported = fmt.Errorf("remote workspace context is not yet suppo
 var ErrNoPackageCustomElements = errors.New("package does not specify a custom elements manifest")
 var ErrManifestNotFound = errors.New("manifest not found")
 var ErrPackageNotFound = errors.New("package not found")
+var ErrIntegrityMismatch = errors.New("integrity check failed")
 var ErrGlobAllOutsideRoot = errors.New("all matched files are outside project root")
 var ErrGlobNoneMatched = errors.New("no files matched glob pattern")
 
@@ -132,6 +134,16 @@ func decodeJSON[T any](rc io.ReadCloser) (*T, error) {
 	return &out, nil
 }
 
+// decodeManifest parses a JSON or JSONC manifest stream.
+func decodeManifest(rc io.ReadCloser) (*M.Package, error) {
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	return M.UnmarshalPackage(data)
+}
+
 // IsPackageSpecifier checks if a string is an npm or jsr package specifier.
 func IsPackageSpecifier(spec string) bool {
 	return strings.HasPrefix(spec, "npm:") || strings.HasPrefix(spec, "jsr:")
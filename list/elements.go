@@ -0,0 +1,316 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"bennypowers.dev/cem/internal/tui"
+	M "bennypowers.dev/cem/manifest"
+	treeview "github.com/Digital-Shane/treeview/v2"
+)
+
+// ElementSummary is a one-line digest of a custom element, for audits of a
+// package or workspace.
+type ElementSummary struct {
+	TagName    string   `json:"tagName"`
+	Class      string   `json:"class"`
+	Module     string   `json:"module"`
+	Summary    string   `json:"summary,omitempty"`
+	Attributes int      `json:"attributes"`
+	Slots      int      `json:"slots"`
+	Events     int      `json:"events"`
+	Deprecated bool     `json:"deprecated"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// PackageElements groups the element summaries of one package.
+type PackageElements struct {
+	Package  string           `json:"package"`
+	Elements []ElementSummary `json:"elements"`
+}
+
+// ElementFilter reports whether an element should be listed.
+type ElementFilter func(ElementSummary) bool
+
+// SummarizeElements returns a summary of each custom element in the manifest,
+// in module order. When filter is non-nil, only matching elements are returned.
+func SummarizeElements(manifest *M.Package, filter ElementFilter) []ElementSummary {
+	elements := make([]ElementSummary, 0)
+	if manifest == nil {
+		return elements
+	}
+	for _, mod := range manifest.Modules {
+		for _, decl := range mod.Declarations {
+			ce, ok := decl.(*M.CustomElementDeclaration)
+			if !ok {
+				continue
+			}
+			summary := ElementSummary{
+				TagName:    ce.TagName,
+				Class:      ce.Name(),
+				Module:     mod.Path,
+				Summary:    ce.Summary,
+				Attributes: len(ce.Attributes()),
+				Slots:      len(ce.Slots()),
+				Events:     len(ce.Events()),
+				Deprecated: ce.IsDeprecated(),
+				Categories: ce.Categories,
+			}
+			if filter == nil || filter(summary) {
+				elements = append(elements, summary)
+			}
+		}
+	}
+	return elements
+}
+
+// ParseElementFilter parses a filter expression into an ElementFilter.
+// An expression is a comma-separated list of terms, all of which must match.
+// Each term compares a field to a value:
+//
+//	tag=my-*          tag name, class, or module path matches a glob
+//	module!=src/old/* negated glob match
+//	attrs>0           attribute, slot, or event count comparison (=, !=, <, <=, >, >=)
+//	category=form     any of the element's categories matches a glob
+//	deprecated        deprecated elements; "!deprecated" for the rest
+//
+// An empty expression matches every element.
+func ParseElementFilter(expr string) (ElementFilter, error) {
+	var terms []ElementFilter
+	for raw := range strings.SplitSeq(expr, ",") {
+		term := strings.TrimSpace(raw)
+		if term == "" {
+			continue
+		}
+		filter, err := parseFilterTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, filter)
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	return func(e ElementSummary) bool {
+		for _, term := range terms {
+			if !term(e) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// filterOperators is ordered so that two-character operators are found
+// before their one-character prefixes.
+var filterOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+func parseFilterTerm(term string) (ElementFilter, error) {
+	switch term {
+	case "deprecated":
+		return func(e ElementSummary) bool { return e.Deprecated }, nil
+	case "!deprecated":
+		return func(e ElementSummary) bool { return !e.Deprecated }, nil
+	}
+
+	field, op, value, found := "", "", "", false
+	for i := range term {
+		for _, candidate := range filterOperators {
+			if strings.HasPrefix(term[i:], candidate) {
+				field, op, value = strings.TrimSpace(term[:i]), candidate, strings.TrimSpace(term[i+len(candidate):])
+				found = true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+	if !found || field == "" {
+		return nil, fmt.Errorf("invalid filter %q: expected <field><operator><value>", term)
+	}
+
+	switch field {
+	case "tag", "class", "module":
+		return globFilter(term, field, op, value)
+	case "category":
+		return categoryFilter(term, op, value)
+	case "attrs", "attributes", "slots", "events":
+		return countFilter(term, field, op, value)
+	case "deprecated":
+		want, err := strconv.ParseBool(value)
+		if err != nil || (op != "=" && op != "!=") {
+			return nil, fmt.Errorf("invalid filter %q: deprecated takes = or != with true or false", term)
+		}
+		if op == "!=" {
+			want = !want
+		}
+		return func(e ElementSummary) bool { return e.Deprecated == want }, nil
+	default:
+		return nil, fmt.Errorf("invalid filter %q: unknown field %q", term, field)
+	}
+}
+
+func globFilter(term, field, op, pattern string) (ElementFilter, error) {
+	if op != "=" && op != "!=" {
+		return nil, fmt.Errorf("invalid filter %q: %s takes = or !=", term, field)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", term, err)
+	}
+	return func(e ElementSummary) bool {
+		var subject string
+		switch field {
+		case "tag":
+			subject = e.TagName
+		case "class":
+			subject = e.Class
+		default:
+			subject = e.Module
+		}
+		matched, _ := path.Match(pattern, subject)
+		return matched == (op == "=")
+	}, nil
+}
+
+// categoryFilter matches elements with any category matching pattern, or with
+// none when op is !=.
+func categoryFilter(term, op, pattern string) (ElementFilter, error) {
+	if op != "=" && op != "!=" {
+		return nil, fmt.Errorf("invalid filter %q: category takes = or !=", term)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", term, err)
+	}
+	return func(e ElementSummary) bool {
+		matched := slices.ContainsFunc(e.Categories, func(category string) bool {
+			ok, _ := path.Match(pattern, category)
+			return ok
+		})
+		return matched == (op == "=")
+	}, nil
+}
+
+func countFilter(term, field, op, value string) (ElementFilter, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %s takes a number", term, field)
+	}
+	return func(e ElementSummary) bool {
+		var count int
+		switch field {
+		case "slots":
+			count = e.Slots
+		case "events":
+			count = e.Events
+		default:
+			count = e.Attributes
+		}
+		switch op {
+		case "=":
+			return count == n
+		case "!=":
+			return count != n
+		case ">":
+			return count > n
+		case ">=":
+			return count >= n
+		case "<":
+			return count < n
+		default:
+			return count <= n
+		}
+	}, nil
+}
+
+// RenderElementsJSON renders element summaries grouped by package as
+// indented JSON.
+func RenderElementsJSON(packages []PackageElements) (string, error) {
+	if packages == nil {
+		packages = []PackageElements{}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(packages); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// RenderElementsTree renders element summaries as a tree of modules and
+// their elements, with each element's counts and deprecation.
+func RenderElementsTree(title string, elements []ElementSummary) (string, error) {
+	if len(elements) == 0 {
+		return "", nil
+	}
+
+	var modules []*treeview.Node[M.DisplayNode]
+	children := make(map[string][]*treeview.Node[M.DisplayNode])
+	for _, e := range elements {
+		if _, ok := children[e.Module]; !ok {
+			modNode := treeview.NewNode("module:"+e.Module, e.Module, M.DisplayNode{Label: e.Module})
+			modules = append(modules, modNode)
+		}
+		label := elementTreeLabel(e)
+		children[e.Module] = append(children[e.Module],
+			treeview.NewNode("element:"+e.Module+":"+e.TagName, label, M.DisplayNode{Label: label}))
+	}
+	for i, e := range uniqueModules(elements) {
+		modules[i].SetChildren(children[e])
+		modules[i].Expand()
+	}
+
+	tree := treeview.NewTree(modules,
+		treeview.WithExpandFunc[M.DisplayNode](func(_ *treeview.Node[M.DisplayNode]) bool { return true }),
+	)
+	s, err := tree.Render(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return tui.HeaderStyle.Render(title) + "\n" + s, nil
+}
+
+// uniqueModules returns the module paths of elements in first-seen order.
+func uniqueModules(elements []ElementSummary) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, e := range elements {
+		if !seen[e.Module] {
+			seen[e.Module] = true
+			paths = append(paths, e.Module)
+		}
+	}
+	return paths
+}
+
+func elementTreeLabel(e ElementSummary) string {
+	label := fmt.Sprintf("<%s> %s (%d attrs, %d slots, %d events)", e.TagName, e.Class, e.Attributes, e.Slots, e.Events)
+	if e.Deprecated {
+		label += " DEPRECATED"
+	}
+	return label
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package list_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/list"
+	M "bennypowers.dev/cem/manifest"
+)
+
+func TestRenderElementsJSON(t *testing.T) {
+	var pkg M.Package
+	testutil.LoadJSONFixture(t, "element-summaries/input.json", &pkg)
+
+	output, err := list.RenderElementsJSON([]list.PackageElements{{
+		Package:  "@test/elements",
+		Elements: list.SummarizeElements(&pkg, nil),
+	}})
+	if err != nil {
+		t.Fatalf("RenderElementsJSON failed: %v", err)
+	}
+
+	testutil.CheckGolden(t, "element-summaries/expected.json", []byte(output), testutil.GoldenOptions{
+		Dir:         "testdata",
+		UseJSONDiff: true,
+	})
+}
+
+// Inline: pure function, table-driven
+func TestParseElementFilter(t *testing.T) {
+	button := list.ElementSummary{TagName: "my-button", Class: "MyButton", Module: "src/my-button.js", Attributes: 2, Slots: 1, Events: 1, Categories: []string{"form", "action"}}
+	card := list.ElementSummary{TagName: "old-card", Class: "OldCard", Module: "src/old-card.js", Slots: 2, Deprecated: true}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"my-button", "old-card"}},
+		{"deprecated", []string{"old-card"}},
+		{"!deprecated", []string{"my-button"}},
+		{"deprecated=false", []string{"my-button"}},
+		{"tag=my-*", []string{"my-button"}},
+		{"module!=src/old-*", []string{"my-button"}},
+		{"class=*Card", []string{"old-card"}},
+		{"attrs>0", []string{"my-button"}},
+		{"slots>=2", []string{"old-card"}},
+		{"events=0", []string{"old-card"}},
+		{"slots>0, attrs<=2", []string{"my-button", "old-card"}},
+		{"slots>0,deprecated", []string{"old-card"}},
+		{"category=form", []string{"my-button"}},
+		{"category=act*", []string{"my-button"}},
+		{"category!=form", []string{"old-card"}},
+	}
+	for _, tt := range tests {
+		filter, err := list.ParseElementFilter(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseElementFilter(%q) failed: %v", tt.expr, err)
+		}
+		var got []string
+		for _, e := range []list.ElementSummary{button, card} {
+			if filter == nil || filter(e) {
+				got = append(got, e.TagName)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseElementFilter(%q) matched %v, want %v", tt.expr, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseElementFilter(%q) matched %v, want %v", tt.expr, got, tt.want)
+				break
+			}
+		}
+	}
+
+	for _, expr := range []string{"color=red", "attrs>many", "tag>a", "deprecated=maybe", "=x", "tag=[", "category>a", "shiny"} {
+		if _, err := list.ParseElementFilter(expr); err == nil {
+			t.Errorf("ParseElementFilter(%q) should fail", expr)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"slices"
@@ -45,6 +46,8 @@ type RenderOptions struct {
 	Columns         []string
 	IncludeSections []string
 	Markdown        bool
+	// ElementFilter restricts the rows of the tags table
+	ElementFilter ElementFilter
 }
 
 // Render recursively renders a Renderable, creating sectioned tables.
@@ -193,19 +196,35 @@ func RenderModulesTable(manifest *M.Package, opts RenderOptions) (string, error)
 
 func RenderTagsTable(manifest *M.Package, opts RenderOptions) (string, error) {
 	headers := []string{"Tag Name", "Class", "Module", "Summary"}
+	// The count and deprecation columns are only shown when asked for by name
+	counts := slices.ContainsFunc(opts.Columns, func(c string) bool {
+		return slices.Contains(tagsCountHeaders, c)
+	})
+	if counts {
+		headers = append(headers, tagsCountHeaders...)
+	}
 	rows := make([][]string, 0)
 
-	for _, mod := range manifest.Modules {
-		for _, decl := range mod.Declarations {
-			if ce, ok := decl.(*M.CustomElementDeclaration); ok {
-				rows = append(rows, []string{
-					"<" + ce.TagName + ">",
-					ce.Name(),
-					mod.Path,
-					ce.Summary,
-				})
-			}
+	for _, e := range SummarizeElements(manifest, opts.ElementFilter) {
+		row := []string{
+			"<" + e.TagName + ">",
+			e.Class,
+			e.Module,
+			e.Summary,
 		}
+		if counts {
+			deprecated := ""
+			if e.Deprecated {
+				deprecated = "yes"
+			}
+			row = append(row,
+				strconv.Itoa(e.Attributes),
+				strconv.Itoa(e.Slots),
+				strconv.Itoa(e.Events),
+				deprecated,
+			)
+		}
+		rows = append(rows, row)
 	}
 
 	if opts.Markdown {
@@ -219,6 +238,8 @@ func RenderTagsTable(manifest *M.Package, opts RenderOptions) (string, error) {
 	return formatTable(headers, rows, opts.Columns)
 }
 
+var tagsCountHeaders = []string{"Attributes", "Slots", "Events", "Deprecated"}
+
 // MapToTableRows maps a slice of Renderables to [][]string.
 func MapToTableRows[T M.Renderable](items []T) [][]string {
 	rows := make([][]string, 0, len(items))
@@ -0,0 +1,254 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package configfile
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	IC "bennypowers.dev/cem/internal/config"
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/set"
+	"bennypowers.dev/cem/lsp/helpers"
+	"go.lsp.dev/protocol"
+)
+
+// configItem mirrors the wire format of a completion item with a text edit.
+// The protocol package models text edits as a union, so the item is built in
+// wire format and decoded into a protocol.CompletionItem.
+type configItem struct {
+	Label         string                       `json:"label"`
+	Kind          protocol.CompletionItemKind  `json:"kind"`
+	Detail        string                       `json:"detail,omitempty"`
+	Documentation *protocol.MarkupContent      `json:"documentation,omitempty"`
+	Tags          []protocol.CompletionItemTag `json:"tags,omitempty"`
+	SortText      string                       `json:"sortText,omitempty"`
+	TextEdit      protocol.TextEdit            `json:"textEdit"`
+}
+
+// globExtensions are the extensions of the files for which glob completions
+// are offered
+var globExtensions = set.NewSet(".ts", ".js", ".css", ".html")
+
+// skipDirs are never searched for completions
+var skipDirs = set.NewSet("node_modules")
+
+// customElementsPattern matches a package.json line up to a cursor inside
+// the value of its customElements field
+var customElementsPattern = regexp.MustCompile(`"customElements"\s*:\s*"([^"]*)$`)
+
+// Completions returns completions at position in a configuration document of
+// the given kind. For the cem config file, completions are offered for keys,
+// for enum and boolean values, and for glob values. For package.json, the
+// manifests in the package are offered as values of customElements.
+func Completions(fsys platform.FileSystem, kind Kind, uri, content string, position protocol.Position) []protocol.CompletionItem {
+	switch kind {
+	case KindCemConfig:
+		if IC.FormatFromPath(uri) != "yaml" {
+			return nil
+		}
+		return configCompletions(fsys, uri, content, position)
+	case KindPackageJSON:
+		return packageJSONCompletions(fsys, uri, content, position)
+	}
+	return nil
+}
+
+func configCompletions(fsys platform.FileSystem, uri, content string, position protocol.Position) []protocol.CompletionItem {
+	cursor, ok := analyzeYAML(content, position)
+	if !ok {
+		helpers.SafeDebugLog("[COMPLETION] Could not find the cursor in config file %s", uri)
+		return nil
+	}
+	node := configSchema().lookup(cursor.path)
+	if node == nil {
+		return nil
+	}
+	editRange := prefixRange(position, cursor.prefix)
+
+	// An item of a sequence of objects takes keys, like a mapping
+	inObjectItem := len(cursor.path) > 0 && cursor.path[len(cursor.path)-1] == sequenceItem && node.Type == "object"
+
+	var items []configItem
+	switch {
+	case cursor.isKey || inObjectItem:
+		for _, name := range slices.Sorted(maps.Keys(node.Properties)) {
+			if slices.Contains(cursor.siblings, name) {
+				continue
+			}
+			property := node.Properties[name]
+			item := configItem{
+				Label:    name,
+				Kind:     protocol.CompletionItemKindProperty,
+				Detail:   property.Type,
+				TextEdit: protocol.TextEdit{Range: editRange, NewText: name + ": "},
+			}
+			if property.Description != "" {
+				item.Documentation = &protocol.MarkupContent{Kind: protocol.MarkupKindMarkdown, Value: property.Description}
+			}
+			if property.Deprecated {
+				item.Tags = []protocol.CompletionItemTag{protocol.CompletionItemTagDeprecated}
+				item.SortText = "1" + name
+			}
+			items = append(items, item)
+		}
+	case len(node.Enum) > 0:
+		for _, value := range node.Enum {
+			text := fmt.Sprint(value)
+			items = append(items, configItem{
+				Label:    text,
+				Kind:     protocol.CompletionItemKindEnumMember,
+				TextEdit: protocol.TextEdit{Range: editRange, NewText: text},
+			})
+		}
+	case node.Type == "boolean":
+		for _, text := range []string{"true", "false"} {
+			items = append(items, configItem{
+				Label:    text,
+				Kind:     protocol.CompletionItemKindValue,
+				TextEdit: protocol.TextEdit{Range: editRange, NewText: text},
+			})
+		}
+	case node.Format == "x-glob-pattern":
+		for _, glob := range workspaceGlobs(fsys, packageRoot(uri)) {
+			items = append(items, configItem{
+				Label:    glob,
+				Kind:     protocol.CompletionItemKindFile,
+				Detail:   "Glob pattern",
+				TextEdit: protocol.TextEdit{Range: editRange, NewText: glob},
+			})
+		}
+	}
+	return toCompletionItems(items)
+}
+
+func packageJSONCompletions(fsys platform.FileSystem, uri, content string, position protocol.Position) []protocol.CompletionItem {
+	lines := strings.Split(content, "\n")
+	if int(position.Line) >= len(lines) {
+		return nil
+	}
+	line := lines[position.Line]
+	match := customElementsPattern.FindStringSubmatch(line[:min(int(position.Character), len(line))])
+	if match == nil {
+		return nil
+	}
+	editRange := prefixRange(position, match[1])
+
+	var items []configItem
+	for _, manifest := range packageManifests(fsys, packageRoot(uri)) {
+		items = append(items, configItem{
+			Label:    manifest,
+			Kind:     protocol.CompletionItemKindFile,
+			Detail:   "Custom elements manifest",
+			TextEdit: protocol.TextEdit{Range: editRange, NewText: manifest},
+		})
+	}
+	return toCompletionItems(items)
+}
+
+// prefixRange is the range of the prefix typed before position, which
+// completions replace
+func prefixRange(position protocol.Position, prefix string) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{
+			Line:      position.Line,
+			Character: position.Character - uint32(len(utf16.Encode([]rune(prefix)))),
+		},
+		End: position,
+	}
+}
+
+// workspaceGlobs suggests a glob for each kind of source file in each
+// top-level directory of the package, e.g. src/**/*.ts. Ignored files, per
+// .gitignore and .cemignore, are not considered.
+func workspaceGlobs(fsys platform.FileSystem, root string) []string {
+	root = filepath.FromSlash(root)
+	matcher := platform.LoadIgnoreMatcher(fsys, root, platform.WorkspaceIgnoreFiles...)
+	globs := set.NewSet[string]()
+	_ = platform.WalkDirIgnoring(fsys, root, skipDirs, matcher, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(p)
+		if !globExtensions.Has(ext) || strings.HasSuffix(p, ".d.ts") {
+			return nil
+		}
+		top, _, found := strings.Cut(filepath.ToSlash(rel), "/")
+		if found {
+			globs.Add(top + "/**/*" + ext)
+		}
+		return nil
+	})
+	members := globs.Members()
+	slices.Sort(members)
+	return members
+}
+
+// packageManifests finds the custom elements manifests in a package, by
+// their conventional name. Ignored files are included, since manifests are
+// often generated and not committed.
+func packageManifests(fsys platform.FileSystem, root string) []string {
+	root = filepath.FromSlash(root)
+	var manifests []string
+	_ = platform.WalkDir(fsys, root, skipDirs, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "custom-elements.json" {
+			return nil
+		}
+		if rel, err := filepath.Rel(root, p); err == nil {
+			manifests = append(manifests, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if len(manifests) == 0 {
+		manifests = append(manifests, "custom-elements.json")
+	}
+	return manifests
+}
+
+func toCompletionItems(items []configItem) []protocol.CompletionItem {
+	completions := make([]protocol.CompletionItem, 0, len(items))
+	for _, item := range items {
+		data, err := protocol.Marshal(item)
+		if err != nil {
+			helpers.SafeDebugLog("[COMPLETION] Failed to marshal config completion: %v", err)
+			continue
+		}
+		var completion protocol.CompletionItem
+		if err := protocol.Unmarshal(data, &completion); err != nil {
+			helpers.SafeDebugLog("[COMPLETION] Failed to unmarshal config completion: %v", err)
+			continue
+		}
+		completions = append(completions, completion)
+	}
+	return completions
+}
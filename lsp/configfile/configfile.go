@@ -0,0 +1,64 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+// Package configfile provides completions and diagnostics for the files which
+// configure cem itself: the cem config file, e.g. .config/cem.yaml, and the
+// customElements field of package.json. Completions for the config file are
+// driven by the config's JSON schema.
+package configfile
+
+import (
+	"path"
+	"strings"
+
+	IC "bennypowers.dev/cem/internal/config"
+)
+
+// Kind is the kind of a configuration document
+type Kind int
+
+const (
+	// KindNone is any document which does not configure cem
+	KindNone Kind = iota
+	// KindCemConfig is a cem config file, e.g. .config/cem.yaml
+	KindCemConfig
+	// KindPackageJSON is an npm package.json
+	KindPackageJSON
+)
+
+// KindOf returns the kind of configuration document at uri
+func KindOf(uri string) Kind {
+	p := strings.TrimPrefix(uri, "file://")
+	if path.Base(p) == "package.json" {
+		return KindPackageJSON
+	}
+	for _, configPath := range IC.ConfigPaths {
+		if strings.HasSuffix(p, "/"+configPath) {
+			return KindCemConfig
+		}
+	}
+	return KindNone
+}
+
+// packageRoot returns the directory of the package a configuration document
+// belongs to, against which the paths and globs in it resolve
+func packageRoot(uri string) string {
+	dir := path.Dir(strings.TrimPrefix(uri, "file://"))
+	if path.Base(dir) == ".config" {
+		return path.Dir(dir)
+	}
+	return dir
+}
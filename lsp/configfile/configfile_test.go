@@ -0,0 +1,168 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package configfile_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/configfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+// Inline: documents are short YAML and JSON snippets, and the workspace is a
+// handful of files in memory
+
+func labels(items []protocol.CompletionItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Label)
+	}
+	return result
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected configfile.Kind
+	}{
+		{uri: "file:///ws/.config/cem.yaml", expected: configfile.KindCemConfig},
+		{uri: "file:///ws/.config/cem.jsonc", expected: configfile.KindCemConfig},
+		{uri: "file:///ws/packages/a/.cem.yml", expected: configfile.KindCemConfig},
+		{uri: "file:///ws/package.json", expected: configfile.KindPackageJSON},
+		{uri: "file:///ws/.config/other.yaml", expected: configfile.KindNone},
+		{uri: "file:///ws/src/my-element.ts", expected: configfile.KindNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			assert.Equal(t, tt.expected, configfile.KindOf(tt.uri))
+		})
+	}
+}
+
+func TestCompletions_Config(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/ws/src/my-button.ts", "", 0644)
+	fsys.AddFile("/ws/src/my-button.css", "", 0644)
+	fsys.AddFile("/ws/elements/my-card.ts", "", 0644)
+	fsys.AddFile("/ws/elements/my-card.d.ts", "", 0644)
+	fsys.AddFile("/ws/dist/my-card.js", "", 0644)
+	fsys.AddFile("/ws/.gitignore", "dist/\n", 0644)
+	uri := "file:///ws/.config/cem.yaml"
+
+	tests := []struct {
+		name     string
+		content  string
+		position protocol.Position
+		expected []string
+	}{
+		{
+			name:     "top-level keys",
+			content:  "ge",
+			position: protocol.Position{Line: 0, Character: 2},
+			expected: []string{"additionalPackages", "breaking", "configFile", "export", "generate", "health", "logLevel", "lsp", "mcp", "packageName", "projectDir", "registry", "serve", "sourceControlRootUrl", "verbose"},
+		},
+		{
+			name:     "nested keys omit those already set",
+			content:  "serve:\n  port: 8000\n  demos:\n    rendering: light\n    \n",
+			position: protocol.Position{Line: 4, Character: 4},
+			expected: []string{"env", "scopedElementsFile", "themeToggle"},
+		},
+		{
+			name:     "enum values",
+			content:  "generate:\n  memberOrder: \n",
+			position: protocol.Position{Line: 1, Character: 15},
+			expected: []string{"source", "alphabetical", "kind"},
+		},
+		{
+			name:     "boolean values",
+			content:  "serve:\n  openBrowser: \n",
+			position: protocol.Position{Line: 1, Character: 15},
+			expected: []string{"true", "false"},
+		},
+		{
+			name:     "enum values in a sequence of objects",
+			content:  "generate:\n  outputs:\n    - path: web-types.json\n      format: w\n",
+			position: protocol.Position{Line: 3, Character: 15},
+			expected: []string{"json", "jsonc", "ndjson", "web-types", "vscode-custom-data", "dts"},
+		},
+		{
+			name:     "globs",
+			content:  "generate:\n  files:\n    - \n",
+			position: protocol.Position{Line: 2, Character: 6},
+			expected: []string{"elements/**/*.ts", "src/**/*.css", "src/**/*.ts"},
+		},
+		{
+			name:     "comment",
+			content:  "# generate\n",
+			position: protocol.Position{Line: 0, Character: 5},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := configfile.Completions(fsys, configfile.KindCemConfig, uri, tt.content, tt.position)
+			assert.Equal(t, tt.expected, labels(items))
+		})
+	}
+}
+
+func TestCompletions_PackageJSON(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/ws/dist/custom-elements.json", "{}", 0644)
+	fsys.AddFile("/ws/node_modules/dep/custom-elements.json", "{}", 0644)
+	content := "{\n  \"name\": \"my-elements\",\n  \"customElements\": \"di\"\n}\n"
+
+	items := configfile.Completions(fsys, configfile.KindPackageJSON, "file:///ws/package.json", content, protocol.Position{Line: 2, Character: 23})
+	assert.Equal(t, []string{"dist/custom-elements.json"}, labels(items))
+
+	items = configfile.Completions(fsys, configfile.KindPackageJSON, "file:///ws/package.json", content, protocol.Position{Line: 1, Character: 12})
+	assert.Empty(t, items, "only the customElements value is completed")
+}
+
+func TestDiagnostics(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/ws/custom-elements.json", "{}", 0644)
+
+	t.Run("config", func(t *testing.T) {
+		content := "generate:\n  memberOrder: alpha\nserve:\n  port: 8000\n"
+		diagnostics := configfile.Diagnostics(fsys, configfile.KindCemConfig, "file:///ws/.config/cem.yaml", content)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, uint32(1), diagnostics[0].Range.Start.Line)
+		assert.Equal(t, uint32(15), diagnostics[0].Range.Start.Character)
+		assert.Contains(t, diagnostics[0].Message, "generate.memberOrder")
+	})
+
+	t.Run("valid config", func(t *testing.T) {
+		content := "generate:\n  memberOrder: kind\n"
+		assert.Empty(t, configfile.Diagnostics(fsys, configfile.KindCemConfig, "file:///ws/.config/cem.yaml", content))
+	})
+
+	t.Run("package.json", func(t *testing.T) {
+		content := "{\n  \"customElements\": \"dist/custom-elements.json\"\n}\n"
+		diagnostics := configfile.Diagnostics(fsys, configfile.KindPackageJSON, "file:///ws/package.json", content)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, protocol.DiagnosticSeverityWarning, diagnostics[0].Severity)
+		assert.Equal(t, uint32(1), diagnostics[0].Range.Start.Line)
+
+		content = "{\n  \"customElements\": \"./custom-elements.json\"\n}\n"
+		assert.Empty(t, configfile.Diagnostics(fsys, configfile.KindPackageJSON, "file:///ws/package.json", content))
+	})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package configfile
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	IC "bennypowers.dev/cem/internal/config"
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/sourcepos"
+	"go.lsp.dev/protocol"
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostics validates a configuration document of the given kind. The cem
+// config file is checked against the config schema and for invalid values,
+// like `cem config validate` does, but without filesystem checks. For
+// package.json, the customElements field must name an existing file.
+func Diagnostics(fsys platform.FileSystem, kind Kind, uri, content string) []protocol.Diagnostic {
+	switch kind {
+	case KindCemConfig:
+		return configDiagnostics(uri, content)
+	case KindPackageJSON:
+		return packageJSONDiagnostics(fsys, uri, content)
+	}
+	return nil
+}
+
+func configDiagnostics(uri, content string) []protocol.Diagnostic {
+	configPath := strings.TrimPrefix(uri, "file://")
+	format := IC.FormatFromPath(configPath)
+	schemaErrs := IC.ValidateSchema([]byte(content), format)
+
+	// Parse the unsaved content, so the semantic checks see what the user sees
+	var semanticErrs []IC.ValidationError
+	if cfg, err := IC.ParseConfig([]byte(content), format); err == nil {
+		semanticErrs = IC.Validate(cfg, IC.ValidateOptions{})
+	}
+
+	errs := IC.DeduplicateErrors(schemaErrs, semanticErrs)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(content, "\n")
+	posMap := sourcepos.BuildPositionMap([]byte(content), format)
+	diagnostics := make([]protocol.Diagnostic, 0, len(errs))
+	for _, e := range errs {
+		var r protocol.Range
+		if pos, ok := sourcepos.Resolve(posMap, sourcepos.FieldToJSONPointer(e.Field)); ok {
+			r = lineRange(lines, pos.Line-1, pos.Column-1)
+		}
+		severity := protocol.DiagnosticSeverityError
+		if e.Severity == IC.SeverityWarning {
+			severity = protocol.DiagnosticSeverityWarning
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:    r,
+			Severity: severity,
+			Source:   protocol.NewOptional("cem-lsp"),
+			Message:  protocol.String(e.Error()),
+		})
+	}
+	return diagnostics
+}
+
+func packageJSONDiagnostics(fsys platform.FileSystem, uri, content string) []protocol.Diagnostic {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		// Leave syntax errors to the editor's JSON support
+		return nil
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "customElements" {
+			continue
+		}
+		value := root.Content[i+1]
+		lines := strings.Split(content, "\n")
+		r := lineRange(lines, value.Line-1, value.Column-1)
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!str" {
+			return []protocol.Diagnostic{{
+				Range:    r,
+				Severity: protocol.DiagnosticSeverityError,
+				Source:   protocol.NewOptional("cem-lsp"),
+				Message:  protocol.String("customElements must be the path to a custom elements manifest"),
+			}}
+		}
+		manifestPath := filepath.Join(filepath.FromSlash(packageRoot(uri)), filepath.FromSlash(path.Clean(value.Value)))
+		if _, err := fsys.Stat(manifestPath); err != nil {
+			return []protocol.Diagnostic{{
+				Range:    r,
+				Severity: protocol.DiagnosticSeverityWarning,
+				Source:   protocol.NewOptional("cem-lsp"),
+				Message:  protocol.String(fmt.Sprintf("custom elements manifest %s does not exist; run `cem generate` to write it", value.Value)),
+			}}
+		}
+	}
+	return nil
+}
+
+// lineRange is the range from a zero-based line and column to the end of the
+// line
+func lineRange(lines []string, line, column int) protocol.Range {
+	if line < 0 || line >= len(lines) {
+		return protocol.Range{}
+	}
+	text := strings.TrimSuffix(lines[line], "\r")
+	column = max(0, min(column, len(text)))
+	return protocol.Range{
+		Start: protocol.Position{Line: uint32(line), Character: uint32(column)},
+		End:   protocol.Position{Line: uint32(line), Character: uint32(len(text))},
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package configfile
+
+import (
+	"encoding/json"
+	"sync"
+
+	IC "bennypowers.dev/cem/internal/config"
+)
+
+// sequenceItem is the path segment for the items of a sequence
+const sequenceItem = "[]"
+
+// schemaNode is the part of a JSON schema which drives completions
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Description          string                 `json:"description"`
+	Enum                 []any                  `json:"enum"`
+	Format               string                 `json:"format"`
+	Deprecated           bool                   `json:"deprecated"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Items                *schemaNode            `json:"items"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+}
+
+// configSchema is the config schema, decoded once
+var configSchema = sync.OnceValue(func() *schemaNode {
+	var root schemaNode
+	if err := json.Unmarshal(IC.SchemaJSON(), &root); err != nil {
+		return &schemaNode{}
+	}
+	return &root
+})
+
+// lookup returns the schema of the value at path, or nil if the schema does
+// not describe it
+func (n *schemaNode) lookup(path []string) *schemaNode {
+	for _, segment := range path {
+		n = n.child(segment)
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// child returns the schema of a sequence's items, or of an object's property.
+// Properties which are not declared take the schema of additionalProperties,
+// unless it is a boolean.
+func (n *schemaNode) child(segment string) *schemaNode {
+	if segment == sequenceItem {
+		return n.Items
+	}
+	if property, ok := n.Properties[segment]; ok {
+		return property
+	}
+	var additional schemaNode
+	if len(n.AdditionalProperties) > 0 && json.Unmarshal(n.AdditionalProperties, &additional) == nil {
+		return &additional
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package configfile
+
+import (
+	"slices"
+	"strings"
+
+	"go.lsp.dev/protocol"
+	"gopkg.in/yaml.v3"
+)
+
+// cursorMarker stands in for the text at the cursor while the document is
+// parsed, so the cursor can be found in the parsed document
+const cursorMarker = "__cem_cursor__"
+
+// yamlCursor is the position of the cursor in a YAML document's structure
+type yamlCursor struct {
+	// path to the value at the cursor, or to the mapping whose key is at
+	// the cursor
+	path []string
+	// isKey is true when the cursor is on a mapping key
+	isKey bool
+	// siblings are the other keys of the mapping, when the cursor is on a key
+	siblings []string
+	// prefix is the text typed before the cursor
+	prefix string
+}
+
+// analyzeYAML finds the cursor in a YAML document. It replaces the line at
+// the cursor with a marker in key or value position, then parses the
+// document and finds the marker. Returns false when the document does not
+// parse, or the cursor is in a comment.
+func analyzeYAML(content string, position protocol.Position) (*yamlCursor, bool) {
+	lines := strings.Split(content, "\n")
+	if int(position.Line) >= len(lines) {
+		return nil, false
+	}
+	line := strings.TrimSuffix(lines[position.Line], "\r")
+	before := line[:min(int(position.Character), len(line))]
+
+	text := strings.TrimLeft(before, " ")
+	indent := before[:len(before)-len(text)]
+	if text == "-" || strings.HasPrefix(text, "- ") {
+		indent += "- "
+		text = strings.TrimLeft(strings.TrimPrefix(text, "-"), " ")
+	}
+	if strings.HasPrefix(text, "#") {
+		return nil, false
+	}
+
+	cursor := &yamlCursor{}
+	if key, value, found := strings.Cut(text, ": "); found {
+		cursor.prefix = strings.TrimLeft(strings.TrimLeft(value, " "), `"'`)
+		lines[position.Line] = indent + key + ": " + cursorMarker
+	} else if strings.HasSuffix(indent, "- ") {
+		cursor.prefix = strings.TrimLeft(text, `"'`)
+		lines[position.Line] = indent + cursorMarker
+	} else {
+		cursor.prefix = text
+		lines[position.Line] = indent + cursorMarker + ": null"
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &doc); err != nil {
+		return nil, false
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, false
+	}
+	if !findCursor(doc.Content[0], nil, cursor) {
+		return nil, false
+	}
+	return cursor, true
+}
+
+// findCursor finds the marker under node, whose path is path, and records
+// where it is in cursor
+func findCursor(node *yaml.Node, path []string, cursor *yamlCursor) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == cursorMarker {
+				cursor.path = path
+				cursor.isKey = true
+				for j := 0; j+1 < len(node.Content); j += 2 {
+					if j != i {
+						cursor.siblings = append(cursor.siblings, node.Content[j].Value)
+					}
+				}
+				return true
+			}
+			if findCursor(value, append(slices.Clone(path), key.Value), cursor) {
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if findCursor(item, append(slices.Clone(path), sequenceItem), cursor) {
+				return true
+			}
+		}
+	case yaml.ScalarNode:
+		if node.Value == cursorMarker {
+			cursor.path = path
+			return true
+		}
+	}
+	return false
+}
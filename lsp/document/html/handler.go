@@ -319,7 +319,7 @@ func (h *Handler) ParseImportMap(doc types.Document) (map[string]string, error)
 					// Multi-line: handle start and end separately
 					if i == startLine {
 						// Start from the character position on the first line
-						if int(scriptTag.ContentRange.Start.Character) < len(line) {
+						if int(scriptTag.ContentRange.Start.Character) <= len(line) {
 							line = line[scriptTag.ContentRange.Start.Character:]
 						}
 					}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package document
+
+import (
+	"regexp"
+	"strings"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+)
+
+// modelinePattern matches a `cem-language: html` comment
+var modelinePattern = regexp.MustCompile(`cem-language:\s*(\w+)`)
+
+// modelineLines is how many lines at the start of a document may hold a modeline
+const modelineLines = 5
+
+// languageAliases maps other names for languages to handler languages
+var languageAliases = map[string]string{
+	"javascript": "typescript",
+	"js":         "typescript",
+	"ts":         "typescript",
+	"jsx":        "tsx",
+	"htm":        "html",
+}
+
+// languageFromModeline finds a `cem-language: <language>` comment in the
+// first lines of a document, in any comment syntax
+func languageFromModeline(content string) string {
+	head := content
+	for i, lines := 0, 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			if lines++; lines == modelineLines {
+				head = content[:i]
+				break
+			}
+		}
+	}
+	if !strings.Contains(head, "cem-language") {
+		return ""
+	}
+	if match := modelinePattern.FindStringSubmatch(head); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// SetLanguageResolver sets the function which returns the configured
+// language of a document, or "" when none is configured
+func (dm *documentManager) SetLanguageResolver(resolve func(uri string) string) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	dm.languageResolver = resolve
+}
+
+// languageFor determines the language of a document. A modeline takes
+// precedence over the configured language, which takes precedence over the
+// file extension. Must be called with dm.mu held.
+func (dm *documentManager) languageFor(uri, content string) string {
+	if language, ok := dm.knownLanguage(languageFromModeline(content)); ok {
+		helpers.SafeDebugLog("[DOCUMENT] Modeline sets language of %s to %s", uri, language)
+		return language
+	}
+	if dm.languageResolver != nil {
+		if language, ok := dm.knownLanguage(dm.languageResolver(uri)); ok {
+			helpers.SafeDebugLog("[DOCUMENT] Configuration sets language of %s to %s", uri, language)
+			return language
+		}
+	}
+	return getLanguageFromURI(uri)
+}
+
+// knownLanguage normalizes a language name, reporting whether the document
+// manager can handle it
+func (dm *documentManager) knownLanguage(language string) (string, bool) {
+	if language == "" {
+		return "", false
+	}
+	language = strings.ToLower(language)
+	if alias, ok := languageAliases[language]; ok {
+		language = alias
+	}
+	if language == types.LanguageIgnore {
+		return language, true
+	}
+	if _, ok := dm.languageHandlers[language]; !ok {
+		helpers.SafeDebugLog("[DOCUMENT] Unknown language override %q", language)
+		return "", false
+	}
+	return language, true
+}
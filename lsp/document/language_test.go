@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package document_test
+
+import (
+	"testing"
+
+	_ "bennypowers.dev/cem/internal/languages/registry"
+	"bennypowers.dev/cem/lsp/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+// Inline: each case is a one-line document whose modeline is the point
+
+func TestDocumentManager_LanguageOverrides(t *testing.T) {
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+
+	dm.SetLanguageResolver(func(uri string) string {
+		switch uri {
+		case "file:///views/page.html":
+			return "typescript"
+		case "file:///vendor/lib.js":
+			return "ignore"
+		case "file:///typo.html":
+			return "htmx"
+		}
+		return ""
+	})
+
+	tests := []struct {
+		name     string
+		uri      string
+		content  string
+		expected string
+	}{
+		{"extension", "file:///index.html", "<div></div>", "html"},
+		{"HTML modeline", "file:///page.js", "<!-- cem-language: html -->\n<div></div>", "html"},
+		{"line comment modeline", "file:///page.html", "// cem-language: javascript\nconst a = 1;", "typescript"},
+		{"configured", "file:///views/page.html", "const a = 1;", "typescript"},
+		{"modeline over configuration", "file:///views/page.html", "<!-- cem-language: html -->", "html"},
+		{"unknown language", "file:///typo.html", "<!-- cem-language: htmx -->", "html"},
+		{"modeline after the first lines", "file:///late.html", "\n\n\n\n\n// cem-language: typescript", "html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := dm.OpenDocument(tt.uri, tt.content, 1)
+			require.NotNil(t, doc)
+			assert.Equal(t, tt.expected, doc.Language())
+		})
+	}
+
+	t.Run("ignore", func(t *testing.T) {
+		assert.Nil(t, dm.OpenDocument("file:///vendor/lib.js", "const a = 1;", 1))
+		assert.Nil(t, dm.Document("file:///vendor/lib.js"))
+
+		doc := dm.OpenDocument("file:///generated.html", "<div></div>", 1)
+		require.NotNil(t, doc)
+		content := "<!-- cem-language: ignore -->\n<div></div>"
+		assert.Nil(t, dm.UpdateDocumentWithChanges("file:///generated.html", content, 2, []protocol.TextDocumentContentChangeEvent{
+			&protocol.TextDocumentContentChangeWholeDocument{Text: content},
+		}))
+		assert.Nil(t, dm.Document("file:///generated.html"))
+	})
+
+	t.Run("editing the modeline", func(t *testing.T) {
+		doc := dm.OpenDocument("file:///edited.html", "<div></div>", 1)
+		require.NotNil(t, doc)
+		content := "// cem-language: typescript\nconst a = 1;"
+		doc = dm.UpdateDocumentWithChanges("file:///edited.html", content, 2, []protocol.TextDocumentContentChangeEvent{
+			&protocol.TextDocumentContentChangeWholeDocument{Text: content},
+		})
+		require.NotNil(t, doc)
+		assert.Equal(t, "typescript", doc.Language())
+	})
+}
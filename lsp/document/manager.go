@@ -41,6 +41,10 @@ type documentManager struct {
 	queryManager      *Q.QueryManager
 	languageHandlers  map[string]types.LanguageHandler
 	incrementalParser types.IncrementalParser
+	languageResolver  func(uri string) string
+	// The language each document was opened as, which a modeline or the
+	// configuration may have chosen over its extension
+	documentLanguages map[string]string
 	mu                sync.RWMutex
 	// Per-URI locks to serialize tree-sitter operations on the same document
 	// Tree-sitter C objects are NOT thread-safe - concurrent access causes segfaults
@@ -60,6 +64,7 @@ func NewDocumentManager() (types.Manager, error) {
 		documents:         make(map[string]types.Document),
 		queryManager:      queryManager,
 		languageHandlers:  make(map[string]types.LanguageHandler),
+		documentLanguages: make(map[string]string),
 		incrementalParser: newIncrementalParser(types.ParseStrategyAuto),
 		uriLocks:          make(map[string]*sync.Mutex),
 	}
@@ -109,7 +114,13 @@ func (dm *documentManager) OpenDocument(uri, content string, version int32) type
 		existing.Close()
 	}
 
-	language := getLanguageFromURI(uri)
+	language := dm.languageFor(uri, content)
+	if language == types.LanguageIgnore {
+		helpers.SafeDebugLog("[DOCUMENT] Ignoring %s", uri)
+		delete(dm.documents, uri)
+		delete(dm.documentLanguages, uri)
+		return nil
+	}
 
 	// Get the appropriate language handler
 	handler, exists := dm.languageHandlers[language]
@@ -125,6 +136,7 @@ func (dm *documentManager) OpenDocument(uri, content string, version int32) type
 
 	doc := handler.CreateDocument(uri, content, version)
 	dm.documents[uri] = doc
+	dm.documentLanguages[uri] = language
 	return doc
 }
 
@@ -159,14 +171,22 @@ func (dm *documentManager) UpdateDocumentWithChanges(uri, content string, versio
 	defer uriLock.Unlock()
 
 	// Get the language handler for this document
-	language := getLanguageFromURI(uri)
+	language := dm.languageFor(uri, content)
+	if language == types.LanguageIgnore {
+		helpers.SafeDebugLog("[DOCUMENT] Ignoring %s", uri)
+		doc.Close()
+		delete(dm.documents, uri)
+		delete(dm.documentLanguages, uri)
+		return nil
+	}
 	handler, handlerExists := dm.languageHandlers[language]
 	if !handlerExists {
 		handler = dm.languageHandlers["html"] // Fallback to HTML
 	}
 
-	// Try incremental parsing if we have changes and an existing tree
-	if len(changes) > 0 && doc.Tree() != nil {
+	// Try incremental parsing if we have changes and an existing tree, unless
+	// an edit to the modeline changed the language
+	if len(changes) > 0 && doc.Tree() != nil && dm.documentLanguages[uri] == language {
 		helpers.SafeDebugLog("[DOCUMENT] Attempting incremental parsing for %s", uri)
 
 		// Use incremental parser to analyze and potentially parse incrementally
@@ -210,6 +230,7 @@ func (dm *documentManager) UpdateDocumentWithChanges(uri, content string, versio
 		// Create a new document with updated content
 		newDoc := handler.CreateDocument(uri, content, version)
 		dm.documents[uri] = newDoc
+		dm.documentLanguages[uri] = language
 		return newDoc
 	}
 
@@ -345,6 +366,7 @@ func (dm *documentManager) CloseDocument(uri string) {
 		doc.Close()
 		delete(dm.documents, uri)
 	}
+	delete(dm.documentLanguages, uri)
 
 	// Clean up the URI lock after closing the document
 	dm.cleanupURILock(uri)
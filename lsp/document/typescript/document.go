@@ -493,7 +493,12 @@ func (d *TypeScriptDocument) collectTemplateAttributes(
 			attrMatch.Value = closestValue
 			if strings.Contains(closestValue, "${") && tsRoot != nil {
 				valueTSOffset := template.startByte + closestValuePos
-				classifyExpression(&attrMatch, valueTSOffset, tsRoot, []byte(docContent))
+				if directive := classifyExpression(&attrMatch, valueTSOffset, tsRoot, []byte(docContent)); directive != nil {
+					attrMatch.DirectiveRange = protocol.Range{
+						Start: d.ByteOffsetToPosition(directive.StartByte(), docContent),
+						End:   d.ByteOffsetToPosition(directive.EndByte(), docContent),
+					}
+				}
 			}
 		}
 
@@ -503,15 +508,25 @@ func (d *TypeScriptDocument) collectTemplateAttributes(
 	return attributes
 }
 
+// litDirectives are the lit-html directives whose wrapped values are
+// understood. ifDefined and live pass their argument through as the value.
+var litDirectives = map[string]bool{
+	"classMap":  true,
+	"styleMap":  true,
+	"ifDefined": true,
+	"live":      true,
+}
+
 // classifyExpression analyzes a template expression (${...}) from the TS tree
 // and populates ExpressionKind/ExpressionDetail on the attribute match.
 // valueTSOffset is the attribute value's byte position in the TS document.
-func classifyExpression(match *types.AttributeMatch, valueTSOffset uint, tsRoot *ts.Node, docContent []byte) {
+// Returns the name node of a known directive wrapping the expression, if any.
+func classifyExpression(match *types.AttributeMatch, valueTSOffset uint, tsRoot *ts.Node, docContent []byte) *ts.Node {
 	searchEnd := valueTSOffset + uint(len(match.Value))
 	sub := findFirstSubstitution(tsRoot, valueTSOffset, searchEnd)
 	if sub == nil {
 		match.ExpressionKind = "complex"
-		return
+		return nil
 	}
 
 	cursor := sub.Walk()
@@ -519,13 +534,27 @@ func classifyExpression(match *types.AttributeMatch, valueTSOffset uint, tsRoot
 	children := sub.NamedChildren(cursor)
 	if len(children) == 0 {
 		match.ExpressionKind = "complex"
-		return
+		return nil
 	}
 
-	classifyExpressionNode(&children[0], match, docContent)
+	return classifyExpressionNode(&children[0], match, docContent)
 }
 
-func classifyExpressionNode(expr *ts.Node, match *types.AttributeMatch, docContent []byte) {
+func classifyExpressionNode(expr *ts.Node, match *types.AttributeMatch, docContent []byte) *ts.Node {
+	if expr.GrammarName() == "call_expression" {
+		function := expr.ChildByFieldName("function")
+		if function != nil && function.GrammarName() == "identifier" && litDirectives[function.Utf8Text(docContent)] {
+			match.Directive = function.Utf8Text(docContent)
+			match.ExpressionKind = "complex"
+			if match.Directive == "ifDefined" || match.Directive == "live" {
+				if args := expr.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() == 1 {
+					classifyExpressionNode(args.NamedChild(0), match, docContent)
+				}
+			}
+			return function
+		}
+	}
+
 	switch expr.GrammarName() {
 	case "string", "template_string":
 		match.ExpressionKind = "literal"
@@ -554,6 +583,7 @@ func classifyExpressionNode(expr *ts.Node, match *types.AttributeMatch, docConte
 	default:
 		match.ExpressionKind = "complex"
 	}
+	return nil
 }
 
 // findFirstSubstitution finds the first template_substitution node
@@ -626,6 +656,14 @@ func (d *TypeScriptDocument) analyzeCompletionContext(
 						helpers.SafeDebugLog("[TypeScript] Found interpolation, returning CompletionUnknown")
 						analysis.Type = types.CompletionUnknown
 						return analysis
+					case "import.source":
+						// Only complete between the quotes of the specifier
+						if capture.StartByte < byteOffset && byteOffset < capture.EndByte {
+							helpers.SafeDebugLog("[TypeScript] Found import specifier")
+							analysis.Type = types.CompletionImportSpecifier
+							analysis.ImportPrefix = content[capture.StartByte+1 : byteOffset]
+							return analysis
+						}
 					}
 				}
 			}
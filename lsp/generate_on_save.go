@@ -0,0 +1,244 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	G "bennypowers.dev/cem/generate"
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/helpers"
+	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/types"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// DefaultSaveGenerateDelay is how long SaveGenerator waits after the last
+// save before regenerating, so that "save all" regenerates once
+const DefaultSaveGenerateDelay = 300 * time.Millisecond
+
+// SaveGenerator regenerates the local project's manifest in-memory when its
+// source files are saved. Unlike InProcessGenerateWatcher it watches no
+// files: the editor reports saves, and only the modules affected by the
+// saved files are reprocessed. It never writes the manifest to disk.
+type SaveGenerator struct {
+	generateSession *G.GenerateSession
+	callback        ManifestUpdateCallback
+	delay           time.Duration
+	mu              sync.Mutex
+	pending         []string           // Saved files since the last generation, relative to the workspace root
+	timer           *time.Timer        // Debounce timer for pending saves
+	cancel          context.CancelFunc // Cancels the running generation
+	wg              sync.WaitGroup     // Waits for running generations to exit
+	stopped         bool
+}
+
+// NewSaveGenerator creates a save generator for the workspace, which calls
+// callback with each regenerated manifest
+func NewSaveGenerator(
+	workspace types.WorkspaceContext,
+	callback ManifestUpdateCallback,
+	fsys platform.FileSystem,
+) (*SaveGenerator, error) {
+	if fsys == nil {
+		fsys = platform.NewOSFileSystem()
+	}
+	generateSession, err := G.NewGenerateSession(workspace, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generate session: %w", err)
+	}
+	return &SaveGenerator{
+		generateSession: generateSession,
+		callback:        callback,
+		delay:           DefaultSaveGenerateDelay,
+	}, nil
+}
+
+// NoteSave schedules regeneration for a saved file, given relative to the
+// workspace root, e.g. "src/my-button.ts". Saves within the debounce delay
+// of each other regenerate together.
+func (g *SaveGenerator) NoteSave(path string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopped {
+		return
+	}
+	if !slices.Contains(g.pending, path) {
+		g.pending = append(g.pending, path)
+	}
+	if g.timer != nil {
+		g.timer.Stop()
+	}
+	g.timer = time.AfterFunc(g.delay, g.generate)
+}
+
+// generate processes the pending saves, first abandoning the previous
+// generation if it is still running, since its result would be stale
+func (g *SaveGenerator) generate() {
+	g.mu.Lock()
+	if g.stopped || len(g.pending) == 0 {
+		g.mu.Unlock()
+		return
+	}
+	changed := g.pending
+	g.pending = nil
+	if g.cancel != nil {
+		g.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	g.wg.Add(1)
+	g.mu.Unlock()
+	defer g.wg.Done()
+
+	helpers.SafeDebugLog("[GENERATE_ON_SAVE] Regenerating manifest for saved files: %v", changed)
+	var pkg *M.Package
+	var err error
+	if g.generateSession.InMemoryManifest() == nil {
+		// The first generation builds the dependency graph which later
+		// saves are processed against
+		pkg, err = g.generateSession.GenerateFullManifest(ctx)
+	} else {
+		pkg, err = g.generateSession.ProcessChangedFiles(ctx, changed)
+	}
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			helpers.SafeDebugLog("[GENERATE_ON_SAVE] Failed to regenerate manifest: %v", err)
+		}
+		return
+	}
+	if pkg == nil || ctx.Err() != nil {
+		return
+	}
+	if g.callback != nil {
+		if err := g.callback(pkg); err != nil {
+			helpers.SafeDebugLog("[GENERATE_ON_SAVE] Manifest callback failed: %v", err)
+			return
+		}
+	}
+	helpers.SafeDebugLog("[GENERATE_ON_SAVE] Regenerated manifest with %d modules", len(pkg.Modules))
+}
+
+// Stop cancels pending and running generations and closes the session
+func (g *SaveGenerator) Stop() {
+	g.mu.Lock()
+	g.stopped = true
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Unlock()
+
+	g.wg.Wait()
+	g.generateSession.Close()
+}
+
+// GenerateOnSave schedules regeneration of the local project's manifest
+// after the file at path is saved. It does nothing unless the project
+// configures `generate.files`, the file is one of them or a stylesheet, and
+// the manifest on disk is missing or older than the file. It reports
+// whether it scheduled regeneration.
+func (r *Registry) GenerateOnSave(path string) bool {
+	r.generateMu.Lock()
+	defer r.generateMu.Unlock()
+
+	if r.localWorkspace == nil {
+		return false
+	}
+	cfg, err := r.localWorkspace.Config()
+	if err != nil || cfg == nil || len(cfg.Generate.Files) == 0 {
+		return false
+	}
+
+	root := r.localWorkspace.Root()
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if !isGenerateSource(rel, cfg.Generate.Files, cfg.Generate.Exclude) {
+		return false
+	}
+	if !r.localManifestStale(path) {
+		return false
+	}
+
+	if r.saveGenerator == nil {
+		generator, err := NewSaveGenerator(r.localWorkspace, func(pkg *M.Package) error {
+			r.addLocalManifest(pkg)
+			return nil
+		}, r.fs)
+		if err != nil {
+			helpers.SafeDebugLog("[GENERATE_ON_SAVE] Could not create save generator: %v", err)
+			return false
+		}
+		r.saveGenerator = generator
+	}
+	r.saveGenerator.NoteSave(rel)
+	return true
+}
+
+// isGenerateSource reports whether a file, relative to the workspace root,
+// is a source of the manifest. Stylesheets count, since elements may import
+// them; the generate session skips those no module depends on.
+func isGenerateSource(rel string, files, exclude []string) bool {
+	for _, glob := range exclude {
+		if ok, err := doublestar.Match(glob, rel); err == nil && ok {
+			return false
+		}
+	}
+	if strings.HasSuffix(rel, ".css") {
+		return true
+	}
+	for _, glob := range files {
+		if ok, err := doublestar.Match(glob, rel); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// localManifestStale reports whether the local manifest on disk is missing
+// or older than the source file at path
+func (r *Registry) localManifestStale(path string) bool {
+	manifestPath := r.localWorkspace.CustomElementsManifestPath()
+	if manifestPath == "" {
+		return true
+	}
+	if !filepath.IsAbs(manifestPath) {
+		manifestPath = filepath.Join(r.localWorkspace.Root(), manifestPath)
+	}
+	manifest, err := r.fs.Stat(manifestPath)
+	if err != nil {
+		return true
+	}
+	source, err := r.fs.Stat(path)
+	if err != nil {
+		return false
+	}
+	return source.ModTime().After(manifest.ModTime())
+}
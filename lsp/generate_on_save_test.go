@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	W "bennypowers.dev/cem/internal/workspace"
+	"bennypowers.dev/cem/lsp"
+)
+
+// Inline: integration test against a real temp directory, since staleness
+// is judged by file modification times
+
+// setupGenerateOnSaveProject writes the generate-on-save fixture project,
+// without a manifest on disk, and loads it into a registry
+func setupGenerateOnSaveProject(t *testing.T) (string, *lsp.Registry) {
+	t.Helper()
+	tempDir := t.TempDir()
+	fixtureDir := filepath.Join("integration", "generate-on-save")
+	files := map[string]string{
+		"package.json":      "package.json",
+		".config/cem.yaml":  "cem.yaml",
+		"src/my-element.ts": "my-element.ts",
+	}
+	for dest, fixture := range files {
+		path := filepath.Join(tempDir, dest)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, testutil.LoadFixtureFile(t, filepath.Join(fixtureDir, fixture)), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", dest, err)
+		}
+	}
+
+	workspace := W.NewFileSystemWorkspaceContext(tempDir)
+	if err := workspace.Init(); err != nil {
+		t.Fatalf("Failed to initialize workspace: %v", err)
+	}
+	registry, err := lsp.NewRegistryWithDefaults()
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	if err := registry.LoadFromWorkspace(workspace); err != nil {
+		t.Fatalf("Failed to load from workspace: %v", err)
+	}
+	t.Cleanup(func() { _ = registry.StopGenerateWatcher() })
+	return tempDir, registry
+}
+
+func TestGenerateOnSave(t *testing.T) {
+	t.Run("new element appears after save", func(t *testing.T) {
+		tempDir, registry := setupGenerateOnSaveProject(t)
+		if _, ok := registry.Element("new-element"); ok {
+			t.Fatal("Expected new-element to be unknown before it is written")
+		}
+
+		path := filepath.Join(tempDir, "src", "new-element.ts")
+		source := testutil.LoadFixtureFile(t, filepath.Join("integration", "generate-on-save", "new-element.ts"))
+		if err := os.WriteFile(path, source, 0644); err != nil {
+			t.Fatalf("Failed to write new element: %v", err)
+		}
+		if !registry.GenerateOnSave(path) {
+			t.Fatal("Expected generation to be scheduled for a source file without a manifest")
+		}
+
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			if _, ok := registry.Element("new-element"); ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("Timed out waiting for new-element to be generated")
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		if attrs, ok := registry.Attributes("new-element"); !ok || attrs["variant"] == nil {
+			t.Errorf("Expected new-element to have a variant attribute, got %v", attrs)
+		}
+	})
+
+	t.Run("ignores files outside generate.files", func(t *testing.T) {
+		tempDir, registry := setupGenerateOnSaveProject(t)
+		if registry.GenerateOnSave(filepath.Join(tempDir, "demo", "index.ts")) {
+			t.Error("Expected no generation for a file outside generate.files")
+		}
+		if registry.GenerateOnSave(filepath.Join(filepath.Dir(tempDir), "elsewhere.ts")) {
+			t.Error("Expected no generation for a file outside the workspace")
+		}
+	})
+
+	t.Run("skips when the manifest is up to date", func(t *testing.T) {
+		tempDir, registry := setupGenerateOnSaveProject(t)
+		source := filepath.Join(tempDir, "src", "my-element.ts")
+		manifest := filepath.Join(tempDir, "custom-elements.json")
+		if err := os.WriteFile(manifest, []byte(`{"schemaVersion":"2.1.0","modules":[]}`), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+		past := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(source, past, past); err != nil {
+			t.Fatalf("Failed to age source file: %v", err)
+		}
+		if registry.GenerateOnSave(source) {
+			t.Error("Expected no generation when the manifest is newer than the source")
+		}
+	})
+
+	t.Run("no local workspace", func(t *testing.T) {
+		registry, err := lsp.NewRegistryWithDefaults()
+		if err != nil {
+			t.Fatalf("Failed to create registry: %v", err)
+		}
+		if registry.GenerateOnSave("/somewhere/src/my-element.ts") {
+			t.Error("Expected no generation without a local workspace")
+		}
+	})
+}
@@ -50,7 +50,8 @@ type InProcessGenerateWatcher struct {
 	workspace       types.WorkspaceContext
 	globs           []string
 	callback        ManifestUpdateCallback
-	debounceTimer   *time.Timer // Track debounce timer for cleanup
+	onFileChange    func(path string) // Called with each changed source file
+	debounceTimer   *time.Timer       // Track debounce timer for cleanup
 	fsys            platform.FileSystem
 }
 
@@ -167,11 +168,14 @@ func (w *InProcessGenerateWatcher) watchFiles() error {
 	}
 	defer func() { _ = watcher.Close() }()
 
-	// Walk the workspace to find files, filtering out node_modules
+	// Walk the workspace to find files, filtering out node_modules and
+	// files ignored by .gitignore or .cemignore
 	var filesToWatch []string
 	rootDir := w.workspace.Root()
+	rootFS := platform.DirFS(w.fsys, rootDir)
+	matcher := platform.LoadIgnoreMatcher(rootFS, ".", platform.WorkspaceIgnoreFiles...)
 
-	err = platform.WalkDir(platform.DirFS(w.fsys, rootDir), ".", set.NewSet("node_modules"), func(path string, d fs.DirEntry, err error) error {
+	err = platform.WalkDirIgnoring(rootFS, ".", set.NewSet("node_modules"), matcher, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -224,6 +228,9 @@ func (w *InProcessGenerateWatcher) watchFiles() error {
 			// Check if this file matches our globs
 			if w.shouldProcessFile(event.Name) {
 				helpers.SafeDebugLog("File change detected: %s", event.Name)
+				if w.onFileChange != nil {
+					w.onFileChange(event.Name)
+				}
 
 				// Reset and store debounce timer
 				w.mu.Lock()
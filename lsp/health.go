@@ -0,0 +1,64 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HealthCheck reports common workspace misconfigurations which leave the
+// server without elements to complete, each with the steps that fix it.
+// It returns nothing for a healthy workspace.
+func (s *Server) HealthCheck() []string {
+	if s.workspace == nil {
+		return nil
+	}
+	var issues []string
+
+	if pkg, err := s.workspace.PackageJSON(); err == nil && pkg != nil && pkg.CustomElements == "" {
+		issues = append(issues, `package.json has no "customElements" field, so tools can't find the package's manifest. `+
+			`Add "customElements": "custom-elements.json", pointing to the output of cem generate.`)
+	}
+
+	if cfg, err := s.workspace.Config(); err == nil && cfg != nil && len(cfg.Generate.Files) > 0 {
+		if !s.anyFileMatches(cfg.Generate.Files) {
+			issues = append(issues, fmt.Sprintf(
+				"generate.files (%s) matches no files. Check that the patterns are relative to the project root.",
+				strings.Join(cfg.Generate.Files, ", "),
+			))
+		}
+	}
+
+	if s.ElementCount() == 0 {
+		issues = append(issues, "No custom elements were found. Run cem generate to create the project's manifest, "+
+			"or install packages which publish one, then reload the window.")
+	}
+
+	return issues
+}
+
+// anyFileMatches reports whether any of the glob patterns match a file in
+// the workspace
+func (s *Server) anyFileMatches(patterns []string) bool {
+	for _, pattern := range patterns {
+		if matches, err := s.workspace.Glob(pattern); err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
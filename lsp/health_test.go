@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/platform/testutil"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/lsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: integration test, checks which issues are reported
+
+func newHealthServer(t *testing.T, mfs *platform.MapFileSystem) *lsp.Server {
+	t.Helper()
+	wsCtx := testworkspace.NewMapWorkspaceContextFromFS(mfs, "/")
+	require.NoError(t, wsCtx.Init())
+	server, err := lsp.NewServer(wsCtx, lsp.TransportStdio)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+	require.NoError(t, server.InitializeForTesting())
+	return server
+}
+
+func TestHealthCheck_Healthy(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata/integration/registry-priority", "/")
+	server := newHealthServer(t, mfs)
+	assert.Empty(t, server.HealthCheck())
+}
+
+func TestHealthCheck_Misconfigured(t *testing.T) {
+	mfs := platform.NewMapFileSystem(nil)
+	mfs.AddFile("/package.json", `{"name": "my-app", "version": "1.0.0"}`, 0644)
+	mfs.AddFile("/.config/cem.yaml", "generate:\n  files:\n    - elements/**/*.ts\n", 0644)
+	mfs.AddFile("/src/my-element.ts", "export class MyElement extends HTMLElement {}", 0644)
+	server := newHealthServer(t, mfs)
+
+	issues := server.HealthCheck()
+	require.Len(t, issues, 3)
+	assert.Contains(t, issues[0], `"customElements"`)
+	assert.Contains(t, issues[1], "elements/**/*.ts")
+	assert.Contains(t, issues[2], "No custom elements")
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import (
+	"slices"
+	"strings"
+
+	M "bennypowers.dev/cem/manifest"
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// ElementLookup finds custom elements by tag name, e.g. the LSP registry
+type ElementLookup interface {
+	AllTagNames() []string
+	Element(tagName string) (*M.CustomElement, bool)
+}
+
+// CustomizedBuiltIns returns the sorted tag names of the customized built-in
+// elements which extend hostTag, i.e. the valid values of its `is` attribute.
+func CustomizedBuiltIns(elements ElementLookup, hostTag string) []string {
+	if hostTag == "" {
+		return nil
+	}
+	var tagNames []string
+	for _, tagName := range elements.AllTagNames() {
+		if element, ok := elements.Element(tagName); ok && strings.EqualFold(element.Extends, hostTag) {
+			tagNames = append(tagNames, tagName)
+		}
+	}
+	slices.Sort(tagNames)
+	return tagNames
+}
+
+// IsAttribute is an `is` attribute in an HTML document,
+// e.g. `is="fancy-button"` in `<button is="fancy-button">`
+type IsAttribute struct {
+	HostTag   string // The tag name of the element the attribute is on
+	Value     string // The attribute value, without quotes
+	StartByte uint   // Start of the value, without quotes
+	EndByte   uint   // End of the value, without quotes
+}
+
+// FindIsAttributes finds the `is` attributes in an HTML syntax tree
+func FindIsAttributes(root *ts.Node, content []byte) []IsAttribute {
+	var result []IsAttribute
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		if kind := node.Kind(); kind == "start_tag" || kind == "self_closing_tag" {
+			if attr, ok := tagIsAttribute(node, content); ok {
+				result = append(result, attr)
+			}
+			return
+		}
+		for i := range node.NamedChildCount() {
+			if child := node.NamedChild(i); child != nil {
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+	return result
+}
+
+// tagIsAttribute returns the `is` attribute of a start_tag or self_closing_tag node
+func tagIsAttribute(tag *ts.Node, content []byte) (IsAttribute, bool) {
+	var hostTag string
+	for i := range tag.NamedChildCount() {
+		child := tag.NamedChild(i)
+		if child == nil {
+			continue
+		}
+		switch child.Kind() {
+		case "tag_name":
+			hostTag = strings.ToLower(child.Utf8Text(content))
+		case "attribute":
+			attr, ok := isAttributeValue(child, content)
+			if ok {
+				attr.HostTag = hostTag
+				return attr, true
+			}
+		}
+	}
+	return IsAttribute{}, false
+}
+
+// isAttributeValue returns the value of an attribute node, if it is an `is` attribute
+func isAttributeValue(attribute *ts.Node, content []byte) (IsAttribute, bool) {
+	var isAttr bool
+	for i := range attribute.NamedChildCount() {
+		child := attribute.NamedChild(i)
+		if child == nil {
+			continue
+		}
+		switch child.Kind() {
+		case "attribute_name":
+			isAttr = strings.EqualFold(child.Utf8Text(content), "is")
+		case "attribute_value":
+			if isAttr {
+				return IsAttribute{Value: child.Utf8Text(content), StartByte: child.StartByte(), EndByte: child.EndByte()}, true
+			}
+		case "quoted_attribute_value":
+			if !isAttr {
+				continue
+			}
+			// The value node is absent for empty quotes, e.g. is=""
+			if value := child.NamedChild(0); value != nil {
+				return IsAttribute{Value: value.Utf8Text(content), StartByte: value.StartByte(), EndByte: value.EndByte()}, true
+			}
+			return IsAttribute{StartByte: child.StartByte() + 1, EndByte: child.StartByte() + 1}, true
+		}
+	}
+	return IsAttribute{}, false
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import (
+	"regexp"
+	"slices"
+
+	M "bennypowers.dev/cem/manifest"
+)
+
+// DeclarationLookup finds custom element declarations by tag name, e.g. the
+// LSP registry
+type DeclarationLookup interface {
+	AllTagNames() []string
+	FindCustomElementDeclaration(tagName string) *M.CustomElementDeclaration
+}
+
+var reasonTagNamePattern = regexp.MustCompile(`[a-z][a-z0-9._]*-[a-z0-9._-]*[a-z0-9]`)
+
+// DeprecationReplacement returns the element which replaces a deprecated
+// element: the one current element which its deprecation reason names, e.g.
+// "my-button" in "Use <my-button> instead". It reports false when the
+// element is not deprecated, or its reason names no single replacement.
+func DeprecationReplacement(elements DeclarationLookup, tagName string) (string, bool) {
+	decl := elements.FindCustomElementDeclaration(tagName)
+	if decl == nil || !decl.IsDeprecated() {
+		return "", false
+	}
+	reason, ok := decl.Deprecated.Value().(string)
+	if !ok {
+		return "", false
+	}
+	known := elements.AllTagNames()
+	var candidates []string
+	for _, name := range reasonTagNamePattern.FindAllString(reason, -1) {
+		if name == tagName || slices.Contains(candidates, name) || !slices.Contains(known, name) {
+			continue
+		}
+		if replacement := elements.FindCustomElementDeclaration(name); replacement != nil && replacement.IsDeprecated() {
+			continue
+		}
+		candidates = append(candidates, name)
+	}
+	if len(candidates) != 1 {
+		return "", false
+	}
+	return candidates[0], true
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// FileKind classifies a workspace file by the role it plays in the project.
+type FileKind string
+
+const (
+	FileKindSource FileKind = "source"
+	FileKindTest   FileKind = "test"
+	FileKindStory  FileKind = "story"
+	FileKindDemo   FileKind = "demo"
+)
+
+// DefaultTestGlobs match common test file conventions, with any extension,
+// e.g. `*.test.ts`, `*.spec.tsx`, or web-test-runner's `*.test.html`.
+var DefaultTestGlobs = []string{
+	"**/*.test.*",
+	"**/*.spec.*",
+	"**/test/**",
+	"**/tests/**",
+	"**/__tests__/**",
+}
+
+// DefaultStoryGlobs match Storybook-style story files.
+var DefaultStoryGlobs = []string{
+	"**/*.stories.*",
+	"**/*.story.*",
+}
+
+// DefaultDemoGlobs match common demo directory conventions.
+var DefaultDemoGlobs = []string{
+	"**/demo/**",
+	"**/demos/**",
+}
+
+// ClassifyFile returns the kind of the file at relPath, a slash- or
+// OS-separated path relative to the workspace root. Nil glob lists fall back
+// to the defaults; tests take precedence over stories, and stories over demos.
+func ClassifyFile(relPath string, testGlobs, storyGlobs, demoGlobs []string) FileKind {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+	switch {
+	case matchesAnyGlob(relPath, orDefault(testGlobs, DefaultTestGlobs)):
+		return FileKindTest
+	case matchesAnyGlob(relPath, orDefault(storyGlobs, DefaultStoryGlobs)):
+		return FileKindStory
+	case matchesAnyGlob(relPath, orDefault(demoGlobs, DefaultDemoGlobs)):
+		return FileKindDemo
+	default:
+		return FileKindSource
+	}
+}
+
+func orDefault(globs, defaults []string) []string {
+	if globs == nil {
+		return defaults
+	}
+	return globs
+}
+
+func matchesAnyGlob(path string, globs []string) bool {
+	for _, glob := range globs {
+		if ok, err := doublestar.Match(glob, path); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
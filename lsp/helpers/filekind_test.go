@@ -0,0 +1,74 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import (
+	"testing"
+)
+
+// Inline: pure function, table-driven
+
+func TestClassifyFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		relPath    string
+		testGlobs  []string
+		storyGlobs []string
+		demoGlobs  []string
+		expected   FileKind
+	}{
+		{name: "source file", relPath: "src/my-button.ts", expected: FileKindSource},
+		{name: "test suffix", relPath: "src/my-button.test.ts", expected: FileKindTest},
+		{name: "spec suffix", relPath: "src/my-button.spec.js", expected: FileKindTest},
+		{name: "web-test-runner html test", relPath: "src/my-button.test.html", expected: FileKindTest},
+		{name: "tsx spec", relPath: "src/my-button.spec.tsx", expected: FileKindTest},
+		{name: "commonjs test", relPath: "src/my-button.test.cjs", expected: FileKindTest},
+		{name: "test directory", relPath: "test/fixtures/index.html", expected: FileKindTest},
+		{name: "story file", relPath: "src/my-button.stories.ts", expected: FileKindStory},
+		{name: "mdx story file", relPath: "src/my-button.stories.mdx", expected: FileKindStory},
+		{name: "demo directory", relPath: "elements/my-button/demo/index.html", expected: FileKindDemo},
+		{name: "leading dot slash", relPath: "./src/my-button.test.ts", expected: FileKindTest},
+		{name: "test wins over demo", relPath: "demo/my-button.test.ts", expected: FileKindTest},
+		{
+			name:      "custom test globs replace defaults",
+			relPath:   "src/my-button.test.ts",
+			testGlobs: []string{"e2e/**"},
+			expected:  FileKindSource,
+		},
+		{
+			name:      "custom test globs match",
+			relPath:   "e2e/button.html",
+			testGlobs: []string{"e2e/**"},
+			expected:  FileKindTest,
+		},
+		{
+			name:      "empty demo globs disable demos",
+			relPath:   "demo/index.html",
+			demoGlobs: []string{},
+			expected:  FileKindSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyFile(tt.relPath, tt.testGlobs, tt.storyGlobs, tt.demoGlobs)
+			if got != tt.expected {
+				t.Errorf("ClassifyFile(%q) = %q, want %q", tt.relPath, got, tt.expected)
+			}
+		})
+	}
+}
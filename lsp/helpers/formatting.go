@@ -0,0 +1,52 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import "regexp"
+
+var (
+	attributeQuotePattern = regexp.MustCompile(`\s[\w:@.-]+=(["'])`)
+	importQuotePattern    = regexp.MustCompile(`\b(?:import|from)\s*(["'])`)
+)
+
+// AttributeQuote returns the quote which most attribute values in an HTML
+// document use, or a double quote if they use neither more
+func AttributeQuote(content string) string {
+	return prevailingQuote(attributeQuotePattern, content)
+}
+
+// ImportQuote returns the quote which most module specifiers in the
+// document's import statements use, or a double quote if they use neither
+// more
+func ImportQuote(content string) string {
+	return prevailingQuote(importQuotePattern, content)
+}
+
+func prevailingQuote(pattern *regexp.Regexp, content string) string {
+	single, double := 0, 0
+	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+		if match[1] == "'" {
+			single++
+		} else {
+			double++
+		}
+	}
+	if single > double {
+		return "'"
+	}
+	return `"`
+}
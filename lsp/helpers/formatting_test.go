@@ -0,0 +1,59 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import "testing"
+
+func TestAttributeQuote(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"double", `<my-button variant="primary" size="sm"></my-button>`, `"`},
+		{"single", `<my-button variant='primary' size='sm' label="Go"></my-button>`, "'"},
+		{"lit bindings", `<my-button @click='${x}' .value='${y}'></my-button>`, "'"},
+		{"none", `<my-button disabled></my-button>`, `"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AttributeQuote(tt.content); got != tt.want {
+				t.Errorf("AttributeQuote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportQuote(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"double", `import "./a.js";` + "\n" + `import { b } from "./b.js";`, `"`},
+		{"single", "import { html } from 'lit';\nimport './a.js';", "'"},
+		{"multiline", "import {\n  customElement,\n} from 'lit/decorators.js';", "'"},
+		{"none", "export const x = 1;", `"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ImportQuote(tt.content); got != tt.want {
+				t.Errorf("ImportQuote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,3 +27,26 @@ func IsCustomElementTag(tagName string) bool {
 	firstChar := tagName[0]
 	return firstChar >= 'a' && firstChar <= 'z'
 }
+
+// vueBindingPrefixes map the prefixes of Vue template bindings to the
+// equivalent Lit binding prefixes, longest first
+var vueBindingPrefixes = []struct{ vue, lit string }{
+	{"v-on:", "@"},
+	{"v-bind:", "."},
+	{"@", "@"},
+	{":", "."},
+}
+
+// VueBinding splits a Vue template binding, like @change, v-on:change,
+// :value, or v-bind:value, into the typed Vue prefix, the equivalent Lit
+// binding prefix ("@" or "."), and the bound event or property name without
+// modifiers. The name is empty while only the prefix is typed.
+func VueBinding(attrName string) (vuePrefix, litPrefix, name string, ok bool) {
+	for _, p := range vueBindingPrefixes {
+		if rest, found := strings.CutPrefix(attrName, p.vue); found {
+			name, _, _ = strings.Cut(rest, ".")
+			return p.vue, p.lit, name, true
+		}
+	}
+	return "", "", "", false
+}
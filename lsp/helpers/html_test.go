@@ -32,3 +32,33 @@ func TestIsCustomElementTag(t *testing.T) {
 		})
 	}
 }
+
+func TestVueBinding(t *testing.T) {
+	tests := []struct {
+		attrName  string
+		vuePrefix string
+		litPrefix string
+		name      string
+		ok        bool
+	}{
+		{"@change", "@", "@", "change", true},
+		{"@change.once", "@", "@", "change", true},
+		{"v-on:my-event", "v-on:", "@", "my-event", true},
+		{":value", ":", ".", "value", true},
+		{"v-bind:max-count", "v-bind:", ".", "max-count", true},
+		{":value.prop", ":", ".", "value", true},
+		{"@", "@", "@", "", true},
+		{"variant", "", "", "", false},
+		{"v-if", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.attrName, func(t *testing.T) {
+			vuePrefix, litPrefix, name, ok := VueBinding(tt.attrName)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.vuePrefix, vuePrefix)
+			assert.Equal(t, tt.litPrefix, litPrefix)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
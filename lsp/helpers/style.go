@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import (
+	"regexp"
+	"strings"
+)
+
+// StyleDeclaration is a declaration in an inline style attribute
+type StyleDeclaration struct {
+	Property string
+	Value    string
+}
+
+// ParseStyleDeclarations splits an inline style, like
+// "--size: large; color: red", into its declarations. Declarations without
+// a colon are skipped.
+func ParseStyleDeclarations(style string) []StyleDeclaration {
+	var declarations []StyleDeclaration
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		property = strings.TrimSpace(property)
+		if !ok || property == "" {
+			continue
+		}
+		declarations = append(declarations, StyleDeclaration{
+			Property: property,
+			Value:    strings.TrimSpace(value),
+		})
+	}
+	return declarations
+}
+
+var cssIdentPattern = regexp.MustCompile(`^-?[a-zA-Z_][\w-]*$`)
+
+// CSSSyntaxKeywords returns the keywords a custom property's syntax allows,
+// e.g. ["small", "large"] for "small | large". It returns nil when the
+// syntax also admits other values, e.g. "<length> | auto" or "*".
+func CSSSyntaxKeywords(syntax string) []string {
+	if strings.TrimSpace(syntax) == "" {
+		return nil
+	}
+	var keywords []string
+	for _, keyword := range strings.Split(syntax, "|") {
+		keyword = strings.TrimSpace(keyword)
+		if !cssIdentPattern.MatchString(keyword) {
+			return nil
+		}
+		keywords = append(keywords, keyword)
+	}
+	return keywords
+}
+
+// IsUncheckableCSSValue reports whether a value can't be checked against a
+// custom property's syntax statically: CSS-wide keywords, which every
+// property accepts, and values which compute elsewhere, like var() or calc()
+func IsUncheckableCSSValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "inherit", "initial", "unset", "revert", "revert-layer":
+		return true
+	}
+	return strings.Contains(value, "(") || strings.Contains(value, "${") || strings.Contains(value, "{{")
+}
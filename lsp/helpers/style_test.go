@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package helpers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseStyleDeclarations(t *testing.T) {
+	got := ParseStyleDeclarations(" --size: large ;color:red;; broken ")
+	want := []StyleDeclaration{
+		{Property: "--size", Value: "large"},
+		{Property: "color", Value: "red"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseStyleDeclarations() = %v, want %v", got, want)
+	}
+}
+
+func TestCSSSyntaxKeywords(t *testing.T) {
+	tests := []struct {
+		name   string
+		syntax string
+		want   []string
+	}{
+		{"keywords", "small | medium | large", []string{"small", "medium", "large"}},
+		{"single keyword", "auto", []string{"auto"}},
+		{"data type", "<length>", nil},
+		{"keyword or data type", "<length> | auto", nil},
+		{"universal", "*", nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CSSSyntaxKeywords(tt.syntax); !slices.Equal(got, tt.want) {
+				t.Errorf("CSSSyntaxKeywords(%q) = %v, want %v", tt.syntax, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUncheckableCSSValue(t *testing.T) {
+	for _, value := range []string{"inherit", "var(--size)", "calc(1px + 2px)"} {
+		if !IsUncheckableCSSValue(value) {
+			t.Errorf("Expected %q to be uncheckable", value)
+		}
+	}
+	if IsUncheckableCSSValue("large") {
+		t.Error("Expected a keyword to be checkable")
+	}
+}
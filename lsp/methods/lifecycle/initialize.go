@@ -19,10 +19,12 @@ package lifecycle
 import (
 	"encoding/json"
 	"os"
+	"slices"
 
 	"bennypowers.dev/cem/internal/logging"
 	"bennypowers.dev/cem/internal/version"
 	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/methods/workspace/executeCommand"
 	"bennypowers.dev/cem/lsp/types"
 	"go.lsp.dev/protocol"
 )
@@ -106,6 +108,8 @@ func Initialize(ctx types.ServerContext, params *protocol.InitializeParams) (*pr
 		helpers.SafeDebugLog("[INITIALIZE] Client uses push diagnostics (LSP 3.16)")
 	}
 
+	ctx.SetEditCapabilities(editCapabilities(params.Capabilities))
+
 	openClose := true
 	changeKind := protocol.TextDocumentSyncKindIncremental
 	resolveProvider := true
@@ -114,21 +118,31 @@ func Initialize(ctx types.ServerContext, params *protocol.InitializeParams) (*pr
 	capabilities.TextDocumentSync = &protocol.TextDocumentSyncOptions{
 		OpenClose: &openClose,
 		Change:    &changeKind,
+		Save:      &protocol.SaveOptions{},
 	}
 	capabilities.HoverProvider = &protocol.HoverOptions{}
 	capabilities.CompletionProvider = &protocol.CompletionOptions{
-		TriggerCharacters: []string{"<", "=", "\"", "@", ".", "?"},
+		TriggerCharacters: []string{"<", "=", "\"", "'", "@", ".", "?"},
 		ResolveProvider:   &resolveProvider,
 	}
 	capabilities.DefinitionProvider = &protocol.DefinitionOptions{}
 	capabilities.ReferencesProvider = &protocol.ReferenceOptions{}
+	capabilities.DocumentHighlightProvider = &protocol.DocumentHighlightOptions{}
 	capabilities.CodeActionProvider = &protocol.CodeActionOptions{
 		CodeActionKinds: []protocol.CodeActionKind{
 			protocol.CodeActionKindQuickFix,
+			protocol.CodeActionKindRefactorRewrite,
 		},
+		ResolveProvider: &resolveProvider,
 	}
 	capabilities.WorkspaceSymbolProvider = &protocol.WorkspaceSymbolOptions{}
 	capabilities.InlayHintProvider = &protocol.InlayHintOptions{}
+	capabilities.CodeLensProvider = &protocol.CodeLensOptions{
+		ResolveProvider: &resolveProvider,
+	}
+	capabilities.ExecuteCommandProvider = protocol.ExecuteCommandOptions{
+		Commands: executeCommand.Commands,
+	}
 
 	if ctx.UsePullDiagnostics() {
 		identifier := "cem"
@@ -196,3 +210,18 @@ func parseStringSlice(v any) []string {
 	}
 	return nil
 }
+
+// editCapabilities reads the client's workspace edit and code action resolve
+// support from its capabilities
+func editCapabilities(capabilities protocol.ClientCapabilities) types.EditCapabilities {
+	var result types.EditCapabilities
+	if workspace := capabilities.Workspace; workspace != nil && workspace.WorkspaceEdit != nil {
+		edit := workspace.WorkspaceEdit
+		result.DocumentChanges = edit.DocumentChanges != nil && *edit.DocumentChanges
+		result.ChangeAnnotations = edit.ChangeAnnotationSupport != nil
+	}
+	if textDocument := capabilities.TextDocument; textDocument != nil && textDocument.CodeAction != nil {
+		result.ResolveEdits = slices.Contains(textDocument.CodeAction.ResolveSupport.Properties, "edit")
+	}
+	return result
+}
@@ -17,15 +17,47 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package lifecycle
 
 import (
+	"context"
+	"strings"
+
 	"bennypowers.dev/cem/internal/logging"
 	"bennypowers.dev/cem/lsp/types"
 	"go.lsp.dev/protocol"
 )
 
+// healthChecker reports workspace misconfigurations found at startup
+type healthChecker interface {
+	HealthCheck() []string
+}
+
 // Initialized handles the LSP initialized notification.
 // Manifest loading now happens during Initialize (the request) to avoid
 // races with AsyncHandler's concurrent dispatch.
-func Initialized(_ types.ServerContext, _ *protocol.InitializedParams) error {
+func Initialized(ctx types.ServerContext, _ *protocol.InitializedParams) error {
 	logging.Info("CEM LSP is early software. Report issues at: https://github.com/bennypowers/cem/issues")
-	return nil
+	return reportHealth(ctx)
+}
+
+// reportHealth shows the user any misconfigurations which leave the server
+// without elements, rather than silently offering no completions
+func reportHealth(ctx types.ServerContext) error {
+	checker, ok := ctx.(healthChecker)
+	if !ok {
+		return nil
+	}
+	issues := checker.HealthCheck()
+	if len(issues) == 0 {
+		return nil
+	}
+	for _, issue := range issues {
+		logging.Warning("%s", issue)
+	}
+	client := ctx.Client()
+	if client == nil {
+		return nil
+	}
+	return client.ShowMessage(context.Background(), &protocol.ShowMessageParams{
+		Type:    protocol.MessageTypeWarning,
+		Message: "Custom elements language server: check your workspace setup.\n- " + strings.Join(issues, "\n- "),
+	})
 }
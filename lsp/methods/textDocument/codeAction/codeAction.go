@@ -29,6 +29,10 @@ func CodeAction(ctx types.ServerContext, params *protocol.CodeActionParams) ([]p
 	helpers.SafeDebugLog("[CODE_ACTION] Starting code action for %s", params.TextDocument.URI)
 
 	var actions []protocol.CodeAction
+	// Suppressions follow the fixes, which are listed first
+	var suppressions []protocol.CodeAction
+	// Deprecated elements with a known replacement share one workspace fix
+	var deprecations []protocol.Diagnostic
 
 	docURI := string(params.TextDocument.URI)
 
@@ -37,6 +41,9 @@ func CodeAction(ctx types.ServerContext, params *protocol.CodeActionParams) ([]p
 		if !hasSource || source != "cem-lsp" {
 			continue
 		}
+		if action := createSuppressionAction(ctx, &diagnostic, docURI); action != nil {
+			suppressions = append(suppressions, *action)
+		}
 		if len(diagnostic.Data) == 0 {
 			continue
 		}
@@ -61,6 +68,21 @@ func CodeAction(ctx types.ServerContext, params *protocol.CodeActionParams) ([]p
 				actions = append(actions, *action)
 				helpers.SafeDebugLog("[CODE_ACTION] Created tag autofix action")
 			}
+			fixAll, err := createTagFixAllAction(ctx, &diagnostic, dataMap)
+			if err != nil {
+				return nil, err
+			}
+			if fixAll != nil {
+				actions = append(actions, *fixAll)
+				helpers.SafeDebugLog("[CODE_ACTION] Created workspace tag autofix action")
+			}
+		case "deprecated-element":
+			action := createDeprecatedElementAction(ctx, &diagnostic, dataMap, docURI)
+			if action != nil {
+				actions = append(actions, *action)
+				deprecations = append(deprecations, diagnostic)
+				helpers.SafeDebugLog("[CODE_ACTION] Created deprecated element autofix action")
+			}
 		case "missing-import":
 			action, err := CreateMissingImportAction(ctx, &diagnostic, dataMap, docURI)
 			if err != nil {
@@ -82,6 +104,18 @@ func CodeAction(ctx types.ServerContext, params *protocol.CodeActionParams) ([]p
 				actions = append(actions, *action)
 				helpers.SafeDebugLog("[CODE_ACTION] Created attribute value autofix action")
 			}
+		case "self-closing-custom-element":
+			action := createSelfClosingAutofixAction(&diagnostic, dataMap, docURI)
+			if action != nil {
+				actions = append(actions, *action)
+				helpers.SafeDebugLog("[CODE_ACTION] Created self-closing custom element autofix action")
+			}
+		case "missing-slot":
+			action := createMissingSlotAutofixAction(&diagnostic, dataMap, docURI)
+			if action != nil {
+				actions = append(actions, *action)
+				helpers.SafeDebugLog("[CODE_ACTION] Created missing slot autofix action")
+			}
 		case "css-ambiguous-comment":
 			cssActions := createCSSAmbiguousCommentActions(&diagnostic, dataMap, docURI)
 			actions = append(actions, cssActions...)
@@ -89,6 +123,23 @@ func CodeAction(ctx types.ServerContext, params *protocol.CodeActionParams) ([]p
 		}
 	}
 
+	if len(deprecations) > 0 {
+		fixAll, err := createDeprecationsFixAllAction(ctx, deprecations)
+		if err != nil {
+			return nil, err
+		}
+		if fixAll != nil {
+			actions = append(actions, *fixAll)
+			helpers.SafeDebugLog("[CODE_ACTION] Created workspace deprecated element autofix action")
+		}
+	}
+
+	if documentation := createDocumentationActions(ctx, params); len(documentation) > 0 {
+		actions = append(actions, documentation...)
+		helpers.SafeDebugLog("[CODE_ACTION] Created %d documentation actions", len(documentation))
+	}
+
+	actions = append(actions, suppressions...)
 	helpers.SafeDebugLog("[CODE_ACTION] Returning %d code actions", len(actions))
 	return actions, nil
 }
@@ -232,6 +232,28 @@ func TestCodeActionIsPreferred(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "self-closing custom element",
+			data: map[string]any{
+				"type": "self-closing-custom-element", "original": "<my-icon />", "suggestion": "<my-icon></my-icon>",
+				"tagName": "my-icon",
+				"range": map[string]any{
+					"start": map[string]any{"line": float64(0), "character": float64(0)},
+					"end":   map[string]any{"line": float64(0), "character": float64(11)},
+				},
+			},
+		},
+		{
+			name: "missing slot",
+			data: map[string]any{
+				"type": "missing-slot", "original": "header", "suggestion": `<span slot="header"></span>`,
+				"tagName": "my-card",
+				"range": map[string]any{
+					"start": map[string]any{"line": float64(0), "character": float64(9)},
+					"end":   map[string]any{"line": float64(0), "character": float64(9)},
+				},
+			},
+		},
 		{
 			name: "missing import",
 			data: map[string]any{
@@ -0,0 +1,347 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"bennypowers.dev/cem/internal/languages"
+	"bennypowers.dev/cem/internal/languages/typescript"
+	"bennypowers.dev/cem/internal/textutil"
+	"bennypowers.dev/cem/lsp/types"
+	ts "github.com/tree-sitter/go-tree-sitter"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+// litPropertyTypes maps the `type` option of Lit's `@property()` decorator
+// to the field's type, for JavaScript fields without type annotations
+var litPropertyTypes = map[string]string{
+	"String":  "string",
+	"Number":  "number",
+	"Boolean": "boolean",
+	"Array":   "array",
+	"Object":  "object",
+}
+
+var (
+	litPropertyTypeOption = regexp.MustCompile(`\btype\s*:\s*(\w+)`)
+	slotNameAttribute     = regexp.MustCompile(`\bname\s*=\s*["']([^"']*)["']`)
+)
+
+// createDocumentationActions offers to document the undocumented API at the
+// start of the requested range in a TypeScript or JavaScript module: a Lit
+// `@property()` field, a dispatched event, or a `<slot>` in a template.
+// Fields and events get JSDoc stubs, and slots get an HTML comment, in the
+// syntax `cem generate` reads.
+func createDocumentationActions(ctx types.ServerContext, params *protocol.CodeActionParams) []protocol.CodeAction {
+	docURI := string(params.TextDocument.URI)
+	doc := ctx.Document(docURI)
+	if doc == nil || doc.Language() != "typescript" {
+		return nil
+	}
+	content, err := doc.Content()
+	if err != nil {
+		return nil
+	}
+
+	code := []byte(content)
+	parser := typescript.BorrowParser()
+	defer typescript.ReturnParser(parser)
+	tree := parser.Parse(code, nil)
+	if tree == nil {
+		return nil
+	}
+	defer tree.Close()
+
+	offset := positionToByteOffset(content, params.Range.Start)
+	node := tree.RootNode().NamedDescendantForByteRange(offset, offset)
+
+	var edit *protocol.TextEdit
+	var title string
+walk:
+	for n := node; n != nil && edit == nil; n = n.Parent() {
+		switch n.Kind() {
+		case "public_field_definition":
+			title, edit = documentPropertyEdit(n, code, content, doc)
+			break walk
+		case "template_string":
+			title, edit = documentSlotEdit(n, offset, code, content, doc)
+			break walk
+		case "call_expression":
+			// Keep looking outwards, e.g. from a call in the event's detail
+			title, edit = documentEventEdit(n, code, content, doc)
+		case "class_body", "program":
+			break walk
+		}
+	}
+	if edit == nil {
+		return nil
+	}
+
+	kind := protocol.CodeActionKindRefactorRewrite
+	return []protocol.CodeAction{{
+		Title: title,
+		Kind:  &kind,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[urilib.URI][]protocol.TextEdit{
+				urilib.URI(docURI): {*edit},
+			},
+		},
+	}}
+}
+
+// documentPropertyEdit inserts a JSDoc stub above an undocumented Lit
+// `@property()` field. JavaScript fields get an `@type` tag from the
+// decorator's `type` option.
+func documentPropertyEdit(field *ts.Node, code []byte, content string, doc types.Document) (string, *protocol.TextEdit) {
+	var options string
+	isProperty := false
+	for i := range field.NamedChildCount() {
+		child := field.NamedChild(i)
+		if child.Kind() != "decorator" {
+			continue
+		}
+		call := child.NamedChild(0)
+		if call == nil || call.Kind() != "call_expression" {
+			continue
+		}
+		if fn := call.ChildByFieldName("function"); fn != nil && fn.Utf8Text(code) == "property" {
+			isProperty = true
+			if args := call.ChildByFieldName("arguments"); args != nil {
+				options = args.Utf8Text(code)
+			}
+		}
+	}
+	name := field.ChildByFieldName("name")
+	if !isProperty || name == nil || jsdocBefore(field, code) != nil {
+		return "", nil
+	}
+
+	propertyName := name.Utf8Text(code)
+	lines := []string{fmt.Sprintf("TODO: describe the `%s` property", propertyName)}
+	if field.ChildByFieldName("type") == nil {
+		if match := litPropertyTypeOption.FindStringSubmatch(options); match != nil {
+			if t, ok := litPropertyTypes[match[1]]; ok {
+				lines = append(lines, fmt.Sprintf("@type {%s}", t))
+			}
+		}
+	}
+	return fmt.Sprintf("Document property `%s`", propertyName), insertJSDocEdit(field, lines, content, doc)
+}
+
+// documentEventEdit adds an `@fires` tag for an undocumented event to the
+// JSDoc of the class which dispatches it, e.g.
+// `this.dispatchEvent(new CustomEvent('change'))`
+func documentEventEdit(call *ts.Node, code []byte, content string, doc types.Document) (string, *protocol.TextEdit) {
+	fn := call.ChildByFieldName("function")
+	if fn == nil || fn.Kind() != "member_expression" {
+		return "", nil
+	}
+	if property := fn.ChildByFieldName("property"); property == nil || property.Utf8Text(code) != "dispatchEvent" {
+		return "", nil
+	}
+	args := call.ChildByFieldName("arguments")
+	if args == nil || args.NamedChildCount() == 0 {
+		return "", nil
+	}
+	event := args.NamedChild(0)
+	if event.Kind() != "new_expression" {
+		return "", nil
+	}
+	constructor := event.ChildByFieldName("constructor")
+	eventArgs := event.ChildByFieldName("arguments")
+	if constructor == nil || eventArgs == nil || eventArgs.NamedChildCount() == 0 {
+		return "", nil
+	}
+	nameArg := eventArgs.NamedChild(0)
+	if nameArg.Kind() != "string" {
+		return "", nil
+	}
+	eventName := strings.Trim(nameArg.Utf8Text(code), `"'`)
+	eventType := constructor.Utf8Text(code)
+
+	class := enclosingClass(call)
+	if class == nil {
+		return "", nil
+	}
+	tag := regexp.MustCompile(`@(?:fires|event)\s+(?:\{[^}]*\}\s*)?` + regexp.QuoteMeta(eventName) + `(?:\s|$)`)
+	if comment := jsdocBefore(class, code); comment != nil && tag.MatchString(comment.Utf8Text(code)) {
+		return "", nil
+	}
+
+	line := fmt.Sprintf("@fires {%s} %s - TODO: describe the `%s` event", eventType, eventName, eventName)
+	return fmt.Sprintf("Document event `%s`", eventName), addJSDocTagEdit(class, line, code, content, doc)
+}
+
+// documentSlotEdit inserts an HTML comment before an undocumented `<slot>`
+// in a template. Slots documented by an `@slot` tag in the class' JSDoc are
+// skipped.
+func documentSlotEdit(template *ts.Node, offset uint, code []byte, content string, doc types.Document) (string, *protocol.TextEdit) {
+	if _, ok := languages.TemplateSyntaxForNode(template, code); !ok {
+		return "", nil
+	}
+	start := template.StartByte()
+	text := string(code[start:template.EndByte()])
+	rel := int(offset - start)
+
+	// The cursor may be anywhere in the slot's start tag
+	search := text[:min(rel+len("<slot"), len(text))]
+	tagStart := strings.LastIndex(search, "<slot")
+	if tagStart < 0 || tagStart+len("<slot") >= len(text) {
+		return "", nil
+	}
+	if next := text[tagStart+len("<slot")]; next != ' ' && next != '>' && next != '/' && next != '\n' && next != '\t' {
+		return "", nil
+	}
+	tagEnd := strings.Index(text[tagStart:], ">")
+	if tagEnd < 0 || tagStart+tagEnd < rel {
+		return "", nil
+	}
+
+	slotName := ""
+	if match := slotNameAttribute.FindStringSubmatch(text[tagStart : tagStart+tagEnd]); match != nil {
+		slotName = match[1]
+	}
+
+	// A plain HTML comment immediately before the slot documents it
+	if strings.HasSuffix(strings.TrimRight(text[:tagStart], " \t\r\n"), "-->") {
+		return "", nil
+	}
+	tagPattern := `@slot\s+-`
+	description := "TODO: describe the default slot"
+	title := "Document the default slot"
+	if slotName != "" {
+		tagPattern = `@slot\s+` + regexp.QuoteMeta(slotName) + `(?:\s|$)`
+		description = fmt.Sprintf("TODO: describe the %s slot", slotName)
+		title = fmt.Sprintf("Document slot `%s`", slotName)
+	}
+	if class := enclosingClass(template); class != nil {
+		if comment := jsdocBefore(class, code); comment != nil && regexp.MustCompile(tagPattern).MatchString(comment.Utf8Text(code)) {
+			return "", nil
+		}
+	}
+
+	insertAt := start + uint(tagStart)
+	lineStart := uint(strings.LastIndexByte(content[:insertAt], '\n') + 1)
+	newText := "<!-- " + description + " -->"
+	if indent := content[lineStart:insertAt]; strings.TrimSpace(indent) == "" {
+		// The slot starts its line, so the comment gets a line of its own
+		newText += "\n" + indent
+	} else {
+		newText += " "
+	}
+	position := doc.ByteRangeToProtocolRange(content, insertAt, insertAt)
+	return title, &protocol.TextEdit{Range: position, NewText: newText}
+}
+
+// insertJSDocEdit inserts a JSDoc comment with the given lines on the line
+// above a node, at the node's indentation
+func insertJSDocEdit(node *ts.Node, lines []string, content string, doc types.Document) *protocol.TextEdit {
+	lineStart := uint(strings.LastIndexByte(content[:node.StartByte()], '\n') + 1)
+	indent := content[lineStart:node.StartByte()]
+	if strings.TrimSpace(indent) != "" {
+		indent = leadingWhitespace(indent)
+	}
+
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		b.WriteString(indent + " * " + line + "\n")
+	}
+	b.WriteString(indent + " */\n")
+	return &protocol.TextEdit{
+		Range:   doc.ByteRangeToProtocolRange(content, lineStart, lineStart),
+		NewText: b.String(),
+	}
+}
+
+// addJSDocTagEdit adds a tag line to a class' JSDoc, or gives the class a
+// JSDoc comment with the tag if it has none
+func addJSDocTagEdit(class *ts.Node, line string, code []byte, content string, doc types.Document) *protocol.TextEdit {
+	comment := jsdocBefore(class, code)
+	if comment == nil {
+		if parent := class.Parent(); parent != nil && parent.Kind() == "export_statement" {
+			class = parent
+		}
+		return insertJSDocEdit(class, []string{line}, content, doc)
+	}
+
+	lineStart := uint(strings.LastIndexByte(content[:comment.StartByte()], '\n') + 1)
+	indent := leadingWhitespace(content[lineStart:comment.StartByte()])
+	closing := comment.EndByte() - uint(len("*/"))
+	closingLineStart := uint(strings.LastIndexByte(content[:closing], '\n') + 1)
+
+	var newText string
+	if closingLineStart > comment.StartByte() && strings.TrimSpace(content[closingLineStart:closing]) == "" {
+		// The comment closes on a line of its own, so add the tag above it
+		newText = "* " + line + "\n" + indent + " "
+	} else {
+		newText = "\n" + indent + " * " + line + "\n" + indent + " "
+	}
+	return &protocol.TextEdit{
+		Range:   doc.ByteRangeToProtocolRange(content, closing, closing),
+		NewText: newText,
+	}
+}
+
+// jsdocBefore returns the JSDoc comment which documents a declaration,
+// looking past its export statement
+func jsdocBefore(node *ts.Node, code []byte) *ts.Node {
+	if parent := node.Parent(); parent != nil && parent.Kind() == "export_statement" {
+		node = parent
+	}
+	prev := node.PrevSibling()
+	if prev == nil || prev.Kind() != "comment" || !strings.HasPrefix(prev.Utf8Text(code), "/**") {
+		return nil
+	}
+	return prev
+}
+
+// enclosingClass returns the class declaration which contains a node
+func enclosingClass(node *ts.Node) *ts.Node {
+	for n := node.Parent(); n != nil; n = n.Parent() {
+		switch n.Kind() {
+		case "class_declaration", "abstract_class_declaration":
+			return n
+		}
+	}
+	return nil
+}
+
+func leadingWhitespace(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+}
+
+// positionToByteOffset converts an LSP position to a byte offset in content
+func positionToByteOffset(content string, position protocol.Position) uint {
+	var offset uint
+	for line := uint32(0); line < position.Line; line++ {
+		next := strings.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return uint(len(content))
+		}
+		offset += uint(next) + 1
+	}
+	lineEnd := strings.IndexByte(content[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(content) - int(offset)
+	}
+	return offset + textutil.UTF16ToByteOffset(content[offset:offset+uint(lineEnd)], position.Character)
+}
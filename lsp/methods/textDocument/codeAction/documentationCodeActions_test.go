@@ -0,0 +1,299 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction_test
+
+import (
+	"strings"
+	"testing"
+
+	_ "bennypowers.dev/cem/internal/languages/registry"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/codeAction"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+// documentAt runs code actions with the cursor on the first occurrence of
+// marker in content, and returns the documentation actions
+func documentAt(t *testing.T, uri, content, marker string) []protocol.CodeAction {
+	t.Helper()
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	t.Cleanup(dm.Close)
+	ctx.SetDocumentManager(dm)
+	doc := dm.OpenDocument(uri, content, 1)
+	ctx.AddDocument(uri, doc)
+
+	index := strings.Index(content, marker)
+	if index < 0 {
+		t.Fatalf("marker %q not found", marker)
+	}
+	line := strings.Count(content[:index], "\n")
+	character := index - (strings.LastIndex(content[:index], "\n") + 1)
+	position := protocol.Position{Line: uint32(line), Character: uint32(character)}
+
+	actions, err := codeAction.CodeAction(ctx, &protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: urilib.URI(uri)},
+		Range:        protocol.Range{Start: position, End: position},
+	})
+	if err != nil {
+		t.Fatalf("CodeAction failed: %v", err)
+	}
+	var documentation []protocol.CodeAction
+	for _, action := range actions {
+		if action.Kind != nil && *action.Kind == protocol.CodeActionKindRefactorRewrite {
+			documentation = append(documentation, action)
+		}
+	}
+	return documentation
+}
+
+// applyEdit applies a single-edit code action to content
+func applyEdit(t *testing.T, uri, content string, action protocol.CodeAction) string {
+	t.Helper()
+	edits := action.Edit.Changes[urilib.URI(uri)]
+	if len(edits) != 1 {
+		t.Fatalf("Expected 1 edit, got %d", len(edits))
+	}
+	lines := strings.SplitAfter(content, "\n")
+	offset := func(p protocol.Position) int {
+		n := 0
+		for _, l := range lines[:p.Line] {
+			n += len(l)
+		}
+		return n + int(p.Character)
+	}
+	start, end := offset(edits[0].Range.Start), offset(edits[0].Range.End)
+	return content[:start] + edits[0].NewText + content[end:]
+}
+
+func TestDocumentationCodeActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		content string
+		marker  string
+		title   string
+		want    string
+	}{
+		{
+			name: "typed property",
+			uri:  "file:///my-element.ts",
+			content: `class MyElement extends LitElement {
+  @property() name: string = 'World';
+}
+`,
+			marker: "name",
+			title:  "Document property `name`",
+			want: `class MyElement extends LitElement {
+  /**
+   * TODO: describe the ` + "`name`" + ` property
+   */
+  @property() name: string = 'World';
+}
+`,
+		},
+		{
+			name: "javascript property takes its type from the decorator",
+			uri:  "file:///my-element.js",
+			content: `class MyElement extends LitElement {
+  @property({ type: Boolean, reflect: true })
+  open = false;
+}
+`,
+			marker: "open",
+			title:  "Document property `open`",
+			want: `class MyElement extends LitElement {
+  /**
+   * TODO: describe the ` + "`open`" + ` property
+   * @type {boolean}
+   */
+  @property({ type: Boolean, reflect: true })
+  open = false;
+}
+`,
+		},
+		{
+			name: "event is added to the class JSDoc",
+			uri:  "file:///my-element.ts",
+			content: `/**
+ * An element
+ */
+export class MyElement extends LitElement {
+  #onClick() {
+    this.dispatchEvent(new CustomEvent('change', { bubbles: true }));
+  }
+}
+`,
+			marker: "'change'",
+			title:  "Document event `change`",
+			want: `/**
+ * An element
+ * @fires {CustomEvent} change - TODO: describe the ` + "`change`" + ` event
+ */
+export class MyElement extends LitElement {
+  #onClick() {
+    this.dispatchEvent(new CustomEvent('change', { bubbles: true }));
+  }
+}
+`,
+		},
+		{
+			name: "event gets a new class JSDoc",
+			uri:  "file:///my-element.ts",
+			content: `export class MyElement extends LitElement {
+  #onClick() {
+    this.dispatchEvent(new Event('close'));
+  }
+}
+`,
+			marker: "dispatchEvent",
+			title:  "Document event `close`",
+			want: `/**
+ * @fires {Event} close - TODO: describe the ` + "`close`" + ` event
+ */
+export class MyElement extends LitElement {
+  #onClick() {
+    this.dispatchEvent(new Event('close'));
+  }
+}
+`,
+		},
+		{
+			name: "named slot",
+			uri:  "file:///my-element.ts",
+			content: "class MyElement extends LitElement {\n" +
+				"  render() {\n" +
+				"    return html`\n" +
+				"      <slot name=\"icon\"></slot>\n" +
+				"    `;\n" +
+				"  }\n" +
+				"}\n",
+			marker: "<slot",
+			title:  "Document slot `icon`",
+			want: "class MyElement extends LitElement {\n" +
+				"  render() {\n" +
+				"    return html`\n" +
+				"      <!-- TODO: describe the icon slot -->\n" +
+				"      <slot name=\"icon\"></slot>\n" +
+				"    `;\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name:    "inline default slot",
+			uri:     "file:///my-element.ts",
+			content: "const t = html`<div><slot></slot></div>`;\n",
+			marker:  "slot>",
+			title:   "Document the default slot",
+			want:    "const t = html`<div><!-- TODO: describe the default slot --> <slot></slot></div>`;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := documentAt(t, tt.uri, tt.content, tt.marker)
+			if len(actions) != 1 {
+				t.Fatalf("Expected 1 documentation action, got %d", len(actions))
+			}
+			if actions[0].Title != tt.title {
+				t.Errorf("Expected title %q, got %q", tt.title, actions[0].Title)
+			}
+			if got := applyEdit(t, tt.uri, tt.content, actions[0]); got != tt.want {
+				t.Errorf("Unexpected result:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentationCodeActions_AlreadyDocumented(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		marker  string
+	}{
+		{
+			name: "property",
+			content: `class MyElement extends LitElement {
+  /** The name to greet */
+  @property() name = 'World';
+}
+`,
+			marker: "name =",
+		},
+		{
+			name: "event",
+			content: `/**
+ * @fires change - When the value changes
+ */
+class MyElement extends LitElement {
+  #onClick() {
+    this.dispatchEvent(new Event('change'));
+  }
+}
+`,
+			marker: "'change'",
+		},
+		{
+			name: "slot with a comment",
+			content: "class MyElement extends LitElement {\n" +
+				"  render() {\n" +
+				"    return html`\n" +
+				"      <!-- The icon -->\n" +
+				"      <slot name=\"icon\"></slot>\n" +
+				"    `;\n" +
+				"  }\n" +
+				"}\n",
+			marker: "<slot",
+		},
+		{
+			name: "slot with a JSDoc tag",
+			content: "/** @slot - The content */\n" +
+				"class MyElement extends LitElement {\n" +
+				"  render() {\n" +
+				"    return html`<slot></slot>`;\n" +
+				"  }\n" +
+				"}\n",
+			marker: "<slot",
+		},
+		{
+			name:    "slot outside a registered template",
+			content: "const t = css`<slot></slot>`;\n",
+			marker:  "<slot",
+		},
+		{
+			name: "field without a property decorator",
+			content: `class MyElement extends LitElement {
+  name = 'World';
+}
+`,
+			marker: "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actions := documentAt(t, "file:///my-element.ts", tt.content, tt.marker); len(actions) != 0 {
+				t.Errorf("Expected no documentation actions, got %q", actions[0].Title)
+			}
+		})
+	}
+}
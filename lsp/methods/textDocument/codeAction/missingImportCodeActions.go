@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"bennypowers.dev/cem/internal/languages/typescript"
+	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/types"
 	"go.lsp.dev/protocol"
 	urilib "go.lsp.dev/uri"
@@ -46,11 +47,28 @@ func CreateMissingImportAction(
 	var importStatement string
 	var insertPosition protocol.Position
 
+	// Follow the document's quotes, and the editor's indentation if known
+	importQuote, attributeQuote := `"`, `"`
+	if doc != nil {
+		if content, err := doc.Content(); err == nil {
+			importQuote = helpers.ImportQuote(content)
+			attributeQuote = helpers.AttributeQuote(content)
+		}
+	}
+	importLine := func(indent string) string {
+		return fmt.Sprintf("%simport %s%s%s;", indent, importQuote, autofixData.ImportPath, importQuote)
+	}
+	scriptOpenTag := fmt.Sprintf("<script type=%smodule%s>", attributeQuote, attributeQuote)
+	indentUnit, hasIndentUnit := ctx.Config().Formatting.IndentUnit()
+
 	if strings.HasSuffix(documentURI, ".html") {
 		// For HTML files, prioritize inline module scripts and head placement
 		if doc != nil {
 			// Detect the document's indentation pattern
 			baseIndent, scriptIndent := detectIndentation(doc)
+			if hasIndentUnit {
+				baseIndent, scriptIndent = indentUnit, indentUnit+indentUnit
+			}
 
 			// 1. Try to find existing inline module script (not external src)
 			scriptPosition, hasInlineScript := doc.FindInlineModuleScript()
@@ -72,14 +90,14 @@ func CreateMissingImportAction(
 					tagIndent := strings.Repeat(" ", int(tagRange.Start.Character))
 					var replacement string
 					if afterImport == "" {
-						replacement = fmt.Sprintf("%s\n%s%s\n%simport \"%s\";\n%s</script>",
+						replacement = fmt.Sprintf("%s\n%s%s\n%s\n%s</script>",
 							openTag, scriptContentIndent, beforeImport,
-							scriptContentIndent, autofixData.ImportPath,
+							importLine(scriptContentIndent),
 							tagIndent)
 					} else {
-						replacement = fmt.Sprintf("%s\n%s%s\n%simport \"%s\";\n%s%s\n%s</script>",
+						replacement = fmt.Sprintf("%s\n%s%s\n%s\n%s%s\n%s</script>",
 							openTag, scriptContentIndent, beforeImport,
-							scriptContentIndent, autofixData.ImportPath,
+							importLine(scriptContentIndent),
 							scriptContentIndent, afterImport,
 							tagIndent)
 					}
@@ -87,7 +105,7 @@ func CreateMissingImportAction(
 						tagRange, replacement)
 					return &action, nil
 				}
-				importStatement = fmt.Sprintf(`%simport "%s";`, scriptContentIndent, autofixData.ImportPath)
+				importStatement = importLine(scriptContentIndent)
 				insertPosition = result.position
 			} else {
 				// 2. Try to find head section for new script placement
@@ -98,25 +116,22 @@ func CreateMissingImportAction(
 				headPosition, hasHead := doc.FindHeadInsertionPoint(dm)
 				if hasHead {
 					// Create new script tag inside head section with proper indentation
-					importStatement = fmt.Sprintf(`%s<script type="module">
-%simport "%s";
-%s</script>`, baseIndent, scriptIndent, autofixData.ImportPath, baseIndent)
+					importStatement = fmt.Sprintf("%s%s\n%s\n%s</script>",
+						baseIndent, scriptOpenTag, importLine(scriptIndent), baseIndent)
 					insertPosition = headPosition
 				} else {
 					// 3. Fallback: Create new script tag at beginning of document (HTML partial)
-					importStatement = fmt.Sprintf(`<script type="module">
-%simport "%s";
-</script>
-`, scriptIndent, autofixData.ImportPath)
+					importStatement = fmt.Sprintf("%s\n%s\n</script>\n", scriptOpenTag, importLine(scriptIndent))
 					insertPosition = protocol.Position{Line: 0, Character: 0}
 				}
 			}
 		} else {
 			// Fallback: Create new script tag when document is not available
-			importStatement = fmt.Sprintf(`<script type="module">
-  import "%s";
-</script>
-`, autofixData.ImportPath)
+			scriptIndent := "  "
+			if hasIndentUnit {
+				scriptIndent = indentUnit
+			}
+			importStatement = fmt.Sprintf("%s\n%s\n</script>\n", scriptOpenTag, importLine(scriptIndent))
 			insertPosition = protocol.Position{Line: 0, Character: 0}
 		}
 	} else {
@@ -126,14 +141,13 @@ func CreateMissingImportAction(
 			insertPosition = findImportInsertionPosition(doc)
 			// Add a blank line before the import if inserting after existing imports
 			if insertPosition.Line > 0 {
-				importStatement = fmt.Sprintf(`
-import "%s";`, autofixData.ImportPath)
+				importStatement = "\n" + importLine("")
 			} else {
-				importStatement = fmt.Sprintf(`import "%s";`, autofixData.ImportPath)
+				importStatement = importLine("")
 			}
 		} else {
 			// Fallback to beginning of file
-			importStatement = fmt.Sprintf(`import "%s";`, autofixData.ImportPath)
+			importStatement = importLine("")
 			insertPosition = protocol.Position{Line: 0, Character: 0}
 		}
 	}
@@ -41,6 +41,8 @@ type TestConfig struct {
 	ImportPath   string `json:"importPath"`
 	TagName      string `json:"tagName"`
 	ExpectAction bool   `json:"expectAction"`
+	// Formatting is the editor's formatting options, if it sends them
+	Formatting *types.FormattingConfig `json:"formatting,omitempty"`
 }
 
 func TestCreateMissingImportAction(t *testing.T) {
@@ -83,6 +85,9 @@ func TestCreateMissingImportAction(t *testing.T) {
 			}
 			defer dm.Close()
 			mockCtx.SetDocumentManager(dm)
+			if config.Formatting != nil {
+				mockCtx.SetConfig(types.ServerConfig{Formatting: *config.Formatting})
+			}
 
 			// Create document from input content using DocumentManager
 			doc := dm.OpenDocument(config.DocumentURI, string(inputContent), 1)
@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction
+
+import (
+	"fmt"
+
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+// createMissingSlotAutofixAction creates a code action which inserts a
+// placeholder child, assigned to the slot the element requires, before the
+// element's end tag
+func createMissingSlotAutofixAction(diagnostic *protocol.Diagnostic, data map[string]any, documentURI string) *protocol.CodeAction {
+	autofixData, ok := types.AutofixDataFromMap(data)
+	if !ok || autofixData.Type != types.DiagnosticTypeMissingSlot {
+		return nil
+	}
+
+	title := fmt.Sprintf("Add a placeholder for the '%s' slot", autofixData.Original)
+	if autofixData.Original == "" {
+		title = "Add a placeholder for the default slot"
+	}
+	kind := protocol.CodeActionKindQuickFix
+	preferred := true
+
+	action := protocol.CodeAction{
+		Title:       title,
+		Kind:        &kind,
+		IsPreferred: &preferred,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[urilib.URI][]protocol.TextEdit{
+				urilib.URI(documentURI): {
+					{
+						Range:   autofixData.Range,
+						NewText: autofixData.Suggestion,
+					},
+				},
+			},
+		},
+		Diagnostics: []protocol.Diagnostic{*diagnostic},
+	}
+
+	return &action
+}
@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction
+
+import (
+	"fmt"
+
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+// createSelfClosingAutofixAction creates a code action which expands a
+// self-closing custom element to explicit start and end tags
+func createSelfClosingAutofixAction(diagnostic *protocol.Diagnostic, data map[string]any, documentURI string) *protocol.CodeAction {
+	autofixData, ok := types.AutofixDataFromMap(data)
+	if !ok || autofixData.Type != types.DiagnosticTypeSelfClosingCustomElement {
+		return nil
+	}
+
+	title := fmt.Sprintf("Expand to <%s></%s>", autofixData.TagName, autofixData.TagName)
+	kind := protocol.CodeActionKindQuickFix
+	preferred := true
+
+	action := protocol.CodeAction{
+		Title:       title,
+		Kind:        &kind,
+		IsPreferred: &preferred,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[urilib.URI][]protocol.TextEdit{
+				urilib.URI(documentURI): {
+					{
+						Range:   autofixData.Range,
+						NewText: autofixData.Suggestion,
+					},
+				},
+			},
+		},
+		Diagnostics: []protocol.Diagnostic{*diagnostic},
+	}
+
+	return &action
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction
+
+import (
+	"fmt"
+	"strings"
+
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+// createSuppressionAction creates a code action which inserts a cem-ignore
+// comment above a diagnostic, suppressing its rule on that line
+func createSuppressionAction(ctx types.ServerContext, diagnostic *protocol.Diagnostic, documentURI string) *protocol.CodeAction {
+	rule, ok := types.DiagnosticRuleOf(*diagnostic)
+	// CSS has no HTML or line comments, and ambiguous comments have their own fixes
+	if !ok || rule == types.RuleCSSAmbiguousComment {
+		return nil
+	}
+	doc := ctx.Document(documentURI)
+	if doc == nil {
+		return nil
+	}
+	content, err := doc.Content()
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(content, "\n")
+	lineNumber := diagnostic.Range.Start.Line
+	if int(lineNumber) >= len(lines) {
+		return nil
+	}
+	line := lines[lineNumber]
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+	// Inside templates, the comment is HTML. When the template opens on the
+	// diagnostic's line, the line above is script, so it takes a line comment.
+	comment := fmt.Sprintf("<!-- cem-ignore-next-line %s -->", rule)
+	if doc.Language() == "typescript" {
+		before := line[:min(int(diagnostic.Range.Start.Character), len(line))]
+		if strings.Contains(before, "`") {
+			comment = fmt.Sprintf("// cem-ignore %s", rule)
+		}
+	}
+
+	kind := protocol.CodeActionKindQuickFix
+	insertAt := protocol.Position{Line: lineNumber, Character: 0}
+	return &protocol.CodeAction{
+		Title: fmt.Sprintf("Ignore %s on this line", rule),
+		Kind:  &kind,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[urilib.URI][]protocol.TextEdit{
+				urilib.URI(documentURI): {
+					{
+						Range:   protocol.Range{Start: insertAt, End: insertAt},
+						NewText: indent + comment + "\n",
+					},
+				},
+			},
+		},
+		Diagnostics: []protocol.Diagnostic{*diagnostic},
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/codeAction"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"bennypowers.dev/cem/lsp/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+func TestCodeActionSuppression(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	suppress := func(t *testing.T, uri, content string, position protocol.Position) protocol.TextEdit {
+		t.Helper()
+		doc := dm.OpenDocument(uri, content, 1)
+		ctx.AddDocument(uri, doc)
+		diagnostic := protocol.Diagnostic{
+			Range:    protocol.Range{Start: position, End: position},
+			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     types.RuleUnknownAttribute.Code(),
+			Source:   protocol.NewOptional("cem-lsp"),
+			Message:  protocol.String("Unknown attribute 'colour'"),
+		}
+		actions, err := codeAction.CodeAction(ctx, &protocol.CodeActionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: urilib.URI(uri)},
+			Context:      protocol.CodeActionContext{Diagnostics: []protocol.Diagnostic{diagnostic}},
+		})
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.Equal(t, "Ignore unknown-attribute on this line", actions[0].Title)
+		edits := actions[0].Edit.Changes[urilib.URI(uri)]
+		require.Len(t, edits, 1)
+		return edits[0]
+	}
+
+	t.Run("HTML", func(t *testing.T) {
+		edit := suppress(t, "file:///test.html", "<div>\n  <my-element colour=\"red\"></my-element>\n</div>", protocol.Position{Line: 1, Character: 14})
+		assert.Equal(t, "  <!-- cem-ignore-next-line unknown-attribute -->\n", edit.NewText)
+		assert.Equal(t, protocol.Position{Line: 1}, edit.Range.Start)
+	})
+
+	t.Run("multiline template", func(t *testing.T) {
+		edit := suppress(t, "file:///multiline.ts", "html`\n    <my-element colour=\"red\"></my-element>\n`;", protocol.Position{Line: 1, Character: 16})
+		assert.Equal(t, "    <!-- cem-ignore-next-line unknown-attribute -->\n", edit.NewText)
+	})
+
+	t.Run("template opening on the line", func(t *testing.T) {
+		edit := suppress(t, "file:///inline.ts", "  return html`<my-element colour=\"red\"></my-element>`;", protocol.Position{Line: 0, Character: 27})
+		assert.Equal(t, "  // cem-ignore unknown-attribute\n", edit.NewText)
+	})
+}
@@ -55,3 +55,66 @@ func createTagAutofixAction(diagnostic *protocol.Diagnostic, data map[string]any
 
 	return &action
 }
+
+// createTagFixAllAction creates a code action which fixes every occurrence of
+// an invalid tag name across the workspace. When its edit is computed
+// eagerly, it is only offered when other files contain the tag, since the
+// single-document fix already covers the current one.
+func createTagFixAllAction(ctx types.ServerContext, diagnostic *protocol.Diagnostic, data map[string]any) (*protocol.CodeAction, error) {
+	autofixData, ok := types.AutofixDataFromMap(data)
+	if !ok || autofixData.Type != types.DiagnosticTypeTagSuggestion {
+		return nil, nil
+	}
+
+	title := fmt.Sprintf("Change all '%s' to '%s' in workspace", autofixData.Original, autofixData.Suggestion)
+	return newWorkspaceFixAction(ctx, title, resolveData{
+		Type:       resolveTagFixAll,
+		Original:   autofixData.Original,
+		Suggestion: autofixData.Suggestion,
+	}, []protocol.Diagnostic{*diagnostic}, 1)
+}
+
+// createDeprecatedElementAction creates a code action which replaces a
+// deprecated element's start and end tags in the current document with the
+// element its deprecation reason names
+func createDeprecatedElementAction(ctx types.ServerContext, diagnostic *protocol.Diagnostic, data map[string]any, documentURI string) *protocol.CodeAction {
+	autofixData, ok := types.AutofixDataFromMap(data)
+	if !ok || autofixData.Type != types.DiagnosticTypeDeprecatedElement {
+		return nil
+	}
+	doc := ctx.Document(documentURI)
+	if doc == nil {
+		return nil
+	}
+	content, err := doc.Content()
+	if err != nil {
+		return nil
+	}
+	edits := documentTagRenameEdits(content, map[string]string{autofixData.Original: autofixData.Suggestion})
+	if len(edits) == 0 {
+		return nil
+	}
+
+	kind := protocol.CodeActionKindQuickFix
+	preferred := true
+	return &protocol.CodeAction{
+		Title:       fmt.Sprintf("Replace deprecated '%s' with '%s'", autofixData.Original, autofixData.Suggestion),
+		Kind:        &kind,
+		IsPreferred: &preferred,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[urilib.URI][]protocol.TextEdit{
+				urilib.URI(documentURI): edits,
+			},
+		},
+		Diagnostics: []protocol.Diagnostic{*diagnostic},
+	}
+}
+
+// createDeprecationsFixAllAction creates a code action which replaces every
+// deprecated element whose deprecation reason names its replacement, across
+// the workspace
+func createDeprecationsFixAllAction(ctx types.ServerContext, diagnostics []protocol.Diagnostic) (*protocol.CodeAction, error) {
+	return newWorkspaceFixAction(ctx, "Replace all deprecated elements in workspace", resolveData{
+		Type: resolveDeprecationsFixAll,
+	}, diagnostics, 0)
+}
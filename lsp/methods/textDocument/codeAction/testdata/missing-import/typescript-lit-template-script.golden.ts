@@ -1,7 +1,7 @@
 import { html } from 'lit';
 import './other-element.js';
 
-import "./my-element.js";
+import './my-element.js';
 
 const tpl = html`
   <script type="module">
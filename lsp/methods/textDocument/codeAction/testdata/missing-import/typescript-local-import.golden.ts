@@ -1,6 +1,6 @@
 import { html } from 'lit';
 
-import "./my-element.js";
+import './my-element.js';
 
 export class MyComponent {
   render() {
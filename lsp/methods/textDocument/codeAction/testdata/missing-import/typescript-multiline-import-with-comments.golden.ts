@@ -6,7 +6,7 @@ import {
 } from 'lit/decorators.js';
 import './other-element.js';
 
-import "./my-element.js";
+import './my-element.js';
 
 export class MyComponent {
   render() {
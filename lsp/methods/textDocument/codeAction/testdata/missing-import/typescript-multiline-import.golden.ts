@@ -22,7 +22,7 @@ import '../components/code-icon.js';
 import '../components/overlays/popover.js';
 import './list-item.js';
 
-import "./my-element.js";
+import './my-element.js';
 
 export class MyComponent {
   render() {
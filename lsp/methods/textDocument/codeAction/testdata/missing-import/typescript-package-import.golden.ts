@@ -1,6 +1,6 @@
 import { html } from 'lit';
 
-import "@my-org/components";
+import '@my-org/components';
 
 export class MyComponent {
   render() {
@@ -0,0 +1,286 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/internal/textutil"
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/methods/textDocument/references"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+// confirmationAnnotation identifies the change annotation attached to every
+// edit of a multi-file workspace edit
+const confirmationAnnotation = "cem.confirm"
+
+// Code actions whose workspace edit is computed in codeAction/resolve
+const (
+	resolveTagFixAll          = "tag-fix-all"
+	resolveDeprecationsFixAll = "deprecations-fix-all"
+)
+
+// resolveData identifies the workspace edit of a code action which is
+// resolved lazily, since finding it means reading every workspace file
+type resolveData struct {
+	Type       string `json:"type"`
+	Original   string `json:"original,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// annotatedTextEdit, textDocumentEdit, and changeAnnotation mirror the wire
+// format of their LSP counterparts. The protocol package models document
+// changes and their edits as unions, so annotated edits are built in wire
+// format and decoded into a protocol.WorkspaceEdit.
+type annotatedTextEdit struct {
+	Range        protocol.Range `json:"range"`
+	NewText      string         `json:"newText"`
+	AnnotationID string         `json:"annotationId,omitempty"`
+}
+
+type textDocumentEdit struct {
+	TextDocument struct {
+		URI     string `json:"uri"`
+		Version *int32 `json:"version"`
+	} `json:"textDocument"`
+	Edits []annotatedTextEdit `json:"edits"`
+}
+
+type changeAnnotation struct {
+	Label             string `json:"label"`
+	NeedsConfirmation bool   `json:"needsConfirmation"`
+	Description       string `json:"description,omitempty"`
+}
+
+// Resolve handles codeAction/resolve requests, computing the workspace edit
+// of a workspace-wide fix
+func Resolve(ctx types.ServerContext, action *protocol.CodeAction) (*protocol.CodeAction, error) {
+	if action == nil || len(action.Data) == 0 {
+		return action, nil
+	}
+	var data resolveData
+	if err := json.Unmarshal(action.Data, &data); err != nil {
+		return action, nil
+	}
+	changes, ok := resolveChanges(ctx, data)
+	if !ok {
+		return action, nil
+	}
+	edit, err := newWorkspaceEdit(ctx, action.Title, changes)
+	if err != nil {
+		return nil, err
+	}
+	action.Edit = edit
+	return action, nil
+}
+
+// resolveChanges finds the text edits of a workspace-wide fix, keyed by
+// document URI
+func resolveChanges(ctx types.ServerContext, data resolveData) (map[string][]protocol.TextEdit, bool) {
+	switch data.Type {
+	case resolveTagFixAll:
+		return tagRenameEdits(ctx, map[string]string{data.Original: data.Suggestion}), true
+	case resolveDeprecationsFixAll:
+		return tagRenameEdits(ctx, deprecationRenames(ctx)), true
+	}
+	return nil, false
+}
+
+// newWorkspaceFixAction creates a code action for a workspace-wide fix. When
+// the client resolves code action edits, the edit is left for
+// codeAction/resolve. Otherwise it is computed now, and the action is
+// dropped when it would change no more than minFiles documents.
+func newWorkspaceFixAction(ctx types.ServerContext, title string, data resolveData, diagnostics []protocol.Diagnostic, minFiles int) (*protocol.CodeAction, error) {
+	kind := protocol.CodeActionKindQuickFix
+	action := &protocol.CodeAction{
+		Title:       title,
+		Kind:        &kind,
+		Diagnostics: diagnostics,
+	}
+	if ctx.EditCapabilities().ResolveEdits {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		action.Data = payload
+		return action, nil
+	}
+	changes, _ := resolveChanges(ctx, data)
+	if len(changes) <= minFiles {
+		return nil, nil
+	}
+	edit, err := newWorkspaceEdit(ctx, title, changes)
+	if err != nil {
+		return nil, err
+	}
+	action.Edit = edit
+	return action, nil
+}
+
+// newWorkspaceEdit creates a workspace edit from text edits keyed by document
+// URI. Edits to a single document are applied directly. Edits which touch
+// several documents are sent as versioned document changes, annotated as
+// needing confirmation so that editors show a preview of the changes rather
+// than silently rewriting many files. Clients without document change
+// support receive plain changes, and clients without change annotation
+// support receive unannotated document changes.
+func newWorkspaceEdit(ctx types.ServerContext, label string, changes map[string][]protocol.TextEdit) (*protocol.WorkspaceEdit, error) {
+	capabilities := ctx.EditCapabilities()
+	if len(changes) < 2 || !capabilities.DocumentChanges {
+		edit := &protocol.WorkspaceEdit{Changes: map[urilib.URI][]protocol.TextEdit{}}
+		for uri, edits := range changes {
+			edit.Changes[urilib.URI(uri)] = edits
+		}
+		return edit, nil
+	}
+
+	annotationID := ""
+	if capabilities.ChangeAnnotations {
+		annotationID = confirmationAnnotation
+	}
+	count := 0
+	documentChanges := make([]textDocumentEdit, 0, len(changes))
+	for _, uri := range slices.Sorted(maps.Keys(changes)) {
+		var change textDocumentEdit
+		change.TextDocument.URI = uri
+		if doc := ctx.Document(uri); doc != nil {
+			version := doc.Version()
+			change.TextDocument.Version = &version
+		}
+		for _, edit := range changes[uri] {
+			change.Edits = append(change.Edits, annotatedTextEdit{
+				Range:        edit.Range,
+				NewText:      edit.NewText,
+				AnnotationID: annotationID,
+			})
+		}
+		count += len(change.Edits)
+		documentChanges = append(documentChanges, change)
+	}
+
+	wire := map[string]any{"documentChanges": documentChanges}
+	if annotationID != "" {
+		wire["changeAnnotations"] = map[string]changeAnnotation{
+			annotationID: {
+				Label:             label,
+				NeedsConfirmation: true,
+				Description:       fmt.Sprintf("%d changes in %d files", count, len(changes)),
+			},
+		}
+	}
+	data, err := protocol.Marshal(wire)
+	if err != nil {
+		return nil, err
+	}
+	var edit protocol.WorkspaceEdit
+	if err := protocol.Unmarshal(data, &edit); err != nil {
+		return nil, err
+	}
+	return &edit, nil
+}
+
+// deprecationRenames maps each deprecated element whose deprecation reason
+// names its replacement to that replacement
+func deprecationRenames(ctx types.ServerContext) map[string]string {
+	renames := make(map[string]string)
+	for _, tagName := range ctx.AllTagNames() {
+		if replacement, ok := helpers.DeprecationReplacement(ctx, tagName); ok {
+			renames[tagName] = replacement
+		}
+	}
+	return renames
+}
+
+// tagRenameEdits finds every start and end tag named by a key of renames, in
+// open documents and in workspace files, and returns edits renaming them to
+// the key's value, keyed by document URI
+func tagRenameEdits(ctx types.ServerContext, renames map[string]string) map[string][]protocol.TextEdit {
+	changes := make(map[string][]protocol.TextEdit)
+	if len(renames) == 0 {
+		return changes
+	}
+
+	collect := func(uri, content string) {
+		if edits := documentTagRenameEdits(content, renames); len(edits) > 0 {
+			changes[uri] = edits
+		}
+	}
+
+	documents := ctx.AllDocuments()
+	for _, doc := range documents {
+		content, err := doc.Content()
+		if err != nil {
+			continue
+		}
+		collect(doc.URI(), content)
+	}
+
+	if root := ctx.WorkspaceRoot(); root != "" {
+		filesystem := ctx.FileSystem()
+		references.WalkWorkspaceFiles(root, documents, filesystem, func(path, fileURI string) {
+			content, err := filesystem.ReadFile(path)
+			if err != nil {
+				helpers.SafeDebugLog("[CODE_ACTION] Failed to read %s: %v", path, err)
+				return
+			}
+			collect(fileURI, string(content))
+		})
+	}
+
+	return changes
+}
+
+// documentTagRenameEdits returns edits renaming the start and end tags named
+// by a key of renames in content to the key's value
+func documentTagRenameEdits(content string, renames map[string]string) []protocol.TextEdit {
+	names := make([]string, 0, len(renames))
+	for _, from := range slices.Sorted(maps.Keys(renames)) {
+		names = append(names, regexp.QuoteMeta(from))
+	}
+	pattern := regexp.MustCompile(`</?(` + strings.Join(names, "|") + `)(?:[\s/>]|$)`)
+
+	var edits []protocol.TextEdit
+	for _, match := range pattern.FindAllStringSubmatchIndex(content, -1) {
+		edits = append(edits, protocol.TextEdit{
+			Range: protocol.Range{
+				Start: byteOffsetToPosition(content, match[2]),
+				End:   byteOffsetToPosition(content, match[3]),
+			},
+			NewText: renames[content[match[2]:match[3]]],
+		})
+	}
+	return edits
+}
+
+// byteOffsetToPosition converts a byte offset in content to an LSP position,
+// counting characters in UTF-16 code units
+func byteOffsetToPosition(content string, offset int) protocol.Position {
+	before := content[:offset]
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	return protocol.Position{
+		Line:      uint32(strings.Count(before, "\n")),
+		Character: textutil.ByteOffsetToUTF16(content[lineStart:], uint(offset-lineStart)),
+	}
+}
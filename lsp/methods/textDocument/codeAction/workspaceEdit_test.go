@@ -0,0 +1,395 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeAction_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/codeAction"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+func tagSuggestionParams(t *testing.T, uri string) *protocol.CodeActionParams {
+	t.Helper()
+	data, err := protocol.Marshal(map[string]any{
+		"type": "tag-suggestion", "original": "my-elem", "suggestion": "my-element",
+		"range": map[string]any{
+			"start": map[string]any{"line": float64(0), "character": float64(1)},
+			"end":   map[string]any{"line": float64(0), "character": float64(8)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal diagnostic data: %v", err)
+	}
+	return &protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: urilib.URI(uri)},
+		Context: protocol.CodeActionContext{
+			Diagnostics: []protocol.Diagnostic{{
+				Range:  protocol.Range{Start: protocol.Position{Line: 0, Character: 1}, End: protocol.Position{Line: 0, Character: 8}},
+				Source: protocol.NewOptional("cem-lsp"),
+				Data:   data,
+			}},
+		},
+	}
+}
+
+func TestCodeActionTagFixAllInWorkspace(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	ctx.SetEditCapabilities(types.EditCapabilities{DocumentChanges: true, ChangeAnnotations: true})
+
+	doc := dm.OpenDocument("file:///index.html", `<my-elem></my-elem>`, 3)
+	ctx.AddDocument("file:///index.html", doc)
+
+	ctx.SetFileSystem(platform.NewMapFS(map[string]string{
+		"other.html":     "<p>\n  <my-elem>é</my-elem>\n</p>",
+		"unrelated.html": `<my-element></my-element><my-elemental></my-elemental>`,
+	}))
+	ctx.SetWorkspaceRoot(".")
+
+	actions, err := codeAction.CodeAction(ctx, tagSuggestionParams(t, "file:///index.html"))
+	if err != nil {
+		t.Fatalf("CodeAction failed: %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Expected 2 actions, got %d", len(actions))
+	}
+
+	action := actions[1]
+	if action.Title != "Change all 'my-elem' to 'my-element' in workspace" {
+		t.Errorf("Unexpected title %q", action.Title)
+	}
+	if action.IsPreferred != nil && *action.IsPreferred {
+		t.Error("Expected workspace fix not to be preferred")
+	}
+	if action.Edit == nil {
+		t.Fatal("Expected edit to be present")
+	}
+
+	serialized, err := protocol.Marshal(action.Edit)
+	if err != nil {
+		t.Fatalf("Failed to marshal edit: %v", err)
+	}
+	var edit struct {
+		DocumentChanges []struct {
+			TextDocument struct {
+				URI     string `json:"uri"`
+				Version *int32 `json:"version"`
+			} `json:"textDocument"`
+			Edits []struct {
+				Range        protocol.Range `json:"range"`
+				NewText      string         `json:"newText"`
+				AnnotationID string         `json:"annotationId"`
+			} `json:"edits"`
+		} `json:"documentChanges"`
+		ChangeAnnotations map[string]struct {
+			Label             string `json:"label"`
+			NeedsConfirmation bool   `json:"needsConfirmation"`
+			Description       string `json:"description"`
+		} `json:"changeAnnotations"`
+	}
+	if err := json.Unmarshal(serialized, &edit); err != nil {
+		t.Fatalf("Failed to unmarshal edit: %v", err)
+	}
+
+	if len(edit.DocumentChanges) != 2 {
+		t.Fatalf("Expected changes to 2 documents, got %d: %s", len(edit.DocumentChanges), serialized)
+	}
+
+	index := edit.DocumentChanges[0]
+	if index.TextDocument.URI != "file:///index.html" {
+		t.Errorf("Expected first change to index.html, got %s", index.TextDocument.URI)
+	}
+	if index.TextDocument.Version == nil || *index.TextDocument.Version != 3 {
+		t.Errorf("Expected open document version 3, got %v", index.TextDocument.Version)
+	}
+	if len(index.Edits) != 2 {
+		t.Errorf("Expected start and end tag edits in index.html, got %d", len(index.Edits))
+	}
+
+	other := edit.DocumentChanges[1]
+	if other.TextDocument.URI != "file:///other.html" {
+		t.Errorf("Expected second change to other.html, got %s", other.TextDocument.URI)
+	}
+	if other.TextDocument.Version != nil {
+		t.Errorf("Expected no version for closed file, got %d", *other.TextDocument.Version)
+	}
+	if len(other.Edits) != 2 {
+		t.Fatalf("Expected start and end tag edits in other.html, got %d", len(other.Edits))
+	}
+	// The end tag follows a two-byte character which is one UTF-16 code unit
+	wantEnd := protocol.Range{
+		Start: protocol.Position{Line: 1, Character: 14},
+		End:   protocol.Position{Line: 1, Character: 21},
+	}
+	if other.Edits[1].Range != wantEnd {
+		t.Errorf("Expected end tag range %+v, got %+v", wantEnd, other.Edits[1].Range)
+	}
+
+	for _, change := range edit.DocumentChanges {
+		for _, e := range change.Edits {
+			if e.NewText != "my-element" {
+				t.Errorf("Expected new text 'my-element', got %q", e.NewText)
+			}
+			annotation, ok := edit.ChangeAnnotations[e.AnnotationID]
+			if !ok {
+				t.Fatalf("Edit references unknown annotation %q", e.AnnotationID)
+			}
+			if !annotation.NeedsConfirmation {
+				t.Error("Expected annotation to need confirmation")
+			}
+			if annotation.Description != "4 changes in 2 files" {
+				t.Errorf("Unexpected annotation description %q", annotation.Description)
+			}
+		}
+	}
+}
+
+func TestCodeActionTagFixAllSingleDocument(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	doc := dm.OpenDocument("file:///index.html", `<my-elem></my-elem><my-elem></my-elem>`, 1)
+	ctx.AddDocument("file:///index.html", doc)
+	ctx.SetFileSystem(platform.NewMapFS(nil))
+	ctx.SetWorkspaceRoot(".")
+
+	actions, err := codeAction.CodeAction(ctx, tagSuggestionParams(t, "file:///index.html"))
+	if err != nil {
+		t.Fatalf("CodeAction failed: %v", err)
+	}
+	if len(actions) != 1 {
+		t.Fatalf("Expected only the single-document fix, got %d actions", len(actions))
+	}
+}
+
+func TestCodeActionTagFixAllResolve(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+	ctx.SetEditCapabilities(types.EditCapabilities{ResolveEdits: true})
+
+	doc := dm.OpenDocument("file:///index.html", `<my-elem></my-elem>`, 1)
+	ctx.AddDocument("file:///index.html", doc)
+	ctx.SetFileSystem(platform.NewMapFS(map[string]string{
+		"other.html": `<my-elem></my-elem>`,
+	}))
+	ctx.SetWorkspaceRoot(".")
+
+	actions, err := codeAction.CodeAction(ctx, tagSuggestionParams(t, "file:///index.html"))
+	if err != nil {
+		t.Fatalf("CodeAction failed: %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Expected 2 actions, got %d", len(actions))
+	}
+	action := actions[1]
+	if action.Edit != nil {
+		t.Fatal("Expected the workspace edit to be left for codeAction/resolve")
+	}
+	if len(action.Data) == 0 {
+		t.Fatal("Expected resolve data")
+	}
+
+	resolved, err := codeAction.Resolve(ctx, &action)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.Edit == nil {
+		t.Fatal("Expected resolve to compute the edit")
+	}
+	// The client supports neither document changes nor change annotations,
+	// so the edit falls back to plain changes
+	if len(resolved.Edit.Changes) != 2 {
+		t.Fatalf("Expected plain changes to 2 documents, got %v", resolved.Edit.Changes)
+	}
+	for uri, edits := range resolved.Edit.Changes {
+		if len(edits) != 2 {
+			t.Errorf("Expected start and end tag edits in %s, got %d", uri, len(edits))
+		}
+	}
+}
+
+func TestCodeActionWorkspaceEditWithoutChangeAnnotations(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+	ctx.SetEditCapabilities(types.EditCapabilities{DocumentChanges: true})
+
+	doc := dm.OpenDocument("file:///index.html", `<my-elem></my-elem>`, 1)
+	ctx.AddDocument("file:///index.html", doc)
+	ctx.SetFileSystem(platform.NewMapFS(map[string]string{
+		"other.html": `<my-elem></my-elem>`,
+	}))
+	ctx.SetWorkspaceRoot(".")
+
+	actions, err := codeAction.CodeAction(ctx, tagSuggestionParams(t, "file:///index.html"))
+	if err != nil {
+		t.Fatalf("CodeAction failed: %v", err)
+	}
+	if len(actions) != 2 || actions[1].Edit == nil {
+		t.Fatalf("Expected a workspace fix with an edit, got %+v", actions)
+	}
+
+	serialized, err := protocol.Marshal(actions[1].Edit)
+	if err != nil {
+		t.Fatalf("Failed to marshal edit: %v", err)
+	}
+	var edit struct {
+		DocumentChanges []struct {
+			Edits []map[string]any `json:"edits"`
+		} `json:"documentChanges"`
+		ChangeAnnotations map[string]any `json:"changeAnnotations"`
+	}
+	if err := json.Unmarshal(serialized, &edit); err != nil {
+		t.Fatalf("Failed to unmarshal edit: %v", err)
+	}
+	if len(edit.DocumentChanges) != 2 {
+		t.Fatalf("Expected document changes to 2 documents, got %s", serialized)
+	}
+	if len(edit.ChangeAnnotations) != 0 {
+		t.Errorf("Expected no change annotations, got %s", serialized)
+	}
+	for _, change := range edit.DocumentChanges {
+		for _, e := range change.Edits {
+			if _, ok := e["annotationId"]; ok {
+				t.Errorf("Expected unannotated edits, got %s", serialized)
+			}
+		}
+	}
+}
+
+func TestCodeActionDeprecatedElements(t *testing.T) {
+	var pkg M.Package
+	if err := json.Unmarshal([]byte(`{
+		"schemaVersion": "2.1.0",
+		"modules": [{
+			"kind": "javascript-module",
+			"path": "elements.js",
+			"declarations": [
+				{"kind": "class", "name": "OldButton", "customElement": true, "tagName": "old-button", "deprecated": "Use <new-button> instead"},
+				{"kind": "class", "name": "OldCard", "customElement": true, "tagName": "old-card", "deprecated": "Use new-card"},
+				{"kind": "class", "name": "OldLink", "customElement": true, "tagName": "old-link", "deprecated": true},
+				{"kind": "class", "name": "NewButton", "customElement": true, "tagName": "new-button"},
+				{"kind": "class", "name": "NewCard", "customElement": true, "tagName": "new-card"}
+			]
+		}]
+	}`), &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	ctx := testhelpers.NewMockServerContext()
+	ctx.AddManifest(&pkg)
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+	ctx.SetEditCapabilities(types.EditCapabilities{ResolveEdits: true})
+
+	doc := dm.OpenDocument("file:///index.html", `<old-button>Save</old-button>`, 1)
+	ctx.AddDocument("file:///index.html", doc)
+	ctx.SetFileSystem(platform.NewMapFS(map[string]string{
+		"cards.html": `<old-card></old-card><old-link></old-link>`,
+	}))
+	ctx.SetWorkspaceRoot(".")
+
+	data, err := protocol.Marshal(map[string]any{
+		"type": "deprecated-element", "original": "old-button", "suggestion": "new-button",
+		"range": map[string]any{
+			"start": map[string]any{"line": float64(0), "character": float64(1)},
+			"end":   map[string]any{"line": float64(0), "character": float64(11)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal diagnostic data: %v", err)
+	}
+	actions, err := codeAction.CodeAction(ctx, &protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: "file:///index.html"},
+		Context: protocol.CodeActionContext{
+			Diagnostics: []protocol.Diagnostic{{
+				Range:  protocol.Range{Start: protocol.Position{Line: 0, Character: 1}, End: protocol.Position{Line: 0, Character: 11}},
+				Source: protocol.NewOptional("cem-lsp"),
+				Data:   data,
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CodeAction failed: %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Expected the document and workspace fixes, got %d actions", len(actions))
+	}
+
+	replace := actions[0]
+	if replace.Title != "Replace deprecated 'old-button' with 'new-button'" {
+		t.Errorf("Unexpected title %q", replace.Title)
+	}
+	if edits := replace.Edit.Changes["file:///index.html"]; len(edits) != 2 {
+		t.Errorf("Expected start and end tag edits, got %v", edits)
+	}
+
+	fixAll, err := codeAction.Resolve(ctx, &actions[1])
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if fixAll.Title != "Replace all deprecated elements in workspace" {
+		t.Errorf("Unexpected title %q", fixAll.Title)
+	}
+	if fixAll.Edit == nil {
+		t.Fatal("Expected resolve to compute the edit")
+	}
+	var renamed []string
+	for _, edits := range fixAll.Edit.Changes {
+		for _, e := range edits {
+			renamed = append(renamed, e.NewText)
+		}
+	}
+	slices.Sort(renamed)
+	want := []string{"new-button", "new-button", "new-card", "new-card"}
+	if !slices.Equal(renamed, want) {
+		t.Errorf("Expected renames %v, got %v", want, renamed)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeLens
+
+import (
+	"encoding/json"
+
+	"bennypowers.dev/cem/internal/languages/typescript"
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/methods/textDocument/references"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// CodeLensData holds the data needed to resolve a code lens later
+type CodeLensData struct {
+	TagName string `json:"tagName"`
+}
+
+// CodeLens handles the textDocument/codeLens request, placing a lens above
+// the definition of each custom element defined in a TypeScript or
+// JavaScript module. Usage counts scan the workspace, so they are computed
+// in codeLens/resolve, only for the lenses the client shows.
+func CodeLens(ctx types.ServerContext, params *protocol.CodeLensParams) ([]protocol.CodeLens, error) {
+	doc := ctx.Document(string(params.TextDocument.URI))
+	if doc == nil {
+		return nil, nil
+	}
+
+	lang := doc.Language()
+	if lang != "typescript" && lang != "javascript" {
+		return nil, nil
+	}
+
+	content, err := doc.Content()
+	if err != nil {
+		helpers.SafeDebugLog("[CODE_LENS] Error getting document content: %v", err)
+		return nil, nil
+	}
+
+	queryManager, err := ctx.QueryManager()
+	if err != nil {
+		helpers.SafeDebugLog("[CODE_LENS] Query manager unavailable: %v", err)
+		return nil, nil
+	}
+
+	var lenses []protocol.CodeLens
+	for _, element := range typescript.FindDefinedElements([]byte(content), queryManager) {
+		data, _ := protocol.Marshal(CodeLensData{TagName: element.TagName})
+		lenses = append(lenses, protocol.CodeLens{
+			Range: protocol.Range{
+				Start: protocol.Position{Line: element.Range.Start.Line, Character: element.Range.Start.Character},
+				End:   protocol.Position{Line: element.Range.End.Line, Character: element.Range.End.Character},
+			},
+			Data: data,
+		})
+	}
+	return lenses, nil
+}
+
+// Resolve handles the codeLens/resolve request, titling the lens with the
+// usage counts of its element, e.g. "12 usages (3 in tests)".
+func Resolve(ctx types.ServerContext, params *protocol.CodeLens) (*protocol.CodeLens, error) {
+	if params.Data == nil {
+		return params, nil
+	}
+
+	var data CodeLensData
+	if err := json.Unmarshal(params.Data, &data); err != nil {
+		helpers.SafeDebugLog("[CODE_LENS] Failed to unmarshal data: %v", err)
+		return params, nil
+	}
+
+	counts := references.CountUsages(ctx, data.TagName)
+	// An empty command renders the lens as a label
+	params.Command = protocol.Command{Title: counts.Label()}
+	return params, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package codeLens_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/codeLens"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+func TestCodeLens_Fixtures(t *testing.T) {
+	testutil.RunLSPFixtures(t, "testdata", func(t *testing.T, fixture *testutil.LSPFixture) {
+		ctx := testhelpers.NewMockServerContext()
+
+		dm, err := document.NewDocumentManager()
+		require.NoError(t, err)
+		defer dm.Close()
+		ctx.SetDocumentManager(dm)
+
+		uri := "file:///my-el.ts"
+		ctx.AddDocument(uri, dm.OpenDocument(uri, fixture.InputContent, 1))
+
+		workspaceDir := filepath.Join("testdata", fixture.Name, "workspace")
+		ctx.SetFileSystem(testutil.LoadTestdataFS(t, workspaceDir, "."))
+		ctx.SetWorkspaceRoot(".")
+
+		var expected []protocol.CodeLens
+		require.NoError(t, fixture.GetExpected("expected", &expected))
+
+		lenses, err := codeLens.CodeLens(ctx, &protocol.CodeLensParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: urilib.URI(uri)},
+		})
+		require.NoError(t, err)
+		for _, lens := range lenses {
+			assert.Empty(t, lens.Command.Title, "usage counts are left to codeLens/resolve")
+		}
+
+		resolved := make([]protocol.CodeLens, 0, len(lenses))
+		for i := range lenses {
+			lens, err := codeLens.Resolve(ctx, &lenses[i])
+			require.NoError(t, err)
+			lens.Data = nil
+			resolved = append(resolved, *lens)
+		}
+		assert.Equal(t, expected, resolved)
+	})
+}
@@ -0,0 +1,8 @@
+import { LitElement } from 'lit';
+import { customElement } from 'lit/decorators.js';
+
+@customElement('my-el')
+export class MyEl extends LitElement {}
+
+class UnusedEl extends HTMLElement {}
+customElements.define('unused-el', UnusedEl);
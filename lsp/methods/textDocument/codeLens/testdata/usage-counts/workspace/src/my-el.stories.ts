@@ -0,0 +1 @@
+html`<my-el>story</my-el>`
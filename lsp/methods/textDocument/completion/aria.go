@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion
+
+import (
+	"fmt"
+	"strings"
+
+	"bennypowers.dev/cem/internal/validations"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+)
+
+// elementRole returns the WAI-ARIA role the manifest declares for an element
+func elementRole(ctx types.ServerContext, tagName string) string {
+	if decl := ctx.FindCustomElementDeclaration(tagName); decl != nil {
+		return decl.Role
+	}
+	return ""
+}
+
+// getARIAAttributeCompletions returns completions for the aria-* attributes
+// the element's role supports, except those the element declares itself.
+// Elements without a declared role get only global ARIA attributes. Values
+// are quoted with quote.
+func getARIAAttributeCompletions(ctx types.ServerContext, tagName string, declared map[string]*M.Attribute, quote string) []protocol.CompletionItem {
+	role := elementRole(ctx, tagName)
+	var items []protocol.CompletionItem
+	for _, attr := range validations.ARIAAttributesForRole(role) {
+		if _, ok := declared[attr.Name]; ok {
+			continue
+		}
+		detail := fmt.Sprintf("ARIA attribute (%s)", attr.Type)
+		if !attr.Global() {
+			detail = fmt.Sprintf("ARIA attribute of role %s (%s)", role, attr.Type)
+		}
+		tabStop := "$0"
+		if choices := attr.ValueChoices(); len(choices) > 0 {
+			escaped := make([]string, len(choices))
+			for i, choice := range choices {
+				escaped[i] = snippetChoiceEscaper.Replace(choice)
+			}
+			tabStop = fmt.Sprintf("${1|%s|}", strings.Join(escaped, ","))
+		}
+		items = append(items, protocol.CompletionItem{
+			Label:            attr.Name,
+			Kind:             protocol.CompletionItemKindProperty,
+			Detail:           protocol.NewOptional(detail),
+			InsertText:       protocol.NewOptional(fmt.Sprintf("%s=%s%s%s", attr.Name, quote, tabStop, quote)),
+			InsertTextFormat: protocol.InsertTextFormatSnippet,
+			// Sort after the element's own attributes
+			SortText: protocol.NewOptional("~" + attr.Name),
+		})
+	}
+	return items
+}
+
+// getARIAValueCompletions returns completions for the value of a role
+// attribute or an aria-* attribute, or nil for other attributes
+func getARIAValueCompletions(attributeName string) []protocol.CompletionItem {
+	var values []string
+	var detail string
+	if attributeName == "role" {
+		values = validations.ARIARoles()
+		detail = "ARIA role"
+	} else if attr, ok := validations.LookupARIAAttribute(attributeName); ok {
+		values = attr.ValueChoices()
+		detail = fmt.Sprintf("%s value", attr.Name)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	items := make([]protocol.CompletionItem, 0, len(values))
+	for _, value := range values {
+		items = append(items, protocol.CompletionItem{
+			Label:      value,
+			Kind:       protocol.CompletionItemKindValue,
+			Detail:     protocol.NewOptional(detail),
+			InsertText: protocol.NewOptional(value),
+		})
+	}
+	return items
+}
@@ -18,9 +18,11 @@ package completion
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"bennypowers.dev/cem/internal/tstype"
+	"bennypowers.dev/cem/lsp/configfile"
 	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/methods/textDocument"
 	"bennypowers.dev/cem/lsp/types"
@@ -42,6 +44,15 @@ func Completion(ctx types.ServerContext, params *protocol.CompletionParams) ([]p
 	}
 	helpers.SafeDebugLog("[COMPLETION] Found document for URI: %s", uri)
 
+	// Files which configure cem get schema-driven completions
+	if kind := configfile.KindOf(uri); kind != configfile.KindNone {
+		content, err := doc.Content()
+		if err != nil {
+			return nil, err
+		}
+		return configfile.Completions(ctx.FileSystem(), kind, uri, content, params.Position), nil
+	}
+
 	// Extract trigger character from the context
 	triggerChar := ""
 	if params.Context.TriggerCharacter != nil {
@@ -71,6 +82,12 @@ func Completion(ctx types.ServerContext, params *protocol.CompletionParams) ([]p
 		helpers.SafeDebugLog("[COMPLETION] Providing tag name completions")
 		return getTagNameCompletions(ctx, doc, analysis), nil
 	case types.CompletionAttributeName:
+		if ctx.Config().VueBindings && !analysis.IsLitTemplate {
+			if vuePrefix, litPrefix, _, ok := helpers.VueBinding(analysis.AttributeName); ok {
+				helpers.SafeDebugLog("[COMPLETION] Providing Vue %s binding completions for element: %s", vuePrefix, analysis.TagName)
+				return getVueBindingCompletions(ctx, analysis.TagName, vuePrefix, litPrefix), nil
+			}
+		}
 		helpers.SafeDebugLog("[COMPLETION] Providing attribute completions for element: %s", analysis.TagName)
 		return GetAttributeCompletionsWithContext(ctx, doc, params.Position, analysis.TagName), nil
 	case types.CompletionAttributeValue:
@@ -87,6 +104,9 @@ func Completion(ctx types.ServerContext, params *protocol.CompletionParams) ([]p
 	case types.CompletionLitBooleanAttribute:
 		helpers.SafeDebugLog("[COMPLETION] Providing Lit boolean attribute completions for element: %s", analysis.TagName)
 		return getLitBooleanAttributeCompletions(ctx, analysis.TagName), nil
+	case types.CompletionImportSpecifier:
+		helpers.SafeDebugLog("[COMPLETION] Providing import specifier completions for prefix: %s", analysis.ImportPrefix)
+		return getImportSpecifierCompletions(ctx, doc, params.Position, analysis.ImportPrefix), nil
 	default:
 		helpers.SafeDebugLog("[COMPLETION] Unknown completion context type: %d", analysis.Type)
 		return []protocol.CompletionItem{}, nil
@@ -160,12 +180,102 @@ func getTagNameCompletions(ctx types.ServerContext, doc types.Document, analysis
 				InsertText:       protocol.NewOptional(snippet),
 				InsertTextFormat: protocol.InsertTextFormatSnippet,
 			})
+
+			if item, ok := createExpandedTagCompletion(tagName, element); ok {
+				items = append(items, item)
+			}
 		}
 	}
 
 	return items
 }
 
+// requiredAttributes returns the attributes of an element which authors must
+// set: those with neither a default value nor a type admitting undefined or
+// null. Boolean and deprecated attributes are never required.
+func requiredAttributes(element *M.CustomElement) []M.Attribute {
+	var required []M.Attribute
+	for _, attr := range element.Attributes {
+		if attr.Default != "" || attr.IsDeprecated() {
+			continue
+		}
+		if attr.Type == nil || attr.Type.Text == "" || strings.ToLower(attr.Type.Text) == "boolean" {
+			continue
+		}
+		optional := false
+		for _, part := range tstype.SplitTopLevelUnion(attr.Type.Text) {
+			if part == "undefined" || part == "null" {
+				optional = true
+				break
+			}
+		}
+		if !optional {
+			required = append(required, attr)
+		}
+	}
+	return required
+}
+
+// createExpandedTagCompletion creates an emmet-style completion which
+// expands to the element with a tab stop for each of its required
+// attributes, offering the values of string literal union types as choices,
+// and a tab stop for its default slot. Returns false when the element has
+// no required attributes.
+func createExpandedTagCompletion(tagName string, element *M.CustomElement) (protocol.CompletionItem, bool) {
+	required := requiredAttributes(element)
+	if len(required) == 0 {
+		return protocol.CompletionItem{}, false
+	}
+
+	var snippet strings.Builder
+	names := make([]string, len(required))
+	snippet.WriteString("<" + tagName)
+	for i, attr := range required {
+		names[i] = attr.Name
+		fmt.Fprintf(&snippet, ` %s="%s"`, attr.Name, attributeValueTabStop(i+1, attr))
+	}
+	snippet.WriteString(">")
+
+	hasDefaultSlot := slices.ContainsFunc(element.Slots, func(slot M.Slot) bool {
+		return slot.Name == ""
+	})
+	if hasDefaultSlot {
+		fmt.Fprintf(&snippet, "${%d}</%s>$0", len(required)+1, tagName)
+	} else {
+		fmt.Fprintf(&snippet, "$0</%s>", tagName)
+	}
+
+	data, _ := protocol.Marshal(createCompletionData("tag", tagName, ""))
+	return protocol.CompletionItem{
+		Label:            fmt.Sprintf("%s[%s]", tagName, strings.Join(names, " ")),
+		Kind:             protocol.CompletionItemKindClass,
+		Detail:           protocol.NewOptional(fmt.Sprintf("Custom element: %s with required attributes", tagName)),
+		Data:             data,
+		FilterText:       protocol.NewOptional(tagName),
+		InsertText:       protocol.NewOptional(snippet.String()),
+		InsertTextFormat: protocol.InsertTextFormatSnippet,
+	}, true
+}
+
+// attributeValueTabStop returns the numbered snippet tab stop for an
+// attribute's value, as a choice when its type is a union of string literals
+func attributeValueTabStop(n int, attr M.Attribute) string {
+	var choices []string
+	for _, part := range tstype.SplitTopLevelUnion(attr.Type.Text) {
+		if !isQuotedLiteral(part) {
+			return fmt.Sprintf("${%d}", n)
+		}
+		choices = append(choices, snippetChoiceEscaper.Replace(part[1:len(part)-1]))
+	}
+	if len(choices) < 2 {
+		return fmt.Sprintf("${%d}", n)
+	}
+	return fmt.Sprintf("${%d|%s|}", n, strings.Join(choices, ","))
+}
+
+// snippetChoiceEscaper escapes the characters which are special in snippet choices
+var snippetChoiceEscaper = strings.NewReplacer(`\`, `\\`, `$`, `\$`, `}`, `\}`, `,`, `\,`, `|`, `\|`)
+
 // GetAttributeCompletions returns completions for attributes of a specific element
 func GetAttributeCompletions(ctx types.ServerContext, tagName string) []protocol.CompletionItem {
 	return GetAttributeCompletionsWithContext(ctx, nil, protocol.Position{}, tagName)
@@ -175,6 +285,7 @@ func GetAttributeCompletions(ctx types.ServerContext, tagName string) []protocol
 func GetAttributeCompletionsWithContext(ctx types.ServerContext, doc types.Document, position protocol.Position, tagName string) []protocol.CompletionItem {
 	var items []protocol.CompletionItem
 	helpers.SafeDebugLog("[COMPLETION] getAttributeCompletions called for tagName: '%s'", tagName)
+	quote := attributeQuote(doc)
 
 	// Only provide attribute completions for custom elements
 	if tagName == "" || !helpers.IsCustomElementTag(tagName) {
@@ -186,14 +297,20 @@ func GetAttributeCompletionsWithContext(ctx types.ServerContext, doc types.Docum
 		if doc != nil {
 			if shouldSuggest, parentTagName := shouldSuggestSlotAttribute(ctx, doc, position); shouldSuggest {
 				helpers.SafeDebugLog("[COMPLETION] Adding slot attribute suggestion for non-custom element")
-				items = append(items, createSlotAttributeCompletion(parentTagName))
+				items = append(items, createSlotAttributeCompletion(parentTagName, quote))
 			}
 		}
 
+		// Suggest the is attribute if customized built-in elements extend this element
+		if len(helpers.CustomizedBuiltIns(ctx, tagName)) > 0 {
+			items = append(items, createIsAttributeCompletion(tagName, quote))
+		}
+
 		return items
 	}
 
-	if attrs, exists := ctx.Attributes(tagName); exists {
+	attrs, exists := ctx.Attributes(tagName)
+	if exists {
 		helpers.SafeDebugLog("[COMPLETION] Found %d attributes for element '%s'", len(attrs), tagName)
 		for attrName, attr := range attrs {
 			var snippet string
@@ -205,7 +322,7 @@ func GetAttributeCompletionsWithContext(ctx types.ServerContext, doc types.Docum
 				// No insert text format needed for plain text
 			} else {
 				// For non-boolean attributes, use the value snippet
-				snippet = fmt.Sprintf("%s=\"$0\"", attrName)
+				snippet = fmt.Sprintf("%s=%s$0%s", attrName, quote, quote)
 				insertTextFormat = protocol.InsertTextFormatSnippet
 			}
 
@@ -232,11 +349,14 @@ func GetAttributeCompletionsWithContext(ctx types.ServerContext, doc types.Docum
 		helpers.SafeDebugLog("[COMPLETION] No attributes found for element '%s' in registry", tagName)
 	}
 
+	// Add the aria-* attributes the element's role supports
+	items = append(items, getARIAAttributeCompletions(ctx, tagName, attrs, quote)...)
+
 	// Add slot attribute suggestion if this element is a child of a custom element with slots
 	if doc != nil {
 		if shouldSuggest, parentTagName := shouldSuggestSlotAttribute(ctx, doc, position); shouldSuggest {
 			helpers.SafeDebugLog("[COMPLETION] Adding slot attribute suggestion for custom element")
-			items = append(items, createSlotAttributeCompletion(parentTagName))
+			items = append(items, createSlotAttributeCompletion(parentTagName, quote))
 		}
 	}
 
@@ -258,13 +378,31 @@ func GetAttributeValueCompletionsWithContext(ctx types.ServerContext, doc types.
 		return getSlotAttributeCompletions(ctx, doc, position)
 	}
 
+	// Handle is attribute specially - provide customized built-ins extending the element
+	if attributeName == "is" {
+		return getIsAttributeCompletions(ctx, tagName)
+	}
+
+	// Handle style attribute specially - provide the element's CSS custom properties
+	if attributeName == "style" {
+		return getStyleCompletions(ctx, doc, position, tagName)
+	}
+
 	// Only provide value completions for custom elements
 	if tagName == "" || !helpers.IsCustomElementTag(tagName) || attributeName == "" {
 		return items
 	}
 
+	// ARIA attributes which the element doesn't declare get values from ARIA
+	attrs, exists := ctx.Attributes(tagName)
+	if _, declared := attrs[attributeName]; !declared {
+		if ariaItems := getARIAValueCompletions(attributeName); ariaItems != nil {
+			return ariaItems
+		}
+	}
+
 	// Get the attribute definition
-	if attrs, exists := ctx.Attributes(tagName); exists {
+	if exists {
 		if attr, attrExists := attrs[attributeName]; attrExists {
 			// For boolean attributes, don't provide value completions
 			// Their presence means true, absence means false
@@ -647,6 +785,22 @@ func getLitBooleanAttributeCompletions(ctx types.ServerContext, tagName string)
 	return items
 }
 
+// getVueBindingCompletions returns completions for Vue event bindings
+// (@event-name, v-on:event-name) and property bindings (:property,
+// v-bind:property), labeled with the prefix which was typed
+func getVueBindingCompletions(ctx types.ServerContext, tagName, vuePrefix, litPrefix string) []protocol.CompletionItem {
+	var items []protocol.CompletionItem
+	if litPrefix == "@" {
+		items = getLitEventCompletions(ctx, tagName)
+	} else {
+		items = getLitPropertyCompletions(ctx, tagName)
+	}
+	for i := range items {
+		items[i].Label = vuePrefix + strings.TrimPrefix(items[i].Label, litPrefix)
+	}
+	return items
+}
+
 // startsWithIgnoreCase checks if a string starts with a prefix, ignoring case
 func startsWithIgnoreCase(s, prefix string) bool {
 	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
@@ -695,11 +849,17 @@ func getSlotAttributeCompletions(ctx types.ServerContext, doc types.Document, po
 		}
 
 		detail := fmt.Sprintf("Slot for <%s>", parentTagName)
+		insertText := slot.Name
+		if slot.Pattern {
+			// Insert the fixed part of a pattern like "item-*", for the
+			// user to complete
+			insertText, _, _ = strings.Cut(slot.Name, "*")
+		}
 		items = append(items, protocol.CompletionItem{
 			Label:      slot.Name,
 			Kind:       valueKind,
 			Detail:     protocol.NewOptional(detail),
-			InsertText: protocol.NewOptional(slot.Name),
+			InsertText: protocol.NewOptional(insertText),
 		})
 	}
 
@@ -918,9 +1078,22 @@ func shouldSuggestSlotAttribute(ctx types.ServerContext, doc types.Document, pos
 	return false, ""
 }
 
+// attributeQuote returns the quote which the document's attribute values
+// use, so that inserted attributes match them
+func attributeQuote(doc types.Document) string {
+	if doc == nil {
+		return `"`
+	}
+	content, err := doc.Content()
+	if err != nil {
+		return `"`
+	}
+	return helpers.AttributeQuote(content)
+}
+
 // createSlotAttributeCompletion creates a completion item for the slot attribute
-func createSlotAttributeCompletion(parentTagName string) protocol.CompletionItem {
-	snippet := `slot="$0"`
+func createSlotAttributeCompletion(parentTagName, quote string) protocol.CompletionItem {
+	snippet := fmt.Sprintf("slot=%s$0%s", quote, quote)
 	insertTextFormat := protocol.InsertTextFormatSnippet
 
 	data, _ := protocol.Marshal(createCompletionData("attribute", parentTagName, "slot"))
@@ -933,3 +1106,37 @@ func createSlotAttributeCompletion(parentTagName string) protocol.CompletionItem
 		InsertTextFormat: insertTextFormat,
 	}
 }
+
+// createIsAttributeCompletion creates a completion item for the is attribute
+func createIsAttributeCompletion(tagName, quote string) protocol.CompletionItem {
+	return protocol.CompletionItem{
+		Label:            "is",
+		Kind:             protocol.CompletionItemKindProperty,
+		Detail:           protocol.NewOptional(fmt.Sprintf("Customized built-in element for <%s>", tagName)),
+		InsertText:       protocol.NewOptional(fmt.Sprintf("is=%s$0%s", quote, quote)),
+		InsertTextFormat: protocol.InsertTextFormatSnippet,
+	}
+}
+
+// getIsAttributeCompletions returns completions for is attribute values:
+// the customized built-in elements which extend the element
+func getIsAttributeCompletions(ctx types.ServerContext, tagName string) []protocol.CompletionItem {
+	var items []protocol.CompletionItem
+	for _, builtIn := range helpers.CustomizedBuiltIns(ctx, tagName) {
+		item := protocol.CompletionItem{
+			Label:      builtIn,
+			Kind:       protocol.CompletionItemKindClass,
+			Detail:     protocol.NewOptional(fmt.Sprintf("Customized built-in <%s>", tagName)),
+			InsertText: protocol.NewOptional(builtIn),
+		}
+		if description, ok := ctx.ElementDescription(builtIn); ok && description != "" {
+			item.Documentation = &protocol.MarkupContent{
+				Kind:  protocol.MarkupKindMarkdown,
+				Value: description,
+			}
+		}
+		items = append(items, item)
+	}
+	helpers.SafeDebugLog("[COMPLETION] Returning %d customized built-in completions for <%s>", len(items), tagName)
+	return items
+}
@@ -290,9 +290,9 @@ func TestCompletionIntegration(t *testing.T) {
 
 		items := result
 
-		// Should contain the test element
-		if len(items) != 1 {
-			t.Errorf("Expected 1 completion items, got %d", len(items))
+		// Should contain the test element, and its expansion with required attributes
+		if len(items) != 2 {
+			t.Errorf("Expected 2 completion items, got %d", len(items))
 		}
 
 		// Check that we have the expected element
@@ -317,6 +317,9 @@ func TestCompletionIntegration(t *testing.T) {
 
 		// Verify that snippets include proper < and > characters
 		for _, item := range items {
+			if strings.Contains(item.Label, "[") {
+				continue // Expansion with required attributes
+			}
 			if !item.InsertText.IsZero() {
 				insertText, _ := item.InsertText.Get()
 				// Should start with < and include closing >
@@ -430,9 +433,9 @@ func TestCompletionIntegration(t *testing.T) {
 
 		items := result
 
-		// Should contain the test element
-		if len(items) != 1 {
-			t.Errorf("Expected 1 completion items, got %d", len(items))
+		// Should contain the test element, and its expansion with required attributes
+		if len(items) != 2 {
+			t.Errorf("Expected 2 completion items, got %d", len(items))
 		}
 
 		// Verify that when trigger character is <, we don't get <<tag-name
@@ -443,6 +446,14 @@ func TestCompletionIntegration(t *testing.T) {
 				if strings.HasPrefix(insertText, "<<") {
 					t.Errorf("Expected InsertText not to start with '<<' when trigger char is '<', got %q for %s", insertText, item.Label)
 				}
+				// The expansion fills in the element's required attributes
+				tagName, _, expanded := strings.Cut(item.Label, "[")
+				if expanded {
+					if !strings.HasPrefix(insertText, "<"+tagName+" ") || !strings.HasSuffix(insertText, ">$0</"+tagName+">") {
+						t.Errorf("Expected InsertText to expand <%s> with its attributes, got %q", tagName, insertText)
+					}
+					continue
+				}
 				// Should still be a proper snippet
 				expectedSnippet := fmt.Sprintf("<%s>$0</%s>", item.Label, item.Label)
 				if insertText != expectedSnippet {
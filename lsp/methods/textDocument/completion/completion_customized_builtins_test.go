@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/methods/textDocument/completion"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+)
+
+func TestCustomizedBuiltInCompletions(t *testing.T) {
+	fs := testutil.NewFixtureFS(t, "customized-builtins-test", "/test")
+	manifestBytes, err := fs.ReadFile("/test/manifest.json")
+	if err != nil {
+		t.Fatalf("Failed to read test manifest: %v", err)
+	}
+	var pkg M.Package
+	if err := json.Unmarshal(manifestBytes, &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	ctx := testhelpers.NewMockServerContext()
+	ctx.AddManifest(&pkg)
+
+	labels := func(items []protocol.CompletionItem) []string {
+		result := []string{}
+		for _, item := range items {
+			result = append(result, item.Label)
+		}
+		return result
+	}
+
+	t.Run("is attribute values", func(t *testing.T) {
+		tests := []struct {
+			tagName  string
+			expected []string
+		}{
+			{"button", []string{"fancy-button"}},
+			{"input", []string{"fancy-input"}},
+			{"div", []string{}},
+		}
+		for _, tt := range tests {
+			got := labels(completion.GetAttributeValueCompletions(ctx, tt.tagName, "is"))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("<%s is>: expected %v, got %v", tt.tagName, tt.expected, got)
+			}
+		}
+	})
+
+	t.Run("is attribute name", func(t *testing.T) {
+		if got := labels(completion.GetAttributeCompletions(ctx, "button")); !slices.Contains(got, "is") {
+			t.Errorf("Expected is attribute completion for <button>, got %v", got)
+		}
+		if got := labels(completion.GetAttributeCompletions(ctx, "div")); slices.Contains(got, "is") {
+			t.Errorf("Expected no is attribute completion for <div>, got %v", got)
+		}
+	})
+}
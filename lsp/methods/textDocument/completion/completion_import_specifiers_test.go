@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/completion"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: testing presence/absence of specific labels and edit ranges in
+// completion results, with a small virtual workspace
+func TestImportSpecifierCompletion(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/ws/src/my-card.ts", "export class MyCard extends HTMLElement {}", 0644)
+	ctx.SetFileSystem(fsys)
+	ctx.SetWorkspaceRoot("/ws")
+
+	ctx.AddElement("ds-button", &M.CustomElement{})
+	ctx.AddElementDefinition("ds-button", &testhelpers.MockElementDefinition{
+		ModulePathStr:  "@ds/elements/ds-button.js",
+		PackageNameStr: "@ds/elements",
+	})
+	ctx.AddElement("my-card", &M.CustomElement{})
+	ctx.AddElementDefinition("my-card", &testhelpers.MockElementDefinition{
+		ModulePathStr: "src/my-card.js",
+	})
+
+	complete := func(t *testing.T, content string, character uint32) map[string]protocol.CompletionItem {
+		t.Helper()
+		docURI := "file:///ws/src/app.ts"
+		doc := dm.OpenDocument(docURI, content, 1)
+		ctx.AddDocument(docURI, doc)
+		items, err := completion.Completion(ctx, &protocol.CompletionParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: uri.URI(docURI)},
+				Position:     protocol.Position{Line: 0, Character: character},
+			},
+		})
+		require.NoError(t, err)
+		byLabel := make(map[string]protocol.CompletionItem)
+		for _, item := range items {
+			byLabel[item.Label] = item
+		}
+		return byLabel
+	}
+
+	t.Run("side-effect import", func(t *testing.T) {
+		byLabel := complete(t, "import '';", 8)
+		assert.Contains(t, byLabel, "@ds/elements/ds-button.js", "should offer the bare specifier")
+		assert.Contains(t, byLabel, "./my-card.js", "should offer the workspace-relative path")
+		assert.NotContains(t, byLabel, "src/my-card.js", "should not offer a bare specifier without a package")
+	})
+
+	t.Run("named import filters by prefix", func(t *testing.T) {
+		content := "import { DsButton } from '@ds/';"
+		byLabel := complete(t, content, 30)
+		require.Contains(t, byLabel, "@ds/elements/ds-button.js")
+		assert.NotContains(t, byLabel, "./my-card.js")
+
+		data, err := protocol.Marshal(byLabel["@ds/elements/ds-button.js"])
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"newText":"@ds/elements/ds-button.js"`)
+		assert.Contains(t, string(data), `"start":{"line":0,"character":26}`, "should replace the typed prefix")
+	})
+
+	t.Run("dynamic import", func(t *testing.T) {
+		byLabel := complete(t, "await import('./');", 16)
+		assert.Contains(t, byLabel, "./my-card.js")
+	})
+
+	t.Run("other strings", func(t *testing.T) {
+		byLabel := complete(t, "const specifier = '';", 19)
+		assert.Empty(t, byLabel)
+	})
+}
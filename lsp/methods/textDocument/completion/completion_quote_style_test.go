@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/completion"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+)
+
+// TestAttributeCompletionQuoteStyle verifies inserted attributes follow the
+// quotes which the document's attributes use
+func TestAttributeCompletionQuoteStyle(t *testing.T) {
+	fs := testutil.NewFixtureFS(t, "legacy/slot-attribute-regression", "/test")
+	manifestBytes, err := fs.ReadFile("/test/manifest.json")
+	if err != nil {
+		t.Fatalf("Failed to read test manifest: %v", err)
+	}
+	var pkg M.Package
+	if err := json.Unmarshal(manifestBytes, &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	ctx := testhelpers.NewMockServerContext()
+	ctx.AddManifest(&pkg)
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	tests := []struct {
+		name    string
+		content string
+		variant string
+		slot    string
+	}{
+		{"double quotes", `<card-layout class="grid"><icon-button `, `variant="$0"`, `slot="$0"`},
+		{"single quotes", `<card-layout class='grid'><icon-button `, `variant='$0'`, `slot='$0'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := dm.OpenDocument("test://"+tt.name+".html", tt.content, 1)
+			position := protocol.Position{Line: 0, Character: uint32(len(tt.content))}
+			insertText := map[string]string{}
+			for _, item := range completion.GetAttributeCompletionsWithContext(ctx, doc, position, "icon-button") {
+				insertText[item.Label], _ = item.InsertText.Get()
+			}
+			if got := insertText["variant"]; got != tt.variant {
+				t.Errorf("Expected variant to insert %q, got %q", tt.variant, got)
+			}
+			if got := insertText["slot"]; got != tt.slot {
+				t.Errorf("Expected slot to insert %q, got %q", tt.slot, got)
+			}
+		})
+	}
+}
@@ -196,6 +196,61 @@ func TestStartTagCompletion(t *testing.T) {
 	})
 }
 
+// TestStartTagCompletion_RequiredAttributes tests the completion which
+// expands to an element with its required attributes as tab stops
+func TestStartTagCompletion_RequiredAttributes(t *testing.T) {
+	fs := testutil.NewFixtureFS(t, "html-tag-completions/required-attributes", "/test")
+	manifestBytes, err := fs.ReadFile("/test/manifest.json")
+	if err != nil {
+		t.Fatalf("Failed to read test manifest: %v", err)
+	}
+	var pkg M.Package
+	if err := json.Unmarshal(manifestBytes, &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	ctx := testhelpers.NewMockServerContext()
+	ctx.AddManifest(&pkg)
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	doc := dm.OpenDocument("test://test.html", "<al", 1)
+	ctx.AddDocument("test://test.html", doc)
+
+	items, err := completion.Completion(ctx, &protocol.CompletionParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: "test://test.html"},
+			Position:     protocol.Position{Line: 0, Character: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+
+	var expansion *protocol.CompletionItem
+	for i := range items {
+		if items[i].Label == "alert-element[state heading]" {
+			expansion = &items[i]
+		}
+	}
+	if expansion == nil {
+		t.Fatalf("Expected expansion with required attributes, got %v", getCompletionLabels(items))
+	}
+
+	insertText, _ := expansion.InsertText.Get()
+	expected := `<alert-element state="${1|info,warning,danger|}" heading="${2}">${3}</alert-element>$0`
+	if insertText != expected {
+		t.Errorf("Expected InsertText %q, got %q", expected, insertText)
+	}
+	if filterText, _ := expansion.FilterText.Get(); filterText != "alert-element" {
+		t.Errorf("Expected FilterText %q, got %q", "alert-element", filterText)
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/completion"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+func TestStyleAttributeCompletions(t *testing.T) {
+	fs := testutil.NewFixtureFS(t, "style-completions-test", "/test")
+	manifestBytes, err := fs.ReadFile("/test/manifest.json")
+	require.NoError(t, err)
+	var pkg M.Package
+	require.NoError(t, json.Unmarshal(manifestBytes, &pkg))
+
+	ctx := testhelpers.NewMockServerContext()
+	ctx.AddManifest(&pkg)
+
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	complete := func(t *testing.T, tagName, html string) map[string]protocol.CompletionItem {
+		t.Helper()
+		doc := dm.OpenDocument("test://test.html", html, 1)
+		position := protocol.Position{Line: 0, Character: uint32(len(html))}
+		items := completion.GetAttributeValueCompletionsWithContext(ctx, doc, position, tagName, "style")
+		byLabel := make(map[string]protocol.CompletionItem)
+		for _, item := range items {
+			byLabel[item.Label] = item
+		}
+		return byLabel
+	}
+
+	t.Run("empty style", func(t *testing.T) {
+		byLabel := complete(t, "my-card", `<my-card style="`)
+		assert.Contains(t, byLabel, "--my-card-padding")
+		assert.Contains(t, byLabel, "--my-card-size")
+	})
+
+	t.Run("replaces the typed prefix", func(t *testing.T) {
+		byLabel := complete(t, "my-card", `<my-card style="color: red; --my-card-s`)
+		require.Contains(t, byLabel, "--my-card-size")
+		assert.NotContains(t, byLabel, "--my-card-padding")
+
+		data, err := protocol.Marshal(byLabel["--my-card-size"])
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"newText":"--my-card-size: "`)
+		assert.Contains(t, string(data), `"start":{"line":0,"character":28}`, "should replace the typed prefix")
+	})
+
+	t.Run("keyword values", func(t *testing.T) {
+		byLabel := complete(t, "my-card", `<my-card style="--my-card-size: `)
+		assert.Len(t, byLabel, 3)
+		assert.Contains(t, byLabel, "small")
+		assert.Contains(t, byLabel, "medium")
+		assert.Contains(t, byLabel, "large")
+	})
+
+	t.Run("default value", func(t *testing.T) {
+		byLabel := complete(t, "my-card", `<my-card style="--my-card-padding: `)
+		assert.Len(t, byLabel, 1)
+		assert.Contains(t, byLabel, "16px")
+	})
+
+	t.Run("standard property", func(t *testing.T) {
+		assert.Empty(t, complete(t, "my-card", `<my-card style="col`))
+	})
+
+	t.Run("built-in element", func(t *testing.T) {
+		assert.Empty(t, complete(t, "div", `<div style="--`))
+	})
+}
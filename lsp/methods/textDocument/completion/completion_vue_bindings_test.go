@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/completion"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// Inline: testing presence/absence of specific labels with the vueBindings
+// setting on and off -- see TestLitPropertyCompletionUsesFieldName.
+func TestVueBindingCompletions(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	ctx.AddElement("my-counter", &M.CustomElement{
+		Events: []M.Event{{FullyQualified: M.FullyQualified{Name: "count-change"}}},
+	})
+	ctx.AddFields("my-counter", map[string]*M.ClassField{
+		"maxCount": {PropertyLike: M.PropertyLike{FullyQualified: M.FullyQualified{Name: "maxCount"}}},
+	})
+	ctx.AddAttributes("my-counter", map[string]*M.Attribute{
+		"max-count": {FullyQualified: M.FullyQualified{Name: "max-count"}},
+	})
+
+	complete := func(binding string) map[string]protocol.CompletionItem {
+		t.Helper()
+		content := "<template><my-counter " + binding + "></my-counter></template>"
+		docURI := "file:///src/App.vue"
+		doc := dm.OpenDocument(docURI, content, 1)
+		ctx.AddDocument(docURI, doc)
+
+		result, err := completion.Completion(ctx, &protocol.CompletionParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: uri.URI(docURI)},
+				Position:     protocol.Position{Line: 0, Character: uint32(len("<template><my-counter " + binding))},
+			},
+		})
+		require.NoError(t, err)
+
+		byLabel := make(map[string]protocol.CompletionItem)
+		for _, item := range result {
+			byLabel[item.Label] = item
+		}
+		return byLabel
+	}
+
+	assert.NotContains(t, complete("@"), "@count-change", "should not offer Vue bindings unless enabled")
+
+	config := ctx.Config()
+	config.VueBindings = true
+	ctx.SetConfig(config)
+
+	events := complete("@")
+	assert.Contains(t, events, "@count-change", "should offer events after @")
+	assert.NotContains(t, events, "max-count", "should not offer attributes after @")
+	if item, ok := events["@count-change"]; ok {
+		v, _ := item.InsertText.Get()
+		assert.Equal(t, "count-change", v, "InsertText should be the event name")
+	}
+
+	assert.Contains(t, complete("v-on:"), "v-on:count-change", "should offer events after v-on:")
+
+	properties := complete(":")
+	assert.Contains(t, properties, ":maxCount", "should offer properties after :")
+	assert.NotContains(t, properties, "@count-change", "should not offer events after :")
+
+	assert.Contains(t, complete("v-bind:"), "v-bind:maxCount", "should offer properties after v-bind:")
+	assert.Contains(t, complete(""), "max-count", "should still offer attributes")
+}
@@ -0,0 +1,152 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// textEditItem mirrors the wire format of a completion item with a text
+// edit. The protocol package models text edits as a union, so the item is
+// built in wire format and decoded into a protocol.CompletionItem.
+type textEditItem struct {
+	Label    string                      `json:"label"`
+	Kind     protocol.CompletionItemKind `json:"kind"`
+	Detail   string                      `json:"detail,omitempty"`
+	SortText string                      `json:"sortText,omitempty"`
+	TextEdit protocol.TextEdit           `json:"textEdit"`
+}
+
+// completionItem decodes the item into a protocol.CompletionItem
+func (item textEditItem) completionItem() (protocol.CompletionItem, error) {
+	var completion protocol.CompletionItem
+	data, err := protocol.Marshal(item)
+	if err != nil {
+		return completion, err
+	}
+	err = protocol.Unmarshal(data, &completion)
+	return completion, err
+}
+
+// getImportSpecifierCompletions returns completions for the module specifiers
+// of known component modules, inside an import statement's string literal.
+// Each module is offered by its bare package specifier and, when it lives in
+// the workspace, by its path relative to the importing document.
+func getImportSpecifierCompletions(ctx types.ServerContext, doc types.Document, position protocol.Position, prefix string) []protocol.CompletionItem {
+	// Module specifier to the tag names it defines
+	specifiers := make(map[string][]string)
+	// Relative specifiers sort before bare ones
+	relative := make(map[string]bool)
+
+	for _, tagName := range ctx.AllTagNames() {
+		definition, ok := ctx.ElementDefinition(tagName)
+		if !ok {
+			continue
+		}
+		if definition.PackageName() != "" {
+			if source, ok := ctx.ElementSource(tagName); ok {
+				specifiers[source] = append(specifiers[source], tagName)
+			}
+		}
+		if specifier, ok := relativeModuleSpecifier(ctx, doc.URI(), definition.ModulePath()); ok {
+			specifiers[specifier] = append(specifiers[specifier], tagName)
+			relative[specifier] = true
+		}
+	}
+
+	// Replace the specifier typed so far
+	editRange := protocol.Range{
+		Start: protocol.Position{
+			Line:      position.Line,
+			Character: position.Character - uint32(len(utf16.Encode([]rune(prefix)))),
+		},
+		End: position,
+	}
+
+	var items []protocol.CompletionItem
+	for _, specifier := range slices.Sorted(maps.Keys(specifiers)) {
+		if !strings.HasPrefix(specifier, prefix) {
+			continue
+		}
+		tagNames := specifiers[specifier]
+		slices.Sort(tagNames)
+		sortText := "1" + specifier
+		if relative[specifier] {
+			sortText = "0" + specifier
+		}
+		item, err := textEditItem{
+			Label:    specifier,
+			Kind:     protocol.CompletionItemKindModule,
+			Detail:   "Defines <" + strings.Join(tagNames, ">, <") + ">",
+			SortText: sortText,
+			TextEdit: protocol.TextEdit{Range: editRange, NewText: specifier},
+		}.completionItem()
+		if err != nil {
+			helpers.SafeDebugLog("[COMPLETION] Failed to build import specifier completion: %v", err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// relativeModuleSpecifier returns the path of a workspace module relative to
+// the importing document, e.g. "./my-button.js" or "../elements/my-button.js".
+// Modules which are not in the workspace have no relative specifier.
+func relativeModuleSpecifier(ctx types.ServerContext, docURI, modulePath string) (string, bool) {
+	root := ctx.WorkspaceRoot()
+	if root == "" || modulePath == "" || !strings.HasPrefix(docURI, "file://") {
+		return "", false
+	}
+
+	modulePath = filepath.FromSlash(modulePath)
+	if !filepath.IsAbs(modulePath) {
+		modulePath = filepath.Join(root, modulePath)
+	}
+
+	// Manifests reference compiled .js modules, whose .ts sources may be
+	// all that exist in the workspace
+	fsys := ctx.FileSystem()
+	source := strings.TrimSuffix(modulePath, filepath.Ext(modulePath)) + ".ts"
+	if !fsys.Exists(modulePath) && !fsys.Exists(source) {
+		return "", false
+	}
+
+	docPath := filepath.FromSlash(strings.TrimPrefix(docURI, "file://"))
+	if strings.TrimSuffix(docPath, filepath.Ext(docPath)) == strings.TrimSuffix(modulePath, filepath.Ext(modulePath)) {
+		// A module doesn't import itself
+		return "", false
+	}
+	specifier, err := filepath.Rel(filepath.Dir(docPath), modulePath)
+	if err != nil {
+		return "", false
+	}
+	specifier = filepath.ToSlash(specifier)
+	if !strings.HasPrefix(specifier, "../") {
+		specifier = "./" + specifier
+	}
+	return specifier, true
+}
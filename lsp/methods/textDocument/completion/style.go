@@ -0,0 +1,155 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package completion
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"bennypowers.dev/cem/internal/textutil"
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+)
+
+// getStyleCompletions returns completions inside a custom element's style
+// attribute: the names of the CSS custom properties the element declares,
+// and after one of them, the keywords its syntax allows
+func getStyleCompletions(ctx types.ServerContext, doc types.Document, position protocol.Position, tagName string) []protocol.CompletionItem {
+	if doc == nil || !helpers.IsCustomElementTag(tagName) {
+		return nil
+	}
+	element, ok := ctx.Element(tagName)
+	if !ok || len(element.CssProperties) == 0 {
+		return nil
+	}
+	declaration, ok := styleDeclarationBeforeCursor(doc, position)
+	if !ok {
+		return nil
+	}
+	if property, _, isValue := strings.Cut(declaration, ":"); isValue {
+		return getCSSPropertyValueCompletions(element, strings.TrimSpace(property))
+	}
+	return getCSSPropertyNameCompletions(element, strings.TrimLeft(declaration, " \t\r\n"), position)
+}
+
+// styleDeclarationBeforeCursor returns the text of the style declaration
+// being typed, from the end of the previous declaration, or the opening
+// quote of the attribute value, to the cursor
+func styleDeclarationBeforeCursor(doc types.Document, position protocol.Position) (string, bool) {
+	content, err := doc.Content()
+	if err != nil {
+		return "", false
+	}
+	lines := strings.Split(content, "\n")
+	if int(position.Line) >= len(lines) {
+		return "", false
+	}
+	offset := 0
+	for _, line := range lines[:position.Line] {
+		offset += len(line) + 1
+	}
+	offset += int(textutil.UTF16ToByteOffset(lines[position.Line], position.Character))
+
+	before := content[:offset]
+	quote := strings.LastIndexAny(before, `"'`)
+	if quote == -1 {
+		return "", false
+	}
+	value := before[quote+1:]
+	if semicolon := strings.LastIndex(value, ";"); semicolon != -1 {
+		value = value[semicolon+1:]
+	}
+	return value, true
+}
+
+// getCSSPropertyNameCompletions offers the element's custom properties,
+// replacing the prefix typed so far, e.g. "--my-"
+func getCSSPropertyNameCompletions(element *M.CustomElement, prefix string, position protocol.Position) []protocol.CompletionItem {
+	if strings.ContainsAny(prefix, " \t\r\n") ||
+		!strings.HasPrefix(prefix, "--") && !strings.HasPrefix("--", prefix) {
+		// Not a custom property name, e.g. a standard property like "color"
+		return nil
+	}
+
+	editRange := protocol.Range{
+		Start: protocol.Position{
+			Line:      position.Line,
+			Character: position.Character - uint32(len(utf16.Encode([]rune(prefix)))),
+		},
+		End: position,
+	}
+
+	var items []protocol.CompletionItem
+	for _, property := range element.CssProperties {
+		if !strings.HasPrefix(property.Name, "--") || !strings.HasPrefix(property.Name, prefix) {
+			continue
+		}
+		detail := fmt.Sprintf("CSS property of <%s>", element.TagName)
+		if property.Syntax != "" {
+			detail = fmt.Sprintf("%s: %s", detail, property.Syntax)
+		}
+		item, err := textEditItem{
+			Label:    property.Name,
+			Kind:     protocol.CompletionItemKindProperty,
+			Detail:   detail,
+			TextEdit: protocol.TextEdit{Range: editRange, NewText: property.Name + ": "},
+		}.completionItem()
+		if err != nil {
+			helpers.SafeDebugLog("[COMPLETION] Failed to build CSS property completion: %v", err)
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// getCSSPropertyValueCompletions offers the keywords a declared custom
+// property's syntax allows, and its default value
+func getCSSPropertyValueCompletions(element *M.CustomElement, name string) []protocol.CompletionItem {
+	index := slices.IndexFunc(element.CssProperties, func(property M.CssCustomProperty) bool {
+		return property.Name == name
+	})
+	if index == -1 {
+		return nil
+	}
+	property := element.CssProperties[index]
+
+	valueKind := protocol.CompletionItemKindValue
+	var items []protocol.CompletionItem
+	keywords := helpers.CSSSyntaxKeywords(property.Syntax)
+	for _, keyword := range keywords {
+		items = append(items, protocol.CompletionItem{
+			Label:      keyword,
+			Kind:       valueKind,
+			Detail:     protocol.NewOptional(fmt.Sprintf("%s: %s", name, property.Syntax)),
+			InsertText: protocol.NewOptional(keyword),
+		})
+	}
+	if property.Default != "" && !slices.Contains(keywords, property.Default) {
+		items = append(items, protocol.CompletionItem{
+			Label:      property.Default,
+			Kind:       valueKind,
+			Detail:     protocol.NewOptional(fmt.Sprintf("Default value of %s", name)),
+			InsertText: protocol.NewOptional(property.Default),
+		})
+	}
+	return items
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package documentHighlight
+
+import (
+	"sort"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// attributeTarget identifies one attribute of one element, under both the
+// names it can be written with in markup: the HTML attribute name (plain or
+// Lit `?attr`), and the backing property name (Lit `.prop`).
+type attributeTarget struct {
+	tagName       string
+	attributeName string
+	propertyName  string
+	eventName     string
+}
+
+// DocumentHighlight handles textDocument/documentHighlight requests. With the
+// cursor on an attribute name, it highlights every usage of that attribute on
+// elements of the same tag in the document, including Lit `.prop` and `?attr`
+// bindings of the same attribute.
+func DocumentHighlight(ctx types.ServerContext, params *protocol.DocumentHighlightParams) ([]protocol.DocumentHighlight, error) {
+	uri := string(params.TextDocument.URI)
+	helpers.SafeDebugLog("[DOCUMENT_HIGHLIGHT] Request for %s at position %d:%d", uri, params.Position.Line, params.Position.Character)
+
+	doc := ctx.Document(uri)
+	if doc == nil {
+		return nil, nil
+	}
+
+	dm, err := ctx.DocumentManager()
+	if err != nil {
+		return nil, nil
+	}
+
+	attr, tagName := doc.FindAttributeAtPosition(params.Position, dm)
+	if attr == nil || tagName == "" {
+		return nil, nil
+	}
+
+	target := resolveAttributeTarget(ctx, tagName, attr)
+
+	elements, err := doc.FindCustomElements(dm)
+	if err != nil {
+		helpers.SafeDebugLog("[DOCUMENT_HIGHLIGHT] Error finding custom elements: %v", err)
+		return nil, nil
+	}
+
+	var highlights []protocol.DocumentHighlight
+	for _, element := range elements {
+		if element.TagName != target.tagName {
+			continue
+		}
+		for _, match := range element.Attributes {
+			if target.matches(match) {
+				highlights = append(highlights, protocol.DocumentHighlight{
+					Range: match.Range,
+					Kind:  protocol.DocumentHighlightKindText,
+				})
+			}
+		}
+	}
+
+	sort.Slice(highlights, func(i, j int) bool {
+		a, b := highlights[i].Range.Start, highlights[j].Range.Start
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Character < b.Character
+	})
+
+	helpers.SafeDebugLog("[DOCUMENT_HIGHLIGHT] Found %d highlights for %s on <%s>", len(highlights), attr.Name, tagName)
+	return highlights, nil
+}
+
+// resolveAttributeTarget determines the attribute and property names of the
+// attribute under the cursor, using the element's manifest attributes to map
+// between them. Without manifest data, the attribute and property are assumed
+// to share a name.
+func resolveAttributeTarget(ctx types.ServerContext, tagName string, attr *types.AttributeMatch) attributeTarget {
+	target := attributeTarget{tagName: tagName}
+
+	switch attr.BindingPrefix {
+	case "@":
+		target.eventName = attr.Name
+		return target
+	case ".":
+		target.propertyName = attr.Name
+		target.attributeName = attr.Name
+		if attrs, ok := ctx.Attributes(tagName); ok {
+			for name, manifestAttr := range attrs {
+				if manifestAttr != nil && manifestAttr.FieldName == attr.Name {
+					target.attributeName = name
+					break
+				}
+			}
+		}
+	default:
+		target.attributeName = attr.Name
+		target.propertyName = attr.Name
+		if attrs, ok := ctx.Attributes(tagName); ok {
+			if manifestAttr, ok := attrs[attr.Name]; ok && manifestAttr != nil && manifestAttr.FieldName != "" {
+				target.propertyName = manifestAttr.FieldName
+			}
+		}
+	}
+
+	return target
+}
+
+// matches reports whether an attribute usage refers to the target
+func (t attributeTarget) matches(match types.AttributeMatch) bool {
+	switch match.BindingPrefix {
+	case "@":
+		return t.eventName != "" && match.Name == t.eventName
+	case ".":
+		return t.propertyName != "" && match.Name == t.propertyName
+	default:
+		return t.attributeName != "" && match.Name == t.attributeName
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package documentHighlight_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/documentHighlight"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	urilib "go.lsp.dev/uri"
+)
+
+type manifestFixture struct {
+	Attributes map[string]map[string]M.Attribute `json:"attributes"`
+}
+
+func TestDocumentHighlight_Fixtures(t *testing.T) {
+	testutil.RunLSPFixtures(t, "testdata", func(t *testing.T, fixture *testutil.LSPFixture) {
+		require.NotNil(t, fixture.Cursor, "fixture needs a cursor position")
+
+		ctx := testhelpers.NewMockServerContext()
+
+		dm, err := document.NewDocumentManager()
+		require.NoError(t, err)
+		defer dm.Close()
+		ctx.SetDocumentManager(dm)
+
+		var uri string
+		if fixture.InputType == "ts" {
+			uri = "file:///test.ts"
+		} else {
+			uri = "test.html"
+		}
+
+		doc := dm.OpenDocument(uri, fixture.InputContent, 1)
+		ctx.AddDocument(uri, doc)
+
+		if len(fixture.Manifest) > 0 {
+			var mf manifestFixture
+			require.NoError(t, json.Unmarshal(fixture.Manifest, &mf))
+			for tagName, attrs := range mf.Attributes {
+				attrMap := make(map[string]*M.Attribute)
+				for attrName, attr := range attrs {
+					attr.Name = attrName
+					a := attr
+					attrMap[attrName] = &a
+				}
+				ctx.AddAttributes(tagName, attrMap)
+			}
+		}
+
+		result, err := documentHighlight.DocumentHighlight(ctx, &protocol.DocumentHighlightParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: urilib.URI(uri)},
+				Position:     *fixture.Cursor,
+			},
+		})
+		require.NoError(t, err)
+
+		var expected []protocol.DocumentHighlight
+		require.NoError(t, fixture.GetExpected("expected", &expected))
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestDocumentHighlight_NilDocument(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	result, err := documentHighlight.DocumentHighlight(ctx, &protocol.DocumentHighlightParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: "nonexistent.html"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,17 @@
+---
+cursor:
+  line: 6
+  character: 42
+---
+import { html } from 'lit';
+
+export class MyApp {
+  render() {
+    return html`
+      <my-toggle label-text="Mute"></my-toggle>
+      <my-toggle .labelText=${this.label} ?pressed=${this.on}></my-toggle>
+      <my-toggle pressed label-text="Loud"></my-toggle>
+      <other-el label-text="Nope"></other-el>
+    `;
+  }
+}
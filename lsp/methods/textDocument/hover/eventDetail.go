@@ -0,0 +1,271 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package hover
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"bennypowers.dev/cem/internal/languages/typescript"
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// maxDetailImportDepth bounds how many imports are followed to find the
+// declaration of an event detail type
+const maxDetailImportDepth = 3
+
+// EventDetail is the structure of an event's detail type, when it resolves
+// to an interface or object type alias in the project
+type EventDetail struct {
+	Name   string
+	Fields []EventDetailField
+}
+
+// EventDetailField is one property of an event detail type
+type EventDetailField struct {
+	Name        string
+	Type        string
+	Optional    bool
+	Description string
+}
+
+// customEventDetailPattern matches the detail type argument of CustomEvent<T>
+var customEventDetailPattern = regexp.MustCompile(`^CustomEvent\s*<\s*([A-Za-z_$][\w$]*)\s*>$`)
+
+// typeNamePattern matches a plain type name, such as an Event subclass
+var typeNamePattern = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+// resolveEventDetail finds the declaration of an event's detail type in the
+// source module of the element which fires it, following relative imports.
+// Returns nil when the type isn't declared in the workspace.
+func resolveEventDetail(ctx types.ServerContext, tagName string, event *M.Event) *EventDetail {
+	if event == nil || event.Type == nil {
+		return nil
+	}
+	typeText := strings.TrimSpace(event.Type.Text)
+	typeName := typeText
+	if match := customEventDetailPattern.FindStringSubmatch(typeText); match != nil {
+		typeName = match[1]
+	} else if !typeNamePattern.MatchString(typeText) {
+		return nil
+	}
+	if typeName == "Event" || typeName == "CustomEvent" {
+		return nil
+	}
+
+	root := ctx.WorkspaceRoot()
+	definition, ok := ctx.ElementDefinition(tagName)
+	if root == "" || !ok || definition.ModulePath() == "" {
+		return nil
+	}
+	modulePath := filepath.FromSlash(definition.ModulePath())
+	if !filepath.IsAbs(modulePath) {
+		modulePath = filepath.Join(root, modulePath)
+	}
+
+	fields, found := findDetailDeclaration(ctx.FileSystem(), modulePath, typeName, maxDetailImportDepth)
+	if !found {
+		helpers.SafeDebugLog("[HOVER] Event detail type %s not found for <%s>", typeName, tagName)
+		return nil
+	}
+	return &EventDetail{Name: typeName, Fields: fields}
+}
+
+// findDetailDeclaration looks for an interface or object type alias named
+// typeName in the module at modulePath, or in the modules it imports
+// typeName from
+func findDetailDeclaration(fsys platform.FileSystem, modulePath, typeName string, depth int) ([]EventDetailField, bool) {
+	source, ok := readModuleSource(fsys, modulePath)
+	if !ok {
+		return nil, false
+	}
+
+	parser := typescript.BorrowParser()
+	defer typescript.ReturnParser(parser)
+	tree := parser.Parse(source, nil)
+	if tree == nil {
+		return nil, false
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	var importedFrom string
+	for i := range root.NamedChildCount() {
+		node := root.NamedChild(i)
+		if node.GrammarName() == "export_statement" {
+			if declaration := node.ChildByFieldName("declaration"); declaration != nil {
+				node = declaration
+			}
+		}
+		switch node.GrammarName() {
+		case "interface_declaration", "type_alias_declaration":
+			if name := node.ChildByFieldName("name"); name == nil || name.Utf8Text(source) != typeName {
+				continue
+			}
+			body := node.ChildByFieldName("body")
+			if body == nil {
+				body = node.ChildByFieldName("value")
+			}
+			if body == nil || (body.GrammarName() != "interface_body" && body.GrammarName() != "object_type") {
+				return nil, false
+			}
+			return detailFields(body, source), true
+		case "import_statement":
+			if specifier := importSourceOf(node, typeName, source); specifier != "" {
+				importedFrom = specifier
+			}
+		}
+	}
+
+	if importedFrom == "" || depth == 0 || !strings.HasPrefix(importedFrom, ".") {
+		return nil, false
+	}
+	return findDetailDeclaration(fsys, filepath.Join(filepath.Dir(modulePath), filepath.FromSlash(importedFrom)), typeName, depth-1)
+}
+
+// readModuleSource reads a module, preferring its TypeScript source, since
+// manifests reference compiled .js modules
+func readModuleSource(fsys platform.FileSystem, modulePath string) ([]byte, bool) {
+	base := strings.TrimSuffix(modulePath, filepath.Ext(modulePath))
+	for _, candidate := range []string{base + ".ts", modulePath} {
+		if data, err := fsys.ReadFile(candidate); err == nil {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// importSourceOf returns the module specifier an import statement imports
+// name from, if it does
+func importSourceOf(node *ts.Node, name string, source []byte) string {
+	sourceNode := node.ChildByFieldName("source")
+	if sourceNode == nil {
+		return ""
+	}
+	imported := false
+	var visit func(n *ts.Node)
+	visit = func(n *ts.Node) {
+		if imported {
+			return
+		}
+		if n.GrammarName() == "import_specifier" {
+			local := n.ChildByFieldName("alias")
+			if local == nil {
+				local = n.ChildByFieldName("name")
+			}
+			if local != nil && local.Utf8Text(source) == name {
+				imported = true
+			}
+			return
+		}
+		for i := range n.NamedChildCount() {
+			visit(n.NamedChild(i))
+		}
+	}
+	for i := range node.NamedChildCount() {
+		if child := node.NamedChild(i); child.GrammarName() == "import_clause" {
+			visit(child)
+		}
+	}
+	if !imported {
+		return ""
+	}
+	return strings.Trim(sourceNode.Utf8Text(source), `"'`)
+}
+
+// detailFields reads the property signatures of an interface body or object
+// type, with the JSDoc comment preceding each one
+func detailFields(body *ts.Node, source []byte) []EventDetailField {
+	var fields []EventDetailField
+	var comment string
+	for i := range body.NamedChildCount() {
+		child := body.NamedChild(i)
+		switch child.GrammarName() {
+		case "comment":
+			comment = child.Utf8Text(source)
+		case "property_signature":
+			field := EventDetailField{Description: jsdocDescription(comment)}
+			if name := child.ChildByFieldName("name"); name != nil {
+				field.Name = name.Utf8Text(source)
+			}
+			if typ := child.ChildByFieldName("type"); typ != nil {
+				field.Type = strings.TrimSpace(strings.TrimPrefix(typ.Utf8Text(source), ":"))
+			}
+			for j := range child.ChildCount() {
+				if child.Child(j).GrammarName() == "?" {
+					field.Optional = true
+				}
+			}
+			fields = append(fields, field)
+			comment = ""
+		default:
+			comment = ""
+		}
+	}
+	return fields
+}
+
+// jsdocDescription returns the description of a JSDoc comment, without its
+// block tags. Other comments have no description.
+func jsdocDescription(comment string) string {
+	if !strings.HasPrefix(comment, "/**") {
+		return ""
+	}
+	comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/**"), "*/")
+	var lines []string
+	for line := range strings.SplitSeq(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if strings.HasPrefix(line, "@") {
+			break
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// formatEventDetail creates markdown content for an event detail type
+func formatEventDetail(detail *EventDetail) string {
+	if detail == nil || len(detail.Fields) == 0 {
+		return ""
+	}
+	var content strings.Builder
+	fmt.Fprintf(&content, "**Detail `%s`:**\n", detail.Name)
+	for _, field := range detail.Fields {
+		optional := ""
+		if field.Optional {
+			optional = "?"
+		}
+		fmt.Fprintf(&content, "- **`%s%s`**", field.Name, optional)
+		if field.Type != "" {
+			fmt.Fprintf(&content, " _%s_", field.Type)
+		}
+		if field.Description != "" {
+			fmt.Fprintf(&content, " - %s", field.Description)
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	return content.String()
+}
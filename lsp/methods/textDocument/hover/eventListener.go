@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package hover
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// eventListenerPattern matches the event name argument of addEventListener
+// and removeEventListener calls
+var eventListenerPattern = regexp.MustCompile(`\b(?:add|remove)EventListener\s*\(\s*['"]([^'"\s]+)['"]`)
+
+// eventListenerHover returns hover content for the event named at position
+// in an addEventListener or removeEventListener call, e.g.
+// el.addEventListener('my-change', ...). The receiver isn't resolved, so
+// every element which fires the event is a candidate.
+func eventListenerHover(ctx types.ServerContext, doc types.Document, position protocol.Position) *protocol.Hover {
+	content, err := doc.Content()
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(content, "\n")
+	if int(position.Line) >= len(lines) {
+		return nil
+	}
+	line := lines[position.Line]
+
+	for _, match := range eventListenerPattern.FindAllStringSubmatchIndex(line, -1) {
+		start := utf16Len(line[:match[2]])
+		end := utf16Len(line[:match[3]])
+		if position.Character < start || position.Character > end {
+			continue
+		}
+		eventName := line[match[2]:match[3]]
+
+		var tagNames []string
+		for _, tagName := range ctx.AllTagNames() {
+			if events, ok := ctx.Events(tagName); ok && events[eventName] != nil {
+				tagNames = append(tagNames, tagName)
+			}
+		}
+		if len(tagNames) == 0 {
+			return nil
+		}
+		slices.Sort(tagNames)
+
+		tagName := tagNames[0]
+		events, _ := ctx.Events(tagName)
+		event := events[eventName]
+		value := CreateEventHoverContentWithDetail(event, tagName, resolveEventDetail(ctx, tagName, event))
+		if len(tagNames) > 1 {
+			value += fmt.Sprintf("_Also fired by `<%s>`_\n\n", strings.Join(tagNames[1:], ">`, `<"))
+		}
+
+		return &protocol.Hover{
+			Contents: &protocol.MarkupContent{
+				Kind:  protocol.MarkupKindMarkdown,
+				Value: value,
+			},
+			Range: &protocol.Range{
+				Start: protocol.Position{Line: position.Line, Character: start},
+				End:   protocol.Position{Line: position.Line, Character: end},
+			},
+		}
+	}
+	return nil
+}
+
+// utf16Len returns the length of s in UTF-16 code units, as LSP positions count
+func utf16Len(s string) uint32 {
+	return uint32(len(utf16.Encode([]rune(s))))
+}
@@ -18,7 +18,9 @@ package hover
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 
 	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/types"
@@ -55,7 +57,7 @@ func Hover(ctx types.ServerContext, params *protocol.HoverParams) (*protocol.Hov
 			helpers.SafeDebugLog("[HOVER] Found custom element declaration in registry: %s\n", element.TagName)
 
 			// Create hover content with full element information including summary/description
-			content := CreateElementHoverContentFromDeclaration(decl)
+			content := elementHoverContent(ctx, decl)
 			result := &protocol.Hover{
 				Contents: &protocol.MarkupContent{
 					Kind:  protocol.MarkupKindMarkdown,
@@ -78,11 +80,18 @@ func Hover(ctx types.ServerContext, params *protocol.HoverParams) (*protocol.Hov
 	if attribute != nil && tagName != "" {
 		helpers.SafeDebugLog("[HOVER] Found attribute at position: name=%s, tagName=%s, bindingPrefix=%s, range=%+v\n", attribute.Name, tagName, attribute.BindingPrefix, attribute.Range)
 
-		switch attribute.BindingPrefix {
+		bindingPrefix, name := attribute.BindingPrefix, attribute.Name
+		if bindingPrefix == "" && ctx.Config().VueBindings {
+			if _, litPrefix, bound, ok := helpers.VueBinding(name); ok {
+				bindingPrefix, name = litPrefix, bound
+			}
+		}
+
+		switch bindingPrefix {
 		case "@":
 			if events, exists := ctx.Events(tagName); exists {
-				if event, exists := events[attribute.Name]; exists {
-					content := CreateEventHoverContent(event, tagName)
+				if event, exists := vueEvent(events, name); exists {
+					content := CreateEventHoverContentWithDetail(event, tagName, resolveEventDetail(ctx, tagName, event))
 					return &protocol.Hover{
 						Contents: &protocol.MarkupContent{
 							Kind:  protocol.MarkupKindMarkdown,
@@ -94,7 +103,7 @@ func Hover(ctx types.ServerContext, params *protocol.HoverParams) (*protocol.Hov
 			}
 		case ".":
 			if fields, exists := ctx.Fields(tagName); exists {
-				if field, exists := fields[attribute.Name]; exists {
+				if field, exists := vueField(fields, name); exists {
 					content := CreateFieldHoverContent(field, tagName)
 					return &protocol.Hover{
 						Contents: &protocol.MarkupContent{
@@ -105,6 +114,20 @@ func Hover(ctx types.ServerContext, params *protocol.HoverParams) (*protocol.Hov
 					}, nil
 				}
 			}
+			// Vue sets bindings which aren't properties as attributes
+			if attribute.BindingPrefix == "" {
+				if attrs, exists := ctx.Attributes(tagName); exists {
+					if attr, exists := attrs[name]; exists {
+						return &protocol.Hover{
+							Contents: &protocol.MarkupContent{
+								Kind:  protocol.MarkupKindMarkdown,
+								Value: CreateAttributeHoverContent(attr, tagName),
+							},
+							Range: &attribute.Range,
+						}, nil
+					}
+				}
+			}
 		default:
 			if attrs, exists := ctx.Attributes(tagName); exists {
 				if attr, exists := attrs[attribute.Name]; exists {
@@ -118,13 +141,111 @@ func Hover(ctx types.ServerContext, params *protocol.HoverParams) (*protocol.Hov
 					}, nil
 				}
 			}
+			if documentation, exists := ctx.Config().SSRAttributes[attribute.Name]; exists && helpers.IsCustomElementTag(tagName) {
+				return &protocol.Hover{
+					Contents: &protocol.MarkupContent{
+						Kind:  protocol.MarkupKindMarkdown,
+						Value: CreateSSRAttributeHoverContent(attribute.Name, documentation, tagName),
+					},
+					Range: &attribute.Range,
+				}, nil
+			}
 		}
 	}
 
+	// Check if cursor is over a lit-html directive wrapping an attribute value
+	if result := directiveHover(ctx, doc, params.Position, dm); result != nil {
+		return result, nil
+	}
+
+	// Check if cursor is over the value of an is attribute, e.g. <button is="fancy-button">
+	if result := customizedBuiltInHover(ctx, doc, params.Position); result != nil {
+		return result, nil
+	}
+
+	// Check if cursor is over the event name of an addEventListener call
+	if result := eventListenerHover(ctx, doc, params.Position); result != nil {
+		return result, nil
+	}
+
 	helpers.SafeDebugLog("[HOVER] No hover content found\n")
 	return nil, nil
 }
 
+// directiveHover returns hover content for the attribute or property whose
+// bound value is wrapped by the lit-html directive at position, e.g.
+// ifDefined in variant=${ifDefined(this.variant)}
+func directiveHover(ctx types.ServerContext, doc types.Document, position protocol.Position, dm types.HandlerProvider) *protocol.Hover {
+	elements, err := doc.FindCustomElements(dm)
+	if err != nil {
+		return nil
+	}
+	for _, element := range elements {
+		for _, attribute := range element.Attributes {
+			if attribute.Directive == "" || !helpers.IsPositionInRange(position, attribute.DirectiveRange) {
+				continue
+			}
+			var content string
+			if attribute.BindingPrefix == "." {
+				if fields, exists := ctx.Fields(element.TagName); exists && fields[attribute.Name] != nil {
+					content = CreateFieldHoverContent(fields[attribute.Name], element.TagName)
+				}
+			} else if attrs, exists := ctx.Attributes(element.TagName); exists && attrs[attribute.Name] != nil {
+				content = CreateAttributeHoverContent(attrs[attribute.Name], element.TagName)
+			}
+			if content == "" {
+				return nil
+			}
+			content += fmt.Sprintf("_Value wrapped in the `%s` directive_\n\n", attribute.Directive)
+			return &protocol.Hover{
+				Contents: &protocol.MarkupContent{
+					Kind:  protocol.MarkupKindMarkdown,
+					Value: content,
+				},
+				Range: &attribute.DirectiveRange,
+			}
+		}
+	}
+	return nil
+}
+
+// customizedBuiltInHover returns hover content for the customized built-in
+// element named by the is attribute value at position, in HTML documents
+func customizedBuiltInHover(ctx types.ServerContext, doc types.Document, position protocol.Position) *protocol.Hover {
+	if doc.Language() != "html" {
+		return nil
+	}
+	content, err := doc.Content()
+	if err != nil {
+		return nil
+	}
+	tree, releaseTree := doc.AcquireTree()
+	if tree == nil {
+		return nil
+	}
+	defer releaseTree()
+
+	for _, attr := range helpers.FindIsAttributes(tree.RootNode(), []byte(content)) {
+		valueRange := doc.ByteRangeToProtocolRange(content, attr.StartByte, attr.EndByte)
+		if !helpers.IsPositionInRange(position, valueRange) {
+			continue
+		}
+		decl := ctx.FindCustomElementDeclaration(attr.Value)
+		if decl == nil {
+			helpers.SafeDebugLog("[HOVER] Customized built-in %s not found in registry\n", attr.Value)
+			return nil
+		}
+		return &protocol.Hover{
+			Contents: &protocol.MarkupContent{
+				Kind:  protocol.MarkupKindMarkdown,
+				Value: elementHoverContent(ctx, decl),
+			},
+			Range: &valueRange,
+		}
+	}
+	return nil
+}
+
 // CreateElementHoverContent creates markdown content for custom element hover
 func CreateElementHoverContent(element *M.CustomElement) string {
 	var content strings.Builder
@@ -153,12 +274,23 @@ func CreateElementHoverContentFromDeclaration(decl *M.CustomElementDeclaration)
 	if decl == nil {
 		return ""
 	}
+	return createElementHoverContent(decl, decl.Attributes(), decl.Events(), decl.Slots())
+}
+
+// createElementHoverContent creates markdown content for a declaration with
+// the given attributes, events, and slots
+func createElementHoverContent(decl *M.CustomElementDeclaration, attrs []M.Attribute, events []M.Event, slots []M.Slot) string {
 
 	var content strings.Builder
 
 	// 1. Tag name first (as title)
 	fmt.Fprintf(&content, "## `<%s>`\n\n", decl.TagName)
 
+	// Customized built-ins are used on the element they extend
+	if decl.Extends != "" {
+		fmt.Fprintf(&content, "Customized built-in: `<%s is=\"%s\">`\n\n", decl.Extends, decl.TagName)
+	}
+
 	// 2. Summary (if available)
 	if decl.Summary != "" {
 		fmt.Fprintf(&content, "**%s**\n\n", decl.Summary)
@@ -170,13 +302,49 @@ func CreateElementHoverContentFromDeclaration(decl *M.CustomElementDeclaration)
 	}
 
 	// 4. Attributes, Events, Slots using shared formatters
-	content.WriteString(formatAttributes(decl.Attributes()))
-	content.WriteString(formatEvents(decl.Events()))
-	content.WriteString(formatSlots(decl.Slots()))
+	content.WriteString(formatAttributes(attrs))
+	content.WriteString(formatEvents(events))
+	content.WriteString(formatSlots(slots))
 
 	return content.String()
 }
 
+// elementHoverContent creates hover content for a declaration, including
+// the members the registry resolves from superclasses in other packages,
+// which the declaration can't see on its own
+func elementHoverContent(ctx types.ServerContext, decl *M.CustomElementDeclaration) string {
+	attrs := decl.Attributes()
+	if registered, exists := ctx.Attributes(decl.TagName); exists {
+		attrs = appendInherited(attrs, registered, func(attr M.Attribute) string { return attr.Name })
+	}
+	events := decl.Events()
+	if registered, exists := ctx.Events(decl.TagName); exists {
+		events = appendInherited(events, registered, func(event M.Event) string { return event.Name })
+	}
+	slots := decl.Slots()
+	if registered, exists := ctx.Slots(decl.TagName); exists && len(registered) > len(slots) {
+		slots = registered
+	}
+	return createElementHoverContent(decl, attrs, events, slots)
+}
+
+// appendInherited appends registered members missing from a declaration's
+// members, sorted by name
+func appendInherited[T any](members []T, registered map[string]*T, name func(T) string) []T {
+	have := make(map[string]bool, len(members))
+	for _, member := range members {
+		have[name(member)] = true
+	}
+	var extra []T
+	for _, member := range registered {
+		if !have[name(*member)] {
+			extra = append(extra, *member)
+		}
+	}
+	slices.SortFunc(extra, func(a, b T) int { return strings.Compare(name(a), name(b)) })
+	return append(slices.Clone(members), extra...)
+}
+
 // formatAttributes creates markdown content for an attributes list
 func formatAttributes(attrs []M.Attribute) string {
 	if len(attrs) == 0 {
@@ -290,6 +458,19 @@ func CreateAttributeHoverContent(attr *M.Attribute, tagName string) string {
 	return content.String()
 }
 
+// CreateSSRAttributeHoverContent creates markdown content for hovering an
+// attribute which server-side rendering adds to custom elements
+func CreateSSRAttributeHoverContent(name, documentation, tagName string) string {
+	var content strings.Builder
+	fmt.Fprintf(&content, "## `%s` attribute\n\n", name)
+	fmt.Fprintf(&content, "**On `<%s>` element**\n\n", tagName)
+	content.WriteString("_Server-side rendering attribute_\n\n")
+	if documentation != "" {
+		fmt.Fprintf(&content, "%s\n\n", documentation)
+	}
+	return content.String()
+}
+
 // CreateFieldHoverContent creates markdown content for class field hover
 func CreateFieldHoverContent(field *M.ClassField, tagName string) string {
 	var content strings.Builder
@@ -327,6 +508,12 @@ func CreateFieldHoverContent(field *M.ClassField, tagName string) string {
 
 // CreateEventHoverContent creates markdown content for event hover
 func CreateEventHoverContent(event *M.Event, tagName string) string {
+	return CreateEventHoverContentWithDetail(event, tagName, nil)
+}
+
+// CreateEventHoverContentWithDetail creates markdown content for event hover,
+// listing the fields of the event's detail type when it's known
+func CreateEventHoverContentWithDetail(event *M.Event, tagName string, detail *EventDetail) string {
 	var content strings.Builder
 
 	// Title
@@ -342,6 +529,7 @@ func CreateEventHoverContent(event *M.Event, tagName string) string {
 	if event.Type != nil && event.Type.Text != "" {
 		fmt.Fprintf(&content, "**Type:** `%s`\n\n", event.Type.Text)
 	}
+	content.WriteString(formatEventDetail(detail))
 
 	// Description
 	if event.Description != "" {
@@ -360,3 +548,39 @@ func CreateEventHoverContent(event *M.Event, tagName string) string {
 
 	return content.String()
 }
+
+// vueEvent finds the event which a binding listens for. Vue hyphenates
+// camelCase event names, so @myEvent listens for my-event.
+func vueEvent(events map[string]*M.Event, name string) (*M.Event, bool) {
+	if event, exists := events[name]; exists {
+		return event, true
+	}
+	var hyphenated strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				hyphenated.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		hyphenated.WriteRune(r)
+	}
+	event, exists := events[hyphenated.String()]
+	return event, exists
+}
+
+// vueField finds the property which a binding sets. Vue camelizes
+// kebab-case bindings on custom elements, so :max-count sets maxCount.
+func vueField(fields map[string]*M.ClassField, name string) (*M.ClassField, bool) {
+	if field, exists := fields[name]; exists {
+		return field, true
+	}
+	words := strings.Split(name, "-")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	field, exists := fields[strings.Join(words, "")]
+	return field, exists
+}
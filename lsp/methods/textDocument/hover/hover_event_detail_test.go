@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package hover_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/hover"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// Inline: the detail type is resolved from element sources in a small
+// virtual workspace, which fixtures can't provide
+func TestHover_EventDetailType(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	fsys := platform.NewMapFileSystem(nil)
+	fsys.AddFile("/ws/elements/my-picker.ts", `import type { PickDetail } from './types.js';
+
+/** @fires {CustomEvent<PickDetail>} pick - when an option is picked */
+export class MyPicker extends HTMLElement {}
+`, 0644)
+	fsys.AddFile("/ws/elements/types.ts", `export interface PickDetail {
+  /** The picked value */
+  value: string;
+  // not documentation
+  index?: number;
+}
+`, 0644)
+	ctx.SetFileSystem(fsys)
+	ctx.SetWorkspaceRoot("/ws")
+
+	ctx.AddElement("my-picker", &M.CustomElement{})
+	ctx.AddElementDefinition("my-picker", &testhelpers.MockElementDefinition{
+		ModulePathStr: "elements/my-picker.js",
+	})
+	ctx.AddEvents("my-picker", map[string]*M.Event{
+		"pick": {
+			FullyQualified: M.FullyQualified{Name: "pick", Description: "when an option is picked"},
+			Type:           &M.Type{Text: "CustomEvent<PickDetail>"},
+		},
+	})
+
+	docURI := "file:///ws/src/app.ts"
+	doc := dm.OpenDocument(docURI, "picker.addEventListener('pick', onPick);", 1)
+	ctx.AddDocument(docURI, doc)
+
+	result, err := hover.Hover(ctx, &protocol.HoverParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri.URI(docURI)},
+			Position:     protocol.Position{Line: 0, Character: 26},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	contents, ok := result.Contents.(*protocol.MarkupContent)
+	require.True(t, ok, "expected markup content, got %T", result.Contents)
+
+	assert.Contains(t, contents.Value, "**Type:** `CustomEvent<PickDetail>`\n\n**Detail `PickDetail`:**\n")
+	assert.Contains(t, contents.Value, "- **`value`** _string_ - The picked value\n")
+	assert.Contains(t, contents.Value, "- **`index?`** _number_\n", "plain comments should not describe fields")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package hover_test
+
+import (
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/hover"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// Inline: the same document is hovered with the vueBindings setting on and
+// off, which fixtures can't configure
+func TestHover_VueBindings(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	ctx.AddElement("my-counter", &M.CustomElement{})
+	ctx.AddEvents("my-counter", map[string]*M.Event{
+		"count-change": {FullyQualified: M.FullyQualified{Name: "count-change", Description: "Fires when the count changes"}},
+	})
+	ctx.AddFields("my-counter", map[string]*M.ClassField{
+		"maxCount": {
+			PropertyLike: M.PropertyLike{
+				FullyQualified: M.FullyQualified{Name: "maxCount", Description: "The highest count"},
+				Type:           &M.Type{Text: "number"},
+			},
+		},
+	})
+	ctx.AddAttributes("my-counter", map[string]*M.Attribute{
+		"label": {FullyQualified: M.FullyQualified{Name: "label", Description: "The button's text"}},
+	})
+
+	docURI := "file:///src/App.vue"
+	content := `<template><my-counter @countChange="onChange" v-on:count-change="onChange" :max-count="10" v-bind:label="text"></my-counter></template>`
+	doc := dm.OpenDocument(docURI, content, 1)
+	ctx.AddDocument(docURI, doc)
+
+	hoverAt := func(character uint32) string {
+		t.Helper()
+		result, err := hover.Hover(ctx, &protocol.HoverParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: uri.URI(docURI)},
+				Position:     protocol.Position{Line: 0, Character: character},
+			},
+		})
+		require.NoError(t, err)
+		if result == nil {
+			return ""
+		}
+		contents, ok := result.Contents.(*protocol.MarkupContent)
+		require.True(t, ok, "expected markup content, got %T", result.Contents)
+		return contents.Value
+	}
+
+	attributes := map[string]string{
+		"@countChange":      "Fires when the count changes",
+		"v-on:count-change": "Fires when the count changes",
+		":max-count":        "The highest count",
+		"v-bind:label":      "The button's text",
+	}
+
+	for attribute := range attributes {
+		assert.Empty(t, hoverAt(uint32(strings.Index(content, attribute)+1)), "%s should have no hover without vueBindings", attribute)
+	}
+
+	config := ctx.Config()
+	config.VueBindings = true
+	ctx.SetConfig(config)
+
+	for attribute, want := range attributes {
+		assert.Contains(t, hoverAt(uint32(strings.Index(content, attribute)+1)), want, "hovering %s", attribute)
+	}
+}
@@ -0,0 +1,3 @@
+const el = document.querySelector('test-component');
+el?.addEventListener('change', () => {});
+/*                      ^cursor */
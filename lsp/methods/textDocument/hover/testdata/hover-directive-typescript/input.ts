@@ -0,0 +1,11 @@
+import { html } from 'lit';
+import { ifDefined } from 'lit/directives/if-defined.js';
+
+export class MyComponent {
+  render() {
+    return html`
+      <test-component variant=${ifDefined(this.variant)}></test-component>
+      <!--                        ^cursor -->
+    `;
+  }
+}
@@ -21,6 +21,7 @@ import (
 	"sort"
 
 	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
 	"bennypowers.dev/cem/lsp/types"
 	"go.lsp.dev/protocol"
 	ts "github.com/tree-sitter/go-tree-sitter"
@@ -28,6 +29,18 @@ import (
 
 // InlayHint handles the textDocument/inlayHint request
 func InlayHint(ctx types.ServerContext, params *protocol.InlayHintParams) ([]protocol.InlayHint, error) {
+	// Clients request inlay hints for the visible range, so it doubles as the
+	// viewport of a regionally analyzed document. Analyze newly visible lines
+	// in the background, so the hints don't wait on diagnostics.
+	uri := string(params.TextDocument.URI)
+	if ctx.DiagnosticRegions().NoteViewport(uri, params.Range) && !ctx.UsePullDiagnostics() {
+		go func() {
+			if err := publishDiagnostics.PublishDiagnostics(ctx, uri); err != nil {
+				helpers.SafeDebugLog("[INLAY_HINT] Failed to publish diagnostics for %s: %v", uri, err)
+			}
+		}()
+	}
+
 	if !ctx.InlayHintsEnabled() {
 		return nil, nil
 	}
@@ -17,6 +17,7 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package textDocument
 
 import (
+	"context"
 	"strings"
 
 	"bennypowers.dev/cem/lsp/helpers"
@@ -112,6 +113,10 @@ func DidChange(ctx types.ServerContext, params *protocol.DidChangeTextDocumentPa
 		}
 	}
 
+	// Move the diagnostics of a regionally analyzed document along with the
+	// edits, and mark the edited lines for analysis
+	ctx.DiagnosticRegions().NoteChanges(uri, params.ContentChanges)
+
 	helpers.SafeDebugLog("[LIFECYCLE] Updating document with content (length=%d)", len(newContent))
 
 	updatedDoc := dm.UpdateDocumentWithChanges(
@@ -133,7 +138,19 @@ func DidChange(ctx types.ServerContext, params *protocol.DidChangeTextDocumentPa
 			}
 		}
 	} else {
-		helpers.SafeDebugLog("[LIFECYCLE] Failed to update document: %s", uri)
+		// A modeline may have set the document's language to ignore, so
+		// clear what was reported for it
+		helpers.SafeDebugLog("[LIFECYCLE] Document no longer tracked: %s", uri)
+		ctx.DiagnosticRegions().Forget(uri)
+		ctx.SynthesizeEphemeralElements(uri)
+		if client := ctx.Client(); client != nil && !ctx.UsePullDiagnostics() {
+			if err := client.PublishDiagnostics(context.Background(), &protocol.PublishDiagnosticsParams{
+				URI:         params.TextDocument.URI,
+				Diagnostics: []protocol.Diagnostic{},
+			}); err != nil {
+				helpers.SafeDebugLog("[LIFECYCLE] Failed to clear diagnostics for %s: %v", uri, err)
+			}
+		}
 	}
 
 	return nil
@@ -147,6 +164,7 @@ func DidClose(ctx types.ServerContext, params *protocol.DidCloseTextDocumentPara
 		return err
 	}
 	dm.CloseDocument(uri)
+	ctx.DiagnosticRegions().Forget(uri)
 
 	// Clean up ephemeral data for the closed document.
 	// SynthesizeEphemeralElements will see doc == nil (already closed)
@@ -156,6 +174,19 @@ func DidClose(ctx types.ServerContext, params *protocol.DidCloseTextDocumentPara
 	return nil
 }
 
+// DidSave handles textDocument/didSave notifications
+func DidSave(ctx types.ServerContext, params *protocol.DidSaveTextDocumentParams) error {
+	uri := string(params.TextDocument.URI)
+	helpers.SafeDebugLog("[LIFECYCLE] DidSave: URI=%s", uri)
+	if moduleGraph := ctx.ModuleGraph(); moduleGraph != nil {
+		if err := moduleGraph.UpdateFile(strings.TrimPrefix(uri, "file://")); err != nil {
+			helpers.SafeDebugLog("[LIFECYCLE] Failed to update module graph for %s: %v", uri, err)
+		}
+	}
+	ctx.GenerateOnSave(uri)
+	return nil
+}
+
 // applyIncrementalChange applies an incremental text change to existing content
 func applyIncrementalChange(content string, change *protocol.TextDocumentContentChangePartial) string {
 	lines := strings.Split(content, "\n")
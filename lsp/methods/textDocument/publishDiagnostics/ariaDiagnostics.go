@@ -0,0 +1,113 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"fmt"
+	"strings"
+
+	"bennypowers.dev/cem/internal/validations"
+	"bennypowers.dev/cem/lsp/types"
+	"github.com/agext/levenshtein"
+	"go.lsp.dev/protocol"
+)
+
+// ariaDiagnostic checks a custom element's role attribute, or one of its
+// aria-* attributes, against the element's role. The role attribute in the
+// document wins over the role the manifest declares. Attributes the element
+// declares in its manifest are its own to validate.
+func ariaDiagnostic(ctx types.ServerContext, match AttributeMatch) (protocol.Diagnostic, bool) {
+	name := strings.ToLower(match.Name)
+	if name != "role" && !strings.HasPrefix(name, "aria-") {
+		return protocol.Diagnostic{}, false
+	}
+	// Property bindings set properties, not attributes
+	if match.BindingPrefix == "." {
+		return protocol.Diagnostic{}, false
+	}
+	if attrs, ok := ctx.Attributes(match.TagName); ok {
+		if _, declared := attrs[match.Name]; declared {
+			return protocol.Diagnostic{}, false
+		}
+	}
+	value := strings.TrimSpace(match.Value)
+	checkValue := match.HasValue && value != "" && !strings.Contains(value, "${") && !strings.Contains(value, "{{")
+
+	if name == "role" {
+		// The first token is the role; the rest are fallbacks
+		if fields := strings.Fields(value); checkValue && !validations.IsARIARole(fields[0]) {
+			return ariaDiagnosticAt(match, fmt.Sprintf("Unknown ARIA role '%s'", fields[0])), true
+		}
+		return protocol.Diagnostic{}, false
+	}
+
+	attr, ok := validations.LookupARIAAttribute(name)
+	if !ok {
+		message := fmt.Sprintf("Unknown ARIA attribute '%s'", match.Name)
+		if suggestion := closestARIAAttribute(name); suggestion != "" {
+			message = fmt.Sprintf("Unknown ARIA attribute '%s'. Did you mean '%s'?", match.Name, suggestion)
+		}
+		return ariaDiagnosticAt(match, message), true
+	}
+
+	role := match.Role
+	if fields := strings.Fields(role); len(fields) > 0 {
+		role = fields[0]
+	} else if decl := ctx.FindCustomElementDeclaration(match.TagName); decl != nil {
+		role = decl.Role
+	}
+	if !attr.AllowedOn(role) {
+		return ariaDiagnosticAt(match, fmt.Sprintf("ARIA attribute '%s' is not supported on '%s', which has role '%s'", attr.Name, match.TagName, role)), true
+	}
+
+	if checkValue && !attr.AcceptsValue(value) {
+		message := fmt.Sprintf("Invalid value '%s' for ARIA attribute '%s'", value, attr.Name)
+		if choices := attr.ValueChoices(); len(choices) > 0 {
+			message += fmt.Sprintf(". Expected one of: %s", strings.Join(choices, ", "))
+		}
+		return ariaDiagnosticAt(match, message), true
+	}
+
+	return protocol.Diagnostic{}, false
+}
+
+// closestARIAAttribute finds the ARIA attribute closest to a misspelled one
+func closestARIAAttribute(target string) string {
+	bestMatch := ""
+	bestDistance := 3
+	for _, name := range validations.ARIAAttributeNames() {
+		if distance := levenshtein.Distance(target, name, nil); distance < bestDistance {
+			bestDistance = distance
+			bestMatch = name
+		}
+	}
+	return bestMatch
+}
+
+// ariaDiagnosticAt creates an ARIA warning at the attribute's name
+func ariaDiagnosticAt(match AttributeMatch, message string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: match.Line, Character: match.StartCol},
+			End:   protocol.Position{Line: match.Line, Character: match.EndCol},
+		},
+		Message:  protocol.String(message),
+		Severity: protocol.DiagnosticSeverityWarning,
+		Code:     types.RuleInvalidARIA.Code(),
+		Source:   protocol.NewOptional("cem-lsp"),
+	}
+}
@@ -40,6 +40,7 @@ type AttributeMatch struct {
 	StartCol         uint32
 	EndCol           uint32
 	BindingPrefix    string // Lit binding prefix: ".", "?", "@", or ""
+	Role             string // The value of the tag's role attribute, if any
 	ExpressionKind   string // "literal", "this-member", "identifier", "complex", or ""
 	ExpressionDetail string
 }
@@ -79,9 +80,15 @@ func AnalyzeAttributeDiagnosticsForTest(ctx types.ServerContext, doc types.Docum
 			if match.BindingPrefix == "@" {
 				continue
 			}
+			if diagnostic, ok := ariaDiagnostic(ctx, match); ok {
+				diagnostics = append(diagnostics, diagnostic)
+			}
 			if validations.IsGlobalAttribute(match.Name) {
 				continue
 			}
+			if _, ok := ctx.Config().SSRAttributes[match.Name]; ok {
+				continue
+			}
 
 			// Get attributes for this custom element
 			if attrs := getCustomElementAttributes(ctx, match.TagName); attrs != nil {
@@ -204,6 +211,16 @@ func processTagCaptures(captureMap Q.CaptureMap, content string, matches *[]Attr
 		unquotedValues = unquoted
 	}
 
+	// The role attribute informs which aria-* attributes are valid
+	var role string
+	for i, attrNameInfo := range attrNames {
+		if attrNameInfo.Text == "role" && i < len(quotedValues) && len(quotedValues[i].Text) >= 2 {
+			role = strings.TrimSpace(quotedValues[i].Text[1 : len(quotedValues[i].Text)-1])
+		} else if attrNameInfo.Text == "role" && i < len(unquotedValues) {
+			role = unquotedValues[i].Text
+		}
+	}
+
 	// Process each attribute
 	for i, attrNameInfo := range attrNames {
 		attrName := attrNameInfo.Text
@@ -243,6 +260,7 @@ func processTagCaptures(captureMap Q.CaptureMap, content string, matches *[]Attr
 			StartCol:      startCol,
 			EndCol:        endCol,
 			BindingPrefix: bindingPrefix,
+			Role:          role,
 		}
 
 		*matches = append(*matches, match)
@@ -318,6 +336,7 @@ func createAttributeDiagnostic(match AttributeMatch, suggestion string) protocol
 		},
 		Message:  protocol.String(message),
 		Severity: protocol.DiagnosticSeverityWarning,
+		Code:     types.RuleUnknownAttribute.Code(),
 		Source:   protocol.NewOptional("cem-lsp"),
 	}
 
@@ -350,6 +369,7 @@ func createDeprecatedAttributeDiagnostic(match AttributeMatch, attr *M.Attribute
 		},
 		Message:  protocol.String(message),
 		Severity: protocol.DiagnosticSeverityHint,
+		Code:     types.RuleDeprecatedAttribute.Code(),
 		Source:   protocol.NewOptional("cem-lsp"),
 		Tags:     protocol.NewDiagnosticTags(protocol.DiagnosticTagDeprecated),
 	}
@@ -371,6 +391,7 @@ func createUnknownAttributeDiagnostic(match AttributeMatch) protocol.Diagnostic
 		},
 		Message:  protocol.String(message),
 		Severity: protocol.DiagnosticSeverityWarning,
+		Code:     types.RuleUnknownAttribute.Code(),
 		Source:   protocol.NewOptional("cem-lsp"),
 	}
 }
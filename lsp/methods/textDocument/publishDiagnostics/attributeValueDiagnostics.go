@@ -58,6 +58,12 @@ func AnalyzeAttributeValueDiagnosticsForTest(ctx types.ServerContext, doc types.
 			continue
 		}
 
+		// Inline styles set the element's CSS custom properties
+		if match.Name == "style" {
+			diagnostics = append(diagnostics, validateStyleCustomProperties(ctx, match)...)
+			continue
+		}
+
 		// Get attribute definition for this custom element
 		if attrs, exists := ctx.Attributes(match.TagName); exists {
 			if attr, attrExists := attrs[match.Name]; attrExists && attr != nil {
@@ -133,6 +139,52 @@ func validateAttributeValue(attr *M.Attribute, match AttributeMatch) []protocol.
 	return diagnostics
 }
 
+// validateStyleCustomProperties validates the values an inline style gives
+// the element's CSS custom properties, when their syntax is a list of
+// keywords like "small | large". Other syntaxes, like "<length>", are not
+// checked.
+func validateStyleCustomProperties(ctx types.ServerContext, match AttributeMatch) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+
+	if match.BindingPrefix != "" || match.ExpressionKind != "" {
+		return diagnostics
+	}
+	element, exists := ctx.Element(match.TagName)
+	if !exists || len(element.CssProperties) == 0 {
+		return diagnostics
+	}
+
+	for _, declaration := range helpers.ParseStyleDeclarations(match.Value) {
+		index := slices.IndexFunc(element.CssProperties, func(property M.CssCustomProperty) bool {
+			return property.Name == declaration.Property
+		})
+		if index == -1 || helpers.IsUncheckableCSSValue(declaration.Value) {
+			continue
+		}
+		keywords := helpers.CSSSyntaxKeywords(element.CssProperties[index].Syntax)
+		if len(keywords) == 0 || slices.Contains(keywords, declaration.Value) {
+			continue
+		}
+		message := fmt.Sprintf("Expected one of: %s for CSS property '%s', got '%s'",
+			formatUnionOptions(keywords), declaration.Property, declaration.Value)
+		if suggestion := findClosestUnionOption(declaration.Value, keywords); suggestion != "" {
+			message = fmt.Sprintf("%s. Did you mean '%s'?", message, suggestion)
+		}
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{Line: match.Line, Character: match.StartCol},
+				End:   protocol.Position{Line: match.Line, Character: match.EndCol},
+			},
+			Message:  protocol.String(message),
+			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     types.RuleInvalidAttributeValue.Code(),
+			Source:   protocol.NewOptional("cem-lsp"),
+		})
+	}
+
+	return diagnostics
+}
+
 // validateBooleanAttribute validates boolean attributes according to HTML semantics
 func validateBooleanAttribute(match AttributeMatch) []protocol.Diagnostic {
 	var diagnostics []protocol.Diagnostic
@@ -153,6 +205,7 @@ func validateBooleanAttribute(match AttributeMatch) []protocol.Diagnostic {
 			},
 			Message:  protocol.String(fmt.Sprintf("Boolean attribute '%s' with value 'false' is still true. Remove the attribute entirely to make it false.", match.Name)),
 			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 	} else if match.Value == "true" {
@@ -164,6 +217,7 @@ func validateBooleanAttribute(match AttributeMatch) []protocol.Diagnostic {
 			},
 			Message:  protocol.String(fmt.Sprintf("Boolean attribute '%s' with value 'true' is redundant. Use <%s %s> instead.", match.Name, match.TagName, match.Name)),
 			Severity: protocol.DiagnosticSeverityInformation,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 	} else if match.Value != "" && match.Value != match.Name {
@@ -175,6 +229,7 @@ func validateBooleanAttribute(match AttributeMatch) []protocol.Diagnostic {
 			},
 			Message:  protocol.String(fmt.Sprintf("Boolean attribute '%s' should not have value '%s'. Use <%s %s> instead.", match.Name, match.Value, match.TagName, match.Name)),
 			Severity: protocol.DiagnosticSeverityInformation,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 	}
@@ -195,6 +250,7 @@ func validateNumberAttribute(match AttributeMatch) []protocol.Diagnostic {
 			},
 			Message:  protocol.String(fmt.Sprintf("Number attribute '%s' requires a numeric value", match.Name)),
 			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 		return diagnostics
@@ -209,6 +265,7 @@ func validateNumberAttribute(match AttributeMatch) []protocol.Diagnostic {
 			},
 			Message:  protocol.String(fmt.Sprintf("Expected number for attribute '%s', got '%s'", match.Name, match.Value)),
 			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 	}
@@ -229,6 +286,7 @@ func validateUnionType(typeText string, match AttributeMatch) []protocol.Diagnos
 			},
 			Message:  protocol.String(fmt.Sprintf("Union type attribute '%s' requires a value", match.Name)),
 			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 		return diagnostics
@@ -258,6 +316,7 @@ func validateUnionType(typeText string, match AttributeMatch) []protocol.Diagnos
 			},
 			Message:  protocol.String(fmt.Sprintf("Expected one of: %s for attribute '%s', got '%s'. Did you mean '%s'?", formatUnionOptions(options), match.Name, match.Value, suggestion)),
 			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		}
 
@@ -280,6 +339,7 @@ func validateUnionType(typeText string, match AttributeMatch) []protocol.Diagnos
 			},
 			Message:  protocol.String(fmt.Sprintf("Expected one of: %s for attribute '%s', got '%s'", formatUnionOptions(options), match.Name, match.Value)),
 			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 	}
@@ -302,6 +362,7 @@ func validateLiteralType(typeText string, match AttributeMatch) []protocol.Diagn
 			},
 			Message:  protocol.String(fmt.Sprintf("Expected literal value '%s' for attribute '%s'", expectedValue, match.Name)),
 			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		})
 		return diagnostics
@@ -317,6 +378,7 @@ func validateLiteralType(typeText string, match AttributeMatch) []protocol.Diagn
 				},
 				Message:  protocol.String(fmt.Sprintf("Expected literal value '%s', got '%s' (case mismatch)", expectedValue, match.Value)),
 				Severity: protocol.DiagnosticSeverityError,
+				Code:     types.RuleInvalidAttributeValue.Code(),
 				Source:   protocol.NewOptional("cem-lsp"),
 			}
 
@@ -339,6 +401,7 @@ func validateLiteralType(typeText string, match AttributeMatch) []protocol.Diagn
 				},
 				Message:  protocol.String(fmt.Sprintf("Expected literal value '%s' for attribute '%s', got '%s'", expectedValue, match.Name, match.Value)),
 				Severity: protocol.DiagnosticSeverityError,
+				Code:     types.RuleInvalidAttributeValue.Code(),
 				Source:   protocol.NewOptional("cem-lsp"),
 			})
 		}
@@ -357,6 +420,7 @@ func createArrayTypeInfo(match AttributeMatch) []protocol.Diagnostic {
 			},
 			Message:  protocol.String("Array attributes support multiple formats (JSON, comma-separated, space-separated). Refer to component documentation."),
 			Severity: protocol.DiagnosticSeverityInformation,
+			Code:     types.RuleInvalidAttributeValue.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 		},
 	}
@@ -705,3 +705,43 @@ func TestAttributeValueDiagnostics_EdgeCases(t *testing.T) {
 		}
 	}
 }
+
+func TestAttributeValueDiagnostics_StyleCustomProperties(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	content := `<my-card style="--my-card-size: lage; --my-card-padding: 2px"></my-card>
+<my-card style="--my-card-size: var(--size); color: blue"></my-card>
+<my-card style="--my-card-size: inherit; --other: huge"></my-card>
+<my-card style="--my-card-size: large"></my-card>`
+
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+	doc := dm.OpenDocument("test.html", content, 1)
+	ctx.AddDocument("test.html", doc)
+
+	ctx.AddElement("my-card", &M.CustomElement{
+		TagName: "my-card",
+		CssProperties: []M.CssCustomProperty{
+			{FullyQualified: M.FullyQualified{Name: "--my-card-size"}, Syntax: "small | medium | large"},
+			{FullyQualified: M.FullyQualified{Name: "--my-card-padding"}, Syntax: "<length>"},
+		},
+	})
+
+	diagnostics := publishDiagnostics.AnalyzeAttributeValueDiagnosticsForTest(ctx, doc)
+
+	// Only the misspelled keyword is invalid: other syntaxes, var(), CSS-wide
+	// keywords, and undeclared properties are not checked
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %d: %v", len(diagnostics), diagnostics)
+	}
+	want := "Expected one of: 'small', 'medium' or 'large' for CSS property '--my-card-size', got 'lage'. Did you mean 'large'?"
+	if got := msgString(diagnostics[0].Message); got != want {
+		t.Errorf("Expected message %q, got %q", want, got)
+	}
+	if diagnostics[0].Range.Start.Line != 0 {
+		t.Errorf("Expected diagnostic on line 0, got line %d", diagnostics[0].Range.Start.Line)
+	}
+}
@@ -106,6 +106,7 @@ func AnalyzeCssDiagnosticsForTest(ctx types.ServerContext, doc types.Document) [
 		diagnostics = append(diagnostics, protocol.Diagnostic{
 			Range:    commentRange,
 			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     types.RuleCSSAmbiguousComment.Code(),
 			Source:   protocol.NewOptional("cem-lsp"),
 			Message:  protocol.String("Ambiguous comment ignored: more than one var() call in declaration."),
 			Data:     data,
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"fmt"
+	"strings"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// analyzeCustomizedBuiltInDiagnostics finds is attributes naming elements
+// which don't extend the element they're on
+func analyzeCustomizedBuiltInDiagnostics(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	return AnalyzeCustomizedBuiltInDiagnosticsForTest(ctx, doc)
+}
+
+// AnalyzeCustomizedBuiltInDiagnosticsForTest is the exported version for testing
+func AnalyzeCustomizedBuiltInDiagnosticsForTest(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+
+	if doc.Language() != "html" {
+		return diagnostics
+	}
+
+	content, err := doc.Content()
+	if err != nil {
+		return diagnostics
+	}
+
+	tree, releaseTree := doc.AcquireTree()
+	if tree == nil {
+		return diagnostics
+	}
+	defer releaseTree()
+
+	for _, attr := range helpers.FindIsAttributes(tree.RootNode(), []byte(content)) {
+		// Unknown elements may be defined outside the manifests
+		element, exists := ctx.Element(attr.Value)
+		if !exists || strings.EqualFold(element.Extends, attr.HostTag) {
+			continue
+		}
+
+		var message string
+		if element.Extends == "" {
+			message = fmt.Sprintf("'%s' is not a customized built-in element. Use <%s> instead of <%s is=\"%s\">", attr.Value, attr.Value, attr.HostTag, attr.Value)
+		} else {
+			message = fmt.Sprintf("'%s' extends <%s>, not <%s>. Use <%s is=\"%s\">", attr.Value, element.Extends, attr.HostTag, element.Extends, attr.Value)
+		}
+
+		helpers.SafeDebugLog("[DIAGNOSTICS] Invalid is attribute '%s' on <%s>", attr.Value, attr.HostTag)
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range:    doc.ByteRangeToProtocolRange(content, attr.StartByte, attr.EndByte),
+			Severity: protocol.DiagnosticSeverityError,
+			Code:     types.RuleInvalidIsAttribute.Code(),
+			Source:   protocol.NewOptional("cem-lsp"),
+			Message:  protocol.String(message),
+		})
+	}
+
+	return diagnostics
+}
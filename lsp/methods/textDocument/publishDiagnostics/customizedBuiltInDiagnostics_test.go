@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+)
+
+func TestCustomizedBuiltInDiagnostics_Fixtures(t *testing.T) {
+	testutil.RunLSPFixtures(t, "testdata/customized-builtins", func(t *testing.T, fixture *testutil.LSPFixture) {
+		ctx := testhelpers.NewMockServerContext()
+
+		var pkg M.Package
+		if err := json.Unmarshal(fixture.Manifest, &pkg); err != nil {
+			t.Fatalf("Failed to parse manifest: %v", err)
+		}
+		ctx.AddManifest(&pkg)
+
+		dm, err := document.NewDocumentManager()
+		if err != nil {
+			t.Fatalf("Failed to create DocumentManager: %v", err)
+		}
+		defer dm.Close()
+		ctx.SetDocumentManager(dm)
+
+		uri := "file:///test.html"
+		doc := dm.OpenDocument(uri, fixture.InputContent, 1)
+		ctx.AddDocument(uri, doc)
+
+		diagnostics := publishDiagnostics.AnalyzeCustomizedBuiltInDiagnosticsForTest(ctx, doc)
+
+		var expected []protocol.Diagnostic
+		if err := fixture.GetExpected("expected", &expected); err != nil {
+			t.Fatalf("Failed to load expected diagnostics: %v", err)
+		}
+
+		if len(diagnostics) != len(expected) {
+			t.Errorf("Expected %d diagnostics, got %d", len(expected), len(diagnostics))
+			for i, diag := range diagnostics {
+				t.Errorf("  Diagnostic %d: %s (line %d)", i, diag.Message, diag.Range.Start.Line)
+			}
+			return
+		}
+
+		for i, exp := range expected {
+			act := diagnostics[i]
+			if act.Range != exp.Range {
+				t.Errorf("Diagnostic %d: expected range %v, got %v", i, exp.Range, act.Range)
+			}
+			if act.Message != exp.Message {
+				t.Errorf("Diagnostic %d: expected message %q, got %q", i, exp.Message, act.Message)
+			}
+		}
+	})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"fmt"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// directiveAttributes are the attributes which lit-html directives are
+// restricted to
+var directiveAttributes = map[string]string{
+	"classMap": "class",
+	"styleMap": "style",
+}
+
+// analyzeDirectiveDiagnostics finds lit-html directives in bindings where
+// lit would throw at runtime
+func analyzeDirectiveDiagnostics(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	return AnalyzeDirectiveDiagnosticsForTest(ctx, doc)
+}
+
+// AnalyzeDirectiveDiagnosticsForTest is the exported version for testing
+func AnalyzeDirectiveDiagnosticsForTest(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+
+	if doc.Language() != "typescript" {
+		return diagnostics
+	}
+
+	dm, err := ctx.DocumentManager()
+	if err != nil {
+		return diagnostics
+	}
+	elements, err := doc.FindCustomElements(dm)
+	if err != nil {
+		helpers.SafeDebugLog("[DIAGNOSTICS] Error finding custom elements: %v", err)
+		return diagnostics
+	}
+
+	for _, element := range elements {
+		for _, attr := range element.Attributes {
+			if attr.Directive == "" {
+				continue
+			}
+
+			var message string
+			if required, restricted := directiveAttributes[attr.Directive]; restricted {
+				if attr.Name != required || attr.BindingPrefix != "" {
+					message = fmt.Sprintf("The %s directive must be used in the %s attribute", attr.Directive, required)
+				}
+			} else if attr.Directive == "live" && attr.BindingPrefix == "@" {
+				message = "The live directive is not allowed in event bindings"
+			}
+			if message == "" {
+				continue
+			}
+
+			helpers.SafeDebugLog("[DIAGNOSTICS] Misplaced %s directive on <%s>", attr.Directive, element.TagName)
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range:    attr.DirectiveRange,
+				Severity: protocol.DiagnosticSeverityError,
+				Code:     types.RuleMisplacedDirective.Code(),
+				Source:   protocol.NewOptional("cem-lsp"),
+				Message:  protocol.String(message),
+			})
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+// Inline: one template per case, asserting messages and ranges of directive
+// diagnostics alongside the absence of value diagnostics
+
+func TestDirectiveDiagnostics(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	ctx.AddElement("my-element", &M.CustomElement{})
+	ctx.AddAttributes("my-element", map[string]*M.Attribute{
+		"variant": {
+			FullyQualified: M.FullyQualified{Name: "variant"},
+			Type:           &M.Type{Text: "'primary' | 'secondary'"},
+		},
+	})
+
+	analyze := func(t *testing.T, template string) ([]protocol.Diagnostic, []protocol.Diagnostic) {
+		t.Helper()
+		uri := "file:///test.ts"
+		doc := dm.OpenDocument(uri, "html`"+template+"`;", 1)
+		ctx.AddDocument(uri, doc)
+		return publishDiagnostics.AnalyzeDirectiveDiagnosticsForTest(ctx, doc),
+			publishDiagnostics.AnalyzeAttributeValueDiagnosticsForTest(ctx, doc)
+	}
+
+	t.Run("directives in their attributes", func(t *testing.T) {
+		directives, values := analyze(t, `<my-element class=${classMap(classes)} style=${styleMap(styles)} variant=${ifDefined(this.variant)}></my-element>`)
+		assert.Empty(t, directives)
+		assert.Empty(t, values, "directive-wrapped values should not be validated as strings")
+	})
+
+	t.Run("classMap outside class", func(t *testing.T) {
+		directives, _ := analyze(t, `<my-element variant=${classMap(classes)}></my-element>`)
+		require.Len(t, directives, 1)
+		assert.Equal(t, protocol.String("The classMap directive must be used in the class attribute"), directives[0].Message)
+		assert.Equal(t, protocol.Range{
+			Start: protocol.Position{Line: 0, Character: 27},
+			End:   protocol.Position{Line: 0, Character: 35},
+		}, directives[0].Range)
+	})
+
+	t.Run("styleMap in a property binding", func(t *testing.T) {
+		directives, _ := analyze(t, `<my-element .style=${styleMap(styles)}></my-element>`)
+		require.Len(t, directives, 1)
+		assert.Equal(t, protocol.String("The styleMap directive must be used in the style attribute"), directives[0].Message)
+	})
+
+	t.Run("live in an event binding", func(t *testing.T) {
+		directives, _ := analyze(t, `<my-element @change=${live(this.onChange)}></my-element>`)
+		require.Len(t, directives, 1)
+		assert.Equal(t, protocol.String("The live directive is not allowed in event bindings"), directives[0].Message)
+	})
+}
@@ -19,6 +19,7 @@ package publishDiagnostics
 import (
 	"context"
 
+	"bennypowers.dev/cem/lsp/configfile"
 	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/types"
 	"go.lsp.dev/protocol"
@@ -26,12 +27,45 @@ import (
 )
 
 // ComputeDiagnostics analyzes a document and returns a non-nil slice of diagnostics.
+// Files which configure cem, like .config/cem.yaml, are validated against the
+// config schema instead.
+// Documents over the large file threshold are analyzed one region at a time,
+// keeping the diagnostics already found elsewhere in the document.
+// Diagnostics suppressed by cem-ignore comments are left out, as are those of
+// rules turned off in the diagnostics settings, which also set the severity of
+// each rule's diagnostics.
 func ComputeDiagnostics(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	if kind := configfile.KindOf(doc.URI()); kind != configfile.KindNone {
+		diagnostics := []protocol.Diagnostic{}
+		if content, err := doc.Content(); err == nil {
+			diagnostics = append(diagnostics, configfile.Diagnostics(ctx.FileSystem(), kind, doc.URI(), content)...)
+		}
+		return diagnostics
+	}
+	if content, err := doc.Content(); err == nil && isLargeDocument(ctx, content) {
+		if diagnostics, ok := computeRegionalDiagnostics(ctx, doc, content); ok {
+			if diagnostics == nil {
+				diagnostics = []protocol.Diagnostic{}
+			}
+			return applySeverities(ctx, applySuppressions(doc, diagnostics))
+		}
+	} else {
+		ctx.DiagnosticRegions().Forget(doc.URI())
+	}
+	return applySeverities(ctx, applySuppressions(doc, analyzeDocument(ctx, doc)))
+}
+
+// analyzeDocument runs every diagnostic analyzer over a document
+func analyzeDocument(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
 	diagnostics := []protocol.Diagnostic{}
 	diagnostics = append(diagnostics, analyzeSlotDiagnostics(ctx, doc)...)
 	diagnostics = append(diagnostics, analyzeTagNameDiagnostics(ctx, doc)...)
 	diagnostics = append(diagnostics, analyzeAttributeDiagnostics(ctx, doc)...)
 	diagnostics = append(diagnostics, analyzeAttributeValueDiagnostics(ctx, doc)...)
+	diagnostics = append(diagnostics, analyzeDirectiveDiagnostics(ctx, doc)...)
+	diagnostics = append(diagnostics, analyzeCustomizedBuiltInDiagnostics(ctx, doc)...)
+	diagnostics = append(diagnostics, analyzeSelfClosingDiagnostics(ctx, doc)...)
+	diagnostics = append(diagnostics, analyzeRequiredSlotDiagnostics(ctx, doc)...)
 	diagnostics = append(diagnostics, analyzeCssDiagnostics(ctx, doc)...)
 	return diagnostics
 }
@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"strings"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	ts "github.com/tree-sitter/go-tree-sitter"
+	"go.lsp.dev/protocol"
+)
+
+const (
+	// initialRegionLines is how many lines at the top of a large document are
+	// analyzed when it opens, before the client reports a visible range
+	initialRegionLines = 500
+	// regionMargin is how many lines around a region are parsed along with
+	// it, so that elements which straddle the region's edges parse whole
+	regionMargin = 50
+)
+
+// isLargeDocument reports whether content exceeds the large file threshold
+func isLargeDocument(ctx types.ServerContext, content string) bool {
+	threshold := ctx.Config().LargeFileThreshold
+	return threshold > 0 && len(content) > threshold
+}
+
+// computeRegionalDiagnostics analyzes only the pending regions of a large
+// document, parsing them with tree-sitter included ranges, and merges the
+// results with the diagnostics already known for the rest of the document.
+// It reports false when the document's language can't be parsed by region.
+func computeRegionalDiagnostics(ctx types.ServerContext, doc types.Document, content string) ([]protocol.Diagnostic, bool) {
+	dm, err := ctx.DocumentManager()
+	if err != nil {
+		return nil, false
+	}
+	rp, ok := dm.GetLanguageHandler(doc.Language()).(types.RangeParser)
+	if !ok {
+		return nil, false
+	}
+
+	lineStarts := lineStartOffsets(content)
+	lastLine := uint32(len(lineStarts) - 1)
+	regions := ctx.DiagnosticRegions()
+	uri := doc.URI()
+
+	var spans []types.LineSpan
+	for _, s := range regions.Pending(uri, types.LineSpan{Start: 0, End: initialRegionLines - 1}) {
+		if s.Start > lastLine {
+			continue
+		}
+		spans = append(spans, types.LineSpan{Start: s.Start, End: min(s.End, lastLine)})
+	}
+	if len(spans) == 0 {
+		return regions.Diagnostics(uri), true
+	}
+
+	// Parse each region with its margin, along with the document's script
+	// tags, which declare the imports that tag diagnostics check against
+	parsed := make([]types.LineSpan, 0, len(spans))
+	for _, s := range spans {
+		start := uint32(0)
+		if s.Start > regionMargin {
+			start = s.Start - regionMargin
+		}
+		parsed = append(parsed, types.LineSpan{Start: start, End: min(s.End+regionMargin, lastLine)})
+	}
+	for _, script := range doc.ScriptTags() {
+		parsed = append(parsed, types.LineSpan{Start: script.Range.Start.Line, End: min(script.Range.End.Line, lastLine)})
+	}
+	parsed = types.MergeLineSpans(parsed)
+
+	ranges := make([]ts.Range, 0, len(parsed))
+	for _, s := range parsed {
+		endByte := uint(len(content))
+		endPoint := ts.Point{Row: uint(lastLine), Column: uint(len(content)) - lineStarts[lastLine]}
+		if s.End < lastLine {
+			endByte = lineStarts[s.End+1]
+			endPoint = ts.Point{Row: uint(s.End + 1), Column: 0}
+		}
+		ranges = append(ranges, ts.Range{
+			StartByte:  lineStarts[s.Start],
+			EndByte:    endByte,
+			StartPoint: ts.Point{Row: uint(s.Start), Column: 0},
+			EndPoint:   endPoint,
+		})
+	}
+
+	helpers.SafeDebugLog("[DIAGNOSTICS] Large document %s (%d bytes): analyzing %d regions", uri, len(content), len(spans))
+
+	regionDoc := rp.CreateDocumentWithRanges(uri, content, doc.Version(), ranges)
+	defer regionDoc.Close()
+
+	return regions.Update(uri, spans, analyzeDocument(ctx, regionDoc)), true
+}
+
+// lineStartOffsets returns the byte offset at which each line begins
+func lineStartOffsets(content string) []uint {
+	offsets := make([]uint, 1, strings.Count(content, "\n")+1)
+	for i := range len(content) {
+		if content[i] == '\n' {
+			offsets = append(offsets, uint(i+1))
+		}
+	}
+	return offsets
+}
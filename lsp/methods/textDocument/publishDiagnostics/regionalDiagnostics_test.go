@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+func diagnosticLines(diagnostics []protocol.Diagnostic) []uint32 {
+	lines := []uint32{}
+	for _, d := range diagnostics {
+		lines = append(lines, d.Range.Start.Line)
+	}
+	return lines
+}
+
+func TestComputeDiagnostics_LargeDocumentRegions(t *testing.T) {
+	fs := testutil.NewFixtureFS(t, "customized-builtins/is-attribute", "/test")
+	manifestBytes, err := fs.ReadFile("/test/manifest.json")
+	require.NoError(t, err)
+	var pkg M.Package
+	require.NoError(t, json.Unmarshal(manifestBytes, &pkg))
+
+	ctx := testhelpers.NewMockServerContext()
+	ctx.AddManifest(&pkg)
+	config := ctx.Config()
+	config.LargeFileThreshold = 1024
+	ctx.SetConfig(config)
+
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	// An invalid customized built-in on the first line and on line 800,
+	// beyond the region analyzed when the document opens
+	lines := make([]string, 801)
+	for i := range lines {
+		lines[i] = "<p>filler</p>"
+	}
+	lines[0] = `<div is="fancy-button"></div>`
+	lines[800] = `<div is="fancy-button"></div>`
+	content := strings.Join(lines, "\n")
+
+	uri := "file:///large.html"
+	doc := dm.OpenDocument(uri, content, 1)
+	ctx.AddDocument(uri, doc)
+
+	assert.Equal(t, []uint32{0}, diagnosticLines(publishDiagnostics.ComputeDiagnostics(ctx, doc)),
+		"only the top of a large document is analyzed when it opens")
+
+	regions := ctx.DiagnosticRegions()
+	require.True(t, regions.NoteViewport(uri, protocol.Range{
+		Start: protocol.Position{Line: 780},
+		End:   protocol.Position{Line: 800},
+	}))
+	assert.Equal(t, []uint32{0, 800}, diagnosticLines(publishDiagnostics.ComputeDiagnostics(ctx, doc)),
+		"scrolling analyzes the visible lines, keeping earlier diagnostics")
+	assert.False(t, regions.NoteViewport(uri, protocol.Range{
+		Start: protocol.Position{Line: 790},
+		End:   protocol.Position{Line: 800},
+	}), "an analyzed viewport needs no analysis")
+
+	// Insert a line at the top: the edited region is re-analyzed, and the
+	// diagnostic further down follows the edit without re-analysis
+	changes := []protocol.TextDocumentContentChangeEvent{&protocol.TextDocumentContentChangePartial{
+		Range: protocol.Range{},
+		Text:  "<p>new</p>\n",
+	}}
+	regions.NoteChanges(uri, changes)
+	doc = dm.UpdateDocumentWithChanges(uri, "<p>new</p>\n"+content, 2, changes)
+	ctx.AddDocument(uri, doc)
+	assert.Equal(t, []uint32{1, 801}, diagnosticLines(publishDiagnostics.ComputeDiagnostics(ctx, doc)))
+
+	// The regional results match a full analysis
+	config.LargeFileThreshold = 0
+	ctx.SetConfig(config)
+	assert.Equal(t, []uint32{1, 801}, diagnosticLines(publishDiagnostics.ComputeDiagnostics(ctx, doc)))
+}
@@ -0,0 +1,272 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	ts "github.com/tree-sitter/go-tree-sitter"
+	"go.lsp.dev/protocol"
+)
+
+// analyzeRequiredSlotDiagnostics finds custom elements which leave a required
+// slot empty
+func analyzeRequiredSlotDiagnostics(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	return AnalyzeRequiredSlotDiagnosticsForTest(ctx, doc)
+}
+
+// AnalyzeRequiredSlotDiagnosticsForTest is the exported version for testing
+func AnalyzeRequiredSlotDiagnosticsForTest(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+
+	// Template bindings may fill slots at runtime, so only HTML documents,
+	// whose children are all written out, are checked
+	if doc.Language() != "html" {
+		return diagnostics
+	}
+
+	content, err := doc.Content()
+	if err != nil {
+		return diagnostics
+	}
+
+	tree, releaseTree := doc.AcquireTree()
+	if tree == nil {
+		return diagnostics
+	}
+	defer releaseTree()
+
+	source := []byte(content)
+	quote := helpers.AttributeQuote(content)
+	indentUnit, hasIndentUnit := ctx.Config().Formatting.IndentUnit()
+
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		if node.Kind() == "element" {
+			diagnostics = append(diagnostics, missingSlotDiagnostics(ctx, doc, content, node, source, quote, indentUnit, hasIndentUnit)...)
+		}
+		for i := range node.NamedChildCount() {
+			if child := node.NamedChild(i); child != nil {
+				walk(child)
+			}
+		}
+	}
+	walk(tree.RootNode())
+
+	return diagnostics
+}
+
+// requiredSlots returns the names of the slots which usages of an element
+// must fill, which its manifest or the configuration mark required
+func requiredSlots(ctx types.ServerContext, tagName string) []string {
+	var required []string
+	if slots, ok := ctx.Slots(tagName); ok {
+		for _, slot := range slots {
+			if slot.Required && !slot.Pattern && !slices.Contains(required, slot.Name) {
+				required = append(required, slot.Name)
+			}
+		}
+	}
+	for _, name := range ctx.Config().RequiredSlots[tagName] {
+		if !slices.Contains(required, name) {
+			required = append(required, name)
+		}
+	}
+	return required
+}
+
+// missingSlotDiagnostics reports each required slot of a custom element which
+// none of its children fill, with a quick fix which inserts a placeholder
+// child for the slot before the element's end tag
+func missingSlotDiagnostics(
+	ctx types.ServerContext,
+	doc types.Document,
+	content string,
+	element *ts.Node,
+	source []byte,
+	quote string,
+	indentUnit string,
+	hasIndentUnit bool,
+) []protocol.Diagnostic {
+	var startTag, endTag *ts.Node
+	for i := range element.NamedChildCount() {
+		switch child := element.NamedChild(i); child.Kind() {
+		case "start_tag":
+			startTag = child
+		case "end_tag":
+			endTag = child
+		}
+	}
+	// Without an end tag, the element's children are unknown, e.g. when a
+	// large document is parsed one region at a time
+	if startTag == nil || endTag == nil || endTag.IsMissing() {
+		return nil
+	}
+	tagName := tagNameOf(startTag, source)
+	if !helpers.IsCustomElementTag(tagName) {
+		return nil
+	}
+	required := requiredSlots(ctx, tagName)
+	if len(required) == 0 {
+		return nil
+	}
+
+	filled := filledSlots(element, source)
+	var tagNameNode *ts.Node
+	for i := range startTag.NamedChildCount() {
+		if child := startTag.NamedChild(i); child.Kind() == "tag_name" {
+			tagNameNode = child
+		}
+	}
+	if tagNameNode == nil {
+		return nil
+	}
+	tagRange := doc.ByteRangeToProtocolRange(content, tagNameNode.StartByte(), tagNameNode.EndByte())
+
+	var diagnostics []protocol.Diagnostic
+	for _, name := range required {
+		if filled[name] {
+			continue
+		}
+		slotDescription := fmt.Sprintf("'%s' slot", name)
+		placeholder := "<span></span>"
+		if name == "" {
+			slotDescription = "default slot"
+		} else {
+			placeholder = fmt.Sprintf("<span slot=%s%s%s></span>", quote, name, quote)
+		}
+
+		helpers.SafeDebugLog("[DIAGNOSTICS] <%s> leaves its required %s empty", tagName, slotDescription)
+		diagnostic := protocol.Diagnostic{
+			Range:    tagRange,
+			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     types.RuleMissingSlot.Code(),
+			Source:   protocol.NewOptional("cem-lsp"),
+			Message:  protocol.String(fmt.Sprintf("<%s> requires content in its %s", tagName, slotDescription)),
+		}
+		insertRange, insertText := placeholderInsertion(doc, content, endTag, placeholder, indentUnit, hasIndentUnit)
+		autofixData := &types.AutofixData{
+			Type:       types.DiagnosticTypeMissingSlot,
+			Original:   name,
+			Suggestion: insertText,
+			Range:      insertRange,
+			TagName:    tagName,
+		}
+		data, _ := json.Marshal(autofixData.ToMap())
+		diagnostic.Data = data
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// filledSlots returns the names of the slots which an element's children are
+// assigned to. Text other than whitespace fills the default slot.
+func filledSlots(element *ts.Node, source []byte) map[string]bool {
+	filled := make(map[string]bool)
+	for i := range element.NamedChildCount() {
+		child := element.NamedChild(i)
+		switch child.Kind() {
+		case "element":
+			var tag *ts.Node
+			for j := range child.NamedChildCount() {
+				if grandchild := child.NamedChild(j); grandchild.Kind() == "start_tag" || grandchild.Kind() == "self_closing_tag" {
+					tag = grandchild
+					break
+				}
+			}
+			if tag == nil {
+				continue
+			}
+			// Templates render nothing, unless they declare a shadow root,
+			// which isn't slotted
+			if tagNameOf(tag, source) == "template" {
+				continue
+			}
+			filled[slotAttributeValue(tag, source)] = true
+		case "text", "entity":
+			if strings.TrimSpace(child.Utf8Text(source)) != "" {
+				filled[""] = true
+			}
+		}
+	}
+	return filled
+}
+
+// slotAttributeValue returns the value of a tag's slot attribute. Without
+// one, or with an empty one, the element fills the default slot, named "".
+func slotAttributeValue(tag *ts.Node, source []byte) string {
+	for i := range tag.NamedChildCount() {
+		attribute := tag.NamedChild(i)
+		if attribute.Kind() != "attribute" {
+			continue
+		}
+		var isSlot bool
+		for j := range attribute.NamedChildCount() {
+			child := attribute.NamedChild(j)
+			switch child.Kind() {
+			case "attribute_name":
+				isSlot = strings.EqualFold(child.Utf8Text(source), "slot")
+			case "attribute_value":
+				if isSlot {
+					return child.Utf8Text(source)
+				}
+			case "quoted_attribute_value":
+				if !isSlot {
+					continue
+				}
+				// The value node is absent for empty quotes, e.g. slot=""
+				if value := child.NamedChild(0); value != nil {
+					return value.Utf8Text(source)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// placeholderInsertion returns where and what to insert to add a placeholder
+// child before an element's end tag. When the end tag is on a line of its
+// own, the placeholder goes on a new line, indented one level deeper.
+func placeholderInsertion(
+	doc types.Document,
+	content string,
+	endTag *ts.Node,
+	placeholder string,
+	indentUnit string,
+	hasIndentUnit bool,
+) (protocol.Range, string) {
+	end := endTag.StartByte()
+	lineStart := uint(strings.LastIndexByte(content[:end], '\n') + 1)
+	indent := content[lineStart:end]
+	if strings.TrimSpace(indent) != "" {
+		position := doc.ByteRangeToProtocolRange(content, end, end)
+		return position, placeholder
+	}
+	if !hasIndentUnit {
+		indentUnit = "  "
+		if strings.Contains(indent, "\t") {
+			indentUnit = "\t"
+		}
+	}
+	position := doc.ByteRangeToProtocolRange(content, lineStart, lineStart)
+	return position, indent + indentUnit + placeholder + "\n"
+}
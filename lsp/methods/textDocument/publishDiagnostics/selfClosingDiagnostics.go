@@ -0,0 +1,151 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bennypowers.dev/cem/internal/set"
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	ts "github.com/tree-sitter/go-tree-sitter"
+	"go.lsp.dev/protocol"
+)
+
+// voidElements are the HTML elements which have no content and no end tag
+var voidElements = set.NewSet(
+	"area", "base", "br", "col", "embed", "hr", "img", "input",
+	"link", "meta", "source", "track", "wbr",
+)
+
+// analyzeSelfClosingDiagnostics finds custom elements written as if they were
+// void elements, and end tags of void elements
+func analyzeSelfClosingDiagnostics(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	return AnalyzeSelfClosingDiagnosticsForTest(ctx, doc)
+}
+
+// AnalyzeSelfClosingDiagnosticsForTest is the exported version for testing
+func AnalyzeSelfClosingDiagnosticsForTest(ctx types.ServerContext, doc types.Document) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+
+	// HTML parsers ignore the slash in <my-element />, but Lit and JSX
+	// templates close the element, so only HTML documents are checked
+	if doc.Language() != "html" {
+		return diagnostics
+	}
+
+	content, err := doc.Content()
+	if err != nil {
+		return diagnostics
+	}
+
+	tree, releaseTree := doc.AcquireTree()
+	if tree == nil {
+		return diagnostics
+	}
+	defer releaseTree()
+
+	source := []byte(content)
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		switch node.Kind() {
+		case "self_closing_tag":
+			if diagnostic, ok := selfClosingCustomElementDiagnostic(doc, content, node, source); ok {
+				diagnostics = append(diagnostics, diagnostic)
+			}
+			return
+		case "end_tag":
+			if diagnostic, ok := voidEndTagDiagnostic(doc, content, node, source); ok {
+				diagnostics = append(diagnostics, diagnostic)
+			}
+			return
+		}
+		for i := range node.NamedChildCount() {
+			if child := node.NamedChild(i); child != nil {
+				walk(child)
+			}
+		}
+	}
+	walk(tree.RootNode())
+
+	return diagnostics
+}
+
+// selfClosingCustomElementDiagnostic reports <my-element />. Browsers treat
+// it as a start tag, so the content which follows is swallowed into the
+// element until its parent closes.
+func selfClosingCustomElementDiagnostic(doc types.Document, content string, tag *ts.Node, source []byte) (protocol.Diagnostic, bool) {
+	tagName := tagNameOf(tag, source)
+	if !strings.Contains(tagName, "-") {
+		return protocol.Diagnostic{}, false
+	}
+
+	original := tag.Utf8Text(source)
+	startTag := strings.TrimRight(strings.TrimSuffix(original, "/>"), " \t\r\n") + ">"
+	suggestion := fmt.Sprintf("%s</%s>", startTag, tagName)
+	tagRange := doc.ByteRangeToProtocolRange(content, tag.StartByte(), tag.EndByte())
+
+	helpers.SafeDebugLog("[DIAGNOSTICS] Self-closing custom element <%s />", tagName)
+	diagnostic := protocol.Diagnostic{
+		Range:    tagRange,
+		Severity: protocol.DiagnosticSeverityWarning,
+		Code:     types.RuleSelfClosingCustomElement.Code(),
+		Source:   protocol.NewOptional("cem-lsp"),
+		Message:  protocol.String(fmt.Sprintf("Custom elements can't be self-closing: <%s /> leaves the element open, so the content after it becomes its children. Use <%s></%s>", tagName, tagName, tagName)),
+	}
+	autofixData := &types.AutofixData{
+		Type:       types.DiagnosticTypeSelfClosingCustomElement,
+		Original:   original,
+		Suggestion: suggestion,
+		Range:      tagRange,
+		TagName:    tagName,
+	}
+	data, _ := json.Marshal(autofixData.ToMap())
+	diagnostic.Data = data
+	return diagnostic, true
+}
+
+// voidEndTagDiagnostic reports end tags of void elements, like </input>,
+// which browsers ignore
+func voidEndTagDiagnostic(doc types.Document, content string, tag *ts.Node, source []byte) (protocol.Diagnostic, bool) {
+	tagName := tagNameOf(tag, source)
+	if !voidElements.Has(tagName) {
+		return protocol.Diagnostic{}, false
+	}
+
+	helpers.SafeDebugLog("[DIAGNOSTICS] End tag of void element </%s>", tagName)
+	return protocol.Diagnostic{
+		Range:    doc.ByteRangeToProtocolRange(content, tag.StartByte(), tag.EndByte()),
+		Severity: protocol.DiagnosticSeverityWarning,
+		Code:     types.RuleVoidEndTag.Code(),
+		Source:   protocol.NewOptional("cem-lsp"),
+		Message:  protocol.String(fmt.Sprintf("<%s> is a void element, so it has no end tag: </%s> is ignored", tagName, tagName)),
+		Tags:     protocol.NewDiagnosticTags(protocol.DiagnosticTagUnnecessary),
+	}, true
+}
+
+// tagNameOf returns the lowercase tag name of a tag node
+func tagNameOf(tag *ts.Node, source []byte) string {
+	for i := range tag.NamedChildCount() {
+		if child := tag.NamedChild(i); child != nil && child.Kind() == "tag_name" {
+			return strings.ToLower(child.Utf8Text(source))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+)
+
+func TestSelfClosingDiagnostics_Fixtures(t *testing.T) {
+	testutil.RunLSPFixtures(t, "testdata/self-closing", func(t *testing.T, fixture *testutil.LSPFixture) {
+		ctx := testhelpers.NewMockServerContext()
+
+		var pkg M.Package
+		if err := json.Unmarshal(fixture.Manifest, &pkg); err != nil {
+			t.Fatalf("Failed to parse manifest: %v", err)
+		}
+		ctx.AddManifest(&pkg)
+
+		dm, err := document.NewDocumentManager()
+		if err != nil {
+			t.Fatalf("Failed to create DocumentManager: %v", err)
+		}
+		defer dm.Close()
+		ctx.SetDocumentManager(dm)
+
+		uri := "file:///test." + fixture.InputType
+		doc := dm.OpenDocument(uri, fixture.InputContent, 1)
+		ctx.AddDocument(uri, doc)
+
+		diagnostics := publishDiagnostics.AnalyzeSelfClosingDiagnosticsForTest(ctx, doc)
+
+		var expected []protocol.Diagnostic
+		if err := fixture.GetExpected("expected", &expected); err != nil {
+			t.Fatalf("Failed to load expected diagnostics: %v", err)
+		}
+
+		if len(diagnostics) != len(expected) {
+			t.Errorf("Expected %d diagnostics, got %d", len(expected), len(diagnostics))
+			for i, diag := range diagnostics {
+				t.Errorf("  Diagnostic %d: %s (line %d)", i, diag.Message, diag.Range.Start.Line)
+			}
+			return
+		}
+
+		for i, exp := range expected {
+			act := diagnostics[i]
+			if act.Range != exp.Range {
+				t.Errorf("Diagnostic %d: expected range %v, got %v", i, exp.Range, act.Range)
+			}
+			if act.Message != exp.Message {
+				t.Errorf("Diagnostic %d: expected message %q, got %q", i, exp.Message, act.Message)
+			}
+			if act.Severity != exp.Severity {
+				t.Errorf("Diagnostic %d: expected severity %v, got %v", i, exp.Severity, act.Severity)
+			}
+		}
+	})
+}
+
+// Inline: the quick fix replaces the self-closing tag with start and end tags
+func TestSelfClosingDiagnostics_Autofix(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	uri := "file:///test.html"
+	doc := dm.OpenDocument(uri, `<div><my-icon name="star"
+  /></div>`, 1)
+	ctx.AddDocument(uri, doc)
+
+	diagnostics := publishDiagnostics.AnalyzeSelfClosingDiagnosticsForTest(ctx, doc)
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %d", len(diagnostics))
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(diagnostics[0].Data, &data); err != nil {
+		t.Fatalf("Failed to parse diagnostic data: %v", err)
+	}
+	autofix, ok := types.AutofixDataFromMap(data)
+	if !ok {
+		t.Fatalf("Expected autofix data, got %v", data)
+	}
+	if autofix.Type != types.DiagnosticTypeSelfClosingCustomElement {
+		t.Errorf("Expected type %q, got %q", types.DiagnosticTypeSelfClosingCustomElement, autofix.Type)
+	}
+	if want := `<my-icon name="star"></my-icon>`; autofix.Suggestion != want {
+		t.Errorf("Expected suggestion %q, got %q", want, autofix.Suggestion)
+	}
+}
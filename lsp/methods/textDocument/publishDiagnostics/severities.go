@@ -0,0 +1,49 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// applySeverities sets the configured severity of each diagnostic whose rule
+// is configured, and removes the diagnostics of rules which are turned off.
+// Rules configured with unknown severities keep their default severity.
+func applySeverities(ctx types.ServerContext, diagnostics []protocol.Diagnostic) []protocol.Diagnostic {
+	severities := ctx.Config().Diagnostics
+	if len(severities) == 0 {
+		return diagnostics
+	}
+	// The diagnostics may be cached by region, so they are copied into a new
+	// slice rather than changed in place
+	kept := make([]protocol.Diagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		if rule, ok := types.DiagnosticRuleOf(diagnostic); ok {
+			if configured, ok := severities[rule]; ok {
+				if configured == types.SeverityOff {
+					continue
+				}
+				if severity, ok := configured.DiagnosticSeverity(); ok {
+					diagnostic.Severity = severity
+				}
+			}
+		}
+		kept = append(kept, diagnostic)
+	}
+	return kept
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics_test
+
+import (
+	"fmt"
+	"testing"
+
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+// Inline: the document is a single line per rule, and the cases differ only
+// in their settings
+
+func TestSeverities(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	ctx.AddElement("my-element", &M.CustomElement{})
+	ctx.AddAttributes("my-element", map[string]*M.Attribute{
+		"size": {FullyQualified: M.FullyQualified{Name: "size"}},
+	})
+
+	var documents int
+	compute := func(severities map[types.DiagnosticRule]types.RuleSeverity) map[types.DiagnosticRule]protocol.DiagnosticSeverity {
+		config := types.DefaultConfig()
+		config.Diagnostics = severities
+		ctx.SetConfig(config)
+		documents++
+		uri := fmt.Sprintf("file:///test-%d.html", documents)
+		doc := dm.OpenDocument(uri, `<my-element siz="large"></my-element>
+<input></input>`, 1)
+		ctx.AddDocument(uri, doc)
+		found := make(map[types.DiagnosticRule]protocol.DiagnosticSeverity)
+		for _, d := range publishDiagnostics.ComputeDiagnostics(ctx, doc) {
+			if rule, ok := types.DiagnosticRuleOf(d); ok {
+				found[rule] = d.Severity
+			}
+		}
+		return found
+	}
+
+	defaults := compute(nil)
+	require.Contains(t, defaults, types.RuleUnknownAttribute)
+	require.Contains(t, defaults, types.RuleVoidEndTag)
+
+	t.Run("override", func(t *testing.T) {
+		found := compute(map[types.DiagnosticRule]types.RuleSeverity{
+			types.RuleUnknownAttribute: types.SeverityHint,
+		})
+		assert.Equal(t, protocol.DiagnosticSeverityHint, found[types.RuleUnknownAttribute])
+		assert.Equal(t, defaults[types.RuleVoidEndTag], found[types.RuleVoidEndTag])
+	})
+
+	t.Run("off", func(t *testing.T) {
+		found := compute(map[types.DiagnosticRule]types.RuleSeverity{
+			types.RuleVoidEndTag: types.SeverityOff,
+		})
+		assert.NotContains(t, found, types.RuleVoidEndTag)
+		assert.Equal(t, defaults[types.RuleUnknownAttribute], found[types.RuleUnknownAttribute])
+	})
+
+	t.Run("unknown severity keeps the default", func(t *testing.T) {
+		found := compute(map[types.DiagnosticRule]types.RuleSeverity{
+			types.RuleUnknownAttribute: "loud",
+		})
+		assert.Equal(t, defaults[types.RuleUnknownAttribute], found[types.RuleUnknownAttribute])
+	})
+}
@@ -18,11 +18,11 @@ package publishDiagnostics
 
 import (
 	"fmt"
-	"slices"
 	"strings"
 
 	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
 	"github.com/agext/levenshtein"
 	"encoding/json"
 	"go.lsp.dev/protocol"
@@ -71,34 +71,41 @@ func AnalyzeSlotDiagnosticsForTest(ctx types.ServerContext, doc types.Document)
 		helpers.SafeDebugLog("[DIAGNOSTICS] Available slots for '%s': %v", parentElement, slotNames)
 
 		slotValue := match.Value
-		if slices.Contains(slotNames, slotValue) {
-			for _, slot := range availableSlots {
-				if slot.Name == slotValue && slot.IsDeprecated() {
-					d := protocol.Diagnostic{
-						Range:    match.Range,
-						Severity: protocol.DiagnosticSeverityHint,
-						Source:   protocol.NewOptional("cem-lsp"),
-						Tags:     protocol.NewDiagnosticTags(protocol.DiagnosticTagDeprecated),
-					}
-					if reason, ok := slot.Deprecated.Value().(string); ok && reason != "" {
-						d.Message = protocol.String(fmt.Sprintf("Slot '%s' on '%s' is deprecated: %s", slotValue, parentElement, reason))
-					} else {
-						d.Message = protocol.String(fmt.Sprintf("Slot '%s' on '%s' is deprecated", slotValue, parentElement))
-					}
-					diagnostics = append(diagnostics, d)
-					break
+		if slot := documentedSlot(availableSlots, slotValue); slot != nil {
+			if slot.IsDeprecated() {
+				d := protocol.Diagnostic{
+					Range:    match.Range,
+					Severity: protocol.DiagnosticSeverityHint,
+					Code:     types.RuleDeprecatedSlot.Code(),
+					Source:   protocol.NewOptional("cem-lsp"),
+					Tags:     protocol.NewDiagnosticTags(protocol.DiagnosticTagDeprecated),
+				}
+				if reason, ok := slot.Deprecated.Value().(string); ok && reason != "" {
+					d.Message = protocol.String(fmt.Sprintf("Slot '%s' on '%s' is deprecated: %s", slotValue, parentElement, reason))
+				} else {
+					d.Message = protocol.String(fmt.Sprintf("Slot '%s' on '%s' is deprecated", slotValue, parentElement))
 				}
+				diagnostics = append(diagnostics, d)
 			}
 			continue
 		}
 
 		helpers.SafeDebugLog("[DIAGNOSTICS] Invalid slot '%s' for element '%s'", slotValue, parentElement)
 
-		closestMatch, distance := findClosestMatch(slotValue, slotNames, 3)
+		// Patterns like "item-*" can't be inserted as they are, so suggest
+		// only fixed names
+		var suggestions []string
+		for _, slot := range availableSlots {
+			if !slot.Pattern {
+				suggestions = append(suggestions, slot.Name)
+			}
+		}
+		closestMatch, distance := findClosestMatch(slotValue, suggestions, 3)
 
 		var diagnostic protocol.Diagnostic
 		diagnostic.Range = match.Range
 		diagnostic.Severity = protocol.DiagnosticSeverityError
+		diagnostic.Code = types.RuleUnknownSlot.Code()
 		diagnostic.Source = protocol.NewOptional("cem-lsp")
 
 		if closestMatch != "" && distance <= 2 {
@@ -124,6 +131,22 @@ func AnalyzeSlotDiagnosticsForTest(ctx types.ServerContext, doc types.Document)
 	return diagnostics
 }
 
+// documentedSlot finds the slot which documents name, preferring a slot of
+// that exact name over a pattern like "item-*" which matches it
+func documentedSlot(slots []M.Slot, name string) *M.Slot {
+	var pattern *M.Slot
+	for i := range slots {
+		slot := &slots[i]
+		if slot.Name == name {
+			return slot
+		}
+		if pattern == nil && slot.Matches(name) {
+			pattern = slot
+		}
+	}
+	return pattern
+}
+
 // SlotMatch represents a found slot attribute in the document
 type SlotMatch struct {
 	Value string
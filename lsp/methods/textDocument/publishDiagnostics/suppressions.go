@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// htmlSuppressionPattern matches <!-- cem-ignore-next-line rule ... -->
+var htmlSuppressionPattern = regexp.MustCompile(`<!--\s*cem-ignore(?:-next-line)?((?:[\s,]+[\w-]+)*)\s*-->\s*$`)
+
+// scriptSuppressionPattern matches // cem-ignore rule ...
+var scriptSuppressionPattern = regexp.MustCompile(`//\s*cem-ignore(?:-next-line)?((?:[\s,]+[\w-]+)*)\s*$`)
+
+// suppression is a comment which suppresses diagnostics on the next line.
+// When rules is empty, it suppresses every rule.
+type suppression struct {
+	rules []types.DiagnosticRule
+}
+
+func (s suppression) suppresses(diagnostic protocol.Diagnostic) bool {
+	if len(s.rules) == 0 {
+		return true
+	}
+	rule, ok := types.DiagnosticRuleOf(diagnostic)
+	return ok && slices.Contains(s.rules, rule)
+}
+
+// findSuppressions maps the lines suppressed by comments to the comments.
+// HTML comments suppress diagnostics in HTML documents and in templates;
+// line comments also suppress diagnostics in TypeScript, outside templates.
+func findSuppressions(language, content string) map[uint32]suppression {
+	patterns := []*regexp.Regexp{htmlSuppressionPattern}
+	if language == "typescript" {
+		patterns = append(patterns, scriptSuppressionPattern)
+	}
+
+	suppressions := make(map[uint32]suppression)
+	for i, line := range strings.Split(content, "\n") {
+		if !strings.Contains(line, "cem-ignore") {
+			continue
+		}
+		for _, pattern := range patterns {
+			match := pattern.FindStringSubmatch(strings.TrimSuffix(line, "\r"))
+			if match == nil {
+				continue
+			}
+			var rules []types.DiagnosticRule
+			for _, rule := range strings.FieldsFunc(match[1], func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			}) {
+				rules = append(rules, types.DiagnosticRule(rule))
+			}
+			suppressions[uint32(i+1)] = suppression{rules: rules}
+			break
+		}
+	}
+	return suppressions
+}
+
+// applySuppressions removes the diagnostics suppressed by comments on the
+// line before them
+func applySuppressions(doc types.Document, diagnostics []protocol.Diagnostic) []protocol.Diagnostic {
+	content, err := doc.Content()
+	if err != nil || !strings.Contains(content, "cem-ignore") {
+		return diagnostics
+	}
+	suppressions := findSuppressions(doc.Language(), content)
+	if len(suppressions) == 0 {
+		return diagnostics
+	}
+	// The diagnostics may be cached by region, so they are filtered into a
+	// new slice rather than in place
+	kept := make([]protocol.Diagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		if s, ok := suppressions[diagnostic.Range.Start.Line]; ok && s.suppresses(diagnostic) {
+			helpers.SafeDebugLog("[DIAGNOSTICS] Suppressed diagnostic on line %d", diagnostic.Range.Start.Line)
+			continue
+		}
+		kept = append(kept, diagnostic)
+	}
+	return kept
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package publishDiagnostics_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+// Inline: each case is a short document whose comments and diagnostics are
+// easier to read side by side than across fixture files
+
+// ruleLines lists the diagnostics of the given rules as "line:rule"
+func ruleLines(diagnostics []protocol.Diagnostic, rules ...types.DiagnosticRule) []string {
+	lines := []string{}
+	for _, d := range diagnostics {
+		if rule, ok := types.DiagnosticRuleOf(d); ok && slices.Contains(rules, rule) {
+			lines = append(lines, fmt.Sprintf("%d:%s", d.Range.Start.Line, rule))
+		}
+	}
+	return lines
+}
+
+func TestSuppressions(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	dm, err := document.NewDocumentManager()
+	require.NoError(t, err)
+	defer dm.Close()
+	ctx.SetDocumentManager(dm)
+
+	ctx.AddElement("my-element", &M.CustomElement{})
+	ctx.AddAttributes("my-element", map[string]*M.Attribute{
+		"size":  {FullyQualified: M.FullyQualified{Name: "size"}},
+		"color": {FullyQualified: M.FullyQualified{Name: "color"}},
+	})
+
+	compute := func(uri, content string) []protocol.Diagnostic {
+		doc := dm.OpenDocument(uri, content, 1)
+		ctx.AddDocument(uri, doc)
+		return publishDiagnostics.ComputeDiagnostics(ctx, doc)
+	}
+
+	t.Run("HTML comments", func(t *testing.T) {
+		diagnostics := compute("file:///test.html", `<!-- cem-ignore-next-line unknown-attribute, void-end-tag -->
+<my-element siz="large"></my-element>
+<my-element colour="red"></my-element>
+<!-- cem-ignore-next-line -->
+<my-element colour="red" />
+<!-- cem-ignore-next-line unknown-attribute -->
+<input></input>`)
+
+		assert.Equal(t, []string{"2:unknown-attribute", "6:void-end-tag"}, ruleLines(diagnostics,
+			types.RuleUnknownAttribute,
+			types.RuleSelfClosingCustomElement,
+			types.RuleVoidEndTag,
+		))
+	})
+
+	t.Run("TypeScript comments", func(t *testing.T) {
+		diagnostics := compute("file:///test.ts", `// cem-ignore misplaced-directive
+const a = html`+"`"+`<my-element variant=${classMap(classes)}></my-element>`+"`"+`;
+const b = html`+"`"+`
+  <!-- cem-ignore-next-line -->
+  <my-element variant=${classMap(classes)}></my-element>
+  <my-element .style=${styleMap(styles)}></my-element>
+`+"`"+`;
+// cem-ignore unknown-element
+const c = html`+"`"+`<my-element variant=${classMap(classes)}></my-element>`+"`"+`;`)
+
+		assert.Equal(t, []string{"5:misplaced-directive", "8:misplaced-directive"}, ruleLines(diagnostics,
+			types.RuleMisplacedDirective,
+		))
+	})
+}
@@ -18,6 +18,8 @@ package publishDiagnostics
 
 import (
 	"fmt"
+	"maps"
+	"path"
 	"slices"
 	"strings"
 
@@ -105,6 +107,7 @@ func AnalyzeTagNameDiagnosticsForTest(ctx types.ServerContext, doc types.Documen
 				d := protocol.Diagnostic{
 					Range:    match.Range,
 					Severity: protocol.DiagnosticSeverityHint,
+					Code:     types.RuleDeprecatedElement.Code(),
 					Source:   protocol.NewOptional("cem-lsp"),
 					Tags:     protocol.NewDiagnosticTags(protocol.DiagnosticTagDeprecated),
 				}
@@ -113,6 +116,16 @@ func AnalyzeTagNameDiagnosticsForTest(ctx types.ServerContext, doc types.Documen
 				} else {
 					d.Message = protocol.String(fmt.Sprintf("Custom element '%s' is deprecated", tagName))
 				}
+				if replacement, ok := helpers.DeprecationReplacement(ctx, tagName); ok {
+					autofixData := &types.AutofixData{
+						Type:       types.DiagnosticTypeDeprecatedElement,
+						Original:   tagName,
+						Suggestion: replacement,
+						Range:      match.Range,
+					}
+					data, _ := json.Marshal(autofixData.ToMap())
+					d.Data = data
+				}
 				diagnostics = append(diagnostics, d)
 			}
 			continue
@@ -128,6 +141,7 @@ func AnalyzeTagNameDiagnosticsForTest(ctx types.ServerContext, doc types.Documen
 			var diagnostic protocol.Diagnostic
 			diagnostic.Range = match.Range
 			diagnostic.Severity = protocol.DiagnosticSeverityError
+			diagnostic.Code = types.RuleUnknownElement.Code()
 			diagnostic.Source = protocol.NewOptional("cem-lsp")
 
 			if closestMatch != "" && distance <= 2 {
@@ -189,9 +203,17 @@ func AnalyzeTagNameDiagnosticsForTest(ctx types.ServerContext, doc types.Documen
 
 			// Element exists in manifest but is not imported
 			if importPath, hasSource := ctx.ElementSource(tagName); hasSource {
+				// Prefer the specifier which the document's import map already
+				// declares for the element's module
+				if elementDef, hasDefinition := ctx.ElementDefinition(tagName); hasDefinition {
+					if specifier, ok := importMapSpecifier(doc.ImportMap(), elementDef.ModulePath()); ok {
+						importPath = specifier
+					}
+				}
 				var diagnostic protocol.Diagnostic
 				diagnostic.Range = match.Range
 				diagnostic.Severity = protocol.DiagnosticSeverityError
+				diagnostic.Code = types.RuleMissingImport.Code()
 				diagnostic.Source = protocol.NewOptional("cem-lsp")
 				diagnostic.Message = protocol.String(fmt.Sprintf("Custom element '%s' is not imported. Add import from '%s'", tagName, importPath))
 
@@ -548,6 +570,32 @@ func parseNonModuleScriptImports(content string, ctx types.ServerContext) []stri
 	return importedElements
 }
 
+// importMapSpecifier finds the import map entry whose address is the module
+// at modulePath. Prefix entries, whose specifiers end in "/", are skipped.
+// When several entries match, the first in lexical order wins.
+func importMapSpecifier(importMap map[string]string, modulePath string) (string, bool) {
+	target := strings.TrimPrefix(path.Clean("/"+modulePath), "/")
+	if len(importMap) == 0 || target == "" {
+		return "", false
+	}
+	for _, specifier := range slices.Sorted(maps.Keys(importMap)) {
+		if strings.HasSuffix(specifier, "/") {
+			continue
+		}
+		address := importMap[specifier]
+		if strings.Contains(address, "://") {
+			continue
+		}
+		address = strings.TrimPrefix(path.Clean("/"+address), "/")
+		if address == target ||
+			strings.HasSuffix(address, "/"+target) ||
+			strings.HasSuffix(target, "/"+address) {
+			return specifier, true
+		}
+	}
+	return "", false
+}
+
 // resolveImportPathToElements resolves an import path to custom element tag names
 // This function checks manifest data first, then uses module graph for additional re-export information
 func resolveImportPathToElements(importPath string, ctx types.ServerContext) []string {
@@ -905,3 +905,66 @@ customElements.define('my-button', MyButton);`
 // TestTagDiagnostics_LocalDefinitionNotInManifest has been moved to
 // lsp/ephemeral_integration_test.go as it requires a real Server with
 // ephemeral registry synthesis to work correctly.
+
+// TestTagDiagnostics_ImportMapSpecifier tests that missing import diagnostics
+// suggest the specifier which the document's import map declares for the
+// element's module, falling back to the element's source
+func TestTagDiagnostics_ImportMapSpecifier(t *testing.T) {
+	fixtureDir, err := filepath.Abs("testdata/integration/tag-diagnostics")
+	if err != nil {
+		t.Fatalf("Failed to get fixture path: %v", err)
+	}
+
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create document manager: %v", err)
+	}
+	defer dm.Close()
+
+	ctx := testhelpers.NewMockServerContext()
+	ctx.SetDocumentManager(dm)
+	manifestBytes, err := os.ReadFile(filepath.Join(fixtureDir, "node_modules", "@scope", "package", "custom-elements.json"))
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	var pkg M.Package
+	if err := json.Unmarshal(manifestBytes, &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	ctx.AddManifest(&pkg)
+
+	htmlPath := filepath.Join(fixtureDir, "missing-imports-importmap.html")
+	content, err := os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatalf("Failed to read HTML file: %v", err)
+	}
+	uri := "file://" + htmlPath
+	doc := dm.OpenDocument(uri, string(content), 1)
+	if doc == nil {
+		t.Fatal("Failed to open document")
+	}
+	ctx.AddDocument(uri, doc)
+
+	diagnostics := publishDiagnostics.AnalyzeTagNameDiagnosticsForTest(ctx, doc)
+
+	got := make(map[string]string)
+	for _, diag := range diagnostics {
+		var data map[string]any
+		if err := json.Unmarshal(diag.Data, &data); err != nil {
+			t.Fatalf("Failed to parse diagnostic data: %v", err)
+		}
+		tagName, _ := data["tagName"].(string)
+		importPath, _ := data["importPath"].(string)
+		got[tagName] = importPath
+	}
+
+	want := map[string]string{
+		"my-foo": "@scope/package/my-foo",
+		"my-bar": "./my-bar/my-bar.js",
+	}
+	for tagName, importPath := range want {
+		if got[tagName] != importPath {
+			t.Errorf("Expected %s import path %q, got %q", tagName, importPath, got[tagName])
+		}
+	}
+}
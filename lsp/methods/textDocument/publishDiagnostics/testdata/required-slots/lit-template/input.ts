@@ -0,0 +1,3 @@
+import { html } from 'lit';
+
+const template = html`<my-card>${content}</my-card>`;
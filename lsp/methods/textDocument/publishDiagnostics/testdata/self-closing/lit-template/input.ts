@@ -0,0 +1,3 @@
+import { html } from 'lit';
+
+export const card = html`<my-card variant="a" /><p>after</p>`;
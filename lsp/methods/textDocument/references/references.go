@@ -17,8 +17,10 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package references
 
 import (
+	"fmt"
 	"io/fs"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	htmllang "bennypowers.dev/cem/internal/languages/html"
@@ -30,7 +32,6 @@ import (
 	Q "bennypowers.dev/cem/internal/treesitter"
 	"go.lsp.dev/protocol"
 	urilib "go.lsp.dev/uri"
-	ignore "github.com/sabhiram/go-gitignore"
 )
 
 // References handles textDocument/references requests
@@ -66,7 +67,83 @@ func References(ctx types.ServerContext, params *protocol.ReferenceParams) ([]pr
 
 	// Search for all references across all documents
 	filesystem := ctx.FileSystem()
-	return findAllReferences(ctx, request, filesystem), nil
+	locations := findAllReferences(ctx, request, filesystem)
+
+	if ctx.Config().References.ExcludeTests {
+		locations = slices.DeleteFunc(locations, func(location protocol.Location) bool {
+			return classifyURI(ctx, string(location.URI)) == helpers.FileKindTest
+		})
+	}
+
+	return locations, nil
+}
+
+// UsageCounts summarizes references to an element by the kind of file they appear in
+type UsageCounts struct {
+	Total   int `json:"total"`
+	Tests   int `json:"tests"`
+	Stories int `json:"stories"`
+	Demos   int `json:"demos"`
+}
+
+// Label formats the counts for display, e.g. in a code lens:
+// "12 usages (3 in tests)"
+func (c UsageCounts) Label() string {
+	noun := "usages"
+	if c.Total == 1 {
+		noun = "usage"
+	}
+	label := fmt.Sprintf("%d %s", c.Total, noun)
+
+	var breakdown []string
+	if c.Tests > 0 {
+		breakdown = append(breakdown, fmt.Sprintf("%d in tests", c.Tests))
+	}
+	if c.Stories > 0 {
+		breakdown = append(breakdown, fmt.Sprintf("%d in stories", c.Stories))
+	}
+	if c.Demos > 0 {
+		breakdown = append(breakdown, fmt.Sprintf("%d in demos", c.Demos))
+	}
+	if len(breakdown) > 0 {
+		label += " (" + strings.Join(breakdown, ", ") + ")"
+	}
+	return label
+}
+
+// CountUsages counts references to an element across open documents and the
+// workspace, classifying each by file kind. Test files are always counted,
+// regardless of the references.excludeTests setting.
+func CountUsages(ctx types.ServerContext, elementName string) UsageCounts {
+	var counts UsageCounts
+	request := &ReferenceRequest{ElementName: elementName}
+	for _, location := range findAllReferences(ctx, request, ctx.FileSystem()) {
+		counts.Total++
+		switch classifyURI(ctx, string(location.URI)) {
+		case helpers.FileKindTest:
+			counts.Tests++
+		case helpers.FileKindStory:
+			counts.Stories++
+		case helpers.FileKindDemo:
+			counts.Demos++
+		}
+	}
+	return counts
+}
+
+// classifyURI determines the file kind of a document URI using the
+// configured globs, matched against the path relative to the workspace root
+func classifyURI(ctx types.ServerContext, uri string) helpers.FileKind {
+	path := strings.TrimPrefix(uri, "file://")
+	root := strings.TrimSuffix(ctx.WorkspaceRoot(), "/")
+	if rel, err := filepath.Rel(root, path); root != "" && err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	} else {
+		path = strings.TrimPrefix(path, "/")
+	}
+
+	files := ctx.Config().Files
+	return helpers.ClassifyFile(path, files.Tests, files.Stories, files.Demos)
 }
 
 // ReferenceRequest contains information about what to search for
@@ -275,6 +352,21 @@ func findReferencesInDocument(ctx types.ServerContext, doc types.Document, eleme
 func findReferencesInWorkspaceWithFS(workspaceRoot string, elementName string, openDocuments []types.Document, filesystem platform.FileSystem) []protocol.Location {
 	var locations []protocol.Location
 
+	WalkWorkspaceFiles(workspaceRoot, openDocuments, filesystem, func(path, fileURI string) {
+		// Search for references in this file
+		fileLocations := findReferencesInFileWithFS(path, fileURI, elementName, filesystem)
+		locations = append(locations, fileLocations...)
+	})
+
+	helpers.SafeDebugLog("[REFERENCES] Found %d workspace references for %s", len(locations), elementName)
+	return locations
+}
+
+// WalkWorkspaceFiles calls visit with the path and file URI of each HTML,
+// TypeScript, and JavaScript file in the workspace which is not ignored by
+// .gitignore or .cemignore, skipping files which are open, since those are
+// tracked as documents.
+func WalkWorkspaceFiles(workspaceRoot string, openDocuments []types.Document, filesystem platform.FileSystem, visit func(path, fileURI string)) {
 	// Normalize workspace root - remove trailing slashes for consistent path handling
 	workspaceRoot = strings.TrimSuffix(workspaceRoot, "/")
 
@@ -284,38 +376,16 @@ func findReferencesInWorkspaceWithFS(workspaceRoot string, elementName string, o
 		openFiles[doc.URI()] = true
 	}
 
-	// Load .gitignore if it exists
-	var gitignoreMatcher *ignore.GitIgnore
-	gitignorePath := filepath.Join(workspaceRoot, ".gitignore")
-	if gitignoreContent, err := filesystem.ReadFile(gitignorePath); err == nil {
-		// Parse gitignore content directly (works with in-memory filesystems)
-		gitignoreMatcher = ignore.CompileIgnoreLines(strings.Split(string(gitignoreContent), "\n")...)
-	}
+	// Honor .gitignore and .cemignore at the workspace root
+	matcher := platform.LoadIgnoreMatcher(filesystem, workspaceRoot, platform.WorkspaceIgnoreFiles...)
 
-	// Find all relevant files in workspace using platform.WalkDir
-	walkErr := platform.WalkDir(filesystem, workspaceRoot, nil, func(path string, d fs.DirEntry, err error) error {
+	// Find all relevant files in workspace using platform.WalkDirIgnoring
+	walkErr := platform.WalkDirIgnoring(filesystem, workspaceRoot, nil, matcher, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil // Skip errors and continue
 		}
 
-		// Get relative path from workspace root for gitignore matching
-		relPath, err := filepath.Rel(workspaceRoot, path)
-		if err != nil {
-			return nil // Skip if we can't get relative path
-		}
-
-		// Skip directories that should be ignored
 		if d.IsDir() {
-			// Check gitignore for directories
-			if gitignoreMatcher != nil && gitignoreMatcher.MatchesPath(relPath+"/") {
-				return fs.SkipDir
-			}
-
-			return nil
-		}
-
-		// Check gitignore for files
-		if gitignoreMatcher != nil && gitignoreMatcher.MatchesPath(relPath) {
 			return nil
 		}
 
@@ -342,9 +412,7 @@ func findReferencesInWorkspaceWithFS(workspaceRoot string, elementName string, o
 			return nil
 		}
 
-		// Search for references in this file
-		fileLocations := findReferencesInFileWithFS(path, fileURI, elementName, filesystem)
-		locations = append(locations, fileLocations...)
+		visit(path, fileURI)
 
 		return nil
 	})
@@ -352,9 +420,6 @@ func findReferencesInWorkspaceWithFS(workspaceRoot string, elementName string, o
 	if walkErr != nil {
 		helpers.SafeDebugLog("[REFERENCES] Error walking workspace: %v", walkErr)
 	}
-
-	helpers.SafeDebugLog("[REFERENCES] Found %d workspace references for %s", len(locations), elementName)
-	return locations
 }
 
 // findReferencesInFileWithFS searches for references using a provided filesystem
@@ -19,6 +19,7 @@ package references_test
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"bennypowers.dev/cem/internal/platform"
@@ -144,3 +145,83 @@ func TestReferences_Fixtures(t *testing.T) {
 		}
 	})
 }
+
+// setupFileKindsFixture opens the fixture input as index.html and mounts its
+// workspace directory at the workspace root
+func setupFileKindsFixture(t *testing.T, fixture *testutil.LSPFixture) *testhelpers.MockServerContext {
+	t.Helper()
+	ctx := testhelpers.NewMockServerContext()
+
+	dm, err := document.NewDocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to create DocumentManager: %v", err)
+	}
+	t.Cleanup(dm.Close)
+	ctx.SetDocumentManager(dm)
+
+	uri := "file:///index.html"
+	ctx.AddDocument(uri, dm.OpenDocument(uri, fixture.InputContent, 1))
+
+	workspaceDir := filepath.Join("testdata-filekinds", fixture.Name, "workspace")
+	ctx.SetFileSystem(testutil.LoadTestdataFS(t, workspaceDir, "."))
+	ctx.SetWorkspaceRoot(".")
+	return ctx
+}
+
+func TestReferences_ExcludeTests(t *testing.T) {
+	testutil.RunLSPFixture(t, "testdata-filekinds", "mixed-usages", func(t *testing.T, fixture *testutil.LSPFixture) {
+		ctx := setupFileKindsFixture(t, fixture)
+		config := ctx.Config()
+		config.References.ExcludeTests = true
+		ctx.SetConfig(config)
+
+		var expected []protocol.Location
+		if err := fixture.GetExpected("exclude-tests", &expected); err != nil {
+			t.Fatalf("Failed to get expected locations: %v", err)
+		}
+
+		locations, err := references.References(ctx, &protocol.ReferenceParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: "file:///index.html"},
+				Position:     *fixture.Cursor,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(locations) != len(expected) {
+			t.Fatalf("Expected %d locations, got %d: %+v", len(expected), len(locations), locations)
+		}
+		for _, want := range expected {
+			if !slices.ContainsFunc(locations, func(got protocol.Location) bool {
+				return got.URI == want.URI && got.Range == want.Range
+			}) {
+				t.Errorf("Expected location not found: %s at %+v", want.URI, want.Range)
+			}
+		}
+	})
+}
+
+func TestCountUsages(t *testing.T) {
+	testutil.RunLSPFixture(t, "testdata-filekinds", "mixed-usages", func(t *testing.T, fixture *testutil.LSPFixture) {
+		ctx := setupFileKindsFixture(t, fixture)
+
+		var expected references.UsageCounts
+		if err := fixture.GetExpected("counts", &expected); err != nil {
+			t.Fatalf("Failed to get expected counts: %v", err)
+		}
+		var expectedLabel string
+		if err := fixture.GetExpected("label", &expectedLabel); err != nil {
+			t.Fatalf("Failed to get expected label: %v", err)
+		}
+
+		counts := references.CountUsages(ctx, "my-el")
+		if counts != expected {
+			t.Errorf("Expected counts %+v, got %+v", expected, counts)
+		}
+		if label := counts.Label(); label != expectedLabel {
+			t.Errorf("Expected label %q, got %q", expectedLabel, label)
+		}
+	})
+}
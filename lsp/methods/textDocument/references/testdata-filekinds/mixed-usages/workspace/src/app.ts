@@ -0,0 +1 @@
+html`<my-el>app</my-el>`
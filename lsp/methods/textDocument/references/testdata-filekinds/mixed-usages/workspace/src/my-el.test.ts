@@ -0,0 +1 @@
+html`<my-el>test</my-el>`
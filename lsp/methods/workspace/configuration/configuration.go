@@ -19,8 +19,10 @@ package configuration
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 
 	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/methods/textDocument/publishDiagnostics"
 	"bennypowers.dev/cem/lsp/types"
 	"go.lsp.dev/protocol"
 )
@@ -39,8 +41,18 @@ func DidChangeConfiguration(ctx types.ServerContext, params *protocol.DidChangeC
 		return nil
 	}
 
+	previous := ctx.Config().Diagnostics
 	ctx.SetConfig(config)
 	helpers.SafeDebugLog("[CONFIG] Updated configuration: inlayHints=%v", config.InlayHints)
+
+	// Republish open documents' diagnostics with their new severities
+	if !maps.Equal(previous, ctx.Config().Diagnostics) && !ctx.UsePullDiagnostics() {
+		for _, doc := range ctx.AllDocuments() {
+			if err := publishDiagnostics.PublishDiagnostics(ctx, doc.URI()); err != nil {
+				helpers.SafeDebugLog("[CONFIG] Failed to publish diagnostics for %s: %v", doc.URI(), err)
+			}
+		}
+	}
 	return nil
 }
 
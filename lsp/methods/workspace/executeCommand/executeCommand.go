@@ -0,0 +1,73 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package executeCommand
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// GenerateUsageReport reports how custom elements are used across the
+// workspace. It takes an optional argument, {"format": "markdown"} or
+// {"format": "json"}, and returns the markdown report as a string, or the
+// report itself.
+const GenerateUsageReport = "cem.generateUsageReport"
+
+// Commands lists the commands the server executes, for its
+// executeCommandProvider capability
+var Commands = []string{GenerateUsageReport}
+
+// usageReportArgs are the arguments of the cem.generateUsageReport command
+type usageReportArgs struct {
+	Format string `json:"format"`
+}
+
+// ExecuteCommand handles workspace/executeCommand requests
+func ExecuteCommand(ctx types.ServerContext, params *protocol.ExecuteCommandParams) (any, error) {
+	helpers.SafeDebugLog("[EXECUTE_COMMAND] Request for command: %s", params.Command)
+
+	switch params.Command {
+	case GenerateUsageReport:
+		var args usageReportArgs
+		if len(params.Arguments) > 0 {
+			if err := json.Unmarshal(params.Arguments[0], &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments for %s: %w", params.Command, err)
+			}
+		}
+
+		dm, err := ctx.DocumentManager()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get document manager: %w", err)
+		}
+		report := BuildUsageReport(ctx, dm, ctx.FileSystem(), ctx.WorkspaceRoot(), ctx.AllDocuments())
+
+		switch args.Format {
+		case "", "markdown":
+			return report.Markdown(), nil
+		case "json":
+			return report, nil
+		default:
+			return nil, fmt.Errorf("invalid format %q: must be markdown or json", args.Format)
+		}
+	default:
+		return nil, fmt.Errorf("unknown command: %s", params.Command)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package executeCommand_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/lsp/document"
+	"bennypowers.dev/cem/lsp/methods/workspace/executeCommand"
+	"bennypowers.dev/cem/lsp/testhelpers"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+)
+
+func TestGenerateUsageReport(t *testing.T) {
+	testutil.RunLSPFixture(t, "testdata", "usage-report", func(t *testing.T, fixture *testutil.LSPFixture) {
+		ctx := testhelpers.NewMockServerContext()
+
+		var pkg M.Package
+		require.NoError(t, json.Unmarshal(fixture.Manifest, &pkg))
+		ctx.AddManifest(&pkg)
+
+		dm, err := document.NewDocumentManager()
+		require.NoError(t, err)
+		defer dm.Close()
+		ctx.SetDocumentManager(dm)
+
+		uri := "file:///index.html"
+		ctx.AddDocument(uri, dm.OpenDocument(uri, fixture.InputContent, 1))
+
+		workspaceDir := filepath.Join("testdata", fixture.Name, "workspace")
+		ctx.SetFileSystem(testutil.LoadTestdataFS(t, workspaceDir, "."))
+		ctx.SetWorkspaceRoot(".")
+
+		execute := func(args ...protocol.LSPAny) (any, error) {
+			return executeCommand.ExecuteCommand(ctx, &protocol.ExecuteCommandParams{
+				Command:   executeCommand.GenerateUsageReport,
+				Arguments: args,
+			})
+		}
+
+		t.Run("json", func(t *testing.T) {
+			var expected executeCommand.UsageReport
+			require.NoError(t, fixture.GetExpected("expected", &expected))
+
+			result, err := execute(protocol.LSPAny(`{"format": "json"}`))
+			require.NoError(t, err)
+			assert.Equal(t, &expected, result)
+		})
+
+		t.Run("markdown", func(t *testing.T) {
+			result, err := execute()
+			require.NoError(t, err)
+			markdown, ok := result.(string)
+			require.True(t, ok, "markdown report should be a string")
+			assert.Contains(t, markdown, "Scanned 2 files: 5 uses of 3 elements.")
+			assert.Contains(t, markdown, "| `<my-button>` | 3 | 2 |")
+			assert.Contains(t, markdown, "| `<other-element>` (not in a manifest) | 1 | 1 |")
+			assert.Contains(t, markdown, "| `variant` | 3 | (dynamic) × 1, `primary` × 1, `secondary` × 1 |")
+			assert.Contains(t, markdown, "### `old-size` attribute of `<my-button>`\n\nUse size\n\n- src/app.ts:2\n")
+		})
+
+		t.Run("invalid format", func(t *testing.T) {
+			_, err := execute(protocol.LSPAny(`{"format": "xml"}`))
+			assert.ErrorContains(t, err, "invalid format")
+		})
+	})
+}
+
+func TestExecuteCommand_Unknown(t *testing.T) {
+	ctx := testhelpers.NewMockServerContext()
+	_, err := executeCommand.ExecuteCommand(ctx, &protocol.ExecuteCommandParams{Command: "cem.unknown"})
+	assert.ErrorContains(t, err, "unknown command: cem.unknown")
+}
@@ -0,0 +1,2 @@
+import { html } from 'lit';
+export const view = html`<my-button variant="${this.variant}" old-size="lg">Go</my-button>`;
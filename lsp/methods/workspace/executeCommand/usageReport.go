@@ -0,0 +1,334 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package executeCommand
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/validations"
+	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/lsp/methods/textDocument/references"
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// DynamicValue stands for attribute values which are template expressions,
+// e.g. `variant="${this.variant}"`, in value distributions
+const DynamicValue = "(dynamic)"
+
+// UsageReport summarizes how custom elements are used across a workspace
+type UsageReport struct {
+	Files      int               `json:"files"` // Number of files scanned
+	Elements   []ElementUsage    `json:"elements"`
+	Deprecated []DeprecatedUsage `json:"deprecated"`
+}
+
+// ElementUsage counts the uses of one element, and of its attributes
+type ElementUsage struct {
+	TagName    string           `json:"tagName"`
+	Count      int              `json:"count"`
+	Files      int              `json:"files"`
+	Known      bool             `json:"known"` // Declared in a loaded manifest
+	Attributes []AttributeUsage `json:"attributes,omitempty"`
+}
+
+// AttributeUsage counts the uses of an attribute, by value. Boolean
+// attributes written without a value are counted under the empty string.
+type AttributeUsage struct {
+	Name   string       `json:"name"`
+	Count  int          `json:"count"`
+	Values []ValueCount `json:"values"`
+}
+
+// ValueCount is the number of times an attribute had a value
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// DeprecatedUsage lists the uses of a deprecated element, or of a
+// deprecated attribute when Attribute is set
+type DeprecatedUsage struct {
+	TagName   string   `json:"tagName"`
+	Attribute string   `json:"attribute,omitempty"`
+	Reason    string   `json:"reason,omitempty"`
+	Locations []string `json:"locations"` // Workspace-relative "path:line"
+}
+
+// UsageRegistry is the part of the registry a usage report reads
+type UsageRegistry interface {
+	FindCustomElementDeclaration(tagName string) *M.CustomElementDeclaration
+	Attributes(tagName string) (map[string]*M.Attribute, bool)
+}
+
+// BuildUsageReport scans the open documents, and the workspace files which
+// references.WalkWorkspaceFiles visits, for custom elements, counting them,
+// the values of their attributes, and their deprecated APIs
+func BuildUsageReport(
+	registry UsageRegistry,
+	handlers types.HandlerProvider,
+	fsys platform.FileSystem,
+	workspaceRoot string,
+	openDocuments []types.Document,
+) *UsageReport {
+	b := &usageBuilder{
+		registry:   registry,
+		root:       workspaceRoot,
+		elements:   make(map[string]*elementTally),
+		deprecated: make(map[[2]string]*DeprecatedUsage),
+	}
+
+	for _, doc := range openDocuments {
+		matches, err := doc.FindCustomElements(handlers)
+		if err != nil {
+			helpers.SafeDebugLog("[USAGE_REPORT] Failed to find custom elements in %s: %v", doc.URI(), err)
+			continue
+		}
+		b.addFile(strings.TrimPrefix(doc.URI(), "file://"), matches)
+	}
+
+	if workspaceRoot != "" {
+		references.WalkWorkspaceFiles(workspaceRoot, openDocuments, fsys, func(path, fileURI string) {
+			content, err := fsys.ReadFile(path)
+			if err != nil {
+				helpers.SafeDebugLog("[USAGE_REPORT] Failed to read file %s: %v", path, err)
+				return
+			}
+			language := "typescript"
+			if ext := filepath.Ext(path); ext == ".html" || ext == ".htm" {
+				language = "html"
+			}
+			handler := handlers.GetLanguageHandler(language)
+			if handler == nil {
+				return
+			}
+			doc := handler.CreateDocument(fileURI, string(content), 0)
+			defer doc.Close()
+			matches, err := handler.FindCustomElements(doc)
+			if err != nil {
+				helpers.SafeDebugLog("[USAGE_REPORT] Failed to find custom elements in %s: %v", path, err)
+				return
+			}
+			b.addFile(path, matches)
+		})
+	}
+
+	return b.report()
+}
+
+// elementTally accumulates the uses of one element
+type elementTally struct {
+	count      int
+	files      map[string]bool
+	attributes map[string]map[string]int // name → value → count
+}
+
+type usageBuilder struct {
+	registry   UsageRegistry
+	root       string
+	files      int
+	elements   map[string]*elementTally
+	deprecated map[[2]string]*DeprecatedUsage // [tagName, attribute] → uses
+}
+
+func (b *usageBuilder) addFile(path string, matches []types.CustomElementMatch) {
+	b.files++
+	path = b.relativePath(path)
+	for _, match := range matches {
+		tally := b.elements[match.TagName]
+		if tally == nil {
+			tally = &elementTally{files: make(map[string]bool), attributes: make(map[string]map[string]int)}
+			b.elements[match.TagName] = tally
+		}
+		tally.count++
+		tally.files[path] = true
+
+		decl := b.registry.FindCustomElementDeclaration(match.TagName)
+		if decl != nil && decl.IsDeprecated() {
+			b.addDeprecated(match.TagName, "", decl.Deprecated, path, match.Range.Start.Line)
+		}
+		declared, _ := b.registry.Attributes(match.TagName)
+		for _, attr := range match.Attributes {
+			// Event listeners and property bindings aren't attributes
+			if attr.BindingPrefix == "@" || attr.BindingPrefix == "." {
+				continue
+			}
+			a := declared[attr.Name]
+			if a == nil && validations.IsGlobalAttribute(attr.Name) {
+				continue
+			}
+			if tally.attributes[attr.Name] == nil {
+				tally.attributes[attr.Name] = make(map[string]int)
+			}
+			tally.attributes[attr.Name][attributeValue(attr)]++
+			if a != nil && a.IsDeprecated() {
+				b.addDeprecated(match.TagName, attr.Name, a.Deprecated, path, attr.Range.Start.Line)
+			}
+		}
+	}
+}
+
+func (b *usageBuilder) addDeprecated(tagName, attribute string, deprecated M.Deprecated, path string, line uint32) {
+	key := [2]string{tagName, attribute}
+	usage := b.deprecated[key]
+	if usage == nil {
+		usage = &DeprecatedUsage{TagName: tagName, Attribute: attribute}
+		if reason, ok := deprecated.Value().(string); ok {
+			usage.Reason = reason
+		}
+		b.deprecated[key] = usage
+	}
+	usage.Locations = append(usage.Locations, fmt.Sprintf("%s:%d", path, line+1))
+}
+
+// relativePath makes a document path relative to the workspace root, for
+// display
+func (b *usageBuilder) relativePath(path string) string {
+	root := strings.TrimSuffix(b.root, "/")
+	if rel, err := filepath.Rel(root, path); root != "" && err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return strings.TrimPrefix(filepath.ToSlash(path), "/")
+}
+
+// attributeValue returns the value an attribute was written with, or
+// DynamicValue for template expressions
+func attributeValue(attr types.AttributeMatch) string {
+	switch {
+	case attr.ExpressionKind == "literal":
+		return attr.ExpressionDetail
+	case attr.ExpressionKind != "" || attr.BindingPrefix == "?" || strings.Contains(attr.Value, "${"):
+		return DynamicValue
+	default:
+		return attr.Value
+	}
+}
+
+// report sorts the tallies into a report, most used first
+func (b *usageBuilder) report() *UsageReport {
+	report := &UsageReport{
+		Files:      b.files,
+		Elements:   []ElementUsage{},
+		Deprecated: []DeprecatedUsage{},
+	}
+	for tagName, tally := range b.elements {
+		usage := ElementUsage{
+			TagName: tagName,
+			Count:   tally.count,
+			Files:   len(tally.files),
+			Known:   b.registry.FindCustomElementDeclaration(tagName) != nil,
+		}
+		for name, values := range tally.attributes {
+			attribute := AttributeUsage{Name: name}
+			for value, count := range values {
+				attribute.Count += count
+				attribute.Values = append(attribute.Values, ValueCount{Value: value, Count: count})
+			}
+			slices.SortFunc(attribute.Values, func(a, b ValueCount) int {
+				return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Value, b.Value))
+			})
+			usage.Attributes = append(usage.Attributes, attribute)
+		}
+		slices.SortFunc(usage.Attributes, func(a, b AttributeUsage) int {
+			return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+		})
+		report.Elements = append(report.Elements, usage)
+	}
+	slices.SortFunc(report.Elements, func(a, b ElementUsage) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.TagName, b.TagName))
+	})
+
+	for _, usage := range b.deprecated {
+		slices.Sort(usage.Locations)
+		report.Deprecated = append(report.Deprecated, *usage)
+	}
+	slices.SortFunc(report.Deprecated, func(a, b DeprecatedUsage) int {
+		return cmp.Or(cmp.Compare(a.TagName, b.TagName), cmp.Compare(a.Attribute, b.Attribute))
+	})
+	return report
+}
+
+// Markdown renders the report for people, e.g. to track the adoption of a
+// design system
+func (r *UsageReport) Markdown() string {
+	var b strings.Builder
+	total := 0
+	for _, element := range r.Elements {
+		total += element.Count
+	}
+	b.WriteString("# Custom Element Usage\n\n")
+	fmt.Fprintf(&b, "Scanned %d files: %d uses of %d elements.\n", r.Files, total, len(r.Elements))
+
+	if len(r.Elements) > 0 {
+		b.WriteString("\n## Elements\n\n")
+		b.WriteString("| Element | Uses | Files |\n")
+		b.WriteString("| ------- | ---- | ----- |\n")
+		for _, element := range r.Elements {
+			name := fmt.Sprintf("`<%s>`", element.TagName)
+			if !element.Known {
+				name += " (not in a manifest)"
+			}
+			fmt.Fprintf(&b, "| %s | %d | %d |\n", name, element.Count, element.Files)
+		}
+	}
+
+	for _, element := range r.Elements {
+		if len(element.Attributes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## `<%s>` Attributes\n\n", element.TagName)
+		b.WriteString("| Attribute | Uses | Values |\n")
+		b.WriteString("| --------- | ---- | ------ |\n")
+		for _, attribute := range element.Attributes {
+			values := make([]string, len(attribute.Values))
+			for i, value := range attribute.Values {
+				label := fmt.Sprintf("`%s`", value.Value)
+				switch value.Value {
+				case "":
+					label = "(no value)"
+				case DynamicValue:
+					label = DynamicValue
+				}
+				values[i] = fmt.Sprintf("%s × %d", label, value.Count)
+			}
+			fmt.Fprintf(&b, "| `%s` | %d | %s |\n", attribute.Name, attribute.Count, strings.Join(values, ", "))
+		}
+	}
+
+	if len(r.Deprecated) > 0 {
+		b.WriteString("\n## Deprecated APIs\n")
+		for _, usage := range r.Deprecated {
+			api := fmt.Sprintf("`<%s>`", usage.TagName)
+			if usage.Attribute != "" {
+				api = fmt.Sprintf("`%s` attribute of `<%s>`", usage.Attribute, usage.TagName)
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n", api)
+			if usage.Reason != "" {
+				fmt.Fprintf(&b, "%s\n\n", usage.Reason)
+			}
+			for _, location := range usage.Locations {
+				fmt.Fprintf(&b, "- %s\n", location)
+			}
+		}
+	}
+	return b.String()
+}
@@ -19,7 +19,6 @@ package symbol
 import (
 	"fmt"
 	"path/filepath"
-	"strings"
 
 	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/types"
@@ -34,20 +33,15 @@ func Symbol(ctx types.ServerContext, params *protocol.WorkspaceSymbolParams) ([]
 
 	var symbols []protocol.SymbolInformation
 
-	// Get all available custom elements
-	tagNames := ctx.AllTagNames()
-	helpers.SafeDebugLog("[WORKSPACE_SYMBOL] Found %d custom elements", len(tagNames))
+	// Rank elements by how well their tag names, class names, and
+	// descriptions match the query; an empty query returns all
+	matches := ctx.FindElements(params.Query, 0)
+	helpers.SafeDebugLog("[WORKSPACE_SYMBOL] Found %d matching custom elements", len(matches))
 
-	// Filter based on query
-	query := strings.ToLower(params.Query)
-
-	for _, tagName := range tagNames {
-		// Match logic: empty query returns all, otherwise filter by substring match
-		if query == "" || strings.Contains(strings.ToLower(tagName), query) {
-			symbol := createSymbolInformation(ctx, tagName)
-			if symbol != nil {
-				symbols = append(symbols, *symbol)
-			}
+	for _, match := range matches {
+		symbol := createSymbolInformation(ctx, match.TagName)
+		if symbol != nil {
+			symbols = append(symbols, *symbol)
 		}
 	}
 
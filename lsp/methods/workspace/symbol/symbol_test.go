@@ -97,6 +97,18 @@ func TestWorkspaceSymbol(t *testing.T) {
 			expectedCount: 1,
 			expectedNames: []string{"my-button - A reusable button component"},
 		},
+		{
+			name:          "Misspelled element name",
+			query:         "buton",
+			expectedCount: 1,
+			expectedNames: []string{"my-button - A reusable button component"},
+		},
+		{
+			name:          "Description",
+			query:         "layout",
+			expectedCount: 1,
+			expectedNames: []string{"my-card - Card layout component"},
+		},
 		{
 			name:          "No matches",
 			query:         "nonexistent",
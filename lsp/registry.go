@@ -21,15 +21,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
 
 	"bennypowers.dev/cem/generate"
+	"bennypowers.dev/cem/internal/config"
 	"bennypowers.dev/cem/internal/logging"
 	"bennypowers.dev/cem/internal/platform"
 	"bennypowers.dev/cem/lsp/helpers"
+	lspTypes "bennypowers.dev/cem/lsp/types"
 	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/internal/modulegraph"
 	"bennypowers.dev/cem/internal/treesitter"
@@ -103,6 +106,19 @@ type Registry struct {
 	fields map[string]map[string]*M.ClassField
 	// Events maps element tag names to their available events
 	events map[string]map[string]*M.Event
+	// Slots maps element tag names to their available slots
+	slots map[string][]M.Slot
+	// declarations maps element tag names to their declarations, for resolving
+	// superclasses declared in other manifests
+	declarations map[string]*M.CustomElementDeclaration
+	// manifestNames maps loaded manifests to their package names
+	manifestNames map[*M.Package]string
+	// tagSources records which manifest describes each tag name, and which
+	// others declare it too
+	tagSources map[string]*lspTypes.TagSource
+	// registryConfig decides which manifest describes a tag name which
+	// several manifests declare
+	registryConfig config.RegistryConfig
 	// Manifests stores all loaded manifest packages
 	Manifests []*M.Package
 	// ManifestPaths tracks the file paths of loaded CEM manifests for reload
@@ -111,6 +127,10 @@ type Registry struct {
 	WatchPaths []string
 	// ManifestPackageNames tracks the package name for each manifest path
 	ManifestPackageNames map[string]string
+	// manifestSources tracks where each manifest path was found
+	manifestSources map[string]lspTypes.ManifestSource
+	// packages records where each package came from, and why it failed to load
+	packages []lspTypes.PackageInfo
 	// File watching
 	fileWatcher platform.FileWatcher
 	watcherMu   sync.RWMutex
@@ -121,6 +141,7 @@ type Registry struct {
 	generateWatcher platform.GenerateWatcher
 	generateMu      sync.RWMutex
 	localWorkspace  types.WorkspaceContext // Track the local workspace for generate watching
+	saveGenerator   *SaveGenerator         // Regenerates the local manifest on save, when enabled
 	// Module graph for tracking re-export relationships
 	moduleGraph *modulegraph.ModuleGraph
 	// Filesystem abstraction for file operations
@@ -151,10 +172,15 @@ func NewRegistry(fileWatcher platform.FileWatcher, fsys platform.FileSystem) *Re
 		attributes:           make(map[string]map[string]*M.Attribute),
 		fields:               make(map[string]map[string]*M.ClassField),
 		events:               make(map[string]map[string]*M.Event),
+		slots:                make(map[string][]M.Slot),
+		declarations:         make(map[string]*M.CustomElementDeclaration),
+		manifestNames:        make(map[*M.Package]string),
+		tagSources:           make(map[string]*lspTypes.TagSource),
 		Manifests:            make([]*M.Package, 0),
 		ManifestPaths:        make([]string, 0),
 		WatchPaths:           make([]string, 0),
 		ManifestPackageNames: make(map[string]string),
+		manifestSources:      make(map[string]lspTypes.ManifestSource),
 		fileWatcher:          fileWatcher,
 		moduleGraph:          moduleGraph,
 		fs:                   fsys,
@@ -178,6 +204,9 @@ func (r *Registry) LoadFromWorkspace(workspace types.WorkspaceContext) error {
 
 	// Clear existing data
 	r.clear()
+	if cfg, err := workspace.Config(); err == nil && cfg != nil {
+		r.registryConfig = cfg.Registry
+	}
 
 	// 1. Load manifest from workspace itself (if available)
 	if err := r.loadWorkspaceManifest(workspace); err != nil {
@@ -216,10 +245,17 @@ func (r *Registry) clear() {
 	r.attributes = make(map[string]map[string]*M.Attribute)
 	r.fields = make(map[string]map[string]*M.ClassField)
 	r.events = make(map[string]map[string]*M.Event)
+	r.slots = make(map[string][]M.Slot)
+	r.declarations = make(map[string]*M.CustomElementDeclaration)
+	r.manifestNames = make(map[*M.Package]string)
+	r.tagSources = make(map[string]*lspTypes.TagSource)
 	r.Manifests = r.Manifests[:0]
 	r.ManifestPaths = r.ManifestPaths[:0]
 	r.WatchPaths = r.WatchPaths[:0]
 	r.ManifestPackageNames = make(map[string]string)
+	r.manifestSources = make(map[string]lspTypes.ManifestSource)
+	r.registryConfig = config.RegistryConfig{}
+	r.packages = nil
 	// Get QueryManager for dependency injection
 	queryManager, err := treesitter.GetGlobalQueryManager()
 	if err != nil {
@@ -239,6 +275,10 @@ func (r *Registry) clearDataOnly() {
 	r.attributes = make(map[string]map[string]*M.Attribute)
 	r.fields = make(map[string]map[string]*M.ClassField)
 	r.events = make(map[string]map[string]*M.Event)
+	r.slots = make(map[string][]M.Slot)
+	r.declarations = make(map[string]*M.CustomElementDeclaration)
+	r.manifestNames = make(map[*M.Package]string)
+	r.tagSources = make(map[string]*lspTypes.TagSource)
 	r.Manifests = r.Manifests[:0]
 	// Get QueryManager for dependency injection
 	queryManager, err := treesitter.GetGlobalQueryManager()
@@ -257,9 +297,10 @@ func (r *Registry) loadWorkspaceManifest(workspace types.WorkspaceContext) error
 	helpers.SafeDebugLog("Workspace manifest path: %s", workspace.CustomElementsManifestPath())
 
 	// Try to get package name from workspace package.json first
-	var packageName string
+	var packageName, packageVersion string
 	if packageJSON, err := workspace.PackageJSON(); err == nil && packageJSON != nil {
 		packageName = packageJSON.Name
+		packageVersion = packageJSON.Version
 		helpers.SafeDebugLog("Package name from workspace package.json: '%s'", packageName)
 	} else {
 		helpers.SafeDebugLog("Could not read workspace package.json: %v", err)
@@ -275,12 +316,22 @@ func (r *Registry) loadWorkspaceManifest(workspace types.WorkspaceContext) error
 		// 2. package.json has no "customElements" field (RHDS case)
 		helpers.SafeDebugLog("Workspace manifest not available, attempting in-memory generation")
 		if generatedPkg := r.generateInMemoryManifest(workspace.Root(), packageName); generatedPkg != nil {
-			r.addManifest(generatedPkg, packageName)
+			r.addManifest(generatedPkg, packageName, lspTypes.SourceWorkspace)
+			r.recordPackage(lspTypes.PackageInfo{Name: packageName, Version: packageVersion, Source: lspTypes.SourceWorkspace, Generated: true}, generatedPkg)
 			r.localWorkspace = workspace
 			helpers.SafeDebugLog("Successfully generated in-memory manifest for workspace")
 			return nil
 		}
 		helpers.SafeDebugLog("Failed to generate in-memory manifest for workspace")
+		if packageName != "" {
+			r.recordPackage(lspTypes.PackageInfo{
+				Name:         packageName,
+				Version:      packageVersion,
+				Source:       lspTypes.SourceWorkspace,
+				ManifestPath: workspace.CustomElementsManifestPath(),
+				Error:        err.Error(),
+			}, nil)
+		}
 
 		// Return original error since in-memory generation also failed
 		return err
@@ -297,12 +348,13 @@ func (r *Registry) loadWorkspaceManifest(workspace types.WorkspaceContext) error
 			}
 		}
 
-		r.addManifest(pkg, packageName)
+		r.addManifest(pkg, packageName, lspTypes.SourceWorkspace)
 		// Track the manifest file path for watching
 		manifestPath := workspace.CustomElementsManifestPath()
+		r.recordPackage(lspTypes.PackageInfo{Name: packageName, Version: packageVersion, Source: lspTypes.SourceWorkspace, ManifestPath: manifestPath}, pkg)
 		if manifestPath != "" {
 			helpers.SafeDebugLog("Tracking workspace manifest path: %s", manifestPath)
-			r.addManifestPathWithPackageName(manifestPath, packageName)
+			r.addManifestPathWithPackageName(manifestPath, packageName, lspTypes.SourceWorkspace)
 		} else {
 			helpers.SafeDebugLog("Warning: No manifest path returned from workspace")
 		}
@@ -315,7 +367,8 @@ func (r *Registry) loadWorkspaceManifest(workspace types.WorkspaceContext) error
 		helpers.SafeDebugLog("Workspace manifest is nil, attempting in-memory generation")
 		// If no manifest but workspace exists, try to generate in-memory
 		if generatedPkg := r.generateInMemoryManifest(workspace.Root(), packageName); generatedPkg != nil {
-			r.addManifest(generatedPkg, packageName)
+			r.addManifest(generatedPkg, packageName, lspTypes.SourceWorkspace)
+			r.recordPackage(lspTypes.PackageInfo{Name: packageName, Version: packageVersion, Source: lspTypes.SourceWorkspace, Generated: true}, generatedPkg)
 			r.localWorkspace = workspace
 			helpers.SafeDebugLog("Successfully generated in-memory manifest for workspace")
 		} else {
@@ -345,7 +398,7 @@ func (r *Registry) loadWorkspacePackageManifests(workspace types.WorkspaceContex
 
 	for _, pkgPath := range workspacePackages {
 		helpers.SafeDebugLog("Loading workspace package from: %s", pkgPath)
-		r.loadPackageManifest(pkgPath, workspace)
+		r.loadPackageManifest(pkgPath, workspace, lspTypes.SourceWorkspace)
 	}
 
 	return nil
@@ -583,11 +636,11 @@ func (r *Registry) loadNodeModulesManifests(workspace types.WorkspaceContext) er
 			for _, scopedEntry := range scopedEntries {
 				if scopedEntry.IsDir() {
 					scopedPkgPath := filepath.Join(pkgPath, scopedEntry.Name())
-					r.loadPackageManifest(scopedPkgPath, workspace)
+					r.loadPackageManifest(scopedPkgPath, workspace, lspTypes.SourceNodeModules)
 				}
 			}
 		} else {
-			r.loadPackageManifest(pkgPath, workspace)
+			r.loadPackageManifest(pkgPath, workspace, lspTypes.SourceNodeModules)
 		}
 	}
 
@@ -596,7 +649,7 @@ func (r *Registry) loadNodeModulesManifests(workspace types.WorkspaceContext) er
 
 // loadPackageManifest loads a manifest from a specific package directory.
 // When workspace is non-nil, reads through the workspace interface.
-func (r *Registry) loadPackageManifest(packagePath string, workspace types.WorkspaceContext) {
+func (r *Registry) loadPackageManifest(packagePath string, workspace types.WorkspaceContext, source lspTypes.ManifestSource) {
 	packageJSONPath := filepath.Join(packagePath, "package.json")
 	packageJSON, err := r.readPackageJSON(packageJSONPath, workspace)
 	if err != nil {
@@ -608,9 +661,12 @@ func (r *Registry) loadPackageManifest(packagePath string, workspace types.Works
 	}
 
 	manifestPath := filepath.Join(packagePath, packageJSON.CustomElements)
+	info := lspTypes.PackageInfo{Name: packageJSON.Name, Version: packageJSON.Version, Source: source, ManifestPath: manifestPath}
 
-	if pkg, err := r.loadManifestFileWithPackageName(manifestPath, packageJSON.Name, workspace); err == nil {
-		r.addManifest(pkg, packageJSON.Name)
+	pkg, err := r.loadManifestFileWithPackageName(manifestPath, packageJSON.Name, workspace, source)
+	if err == nil {
+		r.addManifest(pkg, packageJSON.Name, source)
+		r.recordPackage(info, pkg)
 		helpers.SafeDebugLog("Loaded manifest from %s (%s)", packageJSON.Name, manifestPath)
 		return
 	}
@@ -621,12 +677,17 @@ func (r *Registry) loadPackageManifest(packagePath string, workspace types.Works
 	if !exists {
 		helpers.SafeDebugLog("Manifest file %s doesn't exist, generating in-memory for %s", manifestPath, packageJSON.Name)
 		if pkg := r.generateInMemoryManifest(packagePath, packageJSON.Name); pkg != nil {
-			r.addManifest(pkg, packageJSON.Name)
+			r.addManifest(pkg, packageJSON.Name, source)
+			r.recordPackage(lspTypes.PackageInfo{Name: packageJSON.Name, Version: packageJSON.Version, Source: source, Generated: true}, pkg)
 			helpers.SafeDebugLog("Generated in-memory manifest for %s with %d modules", packageJSON.Name, len(pkg.Modules))
 		} else {
+			info.Error = fmt.Sprintf("manifest %s does not exist, and could not be generated", packageJSON.CustomElements)
+			r.recordPackage(info, nil)
 			helpers.SafeDebugLog("Failed to generate in-memory manifest for %s", packageJSON.Name)
 		}
 	} else {
+		info.Error = fmt.Sprintf("could not parse manifest: %v", err)
+		r.recordPackage(info, nil)
 		helpers.SafeDebugLog("Failed to load manifest from %s (%s): file exists but couldn't be parsed", packageJSON.Name, manifestPath)
 	}
 }
@@ -691,6 +752,7 @@ func (r *Registry) LoadAdditionalPackages(packages []string) error {
 	for _, spec := range packages {
 		if err := r.loadAdditionalPackage(spec); err != nil {
 			helpers.SafeDebugLog("Warning: Could not load additional package %s: %v", spec, err)
+			r.recordPackage(lspTypes.PackageInfo{Name: spec, Source: lspTypes.SourceAdditional, ManifestPath: spec, Error: err.Error()}, nil)
 			failed++
 			// Continue loading other packages
 		}
@@ -705,7 +767,7 @@ func (r *Registry) loadAdditionalPackage(spec string) error {
 	helpers.SafeDebugLog("Loading additional package: %s", spec)
 
 	// Create the appropriate workspace context based on the specifier type
-	ctx, err := workspace.GetContextForSpec(spec)
+	ctx, err := workspace.GetContextForSpecWithFileSystem(spec, r.fs)
 	if err != nil {
 		return fmt.Errorf("failed to get context for spec %q: %w", spec, err)
 	}
@@ -728,7 +790,8 @@ func (r *Registry) loadAdditionalPackage(spec string) error {
 	}
 
 	// Register the elements from this manifest
-	r.addManifest(manifest, pkgJSON.Name)
+	r.addManifest(manifest, pkgJSON.Name, lspTypes.SourceAdditional)
+	r.recordPackage(lspTypes.PackageInfo{Name: pkgJSON.Name, Version: pkgJSON.Version, Source: lspTypes.SourceAdditional, ManifestPath: spec}, manifest)
 
 	helpers.SafeDebugLog("Loaded additional package %s with %d modules", pkgJSON.Name, len(manifest.Modules))
 	return nil
@@ -766,20 +829,20 @@ func (r *Registry) readPackageJSON(path string, workspace types.WorkspaceContext
 	return &pkg, nil
 }
 
-func (r *Registry) loadManifestFileWithPackageName(path string, packageName string, workspace types.WorkspaceContext) (*M.Package, error) {
+func (r *Registry) loadManifestFileWithPackageName(path string, packageName string, workspace types.WorkspaceContext, source lspTypes.ManifestSource) (*M.Package, error) {
 	data, err := readFileViaWorkspace(path, workspace, r.fs)
 	if err != nil {
 		return nil, err
 	}
 
-	var pkg M.Package
-	if err := json.Unmarshal(data, &pkg); err != nil {
+	pkg, err := M.UnmarshalPackage(data)
+	if err != nil {
 		return nil, err
 	}
 
-	r.addManifestPathWithPackageName(path, packageName)
+	r.addManifestPathWithPackageName(path, packageName, source)
 
-	return &pkg, nil
+	return pkg, nil
 }
 
 func (r *Registry) loadManifestFile(path string) (*M.Package, error) {
@@ -788,23 +851,26 @@ func (r *Registry) loadManifestFile(path string) (*M.Package, error) {
 		return nil, err
 	}
 
-	var pkg M.Package
-	if err := json.Unmarshal(data, &pkg); err != nil {
+	pkg, err := M.UnmarshalPackage(data)
+	if err != nil {
 		return nil, err
 	}
 
 	// Track this file path for watching
 	r.addManifestPath(path)
 
-	return &pkg, nil
+	return pkg, nil
 }
 
-// addManifest adds a manifest package to the registry with package name context
-func (r *Registry) addManifest(manifest *M.Package, packageName string) {
+// addManifest adds a manifest package to the registry with package name and
+// source context. Where another package already declares one of its tags,
+// the higher-ranked package wins; see outranks.
+func (r *Registry) addManifest(manifest *M.Package, packageName string, source lspTypes.ManifestSource) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.Manifests = append(r.Manifests, manifest)
+	r.manifestNames[manifest] = packageName
 
 	// Collect tag names for this manifest to log
 	var tagNames []string
@@ -816,7 +882,7 @@ func (r *Registry) addManifest(manifest *M.Package, packageName string) {
 			if customElementDecl, ok := decl.(*M.CustomElementDeclaration); ok {
 				element := &customElementDecl.CustomElement
 				// Index by tag name
-				if element.TagName != "" {
+				if element.TagName != "" && r.claimTag(element.TagName, packageName, source) {
 					r.Elements[element.TagName] = element
 
 					// Store the element definition with source information
@@ -833,6 +899,7 @@ func (r *Registry) addManifest(manifest *M.Package, packageName string) {
 					}
 					helpers.SafeDebugLog("[REGISTRY] Registering element '%s' with packageName='%s', modulePath='%s'", element.TagName, packageName, module.Path)
 					r.ElementDefinitions[element.TagName] = elementDef
+					r.declarations[element.TagName] = customElementDecl
 
 					// Collect tag name for logging
 					tagNames = append(tagNames, element.TagName)
@@ -848,20 +915,14 @@ func (r *Registry) addManifest(manifest *M.Package, packageName string) {
 						r.attributes[element.TagName] = attrMap
 					}
 
-					// Index fields for this element
-					if len(customElementDecl.Members) > 0 {
+					// Index fields for this element, including inherited fields
+					if fields := classFields(customElementDecl.Fields()); len(fields) > 0 {
 						fieldMap := make(map[string]*M.ClassField)
-						for _, member := range customElementDecl.Members {
-							switch f := member.(type) {
-							case *M.ClassField:
-								fieldMap[f.Name] = f
-							case *M.CustomElementField:
-								fieldMap[f.Name] = &f.ClassField
-							}
-						}
-						if len(fieldMap) > 0 {
-							r.fields[element.TagName] = fieldMap
+						for i := range fields {
+							field := &fields[i]
+							fieldMap[field.Name] = field
 						}
+						r.fields[element.TagName] = fieldMap
 					}
 
 					// Index events for this element
@@ -874,6 +935,9 @@ func (r *Registry) addManifest(manifest *M.Package, packageName string) {
 						}
 						r.events[element.TagName] = eventMap
 					}
+
+					// Index slots for this element (using flattened getter for mixin support)
+					r.slots[element.TagName] = customElementDecl.Slots()
 				}
 			}
 		}
@@ -907,6 +971,10 @@ func (r *Registry) Attributes(tagName string) (map[string]*M.Attribute, bool) {
 	defer r.mu.RUnlock()
 
 	attrs, exists := r.attributes[tagName]
+	if inherited := r.crossPackageInherited(tagName); inherited != nil && len(inherited.attributes) > 0 {
+		attrs = withInherited(attrs, inherited.attributes, func(attr *M.Attribute) string { return attr.Name })
+		exists = true
+	}
 	helpers.SafeDebugLog("[REGISTRY] Attributes('%s'): exists=%t", tagName, exists)
 	if exists {
 		helpers.SafeDebugLog("[REGISTRY] Element '%s' has %d attributes", tagName, len(attrs))
@@ -923,6 +991,10 @@ func (r *Registry) Fields(tagName string) (map[string]*M.ClassField, bool) {
 	defer r.mu.RUnlock()
 
 	fields, exists := r.fields[tagName]
+	if inherited := r.crossPackageInherited(tagName); inherited != nil && len(inherited.fields) > 0 {
+		fields = withInherited(fields, inherited.fields, func(field *M.ClassField) string { return field.Name })
+		exists = true
+	}
 	return fields, exists
 }
 
@@ -932,6 +1004,10 @@ func (r *Registry) Events(tagName string) (map[string]*M.Event, bool) {
 	defer r.mu.RUnlock()
 
 	events, exists := r.events[tagName]
+	if inherited := r.crossPackageInherited(tagName); inherited != nil && len(inherited.events) > 0 {
+		events = withInherited(events, inherited.events, func(event *M.Event) string { return event.Name })
+		exists = true
+	}
 	return events, exists
 }
 
@@ -946,12 +1022,20 @@ func (r *Registry) Slots(tagName string) ([]M.Slot, bool) {
 		return nil, false
 	}
 
-	helpers.SafeDebugLog("[REGISTRY] GetSlots('%s'): exists=%t, slots=%d", tagName, exists, len(element.Slots))
-	for _, slot := range element.Slots {
+	slots, indexed := r.slots[tagName]
+	if !indexed {
+		slots = element.Slots
+	}
+	if inherited := r.crossPackageInherited(tagName); inherited != nil && len(inherited.slots) > 0 {
+		slots = append(slices.Clone(slots), inherited.slots...)
+	}
+
+	helpers.SafeDebugLog("[REGISTRY] GetSlots('%s'): exists=%t, slots=%d", tagName, exists, len(slots))
+	for _, slot := range slots {
 		helpers.SafeDebugLog("[REGISTRY]   - slot: '%s'", slot.Name)
 	}
 
-	return element.Slots, true
+	return slots, true
 }
 
 // ElementDefinition returns the element definition with source information for a tag name
@@ -1049,7 +1133,7 @@ func (r *Registry) AllTagNames() []string {
 // This allows programmatic addition of manifests from various sources
 func (r *Registry) AddManifest(pkg *M.Package) {
 	// Fallback to empty string when package name is not available
-	r.addManifest(pkg, "")
+	r.addManifest(pkg, "", lspTypes.SourceWorkspace)
 }
 
 // StartFileWatching initializes file watching for manifest changes
@@ -1211,6 +1295,7 @@ func (r *Registry) ReloadManifestsDirectly() error {
 	for _, manifestPath := range manifestPaths {
 		// Read and parse the manifest file directly
 		pkg, err := r.loadManifestFile(manifestPath)
+		r.updatePackage(manifestPath, pkg, err)
 		if err != nil {
 			helpers.SafeDebugLog("Warning: Could not reload manifest %s: %v", manifestPath, err)
 			continue
@@ -1220,8 +1305,8 @@ func (r *Registry) ReloadManifestsDirectly() error {
 		packageName := r.ManifestPackageNames[manifestPath]
 		helpers.SafeDebugLog("Reloading manifest %s with package name: %s", manifestPath, packageName)
 
-		// Add to registry with the preserved package name
-		r.addManifest(pkg, packageName)
+		// Add to registry with the preserved package name and source
+		r.addManifest(pkg, packageName, r.manifestSources[manifestPath])
 		helpers.SafeDebugLog("Reloaded manifest: %s with %d modules", manifestPath, len(pkg.Modules))
 	}
 
@@ -1290,17 +1375,7 @@ func (r *Registry) StartGenerateWatcher() error {
 			}
 		}
 
-		// Get package name from workspace package.json to preserve package scope
-		var packageName string
-		if packageJSON, err := r.localWorkspace.PackageJSON(); err == nil && packageJSON != nil {
-			packageName = packageJSON.Name
-			helpers.SafeDebugLog("[GENERATE_WATCHER] Using package name from workspace package.json: '%s'", packageName)
-		} else {
-			helpers.SafeDebugLog("[GENERATE_WATCHER] Could not read workspace package.json: %v", err)
-		}
-
-		// Add manifest with proper package name instead of empty string
-		r.addManifest(pkg, packageName)
+		r.addLocalManifest(pkg)
 		return nil
 	}
 
@@ -1311,6 +1386,7 @@ func (r *Registry) StartGenerateWatcher() error {
 		helpers.SafeDebugLog("Failed to create InProcessGenerateWatcher: %v", err)
 		return fmt.Errorf("failed to create generate watcher: %w", err)
 	}
+	watcher.onFileChange = r.updateModuleGraphFile
 
 	helpers.SafeDebugLog("Starting in-process generate watcher for workspace: %s", workspaceRoot)
 
@@ -1324,11 +1400,44 @@ func (r *Registry) StartGenerateWatcher() error {
 	return nil
 }
 
-// StopGenerateWatcher stops the generate watcher if it's running
+// addLocalManifest adds a manifest generated for the local workspace,
+// scoped to the workspace's package name
+func (r *Registry) addLocalManifest(pkg *M.Package) {
+	// Get package name from workspace package.json to preserve package scope
+	var packageName string
+	if packageJSON, err := r.localWorkspace.PackageJSON(); err == nil && packageJSON != nil {
+		packageName = packageJSON.Name
+		helpers.SafeDebugLog("[GENERATE_WATCHER] Using package name from workspace package.json: '%s'", packageName)
+	} else {
+		helpers.SafeDebugLog("[GENERATE_WATCHER] Could not read workspace package.json: %v", err)
+	}
+
+	// Add manifest with proper package name instead of empty string
+	r.addManifest(pkg, packageName, lspTypes.SourceWorkspace)
+
+	// The new manifest may map source files to different modules
+	r.moduleGraph.InvalidateManifestModules()
+}
+
+// updateModuleGraphFile refreshes the module graph's edges for a source file
+// which changed on disk
+func (r *Registry) updateModuleGraphFile(path string) {
+	if err := r.moduleGraph.UpdateFile(path); err != nil {
+		helpers.SafeDebugLog("[GENERATE_WATCHER] Failed to update module graph for %s: %v", path, err)
+	}
+}
+
+// StopGenerateWatcher stops the generate watcher and the save generator if
+// they're running
 func (r *Registry) StopGenerateWatcher() error {
 	r.generateMu.Lock()
 	defer r.generateMu.Unlock()
 
+	if r.saveGenerator != nil {
+		r.saveGenerator.Stop()
+		r.saveGenerator = nil
+	}
+
 	if r.generateWatcher == nil {
 		return nil
 	}
@@ -1372,14 +1481,16 @@ func (r *Registry) addWatchPath(path string) {
 	r.watcherMu.RUnlock()
 }
 
-// addManifestPathWithPackageName tracks a CEM manifest path with its package name.
-func (r *Registry) addManifestPathWithPackageName(path string, packageName string) {
+// addManifestPathWithPackageName tracks a CEM manifest path with its package
+// name and source.
+func (r *Registry) addManifestPathWithPackageName(path string, packageName string, source lspTypes.ManifestSource) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		helpers.SafeDebugLog("Warning: Could not resolve manifest path %s: %v", path, err)
 		return
 	}
 
+	r.manifestSources[absPath] = source
 	if slices.Contains(r.ManifestPaths, absPath) {
 		r.ManifestPackageNames[absPath] = packageName
 		return
@@ -1618,5 +1729,56 @@ func (r *Registry) initializeLazyModuleGraph(workspace types.WorkspaceContext) {
 	// Store workspace root for lazy file parsing
 	r.moduleGraph.SetWorkspaceRoot(workspaceRoot)
 
+	// Resolve bare specifiers through package exports and the serve import map
+	if packageJSON, err := workspace.PackageJSON(); err == nil && packageJSON != nil {
+		r.moduleGraph.SetPackageJSON(packageJSON)
+	}
+	if importMap := r.importMapFromConfig(workspace); importMap != nil {
+		r.moduleGraph.SetImportMap(importMap)
+	}
+
 	helpers.SafeDebugLog("[REGISTRY] Module graph initialized with %d manifest elements and manifest resolver, ready for lazy building", len(elementMap))
 }
+
+// importMapFromConfig builds the import map used for specifier resolution
+// from the serve.importMap settings: the override file first, then inline
+// overrides, which take precedence
+func (r *Registry) importMapFromConfig(workspace types.WorkspaceContext) *modulegraph.ImportMap {
+	cfg, err := workspace.Config()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	importMapConfig := cfg.Serve.ImportMap
+
+	importMap := &modulegraph.ImportMap{
+		Imports: make(map[string]string),
+		Scopes:  make(map[string]map[string]string),
+	}
+
+	if importMapConfig.OverrideFile != "" && r.fs != nil {
+		overridePath := importMapConfig.OverrideFile
+		if !filepath.IsAbs(overridePath) {
+			overridePath = filepath.Join(workspace.Root(), overridePath)
+		}
+		data, err := r.fs.ReadFile(overridePath)
+		if err != nil {
+			helpers.SafeDebugLog("[REGISTRY] Failed to read import map override file %s: %v", overridePath, err)
+		} else {
+			var fileMap modulegraph.ImportMap
+			if err := json.Unmarshal(data, &fileMap); err != nil {
+				helpers.SafeDebugLog("[REGISTRY] Failed to parse import map override file %s: %v", overridePath, err)
+			} else {
+				maps.Copy(importMap.Imports, fileMap.Imports)
+				maps.Copy(importMap.Scopes, fileMap.Scopes)
+			}
+		}
+	}
+
+	maps.Copy(importMap.Imports, importMapConfig.Override.Imports)
+	maps.Copy(importMap.Scopes, importMapConfig.Override.Scopes)
+
+	if len(importMap.Imports) == 0 && len(importMap.Scopes) == 0 {
+		return nil
+	}
+	return importMap
+}
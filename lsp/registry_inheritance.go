@@ -0,0 +1,194 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp
+
+import (
+	"maps"
+
+	M "bennypowers.dev/cem/manifest"
+)
+
+// inheritedMembers holds the members an element inherits from superclasses
+// declared in other loaded manifests
+type inheritedMembers struct {
+	attributes []M.Attribute
+	slots      []M.Slot
+	events     []M.Event
+	fields     []M.ClassField
+}
+
+// crossPackageInherited walks an element's superclass chain across all
+// loaded manifests, collecting the members of ancestors declared in other
+// packages, e.g. a design system element which subclasses another package's
+// element. The manifest package already flattens ancestors declared in the
+// element's own package. Inherited members have InheritedFrom set, and
+// members the element already has are skipped, so nearer classes win.
+// Callers must hold r.mu.
+func (r *Registry) crossPackageInherited(tagName string) *inheritedMembers {
+	decl, ok := r.declarations[tagName]
+	if !ok || decl.Superclass == nil || decl.Module == nil {
+		return nil
+	}
+
+	seenAttrs := make(map[string]bool)
+	for _, attr := range decl.Attributes() {
+		seenAttrs[attr.Name] = true
+	}
+	seenSlots := make(map[string]bool)
+	for _, slot := range decl.Slots() {
+		seenSlots[slot.Name] = true
+	}
+	seenEvents := make(map[string]bool)
+	for _, event := range decl.Events() {
+		seenEvents[event.Name] = true
+	}
+	seenFields := make(map[string]bool)
+	for _, field := range classFields(decl.Fields()) {
+		seenFields[field.Name] = true
+	}
+
+	var inherited inheritedMembers
+	visited := make(map[M.Reference]bool)
+	home := decl.Module.Package
+	classLike := &decl.ClassLike
+	for classLike.Superclass != nil {
+		ref := *classLike.Superclass
+		if visited[ref] {
+			break
+		}
+		visited[ref] = true
+
+		ancestor, pkg := r.findSuperclass(ref, home)
+		if ancestor == nil {
+			break
+		}
+
+		if ce, ok := ancestor.(*M.CustomElementDeclaration); ok && pkg != home {
+			for _, attr := range ce.Attributes() {
+				if !seenAttrs[attr.Name] {
+					seenAttrs[attr.Name] = true
+					attr.InheritedFrom = inheritedFrom(attr.InheritedFrom, ref)
+					inherited.attributes = append(inherited.attributes, attr)
+				}
+			}
+			for _, slot := range ce.Slots() {
+				if !seenSlots[slot.Name] {
+					seenSlots[slot.Name] = true
+					slot.InheritedFrom = inheritedFrom(slot.InheritedFrom, ref)
+					inherited.slots = append(inherited.slots, slot)
+				}
+			}
+			for _, event := range ce.Events() {
+				if !seenEvents[event.Name] {
+					seenEvents[event.Name] = true
+					event.InheritedFrom = inheritedFrom(event.InheritedFrom, ref)
+					inherited.events = append(inherited.events, event)
+				}
+			}
+			for _, field := range classFields(ce.Fields()) {
+				if !seenFields[field.Name] {
+					seenFields[field.Name] = true
+					field.InheritedFrom = inheritedFrom(field.InheritedFrom, ref)
+					inherited.fields = append(inherited.fields, field)
+				}
+			}
+		}
+
+		switch d := ancestor.(type) {
+		case *M.CustomElementDeclaration:
+			classLike = &d.ClassLike
+		case *M.ClassDeclaration:
+			classLike = &d.ClassLike
+		default:
+			return &inherited
+		}
+		home = pkg
+	}
+
+	return &inherited
+}
+
+// findSuperclass finds the declaration a superclass reference points to,
+// and the manifest which declares it. References without a package are
+// looked up in the referring manifest first.
+func (r *Registry) findSuperclass(ref M.Reference, home *M.Package) (M.Declaration, *M.Package) {
+	if ref.Package == "" {
+		if decl := home.FindDeclaration(ref); decl != nil {
+			return decl, home
+		}
+	} else {
+		// Prefer the manifest loaded for the referenced package, whose module
+		// paths may not match the reference's exactly
+		for _, pkg := range r.Manifests {
+			if r.manifestNames[pkg] != ref.Package {
+				continue
+			}
+			if decl := pkg.FindDeclaration(ref); decl != nil {
+				return decl, pkg
+			}
+			if decl := pkg.FindDeclaration(M.Reference{Name: ref.Name}); decl != nil {
+				return decl, pkg
+			}
+		}
+	}
+
+	for _, pkg := range r.Manifests {
+		if pkg == home {
+			continue
+		}
+		if decl := pkg.FindDeclaration(ref); decl != nil {
+			return decl, pkg
+		}
+	}
+	return nil, nil
+}
+
+// inheritedFrom returns the existing source of an inherited member, or else
+// the ancestor it was found on
+func inheritedFrom(existing *M.Reference, ancestor M.Reference) *M.Reference {
+	if existing != nil {
+		return existing
+	}
+	return &ancestor
+}
+
+// classFields returns the fields among a class's members
+func classFields(members []M.ClassMember) []M.ClassField {
+	var fields []M.ClassField
+	for _, member := range members {
+		switch f := member.(type) {
+		case *M.ClassField:
+			fields = append(fields, *f)
+		case *M.CustomElementField:
+			fields = append(fields, f.ClassField)
+		}
+	}
+	return fields
+}
+
+// withInherited returns a copy of an element's indexed members with its
+// inherited members added
+func withInherited[T any](own map[string]*T, inherited []T, name func(*T) string) map[string]*T {
+	merged := make(map[string]*T, len(own)+len(inherited))
+	maps.Copy(merged, own)
+	for i := range inherited {
+		if _, exists := merged[name(&inherited[i])]; !exists {
+			merged[name(&inherited[i])] = &inherited[i]
+		}
+	}
+	return merged
+}
@@ -0,0 +1,187 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	W "bennypowers.dev/cem/internal/workspace"
+	"bennypowers.dev/cem/lsp"
+	"bennypowers.dev/cem/lsp/methods/textDocument/hover"
+	M "bennypowers.dev/cem/manifest"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+const baseElementsManifest = `{
+  "schemaVersion": "2.1.1",
+  "modules": [
+    {
+      "kind": "javascript-module",
+      "path": "base-button.js",
+      "declarations": [
+        {
+          "kind": "class",
+          "name": "BaseButton",
+          "tagName": "base-button",
+          "customElement": true,
+          "attributes": [
+            { "name": "disabled", "type": { "text": "boolean" } },
+            { "name": "variant", "type": { "text": "'primary' | 'secondary'" } }
+          ],
+          "slots": [
+            { "name": "", "description": "Label" },
+            { "name": "icon", "description": "Icon" }
+          ],
+          "events": [
+            { "name": "activate" }
+          ],
+          "members": [
+            { "kind": "field", "name": "disabled", "type": { "text": "boolean" } }
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+const designSystemManifest = `{
+  "schemaVersion": "2.1.1",
+  "modules": [
+    {
+      "kind": "javascript-module",
+      "path": "my-button.js",
+      "declarations": [
+        {
+          "kind": "class",
+          "name": "MyButton",
+          "tagName": "my-button",
+          "customElement": true,
+          "superclass": { "name": "BaseButton", "package": "base-elements", "module": "base-button.js" },
+          "attributes": [
+            { "name": "variant", "type": { "text": "'primary' | 'danger'" } }
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+func parseManifest(t *testing.T, manifestJSON string) *M.Package {
+	t.Helper()
+	var pkg M.Package
+	if err := json.Unmarshal([]byte(manifestJSON), &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	return &pkg
+}
+
+// TestRegistry_CrossPackageInheritance tests that elements which subclass
+// elements from other loaded manifests inherit their APIs
+func TestRegistry_CrossPackageInheritance(t *testing.T) {
+	registry := lsp.NewRegistry(platform.NewMockFileWatcher(), nil)
+	registry.AddManifest(parseManifest(t, designSystemManifest))
+	registry.AddManifest(parseManifest(t, baseElementsManifest))
+
+	attrs, exists := registry.Attributes("my-button")
+	if !exists {
+		t.Fatal("Expected attributes for my-button")
+	}
+	if variant := attrs["variant"]; variant == nil || variant.InheritedFrom != nil || variant.Type.Text != "'primary' | 'danger'" {
+		t.Errorf("Expected my-button's own variant attribute to win, got %+v", variant)
+	}
+	if disabled := attrs["disabled"]; disabled == nil || disabled.InheritedFrom == nil || disabled.InheritedFrom.Name != "BaseButton" {
+		t.Errorf("Expected disabled attribute inherited from BaseButton, got %+v", disabled)
+	}
+
+	slots, _ := registry.Slots("my-button")
+	if len(slots) != 2 {
+		t.Fatalf("Expected 2 inherited slots, got %d", len(slots))
+	}
+	for _, slot := range slots {
+		if slot.InheritedFrom == nil || slot.InheritedFrom.Name != "BaseButton" {
+			t.Errorf("Expected slot %q inherited from BaseButton", slot.Name)
+		}
+	}
+
+	events, _ := registry.Events("my-button")
+	if _, ok := events["activate"]; !ok {
+		t.Error("Expected activate event inherited from BaseButton")
+	}
+
+	fields, _ := registry.Fields("my-button")
+	if _, ok := fields["disabled"]; !ok {
+		t.Error("Expected disabled field inherited from BaseButton")
+	}
+
+	// The superclass's own index is untouched
+	if baseAttrs, _ := registry.Attributes("base-button"); baseAttrs["disabled"].InheritedFrom != nil {
+		t.Error("Expected base-button's own attributes not to be marked inherited")
+	}
+}
+
+// TestHover_CrossPackageInheritance tests that hovering a subclassed element
+// lists its inherited members
+func TestHover_CrossPackageInheritance(t *testing.T) {
+	workspace := W.NewFileSystemWorkspaceContext("/test")
+	if err := workspace.Init(); err != nil {
+		t.Fatalf("Failed to init workspace: %v", err)
+	}
+	server, err := lsp.NewServer(workspace, lsp.TransportStdio)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+
+	server.AddManifest(parseManifest(t, baseElementsManifest))
+	server.AddManifest(parseManifest(t, designSystemManifest))
+
+	dm, err := server.DocumentManager()
+	if err != nil {
+		t.Fatalf("Failed to get document manager: %v", err)
+	}
+	docURI := "file:///test.html"
+	dm.OpenDocument(docURI, "<my-button></my-button>", 1)
+
+	result, err := hover.Hover(server, &protocol.HoverParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri.URI(docURI)},
+			Position:     protocol.Position{Line: 0, Character: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Hover failed: %v", err)
+	}
+	if result == nil {
+		t.Fatal("Expected hover result, got nil")
+	}
+
+	content := result.Contents.(*protocol.MarkupContent).Value
+	for _, expected := range []string{
+		"**`variant`** _'primary' | 'danger'_\n",
+		"**`disabled`** _boolean_ _(inherited from BaseButton)_",
+		"**`activate`**",
+		"_(inherited from BaseButton)_ - Icon",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in hover content, got:\n%s", expected, content)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp
+
+import (
+	"cmp"
+	"slices"
+
+	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// Packages returns the provenance of each package the registry tried to
+// load, including those whose manifests failed to load, sorted by name
+func (r *Registry) Packages() []types.PackageInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	packages := make([]types.PackageInfo, len(r.packages))
+	copy(packages, r.packages)
+	slices.SortStableFunc(packages, func(a, b types.PackageInfo) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return packages
+}
+
+// recordPackage records the provenance of a loaded package. The element
+// count is taken from its manifest, when it loaded.
+func (r *Registry) recordPackage(info types.PackageInfo, pkg *M.Package) {
+	info.ElementCount = countElements(pkg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.packages = append(r.packages, info)
+}
+
+// updatePackage updates the provenance of the package loaded from a
+// manifest path after the manifest is reloaded
+func (r *Registry) updatePackage(manifestPath string, pkg *M.Package, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.packages {
+		if r.packages[i].ManifestPath != manifestPath {
+			continue
+		}
+		r.packages[i].ElementCount = countElements(pkg)
+		r.packages[i].Error = ""
+		if err != nil {
+			r.packages[i].Error = err.Error()
+		}
+	}
+}
+
+// countElements counts the custom elements with tag names in a manifest
+func countElements(pkg *M.Package) int {
+	if pkg == nil {
+		return 0
+	}
+	var count int
+	for _, module := range pkg.Modules {
+		for _, decl := range module.Declarations {
+			if ce, ok := decl.(*M.CustomElementDeclaration); ok && ce.TagName != "" {
+				count++
+			}
+		}
+	}
+	return count
+}
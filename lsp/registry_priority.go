@@ -0,0 +1,129 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lsp
+
+import (
+	"slices"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	lspTypes "bennypowers.dev/cem/lsp/types"
+)
+
+// TagSource reports which package's manifest describes a tag name, where
+// that manifest was found, and which other packages also declare the tag.
+func (r *Registry) TagSource(tagName string) (lspTypes.TagSource, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	source, exists := r.tagSources[tagName]
+	if !exists {
+		return lspTypes.TagSource{}, false
+	}
+	result := *source
+	result.Shadowed = slices.Clone(source.Shadowed)
+	return result, true
+}
+
+// claimTag decides whether the manifest being added describes tagName,
+// recording the outcome either way. It returns false when a previously
+// added package outranks this one. Callers must hold r.mu.
+func (r *Registry) claimTag(tagName, packageName string, source lspTypes.ManifestSource) bool {
+	existing, exists := r.tagSources[tagName]
+	if !exists {
+		r.tagSources[tagName] = &lspTypes.TagSource{
+			TagName:     tagName,
+			PackageName: packageName,
+			Source:      source,
+		}
+		return true
+	}
+
+	// The same package reloading its manifest always replaces itself
+	if existing.PackageName == packageName {
+		existing.Source = source
+		return true
+	}
+
+	if r.outranks(existing.PackageName, existing.Source, packageName, source) {
+		helpers.SafeDebugLog("[REGISTRY] Keeping '%s' from '%s' over '%s'", tagName, existing.PackageName, packageName)
+		if !slices.Contains(existing.Shadowed, packageName) {
+			existing.Shadowed = append(existing.Shadowed, packageName)
+		}
+		return false
+	}
+
+	shadowed := slices.DeleteFunc(slices.Clone(existing.Shadowed), func(name string) bool {
+		return name == packageName
+	})
+	if !slices.Contains(shadowed, existing.PackageName) {
+		shadowed = append(shadowed, existing.PackageName)
+	}
+	r.tagSources[tagName] = &lspTypes.TagSource{
+		TagName:     tagName,
+		PackageName: packageName,
+		Source:      source,
+		Shadowed:    shadowed,
+	}
+	return true
+}
+
+// outranks reports whether package a, found at sourceA, strictly outranks
+// package b, found at sourceB. Packages listed in registry.priority
+// outrank those which are not, and earlier entries outrank later ones.
+// Then, with registry.preferLocal, workspace manifests outrank the rest.
+// Otherwise, packages outrank those which were loaded before them:
+// additional packages, then node_modules, then the workspace.
+func (r *Registry) outranks(a string, sourceA lspTypes.ManifestSource, b string, sourceB lspTypes.ManifestSource) bool {
+	if pa, pb := r.priorityRank(a), r.priorityRank(b); pa != pb {
+		return pa < pb
+	}
+	if r.registryConfig.PreferLocal {
+		if la, lb := isLocalSource(sourceA), isLocalSource(sourceB); la != lb {
+			return la
+		}
+	}
+	return loadOrderRank(sourceA) > loadOrderRank(sourceB)
+}
+
+// priorityRank is a package's index in registry.priority, or the length of
+// that list when the package is not listed.
+func (r *Registry) priorityRank(packageName string) int {
+	if i := slices.Index(r.registryConfig.Priority, packageName); i >= 0 {
+		return i
+	}
+	return len(r.registryConfig.Priority)
+}
+
+// isLocalSource reports whether a manifest comes from the workspace. Manifests
+// added without a source, e.g. by the generate watcher, are local.
+func isLocalSource(source lspTypes.ManifestSource) bool {
+	return source == lspTypes.SourceWorkspace || source == ""
+}
+
+// loadOrderRank orders sources by when LoadFromWorkspace loads them, so that
+// by default the last-loaded manifest wins, as it always has.
+func loadOrderRank(source lspTypes.ManifestSource) int {
+	switch source {
+	case lspTypes.SourceAdditional:
+		return 2
+	case lspTypes.SourceNodeModules:
+		return 1
+	default:
+		return 0
+	}
+}
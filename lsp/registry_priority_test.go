@@ -0,0 +1,112 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/lsp"
+	lspTypes "bennypowers.dev/cem/lsp/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: integration test, scalar assertions
+// The workspace declares x-button, which node_modules/@acme/ui also
+// declares. Both @acme/ui and @other/kit declare x-card.
+
+// loadPriorityWorkspace loads the registry-priority fixture with the given
+// cem config file contents.
+func loadPriorityWorkspace(t *testing.T, config string) *lsp.Registry {
+	t.Helper()
+	mfs := testutil.LoadTestdataFS(t, "testdata/integration/registry-priority", "/")
+	if config != "" {
+		mfs.AddFile("/.config/cem.yaml", config, 0644)
+	}
+	wsCtx := testworkspace.NewMapWorkspaceContextFromFS(mfs, "/")
+	require.NoError(t, wsCtx.Init())
+
+	registry, err := lsp.NewRegistryWithDefaults()
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadFromWorkspace(wsCtx))
+	return registry
+}
+
+func assertTagSource(t *testing.T, registry *lsp.Registry, tagName, attr string, want lspTypes.TagSource) {
+	t.Helper()
+	source, ok := registry.TagSource(tagName)
+	require.True(t, ok, "expected a source for %s", tagName)
+	assert.Equal(t, want, source)
+
+	attrs, ok := registry.Attributes(tagName)
+	require.True(t, ok)
+	assert.Contains(t, attrs, attr)
+}
+
+func TestRegistryPriority_Default(t *testing.T) {
+	registry := loadPriorityWorkspace(t, "")
+
+	assertTagSource(t, registry, "x-button", "acme", lspTypes.TagSource{
+		TagName:     "x-button",
+		PackageName: "@acme/ui",
+		Source:      lspTypes.SourceNodeModules,
+		Shadowed:    []string{"my-app"},
+	})
+	assertTagSource(t, registry, "x-card", "other", lspTypes.TagSource{
+		TagName:     "x-card",
+		PackageName: "@other/kit",
+		Source:      lspTypes.SourceNodeModules,
+		Shadowed:    []string{"@acme/ui"},
+	})
+}
+
+func TestRegistryPriority_PreferLocal(t *testing.T) {
+	registry := loadPriorityWorkspace(t, "registry:\n  preferLocal: true\n")
+
+	assertTagSource(t, registry, "x-button", "local", lspTypes.TagSource{
+		TagName:     "x-button",
+		PackageName: "my-app",
+		Source:      lspTypes.SourceWorkspace,
+		Shadowed:    []string{"@acme/ui"},
+	})
+}
+
+func TestRegistryPriority_PackageList(t *testing.T) {
+	registry := loadPriorityWorkspace(t, "registry:\n  preferLocal: true\n  priority:\n    - \"@acme/ui\"\n")
+
+	// Listed packages outrank local manifests, too
+	assertTagSource(t, registry, "x-button", "acme", lspTypes.TagSource{
+		TagName:     "x-button",
+		PackageName: "@acme/ui",
+		Source:      lspTypes.SourceNodeModules,
+		Shadowed:    []string{"my-app"},
+	})
+	assertTagSource(t, registry, "x-card", "acme", lspTypes.TagSource{
+		TagName:     "x-card",
+		PackageName: "@acme/ui",
+		Source:      lspTypes.SourceNodeModules,
+		Shadowed:    []string{"@other/kit"},
+	})
+}
+
+func TestRegistryPriority_Unknown(t *testing.T) {
+	registry := loadPriorityWorkspace(t, "")
+	_, ok := registry.TagSource("x-missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package lsp
+
+import (
+	"bennypowers.dev/cem/search"
+)
+
+// FindElements ranks the registered custom elements against a fuzzy query,
+// scoring their tag names, class names, and descriptions, and returns up to
+// limit matches, best first. A limit of zero or less returns every match.
+func (r *Registry) FindElements(query string, limit int) []search.ElementMatch {
+	return search.RankElements(query, r.elementCandidates(), limit)
+}
+
+// elementCandidates returns the registered elements, including those only
+// found in the module graph, to score against fuzzy queries
+func (r *Registry) elementCandidates() []search.ElementCandidate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool, len(r.Elements))
+	candidates := make([]search.ElementCandidate, 0, len(r.Elements))
+	for _, pkg := range r.Manifests {
+		for _, candidate := range search.ElementCandidates(pkg) {
+			if _, registered := r.Elements[candidate.TagName]; registered && !seen[candidate.TagName] {
+				seen[candidate.TagName] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	// Elements added without a manifest have no description to search
+	for tagName := range r.Elements {
+		if !seen[tagName] {
+			seen[tagName] = true
+			candidate := search.ElementCandidate{TagName: tagName}
+			if definition, ok := r.ElementDefinitions[tagName]; ok {
+				candidate.ClassName = definition.className
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	if r.moduleGraph != nil {
+		for _, tagName := range r.moduleGraph.GetAllTagNames() {
+			if !seen[tagName] {
+				seen[tagName] = true
+				candidates = append(candidates, search.ElementCandidate{TagName: tagName})
+			}
+		}
+	}
+	return candidates
+}
@@ -59,7 +59,13 @@ type Server struct {
 	additionalPackages []string
 	config             lspTypes.ServerConfig
 	configMu           sync.RWMutex
-	usePullDiagnostics bool
+	projectDiagnostics map[lspTypes.DiagnosticRule]lspTypes.RuleSeverity
+	// projectRequiredSlots are the required slots the project's cem config
+	// file declares
+	projectRequiredSlots map[string][]string
+	usePullDiagnostics   bool
+	editCapabilities     lspTypes.EditCapabilities
+	diagnosticRegions    *lspTypes.DiagnosticRegions
 }
 
 // NewServer creates a new CEM LSP server
@@ -81,7 +87,9 @@ func NewServer(workspace types.WorkspaceContext, transport TransportKind) (*Serv
 		documents:         documents,
 		transport:         transport,
 		config:            lspTypes.DefaultConfig(),
+		diagnosticRegions: lspTypes.NewDiagnosticRegions(),
 	}
+	documents.SetLanguageResolver(s.configuredLanguage)
 
 	return s, nil
 }
@@ -18,7 +18,10 @@ package lsp
 
 import (
 	"fmt"
+	"maps"
 	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -31,6 +34,8 @@ import (
 	"bennypowers.dev/cem/lsp/helpers"
 	"bennypowers.dev/cem/lsp/types"
 	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/search"
+	"github.com/bmatcuk/doublestar/v4"
 	"go.lsp.dev/protocol"
 )
 
@@ -61,6 +66,8 @@ func (s *Server) DebugLog(format string, args ...any) {
 }
 
 func (s *Server) InitializeManifests() error {
+	s.loadProjectDiagnostics()
+
 	// Initialize the manifest registry
 	if err := s.registry.LoadFromWorkspace(s.workspace); err != nil {
 		return fmt.Errorf("failed to load manifests: %w", err)
@@ -177,6 +184,27 @@ func (s *Server) FindCustomElementDeclaration(tagName string) *M.CustomElementDe
 	return s.ephemeralRegistry.FindCustomElementDeclaration(tagName)
 }
 
+// FindElements ranks the elements of both registries against a fuzzy query
+func (s *Server) FindElements(query string, limit int) []search.ElementMatch {
+	candidates := s.registry.elementCandidates()
+	seen := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		seen[candidate.TagName] = true
+	}
+	for _, tagName := range s.ephemeralRegistry.AllTagNames() {
+		if seen[tagName] {
+			continue
+		}
+		candidate := search.ElementCandidate{TagName: tagName}
+		if decl := s.ephemeralRegistry.FindCustomElementDeclaration(tagName); decl != nil {
+			candidate.ClassName = decl.Name()
+			candidate.Description = strings.TrimSpace(decl.Summary + " " + decl.Description)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return search.RankElements(query, candidates, limit)
+}
+
 // ManifestCount returns the number of loaded manifests
 func (s *Server) ManifestCount() int {
 	s.registry.mu.RLock()
@@ -246,6 +274,30 @@ func (s *Server) WorkspaceRoot() string {
 	return ""
 }
 
+// configuredLanguage returns the language which the configuration sets for a
+// document, matching its path relative to the workspace root, or "" when no
+// override matches
+func (s *Server) configuredLanguage(uri string) string {
+	overrides := s.Config().Languages
+	if len(overrides) == 0 {
+		return ""
+	}
+	docPath := strings.TrimPrefix(uri, "file://")
+	root := strings.TrimSuffix(s.WorkspaceRoot(), "/")
+	if rel, err := filepath.Rel(root, docPath); root != "" && err == nil && !strings.HasPrefix(rel, "..") {
+		docPath = rel
+	} else {
+		docPath = strings.TrimPrefix(docPath, "/")
+	}
+	docPath = filepath.ToSlash(docPath)
+	for _, override := range overrides {
+		if ok, err := doublestar.Match(override.Files, docPath); err == nil && ok {
+			return override.Language
+		}
+	}
+	return ""
+}
+
 func (s *Server) FileSystem() platform.FileSystem {
 	return platform.NewOSFileSystem()
 }
@@ -338,11 +390,58 @@ func (s *Server) ElementDescription(tagName string) (string, bool) {
 	return "", false
 }
 
-// Config returns the current server configuration (thread-safe)
+// GenerateOnSave regenerates the local project's manifest after the
+// document at uri is saved, when the generateOnSave setting is enabled
+func (s *Server) GenerateOnSave(uri string) {
+	if !s.Config().GenerateOnSave {
+		return
+	}
+	path := strings.TrimPrefix(uri, "file://")
+	if s.registry.GenerateOnSave(path) {
+		helpers.SafeDebugLog("[GENERATE_ON_SAVE] Scheduled manifest generation for %s", path)
+	}
+}
+
+// Config returns the current server configuration (thread-safe). Diagnostic
+// severities from the project's cem config file apply unless the client's
+// settings override them, and its required slots add to the client's.
 func (s *Server) Config() types.ServerConfig {
 	s.configMu.RLock()
 	defer s.configMu.RUnlock()
-	return s.config
+	config := s.config
+	if len(s.projectDiagnostics) > 0 {
+		diagnostics := maps.Clone(s.projectDiagnostics)
+		maps.Copy(diagnostics, config.Diagnostics)
+		config.Diagnostics = diagnostics
+	}
+	if len(s.projectRequiredSlots) > 0 {
+		requiredSlots := maps.Clone(s.projectRequiredSlots)
+		for tagName, slots := range config.RequiredSlots {
+			requiredSlots[tagName] = append(slices.Clone(requiredSlots[tagName]), slots...)
+		}
+		config.RequiredSlots = requiredSlots
+	}
+	return config
+}
+
+// loadProjectDiagnostics reads the diagnostic severities and required slots
+// configured in the project's cem config file
+func (s *Server) loadProjectDiagnostics() {
+	if s.workspace == nil {
+		return
+	}
+	cfg, err := s.workspace.Config()
+	if err != nil || cfg == nil {
+		return
+	}
+	diagnostics := make(map[types.DiagnosticRule]types.RuleSeverity, len(cfg.LSP.Diagnostics))
+	for rule, severity := range cfg.LSP.Diagnostics {
+		diagnostics[types.DiagnosticRule(rule)] = types.RuleSeverity(severity)
+	}
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.projectDiagnostics = diagnostics
+	s.projectRequiredSlots = cfg.LSP.RequiredSlots
 }
 
 // SetConfig updates the server configuration (thread-safe)
@@ -376,6 +475,25 @@ func (s *Server) SetUsePullDiagnostics(enabled bool) {
 	s.usePullDiagnostics = enabled
 }
 
+// EditCapabilities returns the workspace edit features the client supports
+func (s *Server) EditCapabilities() types.EditCapabilities {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.editCapabilities
+}
+
+// SetEditCapabilities sets the workspace edit features the client supports
+func (s *Server) SetEditCapabilities(capabilities types.EditCapabilities) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.editCapabilities = capabilities
+}
+
+// DiagnosticRegions returns the tracker for regionally analyzed documents
+func (s *Server) DiagnosticRegions() *types.DiagnosticRegions {
+	return s.diagnosticRegions
+}
+
 // ephemeralQueryManager lazily creates a QueryManager with GenerateQueries()
 // for use by the ephemeral synthesis pipeline
 var (
@@ -18,6 +18,7 @@ package lsp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"runtime/debug"
 
@@ -25,14 +26,17 @@ import (
 	"bennypowers.dev/cem/lsp/methods/lifecycle"
 	"bennypowers.dev/cem/lsp/methods/textDocument"
 	"bennypowers.dev/cem/lsp/methods/textDocument/codeAction"
+	"bennypowers.dev/cem/lsp/methods/textDocument/codeLens"
 	"bennypowers.dev/cem/lsp/methods/textDocument/completion"
 	"bennypowers.dev/cem/lsp/methods/textDocument/definition"
 	"bennypowers.dev/cem/lsp/methods/textDocument/diagnostic"
+	"bennypowers.dev/cem/lsp/methods/textDocument/documentHighlight"
 	"bennypowers.dev/cem/lsp/methods/textDocument/hover"
 	"bennypowers.dev/cem/lsp/methods/textDocument/inlayHint"
 	"bennypowers.dev/cem/lsp/methods/textDocument/references"
 	"bennypowers.dev/cem/lsp/methods/workspace/configuration"
 	workspaceDiag "bennypowers.dev/cem/lsp/methods/workspace/diagnostic"
+	"bennypowers.dev/cem/lsp/methods/workspace/executeCommand"
 	"bennypowers.dev/cem/lsp/methods/workspace/symbol"
 	"go.lsp.dev/protocol"
 )
@@ -72,6 +76,11 @@ func (s *Server) DidClose(_ context.Context, params *protocol.DidCloseTextDocume
 	return textDocument.DidClose(s, params)
 }
 
+func (s *Server) DidSave(_ context.Context, params *protocol.DidSaveTextDocumentParams) (err error) {
+	defer s.recover("textDocument/didSave", &err)
+	return textDocument.DidSave(s, params)
+}
+
 func (s *Server) Hover(_ context.Context, params *protocol.HoverParams) (_ *protocol.Hover, err error) {
 	defer s.recover("textDocument/hover", &err)
 	return hover.Hover(s, params)
@@ -105,6 +114,11 @@ func (s *Server) References(_ context.Context, params *protocol.ReferenceParams)
 	return references.References(s, params)
 }
 
+func (s *Server) DocumentHighlight(_ context.Context, params *protocol.DocumentHighlightParams) (_ []protocol.DocumentHighlight, err error) {
+	defer s.recover("textDocument/documentHighlight", &err)
+	return documentHighlight.DocumentHighlight(s, params)
+}
+
 func (s *Server) CodeAction(_ context.Context, params *protocol.CodeActionParams) (_ []protocol.CommandOrCodeAction, err error) {
 	defer s.recover("textDocument/codeAction", &err)
 	result, err := codeAction.CodeAction(s, params)
@@ -118,6 +132,11 @@ func (s *Server) CodeAction(_ context.Context, params *protocol.CodeActionParams
 	return out, nil
 }
 
+func (s *Server) CodeActionResolve(_ context.Context, params *protocol.CodeAction) (_ *protocol.CodeAction, err error) {
+	defer s.recover("codeAction/resolve", &err)
+	return codeAction.Resolve(s, params)
+}
+
 func (s *Server) Diagnostic(_ context.Context, params *protocol.DocumentDiagnosticParams) (_ protocol.DocumentDiagnosticReport, err error) {
 	defer s.recover("textDocument/diagnostic", &err)
 	return diagnostic.DocumentDiagnostic(s, params)
@@ -133,6 +152,16 @@ func (s *Server) InlayHint(_ context.Context, params *protocol.InlayHintParams)
 	return inlayHint.InlayHint(s, params)
 }
 
+func (s *Server) CodeLens(_ context.Context, params *protocol.CodeLensParams) (_ []protocol.CodeLens, err error) {
+	defer s.recover("textDocument/codeLens", &err)
+	return codeLens.CodeLens(s, params)
+}
+
+func (s *Server) CodeLensResolve(_ context.Context, params *protocol.CodeLens) (_ *protocol.CodeLens, err error) {
+	defer s.recover("codeLens/resolve", &err)
+	return codeLens.Resolve(s, params)
+}
+
 func (s *Server) Symbols(_ context.Context, params *protocol.WorkspaceSymbolParams) (_ protocol.WorkspaceSymbolResult, err error) {
 	defer s.recover("workspace/symbol", &err)
 	result, err := symbol.Symbol(s, params)
@@ -150,6 +179,19 @@ func (s *Server) DidChangeConfiguration(_ context.Context, params *protocol.DidC
 	return configuration.DidChangeConfiguration(s, params)
 }
 
+func (s *Server) ExecuteCommand(_ context.Context, params *protocol.ExecuteCommandParams) (_ protocol.LSPAny, err error) {
+	defer s.recover("workspace/executeCommand", &err)
+	result, err := executeCommand.ExecuteCommand(s, params)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.LSPAny(data), nil
+}
+
 func (s *Server) recover(method string, err *error) {
 	if r := recover(); r != nil {
 		helpers.SafeDebugLog("[LSP] PANIC in %s: %v\nStack trace:\n%s",
@@ -0,0 +1,12 @@
+import { LitElement, html } from 'lit';
+import { customElement } from 'lit/decorators.js';
+
+/**
+ * An element which exists when the server starts
+ */
+@customElement('my-element')
+export class MyElement extends LitElement {
+  render() {
+    return html`<slot></slot>`;
+  }
+}
@@ -0,0 +1,14 @@
+import { LitElement, html } from 'lit';
+import { customElement, property } from 'lit/decorators.js';
+
+/**
+ * An element added while the server runs
+ */
+@customElement('new-element')
+export class NewElement extends LitElement {
+  @property() variant = 'primary';
+
+  render() {
+    return html`<slot></slot>`;
+  }
+}
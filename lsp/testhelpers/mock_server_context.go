@@ -26,6 +26,7 @@ import (
 	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/internal/modulegraph"
 	"bennypowers.dev/cem/internal/treesitter"
+	"bennypowers.dev/cem/search"
 	"go.lsp.dev/protocol"
 )
 
@@ -48,9 +49,11 @@ type MockServerContext struct {
 	ModuleGraphInst    *modulegraph.ModuleGraph
 	FS                 platform.FileSystem
 	AdditionalPackages []string
-	client          protocol.Client
-	config          types.ServerConfig
-	pullDiagnostics bool
+	client           protocol.Client
+	config           types.ServerConfig
+	pullDiagnostics  bool
+	editCapabilities types.EditCapabilities
+	regions          *types.DiagnosticRegions
 	types.Registry
 }
 
@@ -163,6 +166,7 @@ func NewMockServerContext() *MockServerContext {
 		Manifests:        []*M.Package{},
 		WorkspaceRootStr: "/test/workspace",
 		config: types.DefaultConfig(),
+		regions: types.NewDiagnosticRegions(),
 	}
 }
 
@@ -251,6 +255,22 @@ func (m *MockServerContext) AllTagNames() []string {
 	return m.TagNames
 }
 
+func (m *MockServerContext) FindElements(query string, limit int) []search.ElementMatch {
+	if m.Registry != nil {
+		return m.Registry.FindElements(query, limit)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	candidates := make([]search.ElementCandidate, 0, len(m.TagNames))
+	for _, tagName := range m.TagNames {
+		candidates = append(candidates, search.ElementCandidate{
+			TagName:     tagName,
+			Description: m.DescriptionsMap[tagName],
+		})
+	}
+	return search.RankElements(query, candidates, limit)
+}
+
 func (m *MockServerContext) Element(tagName string) (*M.CustomElement, bool) {
 	if m.Registry != nil {
 		return m.Registry.Element(tagName)
@@ -435,6 +455,9 @@ func (m *MockServerContext) QueryManager() (*treesitter.QueryManager, error) {
 // SynthesizeEphemeralElements is a no-op for test contexts
 func (m *MockServerContext) SynthesizeEphemeralElements(uri string) {}
 
+// GenerateOnSave is a no-op for test contexts
+func (m *MockServerContext) GenerateOnSave(uri string) {}
+
 // Configuration
 func (m *MockServerContext) Config() types.ServerConfig {
 	m.mu.RLock()
@@ -469,6 +492,24 @@ func (m *MockServerContext) SetUsePullDiagnostics(enabled bool) {
 	m.pullDiagnostics = enabled
 }
 
+func (m *MockServerContext) EditCapabilities() types.EditCapabilities {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.editCapabilities
+}
+
+func (m *MockServerContext) SetEditCapabilities(capabilities types.EditCapabilities) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.editCapabilities = capabilities
+}
+
+func (m *MockServerContext) DiagnosticRegions() *types.DiagnosticRegions {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.regions
+}
+
 func (m *MockServerContext) Client() protocol.Client {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -27,6 +27,7 @@ const (
 	CompletionLitEventBinding                           // @event-name (lit event binding)
 	CompletionLitPropertyBinding                        // .property (lit property binding)
 	CompletionLitBooleanAttribute                       // ?attribute (lit boolean attribute)
+	CompletionImportSpecifier                           // Inside an import statement's module specifier
 )
 
 // CompletionAnalysis holds the analysis of cursor position for completion
@@ -38,4 +39,5 @@ type CompletionAnalysis struct {
 	LineContent   string // Content of the current line
 	IsLitTemplate bool   // True if we're in a tagged template literal (not innerHTML)
 	LitSyntax     string // The Lit syntax prefix: "@", ".", or "?"
+	ImportPrefix  string // For import specifier completion, the specifier typed before the cursor
 }
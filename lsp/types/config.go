@@ -16,15 +16,116 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 */
 package types
 
+import (
+	"maps"
+	"strings"
+)
+
 // ServerConfig represents user-provided LSP settings
 type ServerConfig struct {
-	InlayHints *bool `json:"inlayHints,omitempty"`
+	InlayHints *bool            `json:"inlayHints,omitempty"`
+	Files      FileKindsConfig  `json:"files,omitempty"`
+	References ReferencesConfig `json:"references,omitempty"`
+	// LargeFileThreshold is the size in bytes beyond which documents are
+	// analyzed for diagnostics one region at a time, around edits and the
+	// visible range. Zero or less analyzes every document in full.
+	LargeFileThreshold int `json:"largeFileThreshold,omitempty"`
+	// SSRAttributes maps attributes which server-side rendering adds to
+	// custom elements, e.g. defer-hydration, to their documentation. They
+	// are never reported as unknown, and hover shows their documentation.
+	// Configured attributes add to the defaults.
+	SSRAttributes map[string]string `json:"ssrAttributes,omitempty"`
+	// Languages force the language of files whose extension would route
+	// them to the wrong parser. The first matching override applies.
+	Languages []LanguageOverride `json:"languages,omitempty"`
+	// GenerateOnSave regenerates the local project's manifest in-memory
+	// when one of its source files is saved, if the project configures
+	// `generate.files` but its manifest on disk is missing or out of date
+	GenerateOnSave bool `json:"generateOnSave,omitempty"`
+	// VueBindings completes and describes Vue template bindings on custom
+	// elements, like @change, v-on:change, and :value, outside of Lit
+	// templates. Off by default, since they overlap Lit's binding syntax.
+	VueBindings bool `json:"vueBindings,omitempty"`
+	// Diagnostics overrides the severity of each rule's diagnostics, e.g.
+	// {"unknown-attribute": "hint", "missing-import": "off"}. Settings from
+	// the client override those in the project's cem config file.
+	Diagnostics map[DiagnosticRule]RuleSeverity `json:"diagnostics,omitempty"`
+	// Formatting is the editor's indentation, e.g. VS Code's
+	// editor.insertSpaces and editor.tabSize, which inserted code follows.
+	// When unset, inserted code follows the document's own indentation.
+	Formatting FormattingConfig `json:"formatting,omitzero"`
+	// RequiredSlots maps tag names to the slots which usages of the element
+	// must fill, like {"my-card": ["header", ""]}, where "" is the default
+	// slot. They add to the slots which manifests and the project's cem
+	// config file mark required.
+	RequiredSlots map[string][]string `json:"requiredSlots,omitempty"`
+}
+
+// FormattingConfig mirrors the LSP FormattingOptions which the client
+// formats documents with
+type FormattingConfig struct {
+	InsertSpaces *bool `json:"insertSpaces,omitempty"`
+	TabSize      int   `json:"tabSize,omitempty"`
+}
+
+// IndentUnit returns one level of the editor's indentation, or false if
+// the client didn't configure it
+func (f FormattingConfig) IndentUnit() (string, bool) {
+	if f.InsertSpaces == nil && f.TabSize <= 0 {
+		return "", false
+	}
+	if f.InsertSpaces != nil && !*f.InsertSpaces {
+		return "\t", true
+	}
+	size := f.TabSize
+	if size <= 0 {
+		size = 2
+	}
+	return strings.Repeat(" ", size), true
+}
+
+// LanguageIgnore is the language of files which the server does not analyze
+const LanguageIgnore = "ignore"
+
+// LanguageOverride sets the language of the files matching a glob pattern,
+// relative to the workspace root
+type LanguageOverride struct {
+	Files string `json:"files"`
+	// Language is html, typescript, tsx, css, php, blade, template, or ignore
+	Language string `json:"language"`
+}
+
+// DefaultLargeFileThreshold is the default LargeFileThreshold, 1 MiB
+const DefaultLargeFileThreshold = 1 << 20
+
+// DefaultSSRAttributes are the SSR attributes recognized without configuration
+var DefaultSSRAttributes = map[string]string{
+	"defer-hydration": "Defers hydration of this server-rendered element until the attribute is removed, " +
+		"following the [defer-hydration community protocol]" +
+		"(https://github.com/webcomponents-cg/community-protocols/blob/main/proposals/defer-hydration.md).",
+}
+
+// FileKindsConfig holds glob patterns, relative to the workspace root, that
+// classify files as tests, stories, or demos. A nil list uses the built-in
+// defaults; an empty list disables that classification.
+type FileKindsConfig struct {
+	Tests   []string `json:"tests,omitempty"`
+	Stories []string `json:"stories,omitempty"`
+	Demos   []string `json:"demos,omitempty"`
+}
+
+// ReferencesConfig controls textDocument/references results
+type ReferencesConfig struct {
+	// ExcludeTests omits references found in test files
+	ExcludeTests bool `json:"excludeTests,omitempty"`
 }
 
 // DefaultConfig returns the default server configuration
 func DefaultConfig() ServerConfig {
 	enabled := true
 	return ServerConfig{
-		InlayHints: &enabled,
+		InlayHints:         &enabled,
+		LargeFileThreshold: DefaultLargeFileThreshold,
+		SSRAttributes:      maps.Clone(DefaultSSRAttributes),
 	}
 }
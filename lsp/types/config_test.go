@@ -0,0 +1,48 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package types_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/lsp/types"
+)
+
+// Inline: pure function, table-driven
+func TestFormattingConfigIndentUnit(t *testing.T) {
+	spaces, tabs := true, false
+	tests := []struct {
+		name   string
+		config types.FormattingConfig
+		unit   string
+		ok     bool
+	}{
+		{"unset", types.FormattingConfig{}, "", false},
+		{"tabs", types.FormattingConfig{InsertSpaces: &tabs, TabSize: 4}, "\t", true},
+		{"spaces", types.FormattingConfig{InsertSpaces: &spaces, TabSize: 4}, "    ", true},
+		{"spaces without size", types.FormattingConfig{InsertSpaces: &spaces}, "  ", true},
+		{"size only", types.FormattingConfig{TabSize: 3}, "   ", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, ok := tt.config.IndentUnit()
+			if unit != tt.unit || ok != tt.ok {
+				t.Errorf("IndentUnit() = (%q, %v), want (%q, %v)", unit, ok, tt.unit, tt.ok)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"bennypowers.dev/cem/internal/platform"
 	"bennypowers.dev/cem/internal/treesitter"
 	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/search"
 	"go.lsp.dev/protocol"
 )
 
@@ -43,6 +44,47 @@ type Workspace interface {
 	Cleanup() error
 }
 
+// ManifestSource is where the registry found a manifest
+type ManifestSource string
+
+const (
+	// SourceWorkspace is the workspace's own manifest, or one of its
+	// workspace packages' manifests
+	SourceWorkspace ManifestSource = "workspace"
+	// SourceNodeModules is the manifest of a package in node_modules
+	SourceNodeModules ManifestSource = "node_modules"
+	// SourceAdditional is the manifest of one of the configured
+	// additionalPackages
+	SourceAdditional ManifestSource = "additional"
+)
+
+// TagSource describes which loaded manifest describes a tag name
+type TagSource struct {
+	TagName     string         `json:"tagName"`
+	PackageName string         `json:"packageName"`
+	Source      ManifestSource `json:"source"`
+	// Shadowed lists the other packages which declare the tag name, in the
+	// order they were loaded
+	Shadowed []string `json:"shadowed,omitempty"`
+}
+
+// PackageInfo describes where a package loaded into the registry came from
+type PackageInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// Source is where the registry found the package's manifest
+	Source ManifestSource `json:"source,omitempty"`
+	// ManifestPath is the manifest file, or the specifier of an additional
+	// package. It is empty for manifests generated in memory.
+	ManifestPath string `json:"manifestPath,omitempty"`
+	// Generated reports that the manifest was generated in memory, because
+	// the package declares none, or its manifest is missing
+	Generated    bool `json:"generated,omitempty"`
+	ElementCount int  `json:"elementCount"`
+	// Error reports why the package's manifest failed to load
+	Error string `json:"error,omitempty"`
+}
+
 // Registry interface for manifest operations
 type Registry interface {
 	AddManifest(manifest *M.Package)
@@ -54,10 +96,26 @@ type Registry interface {
 	Slots(tagName string) ([]M.Slot, bool)
 	ElementDefinition(tagName string) (ElementDefinition, bool)
 	FindCustomElementDeclaration(tagName string) *M.CustomElementDeclaration
+	// FindElements ranks elements against a fuzzy query, returning up to
+	// limit matches, best first
+	FindElements(query string, limit int) []search.ElementMatch
 	ManifestCount() int
 	ElementCount() int
 }
 
+// EditCapabilities describes the workspace edit features the client
+// declared in its initialize request
+type EditCapabilities struct {
+	// DocumentChanges reports support for versioned document changes
+	DocumentChanges bool
+	// ChangeAnnotations reports support for annotated edits, e.g. edits
+	// which need confirmation
+	ChangeAnnotations bool
+	// ResolveEdits reports that code action edits may be computed lazily,
+	// in codeAction/resolve
+	ResolveEdits bool
+}
+
 // ServerContext provides all dependencies needed for LSP methods
 // This unified context eliminates the need for method-specific context interfaces
 type ServerContext interface {
@@ -102,6 +160,9 @@ type ServerContext interface {
 	// Ephemeral registry synthesis for locally-defined elements
 	SynthesizeEphemeralElements(uri string)
 
+	// Manifest generation for the local project when a file is saved
+	GenerateOnSave(uri string)
+
 	// Configuration
 	Config() ServerConfig
 	SetConfig(ServerConfig)
@@ -110,4 +171,11 @@ type ServerContext interface {
 	// Pull diagnostics
 	UsePullDiagnostics() bool
 	SetUsePullDiagnostics(bool)
+
+	// Client workspace edit support
+	EditCapabilities() EditCapabilities
+	SetEditCapabilities(EditCapabilities)
+
+	// Regional diagnostics for documents over the large file threshold
+	DiagnosticRegions() *DiagnosticRegions
 }
@@ -28,8 +28,76 @@ const (
 	DiagnosticTypeAttributeSuggestion      DiagnosticType = "attribute-suggestion"
 	DiagnosticTypeAttributeValueSuggestion DiagnosticType = "attribute-value-suggestion"
 	DiagnosticTypeCSSAmbiguousComment      DiagnosticType = "css-ambiguous-comment"
+	DiagnosticTypeSelfClosingCustomElement DiagnosticType = "self-closing-custom-element"
+	DiagnosticTypeMissingSlot              DiagnosticType = "missing-slot"
+	DiagnosticTypeDeprecatedElement        DiagnosticType = "deprecated-element"
 )
 
+// DiagnosticRule identifies the check which produced a diagnostic. It is
+// sent as the diagnostic's code, and names the check in suppression comments
+// like <!-- cem-ignore-next-line unknown-attribute -->.
+type DiagnosticRule string
+
+const (
+	RuleUnknownElement           DiagnosticRule = "unknown-element"
+	RuleDeprecatedElement        DiagnosticRule = "deprecated-element"
+	RuleMissingImport            DiagnosticRule = "missing-import"
+	RuleUnknownSlot              DiagnosticRule = "unknown-slot"
+	RuleDeprecatedSlot           DiagnosticRule = "deprecated-slot"
+	RuleUnknownAttribute         DiagnosticRule = "unknown-attribute"
+	RuleDeprecatedAttribute      DiagnosticRule = "deprecated-attribute"
+	RuleInvalidAttributeValue    DiagnosticRule = "invalid-attribute-value"
+	RuleInvalidARIA              DiagnosticRule = "invalid-aria"
+	RuleMisplacedDirective       DiagnosticRule = "misplaced-directive"
+	RuleInvalidIsAttribute       DiagnosticRule = "invalid-is-attribute"
+	RuleCSSAmbiguousComment      DiagnosticRule = "css-ambiguous-comment"
+	RuleSelfClosingCustomElement DiagnosticRule = "self-closing-custom-element"
+	RuleVoidEndTag               DiagnosticRule = "void-end-tag"
+	RuleMissingSlot              DiagnosticRule = "missing-slot"
+)
+
+// Code returns the rule as a diagnostic code
+func (r DiagnosticRule) Code() protocol.ProgressToken {
+	return protocol.String(r)
+}
+
+// DiagnosticRuleOf returns the rule of a diagnostic, from its code
+func DiagnosticRuleOf(diagnostic protocol.Diagnostic) (DiagnosticRule, bool) {
+	code, ok := diagnostic.Code.(protocol.String)
+	if !ok || code == "" {
+		return "", false
+	}
+	return DiagnosticRule(code), true
+}
+
+// RuleSeverity configures the severity of a rule's diagnostics, or turns
+// the rule off
+type RuleSeverity string
+
+const (
+	SeverityError   RuleSeverity = "error"
+	SeverityWarning RuleSeverity = "warning"
+	SeverityInfo    RuleSeverity = "info"
+	SeverityHint    RuleSeverity = "hint"
+	SeverityOff     RuleSeverity = "off"
+)
+
+// DiagnosticSeverity returns the protocol severity of a configured severity.
+// It reports false for "off" and for unknown severities.
+func (s RuleSeverity) DiagnosticSeverity() (protocol.DiagnosticSeverity, bool) {
+	switch s {
+	case SeverityError:
+		return protocol.DiagnosticSeverityError, true
+	case SeverityWarning:
+		return protocol.DiagnosticSeverityWarning, true
+	case SeverityInfo:
+		return protocol.DiagnosticSeverityInformation, true
+	case SeverityHint:
+		return protocol.DiagnosticSeverityHint, true
+	}
+	return 0, false
+}
+
 // AutofixData contains the data needed for creating autofix code actions
 type AutofixData struct {
 	Type       DiagnosticType `json:"type"`
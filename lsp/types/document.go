@@ -101,6 +101,9 @@ type Manager interface {
 	Document(uri string) Document
 	AllDocuments() []Document
 	QueryManager() *Q.QueryManager
+	// SetLanguageResolver sets the function which returns the configured
+	// language of a document, or "" when none is configured
+	SetLanguageResolver(resolve func(uri string) string)
 	Close()
 }
 
@@ -116,9 +119,11 @@ type AttributeMatch struct {
 	Name             string
 	Value            string
 	Range            protocol.Range
-	BindingPrefix    string // Lit binding prefix: ".", "?", "@", or "" for plain attributes
-	ExpressionKind   string // "literal", "this-member", "identifier", "complex", or "" (not an expression)
-	ExpressionDetail string // member name, identifier name, or literal value depending on kind
+	BindingPrefix    string         // Lit binding prefix: ".", "?", "@", or "" for plain attributes
+	ExpressionKind   string         // "literal", "this-member", "identifier", "complex", or "" (not an expression)
+	ExpressionDetail string         // member name, identifier name, or literal value depending on kind
+	Directive        string         // Lit directive wrapping the expression: "classMap", "styleMap", "ifDefined", "live", or ""
+	DirectiveRange   protocol.Range // Range of the directive's name, when Directive is set
 }
 
 // ElementDefinition represents a custom element with its source information
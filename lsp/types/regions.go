@@ -0,0 +1,240 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package types
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"go.lsp.dev/protocol"
+)
+
+// LineSpan is an inclusive range of zero-based document lines
+type LineSpan struct {
+	Start uint32
+	End   uint32
+}
+
+// Contains reports whether line lies within the span
+func (s LineSpan) Contains(line uint32) bool {
+	return line >= s.Start && line <= s.End
+}
+
+// DiagnosticRegions tracks diagnostics for documents over the large file
+// threshold, which are analyzed one region at a time. For each document it
+// remembers the diagnostics found so far, which lines they cover, and which
+// lines still need analysis because they were edited or scrolled into view.
+// Diagnostics outside re-analyzed regions are kept, shifted to follow edits,
+// so they are not lost when only part of the document is re-analyzed.
+type DiagnosticRegions struct {
+	mu   sync.Mutex
+	docs map[string]*documentRegions
+}
+
+type documentRegions struct {
+	analyzed    []LineSpan // sorted and merged
+	pending     []LineSpan // sorted and merged
+	diagnostics []protocol.Diagnostic
+}
+
+// NewDiagnosticRegions creates an empty tracker
+func NewDiagnosticRegions() *DiagnosticRegions {
+	return &DiagnosticRegions{docs: make(map[string]*documentRegions)}
+}
+
+// Pending returns the lines of a document which need analysis. A document
+// seen for the first time starts tracking with the initial span pending.
+func (r *DiagnosticRegions) Pending(uri string, initial LineSpan) []LineSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc, ok := r.docs[uri]
+	if !ok {
+		doc = &documentRegions{pending: []LineSpan{initial}}
+		r.docs[uri] = doc
+	}
+	return slices.Clone(doc.pending)
+}
+
+// Update records the diagnostics found by analyzing spans, replacing the
+// cached diagnostics within them, and returns all known diagnostics for the
+// document, in document order. Diagnostics which start outside spans are
+// ignored, since regions are parsed with some margin whose edges may be cut
+// mid-element.
+func (r *DiagnosticRegions) Update(uri string, spans []LineSpan, diagnostics []protocol.Diagnostic) []protocol.Diagnostic {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc, ok := r.docs[uri]
+	if !ok {
+		doc = &documentRegions{}
+		r.docs[uri] = doc
+	}
+
+	inSpans := func(d protocol.Diagnostic) bool {
+		return slices.ContainsFunc(spans, func(s LineSpan) bool { return s.Contains(d.Range.Start.Line) })
+	}
+	kept := slices.DeleteFunc(doc.diagnostics, inSpans)
+	for _, d := range diagnostics {
+		if inSpans(d) {
+			kept = append(kept, d)
+		}
+	}
+	slices.SortStableFunc(kept, func(a, b protocol.Diagnostic) int {
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return int(a.Range.Start.Line) - int(b.Range.Start.Line)
+		}
+		return int(a.Range.Start.Character) - int(b.Range.Start.Character)
+	})
+	doc.diagnostics = kept
+
+	for _, s := range spans {
+		doc.analyzed = MergeLineSpans(append(doc.analyzed, s))
+		doc.pending = subtractLineSpan(doc.pending, s)
+	}
+	return slices.Clone(doc.diagnostics)
+}
+
+// Diagnostics returns the known diagnostics for a document
+func (r *DiagnosticRegions) Diagnostics(uri string) []protocol.Diagnostic {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if doc, ok := r.docs[uri]; ok {
+		return slices.Clone(doc.diagnostics)
+	}
+	return nil
+}
+
+// NoteViewport marks the lines of a visible range for analysis, and reports
+// whether any of them have not been analyzed yet. Untracked documents are
+// ignored.
+func (r *DiagnosticRegions) NoteViewport(uri string, viewport protocol.Range) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc, ok := r.docs[uri]
+	if !ok {
+		return false
+	}
+	missing := subtractLineSpans([]LineSpan{{Start: viewport.Start.Line, End: viewport.End.Line}}, doc.analyzed)
+	if len(missing) == 0 {
+		return false
+	}
+	doc.pending = MergeLineSpans(append(doc.pending, missing...))
+	return true
+}
+
+// NoteChanges follows a tracked document through a batch of content changes,
+// applied in order: cached diagnostics and spans after each edit move with
+// the text, and the edited lines become pending. A whole-document change
+// forgets the document, so that it is analyzed afresh.
+func (r *DiagnosticRegions) NoteChanges(uri string, changes []protocol.TextDocumentContentChangeEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	doc, ok := r.docs[uri]
+	if !ok {
+		return
+	}
+	for _, change := range changes {
+		partial, ok := change.(*protocol.TextDocumentContentChangePartial)
+		if !ok {
+			delete(r.docs, uri)
+			return
+		}
+		edited := LineSpan{Start: partial.Range.Start.Line, End: partial.Range.End.Line}
+		newLines := uint32(strings.Count(partial.Text, "\n"))
+		delta := int64(newLines) - int64(edited.End-edited.Start)
+
+		doc.diagnostics = slices.DeleteFunc(doc.diagnostics, func(d protocol.Diagnostic) bool {
+			return edited.Contains(d.Range.Start.Line)
+		})
+		for i := range doc.diagnostics {
+			d := &doc.diagnostics[i]
+			d.Range.Start.Line = shiftLine(d.Range.Start.Line, edited.End, delta)
+			d.Range.End.Line = shiftLine(d.Range.End.Line, edited.End, delta)
+		}
+
+		inserted := LineSpan{Start: edited.Start, End: edited.Start + newLines}
+		doc.analyzed = shiftLineSpans(subtractLineSpan(doc.analyzed, edited), edited.End, delta)
+		doc.pending = MergeLineSpans(append(shiftLineSpans(doc.pending, edited.End, delta), inserted))
+	}
+}
+
+// Forget stops tracking a document
+func (r *DiagnosticRegions) Forget(uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.docs, uri)
+}
+
+// MergeLineSpans sorts spans and merges those which overlap or touch
+func MergeLineSpans(spans []LineSpan) []LineSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(spans)
+	slices.SortFunc(sorted, func(a, b LineSpan) int { return int(a.Start) - int(b.Start) })
+	merged := []LineSpan{sorted[0]}
+	for _, s := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if s.Start <= last.End+1 {
+			last.End = max(last.End, s.End)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// shiftLine moves a line after the end of an edit by the edit's line delta
+func shiftLine(line, editEnd uint32, delta int64) uint32 {
+	if line <= editEnd {
+		return line
+	}
+	return uint32(max(int64(line)+delta, 0))
+}
+
+func shiftLineSpans(spans []LineSpan, editEnd uint32, delta int64) []LineSpan {
+	shifted := make([]LineSpan, 0, len(spans))
+	for _, s := range spans {
+		shifted = append(shifted, LineSpan{Start: shiftLine(s.Start, editEnd, delta), End: shiftLine(s.End, editEnd, delta)})
+	}
+	return MergeLineSpans(shifted)
+}
+
+// subtractLineSpan removes the lines of cut from spans
+func subtractLineSpan(spans []LineSpan, cut LineSpan) []LineSpan {
+	var result []LineSpan
+	for _, s := range spans {
+		if s.End < cut.Start || s.Start > cut.End {
+			result = append(result, s)
+			continue
+		}
+		if s.Start < cut.Start {
+			result = append(result, LineSpan{Start: s.Start, End: cut.Start - 1})
+		}
+		if s.End > cut.End {
+			result = append(result, LineSpan{Start: cut.End + 1, End: s.End})
+		}
+	}
+	return result
+}
+
+func subtractLineSpans(spans []LineSpan, cuts []LineSpan) []LineSpan {
+	for _, cut := range cuts {
+		spans = subtractLineSpan(spans, cut)
+	}
+	return spans
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"bennypowers.dev/cem/lsp/types"
+	"go.lsp.dev/protocol"
+)
+
+// Inline: pure function, table-driven
+func TestMergeLineSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		spans    []types.LineSpan
+		expected []types.LineSpan
+	}{
+		{"empty", nil, nil},
+		{"disjoint", []types.LineSpan{{10, 20}, {0, 5}}, []types.LineSpan{{0, 5}, {10, 20}}},
+		{"overlapping", []types.LineSpan{{0, 10}, {5, 20}}, []types.LineSpan{{0, 20}}},
+		{"touching", []types.LineSpan{{0, 4}, {5, 9}}, []types.LineSpan{{0, 9}}},
+		{"contained", []types.LineSpan{{0, 20}, {5, 6}}, []types.LineSpan{{0, 20}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := types.MergeLineSpans(tt.spans); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MergeLineSpans(%v) = %v, want %v", tt.spans, got, tt.expected)
+			}
+		})
+	}
+}
+
+func diagnosticAt(line uint32) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range:   protocol.Range{Start: protocol.Position{Line: line}, End: protocol.Position{Line: line, Character: 1}},
+		Message: protocol.String("problem"),
+	}
+}
+
+func startLines(diagnostics []protocol.Diagnostic) []uint32 {
+	var lines []uint32
+	for _, d := range diagnostics {
+		lines = append(lines, d.Range.Start.Line)
+	}
+	return lines
+}
+
+func TestDiagnosticRegions(t *testing.T) {
+	r := types.NewDiagnosticRegions()
+	uri := "file:///large.html"
+
+	// Untracked documents ignore viewports and edits
+	if r.NoteViewport(uri, protocol.Range{End: protocol.Position{Line: 10}}) {
+		t.Error("NoteViewport should ignore an untracked document")
+	}
+
+	pending := r.Pending(uri, types.LineSpan{Start: 0, End: 99})
+	if !reflect.DeepEqual(pending, []types.LineSpan{{0, 99}}) {
+		t.Fatalf("initial pending = %v", pending)
+	}
+	// Diagnostics outside the analyzed spans are dropped
+	got := r.Update(uri, pending, []protocol.Diagnostic{diagnosticAt(50), diagnosticAt(10), diagnosticAt(150)})
+	if !reflect.DeepEqual(startLines(got), []uint32{10, 50}) {
+		t.Errorf("after first update, lines = %v", startLines(got))
+	}
+	if len(r.Pending(uri, types.LineSpan{})) != 0 {
+		t.Error("analyzed spans should no longer be pending")
+	}
+
+	if !r.NoteViewport(uri, protocol.Range{Start: protocol.Position{Line: 90}, End: protocol.Position{Line: 200}}) {
+		t.Error("NoteViewport should report unanalyzed lines")
+	}
+	pending = r.Pending(uri, types.LineSpan{})
+	if !reflect.DeepEqual(pending, []types.LineSpan{{100, 200}}) {
+		t.Fatalf("viewport pending = %v", pending)
+	}
+	got = r.Update(uri, pending, []protocol.Diagnostic{diagnosticAt(150)})
+	if !reflect.DeepEqual(startLines(got), []uint32{10, 50, 150}) {
+		t.Errorf("after viewport update, lines = %v", startLines(got))
+	}
+
+	// Replace lines 40-50 with three lines: the diagnostic within is dropped,
+	// the one after moves up by seven lines, and the edit becomes pending
+	r.NoteChanges(uri, []protocol.TextDocumentContentChangeEvent{&protocol.TextDocumentContentChangePartial{
+		Range: protocol.Range{Start: protocol.Position{Line: 40}, End: protocol.Position{Line: 50, Character: 3}},
+		Text:  "a\nb\nc\n",
+	}})
+	if lines := startLines(r.Diagnostics(uri)); !reflect.DeepEqual(lines, []uint32{10, 143}) {
+		t.Errorf("after edit, lines = %v", lines)
+	}
+	if pending := r.Pending(uri, types.LineSpan{}); !reflect.DeepEqual(pending, []types.LineSpan{{40, 43}}) {
+		t.Errorf("after edit, pending = %v", pending)
+	}
+	if r.NoteViewport(uri, protocol.Range{Start: protocol.Position{Line: 100}, End: protocol.Position{Line: 192}}) {
+		t.Error("shifted analyzed lines should still count as analyzed")
+	}
+
+	// A whole-document change forgets the document
+	r.NoteChanges(uri, []protocol.TextDocumentContentChangeEvent{&protocol.TextDocumentContentChangeWholeDocument{Text: ""}})
+	if r.Diagnostics(uri) != nil {
+		t.Error("a whole-document change should forget the document")
+	}
+}
@@ -33,6 +33,10 @@ type ClassLike struct {
 	Mixins     []Reference      `json:"mixins,omitempty"`
 	Members    []ClassMember    `json:"members,omitempty"`
 	Source     *SourceReference `json:"source,omitempty"`
+	// Implements references the TypeScript interfaces the class implements.
+	// The schema has no field for them, so they are written as a vendor
+	// extension.
+	Implements []Reference `json:"x-implements,omitempty"`
 }
 
 // Clone creates a deep copy of the ClassLike structure.
@@ -55,6 +59,13 @@ func (c ClassLike) Clone() ClassLike {
 		}
 	}
 
+	if len(c.Implements) > 0 {
+		cloned.Implements = make([]Reference, len(c.Implements))
+		for i, iface := range c.Implements {
+			cloned.Implements[i] = iface.Clone()
+		}
+	}
+
 	if len(c.Members) > 0 {
 		cloned.Members = make([]ClassMember, len(c.Members))
 		for i, member := range c.Members {
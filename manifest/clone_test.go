@@ -373,3 +373,15 @@ func TestDeprecatedClone(t *testing.T) {
 		assert.Equal(t, orig, cloned)
 	})
 }
+
+func TestPlatformOptionsClone(t *testing.T) {
+	orig := &PlatformOptions{
+		ShadowRoot: &ShadowRootOptions{Mode: "open"},
+		Features:   []string{FeaturePopover},
+	}
+	cloned := orig.Clone()
+	orig.ShadowRoot.Mode = "closed"
+	orig.Features[0] = "changed"
+	assert.Equal(t, "open", cloned.ShadowRoot.Mode)
+	assert.Equal(t, []string{FeaturePopover}, cloned.Features)
+}
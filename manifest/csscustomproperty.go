@@ -19,6 +19,7 @@ package manifest
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 )
 
 var _ Deprecatable = (*CssCustomProperty)(nil)
@@ -32,6 +33,9 @@ type CssCustomProperty struct {
 	Default       string     `json:"default,omitempty"`
 	Syntax        string     `json:"syntax,omitempty"`
 	Deprecated    Deprecated `json:"deprecated,omitempty"` // bool or string
+	// Group organizes large theming APIs in documentation, e.g. "Surface".
+	// It comes from a `[group: Surface]` suffix on the property's description.
+	Group string `json:"x-group,omitempty"`
 }
 
 func (x *CssCustomProperty) IsDeprecated() bool {
@@ -76,6 +80,7 @@ func (c CssCustomProperty) Clone() CssCustomProperty {
 		StartByte: c.StartByte,
 		Default:   c.Default,
 		Syntax:    c.Syntax,
+		Group:     c.Group,
 	}
 
 	// Clone the embedded FullyQualified
@@ -93,6 +98,35 @@ func (c CssCustomProperty) Clone() CssCustomProperty {
 	return cloned
 }
 
+// CssCustomPropertyGroup is a group of CSS custom properties which share a
+// Group. Ungrouped properties form a group with no name.
+type CssCustomPropertyGroup struct {
+	Name       string
+	Properties []CssCustomProperty
+}
+
+// GroupCssProperties groups props by their Group, ungrouped properties
+// first, then each group in the order it first appears. Properties keep
+// their order within each group.
+func GroupCssProperties(props []CssCustomProperty) []CssCustomPropertyGroup {
+	var groups []CssCustomPropertyGroup
+	index := make(map[string]int)
+	if slices.ContainsFunc(props, func(prop CssCustomProperty) bool { return prop.Group == "" }) {
+		groups = append(groups, CssCustomPropertyGroup{})
+		index[""] = 0
+	}
+	for _, prop := range props {
+		i, ok := index[prop.Group]
+		if !ok {
+			i = len(groups)
+			index[prop.Group] = i
+			groups = append(groups, CssCustomPropertyGroup{Name: prop.Group})
+		}
+		groups[i].Properties = append(groups[i].Properties, prop)
+	}
+	return groups
+}
+
 type RenderableCssCustomProperty struct {
 	CssCustomProperty        *CssCustomProperty
 	CustomElementDeclaration *CustomElementDeclaration
@@ -0,0 +1,47 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupCssProperties(t *testing.T) {
+	prop := func(name, group string) CssCustomProperty {
+		return CssCustomProperty{FullyQualified: FullyQualified{Name: name}, Group: group}
+	}
+	groups := GroupCssProperties([]CssCustomProperty{
+		prop("--surface-bg", "Surface"),
+		prop("--gap", ""),
+		prop("--text-color", "Typography"),
+		prop("--surface-border", "Surface"),
+	})
+	assert.Equal(t, []CssCustomPropertyGroup{
+		{Properties: []CssCustomProperty{prop("--gap", "")}},
+		{Name: "Surface", Properties: []CssCustomProperty{prop("--surface-bg", "Surface"), prop("--surface-border", "Surface")}},
+		{Name: "Typography", Properties: []CssCustomProperty{prop("--text-color", "Typography")}},
+	}, groups)
+
+	assert.Empty(t, GroupCssProperties(nil))
+}
+
+func TestCssCustomPropertyCloneGroup(t *testing.T) {
+	orig := CssCustomProperty{FullyQualified: FullyQualified{Name: "--x"}, Group: "Surface"}
+	assert.Equal(t, "Surface", orig.Clone().Group)
+}
@@ -30,6 +30,10 @@ type CssPart struct {
 	InheritedFrom *Reference `json:"inheritedFrom,omitempty"`
 	StartByte     uint       `json:"-" yaml:"-"`
 	Deprecated    Deprecated `json:"deprecated,omitempty"` // bool or string
+	// Pattern marks a name with wildcards, like "item-*", which documents a
+	// family of names. The schema has no field for it, so it is written as a
+	// vendor extension.
+	Pattern bool `json:"x-pattern,omitempty"`
 }
 
 func NewCssPart(
@@ -54,6 +58,18 @@ func (x *CssPart) IsDeprecated() bool {
 	return x.Deprecated != nil
 }
 
+// Matches reports whether the part documents name, either as its own name or,
+// when it is a pattern, as one of the family of names the pattern describes.
+func (x *CssPart) Matches(name string) bool {
+	if x == nil {
+		return false
+	}
+	if x.Pattern {
+		return MatchNamePattern(x.Name, name)
+	}
+	return x.Name == name
+}
+
 func (c *CssPart) UnmarshalJSON(data []byte) error {
 	type Rest CssPart
 	aux := &struct {
@@ -83,6 +99,7 @@ func (c *CssPart) UnmarshalJSON(data []byte) error {
 func (c CssPart) Clone() CssPart {
 	cloned := CssPart{
 		StartByte: c.StartByte,
+		Pattern:   c.Pattern,
 	}
 
 	// Clone the embedded FullyQualified
@@ -44,6 +44,25 @@ type CustomElement struct {
 	CssStates     []CssCustomState    `json:"cssStates,omitempty"`
 	Demos         []Demo              `json:"demos,omitempty"`
 	CustomElement bool                `json:"customElement"`
+	// Extends is the built-in element a customized built-in element extends,
+	// e.g. "button" for `customElements.define('fancy-button', FancyButton, { extends: 'button' })`.
+	// Customized built-ins are used with the `is` attribute: `<button is="fancy-button">`.
+	// The schema has no field for it, so it is written as a vendor extension.
+	Extends string `json:"x-extends,omitempty"`
+	// Categories group the element in a design system taxonomy, e.g. "form"
+	// or "layout". They come from `@category` JSDoc tags and from the
+	// `generate.categories` config. The schema has no field for them, so
+	// they are written as a vendor extension.
+	Categories []string `json:"x-categories,omitempty"`
+	// Role is the WAI-ARIA role the element takes, e.g. "button", from its
+	// `@role` JSDoc tag. Editors use it to suggest and check `aria-*`
+	// attributes. The schema has no field for it, so it is written as a
+	// vendor extension.
+	Role string `json:"x-role,omitempty"`
+	// Platform describes the element's shadow root and form association.
+	// The schema has no fields for them, so they are written as a vendor
+	// extension.
+	Platform *PlatformOptions `json:"x-platform,omitempty"`
 }
 
 // Clone creates a deep copy of the CustomElement structure.
@@ -52,6 +71,10 @@ func (c CustomElement) Clone() CustomElement {
 	cloned := CustomElement{
 		TagName:       c.TagName,
 		CustomElement: c.CustomElement,
+		Extends:       c.Extends,
+		Categories:    slices.Clone(c.Categories),
+		Role:          c.Role,
+		Platform:      c.Platform.Clone(),
 	}
 
 	if len(c.Attributes) > 0 {
@@ -314,6 +337,20 @@ func (ced *CustomElementDeclaration) Methods() []ClassMember {
 	return ced.flattenMembers(pkg).methods
 }
 
+// AttributeReflects reports whether the named attribute reflects its
+// property value back to the DOM, i.e. whether the field backing it was
+// declared with `reflect: true`.
+func (ced *CustomElementDeclaration) AttributeReflects(name string) bool {
+	// Flattened fields are plain class fields, without attribute or reflects,
+	// so this reads the class' own members
+	for _, member := range ced.Members {
+		if field, ok := member.(*CustomElementField); ok && field.Attribute == name {
+			return field.Reflects
+		}
+	}
+	return false
+}
+
 func (c *CustomElementDeclaration) UnmarshalJSON(data []byte) (errs error) {
 	type Rest CustomElementDeclaration
 	aux := &struct {
@@ -99,3 +99,25 @@ func TestRenderableCustomElementDeclaration(t *testing.T) {
 		})
 	})
 }
+
+func TestCustomElementDeclaration_AttributeReflects(t *testing.T) {
+	fixtureFS := testutil.NewFixtureFS(t, "", "/")
+	manifestJSON, err := fs.ReadFile(fixtureFS, "/custom-element-reflecting-attributes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pkg Package
+	if err := json.Unmarshal(manifestJSON, &pkg); err != nil {
+		t.Fatal(err)
+	}
+
+	decl, ok := pkg.Modules[0].Declarations[0].(*CustomElementDeclaration)
+	if !assert.True(t, ok, "expected a custom element declaration") {
+		t.FailNow()
+	}
+
+	assert.True(t, decl.AttributeReflects("pressed"), "pressed is declared with reflects")
+	assert.False(t, decl.AttributeReflects("label-text"), "label-text does not reflect")
+	assert.False(t, decl.AttributeReflects("missing"), "unknown attributes do not reflect")
+}
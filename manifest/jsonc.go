@@ -0,0 +1,142 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tailscale/hujson"
+)
+
+// StandardizeJSONC converts a JSONC manifest (JSON with comments and trailing
+// commas) to standard JSON. Comments are blanked rather than removed, so line
+// numbers and offsets in errors still point into the original file. Standard
+// JSON is returned as is.
+func StandardizeJSONC(data []byte) ([]byte, error) {
+	if json.Valid(data) {
+		return data, nil
+	}
+	// Standardize rewrites its input in place
+	standard, err := hujson.Standardize(slices.Clone(data))
+	if err != nil {
+		return nil, err
+	}
+	return standard, nil
+}
+
+// UnmarshalPackage parses a JSON or JSONC manifest
+func UnmarshalPackage(data []byte) (*Package, error) {
+	data, err := StandardizeJSONC(data)
+	if err != nil {
+		return nil, err
+	}
+	var pkg Package
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// SerializeAnnotated serializes the package as JSONC, with a comment before
+// each module and declaration naming it. The annotated manifest is for people
+// reading it; tools should read the strict JSON manifest.
+func SerializeAnnotated(pkg *Package) ([]byte, error) {
+	data, err := SerializeToBytes(pkg)
+	if err != nil {
+		return nil, err
+	}
+	root, err := hujson.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	root.BeforeExtra = hujson.Extra("// Custom Elements Manifest, annotated for reading.\n" +
+		"// Tools should read the JSON manifest, which has no comments.\n")
+	if modules, ok := arrayMember(&root, "modules"); ok {
+		for i := range modules.Elements {
+			if i >= len(pkg.Modules) {
+				break
+			}
+			module := &pkg.Modules[i]
+			annotate(&modules.Elements[i], "module "+module.Path)
+			declarations, ok := arrayMember(&modules.Elements[i], "declarations")
+			if !ok {
+				continue
+			}
+			for j := range declarations.Elements {
+				if j >= len(module.Declarations) {
+					break
+				}
+				annotate(&declarations.Elements[j], declarationAnnotation(module.Declarations[j]))
+			}
+		}
+	}
+
+	return append(root.Pack(), '\n'), nil
+}
+
+// arrayMember finds the array value of an object's member
+func arrayMember(v *hujson.Value, name string) (*hujson.Array, bool) {
+	obj, ok := v.Value.(*hujson.Object)
+	if !ok {
+		return nil, false
+	}
+	for i := range obj.Members {
+		if obj.Members[i].Name.Value.(hujson.Literal).String() == name {
+			arr, ok := obj.Members[i].Value.Value.(*hujson.Array)
+			return arr, ok
+		}
+	}
+	return nil, false
+}
+
+// annotate adds a line comment before a value, indented like the value
+func annotate(v *hujson.Value, comment string) {
+	indent := string(v.BeforeExtra)
+	comment = strings.Join(strings.Fields(comment), " ")
+	v.BeforeExtra = hujson.Extra(indent + "// " + comment + indent)
+}
+
+// declarationAnnotation describes a declaration in one line
+func declarationAnnotation(decl Declaration) string {
+	var annotation, summary string
+	switch d := decl.(type) {
+	case *CustomElementDeclaration:
+		annotation = fmt.Sprintf("<%s> %s", d.TagName, d.Name())
+		summary = d.ClassLike.Summary
+	case *CustomElementMixinDeclaration:
+		annotation = fmt.Sprintf("mixin <%s> %s", d.TagName, d.Name())
+		summary = d.FullyQualified.Summary
+	case *ClassDeclaration:
+		annotation, summary = "class "+d.Name(), d.Summary
+	case *MixinDeclaration:
+		annotation, summary = "mixin "+d.Name(), d.FullyQualified.Summary
+	case *FunctionDeclaration:
+		annotation, summary = "function "+d.Name(), d.FullyQualified.Summary
+	case *VariableDeclaration:
+		annotation, summary = "variable "+d.Name(), d.Summary
+	default:
+		annotation = decl.Name()
+	}
+	if summary != "" {
+		annotation += ": " + summary
+	}
+	return annotation
+}
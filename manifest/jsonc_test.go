@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest_test
+
+import (
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/manifest"
+)
+
+func TestUnmarshalPackageJSONC(t *testing.T) {
+	fixtureFS := testutil.NewFixtureFS(t, "", "/")
+	data := testutil.ReadFixture(t, fixtureFS, "/jsonc-manifest.jsonc")
+
+	pkg, err := manifest.UnmarshalPackage(data)
+	if err != nil {
+		t.Fatalf("UnmarshalPackage returned error: %v", err)
+	}
+	if len(pkg.Modules) != 1 || len(pkg.Modules[0].Declarations) != 2 {
+		t.Fatalf("Expected 1 module with 2 declarations, got %+v", pkg.Modules)
+	}
+	ced, ok := pkg.Modules[0].Declarations[0].(*manifest.CustomElementDeclaration)
+	if !ok {
+		t.Fatalf("Expected a custom element, got %T", pkg.Modules[0].Declarations[0])
+	}
+	if ced.TagName != "my-card" {
+		t.Errorf("Expected tag name my-card, got %q", ced.TagName)
+	}
+
+	t.Run("input is not modified", func(t *testing.T) {
+		if !strings.Contains(string(data), "// defined in card.ts") {
+			t.Error("UnmarshalPackage removed comments from its input")
+		}
+	})
+
+	t.Run("invalid JSONC", func(t *testing.T) {
+		if _, err := manifest.UnmarshalPackage([]byte(`{ "modules": [ // unclosed`)); err == nil {
+			t.Error("Expected an error for invalid JSONC")
+		}
+	})
+}
+
+func TestSerializeAnnotated(t *testing.T) {
+	fixtureFS := testutil.NewFixtureFS(t, "", "/")
+	data := testutil.ReadFixture(t, fixtureFS, "/jsonc-manifest.jsonc")
+	pkg, err := manifest.UnmarshalPackage(data)
+	if err != nil {
+		t.Fatalf("UnmarshalPackage returned error: %v", err)
+	}
+
+	annotated, err := manifest.SerializeAnnotated(pkg)
+	if err != nil {
+		t.Fatalf("SerializeAnnotated returned error: %v", err)
+	}
+
+	for _, comment := range []string{
+		"\n    // module src/card.js\n    {",
+		"\n        // <my-card> MyCard: A card for grouping content\n        {",
+		"\n        // class CardBase\n        {",
+	} {
+		if !strings.Contains(string(annotated), comment) {
+			t.Errorf("Expected annotated manifest to contain %q, got:\n%s", comment, annotated)
+		}
+	}
+
+	// Stripped of its comments, the annotated manifest is the JSON manifest
+	roundTrip, err := manifest.UnmarshalPackage(annotated)
+	if err != nil {
+		t.Fatalf("Failed to read annotated manifest: %v", err)
+	}
+	expected, err := manifest.SerializeToString(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := manifest.SerializeToString(roundTrip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != expected {
+		t.Errorf("Annotated manifest does not round trip.\nExpected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
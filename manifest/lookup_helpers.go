@@ -16,7 +16,11 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 */
 package manifest
 
-import "sync"
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
 
 // Package-level caches for thread-safe lazy initialization.
 // These are kept external to manifest structs to avoid affecting their semantic structure.
@@ -182,3 +186,18 @@ func (m *Module) LookupJavaScriptExport(declName string) *JavaScriptExport {
 	maps := m.buildExportMaps()
 	return maps.jsExports[declName]
 }
+
+// MatchNamePattern reports whether name is in the family of names documented
+// by pattern, in which each "*" stands for one or more characters, so that
+// "item-*" matches "item-1" but not "item-".
+func MatchNamePattern(pattern, name string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == name
+	}
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re, err := regexp.Compile("^" + strings.Join(parts, ".+") + "$")
+	return err == nil && re.MatchString(name)
+}
@@ -123,3 +123,43 @@ func TestPackage_FindCustomElementDeclaration_NilPackage(t *testing.T) {
 		t.Errorf("Expected nil for nil package, got %v", decl)
 	}
 }
+
+func TestMatchNamePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"item-*", "item-1", true},
+		{"item-*", "item-first", true},
+		{"item-*", "item-", false},
+		{"item-*", "header", false},
+		{"*-icon", "start-icon", true},
+		{"tab-*-label", "tab-1-label", true},
+		{"tab-*-label", "tab-1", false},
+		{"header", "header", true},
+		{"header", "headers", false},
+	}
+	for _, tt := range tests {
+		if got := manifest.MatchNamePattern(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchNamePattern(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSlot_Matches(t *testing.T) {
+	var pattern, literal manifest.Slot
+	if err := json.Unmarshal([]byte(`{"name": "item-*", "x-pattern": true}`), &pattern); err != nil {
+		t.Fatalf("Failed to unmarshal slot: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"name": "item-*"}`), &literal); err != nil {
+		t.Fatalf("Failed to unmarshal slot: %v", err)
+	}
+	if !pattern.Matches("item-1") {
+		t.Error("Expected pattern slot to match item-1")
+	}
+	// Inline: without x-pattern, a "*" is part of the name
+	if literal.Matches("item-1") || !literal.Matches("item-*") {
+		t.Error("Expected slot without x-pattern to match only its own name")
+	}
+}
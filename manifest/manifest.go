@@ -20,6 +20,8 @@ package manifest
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 )
@@ -122,6 +124,18 @@ func SerializeToString(pkg *Package) (string, error) {
 	return string(b), nil
 }
 
+// WriteNDJSON writes each of the package's modules to w as a single line of
+// JSON, so that consumers can process a manifest one module at a time.
+func WriteNDJSON(w io.Writer, pkg *Package) error {
+	enc := json.NewEncoder(w)
+	for i := range pkg.Modules {
+		if err := enc.Encode(&pkg.Modules[i]); err != nil {
+			return fmt.Errorf("encoding module %s: %w", pkg.Modules[i].Path, err)
+		}
+	}
+	return nil
+}
+
 // Clone helpers for efficient deep copying without JSON serialization overhead.
 // These utility functions are used by Clone methods throughout the manifest package.
 
@@ -1,6 +1,9 @@
 package manifest
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestNormalizeSourcePath(t *testing.T) {
 	tests := []struct {
@@ -27,3 +30,19 @@ func TestNormalizeSourcePath(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteNDJSON(t *testing.T) {
+	pkg := NewPackage([]Module{
+		*NewModule("src/a.ts"),
+		{Kind: "javascript-module", Path: "src/b.js", Summary: "B"},
+	})
+	var out strings.Builder
+	if err := WriteNDJSON(&out, &pkg); err != nil {
+		t.Fatalf("WriteNDJSON: %v", err)
+	}
+	want := `{"kind":"javascript-module","path":"src/a.js"}` + "\n" +
+		`{"kind":"javascript-module","path":"src/b.js","summary":"B"}` + "\n"
+	if out.String() != want {
+		t.Errorf("WriteNDJSON() = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/mod/semver"
+)
+
+// MergeStrategy decides which module wins when merged manifests both
+// contain a module at the same path, with different contents
+type MergeStrategy string
+
+const (
+	// MergeError fails the merge on the first conflict
+	MergeError MergeStrategy = "error"
+	// MergePreferFirst keeps the module from the earliest source
+	MergePreferFirst MergeStrategy = "prefer-first"
+	// MergePreferLatestVersion keeps the module from the source with the
+	// highest package version, or the earliest of equal versions
+	MergePreferLatestVersion MergeStrategy = "prefer-latest-version"
+)
+
+// MergeStrategies lists the valid merge strategies
+var MergeStrategies = []MergeStrategy{MergeError, MergePreferFirst, MergePreferLatestVersion}
+
+// ErrMergeConflict is returned by MergePackages with the error strategy
+var ErrMergeConflict = errors.New("merge conflict")
+
+// MergeSource is one manifest to merge
+type MergeSource struct {
+	// Name identifies the source in conflicts, e.g. its file path
+	Name string
+	// Version of the package the manifest describes, used by the
+	// prefer-latest-version strategy
+	Version string
+	Package *Package
+}
+
+// MergeConflict records a module path present in several sources with
+// different contents, and which source's module was kept
+type MergeConflict struct {
+	Path    string   `json:"path"`
+	Sources []string `json:"sources"`
+	Kept    string   `json:"kept"`
+}
+
+// MergePackages combines the modules of several manifests into one package.
+// Modules are kept in source order. Identical modules at the same path are
+// merged silently; differing ones are resolved by strategy, and reported.
+// The result has the highest schema version of its sources, and the first
+// readme. Sources are not modified.
+func MergePackages(sources []MergeSource, strategy MergeStrategy) (*Package, []MergeConflict, error) {
+	switch strategy {
+	case MergeError, MergePreferFirst, MergePreferLatestVersion:
+	default:
+		return nil, nil, fmt.Errorf("unknown merge strategy %q", strategy)
+	}
+
+	type entry struct {
+		module  *Module
+		source  int
+		encoded []byte
+	}
+	var order []string
+	entries := make(map[string]*entry)
+	conflicts := make(map[string]*MergeConflict)
+
+	merged := &Package{SchemaVersion: "2.1.0"}
+	for i, source := range sources {
+		if source.Package == nil {
+			continue
+		}
+		if compareVersions(source.Package.SchemaVersion, merged.SchemaVersion) > 0 {
+			merged.SchemaVersion = source.Package.SchemaVersion
+		}
+		if merged.Readme == nil {
+			merged.Readme = cloneStringPtr(source.Package.Readme)
+		}
+
+		for j := range source.Package.Modules {
+			module := &source.Package.Modules[j]
+			encoded, err := json.Marshal(module)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encoding module %s of %s: %w", module.Path, source.Name, err)
+			}
+
+			existing, ok := entries[module.Path]
+			if !ok {
+				order = append(order, module.Path)
+				entries[module.Path] = &entry{module: module, source: i, encoded: encoded}
+				continue
+			}
+			if bytes.Equal(existing.encoded, encoded) {
+				continue
+			}
+
+			if strategy == MergeError {
+				return nil, nil, fmt.Errorf("%w: module %s differs between %s and %s",
+					ErrMergeConflict, module.Path, sources[existing.source].Name, source.Name)
+			}
+
+			conflict, ok := conflicts[module.Path]
+			if !ok {
+				conflict = &MergeConflict{Path: module.Path, Sources: []string{sources[existing.source].Name}}
+				conflicts[module.Path] = conflict
+			}
+			conflict.Sources = append(conflict.Sources, source.Name)
+
+			if strategy == MergePreferLatestVersion {
+				current, candidate := sources[existing.source].Version, source.Version
+				if !semver.IsValid("v"+current) || !semver.IsValid("v"+candidate) {
+					return nil, nil, fmt.Errorf("%w: module %s differs between %s and %s, which lack comparable versions (%q, %q)",
+						ErrMergeConflict, module.Path, sources[existing.source].Name, source.Name, current, candidate)
+				}
+				if compareVersions(candidate, current) > 0 {
+					*existing = entry{module: module, source: i, encoded: encoded}
+				}
+			}
+			conflict.Kept = sources[existing.source].Name
+		}
+	}
+
+	merged.Modules = make([]Module, 0, len(order))
+	var resolved []MergeConflict
+	for _, path := range order {
+		merged.Modules = append(merged.Modules, *entries[path].module.Clone())
+		if conflict, ok := conflicts[path]; ok {
+			resolved = append(resolved, *conflict)
+		}
+	}
+	for i := range merged.Modules {
+		merged.Modules[i].Package = merged
+		setDeclarationBackreferences(&merged.Modules[i])
+	}
+	return merged, resolved, nil
+}
+
+// compareVersions compares two semver versions without the leading v
+func compareVersions(a, b string) int {
+	return semver.Compare(semver.Canonical("v"+a), semver.Canonical("v"+b))
+}
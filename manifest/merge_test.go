@@ -0,0 +1,119 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: table-driven over strategies, with tiny manifests whose only
+// difference is a summary
+
+func mergeTestPackage(t *testing.T, schemaVersion string, modules ...string) *M.Package {
+	t.Helper()
+	var pkg M.Package
+	data := `{"schemaVersion":"` + schemaVersion + `","modules":[`
+	for i, module := range modules {
+		if i > 0 {
+			data += ","
+		}
+		data += module
+	}
+	data += "]}"
+	require.NoError(t, json.Unmarshal([]byte(data), &pkg))
+	return &pkg
+}
+
+func mergeTestModule(path, summary string) string {
+	return `{"kind":"javascript-module","path":"` + path + `","declarations":[` +
+		`{"kind":"class","name":"El","customElement":true,"tagName":"x-el","summary":"` + summary + `"}]}`
+}
+
+func TestMergePackages(t *testing.T) {
+	sources := func(t *testing.T) []M.MergeSource {
+		return []M.MergeSource{
+			{Name: "a.json", Version: "1.0.0", Package: mergeTestPackage(t, "2.0.0",
+				mergeTestModule("shared.js", "old"),
+				mergeTestModule("a.js", "a"),
+			)},
+			{Name: "b.json", Version: "1.2.0", Package: mergeTestPackage(t, "2.1.0",
+				mergeTestModule("a.js", "a"),
+				mergeTestModule("shared.js", "new"),
+				mergeTestModule("b.js", "b"),
+			)},
+		}
+	}
+
+	summaryOf := func(pkg *M.Package, path string) string {
+		for i := range pkg.Modules {
+			if pkg.Modules[i].Path == path {
+				element, ok := pkg.Modules[i].Declarations[0].(*M.CustomElementDeclaration)
+				if ok {
+					return element.Summary
+				}
+			}
+		}
+		return ""
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, _, err := M.MergePackages(sources(t), M.MergeError)
+		require.ErrorIs(t, err, M.ErrMergeConflict)
+		assert.Contains(t, err.Error(), "module shared.js differs between a.json and b.json")
+	})
+
+	t.Run("prefer-first", func(t *testing.T) {
+		merged, conflicts, err := M.MergePackages(sources(t), M.MergePreferFirst)
+		require.NoError(t, err)
+		assert.Equal(t, "2.1.0", merged.SchemaVersion, "should use the highest schema version")
+		require.Len(t, merged.Modules, 3, "identical modules should merge")
+		assert.Equal(t, "shared.js", merged.Modules[0].Path)
+		assert.Equal(t, "b.js", merged.Modules[2].Path)
+		assert.Equal(t, "old", summaryOf(merged, "shared.js"))
+		assert.Same(t, merged, merged.Modules[0].Package)
+		assert.Equal(t, []M.MergeConflict{
+			{Path: "shared.js", Sources: []string{"a.json", "b.json"}, Kept: "a.json"},
+		}, conflicts)
+	})
+
+	t.Run("prefer-latest-version", func(t *testing.T) {
+		input := sources(t)
+		merged, conflicts, err := M.MergePackages(input, M.MergePreferLatestVersion)
+		require.NoError(t, err)
+		assert.Equal(t, "new", summaryOf(merged, "shared.js"))
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, "b.json", conflicts[0].Kept)
+		assert.Equal(t, "old", summaryOf(input[0].Package, "shared.js"), "should not modify sources")
+	})
+
+	t.Run("prefer-latest-version without versions", func(t *testing.T) {
+		input := sources(t)
+		input[1].Version = ""
+		_, _, err := M.MergePackages(input, M.MergePreferLatestVersion)
+		require.ErrorIs(t, err, M.ErrMergeConflict)
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, _, err := M.MergePackages(sources(t), "prefer-last")
+		assert.Error(t, err)
+	})
+}
@@ -36,11 +36,16 @@ type JavaScriptModule struct {
 	Declarations []Declaration `json:"declarations,omitempty"`
 	Exports      []Export      `json:"exports,omitempty"`
 	Deprecated   Deprecated    `json:"deprecated,omitempty"` // bool or string
-	Package      *Package      `json:"-"`                    // Backreference to containing package
+	// Types which component APIs refer to. The schema has no declaration kind
+	// for types, so they are written as a vendor extension.
+	Types   []*TypeDeclaration `json:"x-types,omitempty"`
+	Package *Package           `json:"-"` // Backreference to containing package
 
 	// Internal tracking for cross-module re-export resolution (not serialized)
 	ReExportAllSources []string `json:"-"` // module paths for `export * from`
 	SideEffectImports  []string `json:"-"` // module paths for `import './x.js'`
+	// Exported types, by name, which generate declares when component APIs refer to them
+	TypeDeclarations map[string]*TypeDeclaration `json:"-"`
 }
 
 func NewModule(file string) *Module {
@@ -69,6 +74,9 @@ func setDeclarationBackreferences(mod *JavaScriptModule) {
 			decl.Module = mod
 		}
 	}
+	for _, t := range mod.Types {
+		t.Module = mod
+	}
 }
 
 func (m *Module) UnmarshalJSON(data []byte) error {
@@ -169,6 +177,10 @@ func (m *JavaScriptModule) Clone() *JavaScriptModule {
 		cloned.Exports = []Export{} // Maintain consistency
 	}
 
+	for _, t := range m.Types {
+		cloned.Types = append(cloned.Types, t.Clone())
+	}
+
 	// Set backreferences from declarations to module
 	setDeclarationBackreferences(cloned)
 
@@ -220,6 +232,9 @@ func NewRenderableModule(
 			children = append(children, NewRenderableCustomElementMixinDeclaration(cemd, mod, pkg))
 		}
 	}
+	for _, td := range mod.Types {
+		children = append(children, NewRenderableTypeDeclaration(td, mod, pkg))
+	}
 	return &RenderableModule{
 		Path:                 mod.Path,
 		Module:               mod,
@@ -100,6 +100,9 @@ func (p *Package) Clone() *Package {
 				decl.Module = &cloned.Modules[i]
 			}
 		}
+		for _, t := range cloned.Modules[i].Types {
+			t.Module = &cloned.Modules[i]
+		}
 	}
 
 	return cloned
@@ -221,6 +224,9 @@ func (x *Package) UnmarshalJSON(data []byte) error {
 				decl.Module = &x.Modules[i]
 			}
 		}
+		for _, t := range x.Modules[i].Types {
+			t.Module = &x.Modules[i]
+		}
 	}
 
 	return nil
@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest
+
+import "slices"
+
+// PlatformOptions describe how an element uses the platform: the shadow
+// root it attaches, and whether it takes part in forms. Accessibility tools
+// use them to tell, for example, whether the element delegates focus.
+type PlatformOptions struct {
+	ShadowRoot *ShadowRootOptions `json:"shadowRoot,omitempty"`
+	// FormAssociated is true for elements with `static formAssociated = true`,
+	// which take part in forms like built-in form controls
+	FormAssociated bool `json:"formAssociated,omitempty"`
+	// Internals is true for elements which call `attachInternals()`, e.g. to
+	// set their form value or default ARIA semantics
+	Internals bool `json:"internals,omitempty"`
+	// Features are the notable platform APIs the element uses, whose browser
+	// support users may need to consider, e.g. "popover"
+	Features []string `json:"features,omitempty"`
+}
+
+// Platform features which an element may use
+const (
+	FeatureAnchorPositioning    = "anchor-positioning"
+	FeatureDeclarativeShadowDOM = "declarative-shadow-dom"
+	FeatureElementInternals     = "element-internals"
+	FeaturePopover              = "popover"
+)
+
+// platformFeatureSupport describes the browser support considerations of
+// each platform feature
+var platformFeatureSupport = map[string]string{
+	FeatureAnchorPositioning:    "CSS anchor positioning is not yet supported in every browser; consider a polyfill or a fallback position",
+	FeatureDeclarativeShadowDOM: "Declarative shadow DOM needs a polyfill in older browsers to hydrate server-rendered shadow roots",
+	FeatureElementInternals:     "ElementInternals' ARIA and form features need recent browsers, or a polyfill in older ones",
+	FeaturePopover:              "The popover API needs recent browsers, or a polyfill in older ones",
+}
+
+// PlatformFeature is a platform feature an element uses, with its browser
+// support considerations
+type PlatformFeature struct {
+	Name    string
+	Support string
+}
+
+// FeatureSupport returns the element's platform features, each with its
+// browser support considerations, if they are known
+func (p *PlatformOptions) FeatureSupport() []PlatformFeature {
+	if p == nil {
+		return nil
+	}
+	features := make([]PlatformFeature, 0, len(p.Features))
+	for _, name := range p.Features {
+		features = append(features, PlatformFeature{Name: name, Support: platformFeatureSupport[name]})
+	}
+	return features
+}
+
+// IsEmpty reports whether the options say nothing about the element
+func (p *PlatformOptions) IsEmpty() bool {
+	return p.ShadowRoot == nil && !p.FormAssociated && !p.Internals && len(p.Features) == 0
+}
+
+// ShadowRootOptions are the options an element attaches its shadow root
+// with, from an `attachShadow()` call or Lit's `static shadowRootOptions`
+type ShadowRootOptions struct {
+	Mode           string `json:"mode,omitempty"` // 'open' or 'closed'
+	DelegatesFocus bool   `json:"delegatesFocus,omitempty"`
+	SlotAssignment string `json:"slotAssignment,omitempty"` // 'named' or 'manual'
+	Clonable       bool   `json:"clonable,omitempty"`
+	Serializable   bool   `json:"serializable,omitempty"`
+}
+
+// Clone creates a deep copy of the PlatformOptions
+func (p *PlatformOptions) Clone() *PlatformOptions {
+	if p == nil {
+		return nil
+	}
+	cloned := *p
+	if p.ShadowRoot != nil {
+		shadowRoot := *p.ShadowRoot
+		cloned.ShadowRoot = &shadowRoot
+	}
+	cloned.Features = slices.Clone(p.Features)
+	return &cloned
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PublishStrip names something the publish profile removes from a manifest
+type PublishStrip string
+
+const (
+	// StripSource removes source links, which may point at private
+	// repositories or local paths
+	StripSource PublishStrip = "source"
+	// StripPrivate removes private class members
+	StripPrivate PublishStrip = "private"
+	// StripExtensions removes vendor extension fields, whose names begin
+	// with "x-"
+	StripExtensions PublishStrip = "extensions"
+)
+
+// PublishStrips lists everything the publish profile can strip. It is also
+// the default profile.
+var PublishStrips = []PublishStrip{StripSource, StripPrivate, StripExtensions}
+
+// StripForPublish returns a copy of the package without what strip names,
+// or without all of PublishStrips when strip is empty. It works on the
+// package's JSON form, so that it reaches every kind of declaration and
+// member. The package is not modified.
+func StripForPublish(pkg *Package, strip []PublishStrip) (*Package, error) {
+	if len(strip) == 0 {
+		strip = PublishStrips
+	}
+	for _, s := range strip {
+		if !slices.Contains(PublishStrips, s) {
+			return nil, fmt.Errorf("unknown publish strip %q", s)
+		}
+	}
+
+	data, err := json.Marshal(pkg)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	stripValue(tree, strip)
+	if data, err = json.Marshal(tree); err != nil {
+		return nil, err
+	}
+	return UnmarshalPackage(data)
+}
+
+// stripValue removes the stripped fields from a decoded JSON value, in place
+func stripValue(value any, strip []PublishStrip) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			switch {
+			case slices.Contains(strip, StripExtensions) && strings.HasPrefix(key, "x-"):
+				delete(v, key)
+				continue
+			case slices.Contains(strip, StripSource) && key == "source" && isSourceReference(child):
+				delete(v, key)
+				continue
+			case slices.Contains(strip, StripPrivate) && key == "members":
+				if members, ok := child.([]any); ok {
+					child = slices.DeleteFunc(members, isPrivateMember)
+					v[key] = child
+				}
+			}
+			stripValue(child, strip)
+		}
+	case []any:
+		for _, child := range v {
+			stripValue(child, strip)
+		}
+	}
+}
+
+func isSourceReference(value any) bool {
+	source, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = source["href"]
+	return ok
+}
+
+func isPrivateMember(value any) bool {
+	member, ok := value.(map[string]any)
+	return ok && member["privacy"] == string(Private)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	M "bennypowers.dev/cem/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPublishStripPackage(t *testing.T) *M.Package {
+	t.Helper()
+	fixtureFS := testutil.NewFixtureFS(t, "", "/")
+	data := testutil.ReadFixture(t, fixtureFS, "/publish-strip.json")
+	pkg, err := M.UnmarshalPackage(data)
+	require.NoError(t, err)
+	return pkg
+}
+
+func TestStripForPublish(t *testing.T) {
+	t.Run("default strips everything", func(t *testing.T) {
+		pkg := loadPublishStripPackage(t)
+		published, err := M.StripForPublish(pkg, nil)
+		require.NoError(t, err)
+
+		decl := published.FindCustomElementDeclaration("my-card")
+		require.NotNil(t, decl)
+		assert.Nil(t, decl.Source)
+		assert.Nil(t, decl.Platform)
+		assert.False(t, decl.Slots()[0].Pattern)
+		require.Len(t, decl.Members, 2, "private member removed")
+		field, ok := decl.Members[0].(*M.ClassField)
+		require.True(t, ok)
+		assert.Equal(t, "variant", field.Name)
+		assert.Nil(t, field.Source)
+
+		original := pkg.FindCustomElementDeclaration("my-card")
+		assert.NotNil(t, original.Source, "original package is not modified")
+		assert.Len(t, original.Members, 3)
+	})
+
+	t.Run("strips only what is named", func(t *testing.T) {
+		published, err := M.StripForPublish(loadPublishStripPackage(t), []M.PublishStrip{M.StripPrivate})
+		require.NoError(t, err)
+
+		decl := published.FindCustomElementDeclaration("my-card")
+		require.NotNil(t, decl)
+		assert.NotNil(t, decl.Source)
+		assert.NotNil(t, decl.Platform)
+		assert.Len(t, decl.Members, 2)
+	})
+
+	t.Run("unknown strip", func(t *testing.T) {
+		_, err := M.StripForPublish(loadPublishStripPackage(t), []M.PublishStrip{"comments"})
+		assert.Error(t, err)
+	})
+}
@@ -30,6 +30,13 @@ type Slot struct {
 	InheritedFrom *Reference `json:"inheritedFrom,omitempty"`
 	StartByte     uint       `json:"-" yaml:"-"`
 	Deprecated    Deprecated `json:"deprecated,omitempty"` // bool or string
+	// Pattern marks a name with wildcards, like "item-*", which documents a
+	// family of names. The schema has no field for it, so it is written as a
+	// vendor extension.
+	Pattern bool `json:"x-pattern,omitempty"`
+	// Required marks a slot which usages of the element must fill. Like
+	// Pattern, it is written as a vendor extension.
+	Required bool `json:"x-required,omitempty"`
 }
 
 func (x *Slot) IsDeprecated() bool {
@@ -39,6 +46,18 @@ func (x *Slot) IsDeprecated() bool {
 	return x.Deprecated != nil
 }
 
+// Matches reports whether the slot documents name, either as its own name or,
+// when it is a pattern, as one of the family of names the pattern describes.
+func (x *Slot) Matches(name string) bool {
+	if x == nil {
+		return false
+	}
+	if x.Pattern {
+		return MatchNamePattern(x.Name, name)
+	}
+	return x.Name == name
+}
+
 func (s *Slot) UnmarshalJSON(data []byte) error {
 	type Rest Slot
 	aux := &struct {
@@ -68,6 +87,8 @@ func (s *Slot) UnmarshalJSON(data []byte) error {
 func (s Slot) Clone() Slot {
 	cloned := Slot{
 		StartByte: s.StartByte,
+		Pattern:   s.Pattern,
+		Required:  s.Required,
 	}
 
 	// Clone the embedded FullyQualified
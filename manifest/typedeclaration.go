@@ -0,0 +1,161 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var _ Deprecatable = (*TypeDeclaration)(nil)
+var _ Renderable = (*RenderableTypeDeclaration)(nil)
+
+// TypeDeclaration documents a type alias, interface, or JSDoc @typedef which
+// component APIs refer to, so that tools can link type references to their
+// definitions. The Custom Elements Manifest schema has no declaration kind for
+// types, so modules list them apart from their declarations.
+type TypeDeclaration struct {
+	FullyQualified
+	StartByte  uint       `json:"-"`
+	Deprecated Deprecated `json:"deprecated,omitempty"` // bool or string
+	// Type is the definition, e.g. `'primary' | 'secondary'`, or for an
+	// interface, its body
+	Type   *Type            `json:"type,omitempty"`
+	Source *SourceReference `json:"source,omitempty"`
+}
+
+func (t *TypeDeclaration) Name() string {
+	if t == nil {
+		return ""
+	}
+	return t.FullyQualified.Name
+}
+
+// Clone creates a deep copy of the TypeDeclaration.
+func (t *TypeDeclaration) Clone() *TypeDeclaration {
+	if t == nil {
+		return nil
+	}
+
+	cloned := &TypeDeclaration{
+		FullyQualified: t.FullyQualified.Clone(),
+		StartByte:      t.StartByte,
+		Type:           t.Type.Clone(),
+	}
+
+	if t.Deprecated != nil {
+		cloned.Deprecated = t.Deprecated.Clone()
+	}
+
+	if t.Source != nil {
+		source := t.Source.Clone()
+		cloned.Source = &source
+	}
+
+	return cloned
+}
+
+func (x *TypeDeclaration) IsDeprecated() bool {
+	if x == nil {
+		return false
+	}
+	return x.Deprecated != nil
+}
+
+func (x *TypeDeclaration) GetStartByte() uint { return x.StartByte }
+
+func (t *TypeDeclaration) UnmarshalJSON(data []byte) error {
+	type Rest TypeDeclaration
+	aux := &struct {
+		Deprecated json.RawMessage `json:"deprecated"`
+		*Rest
+	}{
+		Rest: (*Rest)(t),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux.Deprecated) > 0 && string(aux.Deprecated) != "null" {
+		var dep Deprecated
+		if !decodeDeprecatedField(&dep, aux.Deprecated) {
+			return fmt.Errorf("invalid type for deprecated field")
+		}
+		t.Deprecated = dep
+	}
+	return nil
+}
+
+type RenderableTypeDeclaration struct {
+	TypeDeclaration *TypeDeclaration
+	Module          *Module
+	Package         *Package
+}
+
+func NewRenderableTypeDeclaration(
+	td *TypeDeclaration,
+	mod *Module,
+	pkg *Package,
+) *RenderableTypeDeclaration {
+	return &RenderableTypeDeclaration{
+		TypeDeclaration: td,
+		Module:          mod,
+		Package:         pkg,
+	}
+}
+
+func (x *RenderableTypeDeclaration) Name() string {
+	if x == nil || x.TypeDeclaration == nil {
+		return ""
+	}
+	return x.TypeDeclaration.Name()
+}
+
+func (x *RenderableTypeDeclaration) Label() string {
+	return kindStyle.Render("type") + " " + highlightIfDeprecated(x)
+}
+
+func (x *RenderableTypeDeclaration) IsDeprecated() bool {
+	return x.TypeDeclaration.IsDeprecated()
+}
+
+func (x *RenderableTypeDeclaration) Deprecation() Deprecated {
+	return x.TypeDeclaration.Deprecated
+}
+
+func (x *RenderableTypeDeclaration) Children() []Renderable {
+	return nil // it's a leaf node
+}
+
+func (x *RenderableTypeDeclaration) ColumnHeadings() []string {
+	return []string{"Name", "Type", "Summary"}
+}
+
+func (x *RenderableTypeDeclaration) ToTableRow() []string {
+	typeText := ""
+	if x.TypeDeclaration.Type != nil {
+		typeText = x.TypeDeclaration.Type.Text
+	}
+	return []string{
+		x.TypeDeclaration.Name(),
+		typeText,
+		x.TypeDeclaration.Summary,
+	}
+}
+
+func (x *RenderableTypeDeclaration) ToTreeNode(p PredicateFunc) TreeNode {
+	return tn("type", x.Label())
+}
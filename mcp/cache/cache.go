@@ -0,0 +1,145 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cache remembers rendered MCP tool responses, so that repeated
+// identical queries return instantly and consistently until the manifests
+// they were rendered from change.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultMaxEntries caps the responses a cache holds when none is given
+const DefaultMaxEntries = 256
+
+// ResponseCache caches tool responses by tool name, arguments, and the
+// content hash of the loaded manifests. When the hash changes, e.g.
+// because manifests were regenerated, every cached response is dropped.
+type ResponseCache struct {
+	mu         sync.Mutex
+	hash       func() string
+	maxEntries int
+	manifest   string // The manifest hash the cached responses were rendered from
+	entries    map[string]*mcp.CallToolResult
+	order      []string // Keys in insertion order, oldest first
+}
+
+// NewResponseCache creates a cache which invalidates its responses whenever
+// hash returns a different manifest content hash. A maxEntries of zero
+// means DefaultMaxEntries.
+func NewResponseCache(hash func() string, maxEntries int) *ResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &ResponseCache{
+		hash:       hash,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*mcp.CallToolResult),
+	}
+}
+
+// Wrap returns a tool handler which serves repeated calls to handler with
+// the same arguments from the cache. Errors and error results are never
+// cached, so failed calls are retried.
+func (c *ResponseCache) Wrap(toolName string, handler mcp.ToolHandler) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, ok := requestKey(toolName, req)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		manifest := c.hash()
+		if result, hit := c.get(manifest, key); hit {
+			helpers.SafeDebugLog("[MCP] Serving cached %s response", toolName)
+			return result, nil
+		}
+
+		result, err := handler(ctx, req)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		c.put(manifest, key, result)
+		return result, nil
+	}
+}
+
+// Len returns the number of cached responses
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// get returns the cached response for key, first dropping every response
+// if the manifests changed since they were cached
+func (c *ResponseCache) get(manifest, key string) (*mcp.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if manifest != c.manifest {
+		if len(c.entries) > 0 {
+			helpers.SafeDebugLog("[MCP] Manifests changed, dropping %d cached tool responses", len(c.entries))
+		}
+		c.manifest = manifest
+		c.entries = make(map[string]*mcp.CallToolResult)
+		c.order = nil
+	}
+	result, ok := c.entries[key]
+	return result, ok
+}
+
+// put caches a response rendered from the given manifests, evicting the
+// oldest responses when the cache is full
+func (c *ResponseCache) put(manifest, key string, result *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The manifests changed while the tool ran, so the response may be stale
+	if manifest != c.manifest {
+		return
+	}
+	if _, exists := c.entries[key]; !exists {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = result
+	for len(c.order) > c.maxEntries {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// requestKey identifies a tool call by its tool name and arguments.
+// Arguments are re-encoded so that key order and whitespace don't matter.
+// It reports false for arguments which aren't valid JSON.
+func requestKey(toolName string, req *mcp.CallToolRequest) (string, bool) {
+	if req == nil || req.Params == nil || len(req.Params.Arguments) == 0 {
+		return toolName, true
+	}
+	var args any
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return "", false
+	}
+	canonical, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return toolName + "\x00" + string(canonical), true
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// countingHandler returns a handler which renders its call count, so that
+// cached responses can be told apart from fresh ones
+func countingHandler(calls *int, isError bool) mcp.ToolHandler {
+	return func(_ context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		*calls++
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("call %d", *calls)}},
+			IsError: isError,
+		}, nil
+	}
+}
+
+func call(t *testing.T, handler mcp.ToolHandler, args string) string {
+	t.Helper()
+	result, err := handler(context.Background(), &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "tool", Arguments: json.RawMessage(args)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result.Content[0].(*mcp.TextContent).Text
+}
+
+func TestResponseCache_Wrap(t *testing.T) {
+	hash := "a"
+	calls := 0
+	c := NewResponseCache(func() string { return hash }, 0)
+	wrapped := c.Wrap("tool", countingHandler(&calls, false))
+
+	if got := call(t, wrapped, `{"tagName":"my-button","depth":1}`); got != "call 1" {
+		t.Errorf("expected first call to render, got %q", got)
+	}
+	// Key order and whitespace don't change the arguments
+	if got := call(t, wrapped, `{ "depth": 1, "tagName": "my-button" }`); got != "call 1" {
+		t.Errorf("expected identical call to be cached, got %q", got)
+	}
+	if got := call(t, wrapped, `{"tagName":"my-card"}`); got != "call 2" {
+		t.Errorf("expected different arguments to render, got %q", got)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 cached responses, got %d", c.Len())
+	}
+
+	// Regenerating the manifests invalidates every response
+	hash = "b"
+	if got := call(t, wrapped, `{"tagName":"my-button","depth":1}`); got != "call 3" {
+		t.Errorf("expected call after manifest change to render, got %q", got)
+	}
+	if c.Len() != 1 {
+		t.Errorf("expected stale responses to be dropped, got %d", c.Len())
+	}
+}
+
+func TestResponseCache_SeparatesTools(t *testing.T) {
+	calls := 0
+	c := NewResponseCache(func() string { return "a" }, 0)
+	handler := countingHandler(&calls, false)
+	first := c.Wrap("first", handler)
+	second := c.Wrap("second", handler)
+
+	call(t, first, `{}`)
+	if got := call(t, second, `{}`); got != "call 2" {
+		t.Errorf("expected other tool to render, got %q", got)
+	}
+}
+
+func TestResponseCache_SkipsErrors(t *testing.T) {
+	calls := 0
+	c := NewResponseCache(func() string { return "a" }, 0)
+	wrapped := c.Wrap("tool", countingHandler(&calls, true))
+
+	call(t, wrapped, `{}`)
+	if got := call(t, wrapped, `{}`); got != "call 2" {
+		t.Errorf("expected error result to be retried, got %q", got)
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected no cached responses, got %d", c.Len())
+	}
+}
+
+func TestResponseCache_EvictsOldest(t *testing.T) {
+	calls := 0
+	c := NewResponseCache(func() string { return "a" }, 2)
+	wrapped := c.Wrap("tool", countingHandler(&calls, false))
+
+	call(t, wrapped, `{"n":1}`)
+	call(t, wrapped, `{"n":2}`)
+	call(t, wrapped, `{"n":3}`)
+	if c.Len() != 2 {
+		t.Errorf("expected 2 cached responses, got %d", c.Len())
+	}
+	if got := call(t, wrapped, `{"n":1}`); got != "call 4" {
+		t.Errorf("expected oldest response to be evicted, got %q", got)
+	}
+	if got := call(t, wrapped, `{"n":3}`); got != "call 3" {
+		t.Errorf("expected newest response to be cached, got %q", got)
+	}
+}
@@ -17,6 +17,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package mcp
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -37,6 +39,9 @@ import (
 	V "bennypowers.dev/cem/validate"
 )
 
+// maxElementSuggestions caps the similar elements suggested for an unknown tag name
+const maxElementSuggestions = 3
+
 // MCPContext manages custom elements manifests for MCP context
 // This is a lightweight wrapper around the LSP registry for reuse
 type MCPContext struct {
@@ -44,8 +49,11 @@ type MCPContext struct {
 	lspRegistry          *LSP.Registry
 	documentManager      lspTypes.DocumentManager
 	fs                   platform.FileSystem
-	mcpCache             map[string]MCPTypes.ElementInfo // Cache for converted MCP elements
-	relationshipDetector *relationships.Detector         // Detects relationships between elements
+	mcpCache             map[string]MCPTypes.ElementInfo        // Cache for converted MCP elements
+	relationshipDetector *relationships.Detector                // Detects relationships between elements
+	declarations         map[string]*M.CustomElementDeclaration // Full manifest declarations by tag name
+	manifestErr          error                                  // Why the most recent manifest reload failed
+	manifestHash         string                                 // Content hash of the loaded manifests, computed lazily
 
 	// Lazy-computed cached values for performance
 	commonPrefixes     []string // Common element tag name prefixes
@@ -103,11 +111,13 @@ func NewMCPContext(workspace types.WorkspaceContext, fsys platform.FileSystem) (
 	}
 	helpers.SafeDebugLog("Creating MCP registry for workspace: %s", workspace.Root())
 
-	// Create the underlying LSP registry for reuse
-	lspRegistry, err := LSP.NewRegistryWithDefaults()
+	// Create the underlying LSP registry for reuse. It reads manifests
+	// through fsys, so that it is subject to the same restrictions as tools.
+	fileWatcher, err := platform.NewFSNotifyFileWatcher()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LSP registry: %w", err)
 	}
+	lspRegistry := LSP.NewRegistry(fileWatcher, fsys)
 
 	// Create a shared document manager for validation
 	documentManager, err := document.NewDocumentManager()
@@ -138,6 +148,7 @@ func (ctx *MCPContext) LoadManifests() error {
 
 	// Invalidate computed cache
 	ctx.computedCacheValid = false
+	ctx.manifestHash = ""
 	ctx.commonPrefixes = nil
 	ctx.allCSSProperties = nil
 
@@ -145,8 +156,10 @@ func (ctx *MCPContext) LoadManifests() error {
 	ctx.relationshipDetector = relationships.NewDetector()
 
 	if err := ctx.lspRegistry.LoadFromWorkspace(ctx.workspace); err != nil {
-		return fmt.Errorf("failed to load manifests from workspace %q: %w", ctx.workspace.Root(), err)
+		ctx.manifestErr = fmt.Errorf("failed to load manifests from workspace %q: %w", ctx.workspace.Root(), err)
+		return ctx.manifestErr
 	}
+	ctx.manifestErr = nil
 
 	if cfgFile := ctx.workspace.ConfigFile(); cfgFile != "" {
 		ctx.lspRegistry.AddWatchPath(cfgFile)
@@ -207,6 +220,8 @@ func (ctx *MCPContext) buildRelationshipDetector() {
 
 		ctx.relationshipDetector.AddElement(data)
 	}
+
+	ctx.declarations = declByTag
 }
 
 // RelationshipsFor returns relationships for the given element.
@@ -224,6 +239,37 @@ func (ctx *MCPContext) RelationshipsFor(tagName string) []relationships.Relation
 	return rels
 }
 
+// ManifestError reports why the most recent manifest reload failed, or nil
+// if it succeeded
+func (ctx *MCPContext) ManifestError() error {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	return ctx.manifestErr
+}
+
+// Packages reports where each loaded package came from, including packages
+// whose manifests failed to load
+func (ctx *MCPContext) Packages() []lspTypes.PackageInfo {
+	return ctx.lspRegistry.Packages()
+}
+
+// ManifestHash returns a content hash of the loaded manifests, which
+// changes whenever they are reloaded with different content
+func (ctx *MCPContext) ManifestHash() string {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.manifestHash == "" {
+		hash := sha256.New()
+		for _, pkg := range ctx.lspRegistry.Manifests {
+			if err := json.NewEncoder(hash).Encode(pkg); err != nil {
+				helpers.SafeDebugLog("Warning: Failed to hash manifest: %v", err)
+			}
+		}
+		ctx.manifestHash = hex.EncodeToString(hash.Sum(nil))
+	}
+	return ctx.manifestHash
+}
+
 // CommonPrefixes returns common element tag name prefixes (lazy-computed and cached)
 func (ctx *MCPContext) CommonPrefixes() []string {
 	ctx.mu.RLock()
@@ -287,7 +333,7 @@ func (ctx *MCPContext) takeRegistrySnapshot() (*registrySnapshot, error) {
 		element := ctx.lspRegistry.Elements[tagName]
 		if element != nil {
 			// Convert element using the existing conversion logic
-			info := ctx.convertElement(element, tagName)
+			info := ctx.convertElement(element, ctx.declarations[tagName], tagName)
 			elements[tagName] = info
 		}
 	}
@@ -399,6 +445,15 @@ func (ctx *MCPContext) FileSystem() platform.FileSystem {
 	return ctx.fs
 }
 
+// ElementModule returns the path of the manifest module which defines the
+// element, or "" when no manifest names one
+func (ctx *MCPContext) ElementModule(tagName string) string {
+	if definition, ok := ctx.lspRegistry.ElementDefinition(tagName); ok {
+		return definition.ModulePath()
+	}
+	return ""
+}
+
 func (ctx *MCPContext) InvalidateConfig() {
 	type invalidatable interface {
 		InvalidateConfig()
@@ -421,13 +476,15 @@ func (ctx *MCPContext) GetElementInfo(tagName string) (MCPTypes.ElementInfo, err
 	// Get basic element from LSP registry
 	element := ctx.lspRegistry.Elements[tagName]
 	if element == nil {
+		registry := ctx.lspRegistry
 		ctx.mu.RUnlock()
-		return nil, fmt.Errorf("failed to get element info for %q: element not found in registry", tagName)
+		return nil, elementNotFoundError(registry, tagName)
 	}
+	decl := ctx.declarations[tagName]
 	ctx.mu.RUnlock()
 
 	// Convert to enhanced MCP format (outside of read lock to avoid blocking)
-	info := ctx.convertElement(element, tagName)
+	info := ctx.convertElement(element, decl, tagName)
 
 	// Cache the result
 	ctx.mu.Lock()
@@ -437,6 +494,21 @@ func (ctx *MCPContext) GetElementInfo(tagName string) (MCPTypes.ElementInfo, err
 	return info, nil
 }
 
+// elementNotFoundError reports an unknown tag name, suggesting the elements
+// whose names are closest to it, since agents often misremember tag names
+func elementNotFoundError(registry *LSP.Registry, tagName string) error {
+	err := fmt.Errorf("failed to get element info for %q: element not found in registry", tagName)
+	matches := registry.FindElements(tagName, maxElementSuggestions)
+	if len(matches) == 0 {
+		return err
+	}
+	suggestions := make([]string, len(matches))
+	for i, match := range matches {
+		suggestions[i] = "<" + match.TagName + ">"
+	}
+	return fmt.Errorf("%w; did you mean %s?", err, strings.Join(suggestions, ", "))
+}
+
 // GetAllElements returns all available elements
 func (ctx *MCPContext) GetAllElements() map[string]MCPTypes.ElementInfo {
 	ctx.mu.RLock()
@@ -553,12 +625,13 @@ func (ctx *MCPContext) selectBestSchemaVersion(versions []string) string {
 // Helper methods for converting LSP types to MCP types
 
 // convertElement converts a manifest element to enhanced MCP format using the new constructor
-func (ctx *MCPContext) convertElement(element *M.CustomElement, tagName string) MCPTypes.ElementInfo {
+func (ctx *MCPContext) convertElement(element *M.CustomElement, decl *M.CustomElementDeclaration, tagName string) MCPTypes.ElementInfo {
 	// Use the proper constructor to create the MCP element declaration
 	mcpElement := NewMCPCustomElementDeclaration(element, tagName)
 
 	return &MCPElementInfoAdapter{
 		MCPCustomElementDeclaration: mcpElement,
+		declaration:                 decl,
 		relationshipsFunc:           ctx.RelationshipsFor,
 	}
 }
@@ -641,15 +714,25 @@ func (ctx *MCPContextAdapter) FileSystem() platform.FileSystem {
 	return ctx.MCPContext.FileSystem()
 }
 
+func (ctx *MCPContextAdapter) ElementModule(tagName string) string {
+	return ctx.MCPContext.ElementModule(tagName)
+}
+
 // ElementInfoAdapter implements MCPTypes.ElementInfo interface
 // MCPElementInfoAdapter implements MCPTypes.ElementInfo interface for MCP-specific behavior
 type MCPElementInfoAdapter struct {
 	*MCPCustomElementDeclaration
+	// declaration is the element's full manifest declaration, with its class
+	// members and deprecation, when the manifest has one
+	declaration       *M.CustomElementDeclaration
 	relationshipsFunc func(tagName string) []relationships.Relationship
 }
 
 // Declaration returns the underlying manifest declaration
 func (e *MCPElementInfoAdapter) Declaration() *M.CustomElementDeclaration {
+	if e.declaration != nil {
+		return e.declaration
+	}
 	if e.CustomElementDeclaration != nil {
 		return e.CustomElementDeclaration
 	}
@@ -702,49 +785,56 @@ func (e *MCPElementInfoAdapter) Package() string {
 	return e.PackageName
 }
 
-// Member accessors returning manifest types directly
+// Member accessors returning manifest types directly, from the registry's element
 func (e *MCPElementInfoAdapter) Attributes() []M.Attribute {
-	if decl := e.Declaration(); decl != nil {
+	if decl := e.CustomElementDeclaration; decl != nil {
 		return decl.Attributes()
 	}
 	return nil
 }
 
 func (e *MCPElementInfoAdapter) Slots() []M.Slot {
-	if decl := e.Declaration(); decl != nil {
+	if decl := e.CustomElementDeclaration; decl != nil {
 		return decl.Slots()
 	}
 	return nil
 }
 
 func (e *MCPElementInfoAdapter) Events() []M.Event {
-	if decl := e.Declaration(); decl != nil {
+	if decl := e.CustomElementDeclaration; decl != nil {
 		return decl.Events()
 	}
 	return nil
 }
 
 func (e *MCPElementInfoAdapter) CssProperties() []M.CssCustomProperty {
-	if decl := e.Declaration(); decl != nil {
+	if decl := e.CustomElementDeclaration; decl != nil {
 		return decl.CssProperties()
 	}
 	return nil
 }
 
 func (e *MCPElementInfoAdapter) CssParts() []M.CssPart {
-	if decl := e.Declaration(); decl != nil {
+	if decl := e.CustomElementDeclaration; decl != nil {
 		return decl.CssParts()
 	}
 	return nil
 }
 
 func (e *MCPElementInfoAdapter) CssStates() []M.CssCustomState {
-	if decl := e.Declaration(); decl != nil {
+	if decl := e.CustomElementDeclaration; decl != nil {
 		return decl.CssStates()
 	}
 	return nil
 }
 
+func (e *MCPElementInfoAdapter) AttributeReflects(name string) bool {
+	if decl := e.Declaration(); decl != nil {
+		return decl.AttributeReflects(name)
+	}
+	return false
+}
+
 // MCP-specific behavior
 func (e *MCPElementInfoAdapter) Guidelines() []string {
 	return e.MCPCustomElementDeclaration.Guidelines
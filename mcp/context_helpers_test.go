@@ -106,6 +106,7 @@ func (m *mockElementInfo) Slots() []M.Slot                             { return
 func (m *mockElementInfo) CssParts() []M.CssPart                      { return nil }
 func (m *mockElementInfo) CssProperties() []M.CssCustomProperty        { return m.cssProperties }
 func (m *mockElementInfo) CssStates() []M.CssCustomState               { return nil }
+func (m *mockElementInfo) AttributeReflects(string) bool               { return false }
 func (m *mockElementInfo) Guidelines() []string                        { return nil }
 func (m *mockElementInfo) Examples() []MCPTypes.Example                { return nil }
 func (m *mockElementInfo) Relationships() []relationships.Relationship { return nil }
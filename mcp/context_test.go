@@ -207,6 +207,22 @@ func TestMCPContext_GetElementInfo(t *testing.T) {
 	}
 }
 
+func TestMCPContext_GetElementInfoSuggestions(t *testing.T) {
+	workspace := testworkspace.NewMapWorkspaceContext(t, "./testdata/fixtures/multiple-elements-integration")
+	require.NoError(t, workspace.Init())
+
+	registry, err := mcp.NewMCPContext(workspace, workspace.FileSystem())
+	require.NoError(t, err, "Failed to create registry")
+	require.NoError(t, registry.LoadManifests())
+
+	_, err = registry.GetElementInfo("buton-element")
+	assert.ErrorContains(t, err, "did you mean <button-element>")
+
+	_, err = registry.GetElementInfo("non-existent")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "did you mean")
+}
+
 func TestMCPContext_GetAllElements(t *testing.T) {
 	workspace := testworkspace.NewMapWorkspaceContext(t, "./testdata/fixtures/multiple-elements-integration")
 	err := workspace.Init()
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/resources"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline assertions justified: testing which elements a category selects,
+// not the full summary content, which the elements golden covers.
+
+type categorySummary struct {
+	Elements []struct {
+		TagName    string   `json:"tagName"`
+		Categories []string `json:"categories"`
+	} `json:"elements"`
+}
+
+func readCategoryResource(t *testing.T, uri string) categorySummary {
+	t.Helper()
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/element-categories")
+	require.NoError(t, workspace.Init())
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	defs, err := resources.Resources(mcp.NewMCPContextAdapter(registry))
+	require.NoError(t, err)
+	res := findResource(t, defs, "elements-category")
+	require.True(t, res.URITemplate)
+
+	result, err := res.Handler(context.Background(), &mcpSDK.ReadResourceRequest{
+		Params: &mcpSDK.ReadResourceParams{URI: uri},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+
+	var summary categorySummary
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &summary))
+	return summary
+}
+
+func TestElementsCategoryResource(t *testing.T) {
+	tests := []struct {
+		category string
+		want     []string
+	}{
+		{"form", []string{"my-input", "my-alert"}},
+		{"feedback", []string{"my-alert"}},
+		{"navigation", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.category, func(t *testing.T) {
+			summary := readCategoryResource(t, "cem://elements/category/"+tc.category)
+			var got []string
+			for _, e := range summary.Elements {
+				got = append(got, e.TagName)
+				assert.Contains(t, e.Categories, tc.category)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
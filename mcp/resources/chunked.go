@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ChunkedURITemplate is the URI template which reads a resource in chunks,
+// e.g. cem://elements?offset=0&limit=65536
+func ChunkedURITemplate(uri string) string {
+	return uri + "{?offset,limit}"
+}
+
+// Chunked wraps a resource handler for clients with message size limits.
+// When the resource URI has offset and limit query parameters, the read
+// returns at most limit bytes of the resource's text, starting at offset.
+// Chunks end at a line break when one falls within the limit, and never
+// split a UTF-8 sequence. Each chunk's _meta reports its offset and length,
+// the total length of the text, and, unless it is the last chunk, the
+// nextOffset to read from. Resources render deterministically, so reading
+// from offset 0 until there is no nextOffset yields the complete resource.
+func Chunked(handler mcp.ResourceHandler) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		base, query, found := strings.Cut(req.Params.URI, "?")
+		if !found {
+			return handler(ctx, req)
+		}
+		offset, limit, err := parseChunkQuery(query)
+		if err != nil {
+			return nil, err
+		}
+
+		inner := *req
+		params := *req.Params
+		params.URI = base
+		inner.Params = &params
+		result, err := handler(ctx, &inner)
+		if err != nil || result == nil {
+			return result, err
+		}
+
+		for _, content := range result.Contents {
+			text := content.Text
+			if offset > len(text) || (offset < len(text) && !utf8.RuneStart(text[offset])) {
+				return nil, fmt.Errorf("offset %d is not a chunk boundary of %s (%d bytes)", offset, base, len(text))
+			}
+			end := chunkEnd(text, offset, limit)
+			meta := mcp.Meta{
+				"offset": offset,
+				"length": end - offset,
+				"total":  len(text),
+			}
+			if end < len(text) {
+				meta["nextOffset"] = end
+			}
+			content.URI = req.Params.URI
+			content.Text = text[offset:end]
+			content.Meta = meta
+		}
+		return result, nil
+	}
+}
+
+// parseChunkQuery reads the offset and limit query parameters. A missing
+// offset reads from the start, and a missing limit reads to the end.
+func parseChunkQuery(query string) (offset, limit int, err error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid resource query %q: %w", query, err)
+	}
+	for name, value := range map[string]*int{"offset": &offset, "limit": &limit} {
+		raw := values.Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid %s %q: must be a non-negative integer", name, raw)
+		}
+		*value = n
+	}
+	return offset, limit, nil
+}
+
+// chunkEnd finds the end of the chunk of at most limit bytes from offset,
+// preferring the last line break within the limit. A limit smaller than
+// the first character still takes that character, so reads progress.
+func chunkEnd(text string, offset, limit int) int {
+	if limit == 0 || offset+limit >= len(text) {
+		return len(text)
+	}
+	end := offset + limit
+	if i := strings.LastIndexByte(text[offset:end], '\n'); i >= 0 {
+		return offset + i + 1
+	}
+	for end > offset && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	if end == offset {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		end = offset + size
+	}
+	return end
+}
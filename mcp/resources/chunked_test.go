@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package resources_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"bennypowers.dev/cem/mcp/resources"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: chunk boundaries are computed from a generated text
+
+func staticHandler(text string) (mcpSDK.ResourceHandler, *string) {
+	var readURI string
+	return func(_ context.Context, req *mcpSDK.ReadResourceRequest) (*mcpSDK.ReadResourceResult, error) {
+		readURI = req.Params.URI
+		return &mcpSDK.ReadResourceResult{
+			Contents: []*mcpSDK.ResourceContents{{URI: req.Params.URI, Text: text}},
+		}, nil
+	}, &readURI
+}
+
+func readChunk(t *testing.T, handler mcpSDK.ResourceHandler, uri string) *mcpSDK.ResourceContents {
+	t.Helper()
+	result, err := handler(context.Background(), &mcpSDK.ReadResourceRequest{
+		Params: &mcpSDK.ReadResourceParams{URI: uri},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	return result.Contents[0]
+}
+
+func TestChunked_ReadsCompleteResource(t *testing.T) {
+	var b strings.Builder
+	for i := range 40 {
+		b.WriteString(strings.Repeat("é", i%7))
+		b.WriteString(strings.Repeat("x", i))
+		b.WriteByte('\n')
+	}
+	b.WriteString(strings.Repeat("ü", 50))
+	text := b.String()
+
+	handler, readURI := staticHandler(text)
+	chunked := resources.Chunked(handler)
+
+	var got strings.Builder
+	offset := 0
+	for reads := 0; ; reads++ {
+		require.Less(t, reads, len(text), "reads should progress")
+		uri := "cem://elements?offset=" + strconv.Itoa(offset) + "&limit=32"
+		content := readChunk(t, chunked, uri)
+		assert.Equal(t, "cem://elements", *readURI, "the wrapped handler reads the resource without the query")
+		assert.Equal(t, uri, content.URI)
+		assert.LessOrEqual(t, len(content.Text), 32)
+		assert.True(t, utf8.ValidString(content.Text), "chunks don't split characters")
+		assert.Equal(t, offset, content.Meta["offset"])
+		assert.Equal(t, len(text), content.Meta["total"])
+		got.WriteString(content.Text)
+
+		next, ok := content.Meta["nextOffset"]
+		if !ok {
+			break
+		}
+		offset = next.(int)
+		if strings.Contains(text[offset-len(content.Text):offset], "\n") {
+			assert.True(t, strings.HasSuffix(content.Text, "\n"), "chunks end at line breaks: %q", content.Text)
+		}
+	}
+	assert.Equal(t, text, got.String())
+}
+
+func TestChunked_WithoutQuery(t *testing.T) {
+	handler, _ := staticHandler("whole resource")
+	content := readChunk(t, resources.Chunked(handler), "cem://elements")
+	assert.Equal(t, "whole resource", content.Text)
+	assert.Nil(t, content.Meta)
+}
+
+func TestChunked_TinyLimitProgresses(t *testing.T) {
+	handler, _ := staticHandler("éa")
+	content := readChunk(t, resources.Chunked(handler), "cem://elements?limit=1")
+	assert.Equal(t, "é", content.Text)
+	assert.Equal(t, 2, content.Meta["nextOffset"])
+}
+
+func TestChunked_InvalidQuery(t *testing.T) {
+	handler, _ := staticHandler("éa")
+	chunked := resources.Chunked(handler)
+	for _, uri := range []string{
+		"cem://elements?offset=-1",
+		"cem://elements?limit=lots",
+		"cem://elements?offset=1",
+		"cem://elements?offset=9",
+	} {
+		_, err := chunked(context.Background(), &mcpSDK.ReadResourceRequest{
+			Params: &mcpSDK.ReadResourceParams{URI: uri},
+		})
+		assert.Error(t, err, uri)
+	}
+}
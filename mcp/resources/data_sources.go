@@ -94,7 +94,7 @@ func (p *DataSourceProvider) createRegistryDataSource() (map[string]any, error)
 			"name":          element.Name(),
 			"description":   element.Description(),
 			"module":        element.Module(),
-			"attributes":    convertAttributes(element.Attributes()),
+			"attributes":    convertAttributes(element),
 			"slots":         convertSlots(element.Slots()),
 			"events":        convertEvents(element.Events()),
 			"cssProperties": convertCssProperties(element.CssProperties()),
@@ -200,6 +200,9 @@ func (p *DataSourceProvider) createElementsSummary(allElements map[string]types.
 			"cssStateCount":    cssStateCount,
 			"capabilities":     capabilities,
 		}
+		if categories := elementCategories(element); len(categories) > 0 {
+			elementSummary["categories"] = categories
+		}
 
 		elements = append(elements, elementSummary)
 	}
@@ -232,6 +235,15 @@ func (p *DataSourceProvider) createElementsSummary(allElements map[string]types.
 	return summary, metadata
 }
 
+// elementCategories returns the design system categories of the element,
+// from its manifest declaration
+func elementCategories(element types.ElementInfo) []string {
+	if decl := element.Declaration(); decl != nil {
+		return decl.Categories
+	}
+	return nil
+}
+
 // createSchemaDataSource creates a schema data source with version detection
 func (p *DataSourceProvider) createSchemaDataSource() (map[string]any, error) {
 	versions := p.registry.GetManifestSchemaVersions()
@@ -334,7 +346,8 @@ func (p *DataSourceProvider) buildPackageStructure(elementMap map[string]types.E
 
 // Helper functions to convert types to map[string]any for JSON path traversal
 
-func convertAttributes(attrs []manifest.Attribute) []map[string]any {
+func convertAttributes(element types.ElementInfo) []map[string]any {
+	attrs := element.Attributes()
 	result := make([]map[string]any, len(attrs))
 	for i, attr := range attrs {
 		result[i] = map[string]any{
@@ -344,6 +357,7 @@ func convertAttributes(attrs []manifest.Attribute) []map[string]any {
 			"default":     attr.Default,
 			"fieldName":   attr.FieldName,
 			"deprecated":  attr.IsDeprecated(),
+			"reflects":    element.AttributeReflects(attr.Name),
 		}
 	}
 	return result
@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/resources"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementResource_PlatformFeatures(t *testing.T) {
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/platform-features")
+	require.NoError(t, workspace.Init())
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	defs, err := resources.Resources(mcp.NewMCPContextAdapter(registry))
+	require.NoError(t, err)
+	res := findResource(t, defs, "element")
+
+	result, err := res.Handler(context.Background(), &mcpSDK.ReadResourceRequest{
+		Params: &mcpSDK.ReadResourceParams{URI: "cem://element/menu-button"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	text := result.Contents[0].Text
+
+	assert.Contains(t, text, "## Platform Behavior")
+	assert.Contains(t, text, "**Browser support considerations:**")
+	assert.Contains(t, text, "• `anchor-positioning` — CSS anchor positioning is not yet supported in every browser")
+	assert.Contains(t, text, "• `element-internals` — ")
+	assert.Contains(t, text, "• `popover` — The popover API needs recent browsers")
+}
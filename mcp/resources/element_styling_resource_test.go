@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package resources_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/resources"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementCssCustomPropertiesResource_Groups(t *testing.T) {
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/css-property-groups")
+	require.NoError(t, workspace.Init())
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	defs, err := resources.Resources(mcp.NewMCPContextAdapter(registry))
+	require.NoError(t, err)
+	res := findResource(t, defs, "element-css-custom-properties")
+
+	result, err := res.Handler(context.Background(), &mcpSDK.ReadResourceRequest{
+		Params: &mcpSDK.ReadResourceParams{URI: "cem://element/themed-card/css/custom-properties"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	text := result.Contents[0].Text
+
+	assert.Contains(t, text, "# Styling Reference: `themed-card`")
+
+	// Ungrouped properties come first, then groups in order of appearance
+	gap := strings.Index(text, "`--themed-card-gap`")
+	surface := strings.Index(text, "### Surface")
+	background := strings.Index(text, "`--themed-card-background`")
+	border := strings.Index(text, "`--themed-card-border-color`")
+	typography := strings.Index(text, "### Typography")
+	font := strings.Index(text, "`--themed-card-heading-font`")
+	for _, i := range []int{gap, surface, background, border, typography, font} {
+		require.NotEqual(t, -1, i, text)
+	}
+	assert.Less(t, gap, surface)
+	assert.Less(t, surface, background)
+	assert.Less(t, background, border)
+	assert.Less(t, border, typography)
+	assert.Less(t, typography, font)
+}
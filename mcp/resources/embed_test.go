@@ -49,8 +49,8 @@ func TestResourceDefinitions_Embedded(t *testing.T) {
 	resourceDefs, err := resources.Resources(adapter)
 	require.NoError(t, err, "Resources() should succeed with embedded definitions")
 
-	// Verify expected number of resources (14 total)
-	assert.Len(t, resourceDefs, 17, "Should have exactly 17 embedded resource definitions")
+	// Verify expected number of resources
+	assert.Len(t, resourceDefs, 20, "Should have exactly 20 embedded resource definitions")
 
 	// Verify expected resource names are present
 	resourceNames := make(map[string]bool)
@@ -64,6 +64,7 @@ func TestResourceDefinitions_Embedded(t *testing.T) {
 		"package",
 		"elements",
 		"element",
+		"element-full",
 		"element-attributes",
 		"element-slots",
 		"element-events",
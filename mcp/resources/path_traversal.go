@@ -240,19 +240,7 @@ func (e *PathTraversalEngine) getExists(result any) bool {
 // getElementAttributes extracts attributes from an ElementInfo interface
 func (e *PathTraversalEngine) getElementAttributes(result any) any {
 	if elementInfo, ok := result.(types.ElementInfo); ok {
-		attrs := elementInfo.Attributes()
-		result := make([]map[string]any, len(attrs))
-		for i, attr := range attrs {
-			result[i] = map[string]any{
-				"name":        attr.Name,
-				"type":        attr.Type,
-				"description": attr.Description,
-				"default":     attr.Default,
-				"fieldName":   attr.FieldName,
-				"deprecated":  attr.IsDeprecated(),
-			}
-		}
-		return result
+		return convertAttributes(elementInfo)
 	}
 	return result
 }
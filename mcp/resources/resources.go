@@ -21,6 +21,7 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -92,6 +93,7 @@ func parseResourceDefinition(filename string, registry types.MCPContext) (types.
 		DataFetchers: frontmatter.DataFetchers,
 		Template:     frontmatter.Template,
 		ResponseType: frontmatter.ResponseType,
+		Chunked:      frontmatter.Chunked,
 	}
 
 	// Get the corresponding handler
@@ -112,6 +114,10 @@ func getResourceHandler(resourceDef types.ResourceDefinition, registry types.MCP
 		return makeConfigSchemaOverviewHandler(registry), nil
 	case "config-schema-section":
 		return makeConfigSchemaSectionHandler(registry), nil
+	case "elements-category":
+		return makeElementsCategoryHandler(registry), nil
+	case "workspace-packages":
+		return makeWorkspacePackagesHandler(registry), nil
 	}
 
 	if len(resourceDef.DataFetchers) == 0 {
@@ -193,3 +199,73 @@ func makeConfigSchemaSectionHandler(_ types.MCPContext) mcp.ResourceHandler {
 		}, nil
 	}
 }
+
+// makeElementsCategoryHandler lists the summaries of elements in the category
+// named by the resource URI
+func makeElementsCategoryHandler(registry types.MCPContext) mcp.ResourceHandler {
+	provider := NewDataSourceProvider(registry)
+	return func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		category := strings.TrimPrefix(uri, "cem://elements/category/")
+		if category == "" || category == uri {
+			return nil, fmt.Errorf("invalid category resource URI %q", uri)
+		}
+
+		inCategory := make(map[string]types.ElementInfo)
+		for tagName, element := range registry.AllElements() {
+			if slices.Contains(elementCategories(element), category) {
+				inCategory[tagName] = element
+			}
+		}
+		summary, _ := provider.createElementsSummary(inCategory)
+		if summary["elements"] == nil {
+			summary["elements"] = []map[string]any{}
+		}
+
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal elements in category %q: %w", category, err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(data),
+			}},
+		}, nil
+	}
+}
+
+// makeWorkspacePackagesHandler lists the packages whose manifests were
+// loaded, with their provenance, and those which failed to load
+func makeWorkspacePackagesHandler(registry types.MCPContext) mcp.ResourceHandler {
+	return func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		packages := registry.Packages()
+		var failed int
+		for _, pkg := range packages {
+			if pkg.Error != "" {
+				failed++
+			}
+		}
+
+		data, err := json.MarshalIndent(map[string]any{
+			"packages": packages,
+			"metadata": map[string]any{
+				"totalPackages":  len(packages),
+				"failedPackages": failed,
+			},
+		}, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal workspace packages: %w", err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			}},
+		}, nil
+	}
+}
@@ -270,6 +270,56 @@ func TestElementResource_Integration(t *testing.T) {
 	}
 }
 
+func TestElementAttributesResource_Reflects(t *testing.T) {
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/reflecting-attributes")
+	require.NoError(t, workspace.Init())
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	resourceDefs, err := resources.Resources(mcp.NewMCPContextAdapter(registry))
+	require.NoError(t, err)
+
+	var attributesResource *types.ResourceDefinition
+	for _, def := range resourceDefs {
+		if def.Name == "element-attributes" {
+			attributesResource = &def
+			break
+		}
+	}
+	require.NotNil(t, attributesResource, "Should have element-attributes resource")
+
+	read := func(uri string) string {
+		t.Helper()
+		result, err := attributesResource.Handler(context.Background(), &mcpSDK.ReadResourceRequest{
+			Params: &mcpSDK.ReadResourceParams{URI: uri},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Contents, 1)
+		return result.Contents[0].Text
+	}
+
+	t.Run("template", func(t *testing.T) {
+		text := read("cem://element/my-toggle/attributes")
+		assert.Contains(t, text, "| `pressed` | `boolean` | - | ✓ | Whether the toggle is pressed |")
+		assert.Contains(t, text, "| `label-text` | `string` | - | - | Accessible label |")
+		assert.Contains(t, text, "**Reflects:** The element keeps this attribute in sync with its `pressed` property")
+		assert.NotContains(t, text, "in sync with its `labelText` property")
+	})
+
+	t.Run("data source", func(t *testing.T) {
+		var attr map[string]any
+		require.NoError(t, json.Unmarshal([]byte(read("cem://element/my-toggle/attributes/pressed")), &attr))
+		assert.Equal(t, "pressed", attr["name"])
+		assert.Equal(t, true, attr["reflects"])
+
+		attr = nil
+		require.NoError(t, json.Unmarshal([]byte(read("cem://element/my-toggle/attributes/label-text")), &attr))
+		assert.Equal(t, "label-text", attr["name"])
+		assert.Equal(t, false, attr["reflects"])
+	})
+}
+
 func TestPackagesResource_Integration(t *testing.T) {
 	registry := getTestRegistry(t)
 	registryAdapter := mcp.NewMCPContextAdapter(registry)
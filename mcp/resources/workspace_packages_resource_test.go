@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/lsp/types"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/resources"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline assertions justified: manifest paths depend on the test
+// filesystem's root, so the test checks each package's provenance fields.
+
+func TestWorkspacePackagesResource(t *testing.T) {
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/workspace-packages")
+	require.NoError(t, workspace.Init())
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	defs, err := resources.Resources(mcp.NewMCPContextAdapter(registry))
+	require.NoError(t, err)
+	res := findResource(t, defs, "workspace-packages")
+	require.Equal(t, "cem://workspace/packages", res.URI)
+
+	result, err := res.Handler(context.Background(), &mcpSDK.ReadResourceRequest{
+		Params: &mcpSDK.ReadResourceParams{URI: res.URI},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+
+	var listing struct {
+		Packages []types.PackageInfo `json:"packages"`
+		Metadata struct {
+			TotalPackages  int `json:"totalPackages"`
+			FailedPackages int `json:"failedPackages"`
+		} `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &listing))
+
+	// Packages without manifests, like left-pad, are not listed
+	require.Len(t, listing.Packages, 3)
+	assert.Equal(t, 3, listing.Metadata.TotalPackages)
+	assert.Equal(t, 1, listing.Metadata.FailedPackages)
+
+	acme := listing.Packages[0]
+	assert.Equal(t, "@acme/ds", acme.Name)
+	assert.Equal(t, "2.3.0", acme.Version)
+	assert.Equal(t, types.SourceNodeModules, acme.Source)
+	assert.Equal(t, 2, acme.ElementCount)
+	assert.Contains(t, acme.ManifestPath, "node_modules/@acme/ds/custom-elements.json")
+	assert.Empty(t, acme.Error)
+
+	broken := listing.Packages[1]
+	assert.Equal(t, "broken-ds", broken.Name)
+	assert.Equal(t, "0.1.0", broken.Version)
+	assert.Equal(t, types.SourceNodeModules, broken.Source)
+	assert.Zero(t, broken.ElementCount)
+	assert.Contains(t, broken.Error, "could not parse manifest")
+
+	app := listing.Packages[2]
+	assert.Equal(t, "my-app", app.Name)
+	assert.Equal(t, "1.0.0", app.Version)
+	assert.Equal(t, types.SourceWorkspace, app.Source)
+	assert.Equal(t, 1, app.ElementCount)
+	assert.Empty(t, app.Error)
+}
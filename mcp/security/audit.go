@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/lsp/helpers"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AuditEntry records a single tool invocation
+type AuditEntry struct {
+	Time       string          `json:"time"`
+	Tool       string          `json:"tool"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	IsError    bool            `json:"isError"`
+	Error      string          `json:"error,omitempty"`
+	DurationMS int64           `json:"durationMs"`
+}
+
+// AuditLog appends a JSON line for every tool invocation to a local file
+type AuditLog struct {
+	mu    sync.Mutex
+	fs    platform.FileSystem
+	path  string
+	clock platform.TimeProvider
+}
+
+// NewAuditLog creates an audit log which appends to the file at path
+func NewAuditLog(fsys platform.FileSystem, path string, clock platform.TimeProvider) *AuditLog {
+	if clock == nil {
+		clock = platform.NewRealTimeProvider()
+	}
+	return &AuditLog{fs: fsys, path: path, clock: clock}
+}
+
+// Record appends an entry to the audit log
+func (a *AuditLog) Record(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fs.AppendFile(a.path, append(line, '\n'), 0600)
+}
+
+// Wrap returns a tool handler which records each invocation of handler.
+// Failing to write the audit log is logged, but does not fail the tool call.
+func (a *AuditLog) Wrap(toolName string, handler mcp.ToolHandler) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := a.clock.Now()
+		result, err := handler(ctx, req)
+
+		entry := AuditEntry{
+			Time:       start.UTC().Format(time.RFC3339),
+			Tool:       toolName,
+			DurationMS: a.clock.Now().Sub(start).Milliseconds(),
+		}
+		if req != nil && req.Params != nil {
+			entry.Arguments = req.Params.Arguments
+		}
+		if err != nil {
+			entry.IsError = true
+			entry.Error = err.Error()
+		} else if result != nil && result.IsError {
+			entry.IsError = true
+		}
+
+		if recordErr := a.Record(entry); recordErr != nil {
+			helpers.SafeDebugLog("Warning: Failed to write MCP audit log %s: %v", a.path, recordErr)
+		}
+		return result, err
+	}
+}
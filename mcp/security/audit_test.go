@@ -0,0 +1,71 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bennypowers.dev/cem/internal/platform"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog_Wrap(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	clock := platform.NewMockTimeProvider(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	auditLog := NewAuditLog(fsys, "/workspace/audit.jsonl", clock)
+
+	ok := auditLog.Wrap("validate_html", func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		clock.Sleep(15 * time.Millisecond)
+		return &mcp.CallToolResult{}, nil
+	})
+	failing := auditLog.Wrap("generate_html", func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("boom")
+	})
+
+	_, err := ok(context.Background(), &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Arguments: json.RawMessage(`{"html":"<my-el></my-el>"}`)},
+	})
+	require.NoError(t, err)
+	_, err = failing(context.Background(), &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{}})
+	require.Error(t, err)
+
+	data, err := fsys.ReadFile("/workspace/audit.jsonl")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second AuditEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "validate_html", first.Tool)
+	assert.Equal(t, "2026-01-02T03:04:05Z", first.Time)
+	assert.JSONEq(t, `{"html":"<my-el></my-el>"}`, string(first.Arguments))
+	assert.Equal(t, int64(15), first.DurationMS)
+	assert.False(t, first.IsError)
+
+	assert.Equal(t, "generate_html", second.Tool)
+	assert.True(t, second.IsError)
+	assert.Equal(t, "boom", second.Error)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package security
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"bennypowers.dev/cem/internal/platform"
+)
+
+// ErrOutsideScope is returned for filesystem access outside the allowed directories
+var ErrOutsideScope = errors.New("path is outside the MCP server's allowed directories")
+
+// ErrReadOnly is returned for filesystem writes when the MCP server is read-only
+var ErrReadOnly = errors.New("MCP server is in read-only mode")
+
+// ResolveAllowedDirs makes allowed directories absolute, resolving relative
+// entries against the workspace root, and symbolic links through fsys.
+func ResolveAllowedDirs(fsys platform.FileSystem, root string, allowedDirs []string) []string {
+	resolved := make([]string, 0, len(allowedDirs))
+	for _, dir := range allowedDirs {
+		if dir == "" {
+			continue
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		resolved = append(resolved, resolveSymlinks(fsys, dir))
+	}
+	return resolved
+}
+
+// IsPathAllowed reports whether path is one of, or is inside one of, the
+// allowed directories. Symbolic links in path are resolved through fsys first,
+// so a link inside an allowed directory cannot reach a target outside of it.
+// An empty allow-list permits every path.
+func IsPathAllowed(fsys platform.FileSystem, path string, allowedDirs []string) bool {
+	if len(allowedDirs) == 0 {
+		return true
+	}
+	path = resolveSymlinks(fsys, path)
+	for _, dir := range allowedDirs {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			continue
+		}
+		if rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSymlinks cleans path and resolves the symbolic links in it. Trailing
+// path elements which do not exist (yet) are kept as they are.
+func resolveSymlinks(fsys platform.FileSystem, path string) string {
+	path = filepath.Clean(path)
+	if resolved, err := platform.EvalSymlinks(fsys, path); err == nil {
+		return resolved
+	}
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path
+	}
+	return filepath.Join(resolveSymlinks(fsys, parent), filepath.Base(path))
+}
+
+// ScopedFileSystem wraps a FileSystem, rejecting access to paths outside the
+// allowed directories, and rejecting all writes when read-only.
+// Relative paths are resolved against the workspace root.
+type ScopedFileSystem struct {
+	platform.FileSystem
+	root        string
+	allowedDirs []string
+	readOnly    bool
+}
+
+// NewScopedFileSystem creates a FileSystem restricted to allowedDirs.
+// allowedDirs should already be resolved with ResolveAllowedDirs.
+func NewScopedFileSystem(fsys platform.FileSystem, root string, allowedDirs []string, readOnly bool) *ScopedFileSystem {
+	return &ScopedFileSystem{
+		FileSystem:  fsys,
+		root:        root,
+		allowedDirs: allowedDirs,
+		readOnly:    readOnly,
+	}
+}
+
+// abs resolves a relative name against the workspace root
+func (s *ScopedFileSystem) abs(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(s.root, name)
+}
+
+func (s *ScopedFileSystem) checkRead(op, name string) error {
+	if !IsPathAllowed(s.FileSystem, s.abs(name), s.allowedDirs) {
+		return &fs.PathError{Op: op, Path: name, Err: ErrOutsideScope}
+	}
+	return nil
+}
+
+func (s *ScopedFileSystem) checkWrite(op, name string) error {
+	if s.readOnly {
+		return &fs.PathError{Op: op, Path: name, Err: ErrReadOnly}
+	}
+	return s.checkRead(op, name)
+}
+
+func (s *ScopedFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := s.checkWrite("write", name); err != nil {
+		return err
+	}
+	return s.FileSystem.WriteFile(s.abs(name), data, perm)
+}
+
+func (s *ScopedFileSystem) AppendFile(name string, data []byte, perm fs.FileMode) error {
+	if err := s.checkWrite("append", name); err != nil {
+		return err
+	}
+	return s.FileSystem.AppendFile(s.abs(name), data, perm)
+}
+
+func (s *ScopedFileSystem) ReadFile(name string) ([]byte, error) {
+	if err := s.checkRead("read", name); err != nil {
+		return nil, err
+	}
+	return s.FileSystem.ReadFile(s.abs(name))
+}
+
+func (s *ScopedFileSystem) Remove(name string) error {
+	if err := s.checkWrite("remove", name); err != nil {
+		return err
+	}
+	return s.FileSystem.Remove(s.abs(name))
+}
+
+func (s *ScopedFileSystem) Create(name string) (io.WriteCloser, error) {
+	if err := s.checkWrite("create", name); err != nil {
+		return nil, err
+	}
+	return s.FileSystem.Create(s.abs(name))
+}
+
+func (s *ScopedFileSystem) CreateTemp(dir, pattern string) (platform.TempFile, error) {
+	if err := s.checkWrite("createtemp", dir); err != nil {
+		return nil, err
+	}
+	return s.FileSystem.CreateTemp(s.abs(dir), pattern)
+}
+
+func (s *ScopedFileSystem) Rename(oldpath, newpath string) error {
+	if err := s.checkWrite("rename", oldpath); err != nil {
+		return err
+	}
+	if err := s.checkWrite("rename", newpath); err != nil {
+		return err
+	}
+	return s.FileSystem.Rename(s.abs(oldpath), s.abs(newpath))
+}
+
+func (s *ScopedFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	if err := s.checkWrite("mkdir", path); err != nil {
+		return err
+	}
+	return s.FileSystem.MkdirAll(s.abs(path), perm)
+}
+
+func (s *ScopedFileSystem) MkdirTemp(dir, pattern string) (string, error) {
+	if err := s.checkWrite("mkdirtemp", dir); err != nil {
+		return "", err
+	}
+	return s.FileSystem.MkdirTemp(s.abs(dir), pattern)
+}
+
+func (s *ScopedFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := s.checkRead("readdir", name); err != nil {
+		return nil, err
+	}
+	return s.FileSystem.ReadDir(s.abs(name))
+}
+
+func (s *ScopedFileSystem) Stat(name string) (fs.FileInfo, error) {
+	if err := s.checkRead("stat", name); err != nil {
+		return nil, err
+	}
+	return s.FileSystem.Stat(s.abs(name))
+}
+
+func (s *ScopedFileSystem) Exists(path string) bool {
+	if s.checkRead("stat", path) != nil {
+		return false
+	}
+	return s.FileSystem.Exists(s.abs(path))
+}
+
+// Glob returns only the matches inside the allowed directories
+func (s *ScopedFileSystem) Glob(pattern string) ([]string, error) {
+	matches, err := s.FileSystem.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	allowed := matches[:0]
+	for _, match := range matches {
+		if s.checkRead("glob", match) == nil {
+			allowed = append(allowed, match)
+		}
+	}
+	return allowed, nil
+}
+
+func (s *ScopedFileSystem) Open(name string) (fs.File, error) {
+	if err := s.checkRead("open", name); err != nil {
+		return nil, err
+	}
+	return s.FileSystem.Open(s.abs(name))
+}
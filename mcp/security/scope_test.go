@@ -0,0 +1,124 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package security
+
+import (
+	"errors"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: security boundary, scalar assertions
+
+func TestIsPathAllowed(t *testing.T) {
+	fsys := platform.NewMapFileSystem(nil)
+	allowed := ResolveAllowedDirs(fsys, "/workspace", []string{".", "../shared", "/opt/tokens"})
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"workspace root", "/workspace", true},
+		{"inside workspace", "/workspace/src/my-el.ts", true},
+		{"relative allowed dir", "/shared/tokens.json", true},
+		{"absolute allowed dir", "/opt/tokens/index.css", true},
+		{"sibling with shared prefix", "/workspace-other/secret", false},
+		{"traversal out of workspace", "/workspace/../etc/passwd", false},
+		{"outside", "/etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPathAllowed(fsys, tt.path, allowed))
+		})
+	}
+
+	t.Run("empty allow-list is unrestricted", func(t *testing.T) {
+		assert.True(t, IsPathAllowed(fsys, "/etc/passwd", nil))
+	})
+}
+
+func TestScopedFileSystem(t *testing.T) {
+	base := platform.NewMapFileSystem(nil)
+	base.AddFile("/workspace/custom-elements.json", `{}`, 0644)
+	base.AddFile("/secret/token.txt", "hunter2", 0644)
+
+	t.Run("reads inside scope", func(t *testing.T) {
+		scoped := NewScopedFileSystem(base, "/workspace", []string{"/workspace"}, false)
+		data, err := scoped.ReadFile("/workspace/custom-elements.json")
+		require.NoError(t, err)
+		assert.Equal(t, "{}", string(data))
+
+		data, err = scoped.ReadFile("custom-elements.json")
+		require.NoError(t, err, "relative paths resolve against the root")
+		assert.Equal(t, "{}", string(data))
+	})
+
+	t.Run("rejects reads outside scope", func(t *testing.T) {
+		scoped := NewScopedFileSystem(base, "/workspace", []string{"/workspace"}, false)
+		_, err := scoped.ReadFile("/secret/token.txt")
+		assert.True(t, errors.Is(err, ErrOutsideScope))
+		assert.False(t, scoped.Exists("/secret/token.txt"))
+	})
+
+	t.Run("rejects writes outside scope", func(t *testing.T) {
+		scoped := NewScopedFileSystem(base, "/workspace", []string{"/workspace"}, false)
+		err := scoped.WriteFile("/secret/new.txt", []byte("x"), 0644)
+		assert.True(t, errors.Is(err, ErrOutsideScope))
+		require.NoError(t, scoped.WriteFile("/workspace/new.txt", []byte("x"), 0644))
+	})
+
+	t.Run("read-only rejects all writes", func(t *testing.T) {
+		scoped := NewScopedFileSystem(base, "/workspace", nil, true)
+		assert.True(t, errors.Is(scoped.WriteFile("/workspace/out.txt", []byte("x"), 0644), ErrReadOnly))
+		assert.True(t, errors.Is(scoped.AppendFile("/workspace/out.txt", []byte("x"), 0644), ErrReadOnly))
+		assert.True(t, errors.Is(scoped.Remove("/workspace/custom-elements.json"), ErrReadOnly))
+		assert.True(t, errors.Is(scoped.MkdirAll("/workspace/dir", 0755), ErrReadOnly))
+		assert.False(t, base.Exists("/workspace/out.txt"))
+
+		_, err := scoped.ReadFile("/secret/token.txt")
+		assert.NoError(t, err, "read-only without an allow-list permits reads")
+	})
+}
+
+func TestScopedFileSystem_Symlinks(t *testing.T) {
+	base := platform.NewMapFileSystem(nil)
+	base.AddFile("/secret/token.txt", "hunter2", 0644)
+	base.AddFile("/workspace/src/my-el.ts", "x", 0644)
+	// A link escaping the workspace, and one which stays inside it
+	base.AddSymlink("/workspace/escape", "/secret")
+	base.AddSymlink("/workspace/source", "src")
+
+	allowed := ResolveAllowedDirs(base, "/workspace", []string{"."})
+	scoped := NewScopedFileSystem(base, "/workspace", allowed, false)
+
+	assert.False(t, IsPathAllowed(base, "/workspace/escape/token.txt", allowed))
+	_, err := scoped.ReadFile("/workspace/escape/token.txt")
+	assert.True(t, errors.Is(err, ErrOutsideScope))
+	_, err = scoped.ReadFile("escape/token.txt")
+	assert.True(t, errors.Is(err, ErrOutsideScope), "relative paths resolve links too")
+	err = scoped.WriteFile("/workspace/escape/new.txt", []byte("x"), 0644)
+	assert.True(t, errors.Is(err, ErrOutsideScope), "writes through a link to a new file are rejected")
+
+	data, err := scoped.ReadFile("/workspace/source/my-el.ts")
+	require.NoError(t, err, "links inside the workspace are allowed")
+	assert.Equal(t, "x", string(data))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package security
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/types"
+)
+
+// ScopedWorkspace wraps a WorkspaceContext, applying the restrictions of a
+// ScopedFileSystem to the workspace's own file access, e.g. when it reads its
+// manifest or the manifests of workspace packages and node_modules.
+type ScopedWorkspace struct {
+	types.WorkspaceContext
+	fs *ScopedFileSystem
+}
+
+// NewScopedWorkspace restricts workspace to the directories fsys allows
+func NewScopedWorkspace(workspace types.WorkspaceContext, fsys *ScopedFileSystem) *ScopedWorkspace {
+	return &ScopedWorkspace{WorkspaceContext: workspace, fs: fsys}
+}
+
+// Manifest returns the workspace manifest, unless it lies outside the
+// allowed directories
+func (w *ScopedWorkspace) Manifest() (*M.Package, error) {
+	if path := w.CustomElementsManifestPath(); path != "" {
+		if err := w.fs.checkRead("read", path); err != nil {
+			return nil, err
+		}
+	}
+	return w.WorkspaceContext.Manifest()
+}
+
+func (w *ScopedWorkspace) PackageJSON() (*M.PackageJSON, error) {
+	if err := w.fs.checkRead("read", filepath.Join(w.Root(), "package.json")); err != nil {
+		return nil, err
+	}
+	return w.WorkspaceContext.PackageJSON()
+}
+
+func (w *ScopedWorkspace) ReadFile(path string) (io.ReadCloser, error) {
+	if err := w.fs.checkRead("open", path); err != nil {
+		return nil, err
+	}
+	return w.WorkspaceContext.ReadFile(path)
+}
+
+func (w *ScopedWorkspace) Stat(path string) (fs.FileInfo, error) {
+	if err := w.fs.checkRead("stat", path); err != nil {
+		return nil, err
+	}
+	return w.WorkspaceContext.Stat(path)
+}
+
+func (w *ScopedWorkspace) ReadDir(path string) ([]fs.DirEntry, error) {
+	if err := w.fs.checkRead("readdir", path); err != nil {
+		return nil, err
+	}
+	return w.WorkspaceContext.ReadDir(path)
+}
+
+// Glob returns only the matches inside the allowed directories
+func (w *ScopedWorkspace) Glob(pattern string) ([]string, error) {
+	matches, err := w.WorkspaceContext.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	allowed := matches[:0]
+	for _, match := range matches {
+		if w.fs.checkRead("glob", match) == nil {
+			allowed = append(allowed, match)
+		}
+	}
+	return allowed, nil
+}
+
+func (w *ScopedWorkspace) OutputWriter(path string) (io.WriteCloser, error) {
+	if err := w.fs.checkWrite("create", path); err != nil {
+		return nil, err
+	}
+	return w.WorkspaceContext.OutputWriter(path)
+}
+
+// InvalidateConfig forwards to the wrapped workspace, when it caches its config
+func (w *ScopedWorkspace) InvalidateConfig() {
+	if ws, ok := w.WorkspaceContext.(interface{ InvalidateConfig() }); ok {
+		ws.InvalidateConfig()
+	}
+}
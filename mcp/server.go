@@ -20,11 +20,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 
 	"bennypowers.dev/cem/internal/platform"
 	"bennypowers.dev/cem/internal/version"
 	"bennypowers.dev/cem/lsp/helpers"
+	"bennypowers.dev/cem/mcp/cache"
 	"bennypowers.dev/cem/mcp/resources"
+	"bennypowers.dev/cem/mcp/security"
+	"bennypowers.dev/cem/mcp/session"
 	"bennypowers.dev/cem/mcp/tools"
 	"bennypowers.dev/cem/types"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -45,6 +49,22 @@ type ServerConfig struct {
 	AdditionalPackages []string
 	// FileSystem to use for filesystem operations. If nil, defaults to OSFileSystem.
 	FileSystem platform.FileSystem
+	// ReadOnly disables mutating tools and rejects filesystem writes
+	ReadOnly bool
+	// AllowedDirs restricts filesystem access to these directories, relative
+	// to the workspace root. Empty means unrestricted.
+	AllowedDirs []string
+	// AuditLogPath is a file to which every tool invocation is appended.
+	// Relative paths are resolved against the workspace root.
+	AuditLogPath string
+	// NoSessionMemory always sends element resources in full, instead of
+	// abbreviating those a session already read
+	NoSessionMemory bool
+	// NoResponseCache renders every tool response afresh, instead of
+	// repeating the response to an identical earlier call
+	NoResponseCache bool
+	// Changelog enables the element_changelog tool
+	Changelog bool
 }
 
 // Server implements an MCP server for custom elements
@@ -53,6 +73,9 @@ type Server struct {
 	registry  *MCPContext
 	server    *mcp.Server
 	config    ServerConfig
+	auditLog  *security.AuditLog
+	memory    *session.Memory
+	cache     *cache.ResponseCache
 }
 
 // NewServer creates a new CEM MCP server with default configuration
@@ -66,7 +89,35 @@ func NewServer(workspace types.WorkspaceContext) (*Server, error) {
 func NewServerWithConfig(workspace types.WorkspaceContext, config ServerConfig) (*Server, error) {
 	helpers.SafeDebugLog("Creating CEM MCP server for workspace: %s", workspace.Root())
 
-	registry, err := NewMCPContext(workspace, config.FileSystem)
+	fsys := config.FileSystem
+	if fsys == nil {
+		fsys = platform.NewOSFileSystem()
+	}
+
+	var auditLog *security.AuditLog
+	if config.AuditLogPath != "" {
+		auditLogPath := config.AuditLogPath
+		if !filepath.IsAbs(auditLogPath) {
+			auditLogPath = filepath.Join(workspace.Root(), auditLogPath)
+		}
+		// The audit log is written outside the scoped filesystem, so that
+		// read-only servers still record their tool invocations
+		auditLog = security.NewAuditLog(fsys, auditLogPath, nil)
+	}
+
+	if config.ReadOnly || len(config.AllowedDirs) > 0 {
+		allowedDirs := security.ResolveAllowedDirs(fsys, workspace.Root(), config.AllowedDirs)
+		if !security.IsPathAllowed(fsys, workspace.Root(), allowedDirs) {
+			return nil, fmt.Errorf("workspace root %s is outside the allowed directories %v", workspace.Root(), allowedDirs)
+		}
+		scoped := security.NewScopedFileSystem(fsys, workspace.Root(), allowedDirs, config.ReadOnly)
+		fsys = scoped
+		// Manifests are loaded through the workspace as well as through the
+		// filesystem, so both must be scoped
+		workspace = security.NewScopedWorkspace(workspace, scoped)
+	}
+
+	registry, err := NewMCPContext(workspace, fsys)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create registry: %w", err)
 	}
@@ -82,6 +133,13 @@ func NewServerWithConfig(workspace types.WorkspaceContext, config ServerConfig)
 		registry:  registry,
 		server:    server,
 		config:    config,
+		auditLog:  auditLog,
+	}
+	if !config.NoSessionMemory {
+		cemServer.memory = session.NewMemory()
+	}
+	if !config.NoResponseCache {
+		cemServer.cache = cache.NewResponseCache(registry.ManifestHash, 0)
 	}
 
 	// Add tools to the server
@@ -158,6 +216,25 @@ func (s *Server) setupTools() error {
 
 	// Register each tool with the MCP server
 	for _, toolDef := range toolDefs {
+		if s.config.ReadOnly && toolDef.Mutating {
+			helpers.SafeDebugLog("Read-only mode: not registering mutating tool %s", toolDef.Name)
+			continue
+		}
+		if toolDef.Optional && !s.optionalToolEnabled(toolDef.Name) {
+			helpers.SafeDebugLog("Not registering optional tool %s", toolDef.Name)
+			continue
+		}
+
+		handler := tools.WithErrorCodes(toolDef.Handler)
+		// Responses of tools which only read the manifests are cached
+		// until the manifests change
+		if s.cache != nil && !toolDef.Mutating && !toolDef.Uncached {
+			handler = s.cache.Wrap(toolDef.Name, handler)
+		}
+		if s.auditLog != nil {
+			handler = s.auditLog.Wrap(toolDef.Name, handler)
+		}
+
 		// Convert input schema from map to *jsonschema.Schema
 		var inputSchema *jsonschema.Schema
 		if toolDef.InputSchema != nil {
@@ -175,14 +252,26 @@ func (s *Server) setupTools() error {
 
 		s.server.AddTool(&mcp.Tool{
 			Name:        toolDef.Name,
+			Title:       toolDef.Title,
 			Description: toolDef.Description,
 			InputSchema: inputSchema,
-		}, toolDef.Handler)
+			Annotations: toolDef.Annotations(),
+		}, handler)
 	}
 
 	return nil
 }
 
+// optionalToolEnabled reports whether the config enables an optional tool
+func (s *Server) optionalToolEnabled(name string) bool {
+	switch name {
+	case "element_changelog":
+		return s.config.Changelog
+	default:
+		return false
+	}
+}
+
 // setupResources adds resources to the MCP server
 func (s *Server) setupResources() error {
 	// Create registry adapter for resources
@@ -196,20 +285,39 @@ func (s *Server) setupResources() error {
 
 	// Register each resource with the MCP server
 	for _, resourceDef := range resourceDefs {
+		handler := resourceDef.Handler
+		// Element overviews are the bulk of the context sent to clients,
+		// so repeat reads within a session are abbreviated
+		if s.memory != nil && resourceDef.Name == "element" {
+			handler = s.memory.Wrap(handler)
+		}
+
 		if resourceDef.URITemplate {
 			s.server.AddResourceTemplate(&mcp.ResourceTemplate{
 				URITemplate: resourceDef.URI,
 				Name:        resourceDef.Name,
 				MIMEType:    resourceDef.MimeType,
 				Description: resourceDef.Description,
-			}, resourceDef.Handler)
+			}, handler)
 		} else {
 			s.server.AddResource(&mcp.Resource{
 				URI:         resourceDef.URI,
 				Name:        resourceDef.Name,
 				MIMEType:    resourceDef.MimeType,
 				Description: resourceDef.Description,
-			}, resourceDef.Handler)
+			}, handler)
+		}
+
+		// Large resources can also be read in chunks, by clients with
+		// message size limits
+		if resourceDef.Chunked {
+			s.server.AddResourceTemplate(&mcp.ResourceTemplate{
+				URITemplate: resources.ChunkedURITemplate(resourceDef.URI),
+				Name:        resourceDef.Name + "-chunk",
+				MIMEType:    resourceDef.MimeType,
+				Description: fmt.Sprintf("Reads %s in chunks of at most limit bytes, starting at offset. "+
+					"Each chunk's _meta has the nextOffset to read, until the last chunk.", resourceDef.URI),
+			}, resources.Chunked(handler))
 		}
 	}
 
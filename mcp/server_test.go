@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"bennypowers.dev/cem/internal/platform"
 	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
 	"bennypowers.dev/cem/internal/version"
 	"bennypowers.dev/cem/mcp/resources"
@@ -133,3 +134,49 @@ func TestDynamicSchemaVersionDetection(t *testing.T) {
 
 	t.Logf("Schema resource returned %d bytes of JSON schema", len(content.Text))
 }
+
+func TestNewServerWithConfig_AllowedDirsScopeManifests(t *testing.T) {
+	manifest := `{
+  "schemaVersion": "2.1.1",
+  "modules": [{
+    "kind": "javascript-module",
+    "path": "outside-el.js",
+    "declarations": [{
+      "kind": "class",
+      "name": "OutsideEl",
+      "tagName": "outside-el",
+      "customElement": true
+    }]
+  }]
+}`
+
+	tests := []struct {
+		name        string
+		allowedDirs []string
+		wantLoaded  bool
+	}{
+		{"unrestricted", nil, true},
+		{"manifest outside the allow-list", []string{"."}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mfs := platform.NewMapFileSystem(nil)
+			mfs.AddFile("/workspace/package.json", `{"name": "scoped", "customElements": "../outside/custom-elements.json"}`, 0644)
+			mfs.AddFile("/outside/custom-elements.json", manifest, 0644)
+
+			workspace := testworkspace.NewMapWorkspaceContextFromFS(mfs, "/workspace")
+			require.NoError(t, workspace.Init())
+
+			server, err := NewServerWithConfig(workspace, ServerConfig{
+				FileSystem:  mfs,
+				AllowedDirs: tt.allowedDirs,
+			})
+			require.NoError(t, err)
+			require.NoError(t, server.Registry().LoadManifests())
+
+			_, loaded := server.Registry().LSPRegistry().Elements["outside-el"]
+			assert.Equal(t, tt.wantLoaded, loaded)
+		})
+	}
+}
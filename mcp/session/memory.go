@@ -0,0 +1,159 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package session remembers what each MCP client session has already been
+// told, so that long sessions don't pay for the same context twice.
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"bennypowers.dev/cem/lsp/helpers"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FullSuffix is appended to a resource URI to read it in full, regardless
+// of what the session was already provided
+const FullSuffix = "/full"
+
+// Memory remembers the markdown resources provided to each client session.
+// When a session reads a resource again, it receives a short refresher if
+// the resource is unchanged, or only the sections which changed.
+type Memory struct {
+	mu       sync.Mutex
+	sessions map[*mcp.ServerSession]map[string]string // session -> resource URI -> text
+}
+
+// NewMemory creates an empty session memory
+func NewMemory() *Memory {
+	return &Memory{sessions: make(map[*mcp.ServerSession]map[string]string)}
+}
+
+// Wrap returns a resource handler which abbreviates handler's responses to
+// resources the session already read. Requests without a session, and
+// responses other than a single text content, pass through unchanged.
+func (m *Memory) Wrap(handler mcp.ResourceHandler) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		result, err := handler(ctx, req)
+		if err != nil || result == nil || len(result.Contents) != 1 || req.Session == nil {
+			return result, err
+		}
+
+		contents := result.Contents[0]
+		previous, seen := m.remember(req.Session, contents.URI, contents.Text)
+		if !seen {
+			return result, nil
+		}
+
+		helpers.SafeDebugLog("[MCP] Session already read %s, sending a refresher", contents.URI)
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      contents.URI,
+				MIMEType: contents.MIMEType,
+				Text:     Refresh(contents.URI, previous, contents.Text),
+			}},
+		}, nil
+	}
+}
+
+// remember records the text provided to the session for a resource,
+// returning the text previously provided for it, if any
+func (m *Memory) remember(session *mcp.ServerSession, uri, text string) (previous string, seen bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	provided, ok := m.sessions[session]
+	if !ok {
+		provided = make(map[string]string)
+		m.sessions[session] = provided
+	}
+	previous, seen = provided[uri]
+	provided[uri] = text
+	return previous, seen
+}
+
+// section is a level-two markdown section, or the preamble before the
+// first one, which has no heading
+type section struct {
+	heading string
+	text    string
+}
+
+// splitSections splits markdown into its preamble and level-two sections
+func splitSections(markdown string) []section {
+	sections := []section{{}}
+	inFence := false
+	for line := range strings.Lines(markdown) {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "## ") {
+			sections = append(sections, section{heading: strings.TrimSpace(line)})
+		}
+		sections[len(sections)-1].text += line
+	}
+	return sections
+}
+
+// Refresh abbreviates a resource the session already read as previous.
+// The preamble, which names and summarizes the element, is always kept.
+// When the resource is unchanged, that is all that is sent; otherwise the
+// sections which were added or changed follow, and removed sections are
+// listed by heading.
+func Refresh(uri, previous, current string) string {
+	before := splitSections(previous)
+	after := splitSections(current)
+
+	var out strings.Builder
+	out.WriteString(strings.TrimRight(after[0].text, "\n"))
+	out.WriteString("\n\n")
+
+	full := uri + FullSuffix
+	if previous == current {
+		fmt.Fprintf(&out, "_This resource was already provided in this session, and has not changed since. "+
+			"Refer to the earlier response, or read `%s` to receive it in full again._\n", full)
+		return out.String()
+	}
+
+	fmt.Fprintf(&out, "_This resource was already provided in this session. "+
+		"Only the sections which changed since are shown; read `%s` to receive it in full again._\n", full)
+
+	previousText := make(map[string]string, len(before))
+	for _, s := range before[1:] {
+		previousText[s.heading] = s.text
+	}
+	for _, s := range after[1:] {
+		if text, ok := previousText[s.heading]; !ok || text != s.text {
+			out.WriteString("\n")
+			out.WriteString(strings.TrimRight(s.text, "\n"))
+			out.WriteString("\n")
+		}
+		delete(previousText, s.heading)
+	}
+
+	if len(previousText) > 0 {
+		out.WriteString("\n## Removed Sections\n\n")
+		for _, s := range before[1:] {
+			if _, removed := previousText[s.heading]; removed {
+				fmt.Fprintf(&out, "- %s\n", strings.TrimPrefix(s.heading, "## "))
+			}
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package session
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const overview = "# my-button\n\nA button.\n\n## Attributes\n\n- `variant`\n\n## Slots\n\n- default\n"
+
+func TestRefresh_Unchanged(t *testing.T) {
+	got := Refresh("cem://element/my-button", overview, overview)
+	if !strings.HasPrefix(got, "# my-button\n\nA button.\n\n") {
+		t.Errorf("expected preamble to be kept, got:\n%s", got)
+	}
+	if !strings.Contains(got, "has not changed") {
+		t.Errorf("expected unchanged note, got:\n%s", got)
+	}
+	if !strings.Contains(got, "`cem://element/my-button/full`") {
+		t.Errorf("expected full resource URI, got:\n%s", got)
+	}
+	if strings.Contains(got, "## Attributes") || strings.Contains(got, "## Slots") {
+		t.Errorf("expected sections to be omitted, got:\n%s", got)
+	}
+}
+
+func TestRefresh_Changed(t *testing.T) {
+	current := "# my-button\n\nA button.\n\n## Attributes\n\n- `variant`\n- `size`\n\n## Events\n\n- click\n"
+	got := Refresh("cem://element/my-button", overview, current)
+	if !strings.Contains(got, "Only the sections which changed") {
+		t.Errorf("expected changed note, got:\n%s", got)
+	}
+	if !strings.Contains(got, "## Attributes\n\n- `variant`\n- `size`\n") {
+		t.Errorf("expected changed section, got:\n%s", got)
+	}
+	if !strings.Contains(got, "## Events\n\n- click\n") {
+		t.Errorf("expected added section, got:\n%s", got)
+	}
+	if !strings.Contains(got, "## Removed Sections\n\n- Slots\n") {
+		t.Errorf("expected removed section, got:\n%s", got)
+	}
+}
+
+func TestRefresh_IgnoresHeadingsInCodeFences(t *testing.T) {
+	previous := "# x-a\n\n## Usage\n\n```md\n## not a heading\n```\n"
+	current := "# x-a\n\n## Usage\n\n```md\n## not a heading, changed\n```\n"
+	got := Refresh("cem://element/x-a", previous, current)
+	if strings.Contains(got, "Removed Sections") {
+		t.Errorf("expected fenced heading to stay in its section, got:\n%s", got)
+	}
+	if !strings.Contains(got, "## not a heading, changed") {
+		t.Errorf("expected changed section, got:\n%s", got)
+	}
+}
+
+func TestMemory_Wrap(t *testing.T) {
+	text := overview
+	handler := func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: req.Params.URI, MIMEType: "text/markdown", Text: text}},
+		}, nil
+	}
+	wrapped := NewMemory().Wrap(handler)
+	read := func(session *mcp.ServerSession) string {
+		t.Helper()
+		result, err := wrapped(context.Background(), &mcp.ReadResourceRequest{
+			Session: session,
+			Params:  &mcp.ReadResourceParams{URI: "cem://element/my-button"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result.Contents[0].Text
+	}
+
+	first, second := &mcp.ServerSession{}, &mcp.ServerSession{}
+	if got := read(first); got != overview {
+		t.Errorf("expected first read in full, got:\n%s", got)
+	}
+	if got := read(first); got == overview {
+		t.Error("expected repeated read to be abbreviated")
+	}
+	if got := read(second); got != overview {
+		t.Errorf("expected other session to read in full, got:\n%s", got)
+	}
+	if got := read(nil); got != overview {
+		t.Errorf("expected read without session in full, got:\n%s", got)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"sync"
 	"text/template"
 
+	M "bennypowers.dev/cem/manifest"
 	"bennypowers.dev/cem/mcp/helpers"
 	"bennypowers.dev/cem/mcp/security"
 )
@@ -210,6 +211,8 @@ func createSecureFuncMap() template.FuncMap {
 		"sanitize": func(input string) string {
 			return security.SanitizeDescription(input)
 		},
+		// cssPropertyGroups groups custom properties by their x-group
+		"cssPropertyGroups": M.GroupCssProperties,
 	}
 }
 
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"text/template"
 
+	M "bennypowers.dev/cem/manifest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -230,6 +231,21 @@ func TestFuncMap_SchemaFieldDesc(t *testing.T) {
 	assert.Equal(t, "", fn(42, "Bar", "name"))
 }
 
+func TestFuncMap_CssPropertyGroups(t *testing.T) {
+	fm := createSecureFuncMap()
+	tmpl := template.Must(template.New("groups").Funcs(fm).Parse(
+		"{{range cssPropertyGroups .}}[{{.Name}}:{{range .Properties}} {{.Name}}{{end}}]{{end}}",
+	))
+
+	var b strings.Builder
+	require.NoError(t, tmpl.Execute(&b, []M.CssCustomProperty{
+		{FullyQualified: M.FullyQualified{Name: "--a"}, Group: "Surface"},
+		{FullyQualified: M.FullyQualified{Name: "--b"}},
+		{FullyQualified: M.FullyQualified{Name: "--c"}, Group: "Surface"},
+	}))
+	assert.Equal(t, "[: --b][Surface: --a --c]", b.String())
+}
+
 // --- getSchemaDescription ---
 
 func TestGetSchemaDescription(t *testing.T) {
@@ -613,6 +629,7 @@ func TestFuncMap_AllExpectedFunctionsExist(t *testing.T) {
 	expected := []string{
 		"title", "schemaDesc", "schemaFieldDesc",
 		"len", "index", "gt", "eq", "join", "add", "sanitize",
+		"cssPropertyGroups",
 	}
 	for _, name := range expected {
 		t.Run(name, func(t *testing.T) {
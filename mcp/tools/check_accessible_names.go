@@ -0,0 +1,460 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	mcpTypes "bennypowers.dev/cem/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// CheckAccessibleNamesArgs represents the arguments for the check_accessible_names tool
+type CheckAccessibleNamesArgs struct {
+	Html    string `json:"html"`
+	TagName string `json:"tagName,omitempty"`
+}
+
+// AccessibleNameResult reports the accessible name computed for one
+// interactive element, where the name came from, and which of a custom
+// element's documented attributes and slots can label it
+type AccessibleNameResult struct {
+	Element             string // the element's start tag, e.g. `<button-element variant="primary">`
+	Role                string
+	Name                string
+	Source              string // e.g. "aria-label", "`label` attribute", "default slot content"
+	Empty               bool
+	LabellingAttributes []string
+	LabellingSlots      []string // "" is the default slot
+}
+
+// AccessibleNamesTemplateData is the template data for the check_accessible_names tool
+type AccessibleNamesTemplateData struct {
+	BaseTemplateData
+	TagName    string
+	Results    []AccessibleNameResult
+	EmptyCount int
+}
+
+// interactiveRoles are the ARIA widget roles which require an accessible name
+var interactiveRoles = map[string]bool{
+	"button":           true,
+	"checkbox":         true,
+	"combobox":         true,
+	"link":             true,
+	"menuitem":         true,
+	"menuitemcheckbox": true,
+	"menuitemradio":    true,
+	"option":           true,
+	"radio":            true,
+	"searchbox":        true,
+	"slider":           true,
+	"spinbutton":       true,
+	"switch":           true,
+	"tab":              true,
+	"textbox":          true,
+}
+
+// handleCheckAccessibleNames computes the accessible name of each interactive
+// element in an HTML snippet, so that generated markup can be corrected until
+// every control has a name
+func handleCheckAccessibleNames(
+	_ context.Context,
+	req *mcp.CallToolRequest,
+	registry mcpTypes.MCPContext,
+) (*mcp.CallToolResult, error) {
+	args, err := ParseToolArgs[CheckAccessibleNamesArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkInputLength("html", args.Html); err != nil {
+		return nil, err
+	}
+
+	results, err := computeAccessibleNames(args.Html, args.TagName, registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check accessible names: %w", err)
+	}
+
+	data := AccessibleNamesTemplateData{
+		TagName: args.TagName,
+		Results: results,
+	}
+	for _, r := range results {
+		if r.Empty {
+			data.EmptyCount++
+		}
+	}
+
+	text, err := RenderTemplate("accessible_names", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render accessible names: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// computeAccessibleNames parses an HTML fragment and computes the accessible
+// name of each interactive element in it. When tagName is set, only instances
+// of that element and the interactive elements inside them are reported.
+func computeAccessibleNames(src, tagName string, registry mcpTypes.MCPContext) ([]AccessibleNameResult, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]*html.Node)
+	var labels []*html.Node
+	for _, n := range nodes {
+		walkElements(n, func(el *html.Node) {
+			if id := attr(el, "id"); id != "" {
+				if _, seen := ids[id]; !seen {
+					ids[id] = el
+				}
+			}
+			if el.Data == "label" {
+				labels = append(labels, el)
+			}
+		})
+	}
+
+	c := nameComputer{registry: registry, ids: ids, labels: labels}
+	results := []AccessibleNameResult{}
+	var visit func(n *html.Node, inFocus bool)
+	visit = func(n *html.Node, inFocus bool) {
+		if n.Type != html.ElementNode {
+			return
+		}
+		inFocus = inFocus || tagName == "" || n.Data == tagName
+		if inFocus {
+			if result, ok := c.compute(n, n.Data == tagName); ok {
+				results = append(results, result)
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			visit(child, inFocus)
+		}
+	}
+	for _, n := range nodes {
+		visit(n, false)
+	}
+	return results, nil
+}
+
+// nameComputer implements a practical subset of the accessible name
+// computation: aria-labelledby, aria-label, native labelling (label
+// elements, alt, value), labelling attributes and slots declared in the
+// manifest, content, and finally title and placeholder
+type nameComputer struct {
+	registry mcpTypes.MCPContext
+	ids      map[string]*html.Node
+	labels   []*html.Node
+}
+
+// compute reports the accessible name of el, and false when el is not
+// interactive. Elements named by focus are treated as interactive.
+func (c nameComputer) compute(el *html.Node, focus bool) (AccessibleNameResult, bool) {
+	var info mcpTypes.ElementInfo
+	if strings.Contains(el.Data, "-") {
+		if found, err := c.registry.ElementInfo(el.Data); err == nil {
+			info = found
+		}
+	}
+
+	role, interactive := nativeRole(el)
+	if explicit := attr(el, "role"); explicit != "" {
+		role, interactive = explicit, interactiveRoles[explicit]
+	}
+	if info != nil && (focus || declaresInteractiveAPI(info)) {
+		interactive = true
+	}
+	if !interactive {
+		return AccessibleNameResult{}, false
+	}
+
+	result := AccessibleNameResult{Element: startTag(el), Role: role}
+	if info != nil {
+		result.LabellingAttributes, result.LabellingSlots = labellingMembers(info)
+	}
+	setName := func(name, source string) bool {
+		name = collapseWhitespace(name)
+		if name == "" {
+			return false
+		}
+		result.Name, result.Source = name, source
+		return true
+	}
+
+	if hasAttr(el, "aria-labelledby") {
+		var parts []string
+		for _, id := range strings.Fields(attr(el, "aria-labelledby")) {
+			if target, found := c.ids[id]; found {
+				parts = append(parts, textContent(target))
+			}
+		}
+		if setName(strings.Join(parts, " "), "aria-labelledby") {
+			return result, true
+		}
+	}
+	if setName(attr(el, "aria-label"), "aria-label") {
+		return result, true
+	}
+	if c.nativeName(el, setName) {
+		return result, true
+	}
+	if info != nil && c.customElementName(el, info, setName) {
+		return result, true
+	}
+	if info == nil && acceptsContentName(el) && setName(textContent(el), "content") {
+		return result, true
+	}
+	if setName(attr(el, "title"), "title") || setName(attr(el, "placeholder"), "placeholder") {
+		return result, true
+	}
+
+	result.Empty = true
+	return result, true
+}
+
+// nativeName computes the name which HTML's own labelling gives a native control
+func (c nameComputer) nativeName(el *html.Node, setName func(name, source string) bool) bool {
+	switch el.Data {
+	case "input":
+		switch strings.ToLower(attr(el, "type")) {
+		case "button", "submit", "reset":
+			return setName(attr(el, "value"), "value attribute")
+		case "image":
+			return setName(attr(el, "alt"), "alt attribute")
+		}
+		return c.labelName(el, setName)
+	case "select", "textarea":
+		return c.labelName(el, setName)
+	}
+	return false
+}
+
+// labelName names a labelable control by its <label for> or wrapping <label>
+func (c nameComputer) labelName(el *html.Node, setName func(name, source string) bool) bool {
+	if id := attr(el, "id"); id != "" {
+		for _, label := range c.labels {
+			if attr(label, "for") == id && setName(textContent(label), "`<label for>`") {
+				return true
+			}
+		}
+	}
+	for p := el.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode && p.Data == "label" {
+			return setName(textContent(p), "wrapping `<label>`")
+		}
+	}
+	return false
+}
+
+// customElementName names a custom element by the labelling attributes and
+// slots its manifest declares
+func (c nameComputer) customElementName(el *html.Node, info mcpTypes.ElementInfo, setName func(name, source string) bool) bool {
+	for _, a := range info.Attributes() {
+		if isLabellingMember(a.Name, a.Description) && setName(attr(el, a.Name), fmt.Sprintf("`%s` attribute", a.Name)) {
+			return true
+		}
+	}
+
+	slotted := make(map[string][]string)
+	for child := el.FirstChild; child != nil; child = child.NextSibling {
+		slot := ""
+		if child.Type == html.ElementNode {
+			slot = attr(child, "slot")
+		}
+		slotted[slot] = append(slotted[slot], textContent(child))
+	}
+	for _, s := range info.Slots() {
+		if s.Name == "" || !isLabellingMember(s.Name, s.Description) {
+			continue
+		}
+		if setName(strings.Join(slotted[s.Name], " "), fmt.Sprintf("`%s` slot content", s.Name)) {
+			return true
+		}
+	}
+	if slices.ContainsFunc(info.Slots(), func(s mcpTypes.Slot) bool { return s.Name == "" }) {
+		return setName(strings.Join(slotted[""], " "), "default slot content")
+	}
+	return false
+}
+
+// labellingMembers lists the attributes and slots an element's manifest
+// documents as providing its label
+func labellingMembers(info mcpTypes.ElementInfo) (attributes, slots []string) {
+	for _, a := range info.Attributes() {
+		if isLabellingMember(a.Name, a.Description) {
+			attributes = append(attributes, a.Name)
+		}
+	}
+	for _, s := range info.Slots() {
+		if s.Name == "" || isLabellingMember(s.Name, s.Description) {
+			slots = append(slots, s.Name)
+		}
+	}
+	return attributes, slots
+}
+
+// nativeRole returns the implicit role of a native element, and whether that
+// role is interactive
+func nativeRole(el *html.Node) (string, bool) {
+	switch el.Data {
+	case "button", "summary":
+		return "button", true
+	case "a":
+		return "link", hasAttr(el, "href")
+	case "select":
+		return "combobox", true
+	case "textarea":
+		return "textbox", true
+	case "input":
+		switch strings.ToLower(attr(el, "type")) {
+		case "hidden":
+			return "", false
+		case "button", "submit", "reset", "image":
+			return "button", true
+		case "checkbox":
+			return "checkbox", true
+		case "radio":
+			return "radio", true
+		case "range":
+			return "slider", true
+		case "number":
+			return "spinbutton", true
+		case "search":
+			return "searchbox", true
+		default:
+			return "textbox", true
+		}
+	}
+	return "", false
+}
+
+// declaresInteractiveAPI reports whether an element's manifest suggests it is
+// a control, i.e. it can be disabled or it navigates
+func declaresInteractiveAPI(info mcpTypes.ElementInfo) bool {
+	return slices.ContainsFunc(info.Attributes(), func(a mcpTypes.Attribute) bool {
+		return a.Name == "disabled" || a.Name == "href"
+	})
+}
+
+// isLabellingMember reports whether a manifest attribute or slot provides
+// its element's label
+func isLabellingMember(name, description string) bool {
+	switch name {
+	case "label", "accessible-label":
+		return true
+	}
+	d := strings.ToLower(description)
+	return strings.Contains(d, "accessible name") || strings.Contains(d, "accessible label")
+}
+
+// acceptsContentName reports whether a native element takes its name from
+// its content
+func acceptsContentName(el *html.Node) bool {
+	switch el.Data {
+	case "input", "select", "textarea":
+		return false
+	}
+	return true
+}
+
+// textContent returns the text an element contributes to a name, using the
+// alt text of images and the aria-label of labelled descendants, and
+// skipping hidden content
+func textContent(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return n.Data
+	case html.ElementNode:
+		if hasAttr(n, "hidden") || attr(n, "aria-hidden") == "true" {
+			return ""
+		}
+		switch n.Data {
+		case "script", "style", "template":
+			return ""
+		case "img", "area":
+			return attr(n, "alt")
+		}
+		if label := attr(n, "aria-label"); label != "" {
+			return label
+		}
+	}
+	var parts []string
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		parts = append(parts, textContent(child))
+	}
+	return strings.Join(parts, " ")
+}
+
+// startTag renders an element's start tag, with its attributes
+func startTag(el *html.Node) string {
+	var b strings.Builder
+	b.WriteString("<" + el.Data)
+	for _, a := range el.Attr {
+		if a.Val == "" {
+			fmt.Fprintf(&b, " %s", a.Key)
+		} else {
+			fmt.Fprintf(&b, " %s=%q", a.Key, a.Val)
+		}
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+func walkElements(n *html.Node, fn func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		fn(n)
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		walkElements(child, fn)
+	}
+}
+
+func attr(el *html.Node, name string) string {
+	for _, a := range el.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(el *html.Node, name string) bool {
+	return slices.ContainsFunc(el.Attr, func(a html.Attribute) bool { return a.Key == name })
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/tools"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccessibleNames_FixtureGolden(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		tagName string
+		golden  string
+	}{
+		{"all interactive elements", "snippet.html", "", "snippet.golden.md"},
+		{"focused on one element", "focused.html", "button-element", "focused.golden.md"},
+	}
+
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/multiple-elements-integration")
+	require.NoError(t, workspace.Init())
+
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	handler := tools.MakeCheckAccessibleNamesHandler(mcp.NewMCPContextAdapter(registry))
+	fs := testutil.LoadTestdataFS(t, "../testdata/fixtures/check-accessible-names", "/")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argsJSON, err := json.Marshal(map[string]any{
+				"html":    string(testutil.ReadFixture(t, fs, "/"+tt.fixture)),
+				"tagName": tt.tagName,
+			})
+			require.NoError(t, err)
+
+			result, err := handler(context.Background(), &mcpSDK.CallToolRequest{
+				Params: &mcpSDK.CallToolParamsRaw{
+					Name:      "check_accessible_names",
+					Arguments: json.RawMessage(argsJSON),
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+
+			textContent, ok := result.Content[0].(*mcpSDK.TextContent)
+			require.True(t, ok)
+
+			expected := testutil.ReadFixture(t, fs, "/"+tt.golden)
+			assert.Equal(t, string(expected), textContent.Text, "Output should match golden file")
+		})
+	}
+}
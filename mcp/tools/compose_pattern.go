@@ -0,0 +1,430 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"bennypowers.dev/cem/mcp/helpers"
+	mcpTypes "bennypowers.dev/cem/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxCompositionDepth caps how deeply slotted elements are nested in a
+// composed skeleton
+const maxCompositionDepth = 4
+
+// slotTagPattern matches the custom elements which a slot's description
+// names, e.g. "`<my-tab>` elements"
+var slotTagPattern = regexp.MustCompile(`<([a-z][a-z0-9]*-[a-z0-9-]*)`)
+
+// ComposePatternArgs represents the arguments for the compose_pattern tool
+type ComposePatternArgs struct {
+	Intent string `json:"intent"`
+}
+
+// ComposedElement is one element of a composed skeleton, and the slot of its
+// parent which it fills
+type ComposedElement struct {
+	TagName      string
+	Parent       string   // the enclosing element, empty at the top level
+	Slot         string   // the slot of Parent, empty for the default slot
+	Attributes   []string // required attributes with placeholder values, e.g. `label="Label"`
+	Reasons      []string // why the element was chosen, e.g. "`tabs` matches the tag name"
+	Content      string   // placeholder content for a default slot without elements
+	Placeholders []string // named slots without elements
+	Children     []*ComposedElement
+}
+
+// CompositionGuidelines lists the guidelines of a composed element
+type CompositionGuidelines struct {
+	TagName    string
+	Guidelines []string
+}
+
+// CompositionTemplateData is the template data for the compose_pattern tool
+type CompositionTemplateData struct {
+	BaseTemplateData
+	Intent     string
+	Skeleton   string
+	Elements   []*ComposedElement
+	Guidelines []CompositionGuidelines
+}
+
+// handleComposePattern selects elements from the design system for a
+// high-level intent, and composes them into a skeleton with their nesting,
+// required attributes, and slot assignments
+func handleComposePattern(
+	_ context.Context,
+	req *mcp.CallToolRequest,
+	registry mcpTypes.MCPContext,
+) (*mcp.CallToolResult, error) {
+	args, err := ParseToolArgs[ComposePatternArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Intent) == "" {
+		return nil, NewToolError(ErrorInvalidArguments, "intent is required")
+	}
+	if err := checkInputLength("intent", args.Intent); err != nil {
+		return nil, err
+	}
+
+	data := composePattern(args.Intent, registry)
+	text, err := RenderTemplate("pattern_composition", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render composition: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// intentWord is a word of the intent, with its singular form when it looks
+// plural, e.g. "tabs" and "tab"
+type intentWord struct {
+	text     string
+	singular string
+}
+
+// intentWords splits an intent into the words which can name elements
+func intentWords(intent string) []intentWord {
+	var words []intentWord
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(intent), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 3 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		w := intentWord{text: word}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			w.singular = strings.TrimSuffix(word, "s")
+		}
+		words = append(words, w)
+	}
+	return words
+}
+
+// composePattern selects an element for each word of the intent which names
+// one, then fills the slots of each selected element with the elements the
+// slots call for
+func composePattern(intent string, registry mcpTypes.MCPContext) CompositionTemplateData {
+	elements := registry.AllElements()
+	c := composer{}
+	for _, tagName := range slices.Sorted(maps.Keys(elements)) {
+		c.candidates = append(c.candidates, newComposeCandidate(elements[tagName]))
+	}
+
+	var roots []*ComposedElement
+	words := intentWords(intent)
+	for _, word := range words {
+		candidate, reasons := c.choose(word, words)
+		if candidate == nil || slices.ContainsFunc(flattenComposition(roots), func(e *ComposedElement) bool {
+			return e.TagName == candidate.info.TagName()
+		}) {
+			continue
+		}
+		root := c.compose(candidate, nil, "", map[string]bool{}, 0)
+		root.Reasons = append(reasons, root.Reasons...)
+		// Elements which the intent names, but which this element calls for
+		// in its slots, are composed in those slots rather than at the top level
+		nested := flattenComposition(root.Children)
+		roots = slices.DeleteFunc(roots, func(e *ComposedElement) bool {
+			return slices.ContainsFunc(nested, func(n *ComposedElement) bool { return n.TagName == e.TagName })
+		})
+		roots = append(roots, root)
+	}
+
+	data := CompositionTemplateData{Intent: intent, Elements: flattenComposition(roots)}
+	var b strings.Builder
+	for _, root := range roots {
+		writeComposedElement(&b, root, 0)
+	}
+	data.Skeleton = strings.TrimSuffix(b.String(), "\n")
+
+	seen := make(map[string]bool)
+	for _, e := range data.Elements {
+		if seen[e.TagName] {
+			continue
+		}
+		seen[e.TagName] = true
+		if info := elements[e.TagName]; info != nil && len(info.Guidelines()) > 0 {
+			data.Guidelines = append(data.Guidelines, CompositionGuidelines{
+				TagName:    e.TagName,
+				Guidelines: info.Guidelines(),
+			})
+		}
+	}
+	return data
+}
+
+// composeCandidate is an element of the design system, with the words
+// which an intent can match
+type composeCandidate struct {
+	info       mcpTypes.ElementInfo
+	nameTokens map[string]bool // words of the tag name
+	words      map[string]bool // words of the summary, description, and guidelines
+}
+
+func newComposeCandidate(info mcpTypes.ElementInfo) composeCandidate {
+	c := composeCandidate{
+		info:       info,
+		nameTokens: make(map[string]bool),
+		words:      make(map[string]bool),
+	}
+	for token := range strings.SplitSeq(info.TagName(), "-") {
+		if token != "element" && token != "component" {
+			c.nameTokens[token] = true
+		}
+	}
+	// The element's own summary and description are only on its declaration
+	var prose []string
+	if decl := info.Declaration(); decl != nil {
+		prose = append(prose, decl.Summary, decl.Description)
+	}
+	text := strings.Join(append(prose, info.Guidelines()...), " ")
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) > 2 {
+			c.words[word] = true
+		}
+	}
+	return c
+}
+
+// composer composes skeletons from the elements of the design system
+type composer struct {
+	candidates []composeCandidate
+}
+
+// choose finds the element which a word of the intent names. Elements which
+// the word names as written rank above those which its singular names, and
+// the other words of the intent rank matches by their descriptions. Among
+// equal matches, the element with the shorter name is the more general one.
+func (c *composer) choose(word intentWord, words []intentWord) (*composeCandidate, []string) {
+	var best *composeCandidate
+	var bestReasons []string
+	bestScore := 0
+	for i := range c.candidates {
+		candidate := &c.candidates[i]
+		var score int
+		var reasons []string
+		switch {
+		case candidate.nameTokens[word.text]:
+			score = 3
+			reasons = append(reasons, fmt.Sprintf("`%s` matches the tag name", word.text))
+		case word.singular != "" && candidate.nameTokens[word.singular]:
+			score = 2
+			reasons = append(reasons, fmt.Sprintf("`%s` matches the tag name", word.singular))
+		default:
+			continue
+		}
+		for _, other := range words {
+			if other.text != word.text && candidate.words[other.text] {
+				score++
+				reasons = append(reasons, fmt.Sprintf("`%s` matches the description", other.text))
+			}
+		}
+		if best == nil || score > bestScore ||
+			(score == bestScore && len(candidate.nameTokens) < len(best.nameTokens)) {
+			best, bestScore, bestReasons = candidate, score, reasons
+		}
+	}
+	return best, bestReasons
+}
+
+// compose builds the skeleton of an element, filling each of its slots with
+// the elements which the slot calls for. ancestors prevents elements from
+// nesting inside themselves.
+func (c *composer) compose(
+	candidate *composeCandidate,
+	parent *composeCandidate,
+	slot string,
+	ancestors map[string]bool,
+	depth int,
+) *ComposedElement {
+	tagName := candidate.info.TagName()
+	e := &ComposedElement{TagName: tagName, Slot: slot}
+	e.Attributes, e.Reasons = requiredAttributes(candidate.info)
+	if parent != nil {
+		e.Parent = parent.info.TagName()
+	}
+
+	ancestors[tagName] = true
+	defer delete(ancestors, tagName)
+
+	for _, s := range candidate.info.Slots() {
+		var children []*composeCandidate
+		var reason string
+		if depth < maxCompositionDepth {
+			children, reason = c.slotted(candidate, s, ancestors)
+		}
+		for _, child := range children {
+			composed := c.compose(child, candidate, s.Name, ancestors, depth+1)
+			composed.Reasons = slices.Insert(composed.Reasons, 0, reason)
+			e.Children = append(e.Children, composed)
+		}
+		switch {
+		case len(children) > 0:
+		case s.Name != "":
+			e.Placeholders = append(e.Placeholders, s.Name)
+		default:
+			e.Content = placeholderContent(candidate)
+		}
+	}
+	return e
+}
+
+// slotted finds the elements which a slot calls for: the elements its
+// description names, or else a related element which its name names, e.g.
+// the `tab` slot of `<my-tabs>` calls for `<my-tab>` from the same package
+func (c *composer) slotted(
+	parent *composeCandidate,
+	slot mcpTypes.Slot,
+	ancestors map[string]bool,
+) ([]*composeCandidate, string) {
+	slotName := "the default slot"
+	if slot.Name != "" {
+		slotName = fmt.Sprintf("the `%s` slot", slot.Name)
+	}
+
+	var named []*composeCandidate
+	for _, match := range slotTagPattern.FindAllStringSubmatch(slot.Description, -1) {
+		if ancestors[match[1]] {
+			continue
+		}
+		if i := slices.IndexFunc(c.candidates, func(candidate composeCandidate) bool {
+			return candidate.info.TagName() == match[1]
+		}); i >= 0 && !slices.Contains(named, &c.candidates[i]) {
+			named = append(named, &c.candidates[i])
+		}
+	}
+	if len(named) > 0 {
+		return named, fmt.Sprintf("%s of `<%s>` names it", slotName, parent.info.TagName())
+	}
+	if slot.Name == "" {
+		return nil, ""
+	}
+
+	var best *composeCandidate
+	var via string
+	for _, rel := range parent.info.Relationships() {
+		if ancestors[rel.TargetTagName] {
+			continue
+		}
+		i := slices.IndexFunc(c.candidates, func(candidate composeCandidate) bool {
+			return candidate.info.TagName() == rel.TargetTagName
+		})
+		if i < 0 || !c.candidates[i].nameTokens[slot.Name] {
+			continue
+		}
+		if best == nil || len(c.candidates[i].nameTokens) < len(best.nameTokens) {
+			best, via = &c.candidates[i], rel.Label()
+		}
+	}
+	if best == nil {
+		return nil, ""
+	}
+	return []*composeCandidate{best}, fmt.Sprintf("%s of `<%s>` matches the tag name; %s",
+		slotName, parent.info.TagName(), via)
+}
+
+// requiredAttributes lists the attributes an element needs, with placeholder
+// values: its labelling attributes, and those documented as required
+func requiredAttributes(info mcpTypes.ElementInfo) (attributes, reasons []string) {
+	for _, a := range info.Attributes() {
+		switch {
+		case isLabellingMember(a.Name, a.Description):
+			reasons = append(reasons, fmt.Sprintf("`%s` provides its accessible name", a.Name))
+		case strings.HasPrefix(strings.ToLower(a.Description), "required"):
+			reasons = append(reasons, fmt.Sprintf("`%s` is required", a.Name))
+		default:
+			continue
+		}
+		switch {
+		case a.Type != nil && a.Type.Text == "boolean":
+			attributes = append(attributes, a.Name)
+		case a.Default != "":
+			attributes = append(attributes, fmt.Sprintf("%s=%q", a.Name, strings.Trim(a.Default, `"'`)))
+		case a.IsEnum() && len(a.EnumValues()) > 0:
+			attributes = append(attributes, fmt.Sprintf("%s=%q", a.Name, strings.Trim(a.EnumValues()[0], `"'`)))
+		default:
+			attributes = append(attributes, fmt.Sprintf("%s=%q", a.Name, helpers.TitleCaser.String(strings.ReplaceAll(a.Name, "-", " "))))
+		}
+	}
+	return attributes, reasons
+}
+
+// placeholderContent is the text of a default slot without elements, named
+// for the last word of the element's tag name, e.g. "Panel content"
+func placeholderContent(candidate *composeCandidate) string {
+	tokens := strings.Split(candidate.info.TagName(), "-")
+	return helpers.TitleCaser.String(tokens[len(tokens)-1]) + " content"
+}
+
+// flattenComposition lists the elements of skeletons in document order
+func flattenComposition(elements []*ComposedElement) []*ComposedElement {
+	var flat []*ComposedElement
+	for _, e := range elements {
+		flat = append(flat, e)
+		flat = append(flat, flattenComposition(e.Children)...)
+	}
+	return flat
+}
+
+// writeComposedElement writes an element of a skeleton as indented HTML
+func writeComposedElement(b *strings.Builder, e *ComposedElement, depth int) {
+	indent := strings.Repeat("  ", depth)
+	b.WriteString(indent + "<" + e.TagName)
+	if e.Slot != "" {
+		fmt.Fprintf(b, " slot=%q", e.Slot)
+	}
+	for _, a := range e.Attributes {
+		b.WriteString(" " + a)
+	}
+	b.WriteString(">")
+
+	if len(e.Children) == 0 && len(e.Placeholders) == 0 {
+		b.WriteString(e.Content + "</" + e.TagName + ">\n")
+		return
+	}
+	b.WriteString("\n")
+	for _, child := range e.Children {
+		writeComposedElement(b, child, depth+1)
+	}
+	for _, slot := range e.Placeholders {
+		fmt.Fprintf(b, "%s  <span slot=%q>%s content</span>\n", indent, slot, helpers.TitleCaser.String(slot))
+	}
+	if e.Content != "" {
+		b.WriteString(indent + "  " + e.Content + "\n")
+	}
+	b.WriteString(indent + "</" + e.TagName + ">\n")
+}
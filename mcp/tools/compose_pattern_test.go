@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/tools"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposePattern_FixtureGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		intent string
+		golden string
+	}{
+		{"settings page", "settings page with tabs and a form", "settings.golden.md"},
+		{"nothing matches", "a dashboard", "unmatched.golden.md"},
+	}
+
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/compose-pattern")
+	require.NoError(t, workspace.Init())
+
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	handler := tools.MakeComposePatternHandler(mcp.NewMCPContextAdapter(registry))
+	fs := testutil.LoadTestdataFS(t, "../testdata/fixtures/compose-pattern", "/")
+
+	call := func(intent string) (*mcpSDK.CallToolResult, error) {
+		argsJSON, err := json.Marshal(map[string]any{"intent": intent})
+		require.NoError(t, err)
+		return handler(context.Background(), &mcpSDK.CallToolRequest{
+			Params: &mcpSDK.CallToolParamsRaw{
+				Name:      "compose_pattern",
+				Arguments: json.RawMessage(argsJSON),
+			},
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := call(tt.intent)
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+
+			textContent, ok := result.Content[0].(*mcpSDK.TextContent)
+			require.True(t, ok)
+
+			expected := testutil.ReadFixture(t, fs, "/"+tt.golden)
+			assert.Equal(t, string(expected), textContent.Text, "Output should match golden file")
+		})
+	}
+
+	t.Run("empty intent", func(t *testing.T) {
+		_, err := call("  ")
+		assert.ErrorContains(t, err, "intent is required")
+	})
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/mcp/security"
+	mcpTypes "bennypowers.dev/cem/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultChangelogDepth is the number of commits read when neither the
+	// client nor the config asks for a depth
+	defaultChangelogDepth = 20
+	// maxChangelogDepth caps the number of commits read for one element
+	maxChangelogDepth = 500
+)
+
+// pullRequestPattern matches the pull request references which GitHub adds
+// to commit subjects, e.g. "fix: focus ring (#123)" or "Merge pull request
+// #123 from user/branch"
+var pullRequestPattern = regexp.MustCompile(`\(#(\d+)\)|pull request #(\d+)`)
+
+// ElementChangelogArgs represents the arguments for the element_changelog tool
+type ElementChangelogArgs struct {
+	TagName string `json:"tagName"`
+	Depth   int    `json:"depth,omitempty"`
+	Search  string `json:"search,omitempty"`
+}
+
+// ChangelogEntry is one commit which touched an element's modules
+type ChangelogEntry struct {
+	Hash         string
+	Date         string // the author date, e.g. 2026-01-31
+	Author       string
+	Subject      string
+	PullRequests []string // pull request numbers referenced by the subject
+}
+
+// ChangelogTemplateData is the template data for the element_changelog tool
+type ChangelogTemplateData struct {
+	BaseTemplateData
+	TagName   string
+	Search    string
+	Paths     []string
+	Entries   []ChangelogEntry
+	Truncated bool
+}
+
+// handleElementChangelog summarizes the git history of the modules which
+// define an element, so assistants can tell when its API or behavior changed
+func handleElementChangelog(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	registry mcpTypes.MCPContext,
+) (*mcp.CallToolResult, error) {
+	args, err := ParseToolArgs[ElementChangelogArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	if args.TagName == "" {
+		return nil, NewToolError(ErrorInvalidArguments, "tagName is required")
+	}
+
+	_, errResponse, err := LookupElement(registry, args.TagName)
+	if err != nil || errResponse != nil {
+		return errResponse, err
+	}
+	module := registry.ElementModule(args.TagName)
+	if module == "" {
+		return nil, fmt.Errorf("the manifest does not name the module which defines %s", args.TagName)
+	}
+
+	depth := args.Depth
+	if depth <= 0 {
+		if cfg, err := registry.Config(); err == nil && cfg != nil {
+			depth = cfg.MCP.ChangelogDepth
+		}
+	}
+	if depth <= 0 {
+		depth = defaultChangelogDepth
+	}
+	depth = min(depth, maxChangelogDepth)
+
+	paths := changelogPaths(module)
+	if err := checkChangelogScope(registry, paths); err != nil {
+		return nil, err
+	}
+	// Read one commit more than asked for, to tell whether there are more
+	entries, err := readChangelog(ctx, registry.Root(), paths, depth+1, args.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	data := ChangelogTemplateData{
+		TagName: args.TagName,
+		Search:  args.Search,
+		Paths:   paths,
+		Entries: entries,
+	}
+	if len(entries) > depth {
+		data.Entries = entries[:depth]
+		data.Truncated = true
+	}
+
+	text, err := RenderTemplate("element_changelog", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render changelog: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// changelogPaths returns the files whose history describes a module: the
+// module itself and, for JavaScript output, its TypeScript source
+func changelogPaths(module string) []string {
+	module = path.Clean(strings.TrimPrefix(module, "./"))
+	paths := []string{module}
+	for js, ts := range map[string]string{".js": ".ts", ".mjs": ".mts"} {
+		if base, ok := strings.CutSuffix(module, js); ok {
+			paths = append(paths, base+ts)
+		}
+	}
+	return paths
+}
+
+// checkChangelogScope rejects reading the history of a repository or of
+// modules outside the MCP server's allowed directories. git reads them
+// directly, rather than through the scoped filesystem.
+func checkChangelogScope(registry mcpTypes.MCPContext, paths []string) error {
+	cfg, err := registry.Config()
+	if err != nil || cfg == nil || len(cfg.MCP.AllowedDirs) == 0 {
+		return nil
+	}
+	fsys := registry.FileSystem()
+	root := registry.Root()
+	allowedDirs := security.ResolveAllowedDirs(fsys, root, cfg.MCP.AllowedDirs)
+	if !security.IsPathAllowed(fsys, root, allowedDirs) {
+		return fmt.Errorf("%w: %s", security.ErrOutsideScope, root)
+	}
+	for _, p := range paths {
+		if !security.IsPathAllowed(fsys, filepath.Join(root, filepath.FromSlash(p)), allowedDirs) {
+			return fmt.Errorf("%w: %s", security.ErrOutsideScope, p)
+		}
+	}
+	return nil
+}
+
+// readChangelog lists the newest commits touching paths in the git
+// repository at root, at most depth of them. When search is not empty, only
+// commits which change the number of its occurrences are listed.
+func readChangelog(ctx context.Context, root string, paths []string, depth int, search string) ([]ChangelogEntry, error) {
+	args := []string{"log", fmt.Sprintf("--max-count=%d", depth), "--format=%h%x1f%as%x1f%an%x1f%s"}
+	if search != "" {
+		args = append(args, "-S"+search)
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+
+	var stderr bytes.Buffer
+	gitCmd := exec.CommandContext(ctx, "git", args...)
+	gitCmd.Stderr = &stderr
+	gitCmd.Dir = root
+	out, err := gitCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history of %s: %w\n%s", strings.Join(paths, ", "), err, stderr.String())
+	}
+	return parseChangelog(string(out)), nil
+}
+
+// parseChangelog parses the output of git log, one commit per line, with
+// unit-separated fields
+func parseChangelog(out string) []ChangelogEntry {
+	var entries []ChangelogEntry
+	for line := range strings.SplitSeq(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		entry := ChangelogEntry{
+			Hash:    fields[0],
+			Date:    fields[1],
+			Author:  fields[2],
+			Subject: fields[3],
+		}
+		for _, match := range pullRequestPattern.FindAllStringSubmatch(entry.Subject, -1) {
+			for _, number := range match[1:] {
+				if number != "" && !slices.Contains(entry.PullRequests, number) {
+					entry.PullRequests = append(entry.PullRequests, number)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
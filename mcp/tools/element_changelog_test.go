@@ -0,0 +1,158 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"bennypowers.dev/cem/internal/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/security"
+	"bennypowers.dev/cem/mcp/tools"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Inline: the git repository is built in the test, so that its commits are
+// known; assertions are on the commit subjects in the output
+
+const changelogManifest = `{
+  "schemaVersion": "1.0.0",
+  "modules": [{
+    "kind": "javascript-module",
+    "path": "elements/my-button.js",
+    "declarations": [{"kind": "class", "name": "MyButton", "tagName": "my-button", "customElement": true}]
+  }]
+}`
+
+// commitFile writes a file to the repository at dir and commits it
+func commitFile(t *testing.T, dir, name, content, subject string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "--quiet", "--message", subject)
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test Author",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test Author",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+}
+
+func TestElementChangelog(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	commitFile(t, dir, "package.json", `{"name": "test", "customElements": "custom-elements.json"}`, "chore: init")
+	commitFile(t, dir, "custom-elements.json", changelogManifest, "chore: add manifest")
+	commitFile(t, dir, "elements/my-button.ts", "export class MyButton extends HTMLElement {}\n",
+		"feat(my-button): add my-button (#1)")
+	commitFile(t, dir, "elements/my-button.ts", "export class MyButton extends HTMLElement {\n  variant = 'primary';\n}\n",
+		"feat(my-button): add variant attribute (#2)")
+	commitFile(t, dir, "README.md", "# Test\n", "docs: add readme")
+
+	ws := workspace.NewFileSystemWorkspaceContext(dir)
+	require.NoError(t, ws.Init())
+	registry, err := mcp.NewMCPContext(ws, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+	handler := tools.MakeElementChangelogHandler(mcp.NewMCPContextAdapter(registry))
+
+	call := func(t *testing.T, args map[string]any) string {
+		t.Helper()
+		argsJSON, err := json.Marshal(args)
+		require.NoError(t, err)
+		result, err := handler(context.Background(), &mcpSDK.CallToolRequest{
+			Params: &mcpSDK.CallToolParamsRaw{
+				Name:      "element_changelog",
+				Arguments: json.RawMessage(argsJSON),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		textContent, ok := result.Content[0].(*mcpSDK.TextContent)
+		require.True(t, ok)
+		return textContent.Text
+	}
+
+	t.Run("lists commits to the module's source", func(t *testing.T) {
+		text := call(t, map[string]any{"tagName": "my-button"})
+		assert.Contains(t, text, "feat(my-button): add variant attribute (#2)")
+		assert.Contains(t, text, "feat(my-button): add my-button (#1)")
+		assert.Contains(t, text, "#2")
+		assert.NotContains(t, text, "docs: add readme")
+		assert.NotContains(t, text, "Pass a larger `depth`")
+	})
+
+	t.Run("search finds the commit which added an attribute", func(t *testing.T) {
+		text := call(t, map[string]any{"tagName": "my-button", "search": "variant"})
+		assert.Contains(t, text, "add variant attribute")
+		assert.NotContains(t, text, "add my-button")
+	})
+
+	t.Run("depth limits the history", func(t *testing.T) {
+		text := call(t, map[string]any{"tagName": "my-button", "depth": 1})
+		assert.Contains(t, text, "add variant attribute")
+		assert.NotContains(t, text, "add my-button")
+		assert.Contains(t, text, "Pass a larger `depth`")
+	})
+}
+
+func TestElementChangelog_OutsideAllowedDirs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	commitFile(t, dir, "package.json", `{"name": "test", "customElements": "custom-elements.json"}`, "chore: init")
+	commitFile(t, dir, "custom-elements.json", changelogManifest, "chore: add manifest")
+	commitFile(t, dir, ".config/cem.yaml", "mcp:\n  allowedDirs:\n    - docs\n", "chore: add config")
+
+	ws := workspace.NewFileSystemWorkspaceContext(dir)
+	require.NoError(t, ws.Init())
+	registry, err := mcp.NewMCPContext(ws, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+	handler := tools.MakeElementChangelogHandler(mcp.NewMCPContextAdapter(registry))
+
+	_, err = handler(context.Background(), &mcpSDK.CallToolRequest{
+		Params: &mcpSDK.CallToolParamsRaw{
+			Name:      "element_changelog",
+			Arguments: json.RawMessage(`{"tagName": "my-button"}`),
+		},
+	})
+	assert.ErrorIs(t, err, security.ErrOutsideScope)
+}
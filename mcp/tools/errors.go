@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrorCode is a machine-readable reason for a tool failure, which clients
+// can use to decide whether and how to retry
+type ErrorCode string
+
+const (
+	// ErrorElementNotFound means no loaded manifest defines the tag name.
+	// Retrying with the same tag name will not help.
+	ErrorElementNotFound ErrorCode = "element_not_found"
+	// ErrorAmbiguousTag means the tag name was not found as given, but
+	// could refer to more than one element. Retry with one of the candidates.
+	ErrorAmbiguousTag ErrorCode = "ambiguous_tag"
+	// ErrorManifestStale means the manifests could not be reloaded after they
+	// changed, so answers may not reflect the workspace. Retry after
+	// regenerating the manifest.
+	ErrorManifestStale ErrorCode = "manifest_stale"
+	// ErrorContextTooLarge means the input exceeds MaxInputLength. Retry with
+	// a smaller snippet.
+	ErrorContextTooLarge ErrorCode = "context_too_large"
+	// ErrorInvalidArguments means the arguments don't match the input schema
+	ErrorInvalidArguments ErrorCode = "invalid_arguments"
+	// ErrorInternal is any other failure
+	ErrorInternal ErrorCode = "internal_error"
+)
+
+// MaxInputLength is the largest HTML input, in bytes, which tools accept
+const MaxInputLength = 1 << 20
+
+// ToolError is a tool failure with a machine-readable code
+type ToolError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *ToolError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// NewToolError creates a tool error with a formatted message
+func NewToolError(code ErrorCode, format string, args ...any) *ToolError {
+	return &ToolError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// BuildToolErrorResponse creates an error result carrying the error's code,
+// both in the text and as structured content, e.g.
+// {"error": {"code": "element_not_found", "message": "..."}}
+func BuildToolErrorResponse(toolErr *ToolError) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("%s\n\nError code: %s", toolErr.Message, toolErr.Code),
+			},
+		},
+		StructuredContent: map[string]any{
+			"error": map[string]any{
+				"code":    toolErr.Code,
+				"message": toolErr.Message,
+			},
+		},
+	}
+}
+
+// WithErrorCodes returns a tool handler which reports handler's errors as
+// coded error results, rather than as protocol errors without a code.
+// Errors which are not a ToolError are reported as ErrorInternal.
+func WithErrorCodes(handler mcp.ToolHandler) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		if err == nil {
+			return result, nil
+		}
+		var toolErr *ToolError
+		if !errors.As(err, &toolErr) {
+			toolErr = &ToolError{Code: ErrorInternal, Message: err.Error()}
+		}
+		return BuildToolErrorResponse(toolErr), nil
+	}
+}
+
+// checkInputLength returns a context_too_large error when input exceeds
+// MaxInputLength
+func checkInputLength(name, input string) error {
+	if len(input) > MaxInputLength {
+		return NewToolError(ErrorContextTooLarge,
+			"%s is %d bytes, more than the limit of %d bytes; send a smaller snippet",
+			name, len(input), MaxInputLength)
+	}
+	return nil
+}
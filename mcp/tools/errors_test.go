@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/mcp/tools"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorCode returns the code of a coded error result
+func errorCode(t *testing.T, result *mcpSDK.CallToolResult) tools.ErrorCode {
+	t.Helper()
+	require.NotNil(t, result)
+	assert.True(t, result.IsError)
+	structured, ok := result.StructuredContent.(map[string]any)
+	require.True(t, ok, "error result should have structured content")
+	errorContent, ok := structured["error"].(map[string]any)
+	require.True(t, ok, "structured content should have an error object")
+	code, ok := errorContent["code"].(tools.ErrorCode)
+	require.True(t, ok, "error object should have a code")
+	return code
+}
+
+func TestLookupElement_ErrorCodes(t *testing.T) {
+	registry := getTestRegistry(t)
+
+	tests := []struct {
+		name     string
+		tagName  string
+		code     tools.ErrorCode
+		contains string
+	}{
+		{"unknown tag", "non-existent", tools.ErrorElementNotFound, "not found in workspace"},
+		{"tag differing in case", "Button-Element", tools.ErrorElementNotFound, "Did you mean 'button-element'?"},
+		{"tag matching several prefixed elements", "element", tools.ErrorAmbiguousTag, "button-element, card-element"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			element, errorResponse, err := tools.LookupElement(registry, test.tagName)
+			require.NoError(t, err)
+			assert.Nil(t, element)
+			assert.Equal(t, test.code, errorCode(t, errorResponse))
+			text := errorResponse.Content[0].(*mcpSDK.TextContent).Text
+			assert.Contains(t, text, test.contains)
+		})
+	}
+}
+
+func TestWithErrorCodes(t *testing.T) {
+	t.Run("passes results through", func(t *testing.T) {
+		want := tools.BuildSuccessResponse("ok")
+		handler := tools.WithErrorCodes(func(context.Context, *mcpSDK.CallToolRequest) (*mcpSDK.CallToolResult, error) {
+			return want, nil
+		})
+		result, err := handler(context.Background(), &mcpSDK.CallToolRequest{})
+		require.NoError(t, err)
+		assert.Same(t, want, result)
+	})
+
+	t.Run("keeps the code of tool errors", func(t *testing.T) {
+		handler := tools.WithErrorCodes(func(context.Context, *mcpSDK.CallToolRequest) (*mcpSDK.CallToolResult, error) {
+			return nil, errors.Join(errors.New("wrapped"), tools.NewToolError(tools.ErrorContextTooLarge, "too big"))
+		})
+		result, err := handler(context.Background(), &mcpSDK.CallToolRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, tools.ErrorContextTooLarge, errorCode(t, result))
+	})
+
+	t.Run("codes other errors as internal", func(t *testing.T) {
+		handler := tools.WithErrorCodes(func(context.Context, *mcpSDK.CallToolRequest) (*mcpSDK.CallToolResult, error) {
+			return nil, errors.New("boom")
+		})
+		result, err := handler(context.Background(), &mcpSDK.CallToolRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, tools.ErrorInternal, errorCode(t, result))
+	})
+}
+
+func TestValidateHtml_InputTooLarge(t *testing.T) {
+	registry := getTestRegistry(t)
+	handler := tools.WithErrorCodes(tools.MakeValidateHtmlHandler(registry))
+
+	args, err := json.Marshal(map[string]string{
+		"html": "<p>" + strings.Repeat("a", tools.MaxInputLength) + "</p>",
+	})
+	require.NoError(t, err)
+
+	result, err := handler(context.Background(), &mcpSDK.CallToolRequest{
+		Params: &mcpSDK.CallToolParamsRaw{
+			Name:      "validate_html",
+			Arguments: json.RawMessage(args),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tools.ErrorContextTooLarge, errorCode(t, result))
+}
+
+func TestTools_Annotations(t *testing.T) {
+	registry := getTestRegistry(t)
+
+	toolDefs, err := tools.Tools(registry)
+	require.NoError(t, err)
+
+	for _, toolDef := range toolDefs {
+		t.Run(toolDef.Name, func(t *testing.T) {
+			annotations := toolDef.Annotations()
+			assert.NotEmpty(t, annotations.Title, "tool should have a title")
+			assert.Equal(t, !toolDef.Mutating, annotations.ReadOnlyHint)
+			require.NotNil(t, annotations.DestructiveHint)
+			assert.Equal(t, toolDef.Mutating && toolDef.Destructive, *annotations.DestructiveHint)
+			require.NotNil(t, annotations.OpenWorldHint)
+			assert.False(t, *annotations.OpenWorldHint)
+		})
+	}
+}
@@ -19,20 +19,22 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"bennypowers.dev/cem/mcp/types"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// ParseToolArgs parses JSON arguments from an MCP request into the specified type
+// ParseToolArgs parses JSON arguments from an MCP request into the specified type.
+// Arguments which don't match the type are reported as ErrorInvalidArguments.
 func ParseToolArgs[T any](req *mcp.CallToolRequest) (T, error) {
 	var args T
 	if req.Params.Arguments != nil {
 		if argsData, err := json.Marshal(req.Params.Arguments); err != nil {
-			return args, fmt.Errorf("failed to marshal args: %w", err)
+			return args, NewToolError(ErrorInvalidArguments, "failed to marshal args: %v", err)
 		} else if err := json.Unmarshal(argsData, &args); err != nil {
-			return args, fmt.Errorf("failed to unmarshal args: %w", err)
+			return args, NewToolError(ErrorInvalidArguments, "failed to unmarshal args: %v", err)
 		}
 	}
 	return args, nil
@@ -45,17 +47,43 @@ func ParseToolArgs[T any](req *mcp.CallToolRequest) (T, error) {
 // - If lookup failed: (nil, nil, error)
 func LookupElement(registry types.MCPContext, tagName string) (types.ElementInfo, *mcp.CallToolResult, error) {
 	element, err := registry.ElementInfo(tagName)
-	if err != nil {
-		// Element not found - return user-friendly response, not an error
-		return nil, &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{
-					Text: fmt.Sprintf("Element '%s' not found in workspace", tagName),
-				},
-			},
-		}, nil
+	if err == nil {
+		return element, nil, nil
+	}
+
+	// Element not found - return user-friendly response, not an error,
+	// with a code telling the client whether retrying could help
+	if manifestErr := registry.ManifestError(); manifestErr != nil {
+		return nil, BuildToolErrorResponse(NewToolError(ErrorManifestStale,
+			"Element '%s' not found in workspace, but the manifests could not be reloaded: %v", tagName, manifestErr)), nil
+	}
+	switch candidates := tagCandidates(registry, tagName); len(candidates) {
+	case 0:
+		return nil, BuildToolErrorResponse(NewToolError(ErrorElementNotFound,
+			"Element '%s' not found in workspace", tagName)), nil
+	case 1:
+		return nil, BuildToolErrorResponse(NewToolError(ErrorElementNotFound,
+			"Element '%s' not found in workspace. Did you mean '%s'?", tagName, candidates[0])), nil
+	default:
+		return nil, BuildToolErrorResponse(NewToolError(ErrorAmbiguousTag,
+			"Element '%s' not found in workspace. It could refer to any of: %s", tagName, strings.Join(candidates, ", "))), nil
+	}
+}
+
+// tagCandidates returns the tag names which tagName could have meant: those
+// equal to it but for case, and those which add a prefix to it, e.g.
+// "my-button" for "button"
+func tagCandidates(registry types.MCPContext, tagName string) []string {
+	name := strings.ToLower(tagName)
+	var candidates []string
+	for candidate := range registry.AllElements() {
+		lower := strings.ToLower(candidate)
+		if lower == name || strings.HasSuffix(lower, "-"+name) {
+			candidates = append(candidates, candidate)
+		}
 	}
-	return element, nil, nil
+	slices.Sort(candidates)
+	return candidates
 }
 
 // BuildErrorResponse creates a standard error response for MCP tools
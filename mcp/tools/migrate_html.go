@@ -0,0 +1,175 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	mcpTypes "bennypowers.dev/cem/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// MigrateHtmlArgs represents the arguments for the migrate_html tool
+type MigrateHtmlArgs struct {
+	Html string `json:"html"`
+}
+
+// MigrationElement is the manifest data of an element of the design system,
+// for choosing replacements for legacy markup
+type MigrationElement struct {
+	TagName    string
+	Summary    string
+	Attributes []string // e.g. "`variant`: `'primary' | 'secondary'`"
+	Slots      []string // e.g. "`header`", with "default" for the default slot
+	Guidelines []string
+}
+
+// MigrationTemplateData is the template data for the migrate_html tool
+type MigrationTemplateData struct {
+	BaseTemplateData
+	Structure string   // the legacy start tags, indented by nesting
+	Classes   []string // the distinct classes of the legacy markup
+	Roles     []string // the distinct roles of the legacy markup
+	Migrated  []string // custom elements already in the markup
+	Elements  []MigrationElement
+}
+
+// handleMigrateHtml describes legacy markup alongside the elements of the
+// design system, so that the client can map the old classes and structure
+// to new elements, attributes, and slots
+func handleMigrateHtml(
+	_ context.Context,
+	req *mcp.CallToolRequest,
+	registry mcpTypes.MCPContext,
+) (*mcp.CallToolResult, error) {
+	args, err := ParseToolArgs[MigrateHtmlArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkInputLength("html", args.Html); err != nil {
+		return nil, err
+	}
+
+	data, err := migrateHtml(args.Html, registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate html: %w", err)
+	}
+
+	text, err := RenderTemplate("html_migration", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render migration: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// migrateHtml parses a legacy HTML fragment into its structure, and collects
+// the manifest data of the elements in the registry
+func migrateHtml(src string, registry mcpTypes.MCPContext) (MigrationTemplateData, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return MigrationTemplateData{}, err
+	}
+
+	var data MigrationTemplateData
+	var b strings.Builder
+	for _, n := range nodes {
+		describeLegacy(&b, &data, n, 0)
+	}
+	data.Structure = strings.TrimSuffix(b.String(), "\n")
+
+	elements := registry.AllElements()
+	for _, tagName := range slices.Sorted(maps.Keys(elements)) {
+		data.Elements = append(data.Elements, migrationElement(elements[tagName]))
+	}
+	return data, nil
+}
+
+// describeLegacy writes the start tag and text of each element under n, one
+// per line and indented by depth, collecting its classes and roles
+func describeLegacy(b *strings.Builder, data *MigrationTemplateData, n *html.Node, depth int) {
+	switch n.Type {
+	case html.TextNode:
+		if text := strings.Join(strings.Fields(n.Data), " "); text != "" {
+			fmt.Fprintf(b, "%s%s\n", strings.Repeat("  ", depth), text)
+		}
+		return
+	case html.ElementNode:
+		fmt.Fprintf(b, "%s%s\n", strings.Repeat("  ", depth), startTag(n))
+		if strings.Contains(n.Data, "-") {
+			if !slices.Contains(data.Migrated, n.Data) {
+				data.Migrated = append(data.Migrated, n.Data)
+			}
+		}
+		for _, class := range strings.Fields(attr(n, "class")) {
+			if !slices.Contains(data.Classes, class) {
+				data.Classes = append(data.Classes, class)
+			}
+		}
+		if role := attr(n, "role"); role != "" && !slices.Contains(data.Roles, role) {
+			data.Roles = append(data.Roles, role)
+		}
+		depth++
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		describeLegacy(b, data, child, depth)
+	}
+}
+
+// migrationElement collects the manifest data of an element which bears on
+// replacing legacy markup with it
+func migrationElement(info mcpTypes.ElementInfo) MigrationElement {
+	e := MigrationElement{
+		TagName:    info.TagName(),
+		Summary:    info.Summary(),
+		Guidelines: info.Guidelines(),
+	}
+	if e.Summary == "" {
+		e.Summary = info.Description()
+	}
+	for _, a := range info.Attributes() {
+		entry := fmt.Sprintf("`%s`", a.Name)
+		if a.Type != nil && a.Type.Text != "" {
+			entry += fmt.Sprintf(": `%s`", a.Type.Text)
+		}
+		e.Attributes = append(e.Attributes, entry)
+	}
+	for _, slot := range info.Slots() {
+		if slot.Name == "" {
+			e.Slots = append(e.Slots, "default")
+		} else {
+			e.Slots = append(e.Slots, fmt.Sprintf("`%s`", slot.Name))
+		}
+	}
+	return e
+}
@@ -0,0 +1,341 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	mcpTypes "bennypowers.dev/cem/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SetAttributesArgs represents the arguments for the set_attributes tool
+type SetAttributesArgs struct {
+	Html        string `json:"html"`
+	Instruction string `json:"instruction"`
+	TagName     string `json:"tagName,omitempty"`
+}
+
+// AttributeChange is one attribute-level edit: an attribute added, removed,
+// or given a new value
+type AttributeChange struct {
+	Name   string
+	Change string // "added", "removed", or "changed"
+	Before string // the attribute as written before, e.g. `size="small"`
+	After  string // the attribute as written after, e.g. `size="large"`
+	Reason string // the words of the instruction which asked for it
+}
+
+// AttributeDiffTemplateData is the template data for the set_attributes tool
+type AttributeDiffTemplateData struct {
+	BaseTemplateData
+	TagName   string
+	Before    string
+	After     string
+	Changes   []AttributeChange
+	Unchanged []string // requested attributes which already had their value
+	Warnings  []string
+	Unmatched []string
+}
+
+// negationWords turn off the attribute named after them, e.g. "not disabled"
+var negationWords = map[string]bool{
+	"no": true, "not": true, "non": true, "remove": true, "unset": true,
+	"without": true, "drop": true, "clear": true,
+}
+
+// instructionAliases normalizes the abbreviations common in size names
+var instructionAliases = map[string]string{"sm": "small", "md": "medium", "lg": "large"}
+
+// instructionFillers are words of an instruction which name no attribute
+var instructionFillers = map[string]bool{
+	"a": true, "an": true, "and": true, "be": true, "it": true, "its": true,
+	"make": true, "set": true, "should": true, "the": true, "to": true,
+	"with": true, "is": true, "of": true, "attribute": true, "also": true,
+	"please": true, "change": true, "use": true, "give": true, "as": true,
+}
+
+// handleSetAttributes applies a plain-language instruction, like "make it
+// disabled and large", to an element in existing markup, and returns only
+// the attributes which change
+func handleSetAttributes(
+	_ context.Context,
+	req *mcp.CallToolRequest,
+	registry mcpTypes.MCPContext,
+) (*mcp.CallToolResult, error) {
+	args, err := ParseToolArgs[SetAttributesArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Instruction) == "" {
+		return nil, NewToolError(ErrorInvalidArguments, "instruction is required")
+	}
+	if err := checkInputLength("html", args.Html); err != nil {
+		return nil, err
+	}
+	if err := checkInputLength("instruction", args.Instruction); err != nil {
+		return nil, err
+	}
+
+	el, err := findTargetElement(args.Html, args.TagName)
+	if err != nil {
+		return nil, err
+	}
+	element, errorResponse, err := LookupElement(registry, el.Data)
+	if err != nil {
+		return nil, err
+	}
+	if errorResponse != nil {
+		return errorResponse, nil
+	}
+
+	data := setAttributes(el, element, args.Instruction)
+	text, err := RenderTemplate("attribute_diff", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render attribute diff: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// findTargetElement returns the first element named tagName in the markup,
+// or without a tagName, its first custom element
+func findTargetElement(src, tagName string) (*html.Node, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+	var find func(n *html.Node) *html.Node
+	find = func(n *html.Node) *html.Node {
+		if n.Type == html.ElementNode &&
+			(n.Data == tagName || tagName == "" && strings.Contains(n.Data, "-")) {
+			return n
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if found := find(child); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	for _, n := range nodes {
+		if found := find(n); found != nil {
+			return found, nil
+		}
+	}
+	if tagName != "" {
+		return nil, NewToolError(ErrorInvalidArguments, "html contains no <%s> element", tagName)
+	}
+	return nil, NewToolError(ErrorInvalidArguments, "html contains no custom element")
+}
+
+// attributeEdit is an attribute value requested by the instruction. A nil
+// value removes the attribute; an empty one sets a boolean attribute.
+type attributeEdit struct {
+	name   string
+	value  *string
+	reason string
+}
+
+// setAttributes matches the words of an instruction against the element's
+// attributes, then diffs the requested values against the markup. Words
+// match:
+//   - the name of a boolean attribute, e.g. "disabled", which turns it on
+//   - a value of one enum attribute, e.g. "large", which sets it
+//   - the name of another attribute followed by a value, e.g. "elevation 3"
+//
+// A negation before a match, e.g. "not disabled", removes the attribute.
+func setAttributes(el *html.Node, element mcpTypes.ElementInfo, instruction string) AttributeDiffTemplateData {
+	data := AttributeDiffTemplateData{TagName: element.TagName(), Before: startTag(el)}
+	attributes := element.Attributes()
+	lookup := func(name string) *mcpTypes.Attribute {
+		if i := slices.IndexFunc(attributes, func(a mcpTypes.Attribute) bool { return a.Name == name }); i != -1 {
+			return &attributes[i]
+		}
+		return nil
+	}
+
+	words := instructionWords(instruction)
+	var edits []attributeEdit
+	negated := ""
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if negationWords[word] {
+			negated = word
+			continue
+		}
+		reason := word
+		if negated != "" {
+			reason = negated + " " + word
+		}
+
+		if a := lookup(word); a != nil {
+			switch {
+			case negated != "":
+				edits = append(edits, attributeEdit{name: a.Name, reason: reason})
+			case isBooleanAttribute(a):
+				edits = append(edits, attributeEdit{name: a.Name, value: new(string), reason: reason})
+			default:
+				// Expect a value, e.g. "size large" or "elevation to 3"
+				j := i + 1
+				for j < len(words) && instructionFillers[words[j]] {
+					j++
+				}
+				if j == len(words) {
+					data.Warnings = append(data.Warnings, fmt.Sprintf("`%s` needs a value", a.Name))
+					break
+				}
+				value := words[j]
+				i = j
+				if err := a.GetValidationError(value); err != nil {
+					data.Warnings = append(data.Warnings, err.Error())
+					break
+				}
+				if a.Type != nil && a.Type.Text == "number" && !isNumber(value) {
+					data.Warnings = append(data.Warnings, fmt.Sprintf("`%s` is a number, not %q", a.Name, value))
+					break
+				}
+				edits = append(edits, attributeEdit{name: a.Name, value: &value, reason: word + " " + value})
+			}
+			negated = ""
+			continue
+		}
+
+		var matches []*mcpTypes.Attribute
+		for j := range attributes {
+			if a := &attributes[j]; a.IsEnum() && a.IsValidValue(word) {
+				matches = append(matches, a)
+			}
+		}
+		switch {
+		case len(matches) == 1 && negated != "":
+			// "not large" only removes the attribute when it is large
+			if current, ok := attrValue(el, matches[0].Name); ok && current == word {
+				edits = append(edits, attributeEdit{name: matches[0].Name, reason: reason})
+			}
+		case len(matches) == 1:
+			value := word
+			edits = append(edits, attributeEdit{name: matches[0].Name, value: &value, reason: reason})
+		case len(matches) > 1:
+			names := make([]string, len(matches))
+			for j, a := range matches {
+				names[j] = fmt.Sprintf("`%s`", a.Name)
+			}
+			data.Warnings = append(data.Warnings, fmt.Sprintf(
+				"%q is a value of %s; name the attribute, e.g. \"%s %s\"",
+				word, strings.Join(names, " and "), matches[0].Name, word))
+		case !instructionFillers[word]:
+			data.Unmatched = append(data.Unmatched, word)
+		}
+		if !instructionFillers[word] {
+			negated = ""
+		}
+	}
+
+	after := &html.Node{Type: html.ElementNode, Data: el.Data, Attr: slices.Clone(el.Attr)}
+	for _, edit := range edits {
+		current, present := attrValue(after, edit.name)
+		change := AttributeChange{Name: edit.name, Reason: edit.reason}
+		if present {
+			change.Before = formatAttribute(edit.name, current)
+		}
+		switch {
+		case edit.value == nil && !present,
+			edit.value != nil && present && current == *edit.value:
+			data.Unchanged = append(data.Unchanged, edit.name)
+			continue
+		case edit.value == nil:
+			change.Change = "removed"
+			after.Attr = slices.DeleteFunc(after.Attr, func(a html.Attribute) bool { return a.Key == edit.name })
+		case present:
+			change.Change = "changed"
+			change.After = formatAttribute(edit.name, *edit.value)
+			for i := range after.Attr {
+				if after.Attr[i].Key == edit.name {
+					after.Attr[i].Val = *edit.value
+				}
+			}
+		default:
+			change.Change = "added"
+			change.After = formatAttribute(edit.name, *edit.value)
+			after.Attr = append(after.Attr, html.Attribute{Key: edit.name, Val: *edit.value})
+		}
+		// A later request for the same attribute supersedes an earlier one
+		data.Changes = slices.DeleteFunc(data.Changes, func(c AttributeChange) bool { return c.Name == edit.name })
+		data.Changes = append(data.Changes, change)
+	}
+	data.After = startTag(after)
+	return data
+}
+
+// instructionWords splits an instruction into lower-case words, normalizing
+// common abbreviations, e.g. "lg" to "large"
+func instructionWords(instruction string) []string {
+	words := strings.FieldsFunc(strings.ToLower(instruction), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '.'
+	})
+	for i, word := range words {
+		word = strings.Trim(word, "-.")
+		if alias, ok := instructionAliases[word]; ok {
+			word = alias
+		}
+		words[i] = word
+	}
+	return slices.DeleteFunc(words, func(word string) bool { return word == "" })
+}
+
+func isBooleanAttribute(a *mcpTypes.Attribute) bool {
+	return a.Type != nil && a.Type.Text == "boolean"
+}
+
+func isNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+func attrValue(el *html.Node, name string) (string, bool) {
+	for _, a := range el.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func formatAttribute(name, value string) string {
+	if value == "" {
+		return name
+	}
+	return fmt.Sprintf("%s=%q", name, value)
+}
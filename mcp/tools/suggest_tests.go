@@ -0,0 +1,486 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	M "bennypowers.dev/cem/manifest"
+	mcpTypes "bennypowers.dev/cem/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Test frameworks which suggest_tests writes snippets for
+const (
+	frameworkWebTestRunner = "web-test-runner"
+	frameworkPlaywright    = "playwright"
+)
+
+// frameworkPackages are the packages which show that a project tests with a
+// framework
+var frameworkPackages = map[string]string{
+	"@web/test-runner": frameworkWebTestRunner,
+	"@playwright/test": frameworkPlaywright,
+}
+
+// SuggestTestsArgs represents the arguments for the suggest_tests tool
+type SuggestTestsArgs struct {
+	TagName   string `json:"tagName"`
+	Framework string `json:"framework,omitempty"`
+}
+
+// TestScenario is one case to test. Scenarios with a kind also get a test
+// in each snippet.
+type TestScenario struct {
+	Text       string
+	Deprecated bool
+
+	kind      string // "attribute", "default", "event", or "slot"
+	attribute string
+	property  string
+	value     string // the attribute's value, or the name of the slot
+	literal   string // the property's expected value, as JavaScript
+	event     string
+}
+
+// TestScenarioGroup is the scenarios for one part of the element's API
+type TestScenarioGroup struct {
+	Title     string
+	Scenarios []TestScenario
+}
+
+// TestSnippet is a test file for one framework
+type TestSnippet struct {
+	Framework string
+	Language  string
+	Code      string
+}
+
+// TestSuggestionsData is the template data for the suggest_tests tool
+type TestSuggestionsData struct {
+	BaseTemplateData
+	TagName  string
+	Groups   []TestScenarioGroup
+	Snippets []TestSnippet
+	// Detected is true when the frameworks came from package.json
+	Detected bool
+}
+
+// handleSuggestTests derives test scenarios from an element's manifest,
+// with snippets in the test frameworks the project uses
+func handleSuggestTests(
+	_ context.Context,
+	req *mcp.CallToolRequest,
+	registry mcpTypes.MCPContext,
+) (*mcp.CallToolResult, error) {
+	args, err := ParseToolArgs[SuggestTestsArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	element, errorResponse, err := LookupElement(registry, args.TagName)
+	if err != nil {
+		return nil, err
+	}
+	if errorResponse != nil {
+		return errorResponse, nil
+	}
+
+	data := TestSuggestionsData{
+		TagName: element.TagName(),
+		Groups:  testScenarios(element),
+	}
+
+	deps := projectDependencies(registry)
+	var frameworks []string
+	switch args.Framework {
+	case "", "auto":
+		data.Detected = true
+		for _, pkg := range []string{"@web/test-runner", "@playwright/test"} {
+			if _, ok := deps[pkg]; ok {
+				frameworks = append(frameworks, frameworkPackages[pkg])
+			}
+		}
+	case frameworkWebTestRunner, frameworkPlaywright:
+		frameworks = []string{args.Framework}
+	case "none":
+	default:
+		return nil, NewToolError(ErrorInvalidArguments,
+			"unknown framework %q: use auto, %s, %s, or none", args.Framework, frameworkWebTestRunner, frameworkPlaywright)
+	}
+
+	language := "js"
+	if _, ok := deps["typescript"]; ok {
+		language = "ts"
+	}
+	for _, framework := range frameworks {
+		snippet := TestSnippet{Framework: framework, Language: language}
+		switch framework {
+		case frameworkWebTestRunner:
+			snippet.Code = webTestRunnerSnippet(element, data.Groups, language)
+		case frameworkPlaywright:
+			snippet.Code = playwrightSnippet(element, data.Groups)
+		}
+		data.Snippets = append(data.Snippets, snippet)
+	}
+
+	text, err := RenderTemplate("test_suggestions", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render test suggestions: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// testScenarios derives the scenarios to test from each attribute's value
+// space, and from the element's events, slots, and deprecations
+func testScenarios(element mcpTypes.ElementInfo) []TestScenarioGroup {
+	tagName := element.TagName()
+	var groups []TestScenarioGroup
+	var deprecations []TestScenario
+	deprecate := func(what string, deprecated M.Deprecated) {
+		text := fmt.Sprintf("%s is deprecated, but keeps working until it is removed", what)
+		if reason, ok := deprecated.(M.DeprecatedReason); ok && reason != "" {
+			text += fmt.Sprintf(": %s", reason)
+		}
+		deprecations = append(deprecations, TestScenario{Text: text})
+	}
+	if decl := element.Declaration(); decl != nil && decl.Deprecated != nil {
+		deprecate(fmt.Sprintf("`<%s>`", tagName), decl.Deprecated)
+	}
+
+	var attributes []TestScenario
+	for _, attr := range element.Attributes() {
+		deprecated := attr.Deprecated != nil
+		if deprecated {
+			deprecate(fmt.Sprintf("The `%s` attribute", attr.Name), attr.Deprecated)
+		}
+		base := TestScenario{Deprecated: deprecated, attribute: attr.Name, property: attr.FieldName}
+		scenario := func(text string) TestScenario {
+			s := base
+			s.Text = text
+			return s
+		}
+
+		switch {
+		case isBooleanAttribute(&attr):
+			s := scenario(fmt.Sprintf("`%s` is on when present", attr.Name))
+			s.kind, s.literal = "attribute", "true"
+			attributes = append(attributes, s)
+			s = scenario(fmt.Sprintf("`%s` is off when absent", attr.Name))
+			s.kind, s.literal = "default", "false"
+			attributes = append(attributes, s)
+		case attr.IsEnum():
+			for _, member := range attr.EnumValues() {
+				value, ok := stringLiteral(member)
+				if !ok {
+					continue
+				}
+				s := scenario(fmt.Sprintf("`%s=%q`", attr.Name, value))
+				s.kind, s.value, s.literal = "attribute", value, "'"+jsString(value)+"'"
+				attributes = append(attributes, s)
+			}
+			attributes = append(attributes, scenario(fmt.Sprintf(
+				"An unknown value, e.g. `%s=\"unknown\"`, falls back to the default", attr.Name)))
+		case attr.Type != nil && attr.Type.Text == "number":
+			s := scenario(fmt.Sprintf("`%s=\"1\"` sets the number", attr.Name))
+			s.kind, s.value, s.literal = "attribute", "1", "1"
+			attributes = append(attributes, s)
+			attributes = append(attributes, scenario(fmt.Sprintf(
+				"A value which is not a number, e.g. `%s=\"one\"`, is handled", attr.Name)))
+		default:
+			attributes = append(attributes, scenario(fmt.Sprintf(
+				"`%s` with a value, and with an empty value", attr.Name)))
+		}
+		if attr.Default != "" && !isBooleanAttribute(&attr) {
+			s := scenario(fmt.Sprintf("`%s` defaults to `%s`", attr.Name, attr.Default))
+			s.kind, s.literal = "default", attr.Default
+			attributes = append(attributes, s)
+		}
+		if element.AttributeReflects(attr.Name) && attr.FieldName != "" {
+			attributes = append(attributes, scenario(fmt.Sprintf(
+				"Setting the `%s` property reflects to the `%s` attribute", attr.FieldName, attr.Name)))
+		}
+	}
+	if len(attributes) > 0 {
+		groups = append(groups, TestScenarioGroup{Title: "Attributes", Scenarios: attributes})
+	}
+
+	var events []TestScenario
+	for _, event := range element.Events() {
+		deprecated := event.Deprecated != nil
+		if deprecated {
+			deprecate(fmt.Sprintf("The `%s` event", event.Name), event.Deprecated)
+		}
+		text := fmt.Sprintf("Fires `%s`", event.Name)
+		if description := strings.TrimSpace(firstNonEmpty(event.Summary, event.Description)); description != "" {
+			text += fmt.Sprintf(": %s", strings.TrimSuffix(description, "."))
+		}
+		if event.Type != nil && event.Type.Text != "" && event.Type.Text != "Event" {
+			text += fmt.Sprintf(" (`%s`)", event.Type.Text)
+		}
+		events = append(events, TestScenario{Text: text, Deprecated: deprecated, kind: "event", event: event.Name})
+	}
+	if len(events) > 0 {
+		groups = append(groups, TestScenarioGroup{Title: "Events", Scenarios: events})
+	}
+
+	var slots []TestScenario
+	for _, slot := range element.Slots() {
+		deprecated := slot.Deprecated != nil
+		text := "The default slot projects its content"
+		if slot.Name != "" {
+			text = fmt.Sprintf("The `%s` slot projects `slot=\"%s\"` content", slot.Name, slot.Name)
+		}
+		if deprecated {
+			what := "The default slot"
+			if slot.Name != "" {
+				what = fmt.Sprintf("The `%s` slot", slot.Name)
+			}
+			deprecate(what, slot.Deprecated)
+		}
+		slots = append(slots, TestScenario{Text: text, Deprecated: deprecated, kind: "slot", value: slot.Name})
+	}
+	if len(slots) > 0 {
+		groups = append(groups, TestScenarioGroup{Title: "Slots", Scenarios: slots})
+	}
+
+	if len(deprecations) > 0 {
+		groups = append(groups, TestScenarioGroup{Title: "Deprecations", Scenarios: deprecations})
+	}
+	return groups
+}
+
+// webTestRunnerSnippet writes the scenarios as @web/test-runner tests, with
+// the fixtures and assertions of @open-wc/testing
+func webTestRunnerSnippet(element mcpTypes.ElementInfo, groups []TestScenarioGroup, language string) string {
+	tagName := element.TagName()
+	fixture := "fixture"
+	if language == "ts" {
+		fixture = "fixture<HTMLElement>"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "import { expect, fixture, html, oneEvent } from '@open-wc/testing';\n")
+	if specifier := moduleSpecifier(element); specifier != "" {
+		fmt.Fprintf(&b, "import '%s';\n\n", specifier)
+	} else {
+		fmt.Fprintf(&b, "// Import the module which defines <%s>\n\n", tagName)
+	}
+	fmt.Fprintf(&b, "describe('<%s>', function() {\n", tagName)
+	fmt.Fprintf(&b, "  it('is accessible', async function() {\n")
+	fmt.Fprintf(&b, "    const el = await %s(html`<%s></%s>`);\n", fixture, tagName, tagName)
+	fmt.Fprintf(&b, "    await expect(el).to.be.accessible();\n")
+	fmt.Fprintf(&b, "  });\n")
+
+	for _, group := range groups {
+		for _, s := range group.Scenarios {
+			switch s.kind {
+			case "attribute":
+				attr := s.attribute
+				if s.value != "" {
+					attr = fmt.Sprintf("%s=%q", s.attribute, s.value)
+				}
+				fmt.Fprintf(&b, "\n  it('renders with %s', async function() {\n", jsString(attr))
+				fmt.Fprintf(&b, "    const el = await %s(html`<%s %s></%s>`);\n", fixture, tagName, attr, tagName)
+				if s.property != "" {
+					fmt.Fprintf(&b, "    expect(el).to.have.property('%s', %s);\n", s.property, s.literal)
+				}
+				fmt.Fprintf(&b, "    await expect(el).to.be.accessible();\n")
+				fmt.Fprintf(&b, "  });\n")
+			case "default":
+				if s.property == "" {
+					continue
+				}
+				fmt.Fprintf(&b, "\n  it('defaults %s to %s', async function() {\n", s.property, jsString(strings.Trim(s.literal, `'"`)))
+				fmt.Fprintf(&b, "    const el = await %s(html`<%s></%s>`);\n", fixture, tagName, tagName)
+				fmt.Fprintf(&b, "    expect(el).to.have.property('%s', %s);\n", s.property, s.literal)
+				fmt.Fprintf(&b, "  });\n")
+			case "event":
+				fmt.Fprintf(&b, "\n  it('fires %s', async function() {\n", s.event)
+				fmt.Fprintf(&b, "    const el = await %s(html`<%s></%s>`);\n", fixture, tagName, tagName)
+				fmt.Fprintf(&b, "    // Replace with the interaction which fires the event\n")
+				fmt.Fprintf(&b, "    setTimeout(() => el.click());\n")
+				fmt.Fprintf(&b, "    const event = await oneEvent(el, '%s');\n", s.event)
+				fmt.Fprintf(&b, "    expect(event).to.be.an.instanceOf(Event);\n")
+				fmt.Fprintf(&b, "  });\n")
+			case "slot":
+				fmt.Fprintf(&b, "\n  it('projects content into the %s slot', async function() {\n", slotLabel(s.value))
+				fmt.Fprintf(&b, "    const el = await %s(html`<%s>%s</%s>`);\n", fixture, tagName, slotContent(s.value), tagName)
+				fmt.Fprintf(&b, "    expect(el.querySelector('span')?.assignedSlot?.name).to.equal('%s');\n", s.value)
+				fmt.Fprintf(&b, "  });\n")
+			}
+		}
+	}
+	b.WriteString("});\n")
+	return b.String()
+}
+
+// playwrightSnippet writes the scenarios as Playwright tests, which run
+// against the element's first demo
+func playwrightSnippet(element mcpTypes.ElementInfo, groups []TestScenarioGroup) string {
+	tagName := element.TagName()
+	page := "/"
+	if decl := element.Declaration(); decl != nil && len(decl.Demos) > 0 && decl.Demos[0].URL != "" {
+		page = decl.Demos[0].URL
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "import { test, expect } from '@playwright/test';\n\n")
+	fmt.Fprintf(&b, "test.describe('<%s>', () => {\n", tagName)
+	fmt.Fprintf(&b, "  test.beforeEach(async ({ page }) => {\n")
+	if page == "/" {
+		fmt.Fprintf(&b, "    // Replace with a page which loads <%s>\n", tagName)
+	}
+	fmt.Fprintf(&b, "    await page.goto('%s');\n", jsString(page))
+	fmt.Fprintf(&b, "  });\n")
+
+	for _, group := range groups {
+		for _, s := range group.Scenarios {
+			switch s.kind {
+			case "attribute":
+				attr := s.attribute
+				if s.value != "" {
+					attr = fmt.Sprintf("%s=%q", s.attribute, s.value)
+				}
+				fmt.Fprintf(&b, "\n  test('renders with %s', async ({ page }) => {\n", jsString(attr))
+				fmt.Fprintf(&b, "    const el = page.locator('%s').first();\n", tagName)
+				fmt.Fprintf(&b, "    await el.evaluate(node => node.setAttribute('%s', '%s'));\n", s.attribute, jsString(s.value))
+				if s.property != "" {
+					fmt.Fprintf(&b, "    await expect(el).toHaveJSProperty('%s', %s);\n", s.property, s.literal)
+				} else {
+					fmt.Fprintf(&b, "    await expect(el).toHaveAttribute('%s', '%s');\n", s.attribute, jsString(s.value))
+				}
+				fmt.Fprintf(&b, "  });\n")
+			case "default":
+				if s.property == "" {
+					continue
+				}
+				fmt.Fprintf(&b, "\n  test('defaults %s to %s', async ({ page }) => {\n", s.property, jsString(strings.Trim(s.literal, `'"`)))
+				fmt.Fprintf(&b, "    const el = page.locator('%s:not([%s])').first();\n", tagName, s.attribute)
+				fmt.Fprintf(&b, "    await expect(el).toHaveJSProperty('%s', %s);\n", s.property, s.literal)
+				fmt.Fprintf(&b, "  });\n")
+			case "event":
+				fmt.Fprintf(&b, "\n  test('fires %s', async ({ page }) => {\n", s.event)
+				fmt.Fprintf(&b, "    const el = page.locator('%s').first();\n", tagName)
+				fmt.Fprintf(&b, "    const fired = el.evaluate(node => new Promise(resolve =>\n")
+				fmt.Fprintf(&b, "      node.addEventListener('%s', () => resolve(true), { once: true })));\n", s.event)
+				fmt.Fprintf(&b, "    // Replace with the interaction which fires the event\n")
+				fmt.Fprintf(&b, "    await el.click();\n")
+				fmt.Fprintf(&b, "    expect(await fired).toBe(true);\n")
+				fmt.Fprintf(&b, "  });\n")
+			case "slot":
+				fmt.Fprintf(&b, "\n  test('projects content into the %s slot', async ({ page }) => {\n", slotLabel(s.value))
+				fmt.Fprintf(&b, "    const el = page.locator('%s').first();\n", tagName)
+				fmt.Fprintf(&b, "    const slot = await el.evaluate(node => {\n")
+				fmt.Fprintf(&b, "      const span = document.createElement('span');\n")
+				if s.value != "" {
+					fmt.Fprintf(&b, "      span.slot = '%s';\n", s.value)
+				}
+				fmt.Fprintf(&b, "      node.append(span);\n")
+				fmt.Fprintf(&b, "      return span.assignedSlot?.name;\n")
+				fmt.Fprintf(&b, "    });\n")
+				fmt.Fprintf(&b, "    expect(slot).toBe('%s');\n", s.value)
+				fmt.Fprintf(&b, "  });\n")
+			}
+		}
+	}
+	b.WriteString("});\n")
+	return b.String()
+}
+
+// projectDependencies reads the dependencies and devDependencies of the
+// workspace's package.json
+func projectDependencies(registry mcpTypes.MCPContext) map[string]string {
+	deps := make(map[string]string)
+	data, err := registry.FileSystem().ReadFile(filepath.Join(registry.Root(), "package.json"))
+	if err != nil {
+		return deps
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return deps
+	}
+	for name, version := range pkg.Dependencies {
+		deps[name] = version
+	}
+	for name, version := range pkg.DevDependencies {
+		deps[name] = version
+	}
+	return deps
+}
+
+// moduleSpecifier imports the element's module from its package, or
+// returns "" when the manifest doesn't name the module
+func moduleSpecifier(element mcpTypes.ElementInfo) string {
+	module := strings.TrimPrefix(element.Module(), "./")
+	if module == "" {
+		return ""
+	}
+	if element.Package() == "" {
+		return "./" + module
+	}
+	return element.Package() + "/" + module
+}
+
+func slotLabel(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+func slotContent(name string) string {
+	if name == "" {
+		return "<span>Content</span>"
+	}
+	return fmt.Sprintf(`<span slot="%s">Content</span>`, name)
+}
+
+// jsString escapes text for a single-quoted JavaScript string
+func jsString(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(text)
+}
+
+// stringLiteral unquotes a string literal member of a union type, e.g.
+// `"large"`, reporting false for other members, e.g. `undefined`
+func stringLiteral(member string) (string, bool) {
+	member = strings.TrimSpace(member)
+	if len(member) < 2 || member[0] != member[len(member)-1] || !strings.ContainsRune(`"'`+"`", rune(member[0])) {
+		return "", false
+	}
+	return member[1 : len(member)-1], true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+	testworkspace "bennypowers.dev/cem/internal/platform/testutil/workspace"
+	"bennypowers.dev/cem/mcp"
+	"bennypowers.dev/cem/mcp/tools"
+	mcpSDK "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestTests_FixtureGolden(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagName   string
+		framework string
+		golden    string
+	}{
+		{"runner from package.json", "acme-switch", "", "switch-web-test-runner.golden.md"},
+		{"requested framework", "acme-switch", "playwright", "switch-playwright.golden.md"},
+		{"deprecated element without snippets", "acme-spacer", "none", "spacer.golden.md"},
+	}
+
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/suggest-tests")
+	require.NoError(t, workspace.Init())
+
+	// The workspace's filesystem has its package.json, for the test runners
+	registry, err := mcp.NewMCPContext(workspace, workspace.FileSystem())
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	handler := tools.MakeSuggestTestsHandler(mcp.NewMCPContextAdapter(registry))
+	fs := testutil.LoadTestdataFS(t, "../testdata/fixtures/suggest-tests", "/")
+
+	call := func(tagName, framework string) (*mcpSDK.CallToolResult, error) {
+		argsJSON, err := json.Marshal(map[string]any{"tagName": tagName, "framework": framework})
+		require.NoError(t, err)
+		return handler(context.Background(), &mcpSDK.CallToolRequest{
+			Params: &mcpSDK.CallToolParamsRaw{
+				Name:      "suggest_tests",
+				Arguments: json.RawMessage(argsJSON),
+			},
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := call(tt.tagName, tt.framework)
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+
+			textContent, ok := result.Content[0].(*mcpSDK.TextContent)
+			require.True(t, ok)
+
+			expected := testutil.ReadFixture(t, fs, "/"+tt.golden)
+			assert.Equal(t, string(expected), textContent.Text, "Output should match golden file")
+		})
+	}
+
+	t.Run("unknown framework", func(t *testing.T) {
+		_, err := call("acme-switch", "jest")
+		assert.ErrorContains(t, err, `unknown framework "jest"`)
+	})
+}
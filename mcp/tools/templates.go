@@ -75,13 +75,18 @@ type ElementWithIssues struct {
 
 // ValidationIssue represents a specific validation problem
 type ValidationIssue struct {
-	Type      string // "missing-attribute", "invalid-value", "semantic-issue"
-	Element   string
-	Attribute string
-	Expected  string
-	Actual    string
-	Message   string
-	Priority  string // "error", "warning", "info"
+	Type      string `json:"type"` // "missing-attribute", "invalid-value", "semantic-issue"
+	Element   string `json:"element,omitempty"`
+	Attribute string `json:"attribute,omitempty"`
+	Slot      string `json:"slot,omitempty"`
+	Expected  string `json:"expected,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+	Message   string `json:"message"`
+	Priority  string `json:"severity,omitempty"` // "error", "warning", "info"
+	// Line and Column locate the issue in the validated HTML, counting from
+	// 1. They are zero when the position is unknown.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
 }
 
 // ValidationFeature represents available manifest features
@@ -81,11 +81,16 @@ func parseToolDefinition(filename string, registry types.MCPContext) (types.Tool
 	// Create tool definition first
 	toolDef := types.ToolDefinition{
 		Name:         frontmatter.Name,
+		Title:        frontmatter.Title,
 		Description:  description,
 		InputSchema:  frontmatter.InputSchema,
 		DataFetchers: frontmatter.DataFetchers,
 		Template:     frontmatter.Template,
 		ResponseType: frontmatter.ResponseType,
+		Mutating:     frontmatter.Mutating,
+		Destructive:  frontmatter.Destructive,
+		Optional:     frontmatter.Optional,
+		Uncached:     frontmatter.Uncached,
 	}
 
 	// Get the corresponding handler
@@ -111,6 +116,18 @@ func getToolHandler(toolDef types.ToolDefinition, registry types.MCPContext) (mc
 		return makeGenerateConfigHandler(registry), nil
 	case "validate_config":
 		return makeValidateConfigHandler(registry), nil
+	case "check_accessible_names":
+		return makeCheckAccessibleNamesHandler(registry), nil
+	case "migrate_html":
+		return makeMigrateHtmlHandler(registry), nil
+	case "element_changelog":
+		return makeElementChangelogHandler(registry), nil
+	case "compose_pattern":
+		return makeComposePatternHandler(registry), nil
+	case "set_attributes":
+		return makeSetAttributesHandler(registry), nil
+	case "suggest_tests":
+		return makeSuggestTestsHandler(registry), nil
 	default:
 		return nil, fmt.Errorf("unknown tool: %s", toolDef.Name)
 	}
@@ -138,3 +155,69 @@ func makeGenerateHtmlHandler(registry types.MCPContext) mcp.ToolHandler {
 func MakeGenerateHtmlHandler(registry types.MCPContext) mcp.ToolHandler {
 	return makeGenerateHtmlHandler(registry)
 }
+
+func makeCheckAccessibleNamesHandler(registry types.MCPContext) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleCheckAccessibleNames(ctx, req, registry)
+	}
+}
+
+// MakeCheckAccessibleNamesHandler is the exported version for testing
+func MakeCheckAccessibleNamesHandler(registry types.MCPContext) mcp.ToolHandler {
+	return makeCheckAccessibleNamesHandler(registry)
+}
+
+func makeMigrateHtmlHandler(registry types.MCPContext) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleMigrateHtml(ctx, req, registry)
+	}
+}
+
+// MakeMigrateHtmlHandler is the exported version for testing
+func MakeMigrateHtmlHandler(registry types.MCPContext) mcp.ToolHandler {
+	return makeMigrateHtmlHandler(registry)
+}
+
+func makeElementChangelogHandler(registry types.MCPContext) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleElementChangelog(ctx, req, registry)
+	}
+}
+
+// MakeElementChangelogHandler is the exported version for testing
+func MakeElementChangelogHandler(registry types.MCPContext) mcp.ToolHandler {
+	return makeElementChangelogHandler(registry)
+}
+
+func makeComposePatternHandler(registry types.MCPContext) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleComposePattern(ctx, req, registry)
+	}
+}
+
+// MakeComposePatternHandler is the exported version for testing
+func MakeComposePatternHandler(registry types.MCPContext) mcp.ToolHandler {
+	return makeComposePatternHandler(registry)
+}
+
+func makeSetAttributesHandler(registry types.MCPContext) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSetAttributes(ctx, req, registry)
+	}
+}
+
+// MakeSetAttributesHandler is the exported version for testing
+func MakeSetAttributesHandler(registry types.MCPContext) mcp.ToolHandler {
+	return makeSetAttributesHandler(registry)
+}
+
+func makeSuggestTestsHandler(registry types.MCPContext) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSuggestTests(ctx, req, registry)
+	}
+}
+
+// MakeSuggestTestsHandler is the exported version for testing
+func MakeSuggestTestsHandler(registry types.MCPContext) mcp.ToolHandler {
+	return makeSuggestTestsHandler(registry)
+}
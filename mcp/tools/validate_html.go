@@ -18,15 +18,18 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"bennypowers.dev/cem/lsp/types"
+	M "bennypowers.dev/cem/manifest"
 	mcpTypes "bennypowers.dev/cem/mcp/types"
 	"bennypowers.dev/cem/internal/validations"
 	"github.com/agext/levenshtein"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // ValidationTemplateData specific to validation tools
@@ -64,32 +67,44 @@ func handleValidateHtml(
 	registry mcpTypes.MCPContext,
 ) (*mcp.CallToolResult, error) {
 	// Parse args from request
-	var validateArgs ValidateHtmlArgs
-	if req.Params.Arguments != nil {
-		if argsData, err := json.Marshal(req.Params.Arguments); err != nil {
-			return nil, fmt.Errorf("failed to marshal args: %w", err)
-		} else if err := json.Unmarshal(argsData, &validateArgs); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal args: %w", err)
-		}
+	validateArgs, err := ParseToolArgs[ValidateHtmlArgs](req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkInputLength("html", validateArgs.Html); err != nil {
+		return nil, err
 	}
 
 	// Use tree-sitter to parse and validate HTML
-	validationResult, err := validateHtmlWithTreeSitter(validateArgs.Html, registry)
+	data, err := validateHtmlWithTreeSitter(validateArgs.Html, registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate HTML: %w", err)
+	}
+	validationResult, err := RenderTemplate("html_validation_results", data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate HTML: %w", err)
 	}
 
+	// Structured diagnostics let clients check generated markup without
+	// parsing the report, e.g. {"valid": false, "diagnostics": [...]}
+	valid := !slices.ContainsFunc(data.ManifestIssues, func(issue ValidationIssue) bool {
+		return issue.Priority == "error"
+	})
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{
 				Text: validationResult,
 			},
 		},
+		StructuredContent: map[string]any{
+			"valid":       valid,
+			"diagnostics": data.ManifestIssues,
+		},
 	}, nil
 }
 
 // validateHtmlWithTreeSitter performs HTML validation using tree-sitter parsing
-func validateHtmlWithTreeSitter(html string, registry mcpTypes.MCPContext) (string, error) {
+func validateHtmlWithTreeSitter(html string, registry mcpTypes.MCPContext) (ValidationTemplateData, error) {
 	// Get the shared document manager from registry
 	dm := registry.DocumentManager()
 
@@ -111,7 +126,7 @@ func validateHtmlWithTreeSitter(html string, registry mcpTypes.MCPContext) (stri
 	// Use tree-sitter to find custom elements
 	elements, err := doc.FindCustomElements(dm)
 	if err != nil {
-		return "", fmt.Errorf("failed to find custom elements: %w", err)
+		return data, fmt.Errorf("failed to find custom elements: %w", err)
 	}
 
 	// Convert tree-sitter results to template-compatible format
@@ -121,9 +136,12 @@ func validateHtmlWithTreeSitter(html string, registry mcpTypes.MCPContext) (stri
 		if err != nil {
 			// Unknown element - add to manifest issues
 			data.ManifestIssues = append(data.ManifestIssues, ValidationIssue{
-				Type:    "unknown-element",
-				Element: element.TagName,
-				Message: fmt.Sprintf("Custom element '%s' not found in registry", element.TagName),
+				Type:     "unknown-element",
+				Element:  element.TagName,
+				Message:  fmt.Sprintf("Custom element '%s' not found in registry", element.TagName),
+				Priority: "error",
+				Line:     int(element.Range.Start.Line) + 1,
+				Column:   int(element.Range.Start.Character) + 1,
 			})
 		} else {
 			// Add to found elements
@@ -132,6 +150,17 @@ func validateHtmlWithTreeSitter(html string, registry mcpTypes.MCPContext) (stri
 				UsageCount:  1, // Tree-sitter found it, so at least 1 usage
 			})
 
+			if decl := registryElement.Declaration(); decl != nil && decl.IsDeprecated() {
+				data.ManifestIssues = append(data.ManifestIssues, ValidationIssue{
+					Type:     "deprecated-element",
+					Element:  element.TagName,
+					Message:  deprecationMessage(fmt.Sprintf("Element <%s> is deprecated", element.TagName), decl.Deprecated),
+					Priority: "warning",
+					Line:     int(element.Range.Start.Line) + 1,
+					Column:   int(element.Range.Start.Character) + 1,
+				})
+			}
+
 			// Validate attributes using tree-sitter parsed data
 			attributeIssues := validateElementAttributes(element, registryElement)
 			data.ManifestIssues = append(data.ManifestIssues, attributeIssues...)
@@ -159,7 +188,13 @@ func validateHtmlWithTreeSitter(html string, registry mcpTypes.MCPContext) (stri
 		}
 	}
 
-	return RenderTemplate("html_validation_results", data)
+	slotIssues, err := validateSlotUsage(html, registry)
+	if err != nil {
+		return data, fmt.Errorf("failed to check slots: %w", err)
+	}
+	data.ManifestIssues = append(data.ManifestIssues, slotIssues...)
+
+	return data, nil
 }
 
 // validateElementAttributes validates attributes for a custom element using tree-sitter parsed data
@@ -198,13 +233,32 @@ func validateElementAttributes(element types.CustomElementMatch, registryElement
 			}
 
 			issues = append(issues, ValidationIssue{
-				Type:    "unknown-attribute",
-				Element: element.TagName,
-				Message: message,
+				Type:      "unknown-attribute",
+				Element:   element.TagName,
+				Attribute: attrName,
+				Message:   message,
+				Priority:  "warning",
+				Line:      int(attrMatch.Range.Start.Line) + 1,
+				Column:    int(attrMatch.Range.Start.Character) + 1,
 			})
 			continue
 		}
 
+		if manifestAttr.IsDeprecated() {
+			issues = append(issues, ValidationIssue{
+				Type:      "deprecated-attribute",
+				Element:   element.TagName,
+				Attribute: attrName,
+				Message: deprecationMessage(
+					fmt.Sprintf("Attribute '%s' of <%s> is deprecated", attrName, element.TagName),
+					manifestAttr.Deprecated,
+				),
+				Priority: "warning",
+				Line:     int(attrMatch.Range.Start.Line) + 1,
+				Column:   int(attrMatch.Range.Start.Character) + 1,
+			})
+		}
+
 		// Validate attribute value using manifest validation methods
 		if manifestAttr.IsEnum() && attrMatch.Value != "" {
 			if !manifestAttr.IsValidValue(attrMatch.Value) {
@@ -217,6 +271,9 @@ func validateElementAttributes(element types.CustomElementMatch, registryElement
 					Expected:  fmt.Sprintf("%v", validValues),
 					Message: fmt.Sprintf("Invalid attribute value: `%s=\"%s\"` in `<%s>`. Valid values: %v",
 						attrName, attrMatch.Value, element.TagName, validValues),
+					Priority: "error",
+					Line:     int(attrMatch.Range.Start.Line) + 1,
+					Column:   int(attrMatch.Range.Start.Character) + 1,
 				})
 			}
 		}
@@ -225,6 +282,110 @@ func validateElementAttributes(element types.CustomElementMatch, registryElement
 	return issues
 }
 
+// validateSlotUsage checks the children of each known custom element against
+// its slots: a child's slot attribute must name one of the element's slots,
+// and unslotted children need a default slot. Elements which document no
+// slots are not checked. The HTML parser does not track positions, so these
+// issues have none.
+func validateSlotUsage(src string, registry mcpTypes.MCPContext) ([]ValidationIssue, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ValidationIssue
+	for _, n := range nodes {
+		walkElements(n, func(el *html.Node) {
+			if !strings.Contains(el.Data, "-") {
+				return
+			}
+			info, err := registry.ElementInfo(el.Data)
+			if err != nil || len(info.Slots()) == 0 {
+				return
+			}
+			slots := make(map[string]M.Slot, len(info.Slots()))
+			for _, slot := range info.Slots() {
+				slots[slot.Name] = slot
+			}
+			for child := el.FirstChild; child != nil; child = child.NextSibling {
+				issues = append(issues, checkSlottedChild(el.Data, child, slots)...)
+			}
+		})
+	}
+	return issues, nil
+}
+
+// checkSlottedChild checks one child of a custom element against that
+// element's slots
+func checkSlottedChild(tagName string, child *html.Node, slots map[string]M.Slot) []ValidationIssue {
+	var name string
+	switch child.Type {
+	case html.ElementNode:
+		if child.Data == "template" || child.Data == "script" || child.Data == "style" {
+			return nil
+		}
+		name = attr(child, "slot")
+	case html.TextNode:
+		if strings.TrimSpace(child.Data) == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	slot, ok := slots[name]
+	switch {
+	case !ok && name == "":
+		return []ValidationIssue{{
+			Type:     "missing-default-slot",
+			Element:  tagName,
+			Message:  fmt.Sprintf("<%s> has no default slot, so content without a slot attribute is not rendered", tagName),
+			Priority: "error",
+		}}
+	case !ok:
+		return []ValidationIssue{{
+			Type:     "unknown-slot",
+			Element:  tagName,
+			Slot:     name,
+			Message:  fmt.Sprintf("<%s> has no slot named '%s'. Valid slots: %v", tagName, name, slotNames(slots)),
+			Priority: "error",
+		}}
+	case slot.IsDeprecated():
+		return []ValidationIssue{{
+			Type:     "deprecated-slot",
+			Element:  tagName,
+			Slot:     name,
+			Message:  deprecationMessage(fmt.Sprintf("Slot '%s' of <%s> is deprecated", name, tagName), slot.Deprecated),
+			Priority: "warning",
+		}}
+	}
+	return nil
+}
+
+// slotNames lists the named slots, sorted
+func slotNames(slots map[string]M.Slot) []string {
+	var names []string
+	for name := range slots {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// deprecationMessage appends the deprecation's reason, if it has one, to message
+func deprecationMessage(message string, deprecated M.Deprecated) string {
+	if reason, ok := deprecated.(M.DeprecatedReason); ok && reason != "" {
+		return fmt.Sprintf("%s: %s", message, reason)
+	}
+	return message
+}
+
 // validateSlotContentGuidelines validates slotted content against manifest guidelines
 func validateSlotContentGuidelines(element types.CustomElementMatch, registryElement mcpTypes.ElementInfo) []SlotContentIssue {
 	var issues []SlotContentIssue
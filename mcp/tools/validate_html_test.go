@@ -682,3 +682,85 @@ func TestValidateHtml_FixtureGolden_GlobalAttributesAllowed(t *testing.T) {
 func TestValidateHtml_FixtureGolden_GlobalAttributesWithUnknown(t *testing.T) {
 	testValidateHtmlWithGolden(t, "global_attributes_with_unknown.html", "mixed attributes test", "validate_html_global_attributes_with_unknown.golden.md")
 }
+
+// Inline assertions justified: the test checks the structured diagnostics,
+// which clients consume instead of the rendered report.
+func TestValidateHtml_StructuredDiagnostics(t *testing.T) {
+	workspace := testworkspace.NewMapWorkspaceContext(t, "../testdata/fixtures/validate-html-api")
+	require.NoError(t, workspace.Init())
+
+	registry, err := mcp.NewMCPContext(workspace, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.LoadManifests())
+
+	html := `<old-button>Go</old-button>
+<media-card raised>
+  <h2 slot="header">Title</h2>
+  <img slot="media" src="a.png">
+  <p slot="footer">Footer</p>
+  <p>Body</p>
+</media-card>`
+
+	argsJSON, err := json.Marshal(map[string]any{"html": html})
+	require.NoError(t, err)
+
+	handler := tools.MakeValidateHtmlHandler(mcp.NewMCPContextAdapter(registry))
+	result, err := handler(context.Background(), &mcpSDK.CallToolRequest{
+		Params: &mcpSDK.CallToolParamsRaw{
+			Name:      "validate_html",
+			Arguments: json.RawMessage(argsJSON),
+		},
+	})
+	require.NoError(t, err)
+
+	structured, ok := result.StructuredContent.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, false, structured["valid"])
+	diagnostics, ok := structured["diagnostics"].([]tools.ValidationIssue)
+	require.True(t, ok)
+
+	assert.Equal(t, []tools.ValidationIssue{
+		{
+			Type:     "deprecated-element",
+			Element:  "old-button",
+			Message:  "Element <old-button> is deprecated: Use new-button instead",
+			Priority: "warning",
+			Line:     1,
+			Column:   2,
+		},
+		{
+			Type:      "deprecated-attribute",
+			Element:   "media-card",
+			Attribute: "raised",
+			Message:   "Attribute 'raised' of <media-card> is deprecated: Use elevation instead",
+			Priority:  "warning",
+			Line:      2,
+			Column:    13,
+		},
+		{
+			Type:     "deprecated-slot",
+			Element:  "media-card",
+			Slot:     "media",
+			Message:  "Slot 'media' of <media-card> is deprecated",
+			Priority: "warning",
+		},
+		{
+			Type:     "unknown-slot",
+			Element:  "media-card",
+			Slot:     "footer",
+			Message:  "<media-card> has no slot named 'footer'. Valid slots: [header media]",
+			Priority: "error",
+		},
+		{
+			Type:     "missing-default-slot",
+			Element:  "media-card",
+			Message:  "<media-card> has no default slot, so content without a slot attribute is not rendered",
+			Priority: "error",
+		},
+	}, diagnostics)
+
+	text, ok := result.Content[0].(*mcpSDK.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "**Deprecated Element**")
+	assert.Contains(t, text.Text, "**Unknown Slot**")
+}
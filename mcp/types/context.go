@@ -32,6 +32,12 @@ type MCPContext interface {
 	LoadManifests() error
 	GetManifestSchemaVersions() []string
 	DocumentManager() types.DocumentManager
+	// ManifestError reports why the most recent manifest reload failed, or
+	// nil if it succeeded
+	ManifestError() error
+	// Packages reports where each loaded package came from, including
+	// packages whose manifests failed to load
+	Packages() []types.PackageInfo
 
 	// Lazy-computed cached methods for performance
 	CommonPrefixes() []string
@@ -45,6 +51,10 @@ type MCPContext interface {
 
 	// Filesystem access
 	FileSystem() platform.FileSystem
+
+	// ElementModule returns the path of the manifest module which defines
+	// the element, or "" when no manifest names one
+	ElementModule(tagName string) string
 }
 
 // ElementInfo represents element information using manifest types directly
@@ -68,6 +78,9 @@ type ElementInfo interface {
 	CssParts() []manifest.CssPart
 	CssStates() []manifest.CssCustomState
 
+	// AttributeReflects reports whether the named attribute reflects its property
+	AttributeReflects(name string) bool
+
 	// MCP-specific extensions
 	Guidelines() []string
 	Examples() []Example
@@ -32,22 +32,47 @@ type DataFetcher struct {
 
 // ToolDefinition represents a complete tool definition with metadata and handler
 type ToolDefinition struct {
-	Name         string                 `yaml:"name"`
-	Description  string                 `yaml:"-"` // From markdown content
-	InputSchema  map[string]any `yaml:"inputSchema"`
-	DataFetchers []DataFetcher          `yaml:"dataFetchers,omitempty"`
-	Template     string                 `yaml:"template,omitempty"`
-	ResponseType string                 `yaml:"responseType,omitempty"`
-	Handler      mcp.ToolHandler        `yaml:"-"`
+	Name         string          `yaml:"name"`
+	Title        string          `yaml:"title,omitempty"`
+	Description  string          `yaml:"-"` // From markdown content
+	InputSchema  map[string]any  `yaml:"inputSchema"`
+	DataFetchers []DataFetcher   `yaml:"dataFetchers,omitempty"`
+	Template     string          `yaml:"template,omitempty"`
+	ResponseType string          `yaml:"responseType,omitempty"`
+	Mutating     bool            `yaml:"mutating,omitempty"`    // Modifies the workspace; disabled in read-only mode
+	Destructive  bool            `yaml:"destructive,omitempty"` // Mutating tool which may overwrite or delete files
+	Optional     bool            `yaml:"optional,omitempty"`    // Registered only when enabled in the server config
+	Uncached     bool            `yaml:"uncached,omitempty"`    // Reads more than the manifests, so responses aren't cached
+	Handler      mcp.ToolHandler `yaml:"-"`
+}
+
+// Annotations returns the hints which tell clients how the tool behaves.
+// Tools only read and write the workspace, so none are open-world, and
+// tools which don't modify it are idempotent.
+func (t ToolDefinition) Annotations() *mcp.ToolAnnotations {
+	destructive := t.Mutating && t.Destructive
+	openWorld := false
+	return &mcp.ToolAnnotations{
+		Title:           t.Title,
+		ReadOnlyHint:    !t.Mutating,
+		DestructiveHint: &destructive,
+		IdempotentHint:  !t.Mutating,
+		OpenWorldHint:   &openWorld,
+	}
 }
 
 // Frontmatter represents the YAML frontmatter from tool markdown files
 type Frontmatter struct {
-	Name         string                 `yaml:"name"`
+	Name         string         `yaml:"name"`
+	Title        string         `yaml:"title,omitempty"`
 	InputSchema  map[string]any `yaml:"inputSchema"`
-	DataFetchers []DataFetcher          `yaml:"dataFetchers,omitempty"`
-	Template     string                 `yaml:"template,omitempty"`
-	ResponseType string                 `yaml:"responseType,omitempty"`
+	DataFetchers []DataFetcher  `yaml:"dataFetchers,omitempty"`
+	Template     string         `yaml:"template,omitempty"`
+	ResponseType string         `yaml:"responseType,omitempty"`
+	Mutating     bool           `yaml:"mutating,omitempty"`
+	Destructive  bool           `yaml:"destructive,omitempty"`
+	Optional     bool           `yaml:"optional,omitempty"`
+	Uncached     bool           `yaml:"uncached,omitempty"`
 }
 
 // ResourceDefinition represents a complete resource definition with metadata and handler
@@ -60,6 +85,7 @@ type ResourceDefinition struct {
 	DataFetchers []DataFetcher       `yaml:"dataFetchers,omitempty"`
 	Template     string              `yaml:"template,omitempty"`
 	ResponseType string              `yaml:"responseType,omitempty"`
+	Chunked      bool                `yaml:"chunked,omitempty"` // Also readable in chunks, with offset and limit query parameters
 	Handler      mcp.ResourceHandler `yaml:"-"`
 }
 
@@ -72,4 +98,5 @@ type ResourceFrontmatter struct {
 	DataFetchers []DataFetcher `yaml:"dataFetchers,omitempty"`
 	Template     string        `yaml:"template,omitempty"`
 	ResponseType string        `yaml:"responseType,omitempty"`
+	Chunked      bool          `yaml:"chunked,omitempty"`
 }
@@ -0,0 +1,76 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package publishcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	lipgloss "charm.land/lipgloss/v2"
+
+	"bennypowers.dev/cem/internal/tui"
+)
+
+type DisplayOptions struct {
+	Format string
+}
+
+func PrintResults(w io.Writer, results []*Result, opts DisplayOptions) error {
+	switch opts.Format {
+	case "json":
+		return printResultsJSON(w, results)
+	case "text", "":
+		return printResultsText(w, results)
+	default:
+		return fmt.Errorf("invalid format: %s. Use 'text' or 'json'", opts.Format)
+	}
+}
+
+func printResultsJSON(w io.Writer, results []*Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
+func printResultsText(w io.Writer, results []*Result) error {
+	for _, result := range results {
+		header := result.Name
+		if result.Version != "" {
+			header += "@" + result.Version
+		}
+		if _, err := lipgloss.Fprintln(w, tui.SectionStyle.Render(header)); err != nil {
+			return err
+		}
+		if result.OK() {
+			message := fmt.Sprintf("%s and its modules are in the %d packed files", result.Manifest, result.Packed)
+			if _, err := lipgloss.Fprintf(w, "  %s %s\n", tui.SuccessStyle.Render("✓"), message); err != nil {
+				return err
+			}
+		}
+		for _, problem := range result.Problems {
+			if _, err := lipgloss.Fprintf(w, "  %s %s\n", tui.ErrorStyle.Render("✗"), problem.Message); err != nil {
+				return err
+			}
+		}
+		if _, err := lipgloss.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
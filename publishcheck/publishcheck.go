@@ -0,0 +1,281 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package publishcheck verifies that a package's custom elements manifest
+// will survive `npm publish`: that the manifest and the modules it
+// describes are in the package tarball, and that its links are valid URLs.
+package publishcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/internal/set"
+	M "bennypowers.dev/cem/manifest"
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// Rule IDs for the problems Check reports
+const (
+	RuleNoCustomElements  = "no-custom-elements"
+	RuleManifestMissing   = "manifest-missing"
+	RuleManifestNotPacked = "manifest-not-packed"
+	RuleManifestInvalid   = "manifest-invalid"
+	RuleModuleMissing     = "module-missing"
+	RuleModuleNotPacked   = "module-not-packed"
+	RuleInvalidURL        = "invalid-url"
+)
+
+// Problem is something which would break the published manifest
+type Problem struct {
+	Rule    string `json:"rule"`
+	Path    string `json:"path,omitempty"` // Package-relative file, or the declaration with the bad URL
+	Message string `json:"message"`
+}
+
+// Result is the outcome of checking one package
+type Result struct {
+	Name     string    `json:"name"`
+	Version  string    `json:"version"`
+	Manifest string    `json:"manifest,omitempty"` // Package-relative manifest path
+	Packed   int       `json:"packed"`             // Number of files the tarball would contain
+	Problems []Problem `json:"problems"`
+}
+
+// OK reports whether the package is ready to publish
+func (r *Result) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *Result) add(rule, file, format string, args ...any) {
+	r.Problems = append(r.Problems, Problem{
+		Rule:    rule,
+		Path:    file,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// packageJSON holds the package.json fields which decide what npm packs
+type packageJSON struct {
+	Name           string   `json:"name"`
+	Version        string   `json:"version"`
+	CustomElements string   `json:"customElements"`
+	Main           string   `json:"main"`
+	Files          []string `json:"files"`
+}
+
+// Check checks the package whose package.json is in dir
+func Check(fsys platform.FileSystem, dir string) (*Result, error) {
+	data, err := fsys.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("reading package.json: %w", err)
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("parsing package.json: %w", err)
+	}
+
+	result := &Result{Name: pkg.Name, Version: pkg.Version, Problems: []Problem{}}
+	packed, err := PackedFiles(fsys, dir, pkg.Files, pkg.Main)
+	if err != nil {
+		return nil, err
+	}
+	result.Packed = len(packed)
+
+	if pkg.CustomElements == "" {
+		result.add(RuleNoCustomElements, "package.json",
+			"package.json has no customElements field, so tools won't find the manifest")
+		return result, nil
+	}
+	manifestPath := cleanPackagePath(pkg.CustomElements)
+	result.Manifest = manifestPath
+
+	data, err = fsys.ReadFile(filepath.Join(dir, filepath.FromSlash(manifestPath)))
+	if err != nil {
+		result.add(RuleManifestMissing, manifestPath,
+			"customElements points to %s, which does not exist. Run `cem generate` first", manifestPath)
+		return result, nil
+	}
+	if !packed.Has(manifestPath) {
+		result.add(RuleManifestNotPacked, manifestPath,
+			"%s is not included by package.json files, so it won't be published", manifestPath)
+	}
+	manifest, err := M.UnmarshalPackage(data)
+	if err != nil {
+		result.add(RuleManifestInvalid, manifestPath, "could not parse %s: %v", manifestPath, err)
+		return result, nil
+	}
+
+	for _, module := range manifest.Modules {
+		modulePath := cleanPackagePath(module.Path)
+		if modulePath == "" {
+			continue
+		}
+		if _, err := fsys.Stat(filepath.Join(dir, filepath.FromSlash(modulePath))); err != nil {
+			result.add(RuleModuleMissing, modulePath,
+				"module %s does not exist. Build the package before publishing", modulePath)
+			continue
+		}
+		if !packed.Has(modulePath) {
+			result.add(RuleModuleNotPacked, modulePath,
+				"module %s is not included by package.json files, so it won't be published", modulePath)
+		}
+	}
+
+	checkURLs(result, manifest)
+	return result, nil
+}
+
+// checkURLs reports source and demo links which aren't absolute URLs.
+// Relative links resolve against wherever the manifest is served from,
+// e.g. a CDN, so they break once the package is published.
+func checkURLs(result *Result, manifest *M.Package) {
+	check := func(subject, kind, href string) {
+		if href != "" && !isAbsoluteURL(href) {
+			result.add(RuleInvalidURL, subject, "%s %s %q is not an absolute URL", subject, kind, href)
+		}
+	}
+	for _, module := range manifest.Modules {
+		for _, decl := range module.Declarations {
+			var name string
+			var source *M.SourceReference
+			var demos []M.Demo
+			switch d := decl.(type) {
+			case *M.CustomElementDeclaration:
+				name, source, demos = d.Name(), d.Source, d.Demos
+			case *M.CustomElementMixinDeclaration:
+				name, source, demos = d.Name(), d.MixinDeclaration.Source, d.Demos
+			case *M.ClassDeclaration:
+				name, source = d.Name(), d.Source
+			case *M.MixinDeclaration:
+				name, source = d.Name(), d.Source
+			case *M.FunctionDeclaration:
+				name, source = d.Name(), d.Source
+			case *M.VariableDeclaration:
+				name, source = d.Name(), d.Source
+			default:
+				continue
+			}
+			subject := module.Path + "#" + name
+			if source != nil {
+				check(subject, "source", source.Href)
+			}
+			for _, demo := range demos {
+				check(subject, "demo", demo.URL)
+				if demo.Source != nil {
+					check(subject, "demo source", demo.Source.Href)
+				}
+			}
+		}
+	}
+}
+
+func isAbsoluteURL(href string) bool {
+	u, err := url.Parse(href)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// cleanPackagePath normalizes a path from package.json or the manifest,
+// e.g. "./custom-elements.json", to a slash-separated package-relative path
+func cleanPackagePath(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(p, "\\", "/")), "/")
+	if p == "." {
+		return ""
+	}
+	return p
+}
+
+// neverPacked are files npm leaves out of every tarball
+var neverPacked = set.NewSet(".npmignore", ".gitignore", ".npmrc", "package-lock.json", ".DS_Store")
+
+// alwaysPacked matches the files npm publishes whatever `files` says
+func alwaysPacked(rel, main string) bool {
+	if rel == "package.json" || (main != "" && rel == cleanPackagePath(main)) {
+		return true
+	}
+	if strings.Contains(rel, "/") {
+		return false
+	}
+	name := strings.ToUpper(strings.TrimSuffix(rel, path.Ext(rel)))
+	return slices.Contains([]string{"README", "LICENSE", "LICENCE"}, name)
+}
+
+// PackedFiles lists the package-relative paths of the files `npm pack`
+// would include from the package in dir, following npm's rules: when
+// package.json lists `files`, only those paths and their contents are
+// packed; otherwise everything but what .npmignore, or failing that
+// .gitignore, excludes. package.json, the README, LICENSE, and the main
+// file are always packed, and node_modules and .git never are.
+func PackedFiles(fsys platform.FileSystem, dir string, files []string, main string) (set.Set[string], error) {
+	rootFS := platform.DirFS(fsys, dir)
+
+	var include *ignore.GitIgnore
+	var exclude *platform.IgnoreMatcher
+	if files != nil {
+		// `files` entries are gitignore-style patterns anchored at the root,
+		// so matching one includes it, or the directory's contents
+		lines := make([]string, 0, len(files))
+		for _, entry := range files {
+			negated := strings.HasPrefix(entry, "!")
+			entry = cleanPackagePath(strings.TrimPrefix(entry, "!"))
+			if entry == "" {
+				continue
+			}
+			if !strings.HasPrefix(entry, "**") {
+				entry = "/" + entry
+			}
+			if negated {
+				entry = "!" + entry
+			}
+			lines = append(lines, entry)
+		}
+		include = ignore.CompileIgnoreLines(lines...)
+	} else if _, err := fs.Stat(rootFS, ".npmignore"); err == nil {
+		exclude = platform.LoadIgnoreMatcher(rootFS, ".", ".npmignore")
+	} else {
+		exclude = platform.LoadIgnoreMatcher(rootFS, ".", ".gitignore")
+	}
+
+	packed := set.NewSet[string]()
+	err := platform.WalkDirIgnoring(rootFS, ".", set.NewSet("node_modules"), exclude, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel := cleanPackagePath(p)
+		if neverPacked.Has(path.Base(rel)) {
+			return nil
+		}
+		if alwaysPacked(rel, main) || include == nil || include.MatchesPath(rel) {
+			packed.Add(rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing package files: %w", err)
+	}
+	return packed, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package publishcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform/testutil"
+)
+
+func TestCheck(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata", "/")
+
+	fixtures := []string{
+		"ready",
+		"unpacked",
+		"relative-urls",
+		"no-custom-elements",
+		"manifest-missing",
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture, func(t *testing.T) {
+			result, err := Check(mfs, filepath.Join("/", "fixtures", fixture))
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			got, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				t.Fatalf("json.MarshalIndent() error = %v", err)
+			}
+			got = append(got, '\n')
+
+			testutil.CheckGolden(t, fixture+".json", got, testutil.GoldenOptions{
+				Dir:         "goldens",
+				UseJSONDiff: true,
+				FS:          mfs,
+			})
+		})
+	}
+}
+
+func TestCheck_NoPackageJSON(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata", "/")
+	if _, err := Check(mfs, "/fixtures"); err == nil {
+		t.Error("Check() expected an error without package.json")
+	}
+}
+
+func TestPrintResults(t *testing.T) {
+	mfs := testutil.LoadTestdataFS(t, "testdata", "/")
+	ready, err := Check(mfs, "/fixtures/ready")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	unpacked, err := Check(mfs, "/fixtures/unpacked")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := PrintResults(&buf, []*Result{ready, unpacked}, DisplayOptions{Format: "text"}); err != nil {
+		t.Fatalf("PrintResults() error = %v", err)
+	}
+	text := testutil.StripANSI(buf.String())
+	for _, want := range []string{
+		"@acme/elements@1.0.0",
+		"✓ custom-elements.json and its modules are in the 4 packed files",
+		"@acme/unpacked@1.0.0",
+		"✗ module dist/my-accordion.js does not exist",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	if err := PrintResults(&buf, nil, DisplayOptions{Format: "yaml"}); err == nil {
+		t.Error("PrintResults() expected an error for an unknown format")
+	}
+}
@@ -0,0 +1 @@
+export class MyButton extends HTMLElement {}
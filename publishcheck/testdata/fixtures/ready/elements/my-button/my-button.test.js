@@ -0,0 +1 @@
+import './my-button.js';
@@ -0,0 +1 @@
+export class MyTabs extends HTMLElement {}
@@ -0,0 +1 @@
+export class MyCard extends HTMLElement {}
@@ -0,0 +1,187 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"bennypowers.dev/cem/list"
+	M "bennypowers.dev/cem/manifest"
+)
+
+// minTrigramSimilarity is the share of a query's trigrams which a name must
+// contain to match it, so that misspellings like "buton" still find my-button
+const minTrigramSimilarity = 0.5
+
+// ElementCandidate is an element which fuzzy queries are scored against
+type ElementCandidate struct {
+	TagName   string
+	ClassName string
+	// Description is the element's summary and description
+	Description string
+}
+
+// ElementMatch is an element's score for a fuzzy query, between 0 and 1
+type ElementMatch struct {
+	TagName string  `json:"tagName"`
+	Score   float64 `json:"score"`
+}
+
+// ScoreElement scores how well an element matches a fuzzy query, case
+// insensitively. The exact tag name scores 1. Substrings of the tag name score
+// more than substrings of the class name, which score more than words in the
+// description, and of those, substrings covering more of the name score more.
+// Failing all of those, a name which shares most of the query's trigrams
+// scores up to 0.5. A score of 0 means the element doesn't match.
+func ScoreElement(query string, candidate ElementCandidate) float64 {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return 1
+	}
+	tagName := strings.ToLower(candidate.TagName)
+	className := strings.ToLower(candidate.ClassName)
+	coverage := func(name string) float64 {
+		return 0.1 * float64(len(query)) / float64(len(name))
+	}
+
+	switch {
+	case tagName == query:
+		return 1
+	case className == query:
+		return 0.95
+	case strings.HasPrefix(tagName, query):
+		return 0.8 + coverage(tagName)
+	case strings.Contains(tagName, query):
+		return 0.7 + coverage(tagName)
+	case className != "" && strings.Contains(className, query):
+		return 0.6 + coverage(className)
+	}
+
+	var score float64
+	if strings.Contains(strings.ToLower(candidate.Description), query) {
+		score = 0.4
+	}
+	similarity := max(trigramSimilarity(query, tagName), trigramSimilarity(query, className))
+	if similarity >= minTrigramSimilarity {
+		score = max(score, 0.5*similarity)
+	}
+	return score
+}
+
+// RankElements scores candidates against a fuzzy query, returning up to limit
+// matches, best first, with ties in tag name order. A limit of zero or less
+// returns every match.
+func RankElements(query string, candidates []ElementCandidate, limit int) []ElementMatch {
+	matches := make([]ElementMatch, 0)
+	for _, candidate := range candidates {
+		if score := ScoreElement(query, candidate); score > 0 {
+			matches = append(matches, ElementMatch{TagName: candidate.TagName, Score: score})
+		}
+	}
+	slices.SortFunc(matches, func(a, b ElementMatch) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.TagName, b.TagName))
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// FindElements ranks the custom elements in a manifest against a fuzzy query,
+// returning up to limit matches, best first
+func FindElements(manifest *M.Package, query string, limit int) []ElementMatch {
+	return RankElements(query, ElementCandidates(manifest), limit)
+}
+
+// RenderFuzzyResults renders a table of the custom elements in a manifest
+// which best match a fuzzy query, best first. It renders nothing when no
+// element matches.
+func RenderFuzzyResults(manifest *M.Package, query string, limit int) (string, error) {
+	matches := FindElements(manifest, query, limit)
+	if len(matches) == 0 {
+		return "", nil
+	}
+	summaries := make(map[string]list.ElementSummary)
+	for _, summary := range list.SummarizeElements(manifest, nil) {
+		if _, ok := summaries[summary.TagName]; !ok {
+			summaries[summary.TagName] = summary
+		}
+	}
+	rows := make([][]string, 0, len(matches))
+	for _, match := range matches {
+		summary := summaries[match.TagName]
+		rows = append(rows, []string{"<" + match.TagName + ">", summary.Class, summary.Module, summary.Summary})
+	}
+	return list.RenderTable("Elements matching: "+query, []string{"Tag Name", "Class", "Module", "Summary"}, rows, nil)
+}
+
+// ElementCandidates returns the custom elements in a manifest, to score
+// against fuzzy queries
+func ElementCandidates(manifest *M.Package) []ElementCandidate {
+	var candidates []ElementCandidate
+	if manifest == nil {
+		return candidates
+	}
+	for _, mod := range manifest.Modules {
+		for _, decl := range mod.Declarations {
+			if ce, ok := decl.(*M.CustomElementDeclaration); ok && ce.TagName != "" {
+				candidates = append(candidates, ElementCandidate{
+					TagName:     ce.TagName,
+					ClassName:   ce.Name(),
+					Description: strings.TrimSpace(ce.Summary + " " + ce.Description),
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// trigramSimilarity is the share of the query's trigrams which the name
+// contains. Each hyphen-separated word is padded, as in PostgreSQL's pg_trgm,
+// so that matching word starts and ends count.
+func trigramSimilarity(query, name string) float64 {
+	if name == "" {
+		return 0
+	}
+	queryTrigrams := trigrams(query)
+	if len(queryTrigrams) == 0 {
+		return 0
+	}
+	nameTrigrams := trigrams(name)
+	shared := 0
+	for trigram := range queryTrigrams {
+		if _, ok := nameTrigrams[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(queryTrigrams))
+}
+
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == '-' || r == ' ' || r == '_'
+	}) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
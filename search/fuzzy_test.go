@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package search_test
+
+import (
+	"testing"
+
+	"bennypowers.dev/cem/search"
+	"github.com/stretchr/testify/assert"
+)
+
+// Inline: pure function, table-driven
+
+var fuzzyCandidates = []search.ElementCandidate{
+	{TagName: "my-button", ClassName: "MyButton", Description: "A clickable button"},
+	{TagName: "my-button-group", ClassName: "MyButtonGroup", Description: "Groups buttons"},
+	{TagName: "my-card", ClassName: "MyCard", Description: "A card for page layout"},
+	{TagName: "icon-toggle", ClassName: "IconToggle"},
+}
+
+func tagNames(matches []search.ElementMatch) []string {
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.TagName)
+	}
+	return names
+}
+
+func TestRankElements(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		limit    int
+		expected []string
+	}{
+		{"exact tag name first", "my-button", 0, []string{"my-button", "my-button-group"}},
+		{"class name", "mycard", 0, []string{"my-card"}},
+		{"case insensitive", "MY-CARD", 0, []string{"my-card"}},
+		{"tag name substring", "toggle", 0, []string{"icon-toggle"}},
+		{"misspelling", "buton", 0, []string{"my-button", "my-button-group"}},
+		{"description", "layout", 0, []string{"my-card"}},
+		{"limit keeps the closest match", "my", 1, []string{"my-card"}},
+		{"empty query matches all", "", 0, []string{"icon-toggle", "my-button", "my-button-group", "my-card"}},
+		{"no match", "zzz", 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := search.RankElements(tt.query, fuzzyCandidates, tt.limit)
+			assert.Equal(t, tt.expected, tagNames(matches))
+		})
+	}
+}
+
+func TestScoreElement(t *testing.T) {
+	button := fuzzyCandidates[0]
+	exact := search.ScoreElement("my-button", button)
+	prefix := search.ScoreElement("my-but", button)
+	substring := search.ScoreElement("button", button)
+	description := search.ScoreElement("clickable", button)
+	misspelled := search.ScoreElement("buton", button)
+
+	assert.InDelta(t, 1.0, exact, 0.0001)
+	assert.Greater(t, exact, prefix)
+	assert.Greater(t, prefix, substring)
+	assert.Greater(t, substring, misspelled)
+	assert.Greater(t, description, 0.0)
+	assert.Zero(t, search.ScoreElement("zzz", button))
+}
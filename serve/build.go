@@ -40,6 +40,9 @@ type BuildConfig struct {
 	// WorkDir is the working directory for resolving relative paths.
 	// If empty, defaults to os.Getwd() at build time.
 	WorkDir string
+	// Docs builds a documentation site, with a page for each element,
+	// in place of the demo listing.
+	Docs bool
 }
 
 // siteRoot returns the output directory including the base path.
@@ -87,7 +90,7 @@ func (s *Server) Build(config BuildConfig) error {
 
 	// Get demo routes from the pre-computed routing table
 	demoRoutes := s.DemoRoutes()
-	if len(demoRoutes) == 0 {
+	if len(demoRoutes) == 0 && !config.Docs {
 		return fmt.Errorf("no demo routes found")
 	}
 
@@ -97,13 +100,21 @@ func (s *Server) Build(config BuildConfig) error {
 	// that httptest.Server causes on resource-constrained CI runners.
 	handler := s.Handler()
 
-	// Write the index listing page
-	indexBody, err := renderPkg.Page(handler, "/")
-	if err != nil {
-		return fmt.Errorf("build index: %w", err)
-	}
-	if err := writePage("/", indexBody, config, s.fs); err != nil {
-		return fmt.Errorf("write index: %w", err)
+	// Write the index listing page, or the docs site, whose index replaces it
+	var docsRoutes []string
+	if config.Docs {
+		var err error
+		if docsRoutes, err = s.buildDocs(handler, config, demoRoutes); err != nil {
+			return fmt.Errorf("build docs: %w", err)
+		}
+	} else {
+		indexBody, err := renderPkg.Page(handler, "/")
+		if err != nil {
+			return fmt.Errorf("build index: %w", err)
+		}
+		if err := writePage("/", indexBody, config, s.fs); err != nil {
+			return fmt.Errorf("write index: %w", err)
+		}
 	}
 
 	// Render all demo pages concurrently
@@ -182,11 +193,11 @@ func (s *Server) Build(config BuildConfig) error {
 	}
 
 	// Generate sitemap
-	if err := s.buildSitemap(demoRoutes, config); err != nil {
+	if err := s.buildSitemap(demoRoutes, config, docsRoutes...); err != nil {
 		s.logger.Warning("Failed to generate sitemap: %v", err)
 	}
 
-	s.logger.Info("Built %d pages", len(demoRoutes)+1)
+	s.logger.Info("Built %d pages", len(demoRoutes)+len(docsRoutes)+1)
 	return nil
 }
 
@@ -545,14 +556,16 @@ func (s *Server) buildDependencies(handler http.Handler, config BuildConfig) err
 	return nil
 }
 
-// buildSitemap generates a sitemap.xml listing all built pages.
-func (s *Server) buildSitemap(demoRoutes map[string]*middleware.DemoRouteEntry, config BuildConfig) error {
+// buildSitemap generates a sitemap.xml listing all built pages, including
+// any extra routes, like the docs site's element pages.
+func (s *Server) buildSitemap(demoRoutes map[string]*middleware.DemoRouteEntry, config BuildConfig, extraRoutes ...string) error {
 	// Collect and sort routes for deterministic output
-	urls := make([]string, 0, len(demoRoutes)+1)
+	urls := make([]string, 0, len(demoRoutes)+len(extraRoutes)+1)
 	urls = append(urls, "/")
 	for route := range demoRoutes {
 		urls = append(urls, route)
 	}
+	urls = append(urls, extraRoutes...)
 	sort.Strings(urls)
 
 	var sb strings.Builder
@@ -0,0 +1,210 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+*/
+
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	M "bennypowers.dev/cem/manifest"
+	"bennypowers.dev/cem/serve/middleware"
+	"bennypowers.dev/cem/serve/middleware/routes"
+	renderPkg "bennypowers.dev/cem/serve/render"
+)
+
+// buildDocs writes the documentation site: an index page with element
+// search, one page per element with its API tables and embedded demos, and
+// the search index. It returns the routes of the pages it wrote.
+func (s *Server) buildDocs(handler http.Handler, config BuildConfig, demoRoutes map[string]*middleware.DemoRouteEntry) ([]string, error) {
+	// Render each demo without chrome, for the element pages' iframes
+	frameRoutes := make([]string, 0, len(demoRoutes))
+	for route := range demoRoutes {
+		frameRoutes = append(frameRoutes, route+"?rendering=chromeless")
+	}
+	sort.Strings(frameRoutes)
+	for _, res := range renderPkg.Pages(handler, frameRoutes, runtime.NumCPU()) {
+		if res.Err != nil {
+			return nil, fmt.Errorf("%s: %w", res.Route, res.Err)
+		}
+		route := chromelessRoute(strings.TrimSuffix(res.Route, "?rendering=chromeless"))
+		if err := writePage(route, res.Body, config, s.fs); err != nil {
+			return nil, fmt.Errorf("write %s: %w", route, err)
+		}
+	}
+
+	// Collect each element's demos
+	demosByTag := make(map[string][]routes.DocsDemo)
+	demoList := make([]string, 0, len(demoRoutes))
+	for route := range demoRoutes {
+		demoList = append(demoList, route)
+	}
+	sort.Strings(demoList)
+	for _, route := range demoList {
+		entry := demoRoutes[route]
+		demo := routes.DocsDemo{
+			Title:    docsDemoTitle(route, entry),
+			URL:      route,
+			FrameURL: chromelessRoute(route),
+		}
+		if entry.Demo != nil {
+			demo.Description = entry.Demo.Description
+		}
+		demosByTag[entry.TagName] = append(demosByTag[entry.TagName], demo)
+	}
+
+	packageName, elements, err := s.docsElements()
+	if err != nil {
+		return nil, err
+	}
+
+	docsRoutes := make([]string, 0, len(elements))
+	index := make([]routes.DocsSearchEntry, 0, len(elements))
+	for _, element := range elements {
+		data := routes.NewDocsElementData(element.decl, element.packageName, demosByTag[element.decl.TagName])
+		body, err := routes.RenderDocsElement(s.templates, data)
+		if err != nil {
+			return nil, fmt.Errorf("render docs for %s: %w", element.decl.TagName, err)
+		}
+		route := routes.DocsElementURL(element.decl.TagName)
+		if err := writePage(route, body, config, s.fs); err != nil {
+			return nil, fmt.Errorf("write %s: %w", route, err)
+		}
+		docsRoutes = append(docsRoutes, route)
+		index = append(index, routes.NewDocsSearchEntry(element.decl))
+	}
+
+	title := packageName
+	if title == "" {
+		title = "Elements"
+	}
+	indexBody, err := routes.RenderDocsIndex(s.templates, routes.DocsIndexData{
+		Title:    title,
+		Elements: index,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render docs index: %w", err)
+	}
+	if err := writePage("/", indexBody, config, s.fs); err != nil {
+		return nil, fmt.Errorf("write docs index: %w", err)
+	}
+
+	// Search links are prefixed with the base path, like the pages' links
+	for i := range index {
+		index[i].URL = config.BasePath + index[i].URL
+	}
+	searchIndex, err := json.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf("marshal search index: %w", err)
+	}
+	outPath := filepath.Join(config.siteRoot(), "docs", "search-index.json")
+	if err := s.fs.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+		return nil, err
+	}
+	if err := s.fs.WriteFile(outPath, searchIndex, 0o644); err != nil {
+		return nil, fmt.Errorf("write search index: %w", err)
+	}
+
+	s.logger.Info("Built docs for %d elements", len(elements))
+	return docsRoutes, nil
+}
+
+// docsElement is an element to document, with the package which declares it
+type docsElement struct {
+	decl        *M.CustomElementDeclaration
+	packageName string
+}
+
+// docsElements collects the custom elements in the served manifests, sorted
+// by tag name. In a workspace, the first package to declare a tag name wins.
+// It also returns the package name, which titles the site.
+func (s *Server) docsElements() (string, []docsElement, error) {
+	type manifestSource struct {
+		name     string
+		manifest []byte
+	}
+	var sources []manifestSource
+	var packageName string
+	if s.IsWorkspace() {
+		for _, pkg := range s.WorkspacePackages() {
+			sources = append(sources, manifestSource{name: pkg.Name, manifest: pkg.Manifest})
+		}
+	} else {
+		manifest, err := s.Manifest()
+		if err != nil {
+			return "", nil, fmt.Errorf("get manifest: %w", err)
+		}
+		if pkg, err := s.PackageJSON(); err == nil && pkg != nil {
+			packageName = pkg.Name
+		}
+		sources = append(sources, manifestSource{name: packageName, manifest: manifest})
+	}
+
+	seen := make(map[string]bool)
+	var elements []docsElement
+	for _, source := range sources {
+		if len(source.manifest) == 0 {
+			continue
+		}
+		var pkg M.Package
+		if err := json.Unmarshal(source.manifest, &pkg); err != nil {
+			return "", nil, fmt.Errorf("parse manifest for %q: %w", source.name, err)
+		}
+		for _, module := range pkg.Modules {
+			for _, decl := range module.Declarations {
+				ce, ok := decl.(*M.CustomElementDeclaration)
+				if !ok || ce.TagName == "" || seen[ce.TagName] {
+					continue
+				}
+				seen[ce.TagName] = true
+				elements = append(elements, docsElement{decl: ce, packageName: source.name})
+			}
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		return elements[i].decl.TagName < elements[j].decl.TagName
+	})
+	return packageName, elements, nil
+}
+
+// chromelessRoute returns the route of a demo's chromeless page, next to
+// the demo's page so that the demo's relative URLs still resolve,
+// e.g. /elements/button/demo/ → /elements/button/demo/chromeless.html
+func chromelessRoute(route string) string {
+	if strings.HasSuffix(route, "/") {
+		return route + "chromeless.html"
+	}
+	if ext := path.Ext(route); ext != "" {
+		return strings.TrimSuffix(route, ext) + ".chromeless.html"
+	}
+	return route + "/chromeless.html"
+}
+
+// docsDemoTitle titles a demo by its last path segment, e.g. "Demo" or
+// "Accents" for /elements/accordion/demo/accents/
+func docsDemoTitle(route string, entry *middleware.DemoRouteEntry) string {
+	name := path.Base(strings.TrimSuffix(route, "/"))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	if name == "" || name == "." || name == "/" {
+		name = entry.TagName
+	}
+	words := strings.Split(strings.ReplaceAll(name, "_", "-"), "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
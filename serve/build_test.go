@@ -607,6 +607,37 @@ func TestBuildSitemap_MapFS(t *testing.T) {
 	}
 }
 
+func TestBuildSitemap_ExtraRoutes(t *testing.T) {
+	memFS := platform.NewMapFS(nil)
+	s := &Server{fs: memFS}
+	config := BuildConfig{OutputDir: "dist", BasePath: "/components"}
+
+	if err := s.buildSitemap(nil, config, "/docs/my-button/"); err != nil {
+		t.Fatalf("buildSitemap: %v", err)
+	}
+
+	data, err := memFS.ReadFile("dist/components/sitemap.xml")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(data), "<loc>/components/docs/my-button/</loc>") {
+		t.Errorf("sitemap missing docs route, got:\n%s", data)
+	}
+}
+
+func TestChromelessRoute(t *testing.T) {
+	tests := map[string]string{
+		"/elements/button/demo/": "/elements/button/demo/chromeless.html",
+		"/demo/basic.html":       "/demo/basic.chromeless.html",
+		"/demo/basic":            "/demo/basic/chromeless.html",
+	}
+	for route, want := range tests {
+		if got := chromelessRoute(route); got != want {
+			t.Errorf("chromelessRoute(%q) = %q, want %q", route, got, want)
+		}
+	}
+}
+
 func TestBuildLightdomCSS_MapFS(t *testing.T) {
 	memFS := platform.NewMapFS(nil)
 	s := &Server{fs: memFS}
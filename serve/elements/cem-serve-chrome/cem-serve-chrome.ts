@@ -357,14 +357,27 @@ export class CemServeChrome extends LitElement {
       onOpen: () => {
         this.#$('reconnection-modal')?.close();
       },
-      onError: (errorData: { title?: string; message?: string; file?: string }) => {
+      onError: (errorData: {
+        title?: string;
+        message?: string;
+        file?: string;
+        line?: number;
+        column?: number;
+        codeFrame?: string;
+      }) => {
         if (errorData?.title && errorData?.message) {
           console.error('[cem-serve] Server error:', errorData);
-          (this.#$('error-overlay') as any)?.show(errorData.title, errorData.message, errorData.file);
+          (this.#$('error-overlay') as any)?.show(errorData.title, errorData.message, errorData.file, errorData);
         } else {
           console.error('[cem-serve] WebSocket error:', errorData);
         }
       },
+      onErrorCleared: ({ file }: { file: string }) => {
+        const errorOverlay = this.#$('error-overlay') as any;
+        if (errorOverlay?.open && errorOverlay.file === file) {
+          errorOverlay.hide();
+        }
+      },
       onReconnecting: ({ attempt, delay }: { attempt: number; delay: number }) => {
         if (attempt >= 15) {
           (this.#$('reconnection-modal') as any)?.showModal();
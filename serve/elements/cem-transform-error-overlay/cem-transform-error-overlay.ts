@@ -28,6 +28,15 @@ export class CemTransformErrorOverlay extends LitElement {
   @property()
   accessor message = '';
 
+  @property({ type: Number })
+  accessor line = 0;
+
+  @property({ type: Number })
+  accessor column = 0;
+
+  @property({ attribute: 'code-frame' })
+  accessor codeFrame = '';
+
   #handleKeydown = (e: KeyboardEvent) => {
     if (e.key === 'Escape' && this.open) {
       this.hide();
@@ -57,7 +66,10 @@ export class CemTransformErrorOverlay extends LitElement {
                         @click=${this.hide}>Dismiss</cem-pf-v6-button>
         </div>
         <div id="body">
-          <div id="file">${this.file ? `File: ${this.file}` : ''}</div>
+          <div id="file">${!this.file ? '' : html`
+            File: <a href="/${this.file}" target="_blank">${this.#location}</a>
+          `}</div>
+          <pre id="code-frame">${this.codeFrame}</pre>
           <div id="message">${this.message}</div>
         </div>
         <div id="footer">
@@ -67,16 +79,30 @@ export class CemTransformErrorOverlay extends LitElement {
     `;
   }
 
+  /** File path with the 1-based line and column of the error, when known */
+  get #location(): string {
+    return this.line ? `${this.file}:${this.line}:${this.column + 1}` : this.file;
+  }
+
   /**
    * Show the error overlay.
    * @param title - Error title
    * @param message - Error message
    * @param file - Optional file path where error occurred
+   * @param location - Optional location of the error in the file
    */
-  show(title: string, message: string, file = ''): void {
+  show(
+    title: string,
+    message: string,
+    file = '',
+    location?: { line?: number; column?: number; codeFrame?: string },
+  ): void {
     this.title = title;
     this.message = message;
     this.file = file;
+    this.line = location?.line ?? 0;
+    this.column = location?.column ?? 0;
+    this.codeFrame = location?.codeFrame ?? '';
     this.open = true;
   }
 
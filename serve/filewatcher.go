@@ -366,69 +366,77 @@ func (fw *fileWatcher) flushDebouncedEvents() {
 		return
 	}
 
-	// Collect all changed files and analyze event types
-	files := make([]string, 0, len(fw.debouncedFiles))
+	changes := make([]FileChange, 0, len(fw.debouncedFiles))
+	for file := range fw.debouncedFiles {
+		changes = append(changes, FileChange{Path: file, EventType: fw.fileEventTypes[file]})
+	}
+
+	// Clear the maps
+	fw.debouncedFiles = make(map[string]time.Time)
+	fw.fileEventTypes = make(map[string]string)
+
+	// Send batched event with all files
+	select {
+	case fw.events <- NewFileEvent(changes...):
+	case <-fw.done:
+		// Watcher closed, don't send
+		return
+	default:
+		// Channel full, drop event
+		if fw.logger != nil {
+			fw.logger.Warning("Dropped file event (channel full) - consider reducing change frequency")
+		}
+	}
+}
+
+// NewFileEvent batches file changes into one event, as the file watcher
+// batches the changes of each debounce window. Pass the result to
+// Server.HandleFileEvent to handle changes the watcher can't see, e.g. to
+// files which a codegen step writes outside the watch directory.
+func NewFileEvent(changes ...FileChange) FileEvent {
+	files := make([]string, 0, len(changes))
 	var hasCreates, hasDeletes, hasPackageJSON bool
 	var primaryEventType string
 
-	for file := range fw.debouncedFiles {
-		files = append(files, file)
-
-		// Check event type for this file
-		eventType := fw.fileEventTypes[file]
-		ext := filepath.Ext(file)
+	for _, change := range changes {
+		files = append(files, change.Path)
+		ext := filepath.Ext(change.Path)
 
 		// Only count creates/deletes for source files (.ts/.js), not temp files
 		isSourceFile := ext == ".ts" || ext == ".js"
-		if isSourceFile && eventType == "create" {
+		if isSourceFile && change.EventType == "create" {
 			hasCreates = true
-		} else if isSourceFile && eventType == "delete" {
+		} else if isSourceFile && change.EventType == "delete" {
 			hasDeletes = true
 		}
 
 		// Check if package.json was modified
-		if filepath.Base(file) == "package.json" {
+		if filepath.Base(change.Path) == "package.json" {
 			hasPackageJSON = true
 		}
 
 		// Use first file's event type as primary
 		if primaryEventType == "" {
-			primaryEventType = eventType
+			primaryEventType = change.EventType
 		}
 	}
 
-	// Clear the maps
-	fw.debouncedFiles = make(map[string]time.Time)
-	fw.fileEventTypes = make(map[string]string)
+	if primaryEventType == "" {
+		primaryEventType = "modify"
+	}
 
-	// Send batched event with all files
+	event := FileEvent{
+		Paths:          files,
+		EventType:      primaryEventType,
+		HasCreates:     hasCreates,
+		HasDeletes:     hasDeletes,
+		HasPackageJSON: hasPackageJSON,
+		Timestamp:      time.Now(),
+	}
 	if len(files) > 0 {
-		if primaryEventType == "" {
-			primaryEventType = "modify"
-		}
-
-		event := FileEvent{
-			Path:           files[0],
-			Paths:          files,
-			EventType:      primaryEventType,
-			HasCreates:     hasCreates,
-			HasDeletes:     hasDeletes,
-			HasPackageJSON: hasPackageJSON,
-			Timestamp:      time.Now(),
-		}
-
-		select {
-		case fw.events <- event:
-		case <-fw.done:
-			// Watcher closed, don't send
-			return
-		default:
-			// Channel full, drop event
-			if fw.logger != nil {
-				fw.logger.Warning("Dropped file event (channel full) - consider reducing change frequency")
-			}
-		}
+		event.Path = files[0]
 	}
+	return event
 }
 
 // shouldIgnore checks if a file path should be ignored based on patterns
@@ -423,3 +423,30 @@ func TestFileWatcher_ConfigFileHotReload(t *testing.T) {
 		t.Errorf("Expected updated URLPattern '/build/:path*', got %q", urlRewritesAfter[0].URLPattern)
 	}
 }
+
+// TestNewFileEvent verifies that synthetic batches are flagged as the
+// watcher flags its own
+func TestNewFileEvent(t *testing.T) {
+	event := serve.NewFileEvent(
+		serve.FileChange{Path: "/project/src/a.ts", EventType: "modify"},
+		serve.FileChange{Path: "/project/src/b.js", EventType: "delete"},
+		serve.FileChange{Path: "/project/package.json", EventType: "modify"},
+		serve.FileChange{Path: "/project/src/c.css", EventType: "create"},
+	)
+
+	if event.Path != "/project/src/a.ts" || event.EventType != "modify" {
+		t.Errorf("Expected the first change to be primary, got %s (%s)", event.Path, event.EventType)
+	}
+	if len(event.Paths) != 4 {
+		t.Errorf("Expected 4 paths, got %v", event.Paths)
+	}
+	if event.HasCreates {
+		t.Error("Expected creating a stylesheet not to count as a structural change")
+	}
+	if !event.HasDeletes {
+		t.Error("Expected deleting a module to set HasDeletes")
+	}
+	if !event.HasPackageJSON {
+		t.Error("Expected package.json to set HasPackageJSON")
+	}
+}
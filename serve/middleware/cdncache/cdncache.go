@@ -0,0 +1,466 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cdncache keeps local copies of the CDN modules which an import map
+// resolves to, so that demos keep working without a network connection.
+package cdncache
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"bennypowers.dev/cem/internal/logging"
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/serve/middleware/types"
+	"github.com/adrg/xdg"
+)
+
+const (
+	// PathPrefix is the URL path under which cached modules are served,
+	// e.g. /__cem/cdn/esm.sh/lit@3 for https://esm.sh/lit@3
+	PathPrefix = "/__cem/cdn/"
+	// maxModules caps the modules cached for one import map, including the
+	// dependencies of its entries
+	maxModules = 1000
+	// maxModuleSize caps the size of a cached module
+	maxModuleSize = 32 << 20
+	indexFileName = "index.json"
+)
+
+var (
+	errTooManyModules    = fmt.Errorf("more than %d modules", maxModules)
+	errIntegrityMismatch = errors.New("contents don't match their integrity hash")
+)
+
+// Config configures the CDN module cache
+type Config struct {
+	// Dir holds the cached modules. Defaults to cem's directory in the
+	// user's cache directory.
+	Dir string
+	// Offline serves every cached module from the cache, without contacting
+	// the CDNs
+	Offline bool
+	// Logger defaults to cem's global logger
+	Logger types.Logger
+	// FS defaults to the OS filesystem
+	FS platform.FileSystem
+	// Client fetches modules. Defaults to a client which requires TLS 1.2
+	// and respects the HTTPS_PROXY environment variable.
+	Client *http.Client
+}
+
+// entry is a cached module, or a redirect to one
+type entry struct {
+	File        string `json:"file,omitempty"`
+	Integrity   string `json:"integrity,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	// Redirect is the URL which the CDN redirected to, e.g. from a version
+	// range to an exact version
+	Redirect string `json:"redirect,omitempty"`
+}
+
+// Cache is an on-disk cache of CDN modules, indexed by URL
+type Cache struct {
+	config Config
+	client *http.Client
+	mu     sync.RWMutex
+	index  map[string]entry
+}
+
+// New creates a CDN module cache, loading the index of modules cached by
+// earlier sessions
+func New(config Config) *Cache {
+	if config.Dir == "" {
+		config.Dir = filepath.Join(xdg.CacheHome, "cem", "cdn")
+	}
+	if config.FS == nil {
+		config.FS = platform.NewOSFileSystem()
+	}
+	if config.Logger == nil {
+		config.Logger = logging.GetLogger()
+	}
+	client := config.Client
+	if client == nil {
+		client = newClient()
+	}
+	c := &Cache{
+		config: config,
+		client: client,
+		index:  make(map[string]entry),
+	}
+	if data, err := config.FS.ReadFile(filepath.Join(config.Dir, indexFileName)); err == nil {
+		if err := json.Unmarshal(data, &c.index); err != nil {
+			config.Logger.Warning("Ignoring corrupt CDN cache index: %v", err)
+			c.index = make(map[string]entry)
+		}
+	}
+	return c
+}
+
+// newClient creates the HTTP client which fetches modules from CDNs
+func newClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     &tls.Config{MinVersion: tls.VersionTLS12},
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Pinned is an import map whose CDN entries are pinned to the cache
+type Pinned struct {
+	Imports map[string]string
+	Scopes  map[string]map[string]string
+	// Integrity maps the URLs of the modules served from the cache to their
+	// hashes, for the import map's "integrity" key
+	Integrity map[string]string
+}
+
+// Pin caches the https modules which an import map's entries resolve to,
+// along with the modules they import, and returns the import map with the
+// entries pointing at the cache wherever their CDN can't be reached, or
+// everywhere when offline. Reachable entries keep their CDN URLs, since
+// CDNs like esm.sh tailor their modules to the browser.
+//
+// Prefix entries, e.g. "lit/": "https://esm.sh/lit/", can't be prefetched,
+// so modules under them are only cached when a cached module imports them.
+func (c *Cache) Pin(ctx context.Context, imports map[string]string, scopes map[string]map[string]string) *Pinned {
+	p := &pinner{
+		cache:       c,
+		ctx:         ctx,
+		unreachable: make(map[string]bool),
+		fetched:     make(map[string]bool),
+	}
+	pinned := &Pinned{
+		Imports:   make(map[string]string, len(imports)),
+		Integrity: make(map[string]string),
+	}
+	if scopes != nil {
+		pinned.Scopes = make(map[string]map[string]string, len(scopes))
+		for scope, entries := range scopes {
+			pinned.Scopes[scope] = make(map[string]string, len(entries))
+		}
+	}
+	// Prefix entries come last, once it's known which CDNs are unreachable
+	for _, prefixes := range []bool{false, true} {
+		p.pinEntries(imports, pinned.Imports, prefixes)
+		for scope, entries := range scopes {
+			p.pinEntries(entries, pinned.Scopes[scope], prefixes)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, target := range p.local {
+		c.addIntegrity(target, pinned.Integrity, seen)
+	}
+	if err := c.saveIndex(); err != nil {
+		c.config.Logger.Warning("Failed to save CDN cache index: %v", err)
+	}
+	return pinned
+}
+
+// pinner pins one import map
+type pinner struct {
+	cache *Cache
+	ctx   context.Context
+	// unreachable records the hosts which couldn't be reached
+	unreachable map[string]bool
+	// fetched records the modules fetched, or found in the cache
+	fetched map[string]bool
+	// local are the entries' modules which are served from the cache
+	local []string
+}
+
+// pinEntries pins either the prefix entries of a map of specifiers to URLs,
+// or the rest
+func (p *pinner) pinEntries(entries, pinned map[string]string, prefixes bool) {
+	for specifier, target := range entries {
+		if strings.HasSuffix(target, "/") == prefixes {
+			pinned[specifier] = p.pin(target)
+		}
+	}
+}
+
+// pin caches an entry's module and returns the URL to serve it from
+func (p *pinner) pin(target string) string {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return target
+	}
+	offline := p.cache.config.Offline || p.unreachable[u.Host]
+	if strings.HasSuffix(target, "/") {
+		if offline {
+			return localURL(u)
+		}
+		return target
+	}
+
+	if !offline {
+		err := p.fetch(target, true)
+		if err == nil {
+			return target
+		}
+		var urlErr *url.Error
+		if !errors.As(err, &urlErr) {
+			p.cache.config.Logger.Warning("Failed to cache %s: %v", target, err)
+			return target
+		}
+		p.unreachable[u.Host] = true
+		p.cache.config.Logger.Warning("%s is unreachable, serving its modules from the CDN cache: %v", u.Host, err)
+	}
+	if _, ok := p.cache.lookup(target); !ok {
+		p.cache.config.Logger.Warning("%s is not cached, so won't load offline", target)
+		return target
+	}
+	p.local = append(p.local, target)
+	return localURL(u)
+}
+
+// fetch caches a module and the modules it imports. Cached modules are only
+// fetched again when refresh is set, e.g. for an import map's entries,
+// whose URLs may name version ranges.
+func (p *pinner) fetch(target string, refresh bool) error {
+	if p.fetched[target] {
+		return nil
+	}
+	if len(p.fetched) >= maxModules {
+		return errTooManyModules
+	}
+	p.fetched[target] = true
+
+	if !refresh {
+		if final, e, ok := p.cache.resolve(target); ok {
+			body, err := p.cache.read(e)
+			if err == nil {
+				return p.fetchDependencies(final, e, body)
+			}
+		}
+	}
+
+	body, final, contentType, err := p.cache.download(p.ctx, target)
+	if err != nil {
+		return err
+	}
+	e, err := p.cache.store(target, final, body, contentType)
+	if err != nil {
+		return err
+	}
+	p.fetched[final] = true
+	return p.fetchDependencies(final, e, body)
+}
+
+// fetchDependencies caches the modules which a cached module imports.
+// Failing to reach the CDN fails the module, while other failures only lose
+// the dependency.
+func (p *pinner) fetchDependencies(moduleURL string, e entry, body []byte) error {
+	if !isJavaScript(e.ContentType) {
+		return nil
+	}
+	base, err := url.Parse(moduleURL)
+	if err != nil {
+		return err
+	}
+	for _, spec := range moduleSpecifiers(body) {
+		dependency := resolveSpecifier(base, spec.text)
+		if !strings.HasPrefix(dependency, "https://") {
+			continue
+		}
+		if err := p.fetch(dependency, false); err != nil {
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) || errors.Is(err, errTooManyModules) {
+				return err
+			}
+			p.cache.config.Logger.Warning("Failed to cache %s, imported by %s: %v", dependency, moduleURL, err)
+		}
+	}
+	return nil
+}
+
+// download fetches a module, returning its contents, its URL after
+// redirects, and its content type
+func (c *Cache) download(ctx context.Context, target string) ([]byte, string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxModuleSize+1))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(body) > maxModuleSize {
+		return nil, "", "", fmt.Errorf("larger than %d bytes", maxModuleSize)
+	}
+	return body, resp.Request.URL.String(), resp.Header.Get("Content-Type"), nil
+}
+
+// store writes a module to the cache, recording a redirect to it from the
+// URL which was requested
+func (c *Cache) store(target, final string, body []byte, contentType string) (entry, error) {
+	sum := sha256.Sum256([]byte(final))
+	file := hex.EncodeToString(sum[:])
+	dir := filepath.Join(c.config.Dir, "modules")
+	if err := c.config.FS.MkdirAll(dir, 0o755); err != nil {
+		return entry{}, err
+	}
+	if err := c.config.FS.WriteFile(filepath.Join(dir, file), body, 0o644); err != nil {
+		return entry{}, err
+	}
+
+	e := entry{File: file, Integrity: integrity(body), ContentType: contentType}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index[final] = e
+	if final != target {
+		c.index[target] = entry{Redirect: final}
+	}
+	return e, nil
+}
+
+// lookup returns the cache entry of a URL
+func (c *Cache) lookup(target string) (entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.index[target]
+	return e, ok
+}
+
+// resolve follows a URL's cached redirects to its module's entry
+func (c *Cache) resolve(target string) (string, entry, bool) {
+	for range 10 {
+		e, ok := c.lookup(target)
+		if !ok {
+			return "", entry{}, false
+		}
+		if e.Redirect == "" {
+			return target, e, true
+		}
+		target = e.Redirect
+	}
+	return "", entry{}, false
+}
+
+// read reads a cached module, checking its contents against the integrity
+// hash recorded when it was fetched
+func (c *Cache) read(e entry) ([]byte, error) {
+	body, err := c.config.FS.ReadFile(filepath.Join(c.config.Dir, "modules", e.File))
+	if err != nil {
+		return nil, err
+	}
+	if integrity(body) != e.Integrity {
+		return nil, errIntegrityMismatch
+	}
+	return body, nil
+}
+
+// addIntegrity records the hashes of a cached module as it is served, and
+// of the cached modules it imports
+func (c *Cache) addIntegrity(target string, hashes map[string]string, seen map[string]bool) {
+	if seen[target] || len(seen) >= maxModules {
+		return
+	}
+	seen[target] = true
+	final, e, ok := c.resolve(target)
+	if !ok {
+		return
+	}
+	body, err := c.read(e)
+	if err != nil {
+		return
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return
+	}
+	var dependencies []string
+	if isJavaScript(e.ContentType) {
+		body, dependencies = c.rewrite(final, body)
+	}
+	hashes[localURL(u)] = integrity(body)
+	if final != target {
+		c.addIntegrity(final, hashes, seen)
+	}
+	for _, dependency := range dependencies {
+		c.addIntegrity(dependency, hashes, seen)
+	}
+}
+
+// saveIndex writes the index of cached modules
+func (c *Cache) saveIndex() error {
+	c.mu.RLock()
+	data, err := json.MarshalIndent(c.index, "", "  ")
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	if err := c.config.FS.MkdirAll(c.config.Dir, 0o755); err != nil {
+		return err
+	}
+	return c.config.FS.WriteFile(filepath.Join(c.config.Dir, indexFileName), data, 0o644)
+}
+
+// integrity formats a subresource integrity hash
+func integrity(body []byte) string {
+	sum := sha512.Sum384(body)
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// localURL returns the URL path which serves a CDN URL from the cache
+func localURL(u *url.URL) string {
+	local := PathPrefix + u.Host + u.EscapedPath()
+	if u.RawQuery != "" {
+		local += "?" + u.RawQuery
+	}
+	return local
+}
+
+// remoteURL returns the CDN URL of a URL under PathPrefix
+func remoteURL(u *url.URL) string {
+	remote := "https://" + strings.TrimPrefix(u.EscapedPath(), PathPrefix)
+	if u.RawQuery != "" {
+		remote += "?" + u.RawQuery
+	}
+	return remote
+}
+
+func isJavaScript(contentType string) bool {
+	return contentType == "" || strings.Contains(contentType, "javascript")
+}
@@ -0,0 +1,263 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cdncache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+)
+
+// testLogger collects warnings
+type testLogger struct {
+	warnings []string
+}
+
+func (l *testLogger) Debug(msg string, args ...any) {}
+func (l *testLogger) Info(msg string, args ...any)  {}
+func (l *testLogger) Error(msg string, args ...any) {}
+func (l *testLogger) Warning(msg string, args ...any) {
+	l.warnings = append(l.warnings, fmt.Sprintf(msg, args...))
+}
+
+// newCDN serves a small module graph over TLS, like esm.sh: an unversioned
+// URL redirects to a versioned one, which imports a root-relative module
+// and a relative one
+func newCDN(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	modules := map[string]string{
+		"/lit@3.2.0/index.js":      "export * from \"/lit-html@3/lit-html.js\";\nimport './decorators.js';\n",
+		"/lit@3.2.0/decorators.js": "export const property = () => {};\n",
+		"/lit-html@3/lit-html.js":  "export const html = () => import(\"/lit-html@3/directive.js\");\n",
+		"/lit-html@3/directive.js": "export const directive = 1;\n",
+	}
+	cdn := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/lit@3" {
+			http.Redirect(w, r, "/lit@3.2.0/index.js", http.StatusFound)
+			return
+		}
+		module, ok := modules[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/javascript")
+		_, _ = w.Write([]byte(module))
+	}))
+	t.Cleanup(cdn.Close)
+	return cdn
+}
+
+func serve(t *testing.T, cache *Cache, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "next", http.StatusTeapot)
+	})
+	cache.Middleware()(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestPin_Online(t *testing.T) {
+	var requests atomic.Int32
+	cdn := newCDN(t, &requests)
+	cache := New(Config{Dir: t.TempDir(), Logger: &testLogger{}, Client: cdn.Client()})
+
+	imports := map[string]string{
+		"lit":   cdn.URL + "/lit@3",
+		"lit/":  cdn.URL + "/lit@3/",
+		"local": "/node_modules/local/index.js",
+	}
+	pinned := cache.Pin(context.Background(), imports, nil)
+
+	for specifier, target := range imports {
+		if pinned.Imports[specifier] != target {
+			t.Errorf("Expected %q to stay %q online, got %q", specifier, target, pinned.Imports[specifier])
+		}
+	}
+	if len(pinned.Integrity) != 0 {
+		t.Errorf("Expected no integrity hashes online, got %v", pinned.Integrity)
+	}
+	for _, path := range []string{"/lit@3.2.0/decorators.js", "/lit-html@3/lit-html.js", "/lit-html@3/directive.js"} {
+		if _, ok := cache.lookup(cdn.URL + path); !ok {
+			t.Errorf("Expected %s to be cached", path)
+		}
+	}
+}
+
+func TestNew_CorruptIndexWithoutLogger(t *testing.T) {
+	fs := platform.NewMapFileSystem(nil)
+	if err := fs.WriteFile(filepath.Join("/cache", indexFileName), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cache := New(Config{Dir: "/cache", FS: fs})
+	if len(cache.index) != 0 {
+		t.Errorf("Expected a corrupt index to be ignored, got %v", cache.index)
+	}
+}
+
+func TestPin_Unreachable(t *testing.T) {
+	var requests atomic.Int32
+	cdn := newCDN(t, &requests)
+	dir := t.TempDir()
+	New(Config{Dir: dir, Logger: &testLogger{}, Client: cdn.Client()}).
+		Pin(context.Background(), map[string]string{"lit": cdn.URL + "/lit@3"}, nil)
+	client := cdn.Client()
+	cdn.Close()
+
+	// A later session, on a plane
+	logger := &testLogger{}
+	cache := New(Config{Dir: dir, Logger: logger, Client: client})
+	host := strings.TrimPrefix(cdn.URL, "https://")
+	pinned := cache.Pin(context.Background(), map[string]string{
+		"lit":  cdn.URL + "/lit@3",
+		"lit/": cdn.URL + "/lit@3/",
+	}, map[string]map[string]string{
+		"/demo/": {"lit-html": cdn.URL + "/lit-html@3/lit-html.js"},
+	})
+
+	if want := PathPrefix + host + "/lit@3"; pinned.Imports["lit"] != want {
+		t.Errorf("Expected lit to be pinned to %q, got %q", want, pinned.Imports["lit"])
+	}
+	if want := PathPrefix + host + "/lit@3/"; pinned.Imports["lit/"] != want {
+		t.Errorf("Expected lit/ to be pinned to %q, got %q", want, pinned.Imports["lit/"])
+	}
+	if want := PathPrefix + host + "/lit-html@3/lit-html.js"; pinned.Scopes["/demo/"]["lit-html"] != want {
+		t.Errorf("Expected scoped lit-html to be pinned to %q, got %q", want, pinned.Scopes["/demo/"]["lit-html"])
+	}
+	if len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "unreachable") {
+		t.Errorf("Expected one unreachable warning, got %q", logger.warnings)
+	}
+
+	// The unversioned URL redirects within the cache
+	rec := serve(t, cache, PathPrefix+host+"/lit@3")
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != PathPrefix+host+"/lit@3.2.0/index.js" {
+		t.Fatalf("Expected a redirect to the versioned module, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// Root-relative imports point at the cache, and relative ones stay
+	rec = serve(t, cache, PathPrefix+host+"/lit@3.2.0/index.js")
+	want := "export * from \"" + PathPrefix + host + "/lit-html@3/lit-html.js\";\nimport './decorators.js';\n"
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("Unexpected module (%d):\n%s\nwant:\n%s", rec.Code, rec.Body.String(), want)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/javascript" {
+		t.Errorf("Expected the CDN's content type, got %q", ct)
+	}
+	if pinned.Integrity[PathPrefix+host+"/lit@3.2.0/index.js"] != integrity(rec.Body.Bytes()) {
+		t.Errorf("Expected the integrity hash of the served module, got %v", pinned.Integrity)
+	}
+
+	// Dynamic imports are rewritten too
+	rec = serve(t, cache, PathPrefix+host+"/lit-html@3/lit-html.js")
+	if !strings.Contains(rec.Body.String(), "import(\""+PathPrefix+host+"/lit-html@3/directive.js\")") {
+		t.Errorf("Expected the dynamic import to be rewritten, got:\n%s", rec.Body.String())
+	}
+	for _, path := range []string{"/lit@3", "/lit@3.2.0/decorators.js", "/lit-html@3/directive.js"} {
+		if _, ok := pinned.Integrity[PathPrefix+host+path]; !ok {
+			t.Errorf("Expected an integrity hash for %s", path)
+		}
+	}
+}
+
+func TestPin_Offline(t *testing.T) {
+	var requests atomic.Int32
+	cdn := newCDN(t, &requests)
+	dir := t.TempDir()
+	New(Config{Dir: dir, Logger: &testLogger{}, Client: cdn.Client()}).
+		Pin(context.Background(), map[string]string{"lit": cdn.URL + "/lit@3"}, nil)
+	before := requests.Load()
+
+	logger := &testLogger{}
+	cache := New(Config{Dir: dir, Offline: true, Logger: logger, Client: cdn.Client()})
+	pinned := cache.Pin(context.Background(), map[string]string{
+		"lit":     cdn.URL + "/lit@3",
+		"missing": cdn.URL + "/missing.js",
+	}, nil)
+
+	if requests.Load() != before {
+		t.Errorf("Expected no requests offline, got %d", requests.Load()-before)
+	}
+	host := strings.TrimPrefix(cdn.URL, "https://")
+	if pinned.Imports["lit"] != PathPrefix+host+"/lit@3" {
+		t.Errorf("Expected lit to be pinned, got %q", pinned.Imports["lit"])
+	}
+	if pinned.Imports["missing"] != cdn.URL+"/missing.js" {
+		t.Errorf("Expected the uncached module to keep its URL, got %q", pinned.Imports["missing"])
+	}
+	if len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "not cached") {
+		t.Errorf("Expected one not cached warning, got %q", logger.warnings)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var requests atomic.Int32
+	cdn := newCDN(t, &requests)
+	dir := t.TempDir()
+	New(Config{Dir: dir, Logger: &testLogger{}, Client: cdn.Client()}).
+		Pin(context.Background(), map[string]string{"lit": cdn.URL + "/lit@3"}, nil)
+	cache := New(Config{Dir: dir, Offline: true, Logger: &testLogger{}, Client: cdn.Client()})
+	host := strings.TrimPrefix(cdn.URL, "https://")
+
+	if rec := serve(t, cache, "/elements/demo/index.html"); rec.Code != http.StatusTeapot {
+		t.Errorf("Expected other requests to pass through, got %d", rec.Code)
+	}
+	if rec := serve(t, cache, PathPrefix+host+"/uncached.js"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected uncached modules to 404, got %d", rec.Code)
+	}
+
+	// Tampered modules aren't served
+	e, _ := cache.lookup(cdn.URL + "/lit@3.2.0/decorators.js")
+	if err := os.WriteFile(filepath.Join(dir, "modules", e.File), []byte("alert(1)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if rec := serve(t, cache, PathPrefix+host+"/lit@3.2.0/decorators.js"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected a tampered module to fail, got %d", rec.Code)
+	}
+}
+
+func TestModuleSpecifiers(t *testing.T) {
+	code := []byte(`import a from "./a.js";
+import "b";
+export { c } from '/c.js';
+export * from "https://esm.sh/d";
+const e = await import("./e.js");
+const f = import(name);
+const g = "from './g.js'";
+`)
+	var got []string
+	for _, spec := range moduleSpecifiers(code) {
+		got = append(got, spec.text)
+		if string(code[spec.start:spec.end]) != spec.text {
+			t.Errorf("Expected the range of %q to cover it, got %q", spec.text, code[spec.start:spec.end])
+		}
+	}
+	want := []string{"./a.js", "b", "/c.js", "https://esm.sh/d", "./e.js"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected specifiers %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cdncache
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"bennypowers.dev/cem/internal/languages/typescript"
+	"bennypowers.dev/cem/serve/middleware"
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Middleware serves cached modules under PathPrefix. Their imports of CDN
+// URLs are rewritten to the cache, and modules whose contents don't match
+// the integrity hash recorded when they were fetched aren't served.
+func (c *Cache) Middleware() middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, PathPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			target := remoteURL(r.URL)
+			e, ok := c.lookup(target)
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			if e.Redirect != "" {
+				redirect, err := url.Parse(e.Redirect)
+				if err != nil {
+					http.NotFound(w, r)
+					return
+				}
+				http.Redirect(w, r, localURL(redirect), http.StatusFound)
+				return
+			}
+
+			body, err := c.read(e)
+			if err != nil {
+				c.config.Logger.Error("Failed to serve %s from the CDN cache: %v", target, err)
+				http.Error(w, "Cached module is unreadable", http.StatusInternalServerError)
+				return
+			}
+			contentType := e.ContentType
+			if isJavaScript(contentType) {
+				body, _ = c.rewrite(target, body)
+				if contentType == "" {
+					contentType = "application/javascript; charset=utf-8"
+				}
+			}
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("Cache-Control", "no-cache")
+			_, _ = w.Write(body)
+		})
+	}
+}
+
+// rewrite points a cached module's imports of CDN URLs, including
+// root-relative ones like esm.sh's "/lit@3/es2022/lit.mjs", at the cache.
+// Relative imports resolve to the cache as they are. It also returns the
+// CDN URLs of the module's https imports.
+func (c *Cache) rewrite(moduleURL string, body []byte) ([]byte, []string) {
+	base, err := url.Parse(moduleURL)
+	if err != nil {
+		return body, nil
+	}
+	var out []byte
+	var dependencies []string
+	last := uint(0)
+	for _, spec := range moduleSpecifiers(body) {
+		dependency := resolveSpecifier(base, spec.text)
+		if !strings.HasPrefix(dependency, "https://") {
+			continue
+		}
+		dependencies = append(dependencies, dependency)
+		if !strings.HasPrefix(spec.text, "/") && !strings.HasPrefix(spec.text, "https://") {
+			continue
+		}
+		u, err := url.Parse(dependency)
+		if err != nil {
+			continue
+		}
+		out = append(out, body[last:spec.start]...)
+		out = append(out, localURL(u)...)
+		last = spec.end
+	}
+	if out == nil {
+		return body, dependencies
+	}
+	return append(out, body[last:]...), dependencies
+}
+
+// specifier is a module specifier in a module's source, with the byte range
+// of its text between the quotes
+type specifier struct {
+	text       string
+	start, end uint
+}
+
+// moduleSpecifiers finds the string specifiers of a module's static imports
+// and re-exports, and of its dynamic imports
+func moduleSpecifiers(code []byte) []specifier {
+	parser := typescript.BorrowParser()
+	defer typescript.ReturnParser(parser)
+	tree := parser.Parse(code, nil)
+	if tree == nil {
+		return nil
+	}
+	defer tree.Close()
+
+	var specifiers []specifier
+	cursor := tree.Walk()
+	defer cursor.Close()
+	for {
+		node := cursor.Node()
+		var source *ts.Node
+		switch node.Kind() {
+		case "import_statement", "export_statement":
+			source = node.ChildByFieldName("source")
+		case "call_expression":
+			if fn := node.ChildByFieldName("function"); fn != nil && fn.Kind() == "import" {
+				if args := node.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() > 0 {
+					source = args.NamedChild(0)
+				}
+			}
+		}
+		if source != nil && source.Kind() == "string" && source.EndByte()-source.StartByte() >= 2 {
+			start, end := source.StartByte()+1, source.EndByte()-1
+			specifiers = append(specifiers, specifier{text: string(code[start:end]), start: start, end: end})
+		}
+
+		if cursor.GotoFirstChild() {
+			continue
+		}
+		for !cursor.GotoNextSibling() {
+			if !cursor.GotoParent() {
+				return specifiers
+			}
+		}
+	}
+}
+
+// resolveSpecifier resolves a URL or relative specifier against a module's
+// URL, returning "" for bare specifiers, which the import map resolves
+func resolveSpecifier(base *url.URL, spec string) string {
+	switch {
+	case strings.HasPrefix(spec, "https://"), strings.HasPrefix(spec, "http://"):
+		return spec
+	case strings.HasPrefix(spec, "/"), strings.HasPrefix(spec, "./"), strings.HasPrefix(spec, "../"):
+		ref, err := url.Parse(spec)
+		if err != nil {
+			return ""
+		}
+		return base.ResolveReference(ref).String()
+	}
+	return ""
+}
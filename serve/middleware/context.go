@@ -106,6 +106,9 @@ type DevServerContext interface {
 	// Returns "light", "shadow", or "iframe"
 	DemoRenderingMode() string
 
+	// DemoEnv returns the configured demo environment variables (may be nil)
+	DemoEnv() map[string]string
+
 	// URLRewrites returns the configured URL rewrites for request path resolution
 	URLRewrites() []config.URLRewrite
 
@@ -0,0 +1,257 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package demohead gives demo pages a consistent head, so that demo files
+// which are bare HTML fragments are presentable when served as they are.
+package demohead
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"bennypowers.dev/cem/serve/middleware"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Demo describes the element which a demo page demonstrates
+type Demo struct {
+	TagName string
+	Summary string
+}
+
+// ThemeToggle configures a button which switches a demo page between light
+// and dark themes, by flipping an attribute or class on the <html> element
+type ThemeToggle struct {
+	// Attribute is set to "light" or "dark"
+	Attribute string
+	// Class is present in the dark theme
+	Class string
+}
+
+// Enabled reports whether the toggle flips anything
+func (t ThemeToggle) Enabled() bool {
+	return t.Attribute != "" || t.Class != ""
+}
+
+// Config holds configuration for the demo head middleware
+type Config struct {
+	// Lookup finds the demo served at a URL path
+	Lookup func(path string) (Demo, bool)
+	// ThemeToggle adds a theme toggle to demo pages, when enabled
+	ThemeToggle ThemeToggle
+}
+
+// New creates a middleware that completes the head of demo pages. Pages
+// without a title get one from the demo's element and its summary, pages
+// without a viewport meta tag get one, and when a theme toggle is configured,
+// pages outside the dev server chrome get a toggle button.
+func New(config Config) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Only pages can be demos, not their modules, styles, or assets
+			if ext := path.Ext(r.URL.Path); ext != "" && ext != ".html" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if config.Lookup == nil || strings.HasPrefix(r.URL.Path, "/__cem") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			demo, ok := config.Lookup(r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := middleware.NewResponseRecorder()
+			next.ServeHTTP(rec, r)
+
+			contentType := rec.Header().Get("Content-Type")
+			if rec.StatusCode() != http.StatusOK || !middleware.IsHTMLResponse(contentType) {
+				writeResponse(w, rec, rec.Body())
+				return
+			}
+
+			body, err := CompleteHead(string(rec.Body()), demo, config.ThemeToggle)
+			if err != nil {
+				writeResponse(w, rec, rec.Body())
+				return
+			}
+			writeResponse(w, rec, []byte(body))
+		})
+	}
+}
+
+// CompleteHead adds whichever of a title, a viewport meta tag, and a theme
+// toggle a demo page lacks. Fragments are parsed into full documents, and
+// complete pages are returned as they are.
+func CompleteHead(page string, demo Demo, toggle ThemeToggle) (string, error) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return "", err
+	}
+	head := findElement(doc, "head")
+	body := findElement(doc, "body")
+	if head == nil || body == nil {
+		return page, nil
+	}
+	changed := false
+
+	if title := findElement(head, "title"); title == nil || strings.TrimSpace(textContent(title)) == "" {
+		if title != nil {
+			head.RemoveChild(title)
+		}
+		title = &html.Node{Type: html.ElementNode, Data: "title", DataAtom: atom.Title}
+		title.AppendChild(&html.Node{Type: html.TextNode, Data: demoTitle(demo)})
+		head.AppendChild(title)
+		changed = true
+	}
+
+	if !hasViewport(head) {
+		head.AppendChild(&html.Node{
+			Type:     html.ElementNode,
+			Data:     "meta",
+			DataAtom: atom.Meta,
+			Attr: []html.Attribute{
+				{Key: "name", Val: "viewport"},
+				{Key: "content", Val: "width=device-width, initial-scale=1"},
+			},
+		})
+		changed = true
+	}
+
+	// The dev server chrome has its own color scheme control
+	if toggle.Enabled() && findElement(doc, "cem-serve-chrome") == nil {
+		nodes, err := html.ParseFragment(strings.NewReader(themeToggleHTML(toggle)), body)
+		if err != nil {
+			return "", err
+		}
+		for _, node := range nodes {
+			body.AppendChild(node)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return page, nil
+	}
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// demoTitle titles a demo page after its element, and the element's summary
+func demoTitle(demo Demo) string {
+	summary := strings.Join(strings.Fields(demo.Summary), " ")
+	switch {
+	case demo.TagName == "":
+		return summary
+	case summary == "":
+		return demo.TagName
+	default:
+		return demo.TagName + " - " + summary
+	}
+}
+
+// themeToggleScript flips the theme, remembering the choice across demos,
+// and starting from the user's preferred color scheme
+const themeToggleScript = `
+const root = document.documentElement;
+const button = document.getElementById('cem-theme-toggle');
+const attribute = %s;
+const className = %s;
+const stored = localStorage.getItem('cem-demo-theme');
+let dark = stored ? stored === 'dark' : matchMedia('(prefers-color-scheme: dark)').matches;
+function apply() {
+  if (attribute) root.setAttribute(attribute, dark ? 'dark' : 'light');
+  if (className) root.classList.toggle(className, dark);
+  root.style.colorScheme = dark ? 'dark' : 'light';
+  button.setAttribute('aria-pressed', String(dark));
+}
+button.addEventListener('click', () => {
+  dark = !dark;
+  localStorage.setItem('cem-demo-theme', dark ? 'dark' : 'light');
+  apply();
+});
+apply();
+`
+
+// themeToggleHTML renders the theme toggle button and its script
+func themeToggleHTML(toggle ThemeToggle) string {
+	// json.Marshal escapes <, >, and &, so the values can't close the script
+	attribute, _ := json.Marshal(toggle.Attribute)
+	className, _ := json.Marshal(toggle.Class)
+	return `<button type="button" id="cem-theme-toggle" aria-label="Dark theme" aria-pressed="false"` +
+		` style="position: fixed; inset-block-end: 1rem; inset-inline-end: 1rem; z-index: 2147483647;">◐</button>` +
+		`<script type="module">` + fmt.Sprintf(themeToggleScript, attribute, className) + `</script>`
+}
+
+// hasViewport reports whether the head has a viewport meta tag
+func hasViewport(head *html.Node) bool {
+	for c := head.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom != atom.Meta {
+			continue
+		}
+		for _, attr := range c.Attr {
+			if attr.Key == "name" && strings.EqualFold(attr.Val, "viewport") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findElement finds the first element with the given tag name
+func findElement(n *html.Node, tag string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tag {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if result := findElement(c, tag); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// textContent concatenates the text inside a node
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+	}
+	return b.String()
+}
+
+// writeResponse writes the captured response with the given body
+func writeResponse(w http.ResponseWriter, rec *middleware.ResponseRecorder, body []byte) {
+	middleware.CopyHeaders(w.Header(), rec.Header(), "Content-Length")
+	w.WriteHeader(rec.StatusCode())
+	if _, err := w.Write(body); err != nil {
+		// Client disconnected or write error - can't respond
+		return
+	}
+}
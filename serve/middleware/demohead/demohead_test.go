@@ -0,0 +1,117 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package demohead_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/serve/middleware/demohead"
+)
+
+// Inline: HTML snippets are clearer inline than as fixtures
+
+var buttonDemo = demohead.Demo{TagName: "my-button", Summary: "A clickable\n  button"}
+
+func lookupButton(path string) (demohead.Demo, bool) {
+	if path == "/elements/my-button/demo/" || path == "/elements/my-button/demo/index.html" {
+		return buttonDemo, true
+	}
+	return demohead.Demo{}, false
+}
+
+func serve(t *testing.T, config demohead.Config, path, contentType, body string) string {
+	t.Helper()
+	handler := demohead.New(config)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(body))
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	return rec.Body.String()
+}
+
+func TestMiddleware_CompletesFragment(t *testing.T) {
+	body := serve(t, demohead.Config{Lookup: lookupButton},
+		"/elements/my-button/demo/index.html", "text/html", "<my-button>Click</my-button>")
+
+	if !strings.Contains(body, "<title>my-button - A clickable button</title>") {
+		t.Errorf("expected title from element summary, got: %s", body)
+	}
+	if !strings.Contains(body, `<meta name="viewport" content="width=device-width, initial-scale=1"/>`) {
+		t.Errorf("expected viewport meta, got: %s", body)
+	}
+	if !strings.Contains(body, "<body><my-button>Click</my-button></body>") {
+		t.Errorf("expected demo content in body, got: %s", body)
+	}
+	if strings.Contains(body, "cem-theme-toggle") {
+		t.Errorf("expected no theme toggle when unconfigured, got: %s", body)
+	}
+}
+
+func TestMiddleware_KeepsExistingHead(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><title>Buttons</title>` +
+		`<meta name="viewport" content="width=320"></head><body><my-button></my-button></body></html>`
+	body := serve(t, demohead.Config{Lookup: lookupButton}, "/elements/my-button/demo/", "text/html; charset=utf-8", page)
+
+	if body != page {
+		t.Errorf("expected complete page to pass through unchanged, got: %s", body)
+	}
+}
+
+func TestMiddleware_ThemeToggle(t *testing.T) {
+	toggle := demohead.ThemeToggle{Attribute: "data-theme", Class: "dark"}
+	config := demohead.Config{Lookup: lookupButton, ThemeToggle: toggle}
+
+	body := serve(t, config, "/elements/my-button/demo/", "text/html", "<my-button></my-button>")
+	if !strings.Contains(body, `id="cem-theme-toggle"`) {
+		t.Errorf("expected theme toggle button, got: %s", body)
+	}
+	if !strings.Contains(body, `const attribute = "data-theme";`) || !strings.Contains(body, `const className = "dark";`) {
+		t.Errorf("expected toggle script to flip the configured attribute and class, got: %s", body)
+	}
+
+	chrome := `<html><head><title>Demo</title></head><body><cem-serve-chrome></cem-serve-chrome></body></html>`
+	body = serve(t, config, "/elements/my-button/demo/", "text/html", chrome)
+	if strings.Contains(body, "cem-theme-toggle") {
+		t.Errorf("expected no theme toggle inside the dev server chrome, got: %s", body)
+	}
+}
+
+func TestMiddleware_SkipsOtherResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+	}{
+		{"not a demo", "/index.html", "text/html"},
+		{"module", "/elements/my-button/demo/index.js", "text/javascript"},
+		{"not HTML", "/elements/my-button/demo/", "application/json"},
+		{"internal route", "/__cem/elements/my-button/demo/", "text/html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const page = "<my-button></my-button>"
+			if body := serve(t, demohead.Config{Lookup: lookupButton}, tt.path, tt.contentType, page); body != page {
+				t.Errorf("expected response to pass through unchanged, got: %s", body)
+			}
+		})
+	}
+}
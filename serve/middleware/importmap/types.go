@@ -30,6 +30,8 @@ import (
 type ImportMap struct {
 	Imports map[string]string            `json:"imports"`
 	Scopes  map[string]map[string]string `json:"scopes,omitempty"`
+	// Integrity maps module URLs to subresource integrity hashes
+	Integrity map[string]string `json:"integrity,omitempty"`
 }
 
 // IsImportMap is a marker method to implement the middleware.ImportMap interface
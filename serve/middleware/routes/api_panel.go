@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package routes
+
+import (
+	"bytes"
+	"html/template"
+
+	M "bennypowers.dev/cem/manifest"
+)
+
+// APIPanelData describes the API of the element a demo demonstrates, for
+// the panel beneath the sidebar navigation
+type APIPanelData struct {
+	TagName    string
+	Summary    string
+	Attributes []APIPanelAttribute
+}
+
+// APIPanelAttribute is an attribute of the demoed element, with its value
+// on the element's first instance in the demo. The demo shell script keeps
+// the value up to date as the attribute changes.
+type APIPanelAttribute struct {
+	Name    string
+	Type    string
+	Summary string
+	Present bool
+	Value   string
+}
+
+// NewAPIPanelData looks up the demoed element in the manifest, returning
+// nil if the manifest doesn't declare it
+func NewAPIPanelData(pkg *M.Package, tagName string, demoHTML []byte) *APIPanelData {
+	if pkg == nil || tagName == "" {
+		return nil
+	}
+	var declaration *M.RenderableCustomElementDeclaration
+	for _, renderable := range pkg.RenderableCustomElementDeclarations() {
+		if renderable.CustomElementDeclaration.TagName == tagName {
+			declaration = renderable
+			break
+		}
+	}
+	if declaration == nil {
+		return nil
+	}
+
+	var values map[string]string
+	if instances, err := discoverElementInstances(tagName, demoHTML); err == nil && len(instances) > 0 {
+		values = instances[0].Attributes
+	}
+
+	data := &APIPanelData{
+		TagName: tagName,
+		Summary: declaration.Summary(),
+	}
+	for _, attr := range declaration.CustomElementDeclaration.Attributes() {
+		attribute := APIPanelAttribute{
+			Name:    attr.Name,
+			Summary: attr.Summary,
+		}
+		if attribute.Summary == "" {
+			attribute.Summary = attr.Description
+		}
+		if attr.Type != nil {
+			attribute.Type = attr.Type.Text
+		}
+		attribute.Value, attribute.Present = values[attr.Name]
+		data.Attributes = append(data.Attributes, attribute)
+	}
+	return data
+}
+
+// RenderAPIPanelHTML renders the API panel for the demoed element, or
+// nothing if the manifest doesn't declare it
+func RenderAPIPanelHTML(templates *TemplateRegistry, pkg *M.Package, tagName string, demoHTML []byte) (template.HTML, error) {
+	data := NewAPIPanelData(pkg, tagName, demoHTML)
+	if data == nil {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := templates.APIPanelTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
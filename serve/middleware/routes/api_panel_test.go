@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package routes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	M "bennypowers.dev/cem/manifest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func loadAPIPanelManifest(t *testing.T) *M.Package {
+	t.Helper()
+	var pkg M.Package
+	if err := json.Unmarshal(loadKnobsFixture(t, "simple-button-manifest.json"), &pkg); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	return &pkg
+}
+
+func TestNewAPIPanelData(t *testing.T) {
+	pkg := loadAPIPanelManifest(t)
+	demoHTML := loadKnobsFixture(t, "simple-button-demo.html")
+
+	data := NewAPIPanelData(pkg, "my-button", demoHTML)
+	if data == nil {
+		t.Fatal("Expected API panel data for my-button")
+	}
+
+	expected := []APIPanelAttribute{
+		{Name: "disabled", Type: "boolean", Summary: "Whether the button is disabled"},
+		{Name: "variant", Type: "'primary' | 'secondary' | 'danger'", Summary: "Button variant", Present: true, Value: "primary"},
+		{Name: "size", Type: "'sm' | 'md' | 'lg'", Summary: "Button size", Present: true, Value: "md"},
+	}
+	if diff := cmp.Diff(expected, data.Attributes); diff != "" {
+		t.Errorf("Attributes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewAPIPanelData_UnknownElement(t *testing.T) {
+	pkg := loadAPIPanelManifest(t)
+	if data := NewAPIPanelData(pkg, "not-declared", nil); data != nil {
+		t.Errorf("Expected no API panel data for undeclared element, got %+v", data)
+	}
+	if data := NewAPIPanelData(nil, "my-button", nil); data != nil {
+		t.Errorf("Expected no API panel data without a manifest, got %+v", data)
+	}
+}
+
+func TestRenderAPIPanelHTML(t *testing.T) {
+	pkg := loadAPIPanelManifest(t)
+	demoHTML := loadKnobsFixture(t, "simple-button-demo.html")
+
+	rendered, err := RenderAPIPanelHTML(testTemplates(), pkg, "my-button", demoHTML)
+	if err != nil {
+		t.Fatalf("RenderAPIPanelHTML failed: %v", err)
+	}
+	html := string(rendered)
+
+	for _, want := range []string{
+		`slot="navigation"`,
+		`id="cem-api-panel"`,
+		`data-tag-name="my-button"`,
+		`data-attribute="variant"`,
+		`<code>&#34;primary&#34;</code>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected API panel to contain %q, got:\n%s", want, html)
+		}
+	}
+	if !strings.Contains(html, `<dd class="cem-api-value" data-absent>not set</dd>`) {
+		t.Errorf("Expected unset disabled attribute to render as absent, got:\n%s", html)
+	}
+
+	rendered, err = RenderAPIPanelHTML(testTemplates(), pkg, "not-declared", demoHTML)
+	if err != nil {
+		t.Fatalf("RenderAPIPanelHTML failed: %v", err)
+	}
+	if rendered != "" {
+		t.Errorf("Expected nothing for undeclared element, got %q", rendered)
+	}
+}
@@ -40,12 +40,14 @@ type ChromeData struct {
 	EnabledKnobs   string
 	KnobsHTML      template.HTML // Rendered knobs controls HTML
 	ImportMap      template.HTML // Use HTML instead of JS for importmap script content
+	Env            template.JS   // Demo environment variables as a JSON object, for window.__CEM_ENV__
 	Description    template.HTML
 	RenderingMode  string                // "light", "shadow", or "iframe"
 	SourceURL      string                // Source file URL (e.g., GitHub blob URL)
 	CanonicalURL   string                // Canonical demo URL
 	PackageName    string                // Package name for title (listing pages)
 	NavigationHTML template.HTML         // Navigation drawer HTML (listing pages)
+	APIPanelHTML   template.HTML         // API panel for the demoed element, beneath the navigation
 	ManifestJSON   template.JS           // Full manifest JSON for client-side tools
 	Manifest       *M.Package            // Parsed manifest for server-side tree rendering
 	Packages       []PackageWithManifest // Workspace packages with modules (for package-level tree)
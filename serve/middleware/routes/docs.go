@@ -0,0 +1,306 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package routes
+
+import (
+	"bytes"
+	"strings"
+
+	M "bennypowers.dev/cem/manifest"
+)
+
+// DocsElementData describes an element's page in the static docs site
+type DocsElementData struct {
+	TagName     string
+	Name        string
+	PackageName string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Demos       []DocsDemo
+	// PlatformFeatures are the notable platform APIs the element uses, with
+	// their browser support considerations
+	PlatformFeatures []M.PlatformFeature
+	Tables           []DocsTable
+}
+
+// DocsDemo is a demo embedded in an element's docs page
+type DocsDemo struct {
+	Title       string
+	Description string
+	// URL is the demo's page with the dev server chrome
+	URL string
+	// FrameURL is the chromeless demo page, for the iframe
+	FrameURL string
+}
+
+// DocsTable is a table of one kind of API, e.g. the element's attributes
+type DocsTable struct {
+	ID    string
+	Title string
+	// Typed tables have type and default columns
+	Typed bool
+	Rows  []DocsRow
+}
+
+// DocsRow is one API in a docs table
+type DocsRow struct {
+	Name        string
+	Type        string
+	Default     string
+	Description string
+	Deprecated  bool
+}
+
+// DocsIndexData describes the docs site's index page
+type DocsIndexData struct {
+	Title    string
+	Elements []DocsSearchEntry
+}
+
+// DocsSearchEntry is an element in the docs search index
+type DocsSearchEntry struct {
+	TagName  string   `json:"tagName"`
+	Name     string   `json:"name"`
+	Summary  string   `json:"summary,omitempty"`
+	URL      string   `json:"url"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// DocsElementURL returns the route of an element's docs page
+func DocsElementURL(tagName string) string {
+	return "/docs/" + tagName + "/"
+}
+
+// NewDocsElementData collects an element's public API into tables for its
+// docs page. Tables with no rows are omitted.
+func NewDocsElementData(decl *M.CustomElementDeclaration, packageName string, demos []DocsDemo) *DocsElementData {
+	data := &DocsElementData{
+		TagName:     decl.TagName,
+		Name:        decl.Name(),
+		PackageName: packageName,
+		Summary:     decl.Summary,
+		Description: decl.Description,
+		Deprecated:  decl.IsDeprecated(),
+		Demos:       demos,
+	}
+	if decl.Platform != nil {
+		data.PlatformFeatures = decl.Platform.FeatureSupport()
+	}
+
+	attributes := DocsTable{Title: "Attributes", Typed: true}
+	for _, attr := range decl.Attributes() {
+		attributes.Rows = append(attributes.Rows, DocsRow{
+			Name:        attr.Name,
+			Type:        typeText(attr.Type),
+			Default:     attr.Default,
+			Description: docsDescription(attr.Summary, attr.Description),
+			Deprecated:  attr.Deprecated != nil,
+		})
+	}
+
+	properties := DocsTable{Title: "Properties", Typed: true}
+	methods := DocsTable{Title: "Methods", Typed: true}
+	for _, member := range decl.Members {
+		switch m := member.(type) {
+		case *M.ClassField:
+			if row, ok := docsFieldRow(m); ok {
+				properties.Rows = append(properties.Rows, row)
+			}
+		case *M.CustomElementField:
+			if row, ok := docsFieldRow(&m.ClassField); ok {
+				properties.Rows = append(properties.Rows, row)
+			}
+		case *M.ClassMethod:
+			if m.Static || m.Privacy == M.Private || m.Privacy == M.Protected {
+				continue
+			}
+			methods.Rows = append(methods.Rows, DocsRow{
+				Name:        m.Name,
+				Type:        methodSignature(m),
+				Description: docsDescription(m.Summary, m.Description),
+				Deprecated:  m.Deprecated != nil,
+			})
+		}
+	}
+
+	events := DocsTable{Title: "Events", Typed: true}
+	for _, event := range decl.Events() {
+		events.Rows = append(events.Rows, DocsRow{
+			Name:        event.Name,
+			Type:        typeText(event.Type),
+			Description: docsDescription(event.Summary, event.Description),
+			Deprecated:  event.Deprecated != nil,
+		})
+	}
+
+	slots := DocsTable{Title: "Slots"}
+	for _, slot := range decl.Slots() {
+		name := slot.Name
+		if name == "" {
+			name = "(default)"
+		}
+		slots.Rows = append(slots.Rows, DocsRow{
+			Name:        name,
+			Description: docsDescription(slot.Summary, slot.Description),
+			Deprecated:  slot.Deprecated != nil,
+		})
+	}
+
+	parts := DocsTable{Title: "CSS Parts"}
+	for _, part := range decl.CssParts() {
+		parts.Rows = append(parts.Rows, DocsRow{
+			Name:        part.Name,
+			Description: docsDescription(part.Summary, part.Description),
+			Deprecated:  part.Deprecated != nil,
+		})
+	}
+
+	// Grouped custom properties get a table per group, so that large
+	// theming APIs stay navigable
+	var cssProperties []DocsTable
+	for _, group := range M.GroupCssProperties(decl.CssProperties()) {
+		table := DocsTable{Title: "CSS Custom Properties", Typed: true}
+		if group.Name != "" {
+			table.Title += ": " + group.Name
+		}
+		for _, prop := range group.Properties {
+			table.Rows = append(table.Rows, DocsRow{
+				Name:        prop.Name,
+				Type:        prop.Syntax,
+				Default:     prop.Default,
+				Description: docsDescription(prop.Summary, prop.Description),
+				Deprecated:  prop.Deprecated != nil,
+			})
+		}
+		cssProperties = append(cssProperties, table)
+	}
+
+	states := DocsTable{Title: "CSS States"}
+	for _, state := range decl.CssStates() {
+		states.Rows = append(states.Rows, DocsRow{
+			Name:        state.Name,
+			Description: docsDescription(state.Summary, state.Description),
+			Deprecated:  state.Deprecated != nil,
+		})
+	}
+
+	tables := []DocsTable{attributes, properties, methods, events, slots, parts}
+	tables = append(tables, cssProperties...)
+	tables = append(tables, states)
+	for _, table := range tables {
+		if len(table.Rows) > 0 {
+			table.ID = slugify(table.Title)
+			data.Tables = append(data.Tables, table)
+		}
+	}
+	return data
+}
+
+// NewDocsSearchEntry indexes an element by its names, summary, and the
+// names of its attributes, events, and slots
+func NewDocsSearchEntry(decl *M.CustomElementDeclaration) DocsSearchEntry {
+	entry := DocsSearchEntry{
+		TagName: decl.TagName,
+		Name:    decl.Name(),
+		Summary: decl.Summary,
+		URL:     DocsElementURL(decl.TagName),
+	}
+	for _, attr := range decl.Attributes() {
+		entry.Keywords = append(entry.Keywords, attr.Name)
+	}
+	for _, event := range decl.Events() {
+		entry.Keywords = append(entry.Keywords, event.Name)
+	}
+	for _, slot := range decl.Slots() {
+		if slot.Name != "" {
+			entry.Keywords = append(entry.Keywords, slot.Name)
+		}
+	}
+	return entry
+}
+
+// RenderDocsElement renders an element's docs page
+func RenderDocsElement(templates *TemplateRegistry, data *DocsElementData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.DocsElementTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderDocsIndex renders the docs site's index page
+func RenderDocsIndex(templates *TemplateRegistry, data DocsIndexData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.DocsIndexTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// docsFieldRow describes a public instance field, skipping the rest
+func docsFieldRow(field *M.ClassField) (DocsRow, bool) {
+	if field.Static || field.Privacy == M.Private || field.Privacy == M.Protected {
+		return DocsRow{}, false
+	}
+	return DocsRow{
+		Name:        field.Name,
+		Type:        typeText(field.Type),
+		Default:     field.Default,
+		Description: docsDescription(field.Summary, field.Description),
+		Deprecated:  field.Deprecated != nil,
+	}, true
+}
+
+// methodSignature formats a method's parameters and return type,
+// e.g. `(name: string, force?: boolean) => void`
+func methodSignature(method *M.ClassMethod) string {
+	params := make([]string, 0, len(method.Parameters))
+	for _, param := range method.Parameters {
+		name := param.Name
+		if param.Rest {
+			name = "..." + name
+		}
+		if param.Optional {
+			name += "?"
+		}
+		if t := typeText(param.Type); t != "" {
+			name += ": " + t
+		}
+		params = append(params, name)
+	}
+	returns := "void"
+	if method.Return != nil && method.Return.Type != nil {
+		returns = method.Return.Type.Text
+	}
+	return "(" + strings.Join(params, ", ") + ") => " + returns
+}
+
+// docsDescription prefers an API's description, falling back to its summary
+func docsDescription(summary, description string) string {
+	if description != "" {
+		return description
+	}
+	return summary
+}
+
+func typeText(t *M.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.Text
+}
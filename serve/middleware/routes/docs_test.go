@@ -0,0 +1,153 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package routes
+
+import (
+	"strings"
+	"testing"
+
+	M "bennypowers.dev/cem/manifest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func loadDocsElement(t *testing.T) *M.CustomElementDeclaration {
+	t.Helper()
+	pkg := loadAPIPanelManifest(t)
+	for _, module := range pkg.Modules {
+		for _, decl := range module.Declarations {
+			if ce, ok := decl.(*M.CustomElementDeclaration); ok && ce.TagName == "my-button" {
+				return ce
+			}
+		}
+	}
+	t.Fatal("Expected my-button in the manifest")
+	return nil
+}
+
+func TestNewDocsElementData(t *testing.T) {
+	demos := []DocsDemo{{Title: "Demo", URL: "/demo/", FrameURL: "/demo/chromeless.html"}}
+	data := NewDocsElementData(loadDocsElement(t), "my-elements", demos)
+
+	var titles []string
+	for _, table := range data.Tables {
+		titles = append(titles, table.Title)
+	}
+	if diff := cmp.Diff([]string{"Attributes", "Properties", "CSS Custom Properties"}, titles); diff != "" {
+		t.Errorf("Tables mismatch (-want +got):\n%s", diff)
+	}
+
+	expected := []DocsRow{
+		{Name: "disabled", Type: "boolean", Description: "Whether the button is disabled"},
+		{Name: "variant", Type: "'primary' | 'secondary' | 'danger'", Default: "primary", Description: "Button variant"},
+		{Name: "size", Type: "'sm' | 'md' | 'lg'", Default: "md", Description: "Button size"},
+	}
+	if diff := cmp.Diff(expected, data.Tables[0].Rows); diff != "" {
+		t.Errorf("Attribute rows mismatch (-want +got):\n%s", diff)
+	}
+	if data.Tables[2].ID != "css-custom-properties" {
+		t.Errorf("Expected a slugged table ID, got %q", data.Tables[2].ID)
+	}
+}
+
+func TestNewDocsSearchEntry(t *testing.T) {
+	entry := NewDocsSearchEntry(loadDocsElement(t))
+	expected := DocsSearchEntry{
+		TagName:  "my-button",
+		Name:     "MyButton",
+		URL:      "/docs/my-button/",
+		Keywords: []string{"disabled", "variant", "size"},
+	}
+	if diff := cmp.Diff(expected, entry); diff != "" {
+		t.Errorf("Search entry mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderDocsElement(t *testing.T) {
+	demos := []DocsDemo{{Title: "Demo", URL: "/demo/", FrameURL: "/demo/chromeless.html"}}
+	data := NewDocsElementData(loadDocsElement(t), "my-elements", demos)
+
+	rendered, err := RenderDocsElement(testTemplates(), data)
+	if err != nil {
+		t.Fatalf("RenderDocsElement failed: %v", err)
+	}
+	html := string(rendered)
+	for _, want := range []string{
+		`<code>&lt;my-button&gt;</code>`,
+		`<iframe src="/demo/chromeless.html" title="Demo" loading="lazy"></iframe>`,
+		`<h2 id="attributes">Attributes</h2>`,
+		`<td><code>--button-color</code></td>`,
+		`<link rel="stylesheet" href="/__cem/docs.css">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected %q in docs page, got:\n%s", want, html)
+		}
+	}
+}
+
+func TestNewDocsElementData_GroupedCssProperties(t *testing.T) {
+	prop := func(name, group string) M.CssCustomProperty {
+		return M.CssCustomProperty{FullyQualified: M.FullyQualified{Name: name}, Group: group}
+	}
+	decl := &M.CustomElementDeclaration{
+		CustomElement: M.CustomElement{
+			TagName: "my-card",
+			CssProperties: []M.CssCustomProperty{
+				prop("--surface-bg", "Surface"),
+				prop("--gap", ""),
+				prop("--surface-border", "Surface"),
+			},
+		},
+	}
+	data := NewDocsElementData(decl, "my-elements", nil)
+
+	var ids []string
+	for _, table := range data.Tables {
+		ids = append(ids, table.ID)
+	}
+	if diff := cmp.Diff([]string{"css-custom-properties", "css-custom-properties-surface"}, ids); diff != "" {
+		t.Errorf("Tables mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(data.Tables[1].Rows); got != 2 {
+		t.Errorf("Expected 2 properties in the Surface group, got %d", got)
+	}
+}
+
+func TestRenderDocsElement_PlatformFeatures(t *testing.T) {
+	decl := &M.CustomElementDeclaration{
+		CustomElement: M.CustomElement{
+			TagName: "my-menu",
+			Platform: &M.PlatformOptions{
+				Features: []string{M.FeaturePopover},
+			},
+		},
+	}
+	data := NewDocsElementData(decl, "my-elements", nil)
+
+	rendered, err := RenderDocsElement(testTemplates(), data)
+	if err != nil {
+		t.Fatalf("RenderDocsElement failed: %v", err)
+	}
+	html := string(rendered)
+	for _, want := range []string{
+		`<h2 id="browser-support">Browser Support</h2>`,
+		`<li><code>popover</code> — The popover API needs recent browsers`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected %q in docs page, got:\n%s", want, html)
+		}
+	}
+}
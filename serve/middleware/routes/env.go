@@ -0,0 +1,59 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package routes
+
+import (
+	"encoding/json"
+	"html/template"
+	"regexp"
+)
+
+// envPlaceholderPattern matches {{env.NAME}} placeholders in demo HTML
+var envPlaceholderPattern = regexp.MustCompile(`\{\{\s*env\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// substituteEnv replaces {{env.NAME}} placeholders in demo HTML with the
+// values of the configured demo environment variables. Values are inserted
+// as-is. Placeholders for undefined variables are left untouched, so that
+// they stand out in the rendered demo.
+func substituteEnv(demoHTML []byte, env map[string]string) []byte {
+	if len(env) == 0 {
+		return demoHTML
+	}
+	return envPlaceholderPattern.ReplaceAllFunc(demoHTML, func(match []byte) []byte {
+		name := envPlaceholderPattern.FindSubmatch(match)[1]
+		if value, ok := env[string(name)]; ok {
+			return []byte(value)
+		}
+		return match
+	})
+}
+
+// envScriptJSON renders the demo environment variables as a JSON object for
+// the window.__CEM_ENV__ script. It returns an empty string when there are
+// none, so that the script is omitted.
+func envScriptJSON(env map[string]string) (template.JS, error) {
+	if len(env) == 0 {
+		return "", nil
+	}
+	// json.Marshal escapes <, >, and &, so values can't close the script tag
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+	return template.JS(data), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package routes
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+// Inline: pure function, table-driven
+func TestSubstituteEnv(t *testing.T) {
+	env := map[string]string{
+		"API_URL": "https://mock.example.com",
+		"NEW_NAV": "true",
+		"EMPTY":   "",
+	}
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"attribute", `<my-data src="{{env.API_URL}}/items">`, `<my-data src="https://mock.example.com/items">`},
+		{"whitespace", `<my-nav {{ env.NEW_NAV }}>`, `<my-nav true>`},
+		{"several", `{{env.API_URL}} {{env.NEW_NAV}}`, `https://mock.example.com true`},
+		{"empty value", `<p>{{env.EMPTY}}</p>`, `<p></p>`},
+		{"undefined", `<p>{{env.MISSING}}</p>`, `<p>{{env.MISSING}}</p>`},
+		{"not env", `<p>{{ name }}</p>`, `<p>{{ name }}</p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(substituteEnv([]byte(tt.html), env)); got != tt.want {
+				t.Errorf("substituteEnv(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+
+	if got := string(substituteEnv([]byte(`{{env.API_URL}}`), nil)); got != `{{env.API_URL}}` {
+		t.Errorf("substituteEnv with no env = %q, want input unchanged", got)
+	}
+}
+
+func TestEnvScriptJSON(t *testing.T) {
+	got, err := envScriptJSON(map[string]string{"B": "</script><script>alert(1)", "A": "1"})
+	if err != nil {
+		t.Fatalf("envScriptJSON failed: %v", err)
+	}
+	want := template.JS(`{"A":"1","B":"\u003c/script\u003e\u003cscript\u003ealert(1)"}`)
+	if got != want {
+		t.Errorf("envScriptJSON = %s, want %s", got, want)
+	}
+
+	if got, _ := envScriptJSON(nil); got != "" {
+		t.Errorf("envScriptJSON(nil) = %q, want empty", got)
+	}
+}
+
+func TestRenderDemo_Env(t *testing.T) {
+	const script = `<script>window.__CEM_ENV__ = Object.freeze({"API_URL":"https://mock.example.com"});</script>`
+	for _, mode := range []string{"light", "chromeless"} {
+		t.Run(mode, func(t *testing.T) {
+			rendered, err := renderDemo(testTemplates(), nil, ChromeData{
+				TagName:       "my-element",
+				DemoTitle:     "Env",
+				DemoHTML:      template.HTML(`<my-element></my-element>`),
+				Env:           template.JS(`{"API_URL":"https://mock.example.com"}`),
+				RenderingMode: mode,
+			})
+			if err != nil {
+				t.Fatalf("Failed to render demo: %v", err)
+			}
+			if !strings.Contains(rendered, script) {
+				t.Errorf("%s demo should define window.__CEM_ENV__", mode)
+			}
+		})
+	}
+
+	rendered, err := renderDemo(testTemplates(), nil, ChromeData{
+		TagName:  "my-element",
+		DemoHTML: template.HTML(`<my-element></my-element>`),
+	})
+	if err != nil {
+		t.Fatalf("Failed to render demo: %v", err)
+	}
+	if strings.Contains(rendered, "__CEM_ENV__") {
+		t.Error("demo without env should not define window.__CEM_ENV__")
+	}
+}
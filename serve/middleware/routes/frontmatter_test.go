@@ -55,6 +55,7 @@ func (c *frontmatterTestContext) FileSystem() platform.FileSystem
 func (c *frontmatterTestContext) PackageJSON() (*middleware.PackageJSON, error)      { return nil, nil }
 func (c *frontmatterTestContext) BroadcastError(title, message, file string) error   { return nil }
 func (c *frontmatterTestContext) DemoRenderingMode() string                         { return c.renderingMode }
+func (c *frontmatterTestContext) DemoEnv() map[string]string                        { return nil }
 func (c *frontmatterTestContext) URLRewrites() []config.URLRewrite                  { return nil }
 func (c *frontmatterTestContext) PathResolver() middleware.PathResolver              { return nil }
 func (c *frontmatterTestContext) HealthResult() (*health.HealthResult, error)        { return nil, nil }
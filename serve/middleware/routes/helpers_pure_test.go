@@ -124,6 +124,24 @@ func TestBuildPackageListingsFromRoutes(t *testing.T) {
 	assert.Len(t, pkgs[0].Elements, 2)
 }
 
+func TestGroupElementsByCategory(t *testing.T) {
+	input := ElementListing{TagName: "my-input", Categories: []string{"form"}}
+	alert := ElementListing{TagName: "my-alert", Categories: []string{"form", "feedback"}}
+	grid := ElementListing{TagName: "my-grid"}
+
+	groups := groupElementsByCategory([]ElementListing{alert, grid, input})
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	assert.Equal(t, []string{"feedback", "form", "Other"}, names)
+	assert.Len(t, groups[0].Elements, 1)
+	assert.Len(t, groups[1].Elements, 2)
+	assert.Equal(t, "my-grid", groups[2].Elements[0].TagName)
+
+	assert.Nil(t, groupElementsByCategory([]ElementListing{grid}))
+}
+
 func TestResolveViaRoutingTable(t *testing.T) {
 	demoRoutes := map[string]*middleware.DemoRouteEntry{
 		"/elements/button/demo/": {
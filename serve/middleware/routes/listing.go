@@ -36,6 +36,7 @@ type ElementListing struct {
 	TagName     string
 	Summary     template.HTML
 	Description template.HTML
+	Categories  []string
 	Demos       []DemoListing
 }
 
@@ -119,8 +120,9 @@ func RenderElementListing(templates *TemplateRegistry, ctx middleware.DevServerC
 	// Wrap in a single package for consistent template structure
 	packages := []PackageNavigation{
 		{
-			Name:     title,
-			Elements: elements,
+			Name:       title,
+			Elements:   elements,
+			Categories: groupElementsByCategory(elements),
 		},
 	}
 
@@ -246,6 +248,7 @@ func extractElementListings(pkg *M.Package, packageName, demoURLPrefix string) (
 				TagName:     tagName,
 				Summary:     template.HTML(summaryHTML),
 				Description: template.HTML(descriptionHTML),
+				Categories:  renderableDemo.CustomElementDeclaration.Categories,
 				Demos:       make([]DemoListing, 0),
 			}
 			elementMap[tagName] = listing
@@ -301,10 +304,55 @@ func slugify(s string) string {
 
 // PackageNavigation represents a package with its elements for navigation
 type PackageNavigation struct {
+	Name       string
+	Elements   []ElementListing
+	Categories []CategoryListing // Elements grouped by category, empty when no element has one
+}
+
+// CategoryListing represents the elements in one design system category
+type CategoryListing struct {
 	Name     string
 	Elements []ElementListing
 }
 
+// uncategorizedName heads the group of elements without a category
+const uncategorizedName = "Other"
+
+// groupElementsByCategory groups elements by their categories, in category
+// name order, listing an element under each of its categories. Elements
+// without a category come last. Returns nil when no element has a category.
+func groupElementsByCategory(elements []ElementListing) []CategoryListing {
+	byCategory := make(map[string][]ElementListing)
+	var uncategorized []ElementListing
+	for _, element := range elements {
+		if len(element.Categories) == 0 {
+			uncategorized = append(uncategorized, element)
+			continue
+		}
+		for _, category := range element.Categories {
+			byCategory[category] = append(byCategory[category], element)
+		}
+	}
+	if len(byCategory) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(byCategory))
+	for name := range byCategory {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	categories := make([]CategoryListing, 0, len(names)+1)
+	for _, name := range names {
+		categories = append(categories, CategoryListing{Name: name, Elements: byCategory[name]})
+	}
+	if len(uncategorized) > 0 {
+		categories = append(categories, CategoryListing{Name: uncategorizedName, Elements: uncategorized})
+	}
+	return categories
+}
+
 // BuildSinglePackageNavigation builds navigation HTML for single-package mode
 // currentPath is used to expand the nav item containing the current demo
 func BuildSinglePackageNavigation(templates *TemplateRegistry, manifestBytes []byte, packageName, currentPath, demoURLPrefix string) (template.HTML, string, error) {
@@ -372,6 +420,7 @@ func buildPackageListingsFromRoutes(routes map[string]*DemoRouteEntry) ([]Packag
 
 			var demoListings []DemoListing
 			var summary, description template.HTML
+			var categories []string
 			for _, route := range tagRoutes {
 				// Get summary and description from first route's declaration
 				if route.Declaration != nil && summary == "" {
@@ -385,6 +434,7 @@ func buildPackageListingsFromRoutes(routes map[string]*DemoRouteEntry) ([]Packag
 					}
 					summary = template.HTML(summaryHTML)
 					description = template.HTML(descriptionHTML)
+					categories = route.Declaration.Categories
 				}
 
 				demoName := prettifyRoute(route.LocalRoute)
@@ -401,6 +451,7 @@ func buildPackageListingsFromRoutes(routes map[string]*DemoRouteEntry) ([]Packag
 				TagName:     tagName,
 				Summary:     summary,
 				Description: description,
+				Categories:  categories,
 				Demos:       demoListings,
 			})
 		}
@@ -411,8 +462,9 @@ func buildPackageListingsFromRoutes(routes map[string]*DemoRouteEntry) ([]Packag
 		})
 
 		packageNav = append(packageNav, PackageNavigation{
-			Name:     pkgName,
-			Elements: elementListings,
+			Name:       pkgName,
+			Elements:   elementListings,
+			Categories: groupElementsByCategory(elementListings),
 		})
 	}
 
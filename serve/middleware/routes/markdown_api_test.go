@@ -55,6 +55,7 @@ func (m *mockContext) FileSystem() platform.FileSystem                   { retur
 func (m *mockContext) PackageJSON() (*middleware.PackageJSON, error)     { return nil, nil }
 func (m *mockContext) BroadcastError(title, message, file string) error  { return nil }
 func (m *mockContext) DemoRenderingMode() string                         { return "light" }
+func (m *mockContext) DemoEnv() map[string]string                        { return nil }
 func (m *mockContext) URLRewrites() []config.URLRewrite                  { return nil }
 func (m *mockContext) PathResolver() middleware.PathResolver             { return nil }
 func (m *mockContext) HealthResult() (*health.HealthResult, error)       { return nil, nil }
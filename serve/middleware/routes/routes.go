@@ -35,6 +35,7 @@ import (
 	"bennypowers.dev/cem/serve/logger"
 	"bennypowers.dev/cem/serve/middleware"
 	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
+	"bennypowers.dev/cem/serve/middleware/tracing"
 )
 
 // notFoundDetector wraps http.ResponseWriter to detect and intercept 404 status codes
@@ -77,6 +78,14 @@ type Config struct {
 	// LogsFunc returns the current logs (if logger supports it)
 	LogsFunc func() []logger.LogEntry
 
+	// TraceFunc returns the latest request trace for a path, or for any
+	// path when it is empty
+	TraceFunc func(path string) *tracing.Trace
+
+	// FileChangesFunc handles a batch of changes to files, relative to the
+	// watch directory, which a script posts
+	FileChangesFunc func(eventType string, files []string) error
+
 	// WebSocketHandler handles WebSocket upgrade requests for live reload
 	WebSocketHandler http.HandlerFunc
 
@@ -109,12 +118,24 @@ func New(config Config) middleware.Middleware {
 			case r.URL.Path == "/__cem/api/markdown":
 				serveMarkdownAPI(w, r, config)
 				return
+			case r.URL.Path == "/__cem/api/changes":
+				serveFileChangesAPI(w, r, config)
+				return
 			case r.URL.Path == "/__cem/api/health":
 				serveHealthAPI(w, r, config)
 				return
 			case r.URL.Path == "/__cem/debug":
 				serveDebugInfo(w, r, config)
 				return
+			case r.URL.Path == "/__cem/trace/latest":
+				serveLatestTrace(w, r, config)
+				return
+			case r.URL.Path == "/__cem/sandbox":
+				serveSandbox(w, r, config)
+				return
+			case r.URL.Path == "/__cem/sandbox/render":
+				serveSandboxRender(w, r, config)
+				return
 			case strings.HasPrefix(r.URL.Path, "/__cem/"):
 				serveInternalModules(w, r, config)
 				return
@@ -304,6 +325,36 @@ func serveLogs(
 	}
 }
 
+// serveLatestTrace serves the latest request trace as an indented tree of
+// middleware stages, or as JSON with ?format=json. ?path= selects the latest
+// trace of a request for that path, e.g. a demo page.
+func serveLatestTrace(
+	w http.ResponseWriter,
+	r *http.Request,
+	config Config,
+) {
+	var trace *tracing.Trace
+	if config.TraceFunc != nil {
+		trace = config.TraceFunc(r.URL.Query().Get("path"))
+	}
+	if trace == nil {
+		http.Error(w, "No requests have been traced yet", http.StatusNotFound)
+		return
+	}
+
+	var err error
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(trace)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		err = trace.WriteTree(w)
+	}
+	if err != nil {
+		config.Context.Logger().Error("Failed to write trace response: %v", err)
+	}
+}
+
 // serveDebugInfo serves debug information for the debug overlay
 func serveDebugInfo(
 	w http.ResponseWriter,
@@ -396,6 +447,47 @@ func serveHealthAPI(
 	}
 }
 
+// fileChangesRequest is the body of a POST to /__cem/api/changes
+type fileChangesRequest struct {
+	Files []string `json:"files"`
+	Type  string   `json:"type,omitempty"`
+}
+
+// serveFileChangesAPI handles file changes which a script reports, e.g.
+// after a codegen step, as the file watcher would, responding once caches
+// are invalidated and clients notified. Requiring a JSON content type
+// keeps other origins' pages from posting without a CORS preflight.
+func serveFileChangesAPI(
+	w http.ResponseWriter,
+	r *http.Request,
+	config Config,
+) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if config.FileChangesFunc == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if mediaType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";"); strings.TrimSpace(mediaType) != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var req fileChangesRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := config.FileChangesFunc(req.Type, req.Files); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // filterHealthByComponent returns a HealthResult containing only the specified component
 func filterHealthByComponent(result *health.HealthResult, component string) *health.HealthResult {
 	var modules []health.ModuleReport
@@ -653,6 +745,12 @@ func renderDemoFromRoute(entry *DemoRouteEntry, queryParams map[string]string, c
 	}
 
 	demoHTML = textutil.StripFrontmatter(demoHTML)
+	demoEnv := config.Context.DemoEnv()
+	demoHTML = substituteEnv(demoHTML, demoEnv)
+	envJSON, err := envScriptJSON(demoEnv)
+	if err != nil {
+		return "", fmt.Errorf("encoding demo env: %w", err)
+	}
 
 	// Get import map as JSON (pre-computed during server initialization)
 	var importMapJSON string
@@ -673,6 +771,7 @@ func renderDemoFromRoute(entry *DemoRouteEntry, queryParams map[string]string, c
 
 	// Generate knobs (default to all categories if not specified)
 	var knobsHTML template.HTML
+	var apiPanelHTML template.HTML
 	enabledKnobs := queryParams["knobs"]
 	if enabledKnobs == "" {
 		// Default to all knob categories
@@ -756,6 +855,11 @@ func renderDemoFromRoute(entry *DemoRouteEntry, queryParams map[string]string, c
 				)
 			}
 		}
+
+		apiPanelHTML, err = RenderAPIPanelHTML(config.Templates, parsedManifest, entry.TagName, demoHTML)
+		if err != nil {
+			config.Context.Logger().Warning("Failed to render API panel HTML: %v", err)
+		}
 	}
 
 	// Extract state from request cookie for SSR
@@ -803,6 +907,7 @@ func renderDemoFromRoute(entry *DemoRouteEntry, queryParams map[string]string, c
 		DemoHTML:       template.HTML(demoHTML),
 		Description:    template.HTML(entry.Demo.Description),
 		ImportMap:      template.HTML(importMapJSON),
+		Env:            envJSON,
 		EnabledKnobs:   enabledKnobs,
 		KnobsHTML:      knobsHTML,
 		RenderingMode:  renderingMode,
@@ -810,6 +915,7 @@ func renderDemoFromRoute(entry *DemoRouteEntry, queryParams map[string]string, c
 		CanonicalURL:   entry.Demo.URL, // Link to canonical demo
 		PackageName:    packageName,
 		NavigationHTML: navigationHTML,
+		APIPanelHTML:   apiPanelHTML,
 		ManifestJSON:   template.JS(manifestBytes),
 		Manifest:       parsedManifest,
 		Packages:       packages, // Workspace packages with modules (for package-level tree)
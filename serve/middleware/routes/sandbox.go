@@ -0,0 +1,232 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	M "bennypowers.dev/cem/manifest"
+	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
+)
+
+// maxSandboxSize is the maximum allowed size for sandbox source (1MB)
+const maxSandboxSize = 1 * 1024 * 1024
+
+// SandboxData holds the template data for the sandbox page
+type SandboxData struct {
+	TagName   string
+	Snippet   string // Starter markup for the editor
+	RenderURL string // Endpoint which renders the editor's markup
+}
+
+// serveSandbox handles GET /__cem/sandbox?element=<tag>, a playground with
+// an editor pre-filled with a starter snippet for the element, beside a
+// preview which re-renders as the snippet is edited. It is dev-only.
+func serveSandbox(w http.ResponseWriter, r *http.Request, config Config) {
+	if config.Context.IsStaticBuild() {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tagName := r.URL.Query().Get("element")
+	decl, _, ok := findSandboxElement(config, tagName)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown element: %q", tagName), http.StatusNotFound)
+		return
+	}
+
+	page, err := executeTemplate(config.Templates.SandboxTemplate, SandboxData{
+		TagName:   tagName,
+		Snippet:   sandboxSnippet(decl),
+		RenderURL: "/__cem/sandbox/render?element=" + url.QueryEscape(tagName),
+	})
+	if err != nil {
+		config.Context.Logger().Error("Failed to render sandbox: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write([]byte(page)); err != nil {
+		config.Context.Logger().Error("Failed to write sandbox response: %v", err)
+	}
+}
+
+// serveSandboxRender handles POST /__cem/sandbox/render?element=<tag>,
+// rendering the markup in the request body as a chromeless demo which loads
+// the element's module, so that it goes through the same import map and
+// transforms as any other demo.
+func serveSandboxRender(w http.ResponseWriter, r *http.Request, config Config) {
+	if config.Context.IsStaticBuild() {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tagName := r.URL.Query().Get("element")
+	_, moduleURL, ok := findSandboxElement(config, tagName)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown element: %q", tagName), http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSandboxSize)
+	source, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	demoEnv := config.Context.DemoEnv()
+	envJSON, err := envScriptJSON(demoEnv)
+	if err != nil {
+		config.Context.Logger().Error("Failed to encode demo env: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var importMapJSON string
+	if im := config.Context.ImportMap(); im != nil {
+		if importMap, ok := im.(*importmappkg.ImportMap); ok {
+			importMapJSON = importMap.ToJSON()
+		}
+	}
+
+	demoHTML := fmt.Sprintf("<script type=\"module\" src=\"%s\"></script>\n%s",
+		html.EscapeString(moduleURL), substituteEnv(source, demoEnv))
+
+	page, err := renderDemo(config.Templates, config.Context, ChromeData{
+		TagName:       tagName,
+		DemoTitle:     fmt.Sprintf("<%s> Sandbox", tagName),
+		DemoHTML:      template.HTML(demoHTML),
+		ImportMap:     template.HTML(importMapJSON),
+		Env:           envJSON,
+		RenderingMode: "chromeless",
+	})
+	if err != nil {
+		config.Context.Logger().Error("Failed to render sandbox preview: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write([]byte(page)); err != nil {
+		config.Context.Logger().Error("Failed to write sandbox preview: %v", err)
+	}
+}
+
+// findSandboxElement looks up an element in the manifest, or in workspace
+// mode, in each package's manifest, returning its declaration and the URL of
+// the module which defines it
+func findSandboxElement(config Config, tagName string) (*M.CustomElementDeclaration, string, bool) {
+	if tagName == "" {
+		return nil, "", false
+	}
+
+	find := func(manifestBytes []byte, base string) (*M.CustomElementDeclaration, string, bool) {
+		var pkg M.Package
+		if len(manifestBytes) == 0 || json.Unmarshal(manifestBytes, &pkg) != nil {
+			return nil, "", false
+		}
+		decl, _, mod, err := pkg.FindCustomElementContext(tagName)
+		if err != nil {
+			return nil, "", false
+		}
+		return decl, path.Join("/", base, strings.TrimPrefix(mod.Path, "./")), true
+	}
+
+	if config.Context.IsWorkspace() {
+		for _, pkg := range config.Context.WorkspacePackages() {
+			base, err := filepath.Rel(config.Context.WatchDir(), pkg.Path)
+			if err != nil || strings.HasPrefix(base, "..") {
+				base = ""
+			}
+			if decl, moduleURL, ok := find(pkg.Manifest, filepath.ToSlash(base)); ok {
+				return decl, moduleURL, true
+			}
+		}
+		return nil, "", false
+	}
+
+	manifestBytes, err := config.Context.Manifest()
+	if err != nil {
+		return nil, "", false
+	}
+	return find(manifestBytes, "")
+}
+
+// sandboxSnippet writes starter markup for an element: its enum attributes,
+// set to their defaults or first values, and placeholder content for each
+// of its slots
+func sandboxSnippet(decl *M.CustomElementDeclaration) string {
+	var b strings.Builder
+	b.WriteString("<" + decl.TagName)
+	for _, attr := range decl.Attributes() {
+		if !attr.IsEnum() || attr.IsDeprecated() {
+			continue
+		}
+		// Only string literals are attribute values, not e.g. `undefined`
+		value, _ := unquoteLiteral(attr.Default)
+		if value == "" {
+			for _, member := range attr.EnumValues() {
+				if unquoted, ok := unquoteLiteral(member); ok && unquoted != "" {
+					value = unquoted
+					break
+				}
+			}
+		}
+		if value != "" {
+			fmt.Fprintf(&b, " %s=%q", attr.Name, value)
+		}
+	}
+	b.WriteString(">")
+
+	hasContent := false
+	for _, slot := range decl.Slots() {
+		if slot.IsDeprecated() || slot.Pattern {
+			continue
+		}
+		if slot.Name == "" {
+			b.WriteString("\n  " + decl.TagName)
+		} else {
+			fmt.Fprintf(&b, "\n  <span slot=%q>%s</span>", slot.Name, slot.Name)
+		}
+		hasContent = true
+	}
+	if hasContent {
+		b.WriteString("\n")
+	}
+	b.WriteString("</" + decl.TagName + ">\n")
+	return b.String()
+}
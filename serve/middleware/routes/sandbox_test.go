@@ -0,0 +1,124 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sandboxManifest = `{
+	"schemaVersion": "1.0.0",
+	"modules": [
+		{
+			"kind": "javascript-module",
+			"path": "elements/my-button/my-button.js",
+			"declarations": [
+				{
+					"kind": "class",
+					"name": "MyButton",
+					"tagName": "my-button",
+					"customElement": true,
+					"attributes": [
+						{ "name": "variant", "type": { "text": "'primary' | 'secondary'" }, "default": "'secondary'" },
+						{ "name": "size", "type": { "text": "'small' | 'large' | undefined" } },
+						{ "name": "disabled", "type": { "text": "boolean" } }
+					],
+					"slots": [
+						{ "name": "", "description": "Label" },
+						{ "name": "icon", "description": "Icon" }
+					]
+				}
+			]
+		}
+	]
+}`
+
+func TestServeSandbox(t *testing.T) {
+	config := Config{Context: &mockContext{manifestBytes: []byte(sandboxManifest)}}
+	config.Templates = NewTemplateRegistry(config.Context)
+
+	req := httptest.NewRequest(http.MethodGet, "/__cem/sandbox?element=my-button", nil)
+	rec := httptest.NewRecorder()
+	serveSandbox(rec, req, config)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, expected := range []string{
+		"<textarea",
+		`&lt;my-button variant=&#34;secondary&#34; size=&#34;small&#34;&gt;`,
+		`&lt;span slot=&#34;icon&#34;&gt;icon&lt;/span&gt;`,
+		`"/__cem/sandbox/render?element=my-button"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %q in sandbox page, got:\n%s", expected, body)
+		}
+	}
+}
+
+func TestServeSandbox_UnknownElement(t *testing.T) {
+	config := Config{Context: &mockContext{manifestBytes: []byte(sandboxManifest)}}
+	config.Templates = NewTemplateRegistry(config.Context)
+
+	for _, target := range []string{"/__cem/sandbox?element=no-such-element", "/__cem/sandbox"} {
+		rec := httptest.NewRecorder()
+		serveSandbox(rec, httptest.NewRequest(http.MethodGet, target, nil), config)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status 404, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestServeSandboxRender(t *testing.T) {
+	config := Config{Context: &mockContext{manifestBytes: []byte(sandboxManifest)}}
+	config.Templates = NewTemplateRegistry(config.Context)
+
+	source := `<my-button variant="primary">Edited</my-button>`
+	req := httptest.NewRequest(http.MethodPost, "/__cem/sandbox/render?element=my-button", strings.NewReader(source))
+	rec := httptest.NewRecorder()
+	serveSandboxRender(rec, req, config)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, expected := range []string{
+		`<script type="module" src="/elements/my-button/my-button.js"></script>`,
+		source,
+		"/__cem/websocket-client.js",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %q in rendered sandbox, got:\n%s", expected, body)
+		}
+	}
+}
+
+func TestServeSandboxRender_RequiresPost(t *testing.T) {
+	config := Config{Context: &mockContext{manifestBytes: []byte(sandboxManifest)}}
+	config.Templates = NewTemplateRegistry(config.Context)
+
+	rec := httptest.NewRecorder()
+	serveSandboxRender(rec, httptest.NewRequest(http.MethodGet, "/__cem/sandbox/render?element=my-button", nil), config)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+}
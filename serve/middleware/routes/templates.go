@@ -38,6 +38,9 @@ var notFoundTemplate string
 //go:embed templates/knobs.html
 var knobsTemplate string
 
+//go:embed templates/api-panel.html
+var apiPanelTemplate string
+
 //go:embed templates/demo-chrome.html
 var demoChromeTemplate string
 
@@ -47,6 +50,15 @@ var demoChromelessTemplate string
 //go:embed templates/template-error.html
 var templateErrorTemplate string
 
+//go:embed templates/sandbox.html
+var sandboxTemplate string
+
+//go:embed templates/docs-element.html
+var docsElementTemplate string
+
+//go:embed templates/docs-index.html
+var docsIndexTemplate string
+
 //go:embed templates/**
 var TemplatesFS embed.FS
 
@@ -59,9 +71,13 @@ type TemplateRegistry struct {
 	NavigationTemplate       *template.Template
 	NotFoundTemplate         *template.Template
 	KnobsTemplate            *template.Template
+	APIPanelTemplate         *template.Template
 	DemoChromeTemplate       *template.Template
 	DemoChromelessTemplate   *template.Template
 	TemplateErrorTemplate    *template.Template
+	SandboxTemplate          *template.Template
+	DocsElementTemplate      *template.Template
+	DocsIndexTemplate        *template.Template
 	context                  middleware.DevServerContext
 }
 
@@ -79,9 +95,13 @@ func NewTemplateRegistry(ctx middleware.DevServerContext) *TemplateRegistry {
 	registry.NavigationTemplate = template.Must(template.New("navigation").Funcs(funcs).Parse(navigationTemplate))
 	registry.NotFoundTemplate = template.Must(template.New("404").Funcs(funcs).Parse(notFoundTemplate))
 	registry.KnobsTemplate = template.Must(template.New("knobs").Funcs(funcs).Parse(knobsTemplate))
+	registry.APIPanelTemplate = template.Must(template.New("api-panel").Funcs(funcs).Parse(apiPanelTemplate))
 	registry.DemoChromeTemplate = template.Must(template.New("demo-chrome").Funcs(funcs).Parse(demoChromeTemplate))
 	registry.DemoChromelessTemplate = template.Must(template.New("demo-chromeless").Parse(demoChromelessTemplate))
 	registry.TemplateErrorTemplate = template.Must(template.New("template-error").Funcs(funcs).Parse(templateErrorTemplate))
+	registry.SandboxTemplate = template.Must(template.New("sandbox").Parse(sandboxTemplate))
+	registry.DocsElementTemplate = template.Must(template.New("docs-element").Funcs(funcs).Parse(docsElementTemplate))
+	registry.DocsIndexTemplate = template.Must(template.New("docs-index").Parse(docsIndexTemplate))
 
 	return registry
 }
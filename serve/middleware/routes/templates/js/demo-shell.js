@@ -0,0 +1,143 @@
+/**
+ * Enhances the demo shell: arrow-key navigation through the sidebar's
+ * element routes, and live attribute values in the element API panel.
+ */
+
+/**
+ * Index of the item to move to from `current` when `key` is pressed, or -1
+ * if the key doesn't move focus.
+ * @param {number} current
+ * @param {number} length
+ * @param {string} key
+ */
+export function nextIndex(current, length, key) {
+  if (length === 0) return -1;
+  switch (key) {
+    case 'ArrowDown': return (current + 1) % length;
+    case 'ArrowUp': return (current - 1 + length) % length;
+    case 'Home': return 0;
+    case 'End': return length - 1;
+    default: return -1;
+  }
+}
+
+/**
+ * Text the API panel shows for an attribute value.
+ * @param {string | null} value
+ */
+export function formatAttributeValue(value) {
+  return value === null ? 'not set' : `"${value}"`;
+}
+
+/** @param {Element} link */
+function focusTarget(link) {
+  return link.shadowRoot?.getElementById('link') ?? link;
+}
+
+/** Links the user can reach, i.e. those not in a collapsed group */
+function visibleLinks(navigation) {
+  return [...navigation.querySelectorAll('cem-pf-v6-nav-link')]
+    .filter(link => !link.closest('[hidden]'));
+}
+
+/** @param {Element} navigation */
+export function initSidebarKeyboard(navigation) {
+  navigation.addEventListener('keydown', event => {
+    const link = event.composedPath()
+      .find(el => el instanceof Element && el.localName === 'cem-pf-v6-nav-link');
+    if (!link || event.altKey || event.ctrlKey || event.metaKey) return;
+
+    const expandable = link.hasAttribute('expandable');
+    const expanded = link.getAttribute('aria-expanded') === 'true';
+    switch (event.key) {
+      case 'ArrowRight':
+        if (expandable && !expanded) {
+          event.preventDefault();
+          focusTarget(link).click();
+        }
+        return;
+      case 'ArrowLeft': {
+        event.preventDefault();
+        if (expandable && expanded) {
+          focusTarget(link).click();
+          return;
+        }
+        // Move to the link which expands this link's group
+        const parent = link.closest('cem-pf-v6-nav-group')
+          ?.closest('cem-pf-v6-nav-item')
+          ?.querySelector(':scope > cem-pf-v6-nav-link[expandable]');
+        if (parent) focusTarget(parent).focus();
+        return;
+      }
+    }
+
+    const links = visibleLinks(navigation);
+    const index = nextIndex(links.indexOf(link), links.length, event.key);
+    if (index < 0) return;
+    event.preventDefault();
+    focusTarget(links[index]).focus();
+  });
+}
+
+/**
+ * The first instance of the demoed element, in whichever way the demo is
+ * rendered: light DOM, shadow DOM, or iframe.
+ * @param {string} tagName
+ */
+function findDemoedElement(tagName) {
+  const demo = document.getElementById('demo');
+  if (!demo) return null;
+  const roots = [demo, demo.shadowRoot, demo.shadowRoot?.querySelector('iframe')?.contentDocument];
+  for (const root of roots) {
+    const element = root?.querySelector(tagName);
+    if (element) return element;
+  }
+  return null;
+}
+
+/** @param {Element} panel */
+export function initAPIPanel(panel, element = findDemoedElement(panel.dataset.tagName)) {
+  if (!element) return null;
+  const update = name => {
+    const dd = panel.querySelector(`[data-attribute="${CSS.escape(name)}"] .cem-api-value`);
+    if (!dd) return;
+    const value = element.getAttribute(name);
+    dd.toggleAttribute('data-absent', value === null);
+    if (value === null) {
+      dd.textContent = formatAttributeValue(value);
+    } else {
+      const code = document.createElement('code');
+      code.textContent = formatAttributeValue(value);
+      dd.replaceChildren(code);
+    }
+  };
+  for (const row of panel.querySelectorAll('[data-attribute]')) {
+    update(row.dataset.attribute);
+  }
+  const observer = new MutationObserver(records => {
+    for (const { attributeName } of records) update(attributeName);
+  });
+  observer.observe(element, { attributes: true });
+  return observer;
+}
+
+function init() {
+  const navigation = document.querySelector('cem-pf-v6-navigation');
+  if (navigation) initSidebarKeyboard(navigation);
+  const panel = document.getElementById('cem-api-panel');
+  if (panel) {
+    // Iframe demos load after the shell
+    if (!initAPIPanel(panel)) {
+      document.getElementById('demo')?.addEventListener('load', () => initAPIPanel(panel), {
+        capture: true,
+        once: true,
+      });
+    }
+  }
+}
+
+if (document.readyState === 'loading') {
+  document.addEventListener('DOMContentLoaded', init, { once: true });
+} else {
+  init();
+}
@@ -0,0 +1,71 @@
+import { expect, fixture, html, nextFrame } from '@open-wc/testing';
+import { nextIndex, formatAttributeValue, initAPIPanel } from './demo-shell.js';
+
+describe('demo-shell', () => {
+  describe('nextIndex', () => {
+    it('moves down and wraps to the first item', () => {
+      expect(nextIndex(0, 3, 'ArrowDown')).to.equal(1);
+      expect(nextIndex(2, 3, 'ArrowDown')).to.equal(0);
+    });
+
+    it('moves up and wraps to the last item', () => {
+      expect(nextIndex(1, 3, 'ArrowUp')).to.equal(0);
+      expect(nextIndex(0, 3, 'ArrowUp')).to.equal(2);
+    });
+
+    it('jumps to the first and last items', () => {
+      expect(nextIndex(1, 3, 'Home')).to.equal(0);
+      expect(nextIndex(1, 3, 'End')).to.equal(2);
+    });
+
+    it('ignores other keys and empty lists', () => {
+      expect(nextIndex(1, 3, 'Enter')).to.equal(-1);
+      expect(nextIndex(0, 0, 'ArrowDown')).to.equal(-1);
+    });
+  });
+
+  describe('formatAttributeValue', () => {
+    it('quotes present values', () => {
+      expect(formatAttributeValue('primary')).to.equal('"primary"');
+      expect(formatAttributeValue('')).to.equal('""');
+    });
+
+    it('describes absent values', () => {
+      expect(formatAttributeValue(null)).to.equal('not set');
+    });
+  });
+
+  describe('initAPIPanel', () => {
+    it('reflects attribute changes', async () => {
+      const panel = await fixture(html`
+        <section data-tag-name="my-button">
+          <dl>
+            <div data-attribute="variant">
+              <dt><code>variant</code></dt>
+              <dd class="cem-api-value" data-absent>not set</dd>
+            </div>
+          </dl>
+        </section>
+      `);
+      const element = document.createElement('my-button');
+      const observer = initAPIPanel(panel, element);
+      const dd = panel.querySelector('.cem-api-value');
+
+      element.setAttribute('variant', 'primary');
+      await nextFrame();
+      expect(dd.hasAttribute('data-absent')).to.be.false;
+      expect(dd.textContent).to.equal('"primary"');
+
+      element.removeAttribute('variant');
+      await nextFrame();
+      expect(dd.hasAttribute('data-absent')).to.be.true;
+      expect(dd.textContent).to.equal('not set');
+      observer.disconnect();
+    });
+
+    it('does nothing without a demoed element', async () => {
+      const panel = await fixture(html`<section data-tag-name="my-button"></section>`);
+      expect(initAPIPanel(panel, null)).to.be.null;
+    });
+  });
+});
@@ -0,0 +1,25 @@
+// Filters the docs index by the search index's names, summaries, and keywords
+const input = document.getElementById('cem-docs-search');
+const list = document.getElementById('cem-docs-elements');
+const empty = document.getElementById('cem-docs-no-results');
+
+const response = await fetch('/docs/search-index.json');
+const index = response.ok ? await response.json() : [];
+const haystacks = new Map(index.map(entry => [
+  entry.tagName,
+  [entry.tagName, entry.name, entry.summary, ...(entry.keywords ?? [])]
+    .filter(Boolean)
+    .join(' ')
+    .toLowerCase(),
+]));
+
+input?.addEventListener('input', () => {
+  const terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+  let visible = 0;
+  for (const item of list.querySelectorAll('li[data-tag-name]')) {
+    const haystack = haystacks.get(item.dataset.tagName) ?? item.dataset.tagName;
+    item.hidden = !terms.every(term => haystack.includes(term));
+    if (!item.hidden) visible++;
+  }
+  empty.hidden = visible > 0;
+});
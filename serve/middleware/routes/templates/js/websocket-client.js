@@ -6,6 +6,55 @@
  * Uses callbacks for UI updates - DOM management is handled by the caller
  * Includes automatic reconnection logic with progressive backoff
  */
+/** Yields the document and every open shadow root in it */
+function* allRoots(root = document) {
+  yield root;
+  for (const element of root.querySelectorAll('*')) {
+    if (element.shadowRoot) yield* allRoots(element.shadowRoot);
+  }
+}
+
+/**
+ * Replaces each `<link rel="stylesheet">` for file with a fresh copy,
+ * removing the old one once the new one loads, so styles never flash.
+ * @returns {number} the number of links replaced
+ */
+function updateLinks(file, stamp) {
+  let count = 0;
+  for (const root of allRoots()) {
+    for (const link of root.querySelectorAll('link[rel="stylesheet"]')) {
+      const url = new URL(link.href, window.location.href);
+      if (url.pathname !== file) continue;
+      url.searchParams.set('__cem-css-update', stamp);
+      const next = link.cloneNode();
+      next.href = url.href;
+      next.addEventListener('load', () => link.remove(), { once: true });
+      next.addEventListener('error', () => next.remove(), { once: true });
+      link.after(next);
+      count++;
+    }
+  }
+  return count;
+}
+
+/**
+ * Replaces the rules of the constructable stylesheets which the CSS
+ * transform registered for file, so adopting roots update in place.
+ * @returns {Promise<number>} the number of stylesheets updated
+ */
+async function updateSheets(file, stamp) {
+  const sheets = globalThis.__cemStyleSheets?.get(file);
+  if (!sheets?.size) return 0;
+  const { default: fresh } =
+    await import(`${file}?__cem-import-attrs[type]=css&__cem-css-update=${stamp}`);
+  sheets.delete(fresh);
+  const text = Array.from(fresh.cssRules, rule => rule.cssText).join('\n');
+  for (const sheet of sheets) {
+    sheet.replaceSync(text);
+  }
+  return sheets.size;
+}
+
 export class CEMReloadClient {
   #url = null;
   #jitterMax;
@@ -132,6 +181,32 @@ export class CEMReloadClient {
       case 'shutdown': return this.callbacks.onShutdown?.();
       case 'logs': return this.callbacks.onLogs?.(data.logs)
       case 'error': return this.callbacks.onError?.(data);
+      case 'error-cleared': return this.callbacks.onErrorCleared?.(data);
+      case 'css-update': return this.updateCSS(data);
+    }
+  }
+
+  /**
+   * Swaps changed stylesheets in place, preserving page state.
+   * `<link>` stylesheets are fetched again, and constructable stylesheets
+   * created by the dev server's CSS transform get the new rules.
+   * Reloads the page when none of the files are in use on it.
+   */
+  async updateCSS(data) {
+    const stamp = Date.now();
+    let updated = 0;
+    try {
+      for (const file of data.files ?? []) {
+        updated += updateLinks(file, stamp) + await updateSheets(file, stamp);
+      }
+    } catch (error) {
+      console.warn('[cem-serve] Failed to update stylesheets, reloading:', error);
+      updated = 0;
+    }
+    if (updated > 0) {
+      this.callbacks.onCSSUpdate?.(data);
+    } else {
+      this.callbacks.onReload?.({ ...data, type: 'reload', reason: 'css-update-fallback' });
     }
   }
 
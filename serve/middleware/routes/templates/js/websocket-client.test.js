@@ -248,6 +248,98 @@ describe('CEMReloadClient', () => {
 
       client.destroy();
     });
+
+    it('handles error-cleared message', async () => {
+      globalThis.fetch.resolves(new Response('[]', { status: 200 }));
+
+      const onErrorCleared = sinon.spy();
+      const client = new CEMReloadClient({
+        callbacks: { onErrorCleared }
+      });
+
+      await client.init();
+      await waitUntil(() => MockWebSocket.instances.length > 0);
+
+      const ws = MockWebSocket.instances[0];
+      ws.simulateOpen();
+      ws.simulateMessage({
+        type: 'error-cleared',
+        file: 'src/my-element.ts'
+      });
+
+      await waitUntil(() => onErrorCleared.called);
+      expect(onErrorCleared.firstCall.args[0]).to.deep.include({
+        type: 'error-cleared',
+        file: 'src/my-element.ts'
+      });
+
+      client.destroy();
+    });
+  });
+
+  describe('css-update', () => {
+    it('swaps linked stylesheets in place without reloading', async () => {
+      globalThis.fetch.resolves(new Response('[]', { status: 200 }));
+
+      const link = document.createElement('link');
+      link.rel = 'stylesheet';
+      link.href = '/src/button.css';
+      document.head.append(link);
+
+      const onReload = sinon.spy();
+      const onCSSUpdate = sinon.spy();
+      const client = new CEMReloadClient({
+        callbacks: { onReload, onCSSUpdate }
+      });
+
+      await client.init();
+      await waitUntil(() => MockWebSocket.instances.length > 0);
+
+      const ws = MockWebSocket.instances[0];
+      ws.simulateOpen();
+      ws.simulateMessage({
+        type: 'css-update',
+        files: ['/src/button.css']
+      });
+
+      await waitUntil(() => onCSSUpdate.called);
+      expect(onReload.called).to.be.false;
+      const links = [...document.head.querySelectorAll('link[rel="stylesheet"]')]
+        .filter(l => new URL(l.href).pathname === '/src/button.css');
+      expect(links.some(l => new URL(l.href).searchParams.has('__cem-css-update'))).to.be.true;
+
+      links.forEach(l => l.remove());
+      client.destroy();
+    });
+
+    it('reloads when no stylesheet on the page matches', async () => {
+      globalThis.fetch.resolves(new Response('[]', { status: 200 }));
+
+      const onReload = sinon.spy();
+      const onCSSUpdate = sinon.spy();
+      const client = new CEMReloadClient({
+        callbacks: { onReload, onCSSUpdate }
+      });
+
+      await client.init();
+      await waitUntil(() => MockWebSocket.instances.length > 0);
+
+      const ws = MockWebSocket.instances[0];
+      ws.simulateOpen();
+      ws.simulateMessage({
+        type: 'css-update',
+        files: ['/src/unused.css']
+      });
+
+      await waitUntil(() => onReload.called);
+      expect(onCSSUpdate.called).to.be.false;
+      expect(onReload.firstCall.args[0]).to.deep.include({
+        type: 'reload',
+        reason: 'css-update-fallback'
+      });
+
+      client.destroy();
+    });
   });
 
   describe('reconnection logic', () => {
@@ -13,6 +13,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -28,15 +29,16 @@ func (testLogger) Error(string, ...any)   {}
 func (testLogger) Success(string, ...any) {}
 func (testLogger) Trace(string, ...any)   {}
 
+var myElementOpenTag = regexp.MustCompile(`<my-element([^>]*)>`)
+
 // mockSSR is a simple SSR renderer for testing that wraps custom elements
 // with a <template shadowrootmode="open"> tag.
 type mockSSR struct{}
 
 func (m *mockSSR) RenderHTML(_ context.Context, html string) (string, error) {
 	// Simple mock: replace <my-element> with DSD-injected version
-	html = strings.ReplaceAll(html,
-		"<my-element>",
-		`<my-element><template shadowrootmode="open"><slot></slot></template>`)
+	html = myElementOpenTag.ReplaceAllString(html,
+		`<my-element$1><template shadowrootmode="open"><slot></slot></template>`)
 	return html, nil
 }
 
@@ -44,7 +46,7 @@ func (m *mockSSR) Close(_ context.Context) error { return nil }
 
 func TestMiddleware_InjectsDSD(t *testing.T) {
 	renderer := &mockSSR{}
-	mw := shadowroot.New(testLogger{}, renderer)
+	mw := shadowroot.New(testLogger{}, renderer, nil)
 
 	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
@@ -63,7 +65,7 @@ func TestMiddleware_InjectsDSD(t *testing.T) {
 
 func TestMiddleware_SkipsNonHTML(t *testing.T) {
 	renderer := &mockSSR{}
-	mw := shadowroot.New(testLogger{}, renderer)
+	mw := shadowroot.New(testLogger{}, renderer, nil)
 
 	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/javascript")
@@ -81,7 +83,7 @@ func TestMiddleware_SkipsNonHTML(t *testing.T) {
 
 func TestMiddleware_SkipsCemRoutes(t *testing.T) {
 	renderer := &mockSSR{}
-	mw := shadowroot.New(testLogger{}, renderer)
+	mw := shadowroot.New(testLogger{}, renderer, nil)
 
 	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
@@ -99,7 +101,7 @@ func TestMiddleware_SkipsCemRoutes(t *testing.T) {
 }
 
 func TestMiddleware_NilRenderer(t *testing.T) {
-	mw := shadowroot.New(testLogger{}, nil)
+	mw := shadowroot.New(testLogger{}, nil, nil)
 
 	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
@@ -114,3 +116,30 @@ func TestMiddleware_NilRenderer(t *testing.T) {
 		t.Errorf("nil renderer should pass through unchanged")
 	}
 }
+
+func TestMiddleware_ScopedElements(t *testing.T) {
+	renderer := &mockSSR{}
+	scoped := shadowroot.ScopedElements{"x-element": "my-element"}
+	mw := shadowroot.New(testLogger{}, renderer, scoped)
+
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body><x-element>hello</x-element><my-element>world</my-element></body></html>"))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	want := `<x-element><template shadowrootmode="open"><slot></slot></template>hello</x-element>`
+	if !strings.Contains(body, want) {
+		t.Errorf("expected scoped element to be rendered with its scoped name, got: %s", body)
+	}
+	if strings.Contains(body, "data-cem-scoped") {
+		t.Errorf("expected scoped marker to be removed, got: %s", body)
+	}
+	if !strings.Contains(body, `<my-element><template shadowrootmode="open">`) {
+		t.Errorf("expected global element to be rendered, got: %s", body)
+	}
+}
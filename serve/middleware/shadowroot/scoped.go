@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+*/
+
+package shadowroot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bennypowers.dev/cem/internal/platform"
+	"golang.org/x/net/html"
+)
+
+// scopedAttr marks elements renamed to their global tag names for rendering,
+// holding the scoped tag name to restore afterwards
+const scopedAttr = "data-cem-scoped"
+
+// ScopedElements maps tag names defined in scoped custom element registries
+// to the global tag names which the SSR renderer knows their classes by,
+// e.g. {"x-button": "my-button"}.
+type ScopedElements map[string]string
+
+// LoadScopedElements reads a scoped elements mapping file, a JSON object
+// from scoped tag names to global tag names
+func LoadScopedElements(fsys platform.FileSystem, path string) (ScopedElements, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scoped ScopedElements
+	if err := json.Unmarshal(data, &scoped); err != nil {
+		return nil, fmt.Errorf("parsing scoped elements %s: %w", path, err)
+	}
+	for scopedName, globalName := range scoped {
+		if !strings.Contains(scopedName, "-") || !strings.Contains(globalName, "-") {
+			return nil, fmt.Errorf("scoped elements %s: %q -> %q is not a mapping between custom element names", path, scopedName, globalName)
+		}
+	}
+	return scoped, nil
+}
+
+// toGlobal renames scoped elements in the document to their global tag
+// names, so the renderer can render them, marking each with the scoped
+// name. It reports whether any element was renamed.
+func (s ScopedElements) toGlobal(document string) (string, bool) {
+	if len(s) == 0 {
+		return document, false
+	}
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return document, false
+	}
+	renamed := false
+	walkElements(doc, func(n *html.Node) {
+		if globalName, ok := s[n.Data]; ok {
+			n.Attr = append(n.Attr, html.Attribute{Key: scopedAttr, Val: n.Data})
+			n.Data = globalName
+			renamed = true
+		}
+	})
+	if !renamed {
+		return document, false
+	}
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return document, false
+	}
+	return buf.String(), true
+}
+
+// fromGlobal restores the scoped tag names of the elements which toGlobal
+// renamed, keeping the shadow roots the renderer gave them
+func (s ScopedElements) fromGlobal(document string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return "", err
+	}
+	walkElements(doc, func(n *html.Node) {
+		for i, attr := range n.Attr {
+			if attr.Key == scopedAttr {
+				n.Data = attr.Val
+				n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+				return
+			}
+		}
+	})
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// walkElements calls fn for each element in the tree, in document order
+func walkElements(n *html.Node, fn func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		fn(n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkElements(c, fn)
+	}
+}
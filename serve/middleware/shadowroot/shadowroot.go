@@ -26,7 +26,9 @@ type SSRRenderer interface {
 
 // New creates a shadow root injection middleware that passes HTML
 // responses through a Lit SSR renderer to inject Declarative Shadow DOM.
-func New(logger types.Logger, renderer SSRRenderer) middleware.Middleware {
+// Elements named in scoped are rendered as their global counterparts, and
+// keep their scoped tag names in the response.
+func New(logger types.Logger, renderer SSRRenderer, scoped ScopedElements) middleware.Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip internal routes
@@ -54,7 +56,11 @@ func New(logger types.Logger, renderer SSRRenderer) middleware.Middleware {
 			}
 
 			// Pass HTML through Lit SSR renderer
-			processed, err := renderer.RenderHTML(r.Context(), string(rec.Body()))
+			source, renamed := scoped.toGlobal(string(rec.Body()))
+			processed, err := renderer.RenderHTML(r.Context(), source)
+			if err == nil && renamed {
+				processed, err = scoped.fromGlobal(processed)
+			}
 			if err != nil {
 				logger.Warning("Lit SSR rendering failed, serving without DSD: %v", err)
 				maps.Copy(w.Header(), rec.Header())
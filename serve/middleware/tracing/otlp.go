@@ -0,0 +1,244 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bennypowers.dev/cem/serve/logger"
+)
+
+const (
+	// serviceName identifies the dev server to the collector
+	serviceName = "cem-serve"
+	// exportQueueSize bounds the traces waiting to be exported; traces are
+	// dropped rather than slowing down requests when the collector lags
+	exportQueueSize = 256
+	// exportBatchSize caps the traces sent in one request
+	exportBatchSize = 64
+)
+
+// OTLP span kinds
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+)
+
+// exporter sends traces to an OpenTelemetry collector over OTLP/HTTP, with
+// the JSON encoding, from a background goroutine
+type exporter struct {
+	url    string
+	client *http.Client
+	logger logger.Logger
+	queue  chan *Trace
+	done   chan struct{}
+	mu     sync.Mutex
+	closed bool // protected by mu, so no trace is queued after close
+}
+
+func newExporter(endpoint string, log logger.Logger) *exporter {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	e := &exporter{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+		logger: log,
+		queue:  make(chan *Trace, exportQueueSize),
+		done:   make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// export queues a trace, or drops it when the queue is full
+func (e *exporter) export(trace *Trace) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	select {
+	case e.queue <- trace:
+	default:
+	}
+}
+
+// close sends the queued traces and stops the exporter
+func (e *exporter) close() {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+	e.closed = true
+	close(e.queue)
+	e.mu.Unlock()
+	<-e.done
+}
+
+func (e *exporter) run() {
+	defer close(e.done)
+	failing := false
+	for trace := range e.queue {
+		batch := []*Trace{trace}
+	drain:
+		for len(batch) < exportBatchSize {
+			select {
+			case next, ok := <-e.queue:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+		err := e.send(batch)
+		// Report the first failure, and recovery, rather than every request
+		if err != nil && !failing && e.logger != nil {
+			e.logger.Warning("Failed to export traces to %s: %v", e.url, err)
+		} else if err == nil && failing && e.logger != nil {
+			e.logger.Info("Exporting traces to %s again", e.url)
+		}
+		failing = err != nil
+	}
+}
+
+func (e *exporter) send(batch []*Trace) error {
+	body, err := json.Marshal(encodeOTLP(batch))
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector responded %s", resp.Status)
+	}
+	return nil
+}
+
+// OTLP/HTTP JSON request types, see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value int) otlpAttribute {
+	// OTLP JSON encodes 64-bit integers as strings
+	s := strconv.Itoa(value)
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &s}}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// encodeOTLP converts traces to an OTLP export request. The request span
+// carries the HTTP semantic convention attributes.
+func encodeOTLP(traces []*Trace) otlpRequest {
+	var spans []otlpSpan
+	for _, trace := range traces {
+		for i, span := range trace.Spans {
+			s := otlpSpan{
+				TraceID:           trace.ID,
+				SpanID:            span.ID,
+				ParentSpanID:      span.ParentID,
+				Name:              span.Name,
+				Kind:              spanKindInternal,
+				StartTimeUnixNano: unixNano(span.Start),
+				EndTimeUnixNano:   unixNano(span.Start.Add(span.Duration)),
+			}
+			if i == 0 {
+				s.Kind = spanKindServer
+				s.Attributes = []otlpAttribute{
+					stringAttribute("http.request.method", trace.Method),
+					stringAttribute("url.path", trace.Path),
+					intAttribute("http.response.status_code", trace.Status),
+				}
+			}
+			spans = append(spans, s)
+		}
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{stringAttribute("service.name", serviceName)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "bennypowers.dev/cem/serve"},
+				Spans: spans,
+			}},
+		}},
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package tracing times each request to the dev server and the middleware
+// stages it passes through, so slow demo loads can be traced to the stage
+// which spent the time. Recent traces are kept in memory, and can be
+// exported to an OpenTelemetry collector.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"bennypowers.dev/cem/serve/logger"
+	"bennypowers.dev/cem/serve/middleware"
+)
+
+// defaultCapacity is the number of recent traces kept in memory
+const defaultCapacity = 100
+
+// Span is a timed stage of a request
+type Span struct {
+	ID       string        `json:"spanId"`
+	ParentID string        `json:"parentSpanId,omitempty"`
+	Name     string        `json:"name"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Trace is a request and the spans of the middleware stages which handled
+// it. The first span is the request itself. Traces are not modified once
+// they are recorded.
+type Trace struct {
+	ID       string        `json:"traceId"`
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Status   int           `json:"status"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Spans    []*Span       `json:"spans"`
+}
+
+// Config configures a Tracer
+type Config struct {
+	// OTLPEndpoint is the base URL of an OpenTelemetry collector, e.g.
+	// http://localhost:4318. When empty, traces are only kept in memory.
+	OTLPEndpoint string
+	// Capacity is the number of recent traces to keep (default: 100)
+	Capacity int
+	// Logger reports export failures
+	Logger logger.Logger
+}
+
+// Tracer records request traces
+type Tracer struct {
+	mu       sync.RWMutex
+	traces   []*Trace // ring buffer of recent traces, protected by mu
+	next     int      // index of the next trace to write, protected by mu
+	exporter *exporter
+}
+
+// New creates a Tracer, and starts its exporter when an OTLP endpoint is
+// configured. Close the tracer to flush its exporter.
+func New(config Config) *Tracer {
+	capacity := config.Capacity
+	if capacity <= 0 {
+		capacity = defaultCapacity
+	}
+	t := &Tracer{traces: make([]*Trace, 0, capacity)}
+	if config.OTLPEndpoint != "" {
+		t.exporter = newExporter(config.OTLPEndpoint, config.Logger)
+	}
+	return t
+}
+
+// Close flushes traces waiting to be exported and stops the exporter
+func (t *Tracer) Close() {
+	if t.exporter != nil {
+		t.exporter.close()
+	}
+}
+
+// activeSpan is the span a stage runs in, carried in the request context
+type activeSpan struct {
+	trace  *Trace
+	spanID string
+}
+
+type activeSpanKey struct{}
+
+// Middleware starts a trace for each request, and records it when the
+// request is handled. It must wrap every stage. Requests for the dev
+// server's own routes under /__cem/ are not traced.
+func (t *Tracer) Middleware() middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/__cem/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			root := &Span{
+				ID:    newID(8),
+				Name:  r.Method + " " + r.URL.Path,
+				Start: time.Now(),
+			}
+			trace := &Trace{
+				ID:     newID(16),
+				Method: r.Method,
+				Path:   r.URL.Path,
+				Start:  root.Start,
+				Spans:  []*Span{root},
+			}
+			ctx := context.WithValue(r.Context(), activeSpanKey{}, &activeSpan{trace: trace, spanID: root.ID})
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			root.Duration = time.Since(root.Start)
+			trace.Duration = root.Duration
+			trace.Status = sw.status
+			t.record(trace)
+		})
+	}
+}
+
+// Stage wraps a middleware in a span called name. Stages nest: a stage's
+// span includes the time spent in the stages it wraps.
+func (t *Tracer) Stage(name string, mw middleware.Middleware) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		handler := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			parent, ok := r.Context().Value(activeSpanKey{}).(*activeSpan)
+			if !ok {
+				handler.ServeHTTP(w, r)
+				return
+			}
+			span := &Span{
+				ID:       newID(8),
+				ParentID: parent.spanID,
+				Name:     name,
+				Start:    time.Now(),
+			}
+			// Spans are appended on the request's goroutine, before the
+			// trace is recorded
+			parent.trace.Spans = append(parent.trace.Spans, span)
+			ctx := context.WithValue(r.Context(), activeSpanKey{}, &activeSpan{trace: parent.trace, spanID: span.ID})
+			handler.ServeHTTP(w, r.WithContext(ctx))
+			span.Duration = time.Since(span.Start)
+		})
+	}
+}
+
+func (t *Tracer) record(trace *Trace) {
+	t.mu.Lock()
+	if len(t.traces) < cap(t.traces) {
+		t.traces = append(t.traces, trace)
+	} else {
+		t.traces[t.next] = trace
+	}
+	t.next = (t.next + 1) % cap(t.traces)
+	t.mu.Unlock()
+
+	if t.exporter != nil {
+		t.exporter.export(trace)
+	}
+}
+
+// Latest returns the most recent trace of a request for path, or of any
+// request when path is empty. It returns nil when there is no such trace.
+func (t *Tracer) Latest(path string) *Trace {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for i := 1; i <= len(t.traces); i++ {
+		trace := t.traces[(t.next-i+len(t.traces))%len(t.traces)]
+		if path == "" || trace.Path == path {
+			return trace
+		}
+	}
+	return nil
+}
+
+// WriteTree writes the trace as an indented tree of spans, with the time
+// each span spent outside of the spans it wraps
+func (trace *Trace) WriteTree(w io.Writer) error {
+	children := make(map[string][]*Span)
+	for _, span := range trace.Spans[1:] {
+		children[span.ParentID] = append(children[span.ParentID], span)
+	}
+
+	if _, err := fmt.Fprintf(w, "trace %s at %s\n", trace.ID, trace.Start.Format(time.RFC3339)); err != nil {
+		return err
+	}
+	var write func(span *Span, depth int) error
+	write = func(span *Span, depth int) error {
+		self := span.Duration
+		for _, child := range children[span.ID] {
+			self -= child.Duration
+		}
+		line := fmt.Sprintf("%s%s %s (self %s)", strings.Repeat("  ", depth), span.Name, formatDuration(span.Duration), formatDuration(self))
+		if depth == 0 {
+			line += fmt.Sprintf(" -> %d", trace.Status)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		for _, child := range children[span.ID] {
+			if err := write(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return write(trace.Spans[0], 0)
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d.Microseconds())/1000)
+}
+
+// newID returns a random hex ID of n bytes, as used for OpenTelemetry trace
+// (16 bytes) and span (8 bytes) IDs
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// statusWriter records the status code of a response
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusWriter) WriteHeader(statusCode int) {
+	if !s.wroteHeader {
+		s.wroteHeader = true
+		s.status = statusCode
+	}
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (s *statusWriter) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (s *statusWriter) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"bennypowers.dev/cem/serve/middleware"
+	"bennypowers.dev/cem/serve/middleware/tracing"
+)
+
+// passthrough is a stage which does nothing but call the next handler
+func passthrough(next http.Handler) http.Handler {
+	return next
+}
+
+// tracedHandler chains two nested stages in front of a handler which
+// responds with status
+func tracedHandler(tracer *tracing.Tracer, status int) http.Handler {
+	return middleware.Chain(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}),
+		tracer.Middleware(),
+		tracer.Stage("outer", passthrough),
+		tracer.Stage("inner", passthrough),
+	)
+}
+
+func TestTracer_RecordsNestedStages(t *testing.T) {
+	tracer := tracing.New(tracing.Config{})
+	handler := tracedHandler(tracer, http.StatusNotFound)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/demos/button/", nil))
+
+	trace := tracer.Latest("")
+	if trace == nil {
+		t.Fatal("Expected a trace")
+	}
+	if trace.Status != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", trace.Status)
+	}
+	if len(trace.Spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(trace.Spans))
+	}
+	root, outer, inner := trace.Spans[0], trace.Spans[1], trace.Spans[2]
+	if root.Name != "GET /demos/button/" {
+		t.Errorf("Expected root span 'GET /demos/button/', got '%s'", root.Name)
+	}
+	if outer.Name != "outer" || outer.ParentID != root.ID {
+		t.Errorf("Expected 'outer' to be a child of the request, got '%s' with parent %s", outer.Name, outer.ParentID)
+	}
+	if inner.Name != "inner" || inner.ParentID != outer.ID {
+		t.Errorf("Expected 'inner' to be a child of 'outer', got '%s' with parent %s", inner.Name, inner.ParentID)
+	}
+	if len(trace.ID) != 32 || len(root.ID) != 16 {
+		t.Errorf("Expected OpenTelemetry-sized IDs, got trace %q and span %q", trace.ID, root.ID)
+	}
+
+	var tree strings.Builder
+	if err := trace.WriteTree(&tree); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(tree.String(), "\n    inner ") {
+		t.Errorf("Expected 'inner' to be indented under 'outer', got:\n%s", tree.String())
+	}
+}
+
+func TestTracer_SkipsInternalRoutes(t *testing.T) {
+	tracer := tracing.New(tracing.Config{})
+	handler := tracedHandler(tracer, http.StatusOK)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/__cem/trace/latest", nil))
+
+	if trace := tracer.Latest(""); trace != nil {
+		t.Errorf("Expected no trace for internal routes, got %s", trace.Path)
+	}
+}
+
+func TestTracer_LatestByPath(t *testing.T) {
+	tracer := tracing.New(tracing.Config{Capacity: 2})
+	handler := tracedHandler(tracer, http.StatusOK)
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if trace := tracer.Latest(""); trace == nil || trace.Path != "/c" {
+		t.Errorf("Expected the latest trace to be /c, got %v", trace)
+	}
+	if trace := tracer.Latest("/b"); trace == nil || trace.Path != "/b" {
+		t.Errorf("Expected a trace for /b, got %v", trace)
+	}
+	if trace := tracer.Latest("/a"); trace != nil {
+		t.Errorf("Expected /a to be dropped from the buffer, got %s", trace.Path)
+	}
+}
+
+func TestTracer_ExportsOTLP(t *testing.T) {
+	var mu sync.Mutex
+	var requests []map[string]any
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("Expected export to /v1/traces, got %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]any
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Expected JSON export, got %s", body)
+		}
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	tracer := tracing.New(tracing.Config{OTLPEndpoint: collector.URL})
+	handler := tracedHandler(tracer, http.StatusOK)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/demos/button/", nil))
+	tracer.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 export request, got %d", len(requests))
+	}
+	encoded, _ := json.Marshal(requests[0])
+	for _, want := range []string{
+		`"service.name"`,
+		`"name":"GET /demos/button/"`,
+		`"name":"outer"`,
+		`"name":"inner"`,
+		`"http.response.status_code"`,
+	} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("Expected export to contain %s, got %s", want, encoded)
+		}
+	}
+}
@@ -75,7 +75,7 @@ func TestExtractDependencies_CSSImports(t *testing.T) {
 	// Extract dependencies from the source
 	absPath, _ := filepath.Abs(inputPath)
 
-	deps := extractDependencies(source, absPath)
+	deps, _ := extractDependencies(source, absPath)
 
 	if len(deps) == 0 {
 		t.Fatal("Expected CSS import to be extracted as dependency, got 0 dependencies")
@@ -107,7 +107,7 @@ func TestCache_CSSImportDependenciesExtracted(t *testing.T) {
 	cssPath, _ := filepath.Abs(filepath.Join(filepath.Dir(inputPath), "component.css"))
 
 	// Extract dependencies and cache with them
-	deps := extractDependencies(source, absPath)
+	deps, _ := extractDependencies(source, absPath)
 	key := CacheKey{Path: absPath, ModTime: time.Now(), Size: int64(len(source))}
 	cache.Set(key, source, deps)
 
@@ -162,27 +162,33 @@ func TransformTypeScript(source []byte, opts TransformOptions) (*TransformResult
 
 	// Check for errors
 	if len(result.Errors) > 0 {
-		// Format errors for user
-		errMsg := "Transform failed:\n"
-		for _, err := range result.Errors {
-			errMsg += fmt.Sprintf("  %s\n", err.Text)
+		transformErr := &TransformError{}
+		for _, message := range result.Errors {
+			transformErr.Messages = append(transformErr.Messages, message.Text)
+			if message.Location != nil && transformErr.Line == 0 {
+				transformErr.Line = message.Location.Line
+				transformErr.Column = message.Location.Column
+				transformErr.CodeFrame = formatCodeFrame(source, message.Location.Line, message.Location.Column)
+			}
 		}
-		return nil, fmt.Errorf("%s", errMsg)
+		return nil, transformErr
 	}
 
 	// Extract dependencies from rewritten source code using tree-sitter
-	dependencies := extractDependencies(rewrittenSource, opts.Sourcefile)
+	dependencies, specifiers := extractDependencies(rewrittenSource, opts.Sourcefile)
 
 	// Return result
 	return &TransformResult{
 		Code:         result.Code,
 		Map:          result.Map,
 		Dependencies: dependencies,
+		Specifiers:   specifiers,
 	}, nil
 }
 
-// extractDependencies extracts import paths from TypeScript/JavaScript source using tree-sitter
-func extractDependencies(source []byte, sourcePath string) []string {
+// extractDependencies extracts import paths from TypeScript/JavaScript source using tree-sitter.
+// Relative imports are resolved to absolute paths; bare specifiers are returned separately.
+func extractDependencies(source []byte, sourcePath string) (resolvedDeps, specifiers []string) {
 	// Create a dependency tracker to collect imports
 	dependencyTracker := modulegraph.NewDependencyTracker()
 	exportTracker := modulegraph.NewExportTracker()
@@ -192,7 +198,7 @@ func extractDependencies(source []byte, sourcePath string) []string {
 	if err != nil {
 		// If QueryManager fails to initialize, dependency tracking won't work
 		// but the transform can still succeed
-		return nil
+		return nil, nil
 	}
 
 	// Use the DefaultExportParser to parse imports/exports
@@ -201,23 +207,26 @@ func extractDependencies(source []byte, sourcePath string) []string {
 	if err != nil {
 		// Log error but don't fail the transform - dependency tracking is best-effort
 		// The transform can still succeed even if we can't track dependencies
-		return nil
+		return nil, nil
 	}
 
 	// Get the dependencies that were found
 	deps := dependencyTracker.GetModuleDependencies(sourcePath)
 
 	// Resolve relative imports to absolute paths
-	resolvedDeps := make([]string, 0, len(deps))
+	resolvedDeps = make([]string, 0, len(deps))
 	for _, dep := range deps {
 		if isRelativeImport(dep) {
 			resolved := resolveImport(sourcePath, dep)
 			resolvedDeps = append(resolvedDeps, resolved)
+		} else if !strings.HasPrefix(dep, "/") {
+			// Bare specifiers (e.g., 'lit', '@rhds/elements') may still be local
+			// files in workspace mode, so leave resolving them to the caller
+			specifiers = append(specifiers, dep)
 		}
-		// Skip bare specifiers (e.g., 'lit', '@rhds/elements') as they're not local files
 	}
 
-	return resolvedDeps
+	return resolvedDeps, specifiers
 }
 
 // isRelativeImport checks if an import path is relative (./ or ../)
@@ -301,7 +310,10 @@ func stringToTemplateLiteral(str string) string {
 	return result.String()
 }
 
-// TransformCSS transforms CSS to a JavaScript module exporting a CSSStyleSheet
+// TransformCSS transforms CSS to a JavaScript module exporting a CSSStyleSheet.
+// The sheet is registered in globalThis.__cemStyleSheets under the module's
+// path, so the live reload client can replace its rules in place when the
+// CSS file changes.
 func TransformCSS(source []byte, path string) string {
 	css := stringToTemplateLiteral(string(source))
 
@@ -310,6 +322,10 @@ func TransformCSS(source []byte, path string) string {
 	return fmt.Sprintf(`// [served] %s
 const sheet = new CSSStyleSheet();
 sheet.replaceSync(%s);
+const sheets = globalThis.__cemStyleSheets ??= new Map();
+const path = new URL(import.meta.url).pathname;
+if (!sheets.has(path)) sheets.set(path, new Set());
+sheets.get(path).add(sheet);
 export default sheet;
 //# sourceMappingURL=data:application/json;base64,eyJ2ZXJzaW9uIjozLCJzb3VyY2VzIjpbXSwibmFtZXMiOltdLCJtYXBwaW5ncyI6IiJ9
 `, path, "`"+css+"`")
@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package transform
+
+import (
+	"path/filepath"
+	"strings"
+
+	cfg "bennypowers.dev/cem/cmd/config"
+	"bennypowers.dev/cem/internal/platform"
+)
+
+// PackageSettings holds TypeScript transform settings for one workspace package.
+// Empty fields fall back to the server's root settings.
+type PackageSettings struct {
+	Dir         string // Absolute path to the package directory
+	TsconfigRaw string // Content of the package's tsconfig
+	Target      string // Transform target from the package's config
+}
+
+// TsconfigCandidates returns the tsconfig files considered for a directory,
+// in order of preference: tsconfig.settings.json (common in monorepos), then
+// tsconfig.json.
+func TsconfigCandidates(dir string) []string {
+	return []string{
+		filepath.Join(dir, "tsconfig.settings.json"),
+		filepath.Join(dir, "tsconfig.json"),
+	}
+}
+
+// LoadPackageSettings reads the tsconfig for the package in dir, returning
+// its transform settings, URL rewrites for its rootDir/outDir prefixed with
+// urlPrefix (the package's URL path relative to the server root, e.g.
+// "/packages/button"), and the tsconfig files read, for hot-reload tracking.
+func LoadPackageSettings(dir, urlPrefix string, fs platform.FileSystem) (PackageSettings, []cfg.URLRewrite, []string) {
+	settings := PackageSettings{Dir: dir}
+	for _, tsconfigPath := range TsconfigCandidates(dir) {
+		data, err := fs.ReadFile(tsconfigPath)
+		if err != nil {
+			continue
+		}
+		settings.TsconfigRaw = string(data)
+
+		rewrites, sourceFiles, err := ParseTsConfig(tsconfigPath, fs)
+		if err != nil {
+			return settings, nil, []string{tsconfigPath}
+		}
+		return settings, PrefixURLRewrites(rewrites, urlPrefix), sourceFiles
+	}
+	return settings, nil, nil
+}
+
+// PrefixURLRewrites prepends a URL path prefix to the patterns and templates
+// of URL rewrites, so rewrites parsed relative to a package directory apply
+// to that package's URLs when served from a workspace root.
+func PrefixURLRewrites(rewrites []cfg.URLRewrite, prefix string) []cfg.URLRewrite {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return rewrites
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	prefixed := make([]cfg.URLRewrite, len(rewrites))
+	for i, rewrite := range rewrites {
+		prefixed[i] = rewrite
+		prefixed[i].URLPattern = prefix + rewrite.URLPattern
+		prefixed[i].URLTemplate = prefix + rewrite.URLTemplate
+	}
+	return prefixed
+}
+
+// FindPackageSettings returns the settings for the innermost package
+// containing path, or nil if path is in none of them.
+func FindPackageSettings(packages []PackageSettings, path string) *PackageSettings {
+	var best *PackageSettings
+	for i := range packages {
+		rel, err := filepath.Rel(packages[i].Dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if best == nil || len(packages[i].Dir) > len(best.Dir) {
+			best = &packages[i]
+		}
+	}
+	return best
+}
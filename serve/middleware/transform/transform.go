@@ -18,6 +18,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package transform
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -41,4 +43,59 @@ type TransformResult struct {
 	Code         []byte
 	Map          []byte
 	Dependencies []string
+	Specifiers   []string // Bare import specifiers, which may resolve to other workspace packages
+}
+
+// TransformError is returned when esbuild fails to transform a source file.
+// The location and code frame describe the first error which has a location.
+type TransformError struct {
+	Messages  []string // Text of each error
+	Line      int      // 1-based line of the first located error, or 0
+	Column    int      // 0-based column of the first located error
+	CodeFrame string   // Source lines around the first located error, with a caret under it
+}
+
+func (e *TransformError) Error() string {
+	var msg strings.Builder
+	msg.WriteString("Transform failed:\n")
+	for _, text := range e.Messages {
+		fmt.Fprintf(&msg, "  %s\n", text)
+	}
+	return msg.String()
+}
+
+// codeFrameContext is the number of source lines shown around an error
+const codeFrameContext = 2
+
+// formatCodeFrame renders the lines of source around a 1-based line and
+// 0-based column, numbering each line and marking the column with a caret
+func formatCodeFrame(source []byte, line, column int) string {
+	lines := strings.Split(strings.TrimSuffix(string(source), "\n"), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	first := max(line-codeFrameContext, 1)
+	last := min(line+codeFrameContext, len(lines))
+	width := len(fmt.Sprint(last))
+
+	var frame strings.Builder
+	for n := first; n <= last; n++ {
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		text := strings.TrimRight(lines[n-1], "\r")
+		fmt.Fprintf(&frame, "%s %*d | %s\n", marker, width, n, text)
+		if n == line {
+			// Keep tabs before the caret, so it lines up with the column
+			indent := []byte(text[:min(column, len(text))])
+			for i, b := range indent {
+				if b != '\t' {
+					indent[i] = ' '
+				}
+			}
+			fmt.Fprintf(&frame, "  %s | %s^\n", strings.Repeat(" ", width), indent)
+		}
+	}
+	return frame.String()
 }
@@ -18,6 +18,7 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package transform
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -33,17 +34,19 @@ const DefaultTarget = "ES2022"
 
 // TypeScriptConfig holds configuration for TypeScript transformation
 type TypeScriptConfig struct {
-	WatchDirFunc        func() string // Function to get current watch directory
-	TsconfigRawFunc     func() string // Function to get current tsconfig.json content
-	Cache               *Cache
-	Pool                *Pool // Worker pool for limiting concurrent transforms
-	Logger              types.Logger
-	ErrorBroadcaster    types.ErrorBroadcaster
-	Target              string
-	Enabled             bool                                  // Enable/disable TypeScript transformation
-	FS                  platform.FileSystem                   // Filesystem abstraction for testability
-	PathResolver        middleware.PathResolver               // Cached path resolver for efficient URL rewriting
-	OnTransformComplete func(requestPath string, code []byte) // Called after successful transform (async)
+	WatchDirFunc         func() string                            // Function to get current watch directory
+	TsconfigRawFunc      func() string                            // Function to get current tsconfig.json content
+	PackageSettingsFunc  func(sourcePath string) *PackageSettings // Per-package tsconfig and target (workspace mode)
+	ResolveSpecifierFunc func(specifier string) string            // Resolves bare specifiers to local source files (workspace mode)
+	Cache                *Cache
+	Pool                 *Pool // Worker pool for limiting concurrent transforms
+	Logger               types.Logger
+	ErrorBroadcaster     types.ErrorBroadcaster
+	Target               string
+	Enabled              bool                                  // Enable/disable TypeScript transformation
+	FS                   platform.FileSystem                   // Filesystem abstraction for testability
+	PathResolver         middleware.PathResolver               // Cached path resolver for efficient URL rewriting
+	OnTransformComplete  func(requestPath string, code []byte) // Called after successful transform (async)
 }
 
 // NewTypeScript creates a middleware that transforms TypeScript files to JavaScript
@@ -153,6 +156,19 @@ func NewTypeScript(config TypeScriptConfig) middleware.Middleware {
 
 					// Get configured target (defaults to ES2022 if not set)
 					target := config.Target
+
+					// In workspace mode, the package's own tsconfig and target win
+					if config.PackageSettingsFunc != nil {
+						if pkg := config.PackageSettingsFunc(fullTsPath); pkg != nil {
+							if pkg.TsconfigRaw != "" {
+								tsconfigRaw = pkg.TsconfigRaw
+							}
+							if pkg.Target != "" {
+								target = pkg.Target
+							}
+						}
+					}
+
 					if target == "" {
 						target = DefaultTarget
 					}
@@ -204,8 +220,25 @@ func NewTypeScript(config TypeScriptConfig) middleware.Middleware {
 							config.Logger.Error("Failed to transform TypeScript file %s: %v", tsPathNorm, transformErr)
 						}
 
-						// Broadcast error to browser overlay
-						if config.ErrorBroadcaster != nil {
+						// Broadcast error to browser overlay, located in the source
+						// file when possible
+						var located *TransformError
+						if broadcaster, ok := config.ErrorBroadcaster.(types.TransformErrorBroadcaster); ok {
+							var location *types.SourceLocation
+							if errors.As(transformErr, &located) && located.Line > 0 {
+								location = &types.SourceLocation{
+									Line:      located.Line,
+									Column:    located.Column,
+									CodeFrame: located.CodeFrame,
+								}
+							}
+							broadcaster.BroadcastTransformError(
+								"TypeScript Transform Error",
+								transformErr.Error(),
+								tsPathNorm,
+								location,
+							)
+						} else if config.ErrorBroadcaster != nil {
 							config.ErrorBroadcaster.BroadcastError(
 								"TypeScript Transform Error",
 								transformErr.Error(),
@@ -217,13 +250,29 @@ func NewTypeScript(config TypeScriptConfig) middleware.Middleware {
 						return
 					}
 
+					// Bare specifiers which resolve to sibling workspace packages are
+					// dependencies too, so changes there invalidate this file
+					dependencies := result.Dependencies
+					if config.ResolveSpecifierFunc != nil {
+						for _, specifier := range result.Specifiers {
+							if dep := config.ResolveSpecifierFunc(specifier); dep != "" {
+								dependencies = append(dependencies, dep)
+							}
+						}
+					}
+
 					// Store in cache
-					if len(result.Dependencies) > 0 {
+					if len(dependencies) > 0 {
 						if config.Logger != nil {
-							config.Logger.Debug("Caching %s with %d dependencies: %v", tsPathNorm, len(result.Dependencies), result.Dependencies)
+							config.Logger.Debug("Caching %s with %d dependencies: %v", tsPathNorm, len(dependencies), dependencies)
 						}
 					}
-					config.Cache.Set(cacheKey, result.Code, result.Dependencies)
+					config.Cache.Set(cacheKey, result.Code, dependencies)
+
+					// Dismiss any overlay shown for an earlier failed transform
+					if broadcaster, ok := config.ErrorBroadcaster.(types.TransformErrorBroadcaster); ok {
+						broadcaster.ClearTransformError(tsPathNorm)
+					}
 
 					// Serve transformed JavaScript
 					w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
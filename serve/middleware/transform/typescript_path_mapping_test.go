@@ -335,3 +335,55 @@ func TestTypeScriptMiddleware_BackwardCompatibility(t *testing.T) {
 		t.Error("Response still contains TypeScript syntax")
 	}
 }
+
+// TestTypeScriptMiddleware_PerPackageTsconfig tests that workspace packages
+// transform with their own tsconfig instead of the root's
+func TestTypeScriptMiddleware_PerPackageTsconfig(t *testing.T) {
+	fs := testutil.NewFixtureFS(t, "path-mappings/per-package-tsconfig", "/test")
+	cache := transform.NewCache(1024 * 1024)
+
+	var packages []transform.PackageSettings
+	var rewrites []config.URLRewrite
+	for _, name := range []string{"legacy", "modern"} {
+		settings, pkgRewrites, _ := transform.LoadPackageSettings("/test/packages/"+name, "/packages/"+name, fs)
+		packages = append(packages, settings)
+		rewrites = append(rewrites, pkgRewrites...)
+	}
+
+	middleware := transform.NewTypeScript(transform.TypeScriptConfig{
+		WatchDirFunc:    func() string { return "/test" },
+		TsconfigRawFunc: func() string { return `{}` },
+		PackageSettingsFunc: func(sourcePath string) *transform.PackageSettings {
+			return transform.FindPackageSettings(packages, sourcePath)
+		},
+		Cache:        cache,
+		Enabled:      true,
+		FS:           fs,
+		PathResolver: transform.NewPathResolver("/test", rewrites, fs, nil),
+	})
+
+	terminalHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Terminal handler called for %s - transform should have handled request", r.URL.Path)
+	})
+	handler := middleware(terminalHandler)
+
+	serve := func(path string) string {
+		req := httptest.NewRequest("GET", path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d", path, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	// legacy's tsconfig enables experimentalDecorators and maps lib -> src
+	if body := serve("/packages/legacy/lib/my-element.js"); !strings.Contains(body, "__decorateClass") {
+		t.Errorf("Expected legacy package to use experimental decorators, got:\n%s", body)
+	}
+
+	// modern's tsconfig uses standard decorators and maps dist -> src
+	if body := serve("/packages/modern/dist/my-element.js"); strings.Contains(body, "__decorateClass") {
+		t.Errorf("Expected modern package to use standard decorators, got:\n%s", body)
+	}
+}
@@ -18,6 +18,7 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package transform_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -56,3 +57,34 @@ func TestTypeScriptTransform_Basic(t *testing.T) {
 		t.Error("Output missing inline source map")
 	}
 }
+
+// TestTypeScriptTransform_ErrorLocation tests that transform errors locate
+// the failure in the original source with a code frame
+func TestTypeScriptTransform_ErrorLocation(t *testing.T) {
+	// Inline: a syntax error on the second of three lines
+	input := []byte("const a = 1;\nconst b = ;\nconst c = 3;\n")
+
+	_, err := transform.TransformTypeScript(input, transform.TransformOptions{
+		Loader: transform.LoaderTS,
+		Target: transform.ES2020,
+	})
+
+	var transformErr *transform.TransformError
+	if !errors.As(err, &transformErr) {
+		t.Fatalf("Expected *TransformError, got %T: %v", err, err)
+	}
+	if transformErr.Line != 2 || transformErr.Column != 10 {
+		t.Errorf("Expected error at 2:10, got %d:%d", transformErr.Line, transformErr.Column)
+	}
+
+	expectedFrame := "  1 | const a = 1;\n" +
+		"> 2 | const b = ;\n" +
+		"    |           ^\n" +
+		"  3 | const c = 3;\n"
+	if transformErr.CodeFrame != expectedFrame {
+		t.Errorf("Code frame mismatch.\nExpected:\n%s\nGot:\n%s", expectedFrame, transformErr.CodeFrame)
+	}
+	if !strings.HasPrefix(err.Error(), "Transform failed:") {
+		t.Errorf("Expected error message to start with 'Transform failed:', got %q", err.Error())
+	}
+}
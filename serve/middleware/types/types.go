@@ -29,6 +29,22 @@ type ErrorBroadcaster interface {
 	BroadcastError(title, message, filename string)
 }
 
+// SourceLocation locates an error in a source file
+type SourceLocation struct {
+	Line      int    // 1-based
+	Column    int    // 0-based
+	CodeFrame string // Source lines around the error
+}
+
+// TransformErrorBroadcaster is an ErrorBroadcaster which shows transform
+// errors in the pages affected by them, and clears them once the file
+// transforms successfully
+type TransformErrorBroadcaster interface {
+	ErrorBroadcaster
+	BroadcastTransformError(title, message, filename string, location *SourceLocation)
+	ClearTransformError(filename string)
+}
+
 // ImportMapOverride represents import map overrides with structure matching importmap.ImportMap
 // This is used for configuration to avoid circular dependencies while maintaining the same structure
 type ImportMapOverride struct {
@@ -42,4 +58,16 @@ type ImportMapConfig struct {
 	Generate     bool              `mapstructure:"generate" yaml:"generate" json:"generate"`
 	OverrideFile string            `mapstructure:"overrideFile" yaml:"overrideFile" json:"overrideFile"`
 	Override     ImportMapOverride `mapstructure:"override" yaml:"override" json:"override"`
+	CDNCache     CDNCacheConfig    `mapstructure:"cdnCache" yaml:"cdnCache" json:"cdnCache"`
+}
+
+// CDNCacheConfig configures the local cache of the CDN modules which the
+// import map resolves to
+type CDNCacheConfig struct {
+	// Enabled caches CDN modules, serving them locally when the CDN is unreachable
+	Enabled bool `mapstructure:"enabled" yaml:"enabled" json:"enabled"`
+	// Dir holds the cached modules (default: cem's directory in the user cache directory)
+	Dir string `mapstructure:"dir" yaml:"dir,omitempty" json:"dir,omitempty"`
+	// Offline serves cached modules without contacting CDNs
+	Offline bool `mapstructure:"offline" yaml:"offline" json:"offline"`
 }
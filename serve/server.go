@@ -37,8 +37,10 @@ import (
 	"bennypowers.dev/cem/internal/platform"
 	"bennypowers.dev/cem/serve/logger"
 	"bennypowers.dev/cem/serve/middleware"
+	"bennypowers.dev/cem/serve/middleware/cdncache"
 	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
 	"bennypowers.dev/cem/serve/middleware/routes"
+	"bennypowers.dev/cem/serve/middleware/tracing"
 	"bennypowers.dev/cem/serve/middleware/transform"
 	"bennypowers.dev/cem/serve/middleware/types"
 )
@@ -68,10 +70,17 @@ type Server struct {
 	isWorkspace       bool                          // True if serving a monorepo workspace
 	workspaceRoot     string                        // Root directory of workspace
 	workspacePackages []middleware.WorkspacePackage // Discovered packages with manifests
+	packageTransforms []transform.PackageSettings   // Per-package tsconfig and transform settings
 	// Cached routing table for demo routes (both workspace and single-package mode)
 	demoRoutes              map[string]*types.DemoRouteEntry
+	pendingDemoRoutes       demoRoutesBuilder             // Builds demoRoutes on first use, when deferred
+	demoRoutesGeneration    uint64                        // Incremented whenever demoRoutes is replaced or deferred
+	demoRoutesBuildMu       sync.Mutex                    // Serializes builds of deferred routing tables
+	demoImports             *demoImportCache              // Parsed module imports of demo pages, for smart reload
 	importMap               *importmappkg.ImportMap       // Cached import map (workspace or single-package)
 	importMapGraph          *importmappkg.DependencyGraph // Dependency graph for incremental updates
+	pinnedImportMap         *importmappkg.ImportMap       // Import map with CDN entries pinned to the CDN cache
+	cdnCache                *cdncache.Cache               // Local copies of CDN modules, when enabled
 	sourceControlRootURL    string                        // Source control root URL for demo routing
 	demoURLPrefix           string                        // URL path prefix to strip from demo URLs for local routing
 	templates               *routes.TemplateRegistry      // Template registry for HTML rendering
@@ -79,9 +88,11 @@ type Server struct {
 	pathResolver            *transform.PathResolver       // Cached path resolver (initialized once)
 	pathResolverSourceFiles []string                      // Files that pathResolver depends on (for hot-reload)
 	warnedSpecifiers        sync.Map                      // Deduplicates transitive dependency warnings (keyed by specifier)
+	transformErrors         sync.Map                      // Files whose failed transform is shown in an overlay (keyed by watchDir-relative path)
 	healthCache             *health.HealthResult          // Cached health analysis result
 	litSSR                  litSSRRenderer                // Lit SSR renderer for DSD injection
 	staticBuild             bool                          // True during static site build
+	tracer                  *tracing.Tracer               // Request tracer for /__cem/trace/latest and OTLP export
 }
 
 // NewServer creates a new server with the given port
@@ -117,6 +128,7 @@ func NewServerWithConfig(config Config) (*Server, error) {
 		shutdown:             make(chan struct{}),
 		sourceControlRootURL: config.SourceControlRootURL,
 		demoURLPrefix:        config.DemoURLPrefix,
+		demoImports:          newDemoImportCache(demoImportCacheSize),
 	}
 
 	// Use provided filesystem or default to os package
@@ -153,12 +165,50 @@ func NewServerWithConfig(config Config) (*Server, error) {
 		s.logger.Warning("Lit SSR initialization failed, running without SSR: %v", err)
 	}
 
+	if cdn := config.ImportMap.CDNCache; cdn.Enabled || cdn.Offline {
+		s.cdnCache = cdncache.New(cdncache.Config{
+			Dir:     cdn.Dir,
+			Offline: cdn.Offline,
+			Logger:  s.logger,
+			FS:      s.fs,
+		})
+	}
+
+	s.tracer = tracing.New(tracing.Config{
+		OTLPEndpoint: config.OTLPEndpoint,
+		Logger:       s.logger,
+	})
+
 	// Set up handler with middleware pipeline
 	s.setupMiddleware()
 
 	return s, nil
 }
 
+// maxPortAttempts caps the ports tried when AutoPort is enabled
+const maxPortAttempts = 20
+
+// listen binds the requested port. When the port can't be bound and AutoPort
+// is enabled, it tries the following ports in turn.
+func (s *Server) listen() (net.Listener, error) {
+	requested := s.port
+	for port := requested; ; port++ {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err == nil {
+			if port != requested {
+				s.logger.Warning("Port %d is unavailable, serving on port %d instead", requested, port)
+			}
+			return listener, nil
+		}
+		if !s.config.AutoPort || requested == 0 || port-requested+1 >= maxPortAttempts || port >= 65535 {
+			if port != requested {
+				return nil, fmt.Errorf("failed to bind ports %d-%d: %w", requested, port, err)
+			}
+			return nil, fmt.Errorf("failed to bind port %d: %w", port, err)
+		}
+	}
+}
+
 // Port returns the server's port
 func (s *Server) Port() int {
 	s.mu.RLock()
@@ -184,6 +234,13 @@ func (s *Server) DemoRenderingMode() string {
 	return rendering
 }
 
+// DemoEnv returns the configured demo environment variables
+func (s *Server) DemoEnv() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.Demos.Env
+}
+
 // WatchDir returns the current watch directory
 func (s *Server) WatchDir() string {
 	s.mu.RLock()
@@ -259,15 +316,14 @@ func (s *Server) Start() error {
 	}
 
 	// Bind the socket first to catch port binding errors before returning success
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	listener, err := s.listen()
 	if err != nil {
-		return fmt.Errorf("failed to bind port %d: %w", s.port, err)
+		return err
 	}
 
-	// When port 0 is requested, update to the actual port assigned by the OS
-	if s.port == 0 {
-		s.port = listener.Addr().(*net.TCPAddr).Port
-	}
+	// Update to the port actually bound, which differs from the requested
+	// port when it was 0 (assigned by the OS) or taken (auto-incremented)
+	s.port = listener.Addr().(*net.TCPAddr).Port
 
 	// Limit total concurrent connections to prevent resource exhaustion
 	// This caps HTTP handler goroutines even with HTTP/2 multiplexing
@@ -287,6 +343,15 @@ func (s *Server) Start() error {
 		IdleTimeout:  90 * time.Second, // Balances keep-alive reuse vs resource cleanup
 	}
 
+	if s.config.TLS.Enabled {
+		tlsConfig, err := s.loadTLSConfig()
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+		s.server.TLSConfig = tlsConfig
+	}
+
 	// Configure HTTP/2 with concurrent stream limits
 	_ = http2.ConfigureServer(s.server, http2Server)
 
@@ -331,7 +396,12 @@ func (s *Server) Start() error {
 
 	// Start server in goroutine with pre-bound listener
 	go func() {
-		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		run := s.server.Serve
+		if s.server.TLSConfig != nil {
+			// ServeTLS negotiates HTTP/2, which browsers only speak over TLS
+			run = func(l net.Listener) error { return s.server.ServeTLS(l, "", "") }
+		}
+		if err := run(listener); err != nil && err != http.ErrServerClosed {
 			s.logger.Error("Server error: %v", err)
 		}
 	}()
@@ -397,6 +467,11 @@ func (s *Server) Close() error {
 		}
 	}
 
+	// Flush traces waiting to be exported
+	if s.tracer != nil {
+		s.tracer.Close()
+	}
+
 	// Close transform pool to stop accepting new tasks
 	if s.transformPool != nil {
 		s.transformPool.Close()
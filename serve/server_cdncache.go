@@ -0,0 +1,64 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+*/
+
+package serve
+
+import (
+	"net/http"
+	"time"
+
+	"bennypowers.dev/cem/serve/middleware"
+	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
+)
+
+// cdnPinTimeout bounds caching an import map's CDN modules
+const cdnPinTimeout = 5 * time.Minute
+
+// pinImportMap caches the CDN modules of a new import map in the
+// background, then serves the map pinned to the cache, reloading pages whose
+// CDN modules now load from the cache. Callers must hold s.mu.
+func (s *Server) pinImportMap(im *importmappkg.ImportMap) {
+	s.pinnedImportMap = nil
+	if s.cdnCache == nil || im == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := s.contextWithShutdown(cdnPinTimeout)
+		defer cancel()
+		pinned := s.cdnCache.Pin(ctx, im.Imports, im.Scopes)
+
+		s.mu.Lock()
+		if s.importMap != im {
+			// A newer import map replaced this one while pinning
+			s.mu.Unlock()
+			return
+		}
+		s.pinnedImportMap = &importmappkg.ImportMap{
+			Imports:   pinned.Imports,
+			Scopes:    pinned.Scopes,
+			Integrity: pinned.Integrity,
+		}
+		s.mu.Unlock()
+
+		if len(pinned.Integrity) > 0 {
+			s.logger.Info("Serving %d CDN modules from the cache", len(pinned.Integrity))
+			if err := s.BroadcastReload([]string{"importmap"}, "import-map-pinned"); err != nil {
+				s.logger.Debug("Failed to broadcast reload: %v", err)
+			}
+		}
+	}()
+}
+
+// cdnCacheMiddleware serves cached CDN modules, when the CDN cache is enabled
+func (s *Server) cdnCacheMiddleware() middleware.Middleware {
+	if s.cdnCache == nil {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return s.cdnCache.Middleware()
+}
@@ -26,12 +26,14 @@ import (
 	"bennypowers.dev/cem/serve/logger"
 	"bennypowers.dev/cem/serve/middleware"
 	"bennypowers.dev/cem/serve/middleware/cors"
+	"bennypowers.dev/cem/serve/middleware/demohead"
 	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
 	"bennypowers.dev/cem/serve/middleware/inject"
 	"bennypowers.dev/cem/serve/middleware/requestlogger"
 	"bennypowers.dev/cem/serve/middleware/routes"
 	"bennypowers.dev/cem/serve/middleware/shadowroot"
 	"bennypowers.dev/cem/serve/middleware/transform"
+	"bennypowers.dev/cem/serve/middleware/types"
 )
 
 func init() {
@@ -63,6 +65,14 @@ func (e errorBroadcaster) BroadcastError(title, message, filename string) {
 	_ = e.Server.BroadcastError(title, message, filename) // Ignore error
 }
 
+func (e errorBroadcaster) BroadcastTransformError(title, message, filename string, location *types.SourceLocation) {
+	_ = e.Server.BroadcastTransformError(title, message, filename, location) // Ignore error
+}
+
+func (e errorBroadcaster) ClearTransformError(filename string) {
+	_ = e.Server.ClearTransformError(filename) // Ignore error
+}
+
 // getLogs returns logs if the logger supports it
 func (s *Server) getLogs() []logger.LogEntry {
 	if logGetter, ok := s.logger.(interface{ Logs() []logger.LogEntry }); ok {
@@ -71,6 +81,26 @@ func (s *Server) getLogs() []logger.LogEntry {
 	return nil
 }
 
+// scopedElements loads the scoped elements mapping file, if configured
+func (s *Server) scopedElements() shadowroot.ScopedElements {
+	path := s.config.Demos.ScopedElementsFile
+	if path == "" || s.litSSR == nil {
+		return nil
+	}
+	if !filepath.IsAbs(path) {
+		if s.WatchDir() == "" {
+			return nil
+		}
+		path = filepath.Join(s.WatchDir(), path)
+	}
+	scoped, err := shadowroot.LoadScopedElements(s.fs, path)
+	if err != nil {
+		s.logger.Warning("Failed to load scoped elements, rendering them without DSD: %v", err)
+		return nil
+	}
+	return scoped
+}
+
 // setupMiddleware configures the middleware pipeline
 func (s *Server) setupMiddleware() {
 	// Get WebSocket handler if reload is enabled
@@ -85,14 +115,25 @@ func (s *Server) setupMiddleware() {
 
 	// Middlewares are applied in reverse order (last to first in the chain)
 	// Terminal handler: static files
+	// Each stage is wrapped in a trace span, and spans nest like the stages
+	trace := s.tracer.Stage
 	s.handler = middleware.Chain(
-		http.HandlerFunc(s.serveStaticFiles), // Static file server (terminal handler)
-		shadowroot.New(s.logger, s.litSSR), // Lit SSR shadow root injection
-		inject.New(s.config.Reload && !s.staticBuild, "/__cem/websocket-client.js"), // WebSocket injection
-		importmappkg.New(importmappkg.MiddlewareConfig{ // Import map injection
+		http.HandlerFunc(s.serveStaticFiles),                                                         // Static file server (terminal handler)
+		s.tracer.Middleware(),                                                                        // Request tracing (outermost, so spans cover every stage)
+		trace("cdncache", s.cdnCacheMiddleware()),                                                    // Cached CDN modules
+		trace("shadowroot", shadowroot.New(s.logger, s.litSSR, s.scopedElements())),                  // Lit SSR shadow root injection
+		trace("inject", inject.New(s.config.Reload && !s.staticBuild, "/__cem/websocket-client.js")), // WebSocket injection
+		trace("demohead", demohead.New(demohead.Config{ // Demo page title, viewport, and theme toggle
+			Lookup: s.demoAt,
+			ThemeToggle: demohead.ThemeToggle{
+				Attribute: s.config.Demos.ThemeToggle.Attribute,
+				Class:     s.config.Demos.ThemeToggle.Class,
+			},
+		})),
+		trace("importmap", importmappkg.New(importmappkg.MiddlewareConfig{ // Import map injection
 			Context: s,
-		}),
-		transform.NewCSS(transform.CSSConfig{ // CSS transform
+		})),
+		trace("transform.css", transform.NewCSS(transform.CSSConfig{ // CSS transform
 			WatchDirFunc:     s.WatchDir,
 			Logger:           s.logger,
 			ErrorBroadcaster: errorBroadcaster{s},
@@ -102,26 +143,30 @@ func (s *Server) setupMiddleware() {
 			Exclude:          s.config.Transforms.CSS.Exclude,
 			FS:               s.fs,
 			PathResolver:     s.pathResolver,
-		}),
-		transform.NewTypeScript(transform.TypeScriptConfig{ // TypeScript transform
-			WatchDirFunc:        s.WatchDir,
-			TsconfigRawFunc:     s.TsconfigRaw,
-			Cache:               s.transformCache,
-			Pool:                s.transformPool,
-			Logger:              s.logger,
-			ErrorBroadcaster:    errorBroadcaster{s},
-			Target:              string(s.config.Transforms.TypeScript.Target),
-			Enabled:             s.config.Transforms.TypeScript.Enabled,
-			FS:                  s.fs,
-			PathResolver:        s.pathResolver,
-			OnTransformComplete: s.checkBareSpecifiers,
-		}),
-		routes.New(routes.Config{ // Internal CEM routes (includes WebSocket, demos, listings)
+		})),
+		trace("transform.typescript", transform.NewTypeScript(transform.TypeScriptConfig{ // TypeScript transform
+			WatchDirFunc:         s.WatchDir,
+			TsconfigRawFunc:      s.TsconfigRaw,
+			PackageSettingsFunc:  s.PackageTransformSettings,
+			ResolveSpecifierFunc: s.resolveWorkspaceSpecifier,
+			Cache:                s.transformCache,
+			Pool:                 s.transformPool,
+			Logger:               s.logger,
+			ErrorBroadcaster:     errorBroadcaster{s},
+			Target:               string(s.config.Transforms.TypeScript.Target),
+			Enabled:              s.config.Transforms.TypeScript.Enabled,
+			FS:                   s.fs,
+			PathResolver:         s.pathResolver,
+			OnTransformComplete:  s.checkBareSpecifiers,
+		})),
+		trace("routes", routes.New(routes.Config{ // Internal CEM routes (includes WebSocket, demos, listings)
 			Context:          s,
 			LogsFunc:         s.getLogs,
+			FileChangesFunc:  s.HandleFileChanges,
+			TraceFunc:        s.tracer.Latest,
 			WebSocketHandler: wsHandler,
 			Templates:        s.templates,
-		}),
+		})),
 		cors.New(),                  // CORS headers
 		requestlogger.New(s.logger), // HTTP request logging
 	)
@@ -28,10 +28,14 @@ import (
 	"golang.org/x/net/html"
 )
 
-// ImportMap returns the cached import map (may be nil)
+// ImportMap returns the cached import map (may be nil). Once the CDN cache
+// has pinned its CDN entries, the dev server serves the pinned map instead.
 func (s *Server) ImportMap() middleware.ImportMap {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.pinnedImportMap != nil && !s.staticBuild {
+		return s.pinnedImportMap
+	}
 	return s.importMap
 }
 
@@ -114,12 +118,23 @@ func extractImportsFromScript(scriptContent string) ([]string, error) {
 }
 
 // extractModuleImports parses an HTML file and extracts ES module import specifiers
-// Returns both import specifiers from inline scripts and src URLs from script tags
+// Returns both import specifiers from inline scripts and src URLs from script tags.
+// Results are cached until the file's modification time or size changes.
 func (s *Server) extractModuleImports(htmlPath string) ([]string, error) {
 	s.mu.RLock()
 	fs := s.fs
 	s.mu.RUnlock()
 
+	// Files without a modification time (e.g. in-memory test filesystems)
+	// can't be told apart from their earlier versions, so aren't cached
+	var key demoImportKey
+	if info, err := fs.Stat(htmlPath); err == nil && !info.ModTime().IsZero() && s.demoImports != nil {
+		key = demoImportKey{path: htmlPath, modTime: info.ModTime(), size: info.Size()}
+		if imports, ok := s.demoImports.get(key); ok {
+			return imports, nil
+		}
+	}
+
 	content, err := fs.ReadFile(htmlPath)
 	if err != nil {
 		return nil, err
@@ -180,6 +195,9 @@ func (s *Server) extractModuleImports(htmlPath string) ([]string, error) {
 		result = append(result, imp)
 	}
 
+	if key.path != "" {
+		s.demoImports.set(key, result)
+	}
 	return result, nil
 }
 
@@ -58,7 +58,7 @@ func (s *Server) SetManifest(manifest []byte) error {
 	defer s.mu.Unlock()
 
 	s.manifest = manifestCopy
-	s.demoRoutes = routingTable
+	s.setDemoRoutes(routingTable)
 	s.invalidateHealthCache()
 
 	if err != nil {
@@ -130,6 +130,10 @@ func (s *Server) TryLoadExistingManifest() (int, error) {
 		return 0, fmt.Errorf("no watch directory set")
 	}
 
+	if s.config.ManifestFile != "" {
+		return s.loadPinnedManifest(s.config.ManifestFile)
+	}
+
 	// Perform I/O operations without holding lock
 	// Determine manifest path
 	workspace := W.NewFileSystemWorkspaceContext(watchDir)
@@ -178,12 +182,30 @@ func (s *Server) TryLoadExistingManifest() (int, error) {
 	// Store the manifest (defensive copy to prevent external mutation)
 	s.manifest = make([]byte, len(manifestBytes))
 	copy(s.manifest, manifestBytes)
-	s.demoRoutes = routingTable
+	s.setDemoRoutes(routingTable)
 	s.invalidateHealthCache()
 
 	return len(manifestBytes), nil
 }
 
+// loadPinnedManifest reads the pre-built manifest the server is pinned to,
+// in place of generating one
+func (s *Server) loadPinnedManifest(manifestFile string) (int, error) {
+	manifestBytes, err := s.fs.ReadFile(manifestFile)
+	if err != nil {
+		return 0, fmt.Errorf("reading manifest file: %w", err)
+	}
+
+	var pkg any
+	if err := json.Unmarshal(manifestBytes, &pkg); err != nil {
+		return 0, fmt.Errorf("invalid manifest JSON in %s: %w", manifestFile, err)
+	}
+
+	// Routing table failures are logged by SetManifest, and are not fatal
+	_ = s.SetManifest(manifestBytes)
+	return len(manifestBytes), nil
+}
+
 // RegenerateManifest performs a full manifest regeneration
 // Returns the manifest size in bytes and any error
 func (s *Server) RegenerateManifest() (int, error) {
@@ -199,6 +221,11 @@ func (s *Server) RegenerateManifest() (int, error) {
 		return 0, fmt.Errorf("no watch directory set")
 	}
 
+	// A pinned manifest is never generated, only re-read
+	if s.config.ManifestFile != "" {
+		return s.loadPinnedManifest(s.config.ManifestFile)
+	}
+
 	// In workspace mode, regenerate all package manifests
 	if isWorkspace {
 		return s.regenerateWorkspaceManifests()
@@ -276,7 +303,7 @@ func (s *Server) RegenerateManifest() (int, error) {
 	// Defensive copy (though json.MarshalIndent already returns a new slice)
 	s.manifest = make([]byte, len(manifestBytes))
 	copy(s.manifest, manifestBytes)
-	s.demoRoutes = routingTable
+	s.setDemoRoutes(routingTable)
 	s.invalidateHealthCache()
 
 	return len(manifestBytes), nil
@@ -349,7 +376,7 @@ func (s *Server) RegenerateManifestIncremental(changedFiles []string) (int, erro
 	// Defensive copy (though json.MarshalIndent already returns a new slice)
 	s.manifest = make([]byte, len(manifestBytes))
 	copy(s.manifest, manifestBytes)
-	s.demoRoutes = routingTable
+	s.setDemoRoutes(routingTable)
 	s.invalidateHealthCache()
 
 	return len(manifestBytes), nil
@@ -160,27 +160,13 @@ func (s *Server) mergeURLRewrites(configRewrites, tsconfigRewrites []config.URLR
 	return append(configRewrites, tsconfigRewrites...)
 }
 
-// RebuildPathResolverForTest exposes rebuildPathResolver for testing.
-// This is a test-only wrapper that allows tests to trigger path resolver rebuilds.
-func (s *Server) RebuildPathResolverForTest() error {
-	return s.rebuildPathResolver()
-}
-
-// rebuildPathResolver rebuilds the path resolver when tsconfig or config files change.
-// This method is called during hot-reload to update URL rewrites without restarting the server.
-func (s *Server) rebuildPathResolver() error {
-	s.mu.Lock()
-
-	if s.watchDir == "" {
-		s.mu.Unlock()
-		return fmt.Errorf("no watch directory set")
-	}
-
-	// Re-parse config file URL rewrites from disk (hot-reload needs fresh file contents)
+// buildPathResolver parses URL rewrites from the config file, the root
+// tsconfig and, in workspace mode, each package's tsconfig, then builds the
+// path resolver from them. Must be called with s.mu held.
+func (s *Server) buildPathResolver() {
 	configURLRewrites, configSourceFiles := s.parseConfigFileRewrites()
 
-	// Re-parse tsconfig files from disk (hot-reload needs fresh file contents)
-	// Similar to SetWatchDir, but we re-read files instead of using cached config
+	// Try tsconfig.settings.json first (common in monorepos), then tsconfig.json
 	tsconfigPaths := []string{
 		filepath.Join(s.watchDir, "tsconfig.settings.json"),
 		filepath.Join(s.watchDir, "tsconfig.json"),
@@ -188,14 +174,13 @@ func (s *Server) rebuildPathResolver() error {
 
 	var urlRewrites []config.URLRewrite
 	var tsconfigSourceFiles []string
-
 	for _, tsconfigPath := range tsconfigPaths {
 		rewrites, sourceFiles, err := transform.ParseTsConfig(tsconfigPath, s.fs)
 		if err == nil {
 			urlRewrites = rewrites
 			tsconfigSourceFiles = sourceFiles
 			if len(rewrites) > 0 {
-				s.logger.Debug("Rebuilt URL rewrites from %s", tsconfigPath)
+				s.logger.Debug("Extracted URL rewrites from %s", tsconfigPath)
 				for _, r := range rewrites {
 					s.logger.Debug("  %s -> %s", r.URLPattern, r.URLTemplate)
 				}
@@ -204,17 +189,44 @@ func (s *Server) rebuildPathResolver() error {
 		}
 	}
 
-	// Merge URL rewrites: config file takes precedence over tsconfig
-	urlRewrites = s.mergeURLRewrites(configURLRewrites, urlRewrites)
+	// In workspace mode, each package's tsconfig maps its own rootDir/outDir.
+	// Package rewrites are more specific, so they're tried before the root's.
+	var packageSourceFiles []string
+	if s.isWorkspace {
+		var packageRewrites []config.URLRewrite
+		s.packageTransforms, packageRewrites, packageSourceFiles = s.loadPackageTransforms()
+		urlRewrites = append(packageRewrites, urlRewrites...)
+	}
 
-	s.logger.Info("Rebuilding path resolver with %d URL rewrites", len(urlRewrites))
-	s.urlRewrites = urlRewrites
+	// Merge URL rewrites: config file takes precedence over tsconfig
+	s.urlRewrites = s.mergeURLRewrites(configURLRewrites, urlRewrites)
 
-	// Track both config file and tsconfig source files for hot-reload
-	s.pathResolverSourceFiles = append(configSourceFiles, tsconfigSourceFiles...)
+	// Track config file, root tsconfig, and package tsconfig source files for hot-reload
+	s.pathResolverSourceFiles = append(append(configSourceFiles, tsconfigSourceFiles...), packageSourceFiles...)
 
-	// Rebuild path resolver
+	// Initialize path resolver once (cached for all requests)
 	s.pathResolver = transform.NewPathResolver(s.watchDir, s.urlRewrites, s.fs, s.logger)
+}
+
+// RebuildPathResolverForTest exposes rebuildPathResolver for testing.
+// This is a test-only wrapper that allows tests to trigger path resolver rebuilds.
+func (s *Server) RebuildPathResolverForTest() error {
+	return s.rebuildPathResolver()
+}
+
+// rebuildPathResolver rebuilds the path resolver when tsconfig or config files change.
+// This method is called during hot-reload to update URL rewrites without restarting the server.
+func (s *Server) rebuildPathResolver() error {
+	s.mu.Lock()
+
+	if s.watchDir == "" {
+		s.mu.Unlock()
+		return fmt.Errorf("no watch directory set")
+	}
+
+	// Re-parse config and tsconfig files from disk (hot-reload needs fresh file contents)
+	s.buildPathResolver()
+	s.logger.Info("Rebuilt path resolver with %d URL rewrites", len(s.urlRewrites))
 
 	// Invalidate transform cache (path resolution changed, so cached transforms may be stale)
 	if s.transformCache != nil {
@@ -267,9 +279,6 @@ func (s *Server) SetWatchDir(dir string) error {
 		}
 	}
 
-	// Parse config file for URL rewrites (if config file path was set)
-	configURLRewrites, configSourceFiles := s.parseConfigFileRewrites()
-
 	// Load tsconfig - try tsconfig.settings.json first (common in monorepos),
 	// then fall back to tsconfig.json
 	tsconfigPaths := []string{
@@ -292,32 +301,8 @@ func (s *Server) SetWatchDir(dir string) error {
 		s.logger.Debug("No tsconfig found, using default transform settings")
 	}
 
-	// Parse tsconfig for URL rewrites (supports src/dist separation)
-	var urlRewrites []config.URLRewrite
-	var tsconfigSourceFiles []string // Track all files used
-	for _, tsconfigPath := range tsconfigPaths {
-		rewrites, sourceFiles, err := transform.ParseTsConfig(tsconfigPath, s.fs)
-		if err == nil {
-			urlRewrites = rewrites
-			tsconfigSourceFiles = sourceFiles // Store the files
-			if len(rewrites) > 0 {
-				s.logger.Debug("Extracted URL rewrites from %s", tsconfigPath)
-				for _, r := range rewrites {
-					s.logger.Debug("  %s -> %s", r.URLPattern, r.URLTemplate)
-				}
-			}
-			break
-		}
-	}
-
-	// Merge URL rewrites: config file takes precedence over tsconfig
-	s.urlRewrites = s.mergeURLRewrites(configURLRewrites, urlRewrites)
-
-	// Track both config file and tsconfig source files for hot-reload
-	s.pathResolverSourceFiles = append(configSourceFiles, tsconfigSourceFiles...)
-
-	// Initialize path resolver once (cached for all requests)
-	s.pathResolver = transform.NewPathResolver(s.watchDir, s.urlRewrites, s.fs, s.logger)
+	// Parse config file and tsconfig URL rewrites (supports src/dist separation)
+	s.buildPathResolver()
 
 	// Generate import map for single-package mode
 	// (Workspace mode generates in InitializeWorkspaceMode instead)
@@ -336,6 +321,7 @@ func (s *Server) SetWatchDir(dir string) error {
 		} else {
 			s.importMap = result.ImportMap
 			s.importMapGraph = result.Graph
+			s.pinImportMap(s.importMap)
 			s.logger.Debug("Generated import map for single-package mode")
 		}
 	} else if !s.config.ImportMap.Generate {
@@ -19,6 +19,8 @@ package serve
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"path/filepath"
 	"runtime/debug"
 	"strings"
@@ -79,6 +81,64 @@ func (s *Server) BroadcastError(title, message, file string) error {
 	return s.wsManager.Broadcast(msgBytes)
 }
 
+// BroadcastTransformError shows a transform error, located in its source
+// file, in the pages which import the file. Falls back to all clients when
+// no demo page is known to import it.
+func (s *Server) BroadcastTransformError(title, message, file string, location *types.SourceLocation) error {
+	if s.wsManager == nil {
+		return nil // WebSocket disabled
+	}
+
+	msg := ErrorMessage{
+		Type:    "error",
+		Title:   title,
+		Message: message,
+		File:    file,
+	}
+	if location != nil {
+		msg.Line = location.Line
+		msg.Column = location.Column
+		msg.CodeFrame = location.CodeFrame
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.transformErrors.Store(file, true)
+
+	s.mu.RLock()
+	watchDir := s.watchDir
+	s.mu.RUnlock()
+	changedPath := filepath.Join(watchDir, file)
+	affectedPageURLs := s.getAffectedPageURLs(changedPath, s.getModuleGraphAffectedFiles(changedPath))
+	if len(affectedPageURLs) == 0 {
+		s.logger.Debug("Broadcasting transform error to all clients: file=%s", file)
+		return s.wsManager.Broadcast(msgBytes)
+	}
+
+	s.logger.Debug("Broadcasting transform error to %d affected pages: file=%s", len(affectedPageURLs), file)
+	return s.wsManager.BroadcastToPages(msgBytes, affectedPageURLs)
+}
+
+// ClearTransformError dismisses the overlay shown for a file's failed
+// transform, if there is one, now that it transforms successfully
+func (s *Server) ClearTransformError(file string) error {
+	if _, failed := s.transformErrors.LoadAndDelete(file); !failed || s.wsManager == nil {
+		return nil
+	}
+
+	msgBytes, err := json.Marshal(ErrorClearedMessage{
+		Type: "error-cleared",
+		File: file,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug("Clearing transform error: file=%s", file)
+	return s.wsManager.Broadcast(msgBytes)
+}
+
 // logCacheStats periodically logs cache statistics
 func (s *Server) logCacheStats(interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -161,25 +221,30 @@ func (s *Server) filterRelevantFiles(filesToProcess []string) (relevantFiles, ts
 	return relevantFiles, tsJsFiles
 }
 
-// collectAffectedFiles collects files from transform cache and module graph
-func (s *Server) collectAffectedFiles(changedPath string) []string {
+// collectAffectedFiles collects files from transform cache and module graph.
+// A batch's other changed files are affected too, so pages which import
+// them reload.
+func (s *Server) collectAffectedFiles(changedPaths ...string) []string {
 	affectedFiles := make(map[string]bool)
 
-	// Add transform cache invalidations
-	if s.transformCache != nil {
-		invalidatedFiles := s.transformCache.Invalidate(changedPath)
-		if len(invalidatedFiles) > 0 {
-			s.logger.Debug("Transform cache invalidated %d files: %v", len(invalidatedFiles), invalidatedFiles)
-			for _, file := range invalidatedFiles {
-				affectedFiles[file] = true
+	for i, changedPath := range changedPaths {
+		if i > 0 {
+			affectedFiles[changedPath] = true
+		}
+
+		// Add transform cache invalidations
+		if s.transformCache != nil {
+			invalidatedFiles := s.transformCache.Invalidate(changedPath)
+			if len(invalidatedFiles) > 0 {
+				s.logger.Debug("Transform cache invalidated %d files: %v", len(invalidatedFiles), invalidatedFiles)
+				for _, file := range invalidatedFiles {
+					affectedFiles[file] = true
+				}
 			}
 		}
-	}
 
-	// Add module graph affected files (includes non-transformed .js files)
-	moduleGraphFiles := s.getModuleGraphAffectedFiles(changedPath)
-	if len(moduleGraphFiles) > 0 {
-		for _, file := range moduleGraphFiles {
+		// Add module graph affected files (includes non-transformed .js files)
+		for _, file := range s.getModuleGraphAffectedFiles(changedPath) {
 			affectedFiles[file] = true
 		}
 	}
@@ -198,7 +263,8 @@ func (s *Server) collectAffectedFiles(changedPath string) []string {
 // When files are created or deleted, a full regeneration is performed since
 // the incremental dependency tracker has no knowledge of new files.
 func (s *Server) regenerateManifestIfNeeded(tsJsFiles []string, hasStructuralChange bool) {
-	if len(tsJsFiles) == 0 {
+	// Source changes don't affect a pinned manifest
+	if len(tsJsFiles) == 0 || s.config.ManifestFile != "" {
 		return
 	}
 
@@ -312,6 +378,7 @@ func (s *Server) regenerateImportMapIfNeeded(event FileEvent) {
 	} else if importMap != nil {
 		s.importMap = importMap
 		s.importMapGraph = importMapGraph
+		s.pinImportMap(importMap)
 		s.warnedSpecifiers.Clear() // Reset dedup so warnings re-fire for new import map
 		s.logger.Debug("Regenerated import map in %v", importMapDuration)
 	}
@@ -378,6 +445,67 @@ func (s *Server) extractChangedPackages(paths []string, watchDir string) []strin
 	return result
 }
 
+// isCSSOnlyChange reports whether every changed file is a stylesheet. CSS
+// reaches pages only as stylesheets, since transforms never inline it into
+// modules, so clients can swap them without reloading.
+func isCSSOnlyChange(relevantFiles []string) bool {
+	for _, f := range relevantFiles {
+		if filepath.Ext(f) != ".css" {
+			return false
+		}
+	}
+	return len(relevantFiles) > 0
+}
+
+// broadcastCSSUpdate sends a css-update message for the changed stylesheets
+// to affected pages, which swap them in place, or to all clients when no
+// demo routes are known
+func (s *Server) broadcastCSSUpdate(changedPath string, relevantFiles, invalidatedFiles []string) {
+	if s.wsManager == nil {
+		return
+	}
+
+	files := make([]string, 0, len(relevantFiles))
+	for _, f := range relevantFiles {
+		rel := f
+		if s.watchDir != "" {
+			if r, err := filepath.Rel(s.watchDir, f); err == nil {
+				rel = r
+			}
+		}
+		files = append(files, "/"+filepath.ToSlash(rel))
+	}
+
+	msgBytes, err := json.Marshal(CSSUpdateMessage{
+		Type:  "css-update",
+		Files: files,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create CSS update message: %v", err)
+		return
+	}
+
+	affectedPageURLs := s.getAffectedPageURLs(changedPath, invalidatedFiles)
+	if len(affectedPageURLs) == 0 {
+		s.mu.RLock()
+		noDemoRoutes := len(s.demoRoutes) == 0
+		s.mu.RUnlock()
+
+		if !noDemoRoutes {
+			s.logger.Debug("No pages affected by changes to %v", files)
+			return
+		}
+		s.logger.Debug("No demo routes found, broadcasting CSS update to all clients: %v", files)
+		err = s.wsManager.Broadcast(msgBytes)
+	} else {
+		s.logger.Debug("Broadcasting CSS update to %d affected pages: %v", len(affectedPageURLs), files)
+		err = s.wsManager.BroadcastToPages(msgBytes, affectedPageURLs)
+	}
+	if err != nil {
+		s.logger.Error("Failed to broadcast CSS update: %v", err)
+	}
+}
+
 // broadcastSmartReload sends reload messages to affected pages or all clients
 func (s *Server) broadcastSmartReload(changedPath, relPath string, invalidatedFiles []string) {
 	// Smart reload: only reload pages that import the changed file or its dependents
@@ -385,7 +513,8 @@ func (s *Server) broadcastSmartReload(changedPath, relPath string, invalidatedFi
 
 	if len(affectedPageURLs) == 0 {
 		// If smart reload found no affected pages, check if we're in a "no routes" state
-		// (e.g. no manifest yet). In this case, fallback to broadcasting to all clients.
+		// (e.g. no manifest yet, or a workspace routing table which no request has
+		// built yet). In this case, fallback to broadcasting to all clients.
 		s.mu.RLock()
 		noDemoRoutes := len(s.demoRoutes) == 0
 		s.mu.RUnlock()
@@ -450,83 +579,127 @@ func (s *Server) handleFileChanges() {
 			return
 		}
 
-		// Recover from panics to prevent server crash
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					s.logger.Error("Panic in file change handler: %v", r)
-					s.logger.Debug("Stack trace: %s", string(debug.Stack()))
-				}
-			}()
+		s.HandleFileEvent(event)
+	}
+}
 
-			// Process all files in the batched event
-			filesToProcess := event.Paths
-			if len(filesToProcess) == 0 {
-				filesToProcess = []string{event.Path}
-			}
+// HandleFileEvent rebuilds what a batch of file changes affects, then
+// notifies connected clients, as the file watcher does. Test harnesses which
+// change files in memory call it directly, so that changes apply before it
+// returns. It recovers from panics, so that one bad change can't crash the
+// server.
+func (s *Server) HandleFileEvent(event FileEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Panic in file change handler: %v", r)
+			s.logger.Debug("Stack trace: %s", string(debug.Stack()))
+		}
+	}()
 
-			// Check if path resolver needs rebuild (tsconfig.json, config files changed)
-			if s.shouldRebuildPathResolver(filesToProcess) {
-				s.logger.Debug("Rebuilding path resolver due to source file change")
-				if err := s.rebuildPathResolver(); err != nil {
-					s.logger.Error("Failed to rebuild path resolver: %v", err)
-					// Continue processing - don't block other hot-reload logic
-				}
-				// Note: rebuildPathResolver() handles broadcasting reload message
-			}
+	// Process all files in the batched event
+	filesToProcess := event.Paths
+	if len(filesToProcess) == 0 {
+		filesToProcess = []string{event.Path}
+	}
 
-			// Filter to only relevant source files and collect TS/JS files
-			relevantFiles, tsJsFiles := s.filterRelevantFiles(filesToProcess)
-			if len(relevantFiles) == 0 {
-				return
-			}
+	// Check if path resolver needs rebuild (tsconfig.json, config files changed)
+	if s.shouldRebuildPathResolver(filesToProcess) {
+		s.logger.Debug("Rebuilding path resolver due to source file change")
+		if err := s.rebuildPathResolver(); err != nil {
+			s.logger.Error("Failed to rebuild path resolver: %v", err)
+			// Continue processing - don't block other hot-reload logic
+		}
+		// Note: rebuildPathResolver() handles broadcasting reload message
+	}
 
-			// Use first file for display/logging purposes
-			changedPath := relevantFiles[0]
-			relPath := changedPath
-			if s.watchDir != "" {
-				if rel, err := filepath.Rel(s.watchDir, changedPath); err == nil {
-					relPath = rel
-				}
-			}
+	// Filter to only relevant source files and collect TS/JS files
+	relevantFiles, tsJsFiles := s.filterRelevantFiles(filesToProcess)
+	if len(relevantFiles) == 0 {
+		return
+	}
 
-			// Resolve .js to .ts if source exists and log
-			displayPath := s.resolveSourceFile(relPath)
-			s.logger.Info("File changed: %s", displayPath)
+	// Use first file for display/logging purposes
+	changedPath := relevantFiles[0]
+	relPath := changedPath
+	if s.watchDir != "" {
+		if rel, err := filepath.Rel(s.watchDir, changedPath); err == nil {
+			relPath = rel
+		}
+	}
 
-			// Collect affected files from transform cache and module graph
-			invalidatedFiles := s.collectAffectedFiles(changedPath)
-			if len(invalidatedFiles) > 0 {
-				s.logger.Debug("Collected %d invalidated files for %s", len(invalidatedFiles), displayPath)
-			}
+	// Resolve .js to .ts if source exists and log
+	displayPath := s.resolveSourceFile(relPath)
+	s.logger.Info("File changed: %s", displayPath)
 
-			// Regenerate manifest if TS/JS files changed
-			hasStructuralChange := event.HasCreates || event.HasDeletes
-			s.regenerateManifestIfNeeded(tsJsFiles, hasStructuralChange)
-
-			// Regenerate import map if package.json or file structure changed
-			s.regenerateImportMapIfNeeded(event)
-
-			// For structural changes (new/deleted files), broadcast to all clients
-			// since the element list in the sidebar may have changed
-			if hasStructuralChange && len(tsJsFiles) > 0 {
-				files := make([]string, 0, len(relevantFiles))
-				for _, f := range relevantFiles {
-					if rel, err := filepath.Rel(s.watchDir, f); err == nil {
-						files = append(files, rel)
-					} else {
-						files = append(files, f)
-					}
-				}
-				if err := s.BroadcastReload(files, "file-structure-change"); err != nil {
-					s.logger.Error("Failed to broadcast reload: %v", err)
-				}
+	// Collect affected files from transform cache and module graph
+	invalidatedFiles := s.collectAffectedFiles(relevantFiles...)
+	if len(invalidatedFiles) > 0 {
+		s.logger.Debug("Collected %d invalidated files for %s", len(invalidatedFiles), displayPath)
+	}
+
+	// Regenerate manifest if TS/JS files changed
+	hasStructuralChange := event.HasCreates || event.HasDeletes
+	s.regenerateManifestIfNeeded(tsJsFiles, hasStructuralChange)
+
+	// Regenerate import map if package.json or file structure changed
+	s.regenerateImportMapIfNeeded(event)
+
+	// For structural changes (new/deleted files), broadcast to all clients
+	// since the element list in the sidebar may have changed
+	if hasStructuralChange && len(tsJsFiles) > 0 {
+		files := make([]string, 0, len(relevantFiles))
+		for _, f := range relevantFiles {
+			if rel, err := filepath.Rel(s.watchDir, f); err == nil {
+				files = append(files, rel)
 			} else {
-				// Broadcast smart reload to affected pages
-				s.broadcastSmartReload(changedPath, relPath, invalidatedFiles)
+				files = append(files, f)
 			}
-		}() // End panic recovery function
+		}
+		if err := s.BroadcastReload(files, "file-structure-change"); err != nil {
+			s.logger.Error("Failed to broadcast reload: %v", err)
+		}
+	} else if isCSSOnlyChange(relevantFiles) {
+		// Swap stylesheets in place, preserving page state
+		s.broadcastCSSUpdate(changedPath, relevantFiles, invalidatedFiles)
+	} else {
+		// Broadcast smart reload to affected pages
+		s.broadcastSmartReload(changedPath, relPath, invalidatedFiles)
+	}
+}
+
+// HandleFileChanges handles changes to files, relative to the watch
+// directory, as one batch, as though the file watcher had reported them.
+// Scripts report changes the watcher can't see through it, e.g. after a
+// codegen step writes ignored files. eventType is create, delete, or
+// modify, the default.
+func (s *Server) HandleFileChanges(eventType string, files []string) error {
+	switch eventType {
+	case "":
+		eventType = "modify"
+	case "create", "delete", "modify":
+	default:
+		return fmt.Errorf("unknown event type %q, expected create, delete, or modify", eventType)
+	}
+	if len(files) == 0 {
+		return errors.New("no files changed")
+	}
+
+	watchDir := s.WatchDir()
+	if watchDir == "" {
+		return errors.New("no watch directory")
+	}
+	changes := make([]FileChange, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(watchDir, filepath.FromSlash(file))
+		rel, err := filepath.Rel(watchDir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%s is outside the watch directory", file)
+		}
+		changes = append(changes, FileChange{Path: path, EventType: eventType})
 	}
+
+	s.HandleFileEvent(NewFileEvent(changes...))
+	return nil
 }
 
 // getModuleGraphAffectedFiles queries the generate session's dependency tracker
@@ -653,6 +826,10 @@ func (s *Server) isRouteAffectedByFile(routePath string, routeEntry *types.DemoR
 	// Get the directory of the HTML file for resolving relative imports
 	demoDir := filepath.Dir(routeEntry.FilePath)
 
+	s.mu.RLock()
+	pathResolver := s.pathResolver
+	s.mu.RUnlock()
+
 	// Resolve imports to file paths and check if any match affected files
 	for _, importSpec := range imports {
 		resolvedPaths := s.resolveImportToPath(importSpec, demoDir)
@@ -670,6 +847,14 @@ func (s *Server) isRouteAffectedByFile(routePath string, routeEntry *types.DemoR
 				normalizedResolvedTS = normalizedResolved[:len(normalizedResolved)-3] + ".ts"
 			}
 
+			// Also try the TypeScript source in a different directory (e.g. dist/ -> src/),
+			// as when a demo imports a sibling workspace package via the import map
+			if pathResolver != nil && strings.HasPrefix(resolvedPath, "/") {
+				if tsSource := pathResolver.ResolveTsSource(resolvedPath); tsSource != "" {
+					normalizedResolvedTS = filepath.Clean(filepath.FromSlash(tsSource))
+				}
+			}
+
 			// Check if this resolved path matches any affected file
 			if affectedFiles[normalizedResolved] ||
 				affectedFiles[normalizedResolvedTS] ||
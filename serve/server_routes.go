@@ -0,0 +1,200 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package serve
+
+import (
+	"container/list"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"bennypowers.dev/cem/serve/middleware"
+	"bennypowers.dev/cem/serve/middleware/demohead"
+)
+
+// demoImportCacheSize caps the demo pages whose parsed imports are cached
+const demoImportCacheSize = 512
+
+// demoAt finds the demo served at a URL path, either by its demo route or,
+// for demo files served as they are, by its file path
+func (s *Server) demoAt(urlPath string) (demohead.Demo, bool) {
+	routes := s.DemoRoutes()
+	if len(routes) == 0 {
+		return demohead.Demo{}, false
+	}
+	routePath := urlPath
+	if !strings.HasSuffix(routePath, "/") && path.Ext(routePath) == "" {
+		routePath += "/"
+	}
+	entry, ok := routes[routePath]
+	if !ok {
+		for _, candidate := range routes {
+			if candidate.PackagePath == "" && "/"+filepath.ToSlash(candidate.FilePath) == urlPath {
+				entry, ok = candidate, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return demohead.Demo{}, false
+	}
+	demo := demohead.Demo{TagName: entry.TagName}
+	if entry.Declaration != nil {
+		demo.Summary = entry.Declaration.Summary
+	}
+	return demo, true
+}
+
+// demoRoutesBuilder builds a demo routing table
+type demoRoutesBuilder func() (map[string]*middleware.DemoRouteEntry, error)
+
+// DemoRoutes returns the demo routing table (both workspace and single-package
+// mode). In workspace mode the table is built when it is first requested,
+// rather than when the server starts or a manifest is regenerated.
+func (s *Server) DemoRoutes() map[string]*middleware.DemoRouteEntry {
+	s.mu.RLock()
+	routes, pending := s.demoRoutes, s.pendingDemoRoutes
+	s.mu.RUnlock()
+	if pending == nil {
+		return routes
+	}
+	return s.buildPendingDemoRoutes()
+}
+
+// deferDemoRoutes replaces the routing table with one which is built on
+// first use. Must be called with s.mu held.
+func (s *Server) deferDemoRoutes(build demoRoutesBuilder) {
+	s.demoRoutes = nil
+	s.pendingDemoRoutes = build
+	s.demoRoutesGeneration++
+}
+
+// setDemoRoutes replaces the routing table. Must be called with s.mu held.
+func (s *Server) setDemoRoutes(routes map[string]*middleware.DemoRouteEntry) {
+	s.demoRoutes = routes
+	s.pendingDemoRoutes = nil
+	s.demoRoutesGeneration++
+}
+
+// buildPendingDemoRoutes builds a deferred routing table. Concurrent requests
+// wait for one build; a table deferred during the build replaces it.
+func (s *Server) buildPendingDemoRoutes() map[string]*middleware.DemoRouteEntry {
+	s.demoRoutesBuildMu.Lock()
+	defer s.demoRoutesBuildMu.Unlock()
+
+	s.mu.RLock()
+	routes, pending, generation := s.demoRoutes, s.pendingDemoRoutes, s.demoRoutesGeneration
+	s.mu.RUnlock()
+	if pending == nil {
+		// Another request built it while this one waited
+		return routes
+	}
+
+	start := time.Now()
+	routes, err := pending()
+	if err != nil {
+		s.logger.Error("Failed to build demo routing table: %v", err)
+	}
+	s.logger.Debug("Built routing table with %d demo routes in %v", len(routes), time.Since(start))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.demoRoutesGeneration == generation {
+		s.setDemoRoutes(routes)
+	}
+	return routes
+}
+
+// demoImportKey identifies a version of a demo page by its file metadata
+type demoImportKey struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// demoImportEntry is a demo page's parsed module imports
+type demoImportEntry struct {
+	key     demoImportKey
+	imports []string
+}
+
+// demoImportCache caches the module imports of demo pages, so checking which
+// pages a file change affects only parses pages which changed since the last
+// check. Pages are evicted least recently used first.
+type demoImportCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element // path -> element holding a *demoImportEntry
+	lru     *list.List               // most recently used at front
+	maxSize int
+}
+
+func newDemoImportCache(maxSize int) *demoImportCache {
+	return &demoImportCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		maxSize: maxSize,
+	}
+}
+
+// get returns the cached imports of a demo page, if the page hasn't changed
+func (c *demoImportCache) get(key demoImportKey) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key.path]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*demoImportEntry)
+	if entry.key != key {
+		c.lru.Remove(elem)
+		delete(c.entries, key.path)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return slices.Clone(entry.imports), true
+}
+
+// set caches the imports of a demo page, evicting the least recently used
+// page when the cache is full
+func (c *demoImportCache) set(key demoImportKey, imports []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key.path]; ok {
+		elem.Value = &demoImportEntry{key: key, imports: imports}
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key.path] = c.lru.PushFront(&demoImportEntry{key: key, imports: imports})
+	for c.lru.Len() > c.maxSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*demoImportEntry).key.path)
+	}
+}
+
+// count returns the number of cached pages
+func (c *demoImportCache) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
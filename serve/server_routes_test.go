@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package serve
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"bennypowers.dev/cem/serve/logger"
+	"bennypowers.dev/cem/serve/middleware"
+)
+
+func TestDeferredDemoRoutes(t *testing.T) {
+	s := &Server{logger: logger.NewDefaultLogger()}
+
+	builds := 0
+	builder := func(route string) demoRoutesBuilder {
+		return func() (map[string]*middleware.DemoRouteEntry, error) {
+			builds++
+			return map[string]*middleware.DemoRouteEntry{
+				route: {LocalRoute: route},
+			}, nil
+		}
+	}
+
+	s.mu.Lock()
+	s.deferDemoRoutes(builder("/first/"))
+	s.mu.Unlock()
+	if builds != 0 {
+		t.Fatalf("Expected no build before the routes are requested, got %d", builds)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if _, ok := s.DemoRoutes()["/first/"]; !ok {
+				t.Error("Expected the deferred route")
+			}
+		})
+	}
+	wg.Wait()
+	if builds != 1 {
+		t.Errorf("Expected concurrent requests to share one build, got %d", builds)
+	}
+
+	s.mu.Lock()
+	s.deferDemoRoutes(builder("/second/"))
+	s.mu.Unlock()
+	if _, ok := s.DemoRoutes()["/second/"]; !ok {
+		t.Error("Expected routes deferred again to be rebuilt")
+	}
+	if builds != 2 {
+		t.Errorf("Expected 2 builds, got %d", builds)
+	}
+
+	s.mu.Lock()
+	s.deferDemoRoutes(builder("/third/"))
+	s.setDemoRoutes(map[string]*middleware.DemoRouteEntry{"/fourth/": {}})
+	s.mu.Unlock()
+	if _, ok := s.DemoRoutes()["/fourth/"]; !ok {
+		t.Error("Expected routes set after deferring to replace the deferred routes")
+	}
+	if builds != 2 {
+		t.Errorf("Expected the replaced builder not to run, got %d builds", builds)
+	}
+}
+
+func TestDemoImportCache(t *testing.T) {
+	cache := newDemoImportCache(2)
+	modTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := func(path string, size int64) demoImportKey {
+		return demoImportKey{path: path, modTime: modTime, size: size}
+	}
+
+	cache.set(key("/a.html", 1), []string{"./a.js"})
+	cache.set(key("/b.html", 1), []string{"./b.js"})
+
+	if imports, ok := cache.get(key("/a.html", 1)); !ok || imports[0] != "./a.js" {
+		t.Errorf("Expected cached imports of /a.html, got %v", imports)
+	}
+	if _, ok := cache.get(key("/b.html", 2)); ok {
+		t.Error("Expected a changed file to miss the cache")
+	}
+	if cache.count() != 1 {
+		t.Errorf("Expected the changed file to be dropped, got %d entries", cache.count())
+	}
+
+	// /a.html was used most recently, so /c.html evicts /d.html
+	cache.set(key("/d.html", 1), []string{"./d.js"})
+	cache.get(key("/a.html", 1))
+	cache.set(key("/c.html", 1), []string{"./c.js"})
+	if _, ok := cache.get(key("/d.html", 1)); ok {
+		t.Error("Expected the least recently used page to be evicted")
+	}
+	if _, ok := cache.get(key("/a.html", 1)); !ok {
+		t.Error("Expected the most recently used page to be kept")
+	}
+}
@@ -22,7 +22,9 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package serve_test
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -171,6 +173,112 @@ func TestServerDynamicPort(t *testing.T) {
 	t.Logf("Dynamic port assigned: %d", server.Port())
 }
 
+// TestServerAutoPort verifies a taken port fails, or falls through to the
+// next free port with AutoPort
+func TestServerAutoPort(t *testing.T) {
+	taken, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Failed to occupy a port: %v", err)
+	}
+	defer func() { _ = taken.Close() }()
+	port := taken.Addr().(*net.TCPAddr).Port
+
+	strict, err := serve.NewServerWithConfig(serve.Config{Port: port})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = strict.Close() }()
+	if err := strict.Start(); err == nil {
+		t.Fatalf("Expected an error binding taken port %d", port)
+	}
+
+	server, err := serve.NewServerWithConfig(serve.Config{Port: port, AutoPort: true})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	if server.Port() <= port {
+		t.Errorf("Expected a port after %d, got %d", port, server.Port())
+	}
+}
+
+// TestServerTLS verifies TLS mode serves HTTP/2 over a self-signed certificate
+func TestServerTLS(t *testing.T) {
+	resp := getTLS(t, platform.NewMapFileSystem(nil))
+	if err := resp.TLS.PeerCertificates[0].VerifyHostname("localhost"); err != nil {
+		t.Errorf("Expected a certificate for localhost: %v", err)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Errorf("Expected HTTP/2, got %s", resp.Proto)
+	}
+}
+
+// TestServerTLSReusesCertificate verifies the self-signed certificate is
+// cached, so that restarting the server doesn't ask browsers to trust a new one
+func TestServerTLSReusesCertificate(t *testing.T) {
+	mfs := platform.NewMapFileSystem(nil)
+	first := getTLS(t, mfs).TLS.PeerCertificates[0]
+	second := getTLS(t, mfs).TLS.PeerCertificates[0]
+	if first.SerialNumber.Cmp(second.SerialNumber) != 0 {
+		t.Errorf("Expected the cached certificate %s, got %s", first.SerialNumber, second.SerialNumber)
+	}
+
+	other := getTLS(t, platform.NewMapFileSystem(nil)).TLS.PeerCertificates[0]
+	if first.SerialNumber.Cmp(other.SerialNumber) == 0 {
+		t.Error("Expected a new certificate without a cached one")
+	}
+}
+
+// getTLS starts a TLS server with a self-signed certificate, and requests
+// its root over HTTP/2
+func getTLS(t *testing.T, mfs platform.FileSystem) *http.Response {
+	t.Helper()
+	server, err := serve.NewServerWithConfig(serve.Config{Port: 0, FS: mfs, TLS: serve.TLSConfig{Enabled: true}})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+	if err := server.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+
+	url := server.URL()
+	if !strings.HasPrefix(url, "https://localhost:") {
+		t.Fatalf("Expected an https URL, got %q", url)
+	}
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get(url + "/")
+	if err != nil {
+		t.Fatalf("Failed to request %s: %v", url, err)
+	}
+	_ = resp.Body.Close()
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		t.Fatal("Expected a TLS connection with a certificate")
+	}
+	return resp
+}
+
+// TestServerTLSIncompleteConfig verifies a certificate without a key fails
+func TestServerTLSIncompleteConfig(t *testing.T) {
+	server, err := serve.NewServerWithConfig(serve.Config{
+		Port: 0,
+		TLS:  serve.TLSConfig{Enabled: true, CertFile: "cert.pem"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+	if err := server.Start(); err == nil {
+		t.Fatal("Expected an error starting with a certificate but no key")
+	}
+}
+
 // TestServerLifecycle verifies start/stop behavior
 func TestServerLifecycle(t *testing.T) {
 	server, err := serve.NewServer(8003)
@@ -425,6 +533,45 @@ func TestManifestRegenerationTrigger(t *testing.T) {
 	}
 }
 
+// TestPinnedManifest verifies that a server pinned to a pre-built manifest
+// serves it, and re-reads it instead of generating one
+func TestPinnedManifest(t *testing.T) {
+	mfs := newTestFS(t)
+	pinned := `{"schemaVersion":"2.1.1","modules":[{"kind":"javascript-module","path":"dist/pinned.js","declarations":[]}]}`
+	mfs.(*platform.MapFileSystem).AddFile("/test-package/dist/custom-elements.json", pinned, 0644)
+
+	server, err := serve.NewServerWithConfig(serve.Config{
+		Port:         8013,
+		FS:           mfs,
+		ManifestFile: "/test-package/dist/custom-elements.json",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+
+	if err := server.SetWatchDir("/test-package"); err != nil {
+		t.Fatalf("Failed to set watch directory: %v", err)
+	}
+
+	for name, load := range map[string]func() (int, error){
+		"TryLoadExistingManifest": server.TryLoadExistingManifest,
+		"RegenerateManifest":      server.RegenerateManifest,
+	} {
+		size, err := load()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if size != len(pinned) {
+			t.Errorf("%s: expected %d bytes, got %d", name, len(pinned), size)
+		}
+		manifest, _ := server.Manifest()
+		if string(manifest) != pinned {
+			t.Errorf("%s: expected the pinned manifest, got %s", name, manifest)
+		}
+	}
+}
+
 // TestManifestGeneration_WithTempDir verifies manifest generation with temp directory
 func TestManifestGeneration_WithTempDir(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -1395,3 +1542,116 @@ func TestNoConfigFilePath(t *testing.T) {
 		t.Fatalf("Expected 1 URL rewrite after rebuild, got %d", len(urlRewritesAfter))
 	}
 }
+
+// TestWorkspacePackageTransforms verifies that in workspace mode each package
+// is served with its own tsconfig and transform target, and that changes to a
+// package's tsconfig are picked up on reload
+func TestWorkspacePackageTransforms(t *testing.T) {
+	mfs := testutil.NewFixtureFS(t, "path-mappings/workspace-package-transforms", "/test")
+
+	server, err := serve.NewServerWithConfig(serve.Config{
+		Port: 0,
+		FS:   mfs,
+		Transforms: serve.TransformConfig{
+			TypeScript: serve.TypeScriptConfig{
+				Enabled: true,
+				Target:  transform.ES2022,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+
+	if err := server.SetWatchDir("/test"); err != nil {
+		t.Fatalf("Failed to set watch directory: %v", err)
+	}
+	if err := server.InitializeWorkspaceMode(); err != nil {
+		t.Fatalf("Failed to initialize workspace mode: %v", err)
+	}
+	if !server.IsWorkspace() {
+		t.Fatal("Expected workspace mode")
+	}
+
+	// legacy sets its own target in .config/cem.yaml; modern has none
+	legacy := server.PackageTransformSettings("/test/packages/legacy/src/my-element.ts")
+	if legacy == nil {
+		t.Fatal("Expected transform settings for the legacy package")
+	}
+	if legacy.Target != string(transform.ES2020) {
+		t.Errorf("Expected legacy target %q, got %q", transform.ES2020, legacy.Target)
+	}
+	if !strings.Contains(legacy.TsconfigRaw, "experimentalDecorators") {
+		t.Errorf("Expected legacy package tsconfig, got %q", legacy.TsconfigRaw)
+	}
+	modern := server.PackageTransformSettings("/test/packages/modern/src/my-element.ts")
+	if modern == nil {
+		t.Fatal("Expected transform settings for the modern package")
+	}
+	if modern.Target != "" {
+		t.Errorf("Expected no modern target, got %q", modern.Target)
+	}
+
+	patterns := func() []string {
+		var result []string
+		for _, r := range server.URLRewrites() {
+			result = append(result, r.URLPattern)
+		}
+		return result
+	}
+	expected := []string{"/packages/legacy/lib/:path*", "/packages/modern/dist/:path*"}
+	if got := patterns(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected URL rewrites %v, got %v", expected, got)
+	}
+
+	get := func(path string) (int, string) {
+		req := httptest.NewRequest("GET", path, nil)
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+
+	// The package target reaches esbuild: es2020 lowers private fields,
+	// while the server's es2022 target keeps them
+	code, body := get("/packages/legacy/lib/my-element.js")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200 for legacy package, got %d", code)
+	}
+	if strings.Contains(body, "#count") {
+		t.Errorf("Expected legacy package to lower private fields for es2020, got:\n%s", body)
+	}
+	code, body = get("/packages/modern/dist/my-element.js")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200 for modern package, got %d", code)
+	}
+	if !strings.Contains(body, "#count") {
+		t.Errorf("Expected modern package to keep private fields for es2022, got:\n%s", body)
+	}
+
+	// Change legacy's outDir: lib -> build
+	mfs.AddFile("/test/packages/legacy/tsconfig.json", `{
+  "compilerOptions": {
+    "rootDir": "./src",
+    "outDir": "./build"
+  }
+}`, 0644)
+	if err := server.RebuildPathResolverForTest(); err != nil {
+		t.Fatalf("Failed to rebuild path resolver: %v", err)
+	}
+
+	expected = []string{"/packages/legacy/build/:path*", "/packages/modern/dist/:path*"}
+	if got := patterns(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected URL rewrites %v after reload, got %v", expected, got)
+	}
+	legacy = server.PackageTransformSettings("/test/packages/legacy/src/my-element.ts")
+	if legacy == nil || strings.Contains(legacy.TsconfigRaw, "experimentalDecorators") {
+		t.Errorf("Expected reloaded legacy package tsconfig, got %+v", legacy)
+	}
+	if code, _ := get("/packages/legacy/build/my-element.js"); code != http.StatusOK {
+		t.Errorf("Expected status 200 for reloaded legacy path, got %d", code)
+	}
+	if code, _ := get("/packages/legacy/lib/my-element.js"); code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for old legacy path, got %d", code)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package serve
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// selfSignedValidity is how long generated certificates are valid for
+const selfSignedValidity = 30 * 24 * time.Hour
+
+// selfSignedRenewal is how long before it expires a cached certificate is
+// replaced, so that it doesn't expire while the server runs
+const selfSignedRenewal = 24 * time.Hour
+
+// LocalURL returns the URL of a dev server on this machine
+func LocalURL(tls bool, port int) string {
+	if tls {
+		return fmt.Sprintf("https://localhost:%d", port)
+	}
+	return fmt.Sprintf("http://localhost:%d", port)
+}
+
+// URL returns the server's URL on this machine
+func (s *Server) URL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return LocalURL(s.config.TLS.Enabled, s.port)
+}
+
+// loadTLSConfig returns the server's TLS configuration, with the configured
+// certificate, or else a self-signed certificate for this machine
+func (s *Server) loadTLSConfig() (*tls.Config, error) {
+	var cert tls.Certificate
+	switch {
+	case s.config.TLS.CertFile != "" && s.config.TLS.KeyFile != "":
+		certPEM, err := s.fs.ReadFile(s.config.TLS.CertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS certificate: %w", err)
+		}
+		keyPEM, err := s.fs.ReadFile(s.config.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS key: %w", err)
+		}
+		cert, err = tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS certificate: %w", err)
+		}
+	case s.config.TLS.CertFile != "" || s.config.TLS.KeyFile != "":
+		return nil, fmt.Errorf("TLS certificate and key must be configured together")
+	default:
+		var err error
+		cert, err = s.selfSignedCertificate(localHosts(), time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("generating TLS certificate: %w", err)
+		}
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// localHosts returns the names and addresses this machine is reachable at,
+// so that demos are secure contexts on other devices on the network, too
+func localHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append(hosts, hostname)
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && !ipNet.IP.IsLinkLocalUnicast() {
+				hosts = append(hosts, ipNet.IP.String())
+			}
+		}
+	}
+	return hosts
+}
+
+// selfSignedCertificate returns the self-signed certificate cached by an
+// earlier session, while it is valid for hosts, or else generates one and
+// caches it, so that browsers only ask to trust it once a month
+func (s *Server) selfSignedCertificate(hosts []string, now time.Time) (tls.Certificate, error) {
+	dir := filepath.Join(xdg.CacheHome, "cem", "tls")
+	certPath := filepath.Join(dir, "localhost.pem")
+	keyPath := filepath.Join(dir, "localhost-key.pem")
+	if cert, err := s.cachedCertificate(certPath, keyPath, hosts, now); err == nil {
+		s.logger.Info("Serving HTTPS with the self-signed certificate in %s", certPath)
+		return cert, nil
+	}
+
+	cert, err := generateCertificate(hosts, now)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	if err := s.fs.MkdirAll(dir, 0o700); err != nil {
+		s.logger.Warning("Could not cache the TLS certificate: %v", err)
+	} else if err := s.fs.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		s.logger.Warning("Could not cache the TLS certificate: %v", err)
+	} else if err := s.fs.WriteFile(certPath, certPEM, 0o644); err != nil {
+		s.logger.Warning("Could not cache the TLS certificate: %v", err)
+	}
+	s.logger.Warning("Serving HTTPS with a new self-signed certificate, which browsers will ask you to trust")
+	return cert, nil
+}
+
+// cachedCertificate loads the cached certificate, unless it expires within
+// selfSignedRenewal of now, or doesn't cover every one of hosts
+func (s *Server) cachedCertificate(certPath, keyPath string, hosts []string, now time.Time) (tls.Certificate, error) {
+	certPEM, err := s.fs.ReadFile(certPath)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := s.fs.ReadFile(keyPath)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if now.Add(selfSignedRenewal).After(cert.Leaf.NotAfter) {
+		return tls.Certificate{}, fmt.Errorf("certificate expires at %s", cert.Leaf.NotAfter)
+	}
+	for _, host := range hosts {
+		if err := cert.Leaf.VerifyHostname(host); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	return cert, nil
+}
+
+// generateCertificate generates a certificate for hosts, which are names
+// or IP addresses, valid from now
+func generateCertificate(hosts []string, now time.Time) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"cem dev server"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
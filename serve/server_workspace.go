@@ -21,14 +21,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"bennypowers.dev/cem/cmd/config"
 	G "bennypowers.dev/cem/generate"
 	C "bennypowers.dev/cem/internal/config"
 	W "bennypowers.dev/cem/internal/workspace"
 	"bennypowers.dev/cem/serve/middleware"
 	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
 	"bennypowers.dev/cem/serve/middleware/routes"
+	"bennypowers.dev/cem/serve/middleware/transform"
 )
 
 // contextWithShutdown creates a context that is cancelled when either:
@@ -65,13 +69,6 @@ func (s *Server) WorkspacePackages() []middleware.WorkspacePackage {
 	return s.workspacePackages
 }
 
-// DemoRoutes returns the pre-computed demo routing table (both workspace and single-package mode)
-func (s *Server) DemoRoutes() map[string]*middleware.DemoRouteEntry {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.demoRoutes
-}
-
 // SourceControlRootURL returns the source control root URL for demo routing
 func (s *Server) SourceControlRootURL() string {
 	s.mu.RLock()
@@ -86,8 +83,63 @@ func (s *Server) DemoURLPrefix() string {
 	return s.demoURLPrefix
 }
 
+// PackageTransformSettings returns the TypeScript transform settings of the
+// workspace package containing sourcePath, or nil outside workspace mode
+func (s *Server) PackageTransformSettings(sourcePath string) *transform.PackageSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return transform.FindPackageSettings(s.packageTransforms, sourcePath)
+}
+
+// resolveWorkspaceSpecifier resolves a bare import specifier, via the import
+// map, to a source file in one of the workspace packages. The transform cache
+// records it as a dependency, so that editing one package invalidates the
+// modules of sibling packages which import it.
+// Returns "" outside workspace mode, or when the specifier resolves elsewhere.
+func (s *Server) resolveWorkspaceSpecifier(specifier string) string {
+	s.mu.RLock()
+	isWorkspace := s.isWorkspace
+	watchDir := s.watchDir
+	packages := s.workspacePackages
+	pathResolver := s.pathResolver
+	s.mu.RUnlock()
+
+	if !isWorkspace || watchDir == "" {
+		return ""
+	}
+
+	for _, requestPath := range s.resolveImportToPath(specifier, "") {
+		// Prefer the TypeScript source, since that's what the watcher reports
+		if pathResolver != nil {
+			if tsPath := pathResolver.ResolveTsSource(requestPath); tsPath != "" {
+				requestPath = tsPath
+			}
+		}
+		fsPath := filepath.Join(watchDir, filepath.FromSlash(strings.TrimPrefix(requestPath, "/")))
+		for _, pkg := range packages {
+			rel, err := filepath.Rel(pkg.Path, fsPath)
+			if err != nil || strings.HasPrefix(rel, "..") || strings.Contains(filepath.ToSlash(rel), "node_modules/") {
+				continue
+			}
+			return fsPath
+		}
+	}
+	return ""
+}
+
 // InitializeWorkspaceMode detects and initializes workspace mode if applicable
 func (s *Server) InitializeWorkspaceMode() error {
+	if err := s.initializeWorkspaceMode(); err != nil {
+		return err
+	}
+	if s.IsWorkspace() {
+		// Pick up the path resolver built with per-package tsconfigs
+		s.setupMiddleware()
+	}
+	return nil
+}
+
+func (s *Server) initializeWorkspaceMode() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -95,6 +147,12 @@ func (s *Server) InitializeWorkspaceMode() error {
 		return fmt.Errorf("no watch directory set")
 	}
 
+	// A pinned manifest is served as a single package, even in a workspace
+	if s.config.ManifestFile != "" {
+		s.isWorkspace = false
+		return nil
+	}
+
 	// Check if this is a workspace
 	if !W.IsWorkspaceMode(s.watchDir, s.fs) {
 		s.isWorkspace = false
@@ -124,21 +182,13 @@ func (s *Server) InitializeWorkspaceMode() error {
 	s.workspaceRoot = s.watchDir
 	s.workspacePackages = packages
 
-	// Build routing table from workspace packages
-	pkgContexts := make([]routes.PackageContext, len(packages))
-	for i, pkg := range packages {
-		pkgContexts[i] = routes.PackageContext{
-			Name:     pkg.Name,
-			Path:     pkg.Path,
-			Manifest: pkg.Manifest,
-		}
-	}
-	workspaceRoutingTable, err := routes.BuildWorkspaceRoutingTable(pkgContexts, s.demoURLPrefix)
-	if err != nil {
-		return fmt.Errorf("building workspace routing table: %w", err)
-	}
-	s.demoRoutes = workspaceRoutingTable
-	s.logger.Debug("Built routing table with %d demo routes", len(workspaceRoutingTable))
+	// Build the routing table when a page is first requested, so that large
+	// workspaces start serving without parsing every package's demos
+	s.deferDemoRoutes(workspaceRoutesBuilder(packages, s.demoURLPrefix))
+
+	// Now that packages are known, add their tsconfigs and transform targets
+	// to path resolution
+	s.buildPathResolver()
 
 	// Generate workspace import map using middleware package
 	if s.config.ImportMap.Generate {
@@ -154,6 +204,7 @@ func (s *Server) InitializeWorkspaceMode() error {
 			s.importMap = nil
 		} else {
 			s.importMap = importMap
+			s.pinImportMap(importMap)
 			s.logger.Info("Generated workspace import map")
 		}
 	} else {
@@ -163,6 +214,70 @@ func (s *Server) InitializeWorkspaceMode() error {
 	return nil
 }
 
+// workspaceRoutesBuilder builds the routing table of the given workspace
+// packages. Package routing errors are returned along with the routes of
+// the other packages.
+func workspaceRoutesBuilder(packages []middleware.WorkspacePackage, demoURLPrefix string) demoRoutesBuilder {
+	return func() (map[string]*middleware.DemoRouteEntry, error) {
+		pkgContexts := make([]routes.PackageContext, len(packages))
+		for i, pkg := range packages {
+			pkgContexts[i] = routes.PackageContext{
+				Name:     pkg.Name,
+				Path:     pkg.Path,
+				Manifest: pkg.Manifest,
+			}
+		}
+		return routes.BuildWorkspaceRoutingTable(pkgContexts, demoURLPrefix)
+	}
+}
+
+// loadPackageTransforms reads each workspace package's tsconfig and transform
+// target, returning the settings, URL rewrites for each package's
+// rootDir/outDir, and the files read (for hot-reload tracking).
+// Must be called with s.mu held.
+func (s *Server) loadPackageTransforms() ([]transform.PackageSettings, []config.URLRewrite, []string) {
+	var settings []transform.PackageSettings
+	var rewrites []config.URLRewrite
+	var sourceFiles []string
+
+	for _, pkg := range s.workspacePackages {
+		rel, err := filepath.Rel(s.watchDir, pkg.Path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		urlPrefix := "/" + filepath.ToSlash(rel)
+		if rel == "." {
+			urlPrefix = ""
+		}
+
+		pkgSettings, pkgRewrites, pkgSourceFiles := transform.LoadPackageSettings(pkg.Path, urlPrefix, s.fs)
+		pkgSettings.Target = s.packageTransformTarget(pkg.Path)
+		if pkgSettings.TsconfigRaw != "" || pkgSettings.Target != "" {
+			s.logger.Debug("Using package transform settings for %s (target: %q)", pkg.Name, pkgSettings.Target)
+		}
+
+		settings = append(settings, pkgSettings)
+		rewrites = append(rewrites, pkgRewrites...)
+		sourceFiles = append(sourceFiles, pkgSourceFiles...)
+	}
+
+	return settings, rewrites, sourceFiles
+}
+
+// packageTransformTarget reads the TypeScript transform target from a
+// workspace package's own config, returning "" when it has none
+func (s *Server) packageTransformTarget(pkgPath string) string {
+	pkgCtx := W.NewFileSystemWorkspaceContext(pkgPath, W.WithFileSystem(s.fs))
+	if err := pkgCtx.Init(); err != nil {
+		return ""
+	}
+	cfg, err := pkgCtx.Config()
+	if err != nil || cfg == nil {
+		return ""
+	}
+	return cfg.Serve.Transforms.TypeScript.Target
+}
+
 // loadWorkspaceRootConfig loads and caches the root workspace config.
 // Returns nil if watchDir is empty or config cannot be loaded.
 func (s *Server) loadWorkspaceRootConfig() *C.CemConfig {
@@ -367,35 +482,19 @@ func (s *Server) regenerateAffectedWorkspacePackages(changedFiles []string) (int
 		return 0, fmt.Errorf("failed to regenerate any package manifests")
 	}
 
-	// Build routing table from updated packages
-	pkgContexts := make([]routes.PackageContext, len(packages))
-	for i, pkg := range packages {
-		pkgContexts[i] = routes.PackageContext{
-			Name:     pkg.Name,
-			Path:     pkg.Path,
-			Manifest: pkg.Manifest,
-		}
-	}
-
-	workspaceRoutingTable, err := routes.BuildWorkspaceRoutingTable(pkgContexts, demoPrefix)
-	if err != nil {
-		s.logger.Warning("Failed to build workspace routing table: %v", err)
-	}
-
-	// Update server state under write lock
+	// Update server state under write lock. The routing table is rebuilt
+	// when a page is next requested.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.workspacePackages = packages
-	s.demoRoutes = workspaceRoutingTable
+	s.deferDemoRoutes(workspaceRoutesBuilder(packages, demoPrefix))
 
 	preserved := len(packages) - len(affectedPkgInfos)
 	if preserved > 0 {
-		s.logger.Debug("Regenerated %d package(s), preserved %d cached, routing table has %d routes",
-			len(affectedPkgInfos), preserved, len(workspaceRoutingTable))
+		s.logger.Debug("Regenerated %d package(s), preserved %d cached", len(affectedPkgInfos), preserved)
 	} else {
-		s.logger.Debug("Regenerated %d package(s), routing table has %d routes",
-			len(affectedPkgInfos), len(workspaceRoutingTable))
+		s.logger.Debug("Regenerated %d package(s)", len(affectedPkgInfos))
 	}
 
 	return totalSize, nil
@@ -0,0 +1,249 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package servetest runs cem's development server in-process, for
+// integration tests of projects which embed it. The server reads an
+// in-memory filesystem, never binds a socket or watches files, and records
+// what it would send to live-reload clients, so tests are fast and
+// deterministic.
+//
+//	srv := servetest.New(t, servetest.Options{
+//		Files: map[string]string{
+//			"package.json":       `{"name": "my-elements"}`,
+//			"demo/index.html":    `<my-element></my-element>`,
+//			"src/my-element.ts":  `export class MyElement extends HTMLElement {}`,
+//		},
+//		Manifest: manifest,
+//	})
+//	res := srv.Get("/demo/")
+package servetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+
+	"bennypowers.dev/cem/internal/platform"
+	"bennypowers.dev/cem/serve"
+	"bennypowers.dev/cem/serve/middleware/transform"
+)
+
+// Root is the directory which holds the server's files
+const Root = "/project"
+
+// Port is the port the server reports, when Options.Config sets none. The
+// server never listens on it.
+const Port = 8000
+
+// Options configure a test server
+type Options struct {
+	// Files maps paths, relative to Root, to their contents
+	Files map[string]string
+	// Manifest, when set, is the custom elements manifest the server starts
+	// with, as with `cem serve --manifest`
+	Manifest []byte
+	// Config configures the server. Its filesystem and WebSocket manager are
+	// replaced, and live reload is always enabled. Defaults to DefaultConfig.
+	Config *serve.Config
+}
+
+// DefaultConfig returns the configuration `cem serve` uses when its config
+// file sets nothing: TypeScript and CSS transforms are enabled, targeting
+// ES2022.
+func DefaultConfig() serve.Config {
+	return serve.Config{
+		Port: Port,
+		Transforms: serve.TransformConfig{
+			TypeScript: serve.TypeScriptConfig{Enabled: true, Target: transform.ES2022},
+			CSS:        serve.CSSConfig{Enabled: true},
+		},
+	}
+}
+
+// Server is a development server running the full middleware pipeline
+// in-process. It is closed when the test ends.
+type Server struct {
+	*serve.Server
+	t  testing.TB
+	fs *platform.MapFileSystem
+	ws *recordingWebSocketManager
+}
+
+// New starts a test server with opts. It fails the test if the server
+// can't start.
+func New(t testing.TB, opts Options) *Server {
+	t.Helper()
+
+	config := DefaultConfig()
+	if opts.Config != nil {
+		config = *opts.Config
+		if config.Port == 0 {
+			config.Port = Port
+		}
+	}
+
+	mfs := platform.NewMapFileSystem(nil)
+	for name, content := range opts.Files {
+		mfs.AddFile(path.Join(Root, name), content, 0644)
+	}
+
+	ws := &recordingWebSocketManager{}
+	config.FS = mfs
+	config.Reload = true
+	config.WebSocketManager = ws
+
+	server, err := serve.NewServerWithConfig(config)
+	if err != nil {
+		t.Fatalf("servetest: creating server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("servetest: closing server: %v", err)
+		}
+	})
+
+	if err := server.SetWatchDir(Root); err != nil {
+		t.Fatalf("servetest: setting watch dir: %v", err)
+	}
+
+	s := &Server{Server: server, t: t, fs: mfs, ws: ws}
+	if opts.Manifest != nil {
+		s.InjectManifest(opts.Manifest)
+	}
+	return s
+}
+
+// InjectManifest replaces the server's manifest and rebuilds its demo
+// routes before returning. It fails the test if the manifest is invalid.
+func (s *Server) InjectManifest(manifest []byte) {
+	s.t.Helper()
+	if err := s.SetManifest(manifest); err != nil {
+		s.t.Fatalf("servetest: injecting manifest: %v", err)
+	}
+}
+
+// Do serves req through the server's middleware pipeline
+func (s *Server) Do(req *http.Request) *http.Response {
+	s.t.Helper()
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+// Get serves a GET request for target, a path with an optional query
+func (s *Server) Get(target string) *http.Response {
+	s.t.Helper()
+	return s.Do(httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+// WriteFile creates or replaces the file at name, relative to Root, then
+// handles the change as the file watcher would: caches are invalidated, the
+// manifest is regenerated when needed, and reload messages are recorded,
+// all before it returns.
+func (s *Server) WriteFile(name, content string) {
+	s.t.Helper()
+	eventType := "modify"
+	if _, err := s.fs.Stat(path.Join(Root, name)); err != nil {
+		eventType = "create"
+	}
+	s.fs.AddFile(path.Join(Root, name), content, 0644)
+	s.Change(eventType, name)
+}
+
+// RemoveFile deletes the file at name, relative to Root, then handles the
+// deletion as the file watcher would, before it returns
+func (s *Server) RemoveFile(name string) {
+	s.t.Helper()
+	if err := s.fs.Remove(path.Join(Root, name)); err != nil {
+		s.t.Fatalf("servetest: removing %s: %v", name, err)
+	}
+	s.Change("delete", name)
+}
+
+// Change handles changes to the named files, relative to Root, as one
+// batch, as the file watcher reports the changes of one debounce window.
+// It leaves the files as they are, so tests can change several with
+// Files before reporting them together. eventType is create, delete, or
+// modify.
+func (s *Server) Change(eventType string, names ...string) {
+	s.t.Helper()
+	if err := s.HandleFileChanges(eventType, names); err != nil {
+		s.t.Fatalf("servetest: changing files: %v", err)
+	}
+}
+
+// Files returns the in-memory filesystem the server reads
+func (s *Server) Files() *platform.MapFileSystem {
+	return s.fs
+}
+
+// Messages returns the messages the server has sent to live-reload
+// clients, oldest first
+func (s *Server) Messages() [][]byte {
+	return s.ws.messages()
+}
+
+// ResetMessages forgets the messages recorded so far, so that Messages
+// returns only those which follow
+func (s *Server) ResetMessages() {
+	s.ws.reset()
+}
+
+// recordingWebSocketManager records broadcasts instead of sending them
+type recordingWebSocketManager struct {
+	mu        sync.Mutex
+	broadcast [][]byte
+}
+
+func (m *recordingWebSocketManager) ConnectionCount() int           { return 0 }
+func (m *recordingWebSocketManager) BroadcastShutdown() error       { return nil }
+func (m *recordingWebSocketManager) CloseAll() error                { return nil }
+func (m *recordingWebSocketManager) SetLogger(serve.Logger)         {}
+func (m *recordingWebSocketManager) Broadcast(message []byte) error { return m.record(message) }
+
+func (m *recordingWebSocketManager) BroadcastToPages(message []byte, _ []string) error {
+	return m.record(message)
+}
+
+func (m *recordingWebSocketManager) HandleConnection(w http.ResponseWriter, _ *http.Request) {
+	http.Error(w, "servetest: live reload connections are not supported", http.StatusNotImplemented)
+}
+
+func (m *recordingWebSocketManager) record(message []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.broadcast = append(m.broadcast, append([]byte(nil), message...))
+	return nil
+}
+
+func (m *recordingWebSocketManager) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.broadcast = nil
+}
+
+func (m *recordingWebSocketManager) messages() [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	messages := make([][]byte, len(m.broadcast))
+	for i, message := range m.broadcast {
+		messages[i] = append([]byte(nil), message...)
+	}
+	return messages
+}
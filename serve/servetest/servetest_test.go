@@ -0,0 +1,201 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package servetest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bennypowers.dev/cem/serve"
+
+	"bennypowers.dev/cem/serve/servetest"
+)
+
+const manifest = `{
+  "schemaVersion": "1.0.0",
+  "modules": [{
+    "kind": "javascript-module",
+    "path": "src/my-element.ts",
+    "declarations": [{
+      "kind": "class",
+      "customElement": true,
+      "name": "MyElement",
+      "tagName": "my-element",
+      "demos": [{"description": "Basic", "url": "./demo/basic.html"}]
+    }]
+  }]
+}`
+
+func body(t *testing.T, res *http.Response) string {
+	t.Helper()
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", res.StatusCode)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	return string(data)
+}
+
+func TestServer_ServesInjectedManifestDemos(t *testing.T) {
+	srv := servetest.New(t, servetest.Options{
+		Files: map[string]string{
+			"package.json":    `{"name": "my-elements"}`,
+			"demo/basic.html": `<my-element>Hello World</my-element>`,
+		},
+		Manifest: []byte(manifest),
+	})
+
+	if srv.Port() != servetest.Port {
+		t.Errorf("Expected port %d, got %d", servetest.Port, srv.Port())
+	}
+
+	demo := body(t, srv.Get("/demo/basic.html"))
+	if !strings.Contains(demo, "<cem-serve-chrome") {
+		t.Error("Expected the demo to render in the dev server chrome")
+	}
+	if !strings.Contains(demo, "Hello World") {
+		t.Error("Expected the demo's content")
+	}
+}
+
+func TestServer_WriteFile(t *testing.T) {
+	srv := servetest.New(t, servetest.Options{
+		Files: map[string]string{
+			"package.json":      `{"name": "my-elements"}`,
+			"src/my-element.ts": `export const greeting: string = "hello";`,
+		},
+	})
+
+	before := body(t, srv.Get("/src/my-element.js"))
+	if !strings.Contains(before, `"hello"`) || strings.Contains(before, ": string") {
+		t.Errorf("Expected transformed TypeScript, got:\n%s", before)
+	}
+
+	srv.WriteFile("src/my-element.ts", `export const greeting: string = "goodbye";`)
+
+	after := body(t, srv.Get("/src/my-element.js"))
+	if !strings.Contains(after, `"goodbye"`) {
+		t.Errorf("Expected the rewritten file, got:\n%s", after)
+	}
+}
+
+// reloadMessages decodes the recorded live-reload messages
+func reloadMessages(t *testing.T, srv *servetest.Server) []serve.ReloadMessage {
+	t.Helper()
+	var messages []serve.ReloadMessage
+	for _, data := range srv.Messages() {
+		var msg serve.ReloadMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("Failed to decode message %s: %v", data, err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func TestServer_Change(t *testing.T) {
+	srv := servetest.New(t, servetest.Options{
+		Files: map[string]string{
+			"package.json": `{"name": "my-elements"}`,
+			"src/a.js":     `export const a = 1;`,
+			"src/b.js":     `export const b = 1;`,
+		},
+	})
+
+	srv.Files().AddFile(servetest.Root+"/src/a.js", `export const a = 2;`, 0644)
+	srv.Files().AddFile(servetest.Root+"/src/b.js", `export const b = 2;`, 0644)
+	srv.Change("modify", "src/a.js", "src/b.js")
+
+	messages := reloadMessages(t, srv)
+	if len(messages) != 1 {
+		t.Fatalf("Expected one reload for the batch, got %d: %+v", len(messages), messages)
+	}
+	if messages[0].Reason != "file-change-fallback" {
+		t.Errorf("Expected a fallback reload without demo routes, got %q", messages[0].Reason)
+	}
+
+	srv.ResetMessages()
+	if got := srv.Messages(); len(got) != 0 {
+		t.Errorf("Expected no messages after reset, got %d", len(got))
+	}
+
+	srv.RemoveFile("src/b.js")
+	messages = reloadMessages(t, srv)
+	if len(messages) != 1 || messages[0].Reason != "file-structure-change" {
+		t.Errorf("Expected a file-structure-change reload after removing a module, got %+v", messages)
+	}
+}
+
+func TestServer_HandleFileChangesRejectsOutsidePaths(t *testing.T) {
+	srv := servetest.New(t, servetest.Options{})
+	if err := srv.HandleFileChanges("modify", []string{"../outside.js"}); err == nil {
+		t.Error("Expected an error for a file outside the watch directory")
+	}
+	if err := srv.HandleFileChanges("rename", []string{"src/a.js"}); err == nil {
+		t.Error("Expected an error for an unknown event type")
+	}
+	if got := srv.Messages(); len(got) != 0 {
+		t.Errorf("Expected rejected changes not to reload, got %d messages", len(got))
+	}
+}
+
+func TestServer_FileChangesAPI(t *testing.T) {
+	srv := servetest.New(t, servetest.Options{
+		Files: map[string]string{
+			"package.json":     `{"name": "my-elements"}`,
+			"src/generated.js": `export const tokens = {};`,
+		},
+	})
+
+	post := func(contentType, body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/__cem/api/changes", strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		return srv.Do(req)
+	}
+
+	if res := post("text/plain", `{"files": ["src/generated.js"]}`); res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected status 415 without a JSON content type, got %d", res.StatusCode)
+	}
+	if res := post("application/json", `{"files": ["../outside.js"]}`); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a file outside the project, got %d", res.StatusCode)
+	}
+	if res := srv.Get("/__cem/api/changes"); res.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for GET, got %d", res.StatusCode)
+	}
+	if got := srv.Messages(); len(got) != 0 {
+		t.Fatalf("Expected rejected requests not to reload, got %d messages", len(got))
+	}
+
+	res := post("application/json", `{"files": ["src/generated.js"], "type": "modify"}`)
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", res.StatusCode)
+	}
+	messages := reloadMessages(t, srv)
+	if len(messages) != 1 || len(messages[0].Files) != 1 || messages[0].Files[0] != "src/generated.js" {
+		t.Errorf("Expected a reload for src/generated.js, got %+v", messages)
+	}
+}
@@ -18,11 +18,17 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package serve
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"slices"
 	"testing"
 
 	"bennypowers.dev/cem/internal/platform/testutil"
+	"bennypowers.dev/cem/serve/middleware"
+	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
+	"bennypowers.dev/cem/serve/middleware/types"
 )
 
 // TestGetAffectedPageURLs_ImportedFileChange tests that when an imported file changes,
@@ -110,3 +116,134 @@ func TestGetAffectedPageURLs_HTMLFileChange(t *testing.T) {
 		t.Errorf("Expected affected pages to include %s, got: %v", expectedRoute, affectedPages)
 	}
 }
+
+// TestCrossPackageReload_WorkspaceSibling tests that in workspace mode, when
+// package a's module imports package b's source via the import map, a change
+// in package b invalidates a's transformed module and reloads a's demo
+func TestCrossPackageReload_WorkspaceSibling(t *testing.T) {
+	mfs := testutil.NewFixtureFS(t, "smart-reload-workspace", "/test")
+
+	server, err := NewServerWithConfig(Config{
+		Port:   0,
+		Reload: true,
+		FS:     mfs,
+		Transforms: TransformConfig{
+			TypeScript: TypeScriptConfig{Enabled: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer func() { _ = server.Close() }()
+
+	if err := server.SetWatchDir("/test"); err != nil {
+		t.Fatalf("Failed to set watch dir: %v", err)
+	}
+
+	// Stand in for workspace discovery and import map generation
+	server.mu.Lock()
+	server.isWorkspace = true
+	server.workspacePackages = []middleware.WorkspacePackage{
+		{Name: "@test/a", Path: "/test/packages/a"},
+		{Name: "@test/b", Path: "/test/packages/b"},
+	}
+	server.importMap = &importmappkg.ImportMap{Imports: map[string]string{
+		"@test/a/": "/packages/a/",
+		"@test/b/": "/packages/b/",
+	}}
+	server.demoRoutes = map[string]*types.DemoRouteEntry{
+		"/packages/a/demo/": {
+			LocalRoute:  "/packages/a/demo/",
+			TagName:     "a-element",
+			FilePath:    "demo/index.html",
+			PackageName: "@test/a",
+			PackagePath: "/test/packages/a",
+		},
+	}
+	server.mu.Unlock()
+
+	if got := server.resolveWorkspaceSpecifier("@test/b/b-element.js"); got != "/test/packages/b/b-element.ts" {
+		t.Errorf("Expected sibling specifier to resolve to its source, got %q", got)
+	}
+	if got := server.resolveWorkspaceSpecifier("lit"); got != "" {
+		t.Errorf("Expected unmapped specifier not to resolve, got %q", got)
+	}
+
+	// Serving a's module records its dependency on b's source
+	req := httptest.NewRequest("GET", "/packages/a/a-element.js", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	changedFile := "/test/packages/b/b-element.ts"
+	invalidated := server.collectAffectedFiles(changedFile)
+	if !slices.Contains(invalidated, "/test/packages/a/a-element.ts") {
+		t.Fatalf("Expected change in package b to invalidate package a's module, got: %v", invalidated)
+	}
+
+	affectedPages := server.getAffectedPageURLs(changedFile, invalidated)
+	if !slices.Contains(affectedPages, "/packages/a/demo/") {
+		t.Errorf("Expected package a's demo to be affected, got: %v", affectedPages)
+	}
+}
+
+// TestIsCSSOnlyChange tests that only changes to stylesheets alone are
+// swapped in place
+func TestIsCSSOnlyChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  bool
+	}{
+		{"single stylesheet", []string{"/test/src/button.css"}, true},
+		{"several stylesheets", []string{"/test/src/button.css", "/test/src/card.css"}, true},
+		{"stylesheet and module", []string{"/test/src/button.css", "/test/src/button.ts"}, false},
+		{"demo page", []string{"/test/demo/index.html"}, false},
+		{"no files", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCSSOnlyChange(tt.files); got != tt.want {
+				t.Errorf("isCSSOnlyChange(%v) = %v, want %v", tt.files, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBroadcastCSSUpdate tests that CSS-only changes send a css-update
+// message with the URL paths of the changed stylesheets, rather than a reload
+func TestBroadcastCSSUpdate(t *testing.T) {
+	var messages [][]byte
+	server := &Server{
+		logger: &testLogger{
+			warningFn: func(string, ...any) {},
+			debugFn:   func(string, ...any) {},
+		},
+		watchDir: "/test",
+		wsManager: &testWSManager{
+			broadcastFn: func(msg []byte) error {
+				messages = append(messages, msg)
+				return nil
+			},
+		},
+	}
+
+	changed := []string{"/test/src/button.css", "/test/src/card.css"}
+	server.broadcastCSSUpdate(changed[0], changed, nil)
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 broadcast, got %d", len(messages))
+	}
+	var msg CSSUpdateMessage
+	if err := json.Unmarshal(messages[0], &msg); err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+	if msg.Type != "css-update" {
+		t.Errorf("Expected message type css-update, got %q", msg.Type)
+	}
+	if want := []string{"/src/button.css", "/src/card.css"}; !slices.Equal(msg.Files, want) {
+		t.Errorf("Expected files %v, got %v", want, msg.Files)
+	}
+}
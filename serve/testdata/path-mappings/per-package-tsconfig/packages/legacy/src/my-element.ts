@@ -0,0 +1,6 @@
+declare function property(options: { type: unknown }): any;
+
+export class MyElement {
+  @property({ type: String })
+  name: string = 'default';
+}
@@ -0,0 +1,9 @@
+export class MyLegacyElement extends HTMLElement {
+  #count = 0;
+
+  get count() {
+    return this.#count;
+  }
+}
+
+customElements.define('my-legacy', MyLegacyElement);
@@ -0,0 +1,4 @@
+import '@test/b/b-element.js';
+
+export class AElement extends HTMLElement {}
+customElements.define('a-element', AElement);
@@ -0,0 +1,2 @@
+export class BElement extends HTMLElement {}
+customElements.define('b-element', BElement);
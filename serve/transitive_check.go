@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"bennypowers.dev/cem/internal/modulegraph"
 	importmappkg "bennypowers.dev/cem/serve/middleware/importmap"
 )
 
@@ -106,20 +107,11 @@ func isResolvedInImportMap(specifier string, im *importmappkg.ImportMap) bool {
 	if im == nil || len(im.Imports) == 0 {
 		return false
 	}
-
-	// Exact match
-	if _, ok := im.Imports[specifier]; ok {
-		return true
-	}
-
-	// Prefix match: find entries ending with "/" that match the specifier prefix
-	for key := range im.Imports {
-		if strings.HasSuffix(key, "/") && strings.HasPrefix(specifier, key) {
-			return true
-		}
-	}
-
-	return false
+	_, ok := modulegraph.ResolveImportMapSpecifier(&modulegraph.ImportMap{
+		Imports: im.Imports,
+		Scopes:  im.Scopes,
+	}, specifier, "")
+	return ok
 }
 
 // formatTransitiveWarning formats a user-facing warning message for an unresolved
@@ -49,12 +49,32 @@ type CSSConfig struct {
 
 // DemosConfig holds demo rendering configuration
 type DemosConfig struct {
-	Rendering string // Default rendering mode: "light", "shadow", or "iframe"
+	Rendering          string            // Default rendering mode: "light", "shadow", or "iframe"
+	Env                map[string]string // Variables exposed to demos as window.__CEM_ENV__ and {{env.NAME}} placeholders
+	ScopedElementsFile string            // JSON file mapping scoped registry tag names to global ones for SSR, relative to the watch dir
+	ThemeToggle        ThemeToggleConfig // Theme toggle for demo pages outside the dev server chrome
+}
+
+// ThemeToggleConfig holds demo theme toggle configuration. The toggle is
+// shown when either field is set.
+type ThemeToggleConfig struct {
+	Attribute string // Attribute on <html> set to "light" or "dark"
+	Class     string // Class on <html> present in the dark theme
+}
+
+// TLSConfig holds the dev server's HTTPS configuration. When enabled
+// without a certificate, the server generates a self-signed one.
+type TLSConfig struct {
+	Enabled  bool
+	CertFile string // PEM certificate file
+	KeyFile  string // PEM private key file for CertFile
 }
 
 // Config represents the dev server configuration
 type Config struct {
 	Port                 int
+	AutoPort             bool      // Serve on the next free port when Port is taken
+	TLS                  TLSConfig // Serve HTTPS, e.g. for APIs which need a secure context
 	Reload               bool
 	Target               transform.Target      // Transform target (default: ES2022) - deprecated, use Transforms.TypeScript.Target
 	Transforms           TransformConfig       // Transform configuration
@@ -67,6 +87,8 @@ type Config struct {
 	FS                   platform.FileSystem   // Optional filesystem for testing (defaults to os package)
 	URLRewrites          []config.URLRewrite   // URL rewrites for request path mapping (e.g., "/dist/:path*" -> "/src/{{.path}}")
 	WebSocketManager     WebSocketManager      // Optional WebSocket manager for testing (created automatically if nil and Reload=true)
+	OTLPEndpoint         string                // OpenTelemetry collector to export request traces to (e.g., "http://localhost:4318")
+	ManifestFile         string                // Pre-built manifest to serve instead of generating one
 	Logger               Logger                // Optional logger (defaults to defaultLogger)
 }
 
@@ -77,6 +99,13 @@ type ReloadMessage struct {
 	Files  []string `json:"files"`
 }
 
+// CSSUpdateMessage tells clients to swap the stylesheets for Files, URL
+// paths of changed CSS files, in place instead of reloading the page
+type CSSUpdateMessage struct {
+	Type  string   `json:"type"`
+	Files []string `json:"files"`
+}
+
 // LogMessage represents a WebSocket log update
 type LogMessage struct {
 	Type string   `json:"type"`
@@ -85,10 +114,20 @@ type LogMessage struct {
 
 // ErrorMessage represents a WebSocket error notification (e.g., transform errors)
 type ErrorMessage struct {
-	Type    string `json:"type"`
-	Title   string `json:"title"`
-	Message string `json:"message"`
-	File    string `json:"file,omitempty"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`      // 1-based line of the error in File
+	Column    int    `json:"column,omitempty"`    // 0-based column of the error in File
+	CodeFrame string `json:"codeFrame,omitempty"` // Source lines around the error
+}
+
+// ErrorClearedMessage tells clients that File, which previously failed to
+// transform, now transforms successfully
+type ErrorClearedMessage struct {
+	Type string `json:"type"`
+	File string `json:"file"`
 }
 
 // Logger is a type alias for the logger.Logger interface
@@ -124,3 +163,9 @@ type FileEvent struct {
 	HasPackageJSON bool     // True if package.json was modified
 	Timestamp      time.Time
 }
+
+// FileChange is one file's change in a FileEvent
+type FileChange struct {
+	Path      string // Absolute path of the changed file
+	EventType string // create, delete, or modify
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2026 Benny Powers <web@bennypowers.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package validate
+
+import (
+	"fmt"
+	"slices"
+)
+
+// checkSemantics reports errors which the schema can't express: tag names
+// defined by more than one element, superclasses which reference a
+// declaration the manifest doesn't have, and attributes which reflect a
+// field the element doesn't have
+func (p *ValidationPipeline) checkSemantics() []ValidationError {
+	var errors []ValidationError
+	declared := make(map[string]map[string]RawDeclaration)
+	for _, module := range p.navigator.modules {
+		decls, _ := module.Declarations()
+		byName := make(map[string]RawDeclaration, len(decls))
+		for _, decl := range decls {
+			byName[decl.Name()] = decl
+		}
+		declared[module.Path()] = byName
+	}
+
+	tagNames := make(map[string]string)
+	for i, module := range p.navigator.modules {
+		decls, _ := module.Declarations()
+		for j, decl := range decls {
+			location := fmt.Sprintf("/modules/%d/declarations/%d", i, j)
+			declaration := formatDeclaration(decl, j)
+
+			if tagName := decl.TagName(); tagName != "" && decl.IsCustomElement() {
+				definer := fmt.Sprintf("%s in %s", declaration, module.Path())
+				if previous, ok := tagNames[tagName]; ok {
+					errors = append(errors, ValidationError{
+						ID:          "semantic-duplicate-tag-name",
+						Module:      module.Path(),
+						Declaration: declaration,
+						Message:     fmt.Sprintf("tag name %s is already defined by %s", tagName, previous),
+						Location:    location + "/tagName",
+					})
+				} else {
+					tagNames[tagName] = definer
+				}
+			}
+
+			if superclass, ok := decl.Superclass(); ok && superclass.Package() == "" {
+				decls, local := declared[superclass.Module()]
+				if _, ok := decls[superclass.Name()]; local && !ok {
+					errors = append(errors, ValidationError{
+						ID:          "semantic-dangling-superclass",
+						Module:      module.Path(),
+						Declaration: declaration,
+						Message: fmt.Sprintf("superclass %s is not declared in module %s",
+							superclass.Name(), superclass.Module()),
+						Location: location + "/superclass",
+					})
+				}
+			}
+
+			errors = append(errors, missingAttributeFields(declared, decl, module.Path(), declaration, location)...)
+		}
+	}
+	return errors
+}
+
+// missingAttributeFields reports attributes whose fieldName names no field
+// of the declaration, nor of the superclasses this manifest declares.
+// Inherited attributes are skipped, since their fields belong to the
+// declaration they're inherited from.
+func missingAttributeFields(
+	declared map[string]map[string]RawDeclaration,
+	decl RawDeclaration,
+	modulePath, declaration, location string,
+) []ValidationError {
+	attrs, ok := decl.Attributes()
+	if !ok {
+		return nil
+	}
+	var errors []ValidationError
+	for k, attr := range attrs {
+		fieldName, _ := attr["fieldName"].(string)
+		if fieldName == "" || attr["inheritedFrom"] != nil {
+			continue
+		}
+		if hasField(declared, decl, fieldName) {
+			continue
+		}
+		errors = append(errors, ValidationError{
+			ID:          "semantic-missing-attribute-field",
+			Module:      modulePath,
+			Declaration: declaration,
+			Property:    formatProperty(attr, "attributes", k),
+			Message:     fmt.Sprintf("attribute %s reflects field %s, which %s does not declare", attr.Name(), fieldName, declaration),
+			Location:    fmt.Sprintf("%s/attributes/%d/fieldName", location, k),
+		})
+	}
+	return errors
+}
+
+// hasField reports whether the declaration, or one of its superclasses in
+// this manifest, declares the named field. A superclass from another
+// package, or from a module the manifest doesn't list, might declare any
+// field.
+func hasField(declared map[string]map[string]RawDeclaration, decl RawDeclaration, name string) bool {
+	seen := make(map[string]bool)
+	for {
+		members, _ := decl.Members()
+		if slices.ContainsFunc(members, func(m RawMember) bool {
+			return m.Kind() == "field" && m.Name() == name
+		}) {
+			return true
+		}
+		superclass, ok := decl.Superclass()
+		if !ok || superclass.Package() == "global:" {
+			return false
+		}
+		if superclass.Package() != "" {
+			return true
+		}
+		next, ok := declared[superclass.Module()][superclass.Name()]
+		if !ok {
+			return true
+		}
+		key := superclass.Module() + "#" + superclass.Name()
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		decl = next
+	}
+}
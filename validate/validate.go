@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"bennypowers.dev/cem/internal/platform"
+	M "bennypowers.dev/cem/manifest"
 	"github.com/adrg/xdg"
 	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/spf13/viper"
@@ -85,6 +86,12 @@ type ValidationPipeline struct {
 
 // NewValidationPipeline creates a new validation pipeline
 func NewValidationPipeline(fsys platform.FileSystem, manifestData []byte) (*ValidationPipeline, error) {
+	// Accept JSONC manifests; the schema validator needs standard JSON
+	manifestData, err := M.StandardizeJSONC(manifestData)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+
 	// Parse manifest once for efficiency
 	var manifestJSON map[string]any
 	if err := json.Unmarshal(manifestData, &manifestJSON); err != nil {
@@ -136,6 +143,12 @@ func (p *ValidationPipeline) Validate(options ValidationOptions) (*ValidationRes
 		return nil, err
 	}
 
+	// Check references which the schema can't
+	if semantic := p.checkSemantics(); len(semantic) > 0 {
+		result.Errors = append(result.Errors, semantic...)
+		result.IsValid = false
+	}
+
 	// Process warnings if requested
 	if options.IncludeWarnings {
 		warnings := p.warningProcessor.ProcessWarnings(p.navigator)
@@ -59,6 +59,7 @@ func TestValidateGolden(t *testing.T) {
 		{"warning-superclass-modules", "warning_superclass_modules"},
 		{"warning-css-verbose", "warning_css_verbose"},
 		{"schema-upgrade-2-1-0", "schema_upgrade_2_1_0"},
+		{"semantic-errors", "semantic_errors"},
 	}
 
 	t.Run("os", func(t *testing.T) {
@@ -180,6 +181,30 @@ func TestValidateWithDisabledWarnings(t *testing.T) {
 	})
 }
 
+func TestValidateJSONC(t *testing.T) {
+	mfs := platform.NewMapFileSystem(nil)
+	if err := mfs.WriteFile("custom-elements.json", []byte(`// Generated by hand
+{
+  "schemaVersion": "2.1.0",
+  "modules": [
+    {
+      "kind": "javascript-module",
+      "path": "my-element.js", // trailing commas are allowed too
+    },
+  ],
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Validate(mfs, "custom-elements.json", ValidationOptions{})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !result.IsValid {
+		t.Errorf("Expected JSONC manifest to be valid, got errors: %+v", result.Errors)
+	}
+}
+
 // TestTryFetchSchema_NoCacheDir verifies that tryFetchSchema works when
 // xdg.CacheHome is empty, as it would be in wasm or sandboxed environments.
 // The embedded schema path (getSchema -> getEmbeddedSchema) bypasses